@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/app"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/db/migrations"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// migrate applies the migrations built into the binary that the database lacks
+func migrate(cfg *config.Config, logger *slog.Logger) error {
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := postgres.Connect(ctx, &cfg.Database, nil, logger)
+	if err != nil {
+		return fmt.Errorf("connect to database: %w", err)
+	}
+	defer db.Close()
+
+	applied, err := postgres.Migrate(ctx, db, migrations.Files)
+	for _, m := range applied {
+		logger.Info("applied migration", "version", m.Version, "file", m.Name)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("database is up to date", "applied", len(applied))
+	return nil
+}
+
+// retry runs the retry worker on its own, once with --once
+func retry(cfg *config.Config, logger *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("retry", flag.ExitOnError)
+	once := flags.Bool("once", false, "make a single pass and exit")
+	flags.Parse(args) //nolint:errcheck // exits on error
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	gateway, err := app.New(ctx, cfg, nil, logger)
+	if err != nil {
+		return err
+	}
+	defer gateway.Close()
+
+	retryWorker, err := gateway.RetryWorker()
+	if err != nil {
+		return err
+	}
+	if *once {
+		return retryWorker.RunOnce(ctx)
+	}
+	retryWorker.Start(ctx)
+	return nil
+}
+
+// reconcile reconciles every merchant's payments of the last window with the bank, once
+// with --once and otherwise every interval
+func reconcile(cfg *config.Config, logger *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	once := flags.Bool("once", false, "make a single pass and exit")
+	window := flags.Duration("window", 24*time.Hour, "how far back each pass reconciles")
+	interval := flags.Duration("interval", time.Hour, "time between passes without --once")
+	flags.Parse(args) //nolint:errcheck // exits on error
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	gateway, err := app.New(ctx, cfg, nil, logger)
+	if err != nil {
+		return err
+	}
+	defer gateway.Close()
+
+	reconciliationWorker, err := gateway.ReconciliationWorker(*window, *interval)
+	if err != nil {
+		return err
+	}
+	if *once {
+		return reconciliationWorker.ReconcileAll(ctx)
+	}
+	reconciliationWorker.Start(ctx)
+	return nil
+}
+
+// signingSecret issues an API key a new signing secret and prints it. It is shown only
+// this once; the gateway keeps it sealed by the vault.
+func signingSecret(cfg *config.Config, logger *slog.Logger, args []string) error {
+	flags := flag.NewFlagSet("signing-secret", flag.ExitOnError)
+	keyID := flags.String("key-id", "", "ID of the API key to issue the secret for")
+	flags.Parse(args) //nolint:errcheck // exits on error
+	if *keyID == "" {
+		return errors.New("--key-id is required")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	gateway, err := app.New(ctx, cfg, nil, logger)
+	if err != nil {
+		return err
+	}
+	defer gateway.Close()
+
+	secret, err := gateway.SigningSecrets.Issue(ctx, *keyID)
+	if err != nil {
+		return err
+	}
+	fmt.Println(secret)
+	return nil
+}
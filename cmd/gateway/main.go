@@ -1,140 +1,66 @@
+// Command gateway serves the payment API and runs its workers. Its other subcommands
+// run one piece of that on its own, for cron jobs and Kubernetes Jobs:
+//
+//	gateway [serve]                  serve the API and run every worker
+//	gateway migrate                  apply the pending database migrations
+//	gateway retry [--once]           resume stuck payments and time out unauthorized ones
+//	gateway reconcile [--once] ...   reconcile every merchant's recent payments with the bank
+//	gateway signing-secret --key-id  issue an API key a new secret to sign requests with
+//
+// Without --once, retry and reconcile keep running their loop until interrupted.
 package main
 
 import (
-	"context"
-	"errors"
+	"fmt"
 	"log/slog"
-	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
-	"time"
+	"slices"
+	"strings"
 
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/middleware"
-	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
 )
 
+const usage = `usage: gateway [serve]
+       gateway migrate
+       gateway retry [--once]
+       gateway reconcile [--once] [--window 24h] [--interval 1h]
+       gateway signing-secret --key-id <id>
+`
+
 func main() {
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+	if !slices.Contains([]string{"serve", "migrate", "retry", "reconcile", "signing-secret"}, command) {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		slog.Error("failed to load configuration", "error", err)
 		os.Exit(1)
 	}
 
-	logger := cfg.Logger.NewLogger()
+	logger, logControl := cfg.Logger.NewControlledLogger()
 	slog.SetDefault(logger)
 
-	logger.Info("starting gateway service",
-		"port", cfg.Server.Port,
-		"log_level", cfg.Logger.Level,
-	)
+	switch command {
+	case "serve":
+		err = serve(cfg, logger, logControl)
+	case "migrate":
+		err = migrate(cfg, logger)
+	case "retry":
+		err = retry(cfg, logger, args)
+	case "reconcile":
+		err = reconcile(cfg, logger, args)
+	case "signing-secret":
+		err = signingSecret(cfg, logger, args)
+	}
 
-	ctx := context.Background()
-	db, err := postgres.Connect(ctx, &cfg.Database, logger)
 	if err != nil {
-		logger.Error("failed to connect to database", "error", err)
+		logger.Error(command+" failed", "error", err)
 		os.Exit(1)
 	}
-	defer db.Close()
-
-	paymentRepo := postgres.NewPaymentRepository(db)
-	idempotencyRepo := postgres.NewIdempotencyRepository(db)
-
-	bankClient := bank.NewBankClient(cfg.BankClient)
-	retryBankClient := bank.NewRetryBankClient(bankClient, cfg.Retry)
-
-	authService := services.NewAuthorizeService(paymentRepo, idempotencyRepo, retryBankClient, db)
-	captureService := services.NewCaptureService(paymentRepo, idempotencyRepo, retryBankClient, db)
-	voidService := services.NewVoidService(paymentRepo, idempotencyRepo, retryBankClient, db)
-	refundService := services.NewRefundService(paymentRepo, idempotencyRepo, retryBankClient, db)
-
-	h := handlers.NewHandlers(
-		authService,
-		captureService,
-		voidService,
-		refundService,
-		paymentRepo,
-		logger,
-	)
-
-	strictHandler := api.NewStrictHandler(h, nil)
-
-	mux := http.NewServeMux()
-	api.RegisterDocsRoutes(mux)
-	api.HandlerFromMux(strictHandler, mux)
-
-	router := http.Handler(mux)
-
-	handler := middleware.Recovery(logger)(router)
-	handler = middleware.Logging(logger)(handler)
-	handler = middleware.Timeout(cfg.Server.ReadTimeout, logger)(handler)
-
-	server := &http.Server{
-		Addr:         "0.0.0.0:" + cfg.Server.Port,
-		Handler:      handler,
-		ReadTimeout:  cfg.Server.ReadTimeout,
-		WriteTimeout: cfg.Server.WriteTimeout,
-		IdleTimeout:  cfg.Server.IdleTimeout,
-	}
-
-	retryWorker := worker.NewRetryWorker(
-		paymentRepo,
-		idempotencyRepo,
-		retryBankClient,
-		db,
-		cfg.Worker.Interval,
-		cfg.Worker.BatchSize,
-		cfg.Retry.MaxRetries,
-		cfg.Retry.MaxBackoff,
-		logger,
-	)
-
-	expirationWorker := worker.NewExpirationWorker(
-		paymentRepo,
-		retryBankClient,
-		cfg.Worker.Interval,
-		logger,
-	)
-
-	workerCtx, cancelWorkers := context.WithCancel(context.Background())
-	defer cancelWorkers()
-
-	go retryWorker.Start(workerCtx)
-	go expirationWorker.Start(workerCtx)
-
-	serveErr := make(chan error, 1)
-	go func() {
-		logger.Info("server starting", "addr", server.Addr)
-		serveErr <- server.ListenAndServe()
-	}()
-
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	select {
-	case <-quit:
-		logger.Info("shutting down server...")
-	case err := <-serveErr:
-		if err != nil && errors.Is(err, http.ErrServerClosed) {
-			logger.Error("server error", "error", err)
-			cancelWorkers()
-		}
-	}
-
-	cancelWorkers()
-
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.Error("server forced to shutdown", "error", err)
-	}
-
-	logger.Info("server exited")
 }
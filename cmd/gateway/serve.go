@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/app"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/diagnostics"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/graphql"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/logging"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/middleware"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
+)
+
+// paymentEventsPattern is the route the generated server registers the payment event
+// stream under
+const paymentEventsPattern = "GET /payments/events/{paymentID}"
+
+// serve runs the API until the process is told to stop, with every background worker
+// unless they are external
+func serve(cfg *config.Config, logger *slog.Logger, logControl *logging.Controller) error {
+	logger.Info("starting gateway service",
+		"port", cfg.Server.Port,
+		"log_level", cfg.Logger.Level,
+	)
+
+	queryMetrics := metrics.NewQueryMetrics(metrics.QueryBuckets)
+
+	gateway, err := app.New(context.Background(), cfg, queryMetrics, logger)
+	if err != nil {
+		return err
+	}
+	defer gateway.Close()
+
+	// Requests feed the authorize queue, so it runs here even when the workers do not
+	authorizeWorker := worker.NewAuthorizeWorker(
+		gateway.Authorize,
+		cfg.Worker.AuthorizeQueueSize,
+		cfg.Worker.AuthorizeConcurrency,
+		logger,
+	)
+	if cfg.Worker.SoftDeclineRetryDelay > 0 {
+		gateway.Authorize.WithSoftDeclineRetry(cfg.Worker.SoftDeclineRetryDelay, authorizeWorker)
+	}
+
+	h := handlers.NewHandlers(
+		gateway.Authorize,
+		gateway.Capture,
+		gateway.Void,
+		gateway.Refund,
+		gateway.Reauthorize,
+		gateway.PaymentMethods,
+		gateway.Schedules,
+		gateway.Reviews,
+		gateway.DeadLetterQueue,
+		gateway.Subscriptions,
+		gateway.PaymentIntents,
+		gateway.PaymentGroups,
+		gateway.Payouts,
+		gateway.Batches,
+		gateway.Erasure,
+		gateway.Reconciliation,
+		gateway.BankRefunds,
+		gateway.Features,
+		gateway.Regions,
+		gateway.OutboxControl,
+		gateway.PaymentHistory,
+		gateway.Payments,
+		gateway.PaymentReadModel,
+		gateway.Operations,
+		gateway.DebugSessions,
+		gateway.MerchantSettings,
+		gateway.Canary,
+		logControl,
+		gateway.TestClock,
+		authorizeWorker,
+		logger,
+	)
+
+	spec, err := api.GetSwagger()
+	if err != nil {
+		return err
+	}
+
+	strictHandler := api.NewStrictHandlerWithOptions(
+		h,
+		[]api.StrictMiddlewareFunc{handlers.FollowUpHeaders},
+		handlers.StrictServerOptions(logger),
+	)
+	signatureVerifier := middleware.NewSignatureVerifier(gateway.APIKeys, postgres.NewNonceRepository(gateway.DB), gateway.Keyring, cfg.Auth.SignatureWindow)
+
+	httpMetrics := metrics.NewHTTPMetrics(metrics.DefaultBuckets)
+	syntheticMetrics := metrics.NewSyntheticMetrics(metrics.DefaultBuckets)
+	reviewMetrics := metrics.NewReviewMetrics(metrics.ReviewBuckets, gateway.PaymentReviews)
+	outboxMetrics := metrics.NewOutboxMetrics(gateway.Outbox)
+	authorizationMetrics := metrics.NewAuthorizationMetrics(metrics.ExpiringWindows, gateway.Payments)
+	gateway.Reviews.WithMetrics(reviewMetrics)
+
+	mux := http.NewServeMux()
+	api.RegisterDocsRoutes(mux)
+	mux.Handle("GET /metrics", metrics.Handler(httpMetrics, syntheticMetrics, reviewMetrics, outboxMetrics, authorizationMetrics, queryMetrics))
+	// Read-only queries for the support dashboard, which always authenticates
+	graphqlSchema := graphql.NewPaymentSchema(gateway.Payments, gateway.Operations, gateway.Outbox, logger)
+	mux.Handle("POST /graphql", middleware.Authenticate(gateway.APIKeys, signatureVerifier, true, logger)(
+		middleware.Require(domain.RoleViewer, logger)(graphqlSchema),
+	))
+	api.HandlerWithOptions(strictHandler, api.StdHTTPServerOptions{
+		BaseRouter: mux,
+		// The last middleware runs first: requests are timed, then authenticated before anything else
+		Middlewares: []api.MiddlewareFunc{
+			middleware.StrictJSON(spec, logger),
+			middleware.Standby(gateway.Regions, logger),
+			middleware.RequireRole(logger),
+			middleware.Audit(postgres.NewAuditRepository(gateway.DB), logger),
+			middleware.QuotaHeaders(gateway.MerchantSettings, logger),
+			middleware.Authenticate(gateway.APIKeys, signatureVerifier, cfg.Auth.RequireAPIKey, logger),
+			middleware.Chaos(gateway.APIChaos, logger),
+			middleware.Metrics(httpMetrics),
+		},
+	})
+
+	router := middleware.RouteErrors(mux, logger)
+
+	handler := middleware.Recovery(logger)(router)
+	handler = middleware.Logging(logger)(handler)
+	// Event streams are told apart by their route: clients send all sorts of Accept headers
+	streams := middleware.Routes(mux, paymentEventsPattern)
+	handler = middleware.Timeout(cfg.Server.ReadTimeout, streams, logger)(handler)
+
+	server := &http.Server{
+		Addr:         "0.0.0.0:" + cfg.Server.Port,
+		Handler:      handler,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	var debugServer *http.Server
+	if cfg.Server.DebugPort != "" {
+		// Profiles take as long as they are asked to, so only reading the request is bounded
+		debugHandler := middleware.Require(domain.RoleAdmin, logger)(diagnostics.NewHandler(time.Now()))
+		debugServer = &http.Server{
+			Addr:        "0.0.0.0:" + cfg.Server.DebugPort,
+			Handler:     middleware.Authenticate(gateway.APIKeys, signatureVerifier, true, logger)(debugHandler),
+			ReadTimeout: cfg.Server.ReadTimeout,
+			IdleTimeout: cfg.Server.IdleTimeout,
+		}
+	}
+
+	healthChecker := gateway.NewHealthChecker()
+	healthChecker.SetAuthorizeQueue(authorizeWorker)
+	mux.Handle("GET /health", healthChecker)
+
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+
+	go authorizeWorker.Start(workerCtx)
+
+	if cfg.Worker.External {
+		logger.Info("background workers run in the worker process")
+	} else {
+		workers, err := gateway.NewWorkers(syntheticMetrics)
+		if err != nil {
+			return err
+		}
+		workers.Watch(healthChecker)
+		workers.Start(workerCtx)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("server starting", "addr", server.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	if debugServer != nil {
+		go func() {
+			logger.Info("debug server starting", "addr", debugServer.Addr)
+			if err := debugServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("debug server error", "error", err)
+			}
+		}()
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case <-quit:
+		logger.Info("shutting down server...")
+	case err := <-serveErr:
+		if err != nil && errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server error", "error", err)
+			cancelWorkers()
+		}
+	}
+
+	cancelWorkers()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("server forced to shutdown", "error", err)
+	}
+	if debugServer != nil {
+		// A running CPU profile or trace would otherwise hold up the exit
+		debugServer.Close() //nolint:errcheck // exiting anyway
+	}
+
+	logger.Info("server exited")
+	return nil
+}
@@ -0,0 +1,76 @@
+// Command rotate-keys re-seals every stored card and account number and every signing
+// secret with the primary vault key, so a retired key can be dropped from
+// GATEWAY_VAULT__KEYS once it is done. It is safe to run while the gateway serves
+// traffic, and to interrupt and rerun.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+)
+
+func main() {
+	batchSize := flag.Int("batch-size", 500, "rows re-sealed per batch")
+	flag.Parse()
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger := cfg.Logger.NewLogger()
+	slog.SetDefault(logger)
+
+	keyring, err := vault.ParseKeyring(cfg.Vault.Keys, cfg.Vault.EncryptionKey)
+	if err != nil {
+		logger.Error("failed to load vault keys", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	db, err := postgres.Connect(ctx, &cfg.Database, nil, logger)
+	if err != nil {
+		logger.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	rotation := services.NewKeyRotationService(
+		postgres.NewPaymentMethodRepository(db),
+		postgres.NewPayoutRepository(db),
+		postgres.NewOperationRepository(db),
+		postgres.NewAPIKeyRepository(db),
+		keyring,
+	)
+
+	logger.Info("rotating vault keys", "primary_key_id", keyring.PrimaryKeyID())
+
+	var total int
+	for {
+		rotated, err := rotation.RotateBatch(ctx, *batchSize)
+		total += rotated
+		if err != nil {
+			logger.Error("key rotation failed", "rotated", total, "error", err)
+			db.Close()
+			os.Exit(1)
+		}
+		if rotated == 0 {
+			break
+		}
+		logger.Info("re-sealed batch", "rotated", total)
+	}
+
+	logger.Info("key rotation complete", "rotated", total)
+}
@@ -0,0 +1,105 @@
+// Command worker runs the gateway's background workers without the API, so worker pods
+// can be scaled and deployed apart from API pods. The API then runs with
+// GATEWAY_WORKER__EXTERNAL=true. It serves /health and /metrics on the server port.
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/app"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+)
+
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger := cfg.Logger.NewLogger()
+	slog.SetDefault(logger)
+
+	if err := run(cfg, logger); err != nil {
+		logger.Error("worker failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(cfg *config.Config, logger *slog.Logger) error {
+	logger.Info("starting gateway workers",
+		"port", cfg.Server.Port,
+		"log_level", cfg.Logger.Level,
+	)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	queryMetrics := metrics.NewQueryMetrics(metrics.QueryBuckets)
+	syntheticMetrics := metrics.NewSyntheticMetrics(metrics.DefaultBuckets)
+
+	gateway, err := app.New(ctx, cfg, queryMetrics, logger)
+	if err != nil {
+		return err
+	}
+	defer gateway.Close()
+
+	workers, err := gateway.NewWorkers(syntheticMetrics)
+	if err != nil {
+		return err
+	}
+
+	healthChecker := gateway.NewHealthChecker()
+	workers.Watch(healthChecker)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", healthChecker)
+	mux.Handle("GET /metrics", metrics.Handler(syntheticMetrics, queryMetrics))
+
+	server := &http.Server{
+		Addr:         "0.0.0.0:" + cfg.Server.Port,
+		Handler:      mux,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
+	}
+
+	workerCtx, cancelWorkers := context.WithCancel(context.Background())
+	defer cancelWorkers()
+	workers.Start(workerCtx)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		logger.Info("server starting", "addr", server.Addr)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down workers...")
+	case err := <-serveErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("server error", "error", err)
+		}
+	}
+
+	cancelWorkers()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("server forced to shutdown", "error", err)
+	}
+
+	logger.Info("workers exited")
+	return nil
+}
@@ -12,10 +12,12 @@ import (
 // GET /docs         → Swagger UI
 //
 // GET /docs/openapi → OpenAPI spec (JSON)
+//
+// The root matches only itself, so unknown paths are not redirected to the docs.
 func RegisterDocsRoutes(mux *http.ServeMux) {
-	mux.HandleFunc("/", handleRootRedirect)
-	mux.HandleFunc("/docs", handleSwaggerUI)
-	mux.HandleFunc("/docs/openapi", handleOpenAPISpec)
+	mux.HandleFunc("GET /{$}", handleRootRedirect)
+	mux.HandleFunc("GET /docs", handleSwaggerUI)
+	mux.HandleFunc("GET /docs/openapi", handleOpenAPISpec)
 }
 
 func handleRootRedirect(w http.ResponseWriter, r *http.Request) {
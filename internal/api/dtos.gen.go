@@ -9,164 +9,1496 @@ import (
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for BankRefundResultOutcome.
+const (
+	ALREADYRECORDED BankRefundResultOutcome = "ALREADY_RECORDED"
+	RECORDED        BankRefundResultOutcome = "RECORDED"
+	UNRECORDED      BankRefundResultOutcome = "UNRECORDED"
+)
+
+// Defines values for BatchItemStatus.
+const (
+	BatchItemStatusFAILED    BatchItemStatus = "FAILED"
+	BatchItemStatusPENDING   BatchItemStatus = "PENDING"
+	BatchItemStatusSUCCEEDED BatchItemStatus = "SUCCEEDED"
+)
+
+// Defines values for BatchStatus.
+const (
+	COMPLETED  BatchStatus = "COMPLETED"
+	PROCESSING BatchStatus = "PROCESSING"
+)
+
+// Defines values for BatchType.
+const (
+	BatchTypeREFUND BatchType = "REFUND"
+	BatchTypeVOID   BatchType = "VOID"
+)
+
+// Defines values for BillingInterval.
+const (
+	Day   BillingInterval = "day"
+	Month BillingInterval = "month"
+	Week  BillingInterval = "week"
+	Year  BillingInterval = "year"
+)
+
 // Defines values for ErrorResponseErrorCode.
 const (
+	AMOUNTTOOLARGE          ErrorResponseErrorCode = "AMOUNT_TOO_LARGE"
+	AMOUNTTOOSMALL          ErrorResponseErrorCode = "AMOUNT_TOO_SMALL"
+	BANKRATELIMITED         ErrorResponseErrorCode = "BANK_RATE_LIMITED"
+	BATCHNOTFOUND           ErrorResponseErrorCode = "BATCH_NOT_FOUND"
+	CARDEXPIRED             ErrorResponseErrorCode = "CARD_EXPIRED"
+	CARDVELOCITYEXCEEDED    ErrorResponseErrorCode = "CARD_VELOCITY_EXCEEDED"
+	CHAOSINJECTED           ErrorResponseErrorCode = "CHAOS_INJECTED"
+	DEADLETTERNOTFOUND      ErrorResponseErrorCode = "DEAD_LETTER_NOT_FOUND"
+	DEBUGSESSIONNOTFOUND    ErrorResponseErrorCode = "DEBUG_SESSION_NOT_FOUND"
 	DUPLICATEIDEMPOTENCYKEY ErrorResponseErrorCode = "DUPLICATE_IDEMPOTENCY_KEY"
+	DUPLICATEPAYMENT        ErrorResponseErrorCode = "DUPLICATE_PAYMENT"
+	FEATUREOVERRIDENOTFOUND ErrorResponseErrorCode = "FEATURE_OVERRIDE_NOT_FOUND"
+	FORBIDDEN               ErrorResponseErrorCode = "FORBIDDEN"
 	IDEMPOTENCYMISMATCH     ErrorResponseErrorCode = "IDEMPOTENCY_MISMATCH"
 	INTERNALERROR           ErrorResponseErrorCode = "INTERNAL_ERROR"
 	INVALIDAMOUNT           ErrorResponseErrorCode = "INVALID_AMOUNT"
+	INVALIDINPUT            ErrorResponseErrorCode = "INVALID_INPUT"
+	INVALIDSIGNATURE        ErrorResponseErrorCode = "INVALID_SIGNATURE"
 	INVALIDSTATE            ErrorResponseErrorCode = "INVALID_STATE"
 	INVALIDTRANSITION       ErrorResponseErrorCode = "INVALID_TRANSITION"
+	MERCHANTNOTFOUND        ErrorResponseErrorCode = "MERCHANT_NOT_FOUND"
+	METHODNOTALLOWED        ErrorResponseErrorCode = "METHOD_NOT_ALLOWED"
 	MISSINGDEPENDENCY       ErrorResponseErrorCode = "MISSING_DEPENDENCY"
 	MISSINGREQUIREDFIELD    ErrorResponseErrorCode = "MISSING_REQUIRED_FIELD"
+	OPERATIONNOTFOUND       ErrorResponseErrorCode = "OPERATION_NOT_FOUND"
+	ORDERALREADYPAID        ErrorResponseErrorCode = "ORDER_ALREADY_PAID"
 	PAYMENTEXPIRED          ErrorResponseErrorCode = "PAYMENT_EXPIRED"
+	PAYMENTGROUPNOTFOUND    ErrorResponseErrorCode = "PAYMENT_GROUP_NOT_FOUND"
+	PAYMENTINTENTNOTFOUND   ErrorResponseErrorCode = "PAYMENT_INTENT_NOT_FOUND"
+	PAYMENTMETHODNOTFOUND   ErrorResponseErrorCode = "PAYMENT_METHOD_NOT_FOUND"
 	PAYMENTNOTFOUND         ErrorResponseErrorCode = "PAYMENT_NOT_FOUND"
+	PAYOUTNOTFOUND          ErrorResponseErrorCode = "PAYOUT_NOT_FOUND"
+	QUOTAEXCEEDED           ErrorResponseErrorCode = "QUOTA_EXCEEDED"
+	REFUNDNOTFOUND          ErrorResponseErrorCode = "REFUND_NOT_FOUND"
+	REGIONSTANDBY           ErrorResponseErrorCode = "REGION_STANDBY"
 	REQUESTPROCESSING       ErrorResponseErrorCode = "REQUEST_PROCESSING"
+	REVIEWNOTFOUND          ErrorResponseErrorCode = "REVIEW_NOT_FOUND"
+	ROUTENOTFOUND           ErrorResponseErrorCode = "ROUTE_NOT_FOUND"
+	SELFAPPROVAL            ErrorResponseErrorCode = "SELF_APPROVAL"
+	SUBSCRIPTIONNOTFOUND    ErrorResponseErrorCode = "SUBSCRIPTION_NOT_FOUND"
 	TIMEOUT                 ErrorResponseErrorCode = "TIMEOUT"
+	UNAUTHORIZED            ErrorResponseErrorCode = "UNAUTHORIZED"
 	VALIDATIONERROR         ErrorResponseErrorCode = "VALIDATION_ERROR"
 )
 
+// Defines values for ErrorResponseErrorDeclineCategory.
+const (
+	ErrorResponseErrorDeclineCategoryCustomerActionRequired ErrorResponseErrorDeclineCategory = "customer_action_required"
+	ErrorResponseErrorDeclineCategoryDoNotRetry             ErrorResponseErrorDeclineCategory = "do_not_retry"
+	ErrorResponseErrorDeclineCategoryRetryLater             ErrorResponseErrorDeclineCategory = "retry_later"
+	ErrorResponseErrorDeclineCategoryTryOtherCard           ErrorResponseErrorDeclineCategory = "try_other_card"
+)
+
+// Defines values for FeatureFlagName.
+const (
+	AsyncAuthorize FeatureFlagName = "async_authorize"
+	CanaryRouting  FeatureFlagName = "canary_routing"
+)
+
+// Defines values for LogLevelLevel.
+const (
+	Debug LogLevelLevel = "debug"
+	Error LogLevelLevel = "error"
+	Info  LogLevelLevel = "info"
+	Warn  LogLevelLevel = "warn"
+)
+
+// Defines values for OperationReason.
+const (
+	CustomerRequest OperationReason = "customer_request"
+	Duplicate       OperationReason = "duplicate"
+	Fraud           OperationReason = "fraud"
+	OutOfStock      OperationReason = "out_of_stock"
+)
+
+// Defines values for OperationStatus.
+const (
+	OperationStatusFAILED          OperationStatus = "FAILED"
+	OperationStatusPENDING         OperationStatus = "PENDING"
+	OperationStatusPENDINGAPPROVAL OperationStatus = "PENDING_APPROVAL"
+	OperationStatusREJECTED        OperationStatus = "REJECTED"
+	OperationStatusSUCCEEDED       OperationStatus = "SUCCEEDED"
+)
+
+// Defines values for OperationType.
+const (
+	OperationTypeCAPTURE     OperationType = "CAPTURE"
+	OperationTypeREAUTHORIZE OperationType = "REAUTHORIZE"
+	OperationTypeREFUND      OperationType = "REFUND"
+	OperationTypeVOID        OperationType = "VOID"
+)
+
+// Defines values for PaymentDeclineCategory.
+const (
+	PaymentDeclineCategoryCustomerActionRequired PaymentDeclineCategory = "customer_action_required"
+	PaymentDeclineCategoryDoNotRetry             PaymentDeclineCategory = "do_not_retry"
+	PaymentDeclineCategoryRetryLater             PaymentDeclineCategory = "retry_later"
+	PaymentDeclineCategoryTryOtherCard           PaymentDeclineCategory = "try_other_card"
+)
+
+// Defines values for PaymentGroupStatus.
+const (
+	PaymentGroupStatusAUTHORIZED    PaymentGroupStatus = "AUTHORIZED"
+	PaymentGroupStatusCAPTURED      PaymentGroupStatus = "CAPTURED"
+	PaymentGroupStatusEXPIRED       PaymentGroupStatus = "EXPIRED"
+	PaymentGroupStatusFAILED        PaymentGroupStatus = "FAILED"
+	PaymentGroupStatusPENDING       PaymentGroupStatus = "PENDING"
+	PaymentGroupStatusREAUTHORIZING PaymentGroupStatus = "REAUTHORIZING"
+	PaymentGroupStatusREFUNDED      PaymentGroupStatus = "REFUNDED"
+	PaymentGroupStatusREVIEW        PaymentGroupStatus = "REVIEW"
+	PaymentGroupStatusSCHEDULED     PaymentGroupStatus = "SCHEDULED"
+	PaymentGroupStatusVOIDED        PaymentGroupStatus = "VOIDED"
+)
+
+// Defines values for PaymentIntentStatus.
+const (
+	CONFIRMED            PaymentIntentStatus = "CONFIRMED"
+	REQUIRESCONFIRMATION PaymentIntentStatus = "REQUIRES_CONFIRMATION"
+)
+
+// Defines values for PaymentLastErrorCategory.
+const (
+	BUSINESSRULE   PaymentLastErrorCategory = "BUSINESS_RULE"
+	CLIENTERROR    PaymentLastErrorCategory = "CLIENT_ERROR"
+	INFRASTRUCTURE PaymentLastErrorCategory = "INFRASTRUCTURE"
+	PERMANENT      PaymentLastErrorCategory = "PERMANENT"
+	TRANSIENT      PaymentLastErrorCategory = "TRANSIENT"
+)
+
 // Defines values for PaymentStatus.
 const (
-	AUTHORIZED PaymentStatus = "AUTHORIZED"
-	CAPTURED   PaymentStatus = "CAPTURED"
-	EXPIRED    PaymentStatus = "EXPIRED"
-	FAILED     PaymentStatus = "FAILED"
-	PENDING    PaymentStatus = "PENDING"
-	REFUNDED   PaymentStatus = "REFUNDED"
-	VOIDED     PaymentStatus = "VOIDED"
+	PaymentStatusAUTHORIZED    PaymentStatus = "AUTHORIZED"
+	PaymentStatusCAPTURED      PaymentStatus = "CAPTURED"
+	PaymentStatusEXPIRED       PaymentStatus = "EXPIRED"
+	PaymentStatusFAILED        PaymentStatus = "FAILED"
+	PaymentStatusPENDING       PaymentStatus = "PENDING"
+	PaymentStatusREAUTHORIZING PaymentStatus = "REAUTHORIZING"
+	PaymentStatusREFUNDED      PaymentStatus = "REFUNDED"
+	PaymentStatusREVIEW        PaymentStatus = "REVIEW"
+	PaymentStatusSCHEDULED     PaymentStatus = "SCHEDULED"
+	PaymentStatusVOIDED        PaymentStatus = "VOIDED"
+)
+
+// Defines values for PayoutPurpose.
+const (
+	Refund       PayoutPurpose = "refund"
+	SellerPayout PayoutPurpose = "seller_payout"
+)
+
+// Defines values for PayoutStatus.
+const (
+	PayoutStatusFAILED    PayoutStatus = "FAILED"
+	PayoutStatusINTRANSIT PayoutStatus = "IN_TRANSIT"
+	PayoutStatusPAID      PayoutStatus = "PAID"
+	PayoutStatusPENDING   PayoutStatus = "PENDING"
+)
+
+// Defines values for ReceiptFormat.
+const (
+	Json ReceiptFormat = "json"
+	Pdf  ReceiptFormat = "pdf"
+)
+
+// Defines values for ReconciliationIssueKind.
+const (
+	MISSINGATBANK         ReconciliationIssueKind = "MISSING_AT_BANK"
+	ORPHANEDAUTHORIZATION ReconciliationIssueKind = "ORPHANED_AUTHORIZATION"
+	STATUSMISMATCH        ReconciliationIssueKind = "STATUS_MISMATCH"
+	UNKNOWNTOGATEWAY      ReconciliationIssueKind = "UNKNOWN_TO_GATEWAY"
+	UNRECORDEDREFUND      ReconciliationIssueKind = "UNRECORDED_REFUND"
 )
 
-// AuthorizeRequest defines model for AuthorizeRequest.
+// Defines values for RefundDestinationType.
+const (
+	BankTransfer RefundDestinationType = "bank_transfer"
+	Card         RefundDestinationType = "card"
+	StoreCredit  RefundDestinationType = "store_credit"
+)
+
+// Defines values for ReplayPaymentsRequestStatus.
+const (
+	ReplayPaymentsRequestStatusCAPTURING     ReplayPaymentsRequestStatus = "CAPTURING"
+	ReplayPaymentsRequestStatusREAUTHORIZING ReplayPaymentsRequestStatus = "REAUTHORIZING"
+	ReplayPaymentsRequestStatusREFUNDING     ReplayPaymentsRequestStatus = "REFUNDING"
+	ReplayPaymentsRequestStatusVOIDING       ReplayPaymentsRequestStatus = "VOIDING"
+)
+
+// Defines values for SubscriptionStatus.
+const (
+	ACTIVE   SubscriptionStatus = "ACTIVE"
+	CANCELED SubscriptionStatus = "CANCELED"
+	PASTDUE  SubscriptionStatus = "PAST_DUE"
+)
+
+// AcquirerStats defines model for AcquirerStats.
+type AcquirerStats struct {
+	// Declined Calls the bank rejected with a 4xx error
+	Declined int64 `json:"declined"`
+
+	// Errored Calls that failed with a 5xx error or never reached the bank
+	Errored     int64   `json:"errored"`
+	Name        string  `json:"name"`
+	Requests    int64   `json:"requests"`
+	Succeeded   int64   `json:"succeeded"`
+	SuccessRate float64 `json:"success_rate"`
+}
+
+// Acquirers defines model for Acquirers.
+type Acquirers struct {
+	Acquirers []AcquirerStats `json:"acquirers"`
+
+	// CanaryPercent Share of new authorizations currently routed to the canary
+	CanaryPercent int `json:"canary_percent"`
+}
+
+// AcquirersResponse defines model for AcquirersResponse.
+type AcquirersResponse struct {
+	Data Acquirers `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// AdvanceClockRequest defines model for AdvanceClockRequest.
+type AdvanceClockRequest struct {
+	// Seconds How far to move the clock forward, up to a year at a time
+	Seconds int64 `json:"seconds"`
+}
+
+// AuthorizePaymentGroupRequest defines model for AuthorizePaymentGroupRequest.
+type AuthorizePaymentGroupRequest struct {
+	CustomerId string `json:"customer_id"`
+	OrderId    string `json:"order_id"`
+
+	// Parts The cards to authorize, in order, and how much on each
+	Parts []PaymentGroupPart `json:"parts"`
+}
+
+// AuthorizeRequest The payment is made with either `card_number` and `cvv` or a `network_token`,
+// never both.
 type AuthorizeRequest struct {
 	// Amount Amount in cents (e.g., 5000 = $50.00)
 	Amount int64 `json:"amount"`
 
-	// CardNumber Card number (13-19 digits)
-	CardNumber string `json:"card_number"`
+	// CardNumber Card number (13-19 digits)
+	CardNumber string `json:"card_number,omitempty,omitzero"`
+
+	// Category Kind of goods the order is for. A payment for a category the merchant
+	// auto-captures, set in `auto_capture_categories`, is captured in full as soon as
+	// it is authorized and answered `CAPTURED`.
+	Category string `json:"category,omitempty,omitzero"`
+
+	// CustomerId Customer identifier from FicMart
+	CustomerId string `json:"customer_id"`
+
+	// Cvv Card verification value (3-4 digits)
+	Cvv string `json:"cvv,omitempty,omitzero"`
+
+	// ExpiryMonth Expiry month (1-12) of the card, or of the network token if one is given
+	ExpiryMonth int `json:"expiry_month"`
+
+	// ExpiryYear Expiry year (YYYY) of the card, or of the network token if one is given
+	ExpiryYear   int          `json:"expiry_year"`
+	NetworkToken NetworkToken `json:"network_token,omitempty,omitzero"`
+
+	// OrderId Unique order identifier from FicMart
+	OrderId string `json:"order_id"`
+}
+
+// BankRefund defines model for BankRefund.
+type BankRefund struct {
+	// Amount Amount in cents the bank refunded
+	Amount int64 `json:"amount"`
+
+	// AuthorizationId The authorization the bank refunded
+	AuthorizationId string `json:"authorization_id"`
+
+	// RefundId The bank's ID for the refund
+	RefundId string `json:"refund_id"`
+
+	// RefundedAt When the bank made the refund
+	RefundedAt time.Time `json:"refunded_at"`
+}
+
+// BankRefundResult defines model for BankRefundResult.
+type BankRefundResult struct {
+	AuthorizationId string                  `json:"authorization_id"`
+	Issue           ReconciliationIssue     `json:"issue,omitempty,omitzero"`
+	Outcome         BankRefundResultOutcome `json:"outcome"`
+	Refund          Operation               `json:"refund,omitempty,omitzero"`
+	RefundId        string                  `json:"refund_id"`
+}
+
+// BankRefundResultOutcome defines model for BankRefundResultOutcome.
+type BankRefundResultOutcome string
+
+// BankRefundsRequest defines model for BankRefundsRequest.
+type BankRefundsRequest struct {
+	Refunds []BankRefund `json:"refunds"`
+}
+
+// BankRefundsResponse defines model for BankRefundsResponse.
+type BankRefundsResponse struct {
+	Data []BankRefundResult `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// Batch defines model for Batch.
+type Batch struct {
+	CompletedAt time.Time          `json:"completed_at,omitzero"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Failed      int                `json:"failed"`
+	Id          openapi_types.UUID `json:"id"`
+	Items       []BatchItem        `json:"items"`
+	Pending     int                `json:"pending"`
+	Reason      OperationReason    `json:"reason,omitempty,omitzero"`
+
+	// Status COMPLETED once no item is PENDING
+	Status    BatchStatus `json:"status"`
+	Succeeded int         `json:"succeeded"`
+	Type      BatchType   `json:"type"`
+}
+
+// BatchStatus COMPLETED once no item is PENDING
+type BatchStatus string
+
+// BatchType defines model for BatchType.
+type BatchType string
+
+// BatchGetPaymentsRequest defines model for BatchGetPaymentsRequest.
+type BatchGetPaymentsRequest struct {
+	Ids []openapi_types.UUID `json:"ids"`
+}
+
+// BatchItem defines model for BatchItem.
+type BatchItem struct {
+	// AmountCents Requested refund amount; omitted when the item refunds whatever is left, and for voids
+	AmountCents int64     `json:"amount_cents,omitempty,omitzero"`
+	CompletedAt time.Time `json:"completed_at,omitzero"`
+
+	// ErrorCode Why the item failed, using the API's error codes
+	ErrorCode string `json:"error_code,omitzero"`
+
+	// OperationId The operation created on the payment, fetchable from /operations/{operationID}
+	OperationId openapi_types.UUID `json:"operation_id,omitzero"`
+	PaymentId   openapi_types.UUID `json:"payment_id"`
+	Status      BatchItemStatus    `json:"status"`
+}
+
+// BatchItemStatus defines model for BatchItemStatus.
+type BatchItemStatus string
+
+// BatchResponse defines model for BatchResponse.
+type BatchResponse struct {
+	Data Batch `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// BillingInterval defines model for BillingInterval.
+type BillingInterval string
+
+// CaptureRequest defines model for CaptureRequest.
+type CaptureRequest struct {
+	// Amount Amount in cents to capture. Defaults to the remaining authorized amount.
+	Amount int64 `json:"amount,omitempty,omitzero"`
+
+	// PaymentId The payment ID to capture
+	PaymentId openapi_types.UUID `json:"payment_id"`
+}
+
+// Clock defines model for Clock.
+type Clock struct {
+	// Now The time this instance runs on
+	Now time.Time `json:"now"`
+
+	// OffsetSeconds How far the clock has been moved ahead of the system clock
+	OffsetSeconds int64 `json:"offset_seconds"`
+}
+
+// ClockResponse defines model for ClockResponse.
+type ClockResponse struct {
+	Data Clock `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// ConfirmPaymentIntentRequest defines model for ConfirmPaymentIntentRequest.
+type ConfirmPaymentIntentRequest struct {
+	// CardNumber Card number (13-19 digits)
+	CardNumber string `json:"card_number"`
+
+	// Cvv Card verification value (3-4 digits)
+	Cvv         string `json:"cvv"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+}
+
+// CreateCaptureRequest defines model for CreateCaptureRequest.
+type CreateCaptureRequest struct {
+	// Amount Amount in cents to capture. Defaults to the remaining authorized amount.
+	Amount int64 `json:"amount,omitempty,omitzero"`
+}
+
+// CreateDebugSessionRequest defines model for CreateDebugSessionRequest.
+type CreateDebugSessionRequest struct {
+	// IdempotencyKey Capture only the bank calls made with this idempotency key
+	IdempotencyKey string `json:"idempotency_key,omitempty,omitzero"`
+
+	// PaymentId Capture every bank call made for this payment
+	PaymentId openapi_types.UUID `json:"payment_id,omitempty,omitzero"`
+
+	// TtlSeconds How long the session captures traffic before it expires
+	TtlSeconds int `json:"ttl_seconds"`
+}
+
+// CreatePaymentIntentRequest defines model for CreatePaymentIntentRequest.
+type CreatePaymentIntentRequest struct {
+	// Amount Amount in cents
+	Amount     int64  `json:"amount"`
+	CustomerId string `json:"customer_id"`
+	OrderId    string `json:"order_id"`
+}
+
+// CreatePaymentMethodRequest defines model for CreatePaymentMethodRequest.
+type CreatePaymentMethodRequest struct {
+	// CardNumber Card number (13-19 digits)
+	CardNumber string `json:"card_number"`
+
+	// CustomerId Customer the card belongs to
+	CustomerId  string `json:"customer_id"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+}
+
+// CreatePayoutRequest defines model for CreatePayoutRequest.
+type CreatePayoutRequest struct {
+	// AccountNumber Destination account number, 4 to 34 digits. Only its last four digits are returned.
+	AccountNumber string `json:"account_number"`
+
+	// Amount Amount in cents
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+
+	// PaymentId The payment being refunded. Required for, and only allowed on, refunds.
+	PaymentId openapi_types.UUID `json:"payment_id,omitempty,omitzero"`
+	Purpose   PayoutPurpose      `json:"purpose"`
+
+	// RecipientId The seller, or for a refund the payment's customer
+	RecipientId   string `json:"recipient_id"`
+	RoutingNumber string `json:"routing_number"`
+}
+
+// CreateReauthorizationRequest defines model for CreateReauthorizationRequest.
+type CreateReauthorizationRequest struct {
+	// PaymentMethodId Saved card to reauthorize with. Defaults to the one the payment was made with.
+	PaymentMethodId openapi_types.UUID `json:"payment_method_id,omitempty,omitzero"`
+}
+
+// CreateRefundBatchRequest defines model for CreateRefundBatchRequest.
+type CreateRefundBatchRequest struct {
+	Items  []RefundBatchItem `json:"items"`
+	Reason OperationReason   `json:"reason,omitempty,omitzero"`
+}
+
+// CreateRefundRequest defines model for CreateRefundRequest.
+type CreateRefundRequest struct {
+	// Amount Amount in cents to refund. Defaults to the captured amount not refunded yet.
+	Amount      int64             `json:"amount,omitempty,omitzero"`
+	Destination RefundDestination `json:"destination,omitempty,omitzero"`
+	Reason      OperationReason   `json:"reason,omitempty,omitzero"`
+}
+
+// CreateSubscriptionRequest defines model for CreateSubscriptionRequest.
+type CreateSubscriptionRequest struct {
+	// Amount Amount in cents charged every interval
+	Amount int64 `json:"amount"`
+
+	// CustomerId Must own the payment method
+	CustomerId      string             `json:"customer_id"`
+	Interval        BillingInterval    `json:"interval"`
+	PaymentMethodId openapi_types.UUID `json:"payment_method_id"`
+
+	// Plan The merchant's plan identifier
+	Plan string `json:"plan"`
+
+	// StartAt When the first charge is due. Defaults to now.
+	StartAt time.Time `json:"start_at,omitempty,omitzero"`
+}
+
+// CreateVoidBatchRequest defines model for CreateVoidBatchRequest.
+type CreateVoidBatchRequest struct {
+	// AuthorizedBefore Only payments authorized before this time
+	AuthorizedBefore time.Time       `json:"authorized_before,omitempty,omitzero"`
+	CustomerId       string          `json:"customer_id,omitempty,omitzero"`
+	OrderIds         []string        `json:"order_ids,omitempty,omitzero"`
+	Reason           OperationReason `json:"reason,omitempty,omitzero"`
+}
+
+// CreateVoidRequest defines model for CreateVoidRequest.
+type CreateVoidRequest struct {
+	// Amount Amount in cents of the uncaptured remainder to release. Only allowed once the payment has been partly captured; defaults to all of what is left to capture.
+	Amount int64           `json:"amount,omitempty,omitzero"`
+	Reason OperationReason `json:"reason,omitempty,omitzero"`
+}
+
+// CurrencyTotal defines model for CurrencyTotal.
+type CurrencyTotal struct {
+	// AuthorizedCents Total amount of payments that were not FAILED, SCHEDULED or PENDING
+	AuthorizedCents int64  `json:"authorized_cents"`
+	CapturedCents   int64  `json:"captured_cents"`
+	Currency        string `json:"currency"`
+	RefundedCents   int64  `json:"refunded_cents"`
+}
+
+// CustomerPaymentSummary defines model for CustomerPaymentSummary.
+type CustomerPaymentSummary struct {
+	CustomerId string `json:"customer_id"`
+
+	// LastPaymentAt When the customer's newest payment was created
+	LastPaymentAt time.Time `json:"last_payment_at,omitzero"`
+
+	// PaymentCount Payments of the customer in any status
+	PaymentCount int `json:"payment_count"`
+
+	// StatusCounts Number of payments in each status the customer has any in
+	StatusCounts []StatusCount `json:"status_counts"`
+
+	// Totals Amounts per currency the customer paid in
+	Totals []CurrencyTotal `json:"totals"`
+}
+
+// CustomerPaymentSummaryResponse defines model for CustomerPaymentSummaryResponse.
+type CustomerPaymentSummaryResponse struct {
+	Data CustomerPaymentSummary `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// DeadLetter defines model for DeadLetter.
+type DeadLetter struct {
+	// Attempts Bank calls the retry worker made before it gave up
+	Attempts int `json:"attempts"`
+
+	// DeadLetteredAt When the retry worker gave up
+	DeadLetteredAt time.Time `json:"dead_lettered_at"`
+
+	// LastError The error of the last attempt
+	LastError string  `json:"last_error"`
+	Payment   Payment `json:"payment"`
+}
+
+// DeadLettersResponse defines model for DeadLettersResponse.
+type DeadLettersResponse struct {
+	Data []DeadLetter `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// DebugCapture defines model for DebugCapture.
+type DebugCapture struct {
+	CapturedAt     time.Time          `json:"captured_at"`
+	Id             openapi_types.UUID `json:"id"`
+	IdempotencyKey string             `json:"idempotency_key"`
+	Method         string             `json:"method"`
+
+	// RequestBody Sanitized request body sent to the bank
+	RequestBody string `json:"request_body,omitempty,omitzero"`
+
+	// ResponseBody Sanitized response body returned by the bank
+	ResponseBody   string `json:"response_body,omitempty,omitzero"`
+	ResponseStatus int    `json:"response_status"`
+	Url            string `json:"url"`
+}
+
+// DebugSession defines model for DebugSession.
+type DebugSession struct {
+	Captures       []DebugCapture     `json:"captures"`
+	CreatedAt      time.Time          `json:"created_at"`
+	ExpiresAt      time.Time          `json:"expires_at"`
+	Id             openapi_types.UUID `json:"id"`
+	IdempotencyKey string             `json:"idempotency_key,omitzero"`
+	PaymentId      openapi_types.UUID `json:"payment_id,omitzero"`
+}
+
+// DebugSessionResponse defines model for DebugSessionResponse.
+type DebugSessionResponse struct {
+	Data DebugSession `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// Erasure defines model for Erasure.
+type Erasure struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// CustomerToken Replaces the customer ID on the erased records
+	CustomerToken            string             `json:"customer_token"`
+	Id                       openapi_types.UUID `json:"id"`
+	PaymentMethodsAnonymized int                `json:"payment_methods_anonymized"`
+	PaymentsAnonymized       int                `json:"payments_anonymized"`
+
+	// PaymentsRetained Payments of the customer still inside the retention period
+	PaymentsRetained int `json:"payments_retained"`
+
+	// RetentionCutoff Payments created before this time were anonymized
+	RetentionCutoff time.Time `json:"retention_cutoff"`
+
+	// SubscriptionsAnonymized Canceled subscriptions of the customer
+	SubscriptionsAnonymized int `json:"subscriptions_anonymized"`
+}
+
+// ErasureResponse defines model for ErasureResponse.
+type ErasureResponse struct {
+	Data Erasure `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Error struct {
+		// Code Machine-readable error code
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// DeclineCategory Set when the bank declined the request, so a storefront can tell the
+		// customer what to do next whatever the bank's own `code`; see the payment's
+		// `decline_category`
+		DeclineCategory ErrorResponseErrorDeclineCategory `json:"decline_category,omitempty,omitzero"`
+
+		// Message Human-readable error message
+		Message string `json:"message"`
+
+		// PaymentId The existing payment the error refers to, such as the original of a `DUPLICATE_PAYMENT`
+		PaymentId openapi_types.UUID `json:"payment_id,omitempty,omitzero"`
+
+		// StatusUrl Where to get the payment named by `payment_id`
+		StatusUrl string `json:"status_url,omitempty,omitzero"`
+	} `json:"error,omitempty,omitzero"`
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// ErrorResponseErrorCode Machine-readable error code
+type ErrorResponseErrorCode string
+
+// ErrorResponseErrorDeclineCategory Set when the bank declined the request, so a storefront can tell the
+// customer what to do next whatever the bank's own `code`; see the payment's
+// `decline_category`
+type ErrorResponseErrorDeclineCategory string
+
+// FeatureFlag defines model for FeatureFlag.
+type FeatureFlag struct {
+	Name      FeatureFlagName       `json:"name"`
+	Overrides []FeatureFlagOverride `json:"overrides"`
+
+	// Percent Share of merchants the feature is on for
+	Percent int `json:"percent"`
+
+	// UpdatedAt When the rollout was last changed here; omitted while it is the one from the environment
+	UpdatedAt time.Time `json:"updated_at,omitempty,omitzero"`
+}
+
+// FeatureFlagName defines model for FeatureFlagName.
+type FeatureFlagName string
+
+// FeatureFlagOverride defines model for FeatureFlagOverride.
+type FeatureFlagOverride struct {
+	Enabled    bool   `json:"enabled"`
+	MerchantId string `json:"merchant_id"`
+}
+
+// FeatureFlagResponse defines model for FeatureFlagResponse.
+type FeatureFlagResponse struct {
+	Data FeatureFlag `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// FeatureFlagsResponse defines model for FeatureFlagsResponse.
+type FeatureFlagsResponse struct {
+	Data []FeatureFlag `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// LogLevel defines model for LogLevel.
+type LogLevel struct {
+	// DebugPaymentIds Payments logged down to debug whatever the level
+	DebugPaymentIds []openapi_types.UUID `json:"debug_payment_ids,omitempty,omitzero"`
+	Level           LogLevelLevel        `json:"level"`
+}
+
+// LogLevelLevel defines model for LogLevelLevel.
+type LogLevelLevel string
+
+// LogLevelResponse defines model for LogLevelResponse.
+type LogLevelResponse struct {
+	Data LogLevel `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// MerchantQuota defines model for MerchantQuota.
+type MerchantQuota struct {
+	// DailyTransactionLimit Omitted when unlimited
+	DailyTransactionLimit int `json:"daily_transaction_limit,omitempty,omitzero"`
+
+	// DailyVolumeLimit Omitted when unlimited
+	DailyVolumeLimit int64  `json:"daily_volume_limit,omitempty,omitzero"`
+	MerchantId       string `json:"merchant_id"`
+
+	// UsedTransactions Payments created today (UTC)
+	UsedTransactions int `json:"used_transactions"`
+
+	// UsedVolume Amount of the payments created today, in minor units
+	UsedVolume int64 `json:"used_volume"`
+}
+
+// MerchantQuotaResponse defines model for MerchantQuotaResponse.
+type MerchantQuotaResponse struct {
+	Data MerchantQuota `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// NetworkToken A network token (DPAN) from a wallet, with the cryptogram for this payment in
+// place of the CVV. The cryptogram authorizes a single payment, so token payments
+// are never held for manual review.
+type NetworkToken struct {
+	// Cryptogram Base64 payment cryptogram (TAVV or UCAF), 20 bytes decoded
+	Cryptogram string `json:"cryptogram"`
+
+	// Eci Electronic commerce indicator returned with the cryptogram
+	Eci string `json:"eci,omitempty,omitzero"`
+
+	// Number Token number issued by the card network (12-19 digits)
+	Number string `json:"number"`
+}
+
+// Operation defines model for Operation.
+type Operation struct {
+	// AmountCents Amount in cents moved by the operation
+	AmountCents int64 `json:"amount_cents"`
+
+	// BankInitiated Set on a refund the bank made on its own and the gateway only recorded
+	BankInitiated bool `json:"bank_initiated,omitempty,omitzero"`
+
+	// BankReferenceId Bank's capture, void or refund ID, or the new authorization ID of a reauthorization
+	BankReferenceId string `json:"bank_reference_id,omitzero"`
+
+	// CompletedAt When the operation succeeded, failed or was rejected
+	CompletedAt time.Time `json:"completed_at,omitzero"`
+
+	// CreatedAt When the operation was requested
+	CreatedAt   time.Time             `json:"created_at"`
+	Destination RefundDestinationType `json:"destination,omitempty,omitzero"`
+
+	// DestinationLast4 Last four digits of the account a bank transfer refund is sent to
+	DestinationLast4 string `json:"destination_last4,omitempty,omitzero"`
+
+	// DestinationRoutingNumber Routing number of the account a bank transfer refund is sent to
+	DestinationRoutingNumber string `json:"destination_routing_number,omitempty,omitzero"`
+
+	// Id Unique operation identifier
+	Id openapi_types.UUID `json:"id"`
+
+	// PaymentId The payment the operation was performed on
+	PaymentId openapi_types.UUID `json:"payment_id"`
+	Reason    OperationReason    `json:"reason,omitempty,omitzero"`
+
+	// RequestedBy API key that requested a refund held for approval
+	RequestedBy string `json:"requested_by,omitzero"`
+
+	// ReviewedAt When a refund held for approval was approved or rejected
+	ReviewedAt time.Time `json:"reviewed_at,omitzero"`
+
+	// ReviewedBy API key that approved or rejected a refund held for approval
+	ReviewedBy string `json:"reviewed_by,omitzero"`
+
+	// Status Current operation status. Refunds above the approval threshold start as
+	// PENDING_APPROVAL, and become PENDING when approved or REJECTED when not.
+	Status OperationStatus `json:"status"`
+
+	// Type Kind of operation
+	Type OperationType `json:"type"`
+}
+
+// OperationStatus Current operation status. Refunds above the approval threshold start as
+// PENDING_APPROVAL, and become PENDING when approved or REJECTED when not.
+type OperationStatus string
+
+// OperationType Kind of operation
+type OperationType string
+
+// OperationReason Why a void or refund was requested, used by finance for categorization
+type OperationReason string
+
+// OperationResponse defines model for OperationResponse.
+type OperationResponse struct {
+	Data Operation `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// OperationsResponse defines model for OperationsResponse.
+type OperationsResponse struct {
+	Data []Operation `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// OutboxStatus defines model for OutboxStatus.
+type OutboxStatus struct {
+	// OldestAgeSeconds How long the longest waiting event has waited; zero when none wait
+	OldestAgeSeconds float64 `json:"oldest_age_seconds"`
+
+	// OldestOccurredAt When the longest waiting event occurred; omitted when none wait
+	OldestOccurredAt time.Time `json:"oldest_occurred_at,omitempty,omitzero"`
+
+	// Paused Whether delivery is paused
+	Paused bool `json:"paused"`
+
+	// PausedAt When delivery was paused; omitted while it is not
+	PausedAt time.Time `json:"paused_at,omitempty,omitzero"`
+
+	// PausedBy The API key that paused delivery; omitted while it is not paused
+	PausedBy string `json:"paused_by,omitempty,omitzero"`
+
+	// Pending Transition events waiting to be delivered
+	Pending int `json:"pending"`
+
+	// Reason Why delivery was paused; omitted while it is not
+	Reason string `json:"reason,omitempty,omitzero"`
+}
+
+// OutboxStatusResponse defines model for OutboxStatusResponse.
+type OutboxStatusResponse struct {
+	Data OutboxStatus `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// PauseOutboxRequest defines model for PauseOutboxRequest.
+type PauseOutboxRequest struct {
+	// Reason Why delivery is paused, shown with the outbox backlog
+	Reason string `json:"reason,omitempty,omitzero"`
+}
+
+// Payment defines model for Payment.
+type Payment struct {
+	// Acquirer The bank that authorized the payment
+	Acquirer string `json:"acquirer"`
+
+	// AmountCents Amount in cents
+	AmountCents int64 `json:"amount_cents"`
+
+	// AttemptCount Number of retry attempts
+	AttemptCount int `json:"attempt_count"`
+
+	// AuthorizedAt When payment was authorized
+	AuthorizedAt time.Time `json:"authorized_at,omitzero"`
+
+	// BankAuthId Bank's authorization ID
+	BankAuthId string `json:"bank_auth_id,omitzero"`
+
+	// BankCaptureId Bank's capture ID
+	BankCaptureId string `json:"bank_capture_id,omitzero"`
+
+	// BankRefundId Bank's refund ID
+	BankRefundId string `json:"bank_refund_id,omitzero"`
+
+	// BankVoidId Bank's void ID
+	BankVoidId string `json:"bank_void_id,omitzero"`
+
+	// CapturedAmountCents Total amount in cents captured so far
+	CapturedAmountCents int64 `json:"captured_amount_cents"`
+
+	// CapturedAt When payment was captured, most recently for a payment captured in parts
+	CapturedAt time.Time `json:"captured_at,omitzero"`
+
+	// CreatedAt When payment was created
+	CreatedAt time.Time `json:"created_at"`
+
+	// Currency Currency code
+	Currency string `json:"currency"`
+
+	// CustomerId Customer ID from FicMart
+	CustomerId string `json:"customer_id"`
+
+	// DeclineCategory What the customer can do about the bank's refusal of the payment, whichever
+	// code the acquirer refused it with: `do_not_retry` will be refused again,
+	// `retry_later` may go through with the same card later,
+	// `customer_action_required` goes through once the card details are corrected,
+	// and `try_other_card` asks for another card. Missing unless the bank refused
+	// the payment for good, or soft-declined a PENDING payment that is being retried.
+	DeclineCategory PaymentDeclineCategory `json:"decline_category,omitempty,omitzero"`
+
+	// ExpiresAt When authorization expires (7 days from authorization)
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+
+	// FailureReason Why the payment failed without a bank decline, e.g. card_expired
+	FailureReason string `json:"failure_reason,omitzero"`
+
+	// FirstCapturedAt When the payment's first capture went through
+	FirstCapturedAt time.Time `json:"first_captured_at,omitzero"`
+
+	// GroupId The payment group the payment is a part of, if any
+	GroupId openapi_types.UUID `json:"group_id,omitzero"`
+
+	// Id Unique payment identifier
+	Id openapi_types.UUID `json:"id"`
+
+	// LastErrorCategory How the payment's last failed bank call was classified. `TRANSIENT` means the
+	// gateway is retrying it (see `attempt_count` and `next_retry_at`); `PERMANENT`
+	// means it failed for good. Missing when no bank call has failed.
+	LastErrorCategory PaymentLastErrorCategory `json:"last_error_category,omitempty,omitzero"`
+
+	// NetAmountCents What the customer has paid so far, refunds taken off
+	NetAmountCents int64 `json:"net_amount_cents"`
+
+	// NextRetryAt When next retry is scheduled
+	NextRetryAt time.Time `json:"next_retry_at,omitzero"`
+
+	// OrderId Order ID from FicMart
+	OrderId string `json:"order_id"`
+
+	// PaymentMethodId Saved payment method the payment is charged to, if any
+	PaymentMethodId openapi_types.UUID `json:"payment_method_id,omitzero"`
+
+	// RefundedAmountCents Total amount in cents refunded so far
+	RefundedAmountCents int64 `json:"refunded_amount_cents"`
+
+	// RefundedAt When payment was refunded
+	RefundedAt time.Time `json:"refunded_at,omitzero"`
+
+	// ReleasedAmountCents Total amount in cents of the authorization voided after a partial capture, and no longer capturable
+	ReleasedAmountCents int64 `json:"released_amount_cents"`
+
+	// Status Current payment status
+	Status PaymentStatus `json:"status"`
+
+	// TimeToCaptureSeconds Seconds from authorization to the first capture, for capture SLAs
+	TimeToCaptureSeconds int64 `json:"time_to_capture_seconds,omitzero"`
+
+	// VoidedAt When payment was voided
+	VoidedAt time.Time `json:"voided_at,omitzero"`
+}
+
+// PaymentDeclineCategory What the customer can do about the bank's refusal of the payment, whichever
+// code the acquirer refused it with: `do_not_retry` will be refused again,
+// `retry_later` may go through with the same card later,
+// `customer_action_required` goes through once the card details are corrected,
+// and `try_other_card` asks for another card. Missing unless the bank refused
+// the payment for good, or soft-declined a PENDING payment that is being retried.
+type PaymentDeclineCategory string
+
+// PaymentLastErrorCategory How the payment's last failed bank call was classified. `TRANSIENT` means the
+// gateway is retrying it (see `attempt_count` and `next_retry_at`); `PERMANENT`
+// means it failed for good. Missing when no bank call has failed.
+type PaymentLastErrorCategory string
+
+// PaymentStatus Current payment status
+type PaymentStatus string
+
+// PaymentGroup defines model for PaymentGroup.
+type PaymentGroup struct {
+	// AmountCents Total of the parts in cents
+	AmountCents int64 `json:"amount_cents"`
+
+	// CapturedAmountCents Total captured across the parts
+	CapturedAmountCents int64              `json:"captured_amount_cents"`
+	CreatedAt           time.Time          `json:"created_at"`
+	Currency            string             `json:"currency"`
+	CustomerId          string             `json:"customer_id"`
+	Id                  openapi_types.UUID `json:"id"`
+	OrderId             string             `json:"order_id"`
+
+	// Parts The group's payments in order
+	Parts []Payment `json:"parts"`
+
+	// Status The status of the group's least settled part
+	Status PaymentGroupStatus `json:"status"`
+}
+
+// PaymentGroupStatus The status of the group's least settled part
+type PaymentGroupStatus string
+
+// PaymentGroupPart defines model for PaymentGroupPart.
+type PaymentGroupPart struct {
+	// Amount Amount in cents authorized on this card
+	Amount int64 `json:"amount"`
+
+	// CardNumber Card number (13-19 digits)
+	CardNumber string `json:"card_number"`
+
+	// Cvv Card verification value (3-4 digits)
+	Cvv         string `json:"cvv"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+}
+
+// PaymentGroupResponse defines model for PaymentGroupResponse.
+type PaymentGroupResponse struct {
+	Data PaymentGroup `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// PaymentIntent defines model for PaymentIntent.
+type PaymentIntent struct {
+	AmountCents int64 `json:"amount_cents"`
+
+	// ClientToken What the storefront confirms the intent with; only returned when the intent is created
+	ClientToken string             `json:"client_token,omitempty,omitzero"`
+	ConfirmedAt time.Time          `json:"confirmed_at,omitzero"`
+	CreatedAt   time.Time          `json:"created_at"`
+	Currency    string             `json:"currency"`
+	CustomerId  string             `json:"customer_id"`
+	ExpiresAt   time.Time          `json:"expires_at"`
+	Id          openapi_types.UUID `json:"id"`
+	OrderId     string             `json:"order_id"`
+
+	// PaymentId The payment the intent was confirmed with
+	PaymentId openapi_types.UUID  `json:"payment_id,omitzero"`
+	Status    PaymentIntentStatus `json:"status"`
+}
+
+// PaymentIntentStatus defines model for PaymentIntentStatus.
+type PaymentIntentStatus string
+
+// PaymentIntentResponse defines model for PaymentIntentResponse.
+type PaymentIntentResponse struct {
+	Data PaymentIntent `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// PaymentMethod defines model for PaymentMethod.
+type PaymentMethod struct {
+	CreatedAt   time.Time          `json:"created_at"`
+	CustomerId  string             `json:"customer_id"`
+	ExpiryMonth int                `json:"expiry_month"`
+	ExpiryYear  int                `json:"expiry_year"`
+	Id          openapi_types.UUID `json:"id"`
+
+	// Last4 Last four digits of the card number
+	Last4 string `json:"last4"`
+}
+
+// PaymentMethodResponse defines model for PaymentMethodResponse.
+type PaymentMethodResponse struct {
+	Data PaymentMethod `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// PaymentResponse defines model for PaymentResponse.
+type PaymentResponse struct {
+	Data Payment `json:"data,omitempty,omitzero"`
 
-	// CustomerId Customer identifier from FicMart
-	CustomerId string `json:"customer_id"`
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
 
-	// Cvv Card verification value (3-4 digits)
-	Cvv string `json:"cvv"`
+// PaymentReview defines model for PaymentReview.
+type PaymentReview struct {
+	// FlaggedAt When the payment entered the queue
+	FlaggedAt time.Time `json:"flagged_at"`
+	Payment   Payment   `json:"payment"`
 
-	// ExpiryMonth Card expiry month (1-12)
-	ExpiryMonth int `json:"expiry_month"`
+	// Reason Why the payment was flagged
+	Reason string `json:"reason"`
+}
 
-	// ExpiryYear Card expiry year (YYYY)
-	ExpiryYear int `json:"expiry_year"`
+// PaymentReviewsResponse defines model for PaymentReviewsResponse.
+type PaymentReviewsResponse struct {
+	Data []PaymentReview `json:"data,omitempty,omitzero"`
 
-	// OrderId Unique order identifier from FicMart
-	OrderId string `json:"order_id"`
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
 }
 
-// CaptureRequest defines model for CaptureRequest.
-type CaptureRequest struct {
-	// PaymentId The payment ID to capture
-	PaymentId openapi_types.UUID `json:"payment_id"`
+// Payout defines model for Payout.
+type Payout struct {
+	AccountLast4 string    `json:"account_last4"`
+	AmountCents  int64     `json:"amount_cents"`
+	BankPayoutId string    `json:"bank_payout_id,omitzero"`
+	CreatedAt    time.Time `json:"created_at"`
+	Currency     string    `json:"currency"`
+	FailedAt     time.Time `json:"failed_at,omitzero"`
+
+	// FailureCode The bank's reason for a declined or returned payout
+	FailureCode   string             `json:"failure_code,omitzero"`
+	Id            openapi_types.UUID `json:"id"`
+	PaidAt        time.Time          `json:"paid_at,omitzero"`
+	PaymentId     openapi_types.UUID `json:"payment_id,omitzero"`
+	Purpose       PayoutPurpose      `json:"purpose"`
+	RecipientId   string             `json:"recipient_id"`
+	RoutingNumber string             `json:"routing_number"`
+	Status        PayoutStatus       `json:"status"`
 }
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Error struct {
-		// Code Machine-readable error code
-		Code ErrorResponseErrorCode `json:"code"`
+// PayoutStatus defines model for PayoutStatus.
+type PayoutStatus string
 
-		// Message Human-readable error message
-		Message string `json:"message"`
-	} `json:"error,omitempty,omitzero"`
+// PayoutPurpose defines model for PayoutPurpose.
+type PayoutPurpose string
+
+// PayoutResponse defines model for PayoutResponse.
+type PayoutResponse struct {
+	Data Payout `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
 	Success bool `json:"success,omitempty,omitzero"`
 }
 
-// ErrorResponseErrorCode Machine-readable error code
-type ErrorResponseErrorCode string
+// Receipt defines model for Receipt.
+type Receipt struct {
+	// AmountCents Amount authorized
+	AmountCents  int64     `json:"amount_cents"`
+	AuthorizedAt time.Time `json:"authorized_at"`
 
-// Payment defines model for Payment.
-type Payment struct {
-	// AmountCents Amount in cents
-	AmountCents int64 `json:"amount_cents"`
+	// BankAuthId The bank's authorization ID
+	BankAuthId          string    `json:"bank_auth_id"`
+	CapturedAmountCents int64     `json:"captured_amount_cents"`
+	CapturedAt          time.Time `json:"captured_at,omitzero"`
 
-	// AttemptCount Number of retry attempts
-	AttemptCount int `json:"attempt_count"`
+	// CardBrand visa, mastercard, amex, discover, jcb or unknown. Missing for payments
+	// authorized before cards were recorded.
+	CardBrand string `json:"card_brand,omitempty,omitzero"`
 
-	// AuthorizedAt When payment was authorized
-	AuthorizedAt time.Time `json:"authorized_at,omitzero"`
+	// CardLast4 Last four digits of the card. Missing when card_brand is.
+	CardLast4  string         `json:"card_last4,omitempty,omitzero"`
+	CreatedAt  time.Time      `json:"created_at"`
+	Currency   string         `json:"currency"`
+	CustomerId string         `json:"customer_id"`
+	Entries    []ReceiptEntry `json:"entries"`
 
-	// BankAuthId Bank's authorization ID
-	BankAuthId string `json:"bank_auth_id,omitzero"`
+	// NetAmountCents What the customer has paid, refunds taken off
+	NetAmountCents      int64              `json:"net_amount_cents"`
+	OrderId             string             `json:"order_id"`
+	PaymentId           openapi_types.UUID `json:"payment_id"`
+	RefundedAmountCents int64              `json:"refunded_amount_cents"`
+	RefundedAt          time.Time          `json:"refunded_at,omitzero"`
 
-	// BankCaptureId Bank's capture ID
-	BankCaptureId string `json:"bank_capture_id,omitzero"`
+	// Status Status of the payment, as on the payment itself
+	Status   string    `json:"status"`
+	VoidedAt time.Time `json:"voided_at,omitzero"`
+}
 
-	// BankRefundId Bank's refund ID
-	BankRefundId string `json:"bank_refund_id,omitzero"`
+// ReceiptEntry defines model for ReceiptEntry.
+type ReceiptEntry struct {
+	AmountCents int64 `json:"amount_cents"`
 
-	// BankVoidId Bank's void ID
-	BankVoidId string `json:"bank_void_id,omitzero"`
+	// BankReferenceId The bank's capture or refund ID
+	BankReferenceId string    `json:"bank_reference_id,omitempty,omitzero"`
+	CompletedAt     time.Time `json:"completed_at"`
 
-	// CapturedAt When payment was captured
-	CapturedAt time.Time `json:"captured_at,omitzero"`
+	// Type CAPTURE or REFUND
+	Type string `json:"type"`
+}
 
-	// CreatedAt When payment was created
-	CreatedAt time.Time `json:"created_at"`
+// ReceiptFormat defines model for ReceiptFormat.
+type ReceiptFormat string
 
-	// Currency Currency code
-	Currency string `json:"currency"`
+// ReceiptResponse defines model for ReceiptResponse.
+type ReceiptResponse struct {
+	Data Receipt `json:"data,omitempty,omitzero"`
 
-	// CustomerId Customer ID from FicMart
-	CustomerId string `json:"customer_id"`
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
 
-	// ExpiresAt When authorization expires (7 days from authorization)
-	ExpiresAt time.Time `json:"expires_at,omitzero"`
+// ReconcileRequest defines model for ReconcileRequest.
+type ReconcileRequest struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
 
-	// Id Unique payment identifier
-	Id openapi_types.UUID `json:"id"`
+// Reconciliation defines model for Reconciliation.
+type Reconciliation struct {
+	// Checked Payments and unclaimed authorizations checked with the bank
+	Checked int                   `json:"checked"`
+	From    time.Time             `json:"from"`
+	Issues  []ReconciliationIssue `json:"issues"`
 
-	// NextRetryAt When next retry is scheduled
-	NextRetryAt time.Time `json:"next_retry_at,omitzero"`
+	// NextFrom Where to reconcile from next when the period held more than one run checks
+	NextFrom time.Time `json:"next_from,omitzero"`
+	To       time.Time `json:"to"`
+}
 
-	// OrderId Order ID from FicMart
-	OrderId string `json:"order_id"`
+// ReconciliationIssue defines model for ReconciliationIssue.
+type ReconciliationIssue struct {
+	BankAuthId string `json:"bank_auth_id"`
 
-	// RefundedAt When payment was refunded
-	RefundedAt time.Time `json:"refunded_at,omitzero"`
+	// BankStatus What the bank says; absent for MISSING_AT_BANK
+	BankStatus      string                  `json:"bank_status,omitempty,omitzero"`
+	FirstDetectedAt time.Time               `json:"first_detected_at"`
+	GatewayStatus   string                  `json:"gateway_status,omitempty,omitzero"`
+	Id              openapi_types.UUID      `json:"id"`
+	Kind            ReconciliationIssueKind `json:"kind"`
+	LastDetectedAt  time.Time               `json:"last_detected_at"`
 
-	// Status Current payment status
-	Status PaymentStatus `json:"status"`
+	// PaymentId The payment the authorization belongs to or was requested for
+	PaymentId openapi_types.UUID `json:"payment_id,omitempty,omitzero"`
 
-	// VoidedAt When payment was voided
-	VoidedAt time.Time `json:"voided_at,omitzero"`
+	// VoidedAt When reconciliation voided an ORPHANED_AUTHORIZATION to release the customer's funds
+	VoidedAt time.Time `json:"voided_at,omitempty,omitzero"`
 }
 
-// PaymentStatus Current payment status
-type PaymentStatus string
+// ReconciliationIssueKind defines model for ReconciliationIssueKind.
+type ReconciliationIssueKind string
 
-// PaymentResponse defines model for PaymentResponse.
-type PaymentResponse struct {
-	Data Payment `json:"data,omitempty,omitzero"`
+// ReconciliationIssuesResponse defines model for ReconciliationIssuesResponse.
+type ReconciliationIssuesResponse struct {
+	Data []ReconciliationIssue `json:"data,omitempty,omitzero"`
 
 	// Success Whether the request succeeded
 	Success bool `json:"success,omitempty,omitzero"`
 }
 
+// ReconciliationResponse defines model for ReconciliationResponse.
+type ReconciliationResponse struct {
+	Data Reconciliation `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// RefundBatchItem defines model for RefundBatchItem.
+type RefundBatchItem struct {
+	// Amount Amount in cents to refund. Defaults to the captured amount not refunded yet.
+	Amount    int64              `json:"amount,omitempty,omitzero"`
+	PaymentId openapi_types.UUID `json:"payment_id"`
+}
+
+// RefundDestination defines model for RefundDestination.
+type RefundDestination struct {
+	// AccountNumber Account a bank transfer is sent to; required for one, and refused otherwise
+	AccountNumber string `json:"account_number,omitempty,omitzero"`
+
+	// RoutingNumber Routing number of the account; required for a bank transfer
+	RoutingNumber string                `json:"routing_number,omitempty,omitzero"`
+	Type          RefundDestinationType `json:"type"`
+}
+
+// RefundDestinationType Where a refund sends the funds
+type RefundDestinationType string
+
 // RefundRequest defines model for RefundRequest.
 type RefundRequest struct {
+	// Amount Amount in cents to refund. Defaults to the captured amount not refunded yet.
+	Amount int64 `json:"amount,omitempty,omitzero"`
+
 	// PaymentId The payment ID to refund
 	PaymentId openapi_types.UUID `json:"payment_id"`
+	Reason    OperationReason    `json:"reason,omitempty,omitzero"`
+}
+
+// Region defines model for Region.
+type Region struct {
+	// Active Whether the region takes changes
+	Active bool `json:"active"`
+
+	// ActiveRegion The region holding the lease; omitted until a region is first promoted
+	ActiveRegion string `json:"active_region,omitempty,omitzero"`
+
+	// Epoch The epoch the region's changes are recorded under; zero until a region is first promoted
+	Epoch int64 `json:"epoch"`
+
+	// PromotedAt When the lease last moved; omitted until a region is first promoted
+	PromotedAt time.Time `json:"promoted_at,omitempty,omitzero"`
+
+	// Region The region that answered; empty when the gateway is not configured with one
+	Region string `json:"region"`
+}
+
+// RegionResponse defines model for RegionResponse.
+type RegionResponse struct {
+	Data Region `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
+}
+
+// ReplayPaymentsRequest defines model for ReplayPaymentsRequest.
+type ReplayPaymentsRequest struct {
+	// PaymentIds The payments to replay
+	PaymentIds []openapi_types.UUID `json:"payment_ids,omitempty,omitzero"`
+
+	// Status Replay the payments in this status instead
+	Status ReplayPaymentsRequestStatus `json:"status,omitempty,omitzero"`
+}
+
+// ReplayPaymentsRequestStatus Replay the payments in this status instead
+type ReplayPaymentsRequestStatus string
+
+// SchedulePaymentRequest defines model for SchedulePaymentRequest.
+type SchedulePaymentRequest struct {
+	// Amount Amount in cents
+	Amount int64 `json:"amount"`
+
+	// CustomerId Must own the payment method
+	CustomerId      string             `json:"customer_id"`
+	OrderId         string             `json:"order_id"`
+	PaymentMethodId openapi_types.UUID `json:"payment_method_id"`
+
+	// ScheduledFor When to authorize the payment; must be in the future
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+// SetCanaryPercentRequest defines model for SetCanaryPercentRequest.
+type SetCanaryPercentRequest struct {
+	Percent int `json:"percent"`
+}
+
+// SetFeatureFlagOverrideRequest defines model for SetFeatureFlagOverrideRequest.
+type SetFeatureFlagOverrideRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+// SetFeatureFlagRequest defines model for SetFeatureFlagRequest.
+type SetFeatureFlagRequest struct {
+	Percent int `json:"percent"`
+}
+
+// SetMerchantQuotaRequest defines model for SetMerchantQuotaRequest.
+type SetMerchantQuotaRequest struct {
+	// DailyTransactionLimit Payments the merchant may create per UTC day; unlimited if omitted
+	DailyTransactionLimit int `json:"daily_transaction_limit,omitempty,omitzero"`
+
+	// DailyVolumeLimit Total amount in minor units the merchant may charge per UTC day, whatever the currency; unlimited if omitted
+	DailyVolumeLimit int64 `json:"daily_volume_limit,omitempty,omitzero"`
+}
+
+// StatusCount defines model for StatusCount.
+type StatusCount struct {
+	Count  int    `json:"count"`
+	Status string `json:"status"`
+}
+
+// Subscription defines model for Subscription.
+type Subscription struct {
+	AmountCents int64     `json:"amount_cents"`
+	CanceledAt  time.Time `json:"canceled_at,omitzero"`
+	CreatedAt   time.Time `json:"created_at"`
+	Currency    string    `json:"currency"`
+	CustomerId  string    `json:"customer_id"`
+
+	// FailedAttempts Consecutive declines of the current charge
+	FailedAttempts int                `json:"failed_attempts"`
+	Id             openapi_types.UUID `json:"id"`
+	Interval       BillingInterval    `json:"interval"`
+
+	// LastPaymentId Payment made by the most recent charge attempt
+	LastPaymentId openapi_types.UUID `json:"last_payment_id,omitzero"`
+
+	// NextChargeAt When the next charge, or the next retry of a declined one, is due
+	NextChargeAt    time.Time          `json:"next_charge_at"`
+	PaymentMethodId openapi_types.UUID `json:"payment_method_id"`
+	Plan            string             `json:"plan"`
+	Status          SubscriptionStatus `json:"status"`
+}
+
+// SubscriptionStatus defines model for SubscriptionStatus.
+type SubscriptionStatus string
+
+// SubscriptionResponse defines model for SubscriptionResponse.
+type SubscriptionResponse struct {
+	Data Subscription `json:"data,omitempty,omitzero"`
+
+	// Success Whether the request succeeded
+	Success bool `json:"success,omitempty,omitzero"`
 }
 
 // VoidRequest defines model for VoidRequest.
 type VoidRequest struct {
+	// Amount Amount in cents of the uncaptured remainder to release. Only allowed once the payment has been partly captured; defaults to all of what is left to capture.
+	Amount int64 `json:"amount,omitempty,omitzero"`
+
 	// PaymentId The payment ID to void
 	PaymentId openapi_types.UUID `json:"payment_id"`
+	Reason    OperationReason    `json:"reason,omitempty,omitzero"`
 }
 
 // IdempotencyKey defines model for IdempotencyKey.
 type IdempotencyKey = string
 
+// GetExpiringAuthorizationsParams defines parameters for GetExpiringAuthorizations.
+type GetExpiringAuthorizationsParams struct {
+	// WithinHours How far ahead to look, in hours
+	WithinHours int `form:"within_hours,omitempty" json:"within_hours,omitempty,omitzero"`
+
+	// Limit Maximum number of payments to return
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
+// GetDeadLettersParams defines parameters for GetDeadLetters.
+type GetDeadLettersParams struct {
+	// Limit Maximum number of payments to return
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
+// GetReconciliationIssuesParams defines parameters for GetReconciliationIssues.
+type GetReconciliationIssuesParams struct {
+	// Since Only issues detected again at or after this time
+	Since time.Time `form:"since" json:"since"`
+
+	// Limit Maximum number of issues to return
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
+// GetRefundApprovalsParams defines parameters for GetRefundApprovals.
+type GetRefundApprovalsParams struct {
+	// Limit Maximum number of refunds to return
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
+// GetReviewsParams defines parameters for GetReviews.
+type GetReviewsParams struct {
+	// Limit Maximum number of payments to return
+	Limit int `form:"limit,omitempty" json:"limit,omitempty,omitzero"`
+}
+
+// CreateVoidBatchParams defines parameters for CreateVoidBatch.
+type CreateVoidBatchParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
 // AuthorizePaymentParams defines parameters for AuthorizePayment.
 type AuthorizePaymentParams struct {
+	// Async Accept the payment immediately and authorize it in the background
+	Async bool `form:"async,omitempty" json:"async,omitempty,omitzero"`
+
 	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
 	// returns cached response. Prevents duplicate charges.
 	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
@@ -179,6 +1511,33 @@ type CapturePaymentParams struct {
 	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
 }
 
+// AuthorizePaymentGroupParams defines parameters for AuthorizePaymentGroup.
+type AuthorizePaymentGroupParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// CapturePaymentGroupParams defines parameters for CapturePaymentGroup.
+type CapturePaymentGroupParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// VoidPaymentGroupParams defines parameters for VoidPaymentGroup.
+type VoidPaymentGroupParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// ConfirmPaymentIntentParams defines parameters for ConfirmPaymentIntent.
+type ConfirmPaymentIntentParams struct {
+	// XClientToken The client token returned when the intent was created
+	XClientToken string `json:"X-Client-Token"`
+}
+
 // GetPaymentsByCustomerParams defines parameters for GetPaymentsByCustomer.
 type GetPaymentsByCustomerParams struct {
 	// Limit Maximum number of payments to return
@@ -186,6 +1545,65 @@ type GetPaymentsByCustomerParams struct {
 
 	// Offset Number of payments to skip
 	Offset int `form:"offset,omitempty" json:"offset,omitempty,omitzero"`
+
+	// Status Comma-separated statuses to return, such as `CAPTURED,REFUNDED`
+	Status string `form:"status,omitempty" json:"status,omitempty,omitzero"`
+
+	// From Only payments created at or after this time
+	From time.Time `form:"from,omitempty" json:"from,omitempty,omitzero"`
+
+	// To Only payments created before this time
+	To time.Time `form:"to,omitempty" json:"to,omitempty,omitzero"`
+}
+
+// GetPaymentReceiptParams defines parameters for GetPaymentReceipt.
+type GetPaymentReceiptParams struct {
+	// Format json (default) or pdf
+	Format ReceiptFormat `form:"format,omitempty" json:"format,omitempty,omitzero"`
+}
+
+// GetPaymentByIDParams defines parameters for GetPaymentByID.
+type GetPaymentByIDParams struct {
+	// AsOf Return the payment as it stood at this time (RFC 3339)
+	AsOf time.Time `form:"as_of,omitempty" json:"as_of,omitempty,omitzero"`
+
+	// IfNoneMatch ETag of a previous response, or a comma-separated list of them
+	IfNoneMatch string `json:"If-None-Match,omitempty,omitzero"`
+}
+
+// CreateCaptureParams defines parameters for CreateCapture.
+type CreateCaptureParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// CreateReauthorizationParams defines parameters for CreateReauthorization.
+type CreateReauthorizationParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// CreateRefundParams defines parameters for CreateRefund.
+type CreateRefundParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// CreateVoidParams defines parameters for CreateVoid.
+type CreateVoidParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// CreatePayoutParams defines parameters for CreatePayout.
+type CreatePayoutParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
 }
 
 // RefundPaymentParams defines parameters for RefundPayment.
@@ -195,6 +1613,20 @@ type RefundPaymentParams struct {
 	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
 }
 
+// CreateRefundBatchParams defines parameters for CreateRefundBatch.
+type CreateRefundBatchParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
+// SchedulePaymentParams defines parameters for SchedulePayment.
+type SchedulePaymentParams struct {
+	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
+	// returns cached response. Prevents duplicate charges.
+	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
+}
+
 // VoidPaymentParams defines parameters for VoidPayment.
 type VoidPaymentParams struct {
 	// IdempotencyKey Unique key to ensure request idempotency. Same key with same request
@@ -202,14 +1634,92 @@ type VoidPaymentParams struct {
 	IdempotencyKey IdempotencyKey `json:"Idempotency-Key"`
 }
 
+// SetCanaryPercentJSONRequestBody defines body for SetCanaryPercent for application/json ContentType.
+type SetCanaryPercentJSONRequestBody = SetCanaryPercentRequest
+
+// RecordBankRefundsJSONRequestBody defines body for RecordBankRefunds for application/json ContentType.
+type RecordBankRefundsJSONRequestBody = BankRefundsRequest
+
+// AdvanceClockJSONRequestBody defines body for AdvanceClock for application/json ContentType.
+type AdvanceClockJSONRequestBody = AdvanceClockRequest
+
+// CreateDebugSessionJSONRequestBody defines body for CreateDebugSession for application/json ContentType.
+type CreateDebugSessionJSONRequestBody = CreateDebugSessionRequest
+
+// SetFeatureFlagJSONRequestBody defines body for SetFeatureFlag for application/json ContentType.
+type SetFeatureFlagJSONRequestBody = SetFeatureFlagRequest
+
+// SetFeatureFlagOverrideJSONRequestBody defines body for SetFeatureFlagOverride for application/json ContentType.
+type SetFeatureFlagOverrideJSONRequestBody = SetFeatureFlagOverrideRequest
+
+// SetLogLevelJSONRequestBody defines body for SetLogLevel for application/json ContentType.
+type SetLogLevelJSONRequestBody = LogLevel
+
+// SetMerchantQuotaJSONRequestBody defines body for SetMerchantQuota for application/json ContentType.
+type SetMerchantQuotaJSONRequestBody = SetMerchantQuotaRequest
+
+// PauseOutboxJSONRequestBody defines body for PauseOutbox for application/json ContentType.
+type PauseOutboxJSONRequestBody = PauseOutboxRequest
+
+// ReplayPaymentsJSONRequestBody defines body for ReplayPayments for application/json ContentType.
+type ReplayPaymentsJSONRequestBody = ReplayPaymentsRequest
+
+// ReconcileJSONRequestBody defines body for Reconcile for application/json ContentType.
+type ReconcileJSONRequestBody = ReconcileRequest
+
+// CreateVoidBatchJSONRequestBody defines body for CreateVoidBatch for application/json ContentType.
+type CreateVoidBatchJSONRequestBody = CreateVoidBatchRequest
+
 // AuthorizePaymentJSONRequestBody defines body for AuthorizePayment for application/json ContentType.
 type AuthorizePaymentJSONRequestBody = AuthorizeRequest
 
 // CapturePaymentJSONRequestBody defines body for CapturePayment for application/json ContentType.
 type CapturePaymentJSONRequestBody = CaptureRequest
 
+// AuthorizePaymentGroupJSONRequestBody defines body for AuthorizePaymentGroup for application/json ContentType.
+type AuthorizePaymentGroupJSONRequestBody = AuthorizePaymentGroupRequest
+
+// VoidPaymentGroupJSONRequestBody defines body for VoidPaymentGroup for application/json ContentType.
+type VoidPaymentGroupJSONRequestBody = CreateVoidRequest
+
+// CreatePaymentIntentJSONRequestBody defines body for CreatePaymentIntent for application/json ContentType.
+type CreatePaymentIntentJSONRequestBody = CreatePaymentIntentRequest
+
+// ConfirmPaymentIntentJSONRequestBody defines body for ConfirmPaymentIntent for application/json ContentType.
+type ConfirmPaymentIntentJSONRequestBody = ConfirmPaymentIntentRequest
+
+// CreatePaymentMethodJSONRequestBody defines body for CreatePaymentMethod for application/json ContentType.
+type CreatePaymentMethodJSONRequestBody = CreatePaymentMethodRequest
+
+// BatchGetPaymentsJSONRequestBody defines body for BatchGetPayments for application/json ContentType.
+type BatchGetPaymentsJSONRequestBody = BatchGetPaymentsRequest
+
+// CreateCaptureJSONRequestBody defines body for CreateCapture for application/json ContentType.
+type CreateCaptureJSONRequestBody = CreateCaptureRequest
+
+// CreateReauthorizationJSONRequestBody defines body for CreateReauthorization for application/json ContentType.
+type CreateReauthorizationJSONRequestBody = CreateReauthorizationRequest
+
+// CreateRefundJSONRequestBody defines body for CreateRefund for application/json ContentType.
+type CreateRefundJSONRequestBody = CreateRefundRequest
+
+// CreateVoidJSONRequestBody defines body for CreateVoid for application/json ContentType.
+type CreateVoidJSONRequestBody = CreateVoidRequest
+
+// CreatePayoutJSONRequestBody defines body for CreatePayout for application/json ContentType.
+type CreatePayoutJSONRequestBody = CreatePayoutRequest
+
 // RefundPaymentJSONRequestBody defines body for RefundPayment for application/json ContentType.
 type RefundPaymentJSONRequestBody = RefundRequest
 
+// CreateRefundBatchJSONRequestBody defines body for CreateRefundBatch for application/json ContentType.
+type CreateRefundBatchJSONRequestBody = CreateRefundBatchRequest
+
+// SchedulePaymentJSONRequestBody defines body for SchedulePayment for application/json ContentType.
+type SchedulePaymentJSONRequestBody = SchedulePaymentRequest
+
+// CreateSubscriptionJSONRequestBody defines body for CreateSubscription for application/json ContentType.
+type CreateSubscriptionJSONRequestBody = CreateSubscriptionRequest
+
 // VoidPaymentJSONRequestBody defines body for VoidPayment for application/json ContentType.
 type VoidPaymentJSONRequestBody = VoidRequest
@@ -9,6 +9,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/oapi-codegen/runtime"
@@ -18,24 +19,213 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get acquirer routing and success rates
+	// (GET /admin/acquirers)
+	GetAcquirers(w http.ResponseWriter, r *http.Request)
+	// Set the canary percentage
+	// (PUT /admin/acquirers/canary)
+	SetCanaryPercent(w http.ResponseWriter, r *http.Request)
+	// List authorizations about to lapse uncaptured
+	// (GET /admin/authorizations/expiring)
+	GetExpiringAuthorizations(w http.ResponseWriter, r *http.Request, params GetExpiringAuthorizationsParams)
+	// Record refunds the bank made
+	// (POST /admin/bank-refunds)
+	RecordBankRefunds(w http.ResponseWriter, r *http.Request)
+	// Get the test clock
+	// (GET /admin/clock)
+	GetClock(w http.ResponseWriter, r *http.Request)
+	// Advance the test clock
+	// (POST /admin/clock/advance)
+	AdvanceClock(w http.ResponseWriter, r *http.Request)
+	// Erase a customer's personal data
+	// (POST /admin/customers/{customerID}/erasure)
+	EraseCustomer(w http.ResponseWriter, r *http.Request, customerID string)
+	// List payments the retry worker gave up on
+	// (GET /admin/dead-letters)
+	GetDeadLetters(w http.ResponseWriter, r *http.Request, params GetDeadLettersParams)
+	// Requeue a dead-lettered payment
+	// (POST /admin/dead-letters/{paymentID}/requeue)
+	RequeueDeadLetter(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID)
+	// Start a bank traffic debug session
+	// (POST /admin/debug-sessions)
+	CreateDebugSession(w http.ResponseWriter, r *http.Request)
+	// End a debug session
+	// (DELETE /admin/debug-sessions/{sessionID})
+	DeleteDebugSession(w http.ResponseWriter, r *http.Request, sessionID openapi_types.UUID)
+	// Get a debug session and its captures
+	// (GET /admin/debug-sessions/{sessionID})
+	GetDebugSession(w http.ResponseWriter, r *http.Request, sessionID openapi_types.UUID)
+	// List the feature flags
+	// (GET /admin/feature-flags)
+	ListFeatureFlags(w http.ResponseWriter, r *http.Request)
+	// Set a feature flag's rollout
+	// (PUT /admin/feature-flags/{flag})
+	SetFeatureFlag(w http.ResponseWriter, r *http.Request, flag string)
+	// Remove a merchant's feature flag override
+	// (DELETE /admin/feature-flags/{flag}/merchants/{merchantID})
+	DeleteFeatureFlagOverride(w http.ResponseWriter, r *http.Request, flag string, merchantID string)
+	// Override a feature flag for a merchant
+	// (PUT /admin/feature-flags/{flag}/merchants/{merchantID})
+	SetFeatureFlagOverride(w http.ResponseWriter, r *http.Request, flag string, merchantID string)
+	// Get the log level
+	// (GET /admin/log-level)
+	GetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Set the log level
+	// (PUT /admin/log-level)
+	SetLogLevel(w http.ResponseWriter, r *http.Request)
+	// Get a merchant's daily quota
+	// (GET /admin/merchants/{merchantID}/quota)
+	GetMerchantQuota(w http.ResponseWriter, r *http.Request, merchantID string)
+	// Set a merchant's daily quota
+	// (PUT /admin/merchants/{merchantID}/quota)
+	SetMerchantQuota(w http.ResponseWriter, r *http.Request, merchantID string)
+	// Get the outbox backlog
+	// (GET /admin/outbox)
+	GetOutbox(w http.ResponseWriter, r *http.Request)
+	// Pause delivery of transition events
+	// (POST /admin/outbox/pause)
+	PauseOutbox(w http.ResponseWriter, r *http.Request)
+	// Resume delivery of transition events
+	// (POST /admin/outbox/resume)
+	ResumeOutbox(w http.ResponseWriter, r *http.Request)
+	// Replay payments stuck mid-transition
+	// (POST /admin/reconcile)
+	ReplayPayments(w http.ResponseWriter, r *http.Request)
+	// List reconciliation issues
+	// (GET /admin/reconciliation-issues)
+	GetReconciliationIssues(w http.ResponseWriter, r *http.Request, params GetReconciliationIssuesParams)
+	// Reconcile with the bank
+	// (POST /admin/reconciliations)
+	Reconcile(w http.ResponseWriter, r *http.Request)
+	// List refunds waiting for approval
+	// (GET /admin/refund-approvals)
+	GetRefundApprovals(w http.ResponseWriter, r *http.Request, params GetRefundApprovalsParams)
+	// Approve a refund
+	// (POST /admin/refunds/{refundID}/approve)
+	ApproveRefund(w http.ResponseWriter, r *http.Request, refundID openapi_types.UUID)
+	// Reject a refund
+	// (POST /admin/refunds/{refundID}/reject)
+	RejectRefund(w http.ResponseWriter, r *http.Request, refundID openapi_types.UUID)
+	// Get this region's standing
+	// (GET /admin/region)
+	GetRegion(w http.ResponseWriter, r *http.Request)
+	// Promote this region
+	// (POST /admin/region/promote)
+	PromoteRegion(w http.ResponseWriter, r *http.Request)
+	// List payments waiting for review
+	// (GET /admin/reviews)
+	GetReviews(w http.ResponseWriter, r *http.Request, params GetReviewsParams)
+	// Approve a payment in review
+	// (POST /admin/reviews/{paymentID}/approve)
+	ApproveReview(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID)
+	// Decline a payment in review
+	// (POST /admin/reviews/{paymentID}/decline)
+	DeclineReview(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID)
+	// Void abandoned orders in bulk
+	// (POST /admin/voids/batch)
+	CreateVoidBatch(w http.ResponseWriter, r *http.Request, params CreateVoidBatchParams)
 	// Authorize Payment
 	// (POST /authorize)
 	AuthorizePayment(w http.ResponseWriter, r *http.Request, params AuthorizePaymentParams)
+	// Get a batch
+	// (GET /batches/{batchID})
+	GetBatch(w http.ResponseWriter, r *http.Request, batchID openapi_types.UUID)
 	// Capture Payment
 	// (POST /capture)
 	CapturePayment(w http.ResponseWriter, r *http.Request, params CapturePaymentParams)
+	// Customer Payment Summary
+	// (GET /customers/{customerID}/payment-summary)
+	GetCustomerPaymentSummary(w http.ResponseWriter, r *http.Request, customerID string)
+	// Get Operation by ID
+	// (GET /operations/{operationID})
+	GetOperationByID(w http.ResponseWriter, r *http.Request, operationID openapi_types.UUID)
+	// Authorize a payment split across cards
+	// (POST /payment-groups)
+	AuthorizePaymentGroup(w http.ResponseWriter, r *http.Request, params AuthorizePaymentGroupParams)
+	// Get a payment group
+	// (GET /payment-groups/{groupID})
+	GetPaymentGroup(w http.ResponseWriter, r *http.Request, groupID openapi_types.UUID)
+	// Capture a payment group
+	// (POST /payment-groups/{groupID}/captures)
+	CapturePaymentGroup(w http.ResponseWriter, r *http.Request, groupID openapi_types.UUID, params CapturePaymentGroupParams)
+	// Void a payment group
+	// (POST /payment-groups/{groupID}/voids)
+	VoidPaymentGroup(w http.ResponseWriter, r *http.Request, groupID openapi_types.UUID, params VoidPaymentGroupParams)
+	// Create a payment intent
+	// (POST /payment-intents)
+	CreatePaymentIntent(w http.ResponseWriter, r *http.Request)
+	// Get a payment intent
+	// (GET /payment-intents/{intentID})
+	GetPaymentIntent(w http.ResponseWriter, r *http.Request, intentID openapi_types.UUID)
+	// Confirm a payment intent
+	// (POST /payment-intents/{intentID}/confirm)
+	ConfirmPaymentIntent(w http.ResponseWriter, r *http.Request, intentID openapi_types.UUID, params ConfirmPaymentIntentParams)
+	// Save a payment method
+	// (POST /payment-methods)
+	CreatePaymentMethod(w http.ResponseWriter, r *http.Request)
+	// Get a payment method
+	// (GET /payment-methods/{paymentMethodID})
+	GetPaymentMethod(w http.ResponseWriter, r *http.Request, paymentMethodID openapi_types.UUID)
+	// Get Payments by ID
+	// (POST /payments/batch-get)
+	BatchGetPayments(w http.ResponseWriter, r *http.Request)
+	// Get Payment by Idempotency Key
+	// (GET /payments/by-idempotency-key/{idempotencyKey})
+	GetPaymentByIdempotencyKey(w http.ResponseWriter, r *http.Request, idempotencyKey string)
 	// List Customer Payments
 	// (GET /payments/customer/{customerID})
 	GetPaymentsByCustomer(w http.ResponseWriter, r *http.Request, customerID string, params GetPaymentsByCustomerParams)
+	// Stream Payment Status
+	// (GET /payments/events/{paymentID})
+	GetPaymentEvents(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID)
 	// Get Payment by Order ID
 	// (GET /payments/order/{orderID})
 	GetPaymentByOrder(w http.ResponseWriter, r *http.Request, orderID string)
+	// Get Payment Receipt
+	// (GET /payments/receipts/{paymentID})
+	GetPaymentReceipt(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params GetPaymentReceiptParams)
 	// Get Payment by ID
 	// (GET /payments/{paymentID})
-	GetPaymentByID(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID)
+	GetPaymentByID(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params GetPaymentByIDParams)
+	// Create Capture
+	// (POST /payments/{paymentID}/captures)
+	CreateCapture(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateCaptureParams)
+	// Create Reauthorization
+	// (POST /payments/{paymentID}/reauthorizations)
+	CreateReauthorization(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateReauthorizationParams)
+	// Create Refund
+	// (POST /payments/{paymentID}/refunds)
+	CreateRefund(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateRefundParams)
+	// Create Void
+	// (POST /payments/{paymentID}/voids)
+	CreateVoid(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateVoidParams)
+	// Create a payout
+	// (POST /payouts)
+	CreatePayout(w http.ResponseWriter, r *http.Request, params CreatePayoutParams)
+	// Get a payout
+	// (GET /payouts/{payoutID})
+	GetPayout(w http.ResponseWriter, r *http.Request, payoutID openapi_types.UUID)
 	// Refund Payment
 	// (POST /refund)
 	RefundPayment(w http.ResponseWriter, r *http.Request, params RefundPaymentParams)
+	// Refund many payments
+	// (POST /refunds/batch)
+	CreateRefundBatch(w http.ResponseWriter, r *http.Request, params CreateRefundBatchParams)
+	// Get Refund by ID
+	// (GET /refunds/{refundID})
+	GetRefundByID(w http.ResponseWriter, r *http.Request, refundID openapi_types.UUID)
+	// Schedule a payment
+	// (POST /scheduled-payments)
+	SchedulePayment(w http.ResponseWriter, r *http.Request, params SchedulePaymentParams)
+	// Create a subscription
+	// (POST /subscriptions)
+	CreateSubscription(w http.ResponseWriter, r *http.Request)
+	// Get a subscription
+	// (GET /subscriptions/{subscriptionID})
+	GetSubscription(w http.ResponseWriter, r *http.Request, subscriptionID openapi_types.UUID)
+	// Cancel a subscription
+	// (POST /subscriptions/{subscriptionID}/cancel)
+	CancelSubscription(w http.ResponseWriter, r *http.Request, subscriptionID openapi_types.UUID)
 	// Void Authorization
 	// (POST /void)
 	VoidPayment(w http.ResponseWriter, r *http.Request, params VoidPaymentParams)
@@ -50,41 +240,60 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// AuthorizePayment operation middleware
-func (siw *ServerInterfaceWrapper) AuthorizePayment(w http.ResponseWriter, r *http.Request) {
+// GetAcquirers operation middleware
+func (siw *ServerInterfaceWrapper) GetAcquirers(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAcquirers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetCanaryPercent operation middleware
+func (siw *ServerInterfaceWrapper) SetCanaryPercent(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetCanaryPercent(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetExpiringAuthorizations operation middleware
+func (siw *ServerInterfaceWrapper) GetExpiringAuthorizations(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params AuthorizePaymentParams
-
-	headers := r.Header
+	var params GetExpiringAuthorizationsParams
 
-	// ------------- Required header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
+	// ------------- Optional query parameter "within_hours" -------------
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
+	err = runtime.BindQueryParameter("form", true, false, "within_hours", r.URL.Query(), &params.WithinHours)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "within_hours", Err: err})
+		return
+	}
 
-		params.IdempotencyKey = IdempotencyKey
+	// ------------- Optional query parameter "limit" -------------
 
-	} else {
-		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.AuthorizePayment(w, r, params)
+		siw.Handler.GetExpiringAuthorizations(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -94,41 +303,39 @@ func (siw *ServerInterfaceWrapper) AuthorizePayment(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r)
 }
 
-// CapturePayment operation middleware
-func (siw *ServerInterfaceWrapper) CapturePayment(w http.ResponseWriter, r *http.Request) {
-
-	var err error
+// RecordBankRefunds operation middleware
+func (siw *ServerInterfaceWrapper) RecordBankRefunds(w http.ResponseWriter, r *http.Request) {
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params CapturePaymentParams
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RecordBankRefunds(w, r)
+	}))
 
-	headers := r.Header
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// ------------- Required header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
+	handler.ServeHTTP(w, r)
+}
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
+// GetClock operation middleware
+func (siw *ServerInterfaceWrapper) GetClock(w http.ResponseWriter, r *http.Request) {
 
-		params.IdempotencyKey = IdempotencyKey
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetClock(w, r)
+	}))
 
-	} else {
-		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
+	handler.ServeHTTP(w, r)
+}
+
+// AdvanceClock operation middleware
+func (siw *ServerInterfaceWrapper) AdvanceClock(w http.ResponseWriter, r *http.Request) {
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CapturePayment(w, r, params)
+		siw.Handler.AdvanceClock(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -138,8 +345,8 @@ func (siw *ServerInterfaceWrapper) CapturePayment(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// GetPaymentsByCustomer operation middleware
-func (siw *ServerInterfaceWrapper) GetPaymentsByCustomer(w http.ResponseWriter, r *http.Request) {
+// EraseCustomer operation middleware
+func (siw *ServerInterfaceWrapper) EraseCustomer(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -152,8 +359,24 @@ func (siw *ServerInterfaceWrapper) GetPaymentsByCustomer(w http.ResponseWriter,
 		return
 	}
 
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EraseCustomer(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDeadLetters operation middleware
+func (siw *ServerInterfaceWrapper) GetDeadLetters(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetPaymentsByCustomerParams
+	var params GetDeadLettersParams
 
 	// ------------- Optional query parameter "limit" -------------
 
@@ -163,16 +386,33 @@ func (siw *ServerInterfaceWrapper) GetPaymentsByCustomer(w http.ResponseWriter,
 		return
 	}
 
-	// ------------- Optional query parameter "offset" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDeadLetters(w, r, params)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RequeueDeadLetter operation middleware
+func (siw *ServerInterfaceWrapper) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPaymentsByCustomer(w, r, customerID, params)
+		siw.Handler.RequeueDeadLetter(w, r, paymentID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -182,22 +422,36 @@ func (siw *ServerInterfaceWrapper) GetPaymentsByCustomer(w http.ResponseWriter,
 	handler.ServeHTTP(w, r)
 }
 
-// GetPaymentByOrder operation middleware
-func (siw *ServerInterfaceWrapper) GetPaymentByOrder(w http.ResponseWriter, r *http.Request) {
+// CreateDebugSession operation middleware
+func (siw *ServerInterfaceWrapper) CreateDebugSession(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateDebugSession(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDebugSession operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDebugSession(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "orderID" -------------
-	var orderID string
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "orderID", r.PathValue("orderID"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionID", r.PathValue("sessionID"), &sessionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderID", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPaymentByOrder(w, r, orderID)
+		siw.Handler.DeleteDebugSession(w, r, sessionID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -207,22 +461,22 @@ func (siw *ServerInterfaceWrapper) GetPaymentByOrder(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// GetPaymentByID operation middleware
-func (siw *ServerInterfaceWrapper) GetPaymentByID(w http.ResponseWriter, r *http.Request) {
+// GetDebugSession operation middleware
+func (siw *ServerInterfaceWrapper) GetDebugSession(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// ------------- Path parameter "paymentID" -------------
-	var paymentID openapi_types.UUID
+	// ------------- Path parameter "sessionID" -------------
+	var sessionID openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionID", r.PathValue("sessionID"), &sessionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetPaymentByID(w, r, paymentID)
+		siw.Handler.GetDebugSession(w, r, sessionID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -232,41 +486,36 @@ func (siw *ServerInterfaceWrapper) GetPaymentByID(w http.ResponseWriter, r *http
 	handler.ServeHTTP(w, r)
 }
 
-// RefundPayment operation middleware
-func (siw *ServerInterfaceWrapper) RefundPayment(w http.ResponseWriter, r *http.Request) {
+// ListFeatureFlags operation middleware
+func (siw *ServerInterfaceWrapper) ListFeatureFlags(w http.ResponseWriter, r *http.Request) {
 
-	var err error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListFeatureFlags(w, r)
+	}))
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params RefundPaymentParams
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	headers := r.Header
+	handler.ServeHTTP(w, r)
+}
 
-	// ------------- Required header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
+// SetFeatureFlag operation middleware
+func (siw *ServerInterfaceWrapper) SetFeatureFlag(w http.ResponseWriter, r *http.Request) {
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
+	var err error
 
-		params.IdempotencyKey = IdempotencyKey
+	// ------------- Path parameter "flag" -------------
+	var flag string
 
-	} else {
-		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+	err = runtime.BindStyledParameterWithOptions("simple", "flag", r.PathValue("flag"), &flag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flag", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RefundPayment(w, r, params)
+		siw.Handler.SetFeatureFlag(w, r, flag)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -276,41 +525,31 @@ func (siw *ServerInterfaceWrapper) RefundPayment(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r)
 }
 
-// VoidPayment operation middleware
-func (siw *ServerInterfaceWrapper) VoidPayment(w http.ResponseWriter, r *http.Request) {
+// DeleteFeatureFlagOverride operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFeatureFlagOverride(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params VoidPaymentParams
-
-	headers := r.Header
-
-	// ------------- Required header parameter "Idempotency-Key" -------------
-	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
-		var IdempotencyKey IdempotencyKey
-		n := len(valueList)
-		if n != 1 {
-			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
-			return
-		}
+	// ------------- Path parameter "flag" -------------
+	var flag string
 
-		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
-		if err != nil {
-			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
-			return
-		}
+	err = runtime.BindStyledParameterWithOptions("simple", "flag", r.PathValue("flag"), &flag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flag", Err: err})
+		return
+	}
 
-		params.IdempotencyKey = IdempotencyKey
+	// ------------- Path parameter "merchantID" -------------
+	var merchantID string
 
-	} else {
-		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
-		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+	err = runtime.BindStyledParameterWithOptions("simple", "merchantID", r.PathValue("merchantID"), &merchantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchantID", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.VoidPayment(w, r, params)
+		siw.Handler.DeleteFeatureFlagOverride(w, r, flag, merchantID)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -320,550 +559,6930 @@ func (siw *ServerInterfaceWrapper) VoidPayment(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+// SetFeatureFlagOverride operation middleware
+func (siw *ServerInterfaceWrapper) SetFeatureFlagOverride(w http.ResponseWriter, r *http.Request) {
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+	var err error
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
-}
+	// ------------- Path parameter "flag" -------------
+	var flag string
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "flag", r.PathValue("flag"), &flag, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "flag", Err: err})
+		return
+	}
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+	// ------------- Path parameter "merchantID" -------------
+	var merchantID string
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "merchantID", r.PathValue("merchantID"), &merchantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchantID", Err: err})
+		return
+	}
 
-type RequiredParamError struct {
-	ParamName string
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetFeatureFlagOverride(w, r, flag, merchantID)
+	}))
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
-}
-
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+// GetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevel(w http.ResponseWriter, r *http.Request) {
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogLevel(w, r)
+	}))
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+	handler.ServeHTTP(w, r)
 }
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+// SetLogLevel operation middleware
+func (siw *ServerInterfaceWrapper) SetLogLevel(w http.ResponseWriter, r *http.Request) {
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetLogLevel(w, r)
+	}))
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
+	handler.ServeHTTP(w, r)
 }
 
-// ServeMux is an abstraction of http.ServeMux.
-type ServeMux interface {
-	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
-	ServeHTTP(w http.ResponseWriter, r *http.Request)
-}
+// GetMerchantQuota operation middleware
+func (siw *ServerInterfaceWrapper) GetMerchantQuota(w http.ResponseWriter, r *http.Request) {
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	var err error
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
-}
+	// ------------- Path parameter "merchantID" -------------
+	var merchantID string
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "merchantID", r.PathValue("merchantID"), &merchantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchantID", Err: err})
+		return
+	}
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetMerchantQuota(w, r, merchantID)
+	}))
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	handler.ServeHTTP(w, r)
+}
 
-	m.HandleFunc("POST "+options.BaseURL+"/authorize", wrapper.AuthorizePayment)
-	m.HandleFunc("POST "+options.BaseURL+"/capture", wrapper.CapturePayment)
-	m.HandleFunc("GET "+options.BaseURL+"/payments/customer/{customerID}", wrapper.GetPaymentsByCustomer)
-	m.HandleFunc("GET "+options.BaseURL+"/payments/order/{orderID}", wrapper.GetPaymentByOrder)
-	m.HandleFunc("GET "+options.BaseURL+"/payments/{paymentID}", wrapper.GetPaymentByID)
-	m.HandleFunc("POST "+options.BaseURL+"/refund", wrapper.RefundPayment)
-	m.HandleFunc("POST "+options.BaseURL+"/void", wrapper.VoidPayment)
+// SetMerchantQuota operation middleware
+func (siw *ServerInterfaceWrapper) SetMerchantQuota(w http.ResponseWriter, r *http.Request) {
 
-	return m
-}
+	var err error
 
-type AuthorizePaymentRequestObject struct {
-	Params AuthorizePaymentParams
-	Body   *AuthorizePaymentJSONRequestBody
-}
+	// ------------- Path parameter "merchantID" -------------
+	var merchantID string
 
-type AuthorizePaymentResponseObject interface {
-	VisitAuthorizePaymentResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "merchantID", r.PathValue("merchantID"), &merchantID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchantID", Err: err})
+		return
+	}
 
-type AuthorizePayment201JSONResponse PaymentResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetMerchantQuota(w, r, merchantID)
+	}))
 
-func (response AuthorizePayment201JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type AuthorizePayment400JSONResponse ErrorResponse
+// GetOutbox operation middleware
+func (siw *ServerInterfaceWrapper) GetOutbox(w http.ResponseWriter, r *http.Request) {
 
-func (response AuthorizePayment400JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOutbox(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AuthorizePayment408JSONResponse ErrorResponse
+// PauseOutbox operation middleware
+func (siw *ServerInterfaceWrapper) PauseOutbox(w http.ResponseWriter, r *http.Request) {
 
-func (response AuthorizePayment408JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(408)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PauseOutbox(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AuthorizePayment409JSONResponse ErrorResponse
+// ResumeOutbox operation middleware
+func (siw *ServerInterfaceWrapper) ResumeOutbox(w http.ResponseWriter, r *http.Request) {
 
-func (response AuthorizePayment409JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ResumeOutbox(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type AuthorizePayment500JSONResponse ErrorResponse
+// ReplayPayments operation middleware
+func (siw *ServerInterfaceWrapper) ReplayPayments(w http.ResponseWriter, r *http.Request) {
 
-func (response AuthorizePayment500JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayPayments(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type CapturePaymentRequestObject struct {
-	Params CapturePaymentParams
-	Body   *CapturePaymentJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type CapturePaymentResponseObject interface {
-	VisitCapturePaymentResponse(w http.ResponseWriter) error
-}
+// GetReconciliationIssues operation middleware
+func (siw *ServerInterfaceWrapper) GetReconciliationIssues(w http.ResponseWriter, r *http.Request) {
 
-type CapturePayment200JSONResponse PaymentResponse
+	var err error
 
-func (response CapturePayment200JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReconciliationIssuesParams
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Required query parameter "since" -------------
 
-type CapturePayment400JSONResponse ErrorResponse
+	err = runtime.BindQueryParameter("form", true, true, "since", r.URL.Query(), &params.Since)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "since", Err: err})
+		return
+	}
 
-func (response CapturePayment400JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	// ------------- Optional query parameter "limit" -------------
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-type CapturePayment404JSONResponse ErrorResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReconciliationIssues(w, r, params)
+	}))
 
-func (response CapturePayment404JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type CapturePayment408JSONResponse ErrorResponse
+// Reconcile operation middleware
+func (siw *ServerInterfaceWrapper) Reconcile(w http.ResponseWriter, r *http.Request) {
 
-func (response CapturePayment408JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(408)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Reconcile(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type CapturePayment409JSONResponse ErrorResponse
+// GetRefundApprovals operation middleware
+func (siw *ServerInterfaceWrapper) GetRefundApprovals(w http.ResponseWriter, r *http.Request) {
 
-func (response CapturePayment409JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRefundApprovalsParams
 
-type CapturePayment500JSONResponse ErrorResponse
+	// ------------- Optional query parameter "limit" -------------
 
-func (response CapturePayment500JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRefundApprovals(w, r, params)
+	}))
 
-type GetPaymentsByCustomerRequestObject struct {
-	CustomerID string `json:"customerID"`
-	Params     GetPaymentsByCustomerParams
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type GetPaymentsByCustomerResponseObject interface {
-	VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error
+	handler.ServeHTTP(w, r)
 }
 
-type GetPaymentsByCustomer200JSONResponse struct {
-	Data    []Payment `json:"data,omitempty,omitzero"`
-	Success bool      `json:"success,omitempty,omitzero"`
-}
+// ApproveRefund operation middleware
+func (siw *ServerInterfaceWrapper) ApproveRefund(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPaymentsByCustomer200JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "refundID" -------------
+	var refundID openapi_types.UUID
 
-type GetPaymentsByCustomer404JSONResponse ErrorResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "refundID", r.PathValue("refundID"), &refundID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundID", Err: err})
+		return
+	}
 
-func (response GetPaymentsByCustomer404JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveRefund(w, r, refundID)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetPaymentsByCustomer500JSONResponse ErrorResponse
+// RejectRefund operation middleware
+func (siw *ServerInterfaceWrapper) RejectRefund(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPaymentsByCustomer500JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "refundID" -------------
+	var refundID openapi_types.UUID
 
-type GetPaymentByOrderRequestObject struct {
-	OrderID string `json:"orderID"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "refundID", r.PathValue("refundID"), &refundID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundID", Err: err})
+		return
+	}
 
-type GetPaymentByOrderResponseObject interface {
-	VisitGetPaymentByOrderResponse(w http.ResponseWriter) error
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RejectRefund(w, r, refundID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetPaymentByOrder200JSONResponse PaymentResponse
+// GetRegion operation middleware
+func (siw *ServerInterfaceWrapper) GetRegion(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPaymentByOrder200JSONResponse) VisitGetPaymentByOrderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRegion(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetPaymentByOrder404JSONResponse ErrorResponse
+// PromoteRegion operation middleware
+func (siw *ServerInterfaceWrapper) PromoteRegion(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPaymentByOrder404JSONResponse) VisitGetPaymentByOrderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PromoteRegion(w, r)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type GetPaymentByOrder500JSONResponse ErrorResponse
+// GetReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetReviews(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPaymentByOrder500JSONResponse) VisitGetPaymentByOrderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsParams
 
-type GetPaymentByIDRequestObject struct {
-	PaymentID openapi_types.UUID `json:"paymentID"`
-}
+	// ------------- Optional query parameter "limit" -------------
 
-type GetPaymentByIDResponseObject interface {
-	VisitGetPaymentByIDResponse(w http.ResponseWriter) error
-}
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
-type GetPaymentByID200JSONResponse PaymentResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReviews(w, r, params)
+	}))
 
-func (response GetPaymentByID200JSONResponse) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type GetPaymentByID404JSONResponse ErrorResponse
+// ApproveReview operation middleware
+func (siw *ServerInterfaceWrapper) ApproveReview(w http.ResponseWriter, r *http.Request) {
 
-func (response GetPaymentByID404JSONResponse) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
 
-type GetPaymentByID500JSONResponse ErrorResponse
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
 
-func (response GetPaymentByID500JSONResponse) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ApproveReview(w, r, paymentID)
+	}))
 
-	return json.NewEncoder(w).Encode(response)
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-type RefundPaymentRequestObject struct {
-	Params RefundPaymentParams
-	Body   *RefundPaymentJSONRequestBody
+	handler.ServeHTTP(w, r)
 }
 
-type RefundPaymentResponseObject interface {
-	VisitRefundPaymentResponse(w http.ResponseWriter) error
-}
+// DeclineReview operation middleware
+func (siw *ServerInterfaceWrapper) DeclineReview(w http.ResponseWriter, r *http.Request) {
 
-type RefundPayment200JSONResponse PaymentResponse
+	var err error
 
-func (response RefundPayment200JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
 
-	return json.NewEncoder(w).Encode(response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
 
-type RefundPayment400JSONResponse ErrorResponse
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeclineReview(w, r, paymentID)
+	}))
 
-func (response RefundPayment400JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler.ServeHTTP(w, r)
 }
 
-type RefundPayment404JSONResponse ErrorResponse
+// CreateVoidBatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateVoidBatch(w http.ResponseWriter, r *http.Request) {
 
-func (response RefundPayment404JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateVoidBatchParams
 
-type RefundPayment408JSONResponse ErrorResponse
+	headers := r.Header
 
-func (response RefundPayment408JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(408)
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
 
-	return json.NewEncoder(w).Encode(response)
-}
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
 
-type RefundPayment409JSONResponse ErrorResponse
+		params.IdempotencyKey = IdempotencyKey
 
-func (response RefundPayment409JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateVoidBatch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type RefundPayment500JSONResponse ErrorResponse
+// AuthorizePayment operation middleware
+func (siw *ServerInterfaceWrapper) AuthorizePayment(w http.ResponseWriter, r *http.Request) {
 
-func (response RefundPayment500JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var err error
 
-	return json.NewEncoder(w).Encode(response)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthorizePaymentParams
+
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", r.URL.Query(), &params.Async)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "async", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AuthorizePayment(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
 }
 
-type VoidPaymentRequestObject struct {
-	Params VoidPaymentParams
-	Body   *VoidPaymentJSONRequestBody
-}
+// GetBatch operation middleware
+func (siw *ServerInterfaceWrapper) GetBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "batchID" -------------
+	var batchID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "batchID", r.PathValue("batchID"), &batchID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "batchID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetBatch(w, r, batchID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CapturePayment operation middleware
+func (siw *ServerInterfaceWrapper) CapturePayment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CapturePaymentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CapturePayment(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetCustomerPaymentSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetCustomerPaymentSummary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customerID" -------------
+	var customerID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customerID", r.PathValue("customerID"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetCustomerPaymentSummary(w, r, customerID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetOperationByID operation middleware
+func (siw *ServerInterfaceWrapper) GetOperationByID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "operationID" -------------
+	var operationID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "operationID", r.PathValue("operationID"), &operationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "operationID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetOperationByID(w, r, operationID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AuthorizePaymentGroup operation middleware
+func (siw *ServerInterfaceWrapper) AuthorizePaymentGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuthorizePaymentGroupParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AuthorizePaymentGroup(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentGroup operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupID" -------------
+	var groupID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupID", r.PathValue("groupID"), &groupID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentGroup(w, r, groupID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CapturePaymentGroup operation middleware
+func (siw *ServerInterfaceWrapper) CapturePaymentGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupID" -------------
+	var groupID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupID", r.PathValue("groupID"), &groupID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CapturePaymentGroupParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CapturePaymentGroup(w, r, groupID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VoidPaymentGroup operation middleware
+func (siw *ServerInterfaceWrapper) VoidPaymentGroup(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "groupID" -------------
+	var groupID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "groupID", r.PathValue("groupID"), &groupID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "groupID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params VoidPaymentGroupParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VoidPaymentGroup(w, r, groupID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePaymentIntent operation middleware
+func (siw *ServerInterfaceWrapper) CreatePaymentIntent(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePaymentIntent(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentIntent operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentIntent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "intentID" -------------
+	var intentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "intentID", r.PathValue("intentID"), &intentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "intentID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentIntent(w, r, intentID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ConfirmPaymentIntent operation middleware
+func (siw *ServerInterfaceWrapper) ConfirmPaymentIntent(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "intentID" -------------
+	var intentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "intentID", r.PathValue("intentID"), &intentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "intentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ConfirmPaymentIntentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "X-Client-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Client-Token")]; found {
+		var XClientToken string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "X-Client-Token", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Client-Token", valueList[0], &XClientToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "X-Client-Token", Err: err})
+			return
+		}
+
+		params.XClientToken = XClientToken
+
+	} else {
+		err := fmt.Errorf("Header parameter X-Client-Token is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "X-Client-Token", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ConfirmPaymentIntent(w, r, intentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePaymentMethod operation middleware
+func (siw *ServerInterfaceWrapper) CreatePaymentMethod(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePaymentMethod(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentMethod operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentMethod(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentMethodID" -------------
+	var paymentMethodID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentMethodID", r.PathValue("paymentMethodID"), &paymentMethodID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentMethodID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentMethod(w, r, paymentMethodID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// BatchGetPayments operation middleware
+func (siw *ServerInterfaceWrapper) BatchGetPayments(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BatchGetPayments(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentByIdempotencyKey operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentByIdempotencyKey(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "idempotencyKey" -------------
+	var idempotencyKey string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "idempotencyKey", r.PathValue("idempotencyKey"), &idempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "idempotencyKey", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentByIdempotencyKey(w, r, idempotencyKey)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentsByCustomer operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentsByCustomer(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "customerID" -------------
+	var customerID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "customerID", r.PathValue("customerID"), &customerID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "customerID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPaymentsByCustomerParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", r.URL.Query(), &params.Offset)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentsByCustomer(w, r, customerID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentEvents(w, r, paymentID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentByOrder operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentByOrder(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "orderID" -------------
+	var orderID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderID", r.PathValue("orderID"), &orderID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "orderID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentByOrder(w, r, orderID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentReceipt operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentReceipt(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPaymentReceiptParams
+
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", r.URL.Query(), &params.Format)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "format", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentReceipt(w, r, paymentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPaymentByID operation middleware
+func (siw *ServerInterfaceWrapper) GetPaymentByID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPaymentByIDParams
+
+	// ------------- Optional query parameter "as_of" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "as_of", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "as_of", Err: err})
+		return
+	}
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-None-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-None-Match", Err: err})
+			return
+		}
+
+		params.IfNoneMatch = IfNoneMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPaymentByID(w, r, paymentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateCapture operation middleware
+func (siw *ServerInterfaceWrapper) CreateCapture(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateCaptureParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateCapture(w, r, paymentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateReauthorization operation middleware
+func (siw *ServerInterfaceWrapper) CreateReauthorization(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateReauthorizationParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateReauthorization(w, r, paymentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateRefund operation middleware
+func (siw *ServerInterfaceWrapper) CreateRefund(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateRefundParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRefund(w, r, paymentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateVoid operation middleware
+func (siw *ServerInterfaceWrapper) CreateVoid(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "paymentID" -------------
+	var paymentID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "paymentID", r.PathValue("paymentID"), &paymentID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "paymentID", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateVoidParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateVoid(w, r, paymentID, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreatePayout operation middleware
+func (siw *ServerInterfaceWrapper) CreatePayout(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreatePayoutParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreatePayout(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetPayout operation middleware
+func (siw *ServerInterfaceWrapper) GetPayout(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "payoutID" -------------
+	var payoutID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "payoutID", r.PathValue("payoutID"), &payoutID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "payoutID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetPayout(w, r, payoutID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RefundPayment operation middleware
+func (siw *ServerInterfaceWrapper) RefundPayment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RefundPaymentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RefundPayment(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateRefundBatch operation middleware
+func (siw *ServerInterfaceWrapper) CreateRefundBatch(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateRefundBatchParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateRefundBatch(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetRefundByID operation middleware
+func (siw *ServerInterfaceWrapper) GetRefundByID(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "refundID" -------------
+	var refundID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "refundID", r.PathValue("refundID"), &refundID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRefundByID(w, r, refundID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SchedulePayment operation middleware
+func (siw *ServerInterfaceWrapper) SchedulePayment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SchedulePaymentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SchedulePayment(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateSubscription(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSubscription operation middleware
+func (siw *ServerInterfaceWrapper) GetSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "subscriptionID" -------------
+	var subscriptionID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionID", r.PathValue("subscriptionID"), &subscriptionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSubscription(w, r, subscriptionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CancelSubscription operation middleware
+func (siw *ServerInterfaceWrapper) CancelSubscription(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "subscriptionID" -------------
+	var subscriptionID openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subscriptionID", r.PathValue("subscriptionID"), &subscriptionID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "subscriptionID", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelSubscription(w, r, subscriptionID)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// VoidPayment operation middleware
+func (siw *ServerInterfaceWrapper) VoidPayment(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params VoidPaymentParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = IdempotencyKey
+
+	} else {
+		err := fmt.Errorf("Header parameter Idempotency-Key is required, but not found")
+		siw.ErrorHandlerFunc(w, r, &RequiredHeaderError{ParamName: "Idempotency-Key", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.VoidPayment(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/admin/acquirers", wrapper.GetAcquirers)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/acquirers/canary", wrapper.SetCanaryPercent)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/authorizations/expiring", wrapper.GetExpiringAuthorizations)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/bank-refunds", wrapper.RecordBankRefunds)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/clock", wrapper.GetClock)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/clock/advance", wrapper.AdvanceClock)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/customers/{customerID}/erasure", wrapper.EraseCustomer)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/dead-letters", wrapper.GetDeadLetters)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/dead-letters/{paymentID}/requeue", wrapper.RequeueDeadLetter)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/debug-sessions", wrapper.CreateDebugSession)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/debug-sessions/{sessionID}", wrapper.DeleteDebugSession)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/debug-sessions/{sessionID}", wrapper.GetDebugSession)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/feature-flags", wrapper.ListFeatureFlags)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/feature-flags/{flag}", wrapper.SetFeatureFlag)
+	m.HandleFunc("DELETE "+options.BaseURL+"/admin/feature-flags/{flag}/merchants/{merchantID}", wrapper.DeleteFeatureFlagOverride)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/feature-flags/{flag}/merchants/{merchantID}", wrapper.SetFeatureFlagOverride)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/log-level", wrapper.GetLogLevel)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/log-level", wrapper.SetLogLevel)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/merchants/{merchantID}/quota", wrapper.GetMerchantQuota)
+	m.HandleFunc("PUT "+options.BaseURL+"/admin/merchants/{merchantID}/quota", wrapper.SetMerchantQuota)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/outbox", wrapper.GetOutbox)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/outbox/pause", wrapper.PauseOutbox)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/outbox/resume", wrapper.ResumeOutbox)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/reconcile", wrapper.ReplayPayments)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/reconciliation-issues", wrapper.GetReconciliationIssues)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/reconciliations", wrapper.Reconcile)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/refund-approvals", wrapper.GetRefundApprovals)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/refunds/{refundID}/approve", wrapper.ApproveRefund)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/refunds/{refundID}/reject", wrapper.RejectRefund)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/region", wrapper.GetRegion)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/region/promote", wrapper.PromoteRegion)
+	m.HandleFunc("GET "+options.BaseURL+"/admin/reviews", wrapper.GetReviews)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/reviews/{paymentID}/approve", wrapper.ApproveReview)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/reviews/{paymentID}/decline", wrapper.DeclineReview)
+	m.HandleFunc("POST "+options.BaseURL+"/admin/voids/batch", wrapper.CreateVoidBatch)
+	m.HandleFunc("POST "+options.BaseURL+"/authorize", wrapper.AuthorizePayment)
+	m.HandleFunc("GET "+options.BaseURL+"/batches/{batchID}", wrapper.GetBatch)
+	m.HandleFunc("POST "+options.BaseURL+"/capture", wrapper.CapturePayment)
+	m.HandleFunc("GET "+options.BaseURL+"/customers/{customerID}/payment-summary", wrapper.GetCustomerPaymentSummary)
+	m.HandleFunc("GET "+options.BaseURL+"/operations/{operationID}", wrapper.GetOperationByID)
+	m.HandleFunc("POST "+options.BaseURL+"/payment-groups", wrapper.AuthorizePaymentGroup)
+	m.HandleFunc("GET "+options.BaseURL+"/payment-groups/{groupID}", wrapper.GetPaymentGroup)
+	m.HandleFunc("POST "+options.BaseURL+"/payment-groups/{groupID}/captures", wrapper.CapturePaymentGroup)
+	m.HandleFunc("POST "+options.BaseURL+"/payment-groups/{groupID}/voids", wrapper.VoidPaymentGroup)
+	m.HandleFunc("POST "+options.BaseURL+"/payment-intents", wrapper.CreatePaymentIntent)
+	m.HandleFunc("GET "+options.BaseURL+"/payment-intents/{intentID}", wrapper.GetPaymentIntent)
+	m.HandleFunc("POST "+options.BaseURL+"/payment-intents/{intentID}/confirm", wrapper.ConfirmPaymentIntent)
+	m.HandleFunc("POST "+options.BaseURL+"/payment-methods", wrapper.CreatePaymentMethod)
+	m.HandleFunc("GET "+options.BaseURL+"/payment-methods/{paymentMethodID}", wrapper.GetPaymentMethod)
+	m.HandleFunc("POST "+options.BaseURL+"/payments/batch-get", wrapper.BatchGetPayments)
+	m.HandleFunc("GET "+options.BaseURL+"/payments/by-idempotency-key/{idempotencyKey}", wrapper.GetPaymentByIdempotencyKey)
+	m.HandleFunc("GET "+options.BaseURL+"/payments/customer/{customerID}", wrapper.GetPaymentsByCustomer)
+	m.HandleFunc("GET "+options.BaseURL+"/payments/events/{paymentID}", wrapper.GetPaymentEvents)
+	m.HandleFunc("GET "+options.BaseURL+"/payments/order/{orderID}", wrapper.GetPaymentByOrder)
+	m.HandleFunc("GET "+options.BaseURL+"/payments/receipts/{paymentID}", wrapper.GetPaymentReceipt)
+	m.HandleFunc("GET "+options.BaseURL+"/payments/{paymentID}", wrapper.GetPaymentByID)
+	m.HandleFunc("POST "+options.BaseURL+"/payments/{paymentID}/captures", wrapper.CreateCapture)
+	m.HandleFunc("POST "+options.BaseURL+"/payments/{paymentID}/reauthorizations", wrapper.CreateReauthorization)
+	m.HandleFunc("POST "+options.BaseURL+"/payments/{paymentID}/refunds", wrapper.CreateRefund)
+	m.HandleFunc("POST "+options.BaseURL+"/payments/{paymentID}/voids", wrapper.CreateVoid)
+	m.HandleFunc("POST "+options.BaseURL+"/payouts", wrapper.CreatePayout)
+	m.HandleFunc("GET "+options.BaseURL+"/payouts/{payoutID}", wrapper.GetPayout)
+	m.HandleFunc("POST "+options.BaseURL+"/refund", wrapper.RefundPayment)
+	m.HandleFunc("POST "+options.BaseURL+"/refunds/batch", wrapper.CreateRefundBatch)
+	m.HandleFunc("GET "+options.BaseURL+"/refunds/{refundID}", wrapper.GetRefundByID)
+	m.HandleFunc("POST "+options.BaseURL+"/scheduled-payments", wrapper.SchedulePayment)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions", wrapper.CreateSubscription)
+	m.HandleFunc("GET "+options.BaseURL+"/subscriptions/{subscriptionID}", wrapper.GetSubscription)
+	m.HandleFunc("POST "+options.BaseURL+"/subscriptions/{subscriptionID}/cancel", wrapper.CancelSubscription)
+	m.HandleFunc("POST "+options.BaseURL+"/void", wrapper.VoidPayment)
+
+	return m
+}
+
+type GetAcquirersRequestObject struct {
+}
+
+type GetAcquirersResponseObject interface {
+	VisitGetAcquirersResponse(w http.ResponseWriter) error
+}
+
+type GetAcquirers200JSONResponse AcquirersResponse
+
+func (response GetAcquirers200JSONResponse) VisitGetAcquirersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAcquirers500JSONResponse ErrorResponse
+
+func (response GetAcquirers500JSONResponse) VisitGetAcquirersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCanaryPercentRequestObject struct {
+	Body *SetCanaryPercentJSONRequestBody
+}
+
+type SetCanaryPercentResponseObject interface {
+	VisitSetCanaryPercentResponse(w http.ResponseWriter) error
+}
+
+type SetCanaryPercent200JSONResponse AcquirersResponse
+
+func (response SetCanaryPercent200JSONResponse) VisitSetCanaryPercentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCanaryPercent400JSONResponse ErrorResponse
+
+func (response SetCanaryPercent400JSONResponse) VisitSetCanaryPercentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCanaryPercent409JSONResponse ErrorResponse
+
+func (response SetCanaryPercent409JSONResponse) VisitSetCanaryPercentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetCanaryPercent500JSONResponse ErrorResponse
+
+func (response SetCanaryPercent500JSONResponse) VisitSetCanaryPercentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExpiringAuthorizationsRequestObject struct {
+	Params GetExpiringAuthorizationsParams
+}
+
+type GetExpiringAuthorizationsResponseObject interface {
+	VisitGetExpiringAuthorizationsResponse(w http.ResponseWriter) error
+}
+
+type GetExpiringAuthorizations200JSONResponse struct {
+	Data    []Payment `json:"data,omitempty,omitzero"`
+	Success bool      `json:"success,omitempty,omitzero"`
+}
+
+func (response GetExpiringAuthorizations200JSONResponse) VisitGetExpiringAuthorizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetExpiringAuthorizations500JSONResponse ErrorResponse
+
+func (response GetExpiringAuthorizations500JSONResponse) VisitGetExpiringAuthorizationsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecordBankRefundsRequestObject struct {
+	Body *RecordBankRefundsJSONRequestBody
+}
+
+type RecordBankRefundsResponseObject interface {
+	VisitRecordBankRefundsResponse(w http.ResponseWriter) error
+}
+
+type RecordBankRefunds200JSONResponse BankRefundsResponse
+
+func (response RecordBankRefunds200JSONResponse) VisitRecordBankRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecordBankRefunds400JSONResponse ErrorResponse
+
+func (response RecordBankRefunds400JSONResponse) VisitRecordBankRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RecordBankRefunds500JSONResponse ErrorResponse
+
+func (response RecordBankRefunds500JSONResponse) VisitRecordBankRefundsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClockRequestObject struct {
+}
+
+type GetClockResponseObject interface {
+	VisitGetClockResponse(w http.ResponseWriter) error
+}
+
+type GetClock200JSONResponse ClockResponse
+
+func (response GetClock200JSONResponse) VisitGetClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClock409JSONResponse ErrorResponse
+
+func (response GetClock409JSONResponse) VisitGetClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetClock500JSONResponse ErrorResponse
+
+func (response GetClock500JSONResponse) VisitGetClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdvanceClockRequestObject struct {
+	Body *AdvanceClockJSONRequestBody
+}
+
+type AdvanceClockResponseObject interface {
+	VisitAdvanceClockResponse(w http.ResponseWriter) error
+}
+
+type AdvanceClock200JSONResponse ClockResponse
+
+func (response AdvanceClock200JSONResponse) VisitAdvanceClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdvanceClock400JSONResponse ErrorResponse
+
+func (response AdvanceClock400JSONResponse) VisitAdvanceClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdvanceClock409JSONResponse ErrorResponse
+
+func (response AdvanceClock409JSONResponse) VisitAdvanceClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AdvanceClock500JSONResponse ErrorResponse
+
+func (response AdvanceClock500JSONResponse) VisitAdvanceClockResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseCustomerRequestObject struct {
+	CustomerID string `json:"customerID"`
+}
+
+type EraseCustomerResponseObject interface {
+	VisitEraseCustomerResponse(w http.ResponseWriter) error
+}
+
+type EraseCustomer201JSONResponse ErasureResponse
+
+func (response EraseCustomer201JSONResponse) VisitEraseCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseCustomer400JSONResponse ErrorResponse
+
+func (response EraseCustomer400JSONResponse) VisitEraseCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EraseCustomer500JSONResponse ErrorResponse
+
+func (response EraseCustomer500JSONResponse) VisitEraseCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLettersRequestObject struct {
+	Params GetDeadLettersParams
+}
+
+type GetDeadLettersResponseObject interface {
+	VisitGetDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type GetDeadLetters200JSONResponse DeadLettersResponse
+
+func (response GetDeadLetters200JSONResponse) VisitGetDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDeadLetters500JSONResponse ErrorResponse
+
+func (response GetDeadLetters500JSONResponse) VisitGetDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequeueDeadLetterRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+}
+
+type RequeueDeadLetterResponseObject interface {
+	VisitRequeueDeadLetterResponse(w http.ResponseWriter) error
+}
+
+type RequeueDeadLetter200JSONResponse PaymentResponse
+
+func (response RequeueDeadLetter200JSONResponse) VisitRequeueDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequeueDeadLetter404JSONResponse ErrorResponse
+
+func (response RequeueDeadLetter404JSONResponse) VisitRequeueDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RequeueDeadLetter500JSONResponse ErrorResponse
+
+func (response RequeueDeadLetter500JSONResponse) VisitRequeueDeadLetterResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDebugSessionRequestObject struct {
+	Body *CreateDebugSessionJSONRequestBody
+}
+
+type CreateDebugSessionResponseObject interface {
+	VisitCreateDebugSessionResponse(w http.ResponseWriter) error
+}
+
+type CreateDebugSession201JSONResponse DebugSessionResponse
+
+func (response CreateDebugSession201JSONResponse) VisitCreateDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDebugSession400JSONResponse ErrorResponse
+
+func (response CreateDebugSession400JSONResponse) VisitCreateDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDebugSession404JSONResponse ErrorResponse
+
+func (response CreateDebugSession404JSONResponse) VisitCreateDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateDebugSession500JSONResponse ErrorResponse
+
+func (response CreateDebugSession500JSONResponse) VisitCreateDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDebugSessionRequestObject struct {
+	SessionID openapi_types.UUID `json:"sessionID"`
+}
+
+type DeleteDebugSessionResponseObject interface {
+	VisitDeleteDebugSessionResponse(w http.ResponseWriter) error
+}
+
+type DeleteDebugSession204Response struct {
+}
+
+func (response DeleteDebugSession204Response) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteDebugSession404JSONResponse ErrorResponse
+
+func (response DeleteDebugSession404JSONResponse) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteDebugSession500JSONResponse ErrorResponse
+
+func (response DeleteDebugSession500JSONResponse) VisitDeleteDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSessionRequestObject struct {
+	SessionID openapi_types.UUID `json:"sessionID"`
+}
+
+type GetDebugSessionResponseObject interface {
+	VisitGetDebugSessionResponse(w http.ResponseWriter) error
+}
+
+type GetDebugSession200JSONResponse DebugSessionResponse
+
+func (response GetDebugSession200JSONResponse) VisitGetDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSession404JSONResponse ErrorResponse
+
+func (response GetDebugSession404JSONResponse) VisitGetDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDebugSession500JSONResponse ErrorResponse
+
+func (response GetDebugSession500JSONResponse) VisitGetDebugSessionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFeatureFlagsRequestObject struct {
+}
+
+type ListFeatureFlagsResponseObject interface {
+	VisitListFeatureFlagsResponse(w http.ResponseWriter) error
+}
+
+type ListFeatureFlags200JSONResponse FeatureFlagsResponse
+
+func (response ListFeatureFlags200JSONResponse) VisitListFeatureFlagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListFeatureFlags500JSONResponse ErrorResponse
+
+func (response ListFeatureFlags500JSONResponse) VisitListFeatureFlagsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlagRequestObject struct {
+	Flag string `json:"flag"`
+	Body *SetFeatureFlagJSONRequestBody
+}
+
+type SetFeatureFlagResponseObject interface {
+	VisitSetFeatureFlagResponse(w http.ResponseWriter) error
+}
+
+type SetFeatureFlag200JSONResponse FeatureFlagResponse
+
+func (response SetFeatureFlag200JSONResponse) VisitSetFeatureFlagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlag400JSONResponse ErrorResponse
+
+func (response SetFeatureFlag400JSONResponse) VisitSetFeatureFlagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlag500JSONResponse ErrorResponse
+
+func (response SetFeatureFlag500JSONResponse) VisitSetFeatureFlagResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFeatureFlagOverrideRequestObject struct {
+	Flag       string `json:"flag"`
+	MerchantID string `json:"merchantID"`
+}
+
+type DeleteFeatureFlagOverrideResponseObject interface {
+	VisitDeleteFeatureFlagOverrideResponse(w http.ResponseWriter) error
+}
+
+type DeleteFeatureFlagOverride200JSONResponse FeatureFlagResponse
+
+func (response DeleteFeatureFlagOverride200JSONResponse) VisitDeleteFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFeatureFlagOverride400JSONResponse ErrorResponse
+
+func (response DeleteFeatureFlagOverride400JSONResponse) VisitDeleteFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFeatureFlagOverride404JSONResponse ErrorResponse
+
+func (response DeleteFeatureFlagOverride404JSONResponse) VisitDeleteFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteFeatureFlagOverride500JSONResponse ErrorResponse
+
+func (response DeleteFeatureFlagOverride500JSONResponse) VisitDeleteFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlagOverrideRequestObject struct {
+	Flag       string `json:"flag"`
+	MerchantID string `json:"merchantID"`
+	Body       *SetFeatureFlagOverrideJSONRequestBody
+}
+
+type SetFeatureFlagOverrideResponseObject interface {
+	VisitSetFeatureFlagOverrideResponse(w http.ResponseWriter) error
+}
+
+type SetFeatureFlagOverride200JSONResponse FeatureFlagResponse
+
+func (response SetFeatureFlagOverride200JSONResponse) VisitSetFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlagOverride400JSONResponse ErrorResponse
+
+func (response SetFeatureFlagOverride400JSONResponse) VisitSetFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlagOverride404JSONResponse ErrorResponse
+
+func (response SetFeatureFlagOverride404JSONResponse) VisitSetFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetFeatureFlagOverride500JSONResponse ErrorResponse
+
+func (response SetFeatureFlagOverride500JSONResponse) VisitSetFeatureFlagOverrideResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLogLevelRequestObject struct {
+}
+
+type GetLogLevelResponseObject interface {
+	VisitGetLogLevelResponse(w http.ResponseWriter) error
+}
+
+type GetLogLevel200JSONResponse LogLevelResponse
+
+func (response GetLogLevel200JSONResponse) VisitGetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetLogLevel500JSONResponse ErrorResponse
+
+func (response GetLogLevel500JSONResponse) VisitGetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevelRequestObject struct {
+	Body *SetLogLevelJSONRequestBody
+}
+
+type SetLogLevelResponseObject interface {
+	VisitSetLogLevelResponse(w http.ResponseWriter) error
+}
+
+type SetLogLevel200JSONResponse LogLevelResponse
+
+func (response SetLogLevel200JSONResponse) VisitSetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevel400JSONResponse ErrorResponse
+
+func (response SetLogLevel400JSONResponse) VisitSetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetLogLevel500JSONResponse ErrorResponse
+
+func (response SetLogLevel500JSONResponse) VisitSetLogLevelResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMerchantQuotaRequestObject struct {
+	MerchantID string `json:"merchantID"`
+}
+
+type GetMerchantQuotaResponseObject interface {
+	VisitGetMerchantQuotaResponse(w http.ResponseWriter) error
+}
+
+type GetMerchantQuota200JSONResponse MerchantQuotaResponse
+
+func (response GetMerchantQuota200JSONResponse) VisitGetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMerchantQuota404JSONResponse ErrorResponse
+
+func (response GetMerchantQuota404JSONResponse) VisitGetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetMerchantQuota500JSONResponse ErrorResponse
+
+func (response GetMerchantQuota500JSONResponse) VisitGetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMerchantQuotaRequestObject struct {
+	MerchantID string `json:"merchantID"`
+	Body       *SetMerchantQuotaJSONRequestBody
+}
+
+type SetMerchantQuotaResponseObject interface {
+	VisitSetMerchantQuotaResponse(w http.ResponseWriter) error
+}
+
+type SetMerchantQuota200JSONResponse MerchantQuotaResponse
+
+func (response SetMerchantQuota200JSONResponse) VisitSetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMerchantQuota400JSONResponse ErrorResponse
+
+func (response SetMerchantQuota400JSONResponse) VisitSetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMerchantQuota404JSONResponse ErrorResponse
+
+func (response SetMerchantQuota404JSONResponse) VisitSetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SetMerchantQuota500JSONResponse ErrorResponse
+
+func (response SetMerchantQuota500JSONResponse) VisitSetMerchantQuotaResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOutboxRequestObject struct {
+}
+
+type GetOutboxResponseObject interface {
+	VisitGetOutboxResponse(w http.ResponseWriter) error
+}
+
+type GetOutbox200JSONResponse OutboxStatusResponse
+
+func (response GetOutbox200JSONResponse) VisitGetOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOutbox500JSONResponse ErrorResponse
+
+func (response GetOutbox500JSONResponse) VisitGetOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseOutboxRequestObject struct {
+	Body *PauseOutboxJSONRequestBody
+}
+
+type PauseOutboxResponseObject interface {
+	VisitPauseOutboxResponse(w http.ResponseWriter) error
+}
+
+type PauseOutbox200JSONResponse OutboxStatusResponse
+
+func (response PauseOutbox200JSONResponse) VisitPauseOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PauseOutbox500JSONResponse ErrorResponse
+
+func (response PauseOutbox500JSONResponse) VisitPauseOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeOutboxRequestObject struct {
+}
+
+type ResumeOutboxResponseObject interface {
+	VisitResumeOutboxResponse(w http.ResponseWriter) error
+}
+
+type ResumeOutbox200JSONResponse OutboxStatusResponse
+
+func (response ResumeOutbox200JSONResponse) VisitResumeOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ResumeOutbox500JSONResponse ErrorResponse
+
+func (response ResumeOutbox500JSONResponse) VisitResumeOutboxResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayPaymentsRequestObject struct {
+	Body *ReplayPaymentsJSONRequestBody
+}
+
+type ReplayPaymentsResponseObject interface {
+	VisitReplayPaymentsResponse(w http.ResponseWriter) error
+}
+
+type ReplayPayments200JSONResponse struct {
+	Data    []Payment `json:"data,omitempty,omitzero"`
+	Success bool      `json:"success,omitempty,omitzero"`
+}
+
+func (response ReplayPayments200JSONResponse) VisitReplayPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayPayments400JSONResponse ErrorResponse
+
+func (response ReplayPayments400JSONResponse) VisitReplayPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReplayPayments500JSONResponse ErrorResponse
+
+func (response ReplayPayments500JSONResponse) VisitReplayPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReconciliationIssuesRequestObject struct {
+	Params GetReconciliationIssuesParams
+}
+
+type GetReconciliationIssuesResponseObject interface {
+	VisitGetReconciliationIssuesResponse(w http.ResponseWriter) error
+}
+
+type GetReconciliationIssues200JSONResponse ReconciliationIssuesResponse
+
+func (response GetReconciliationIssues200JSONResponse) VisitGetReconciliationIssuesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReconciliationIssues500JSONResponse ErrorResponse
+
+func (response GetReconciliationIssues500JSONResponse) VisitGetReconciliationIssuesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReconcileRequestObject struct {
+	Body *ReconcileJSONRequestBody
+}
+
+type ReconcileResponseObject interface {
+	VisitReconcileResponse(w http.ResponseWriter) error
+}
+
+type Reconcile200JSONResponse ReconciliationResponse
+
+func (response Reconcile200JSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Reconcile400JSONResponse ErrorResponse
+
+func (response Reconcile400JSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Reconcile500JSONResponse ErrorResponse
+
+func (response Reconcile500JSONResponse) VisitReconcileResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRefundApprovalsRequestObject struct {
+	Params GetRefundApprovalsParams
+}
+
+type GetRefundApprovalsResponseObject interface {
+	VisitGetRefundApprovalsResponse(w http.ResponseWriter) error
+}
+
+type GetRefundApprovals200JSONResponse OperationsResponse
+
+func (response GetRefundApprovals200JSONResponse) VisitGetRefundApprovalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRefundApprovals500JSONResponse ErrorResponse
+
+func (response GetRefundApprovals500JSONResponse) VisitGetRefundApprovalsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveRefundRequestObject struct {
+	RefundID openapi_types.UUID `json:"refundID"`
+}
+
+type ApproveRefundResponseObject interface {
+	VisitApproveRefundResponse(w http.ResponseWriter) error
+}
+
+type ApproveRefund200JSONResponse OperationResponse
+
+func (response ApproveRefund200JSONResponse) VisitApproveRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveRefund401JSONResponse ErrorResponse
+
+func (response ApproveRefund401JSONResponse) VisitApproveRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveRefund403JSONResponse ErrorResponse
+
+func (response ApproveRefund403JSONResponse) VisitApproveRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveRefund404JSONResponse ErrorResponse
+
+func (response ApproveRefund404JSONResponse) VisitApproveRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveRefund409JSONResponse ErrorResponse
+
+func (response ApproveRefund409JSONResponse) VisitApproveRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveRefund500JSONResponse ErrorResponse
+
+func (response ApproveRefund500JSONResponse) VisitApproveRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectRefundRequestObject struct {
+	RefundID openapi_types.UUID `json:"refundID"`
+}
+
+type RejectRefundResponseObject interface {
+	VisitRejectRefundResponse(w http.ResponseWriter) error
+}
+
+type RejectRefund200JSONResponse OperationResponse
+
+func (response RejectRefund200JSONResponse) VisitRejectRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectRefund401JSONResponse ErrorResponse
+
+func (response RejectRefund401JSONResponse) VisitRejectRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectRefund404JSONResponse ErrorResponse
+
+func (response RejectRefund404JSONResponse) VisitRejectRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectRefund409JSONResponse ErrorResponse
+
+func (response RejectRefund409JSONResponse) VisitRejectRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RejectRefund500JSONResponse ErrorResponse
+
+func (response RejectRefund500JSONResponse) VisitRejectRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegionRequestObject struct {
+}
+
+type GetRegionResponseObject interface {
+	VisitGetRegionResponse(w http.ResponseWriter) error
+}
+
+type GetRegion200JSONResponse RegionResponse
+
+func (response GetRegion200JSONResponse) VisitGetRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRegion500JSONResponse ErrorResponse
+
+func (response GetRegion500JSONResponse) VisitGetRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegionRequestObject struct {
+}
+
+type PromoteRegionResponseObject interface {
+	VisitPromoteRegionResponse(w http.ResponseWriter) error
+}
+
+type PromoteRegion200JSONResponse RegionResponse
+
+func (response PromoteRegion200JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegion409JSONResponse ErrorResponse
+
+func (response PromoteRegion409JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PromoteRegion500JSONResponse ErrorResponse
+
+func (response PromoteRegion500JSONResponse) VisitPromoteRegionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReviewsRequestObject struct {
+	Params GetReviewsParams
+}
+
+type GetReviewsResponseObject interface {
+	VisitGetReviewsResponse(w http.ResponseWriter) error
+}
+
+type GetReviews200JSONResponse PaymentReviewsResponse
+
+func (response GetReviews200JSONResponse) VisitGetReviewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetReviews500JSONResponse ErrorResponse
+
+func (response GetReviews500JSONResponse) VisitGetReviewsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveReviewRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+}
+
+type ApproveReviewResponseObject interface {
+	VisitApproveReviewResponse(w http.ResponseWriter) error
+}
+
+type ApproveReview200JSONResponse PaymentResponse
+
+func (response ApproveReview200JSONResponse) VisitApproveReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveReview401JSONResponse ErrorResponse
+
+func (response ApproveReview401JSONResponse) VisitApproveReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveReview404JSONResponse ErrorResponse
+
+func (response ApproveReview404JSONResponse) VisitApproveReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveReview409JSONResponse ErrorResponse
+
+func (response ApproveReview409JSONResponse) VisitApproveReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ApproveReview500JSONResponse ErrorResponse
+
+func (response ApproveReview500JSONResponse) VisitApproveReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineReviewRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+}
+
+type DeclineReviewResponseObject interface {
+	VisitDeclineReviewResponse(w http.ResponseWriter) error
+}
+
+type DeclineReview200JSONResponse PaymentResponse
+
+func (response DeclineReview200JSONResponse) VisitDeclineReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineReview401JSONResponse ErrorResponse
+
+func (response DeclineReview401JSONResponse) VisitDeclineReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineReview404JSONResponse ErrorResponse
+
+func (response DeclineReview404JSONResponse) VisitDeclineReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineReview409JSONResponse ErrorResponse
+
+func (response DeclineReview409JSONResponse) VisitDeclineReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineReview500JSONResponse ErrorResponse
+
+func (response DeclineReview500JSONResponse) VisitDeclineReviewResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoidBatchRequestObject struct {
+	Params CreateVoidBatchParams
+	Body   *CreateVoidBatchJSONRequestBody
+}
+
+type CreateVoidBatchResponseObject interface {
+	VisitCreateVoidBatchResponse(w http.ResponseWriter) error
+}
+
+type CreateVoidBatch202JSONResponse BatchResponse
+
+func (response CreateVoidBatch202JSONResponse) VisitCreateVoidBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoidBatch400JSONResponse ErrorResponse
+
+func (response CreateVoidBatch400JSONResponse) VisitCreateVoidBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoidBatch500JSONResponse ErrorResponse
+
+func (response CreateVoidBatch500JSONResponse) VisitCreateVoidBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentRequestObject struct {
+	Params AuthorizePaymentParams
+	Body   *AuthorizePaymentJSONRequestBody
+}
+
+type AuthorizePaymentResponseObject interface {
+	VisitAuthorizePaymentResponse(w http.ResponseWriter) error
+}
+
+type AuthorizePayment201JSONResponse PaymentResponse
+
+func (response AuthorizePayment201JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment202ResponseHeaders struct {
+	Location   string
+	RetryAfter int
+}
+
+type AuthorizePayment202JSONResponse struct {
+	Body    PaymentResponse
+	Headers AuthorizePayment202ResponseHeaders
+}
+
+func (response AuthorizePayment202JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.Header().Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AuthorizePayment400JSONResponse ErrorResponse
+
+func (response AuthorizePayment400JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment408JSONResponse ErrorResponse
+
+func (response AuthorizePayment408JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment409JSONResponse ErrorResponse
+
+func (response AuthorizePayment409JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment429JSONResponse ErrorResponse
+
+func (response AuthorizePayment429JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePayment500JSONResponse ErrorResponse
+
+func (response AuthorizePayment500JSONResponse) VisitAuthorizePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatchRequestObject struct {
+	BatchID openapi_types.UUID `json:"batchID"`
+}
+
+type GetBatchResponseObject interface {
+	VisitGetBatchResponse(w http.ResponseWriter) error
+}
+
+type GetBatch200JSONResponse BatchResponse
+
+func (response GetBatch200JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatch404JSONResponse ErrorResponse
+
+func (response GetBatch404JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetBatch500JSONResponse ErrorResponse
+
+func (response GetBatch500JSONResponse) VisitGetBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePaymentRequestObject struct {
+	Params CapturePaymentParams
+	Body   *CapturePaymentJSONRequestBody
+}
+
+type CapturePaymentResponseObject interface {
+	VisitCapturePaymentResponse(w http.ResponseWriter) error
+}
+
+type CapturePayment200JSONResponse PaymentResponse
+
+func (response CapturePayment200JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment400JSONResponse ErrorResponse
+
+func (response CapturePayment400JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment404JSONResponse ErrorResponse
+
+func (response CapturePayment404JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment408JSONResponse ErrorResponse
+
+func (response CapturePayment408JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment409JSONResponse ErrorResponse
+
+func (response CapturePayment409JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment429JSONResponse ErrorResponse
+
+func (response CapturePayment429JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePayment500JSONResponse ErrorResponse
+
+func (response CapturePayment500JSONResponse) VisitCapturePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerPaymentSummaryRequestObject struct {
+	CustomerID string `json:"customerID"`
+}
+
+type GetCustomerPaymentSummaryResponseObject interface {
+	VisitGetCustomerPaymentSummaryResponse(w http.ResponseWriter) error
+}
+
+type GetCustomerPaymentSummary200JSONResponse CustomerPaymentSummaryResponse
+
+func (response GetCustomerPaymentSummary200JSONResponse) VisitGetCustomerPaymentSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCustomerPaymentSummary500JSONResponse ErrorResponse
+
+func (response GetCustomerPaymentSummary500JSONResponse) VisitGetCustomerPaymentSummaryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOperationByIDRequestObject struct {
+	OperationID openapi_types.UUID `json:"operationID"`
+}
+
+type GetOperationByIDResponseObject interface {
+	VisitGetOperationByIDResponse(w http.ResponseWriter) error
+}
+
+type GetOperationByID200JSONResponse OperationResponse
+
+func (response GetOperationByID200JSONResponse) VisitGetOperationByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOperationByID404JSONResponse ErrorResponse
+
+func (response GetOperationByID404JSONResponse) VisitGetOperationByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOperationByID500JSONResponse ErrorResponse
+
+func (response GetOperationByID500JSONResponse) VisitGetOperationByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentGroupRequestObject struct {
+	Params AuthorizePaymentGroupParams
+	Body   *AuthorizePaymentGroupJSONRequestBody
+}
+
+type AuthorizePaymentGroupResponseObject interface {
+	VisitAuthorizePaymentGroupResponse(w http.ResponseWriter) error
+}
+
+type AuthorizePaymentGroup201JSONResponse PaymentGroupResponse
+
+func (response AuthorizePaymentGroup201JSONResponse) VisitAuthorizePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentGroup400JSONResponse ErrorResponse
+
+func (response AuthorizePaymentGroup400JSONResponse) VisitAuthorizePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentGroup409JSONResponse ErrorResponse
+
+func (response AuthorizePaymentGroup409JSONResponse) VisitAuthorizePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentGroup429JSONResponse ErrorResponse
+
+func (response AuthorizePaymentGroup429JSONResponse) VisitAuthorizePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuthorizePaymentGroup500JSONResponse ErrorResponse
+
+func (response AuthorizePaymentGroup500JSONResponse) VisitAuthorizePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentGroupRequestObject struct {
+	GroupID openapi_types.UUID `json:"groupID"`
+}
+
+type GetPaymentGroupResponseObject interface {
+	VisitGetPaymentGroupResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentGroup200JSONResponse PaymentGroupResponse
+
+func (response GetPaymentGroup200JSONResponse) VisitGetPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentGroup404JSONResponse ErrorResponse
+
+func (response GetPaymentGroup404JSONResponse) VisitGetPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentGroup500JSONResponse ErrorResponse
+
+func (response GetPaymentGroup500JSONResponse) VisitGetPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePaymentGroupRequestObject struct {
+	GroupID openapi_types.UUID `json:"groupID"`
+	Params  CapturePaymentGroupParams
+}
+
+type CapturePaymentGroupResponseObject interface {
+	VisitCapturePaymentGroupResponse(w http.ResponseWriter) error
+}
+
+type CapturePaymentGroup201JSONResponse PaymentGroupResponse
+
+func (response CapturePaymentGroup201JSONResponse) VisitCapturePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePaymentGroup404JSONResponse ErrorResponse
+
+func (response CapturePaymentGroup404JSONResponse) VisitCapturePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePaymentGroup409JSONResponse ErrorResponse
+
+func (response CapturePaymentGroup409JSONResponse) VisitCapturePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CapturePaymentGroup500JSONResponse ErrorResponse
+
+func (response CapturePaymentGroup500JSONResponse) VisitCapturePaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPaymentGroupRequestObject struct {
+	GroupID openapi_types.UUID `json:"groupID"`
+	Params  VoidPaymentGroupParams
+	Body    *VoidPaymentGroupJSONRequestBody
+}
+
+type VoidPaymentGroupResponseObject interface {
+	VisitVoidPaymentGroupResponse(w http.ResponseWriter) error
+}
+
+type VoidPaymentGroup201JSONResponse PaymentGroupResponse
+
+func (response VoidPaymentGroup201JSONResponse) VisitVoidPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPaymentGroup400JSONResponse ErrorResponse
+
+func (response VoidPaymentGroup400JSONResponse) VisitVoidPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPaymentGroup404JSONResponse ErrorResponse
+
+func (response VoidPaymentGroup404JSONResponse) VisitVoidPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPaymentGroup409JSONResponse ErrorResponse
+
+func (response VoidPaymentGroup409JSONResponse) VisitVoidPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPaymentGroup500JSONResponse ErrorResponse
+
+func (response VoidPaymentGroup500JSONResponse) VisitVoidPaymentGroupResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePaymentIntentRequestObject struct {
+	Body *CreatePaymentIntentJSONRequestBody
+}
+
+type CreatePaymentIntentResponseObject interface {
+	VisitCreatePaymentIntentResponse(w http.ResponseWriter) error
+}
+
+type CreatePaymentIntent201JSONResponse PaymentIntentResponse
+
+func (response CreatePaymentIntent201JSONResponse) VisitCreatePaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePaymentIntent400JSONResponse ErrorResponse
+
+func (response CreatePaymentIntent400JSONResponse) VisitCreatePaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePaymentIntent500JSONResponse ErrorResponse
+
+func (response CreatePaymentIntent500JSONResponse) VisitCreatePaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentIntentRequestObject struct {
+	IntentID openapi_types.UUID `json:"intentID"`
+}
+
+type GetPaymentIntentResponseObject interface {
+	VisitGetPaymentIntentResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentIntent200JSONResponse PaymentIntentResponse
+
+func (response GetPaymentIntent200JSONResponse) VisitGetPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentIntent404JSONResponse ErrorResponse
+
+func (response GetPaymentIntent404JSONResponse) VisitGetPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentIntent500JSONResponse ErrorResponse
+
+func (response GetPaymentIntent500JSONResponse) VisitGetPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntentRequestObject struct {
+	IntentID openapi_types.UUID `json:"intentID"`
+	Params   ConfirmPaymentIntentParams
+	Body     *ConfirmPaymentIntentJSONRequestBody
+}
+
+type ConfirmPaymentIntentResponseObject interface {
+	VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error
+}
+
+type ConfirmPaymentIntent201JSONResponse PaymentResponse
+
+func (response ConfirmPaymentIntent201JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntent202JSONResponse PaymentResponse
+
+func (response ConfirmPaymentIntent202JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntent400JSONResponse ErrorResponse
+
+func (response ConfirmPaymentIntent400JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntent404JSONResponse ErrorResponse
+
+func (response ConfirmPaymentIntent404JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntent409JSONResponse ErrorResponse
+
+func (response ConfirmPaymentIntent409JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntent429JSONResponse ErrorResponse
+
+func (response ConfirmPaymentIntent429JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ConfirmPaymentIntent500JSONResponse ErrorResponse
+
+func (response ConfirmPaymentIntent500JSONResponse) VisitConfirmPaymentIntentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePaymentMethodRequestObject struct {
+	Body *CreatePaymentMethodJSONRequestBody
+}
+
+type CreatePaymentMethodResponseObject interface {
+	VisitCreatePaymentMethodResponse(w http.ResponseWriter) error
+}
+
+type CreatePaymentMethod201JSONResponse PaymentMethodResponse
+
+func (response CreatePaymentMethod201JSONResponse) VisitCreatePaymentMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePaymentMethod400JSONResponse ErrorResponse
+
+func (response CreatePaymentMethod400JSONResponse) VisitCreatePaymentMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePaymentMethod500JSONResponse ErrorResponse
+
+func (response CreatePaymentMethod500JSONResponse) VisitCreatePaymentMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentMethodRequestObject struct {
+	PaymentMethodID openapi_types.UUID `json:"paymentMethodID"`
+}
+
+type GetPaymentMethodResponseObject interface {
+	VisitGetPaymentMethodResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentMethod200JSONResponse PaymentMethodResponse
+
+func (response GetPaymentMethod200JSONResponse) VisitGetPaymentMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentMethod404JSONResponse ErrorResponse
+
+func (response GetPaymentMethod404JSONResponse) VisitGetPaymentMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentMethod500JSONResponse ErrorResponse
+
+func (response GetPaymentMethod500JSONResponse) VisitGetPaymentMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetPaymentsRequestObject struct {
+	Body *BatchGetPaymentsJSONRequestBody
+}
+
+type BatchGetPaymentsResponseObject interface {
+	VisitBatchGetPaymentsResponse(w http.ResponseWriter) error
+}
+
+type BatchGetPayments200JSONResponse struct {
+	Data    []Payment `json:"data,omitempty,omitzero"`
+	Success bool      `json:"success,omitempty,omitzero"`
+}
+
+func (response BatchGetPayments200JSONResponse) VisitBatchGetPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetPayments400JSONResponse ErrorResponse
+
+func (response BatchGetPayments400JSONResponse) VisitBatchGetPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BatchGetPayments500JSONResponse ErrorResponse
+
+func (response BatchGetPayments500JSONResponse) VisitBatchGetPaymentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByIdempotencyKeyRequestObject struct {
+	IdempotencyKey string `json:"idempotencyKey"`
+}
+
+type GetPaymentByIdempotencyKeyResponseObject interface {
+	VisitGetPaymentByIdempotencyKeyResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentByIdempotencyKey200JSONResponse PaymentResponse
+
+func (response GetPaymentByIdempotencyKey200JSONResponse) VisitGetPaymentByIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByIdempotencyKey404JSONResponse ErrorResponse
+
+func (response GetPaymentByIdempotencyKey404JSONResponse) VisitGetPaymentByIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByIdempotencyKey500JSONResponse ErrorResponse
+
+func (response GetPaymentByIdempotencyKey500JSONResponse) VisitGetPaymentByIdempotencyKeyResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentsByCustomerRequestObject struct {
+	CustomerID string `json:"customerID"`
+	Params     GetPaymentsByCustomerParams
+}
+
+type GetPaymentsByCustomerResponseObject interface {
+	VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentsByCustomer200JSONResponse struct {
+	Data    []Payment `json:"data,omitempty,omitzero"`
+	Success bool      `json:"success,omitempty,omitzero"`
+}
+
+func (response GetPaymentsByCustomer200JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentsByCustomer400JSONResponse ErrorResponse
+
+func (response GetPaymentsByCustomer400JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentsByCustomer404JSONResponse ErrorResponse
+
+func (response GetPaymentsByCustomer404JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentsByCustomer500JSONResponse ErrorResponse
+
+func (response GetPaymentsByCustomer500JSONResponse) VisitGetPaymentsByCustomerResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentEventsRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+}
+
+type GetPaymentEventsResponseObject interface {
+	VisitGetPaymentEventsResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetPaymentEvents200TexteventStreamResponse) VisitGetPaymentEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetPaymentEvents404JSONResponse ErrorResponse
+
+func (response GetPaymentEvents404JSONResponse) VisitGetPaymentEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentEvents500JSONResponse ErrorResponse
+
+func (response GetPaymentEvents500JSONResponse) VisitGetPaymentEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByOrderRequestObject struct {
+	OrderID string `json:"orderID"`
+}
+
+type GetPaymentByOrderResponseObject interface {
+	VisitGetPaymentByOrderResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentByOrder200JSONResponse PaymentResponse
+
+func (response GetPaymentByOrder200JSONResponse) VisitGetPaymentByOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByOrder404JSONResponse ErrorResponse
+
+func (response GetPaymentByOrder404JSONResponse) VisitGetPaymentByOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByOrder500JSONResponse ErrorResponse
+
+func (response GetPaymentByOrder500JSONResponse) VisitGetPaymentByOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentReceiptRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+	Params    GetPaymentReceiptParams
+}
+
+type GetPaymentReceiptResponseObject interface {
+	VisitGetPaymentReceiptResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentReceipt200JSONResponse ReceiptResponse
+
+func (response GetPaymentReceipt200JSONResponse) VisitGetPaymentReceiptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentReceipt200ApplicationpdfResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetPaymentReceipt200ApplicationpdfResponse) VisitGetPaymentReceiptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/pdf")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetPaymentReceipt404JSONResponse ErrorResponse
+
+func (response GetPaymentReceipt404JSONResponse) VisitGetPaymentReceiptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentReceipt409JSONResponse ErrorResponse
+
+func (response GetPaymentReceipt409JSONResponse) VisitGetPaymentReceiptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentReceipt500JSONResponse ErrorResponse
+
+func (response GetPaymentReceipt500JSONResponse) VisitGetPaymentReceiptResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByIDRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+	Params    GetPaymentByIDParams
+}
+
+type GetPaymentByIDResponseObject interface {
+	VisitGetPaymentByIDResponse(w http.ResponseWriter) error
+}
+
+type GetPaymentByID200ResponseHeaders struct {
+	ETag string
+}
+
+type GetPaymentByID200JSONResponse struct {
+	Body    PaymentResponse
+	Headers GetPaymentByID200ResponseHeaders
+}
+
+func (response GetPaymentByID200JSONResponse) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetPaymentByID304ResponseHeaders struct {
+	ETag string
+}
+
+type GetPaymentByID304Response struct {
+	Headers GetPaymentByID304ResponseHeaders
+}
+
+func (response GetPaymentByID304Response) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
+}
+
+type GetPaymentByID404JSONResponse ErrorResponse
+
+func (response GetPaymentByID404JSONResponse) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPaymentByID500JSONResponse ErrorResponse
+
+func (response GetPaymentByID500JSONResponse) VisitGetPaymentByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCaptureRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+	Params    CreateCaptureParams
+	Body      *CreateCaptureJSONRequestBody
+}
+
+type CreateCaptureResponseObject interface {
+	VisitCreateCaptureResponse(w http.ResponseWriter) error
+}
+
+type CreateCapture201JSONResponse OperationResponse
+
+func (response CreateCapture201JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCapture400JSONResponse ErrorResponse
+
+func (response CreateCapture400JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCapture404JSONResponse ErrorResponse
+
+func (response CreateCapture404JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCapture408JSONResponse ErrorResponse
+
+func (response CreateCapture408JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCapture409JSONResponse ErrorResponse
+
+func (response CreateCapture409JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCapture500JSONResponse ErrorResponse
+
+func (response CreateCapture500JSONResponse) VisitCreateCaptureResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReauthorizationRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+	Params    CreateReauthorizationParams
+	Body      *CreateReauthorizationJSONRequestBody
+}
+
+type CreateReauthorizationResponseObject interface {
+	VisitCreateReauthorizationResponse(w http.ResponseWriter) error
+}
+
+type CreateReauthorization201JSONResponse OperationResponse
+
+func (response CreateReauthorization201JSONResponse) VisitCreateReauthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReauthorization400JSONResponse ErrorResponse
+
+func (response CreateReauthorization400JSONResponse) VisitCreateReauthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReauthorization404JSONResponse ErrorResponse
+
+func (response CreateReauthorization404JSONResponse) VisitCreateReauthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReauthorization408JSONResponse ErrorResponse
+
+func (response CreateReauthorization408JSONResponse) VisitCreateReauthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReauthorization409JSONResponse ErrorResponse
+
+func (response CreateReauthorization409JSONResponse) VisitCreateReauthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateReauthorization500JSONResponse ErrorResponse
+
+func (response CreateReauthorization500JSONResponse) VisitCreateReauthorizationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefundRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+	Params    CreateRefundParams
+	Body      *CreateRefundJSONRequestBody
+}
+
+type CreateRefundResponseObject interface {
+	VisitCreateRefundResponse(w http.ResponseWriter) error
+}
+
+type CreateRefund201JSONResponse OperationResponse
+
+func (response CreateRefund201JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund202JSONResponse OperationResponse
+
+func (response CreateRefund202JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund400JSONResponse ErrorResponse
+
+func (response CreateRefund400JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund404JSONResponse ErrorResponse
+
+func (response CreateRefund404JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund408JSONResponse ErrorResponse
+
+func (response CreateRefund408JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund409JSONResponse ErrorResponse
+
+func (response CreateRefund409JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefund500JSONResponse ErrorResponse
+
+func (response CreateRefund500JSONResponse) VisitCreateRefundResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoidRequestObject struct {
+	PaymentID openapi_types.UUID `json:"paymentID"`
+	Params    CreateVoidParams
+	Body      *CreateVoidJSONRequestBody
+}
+
+type CreateVoidResponseObject interface {
+	VisitCreateVoidResponse(w http.ResponseWriter) error
+}
+
+type CreateVoid201JSONResponse OperationResponse
+
+func (response CreateVoid201JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid400JSONResponse ErrorResponse
+
+func (response CreateVoid400JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid404JSONResponse ErrorResponse
+
+func (response CreateVoid404JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid408JSONResponse ErrorResponse
+
+func (response CreateVoid408JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid409JSONResponse ErrorResponse
+
+func (response CreateVoid409JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateVoid500JSONResponse ErrorResponse
+
+func (response CreateVoid500JSONResponse) VisitCreateVoidResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePayoutRequestObject struct {
+	Params CreatePayoutParams
+	Body   *CreatePayoutJSONRequestBody
+}
+
+type CreatePayoutResponseObject interface {
+	VisitCreatePayoutResponse(w http.ResponseWriter) error
+}
+
+type CreatePayout201JSONResponse PayoutResponse
+
+func (response CreatePayout201JSONResponse) VisitCreatePayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePayout400JSONResponse ErrorResponse
+
+func (response CreatePayout400JSONResponse) VisitCreatePayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePayout404JSONResponse ErrorResponse
+
+func (response CreatePayout404JSONResponse) VisitCreatePayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePayout408JSONResponse ErrorResponse
+
+func (response CreatePayout408JSONResponse) VisitCreatePayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePayout409JSONResponse ErrorResponse
+
+func (response CreatePayout409JSONResponse) VisitCreatePayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePayout500JSONResponse ErrorResponse
+
+func (response CreatePayout500JSONResponse) VisitCreatePayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPayoutRequestObject struct {
+	PayoutID openapi_types.UUID `json:"payoutID"`
+}
+
+type GetPayoutResponseObject interface {
+	VisitGetPayoutResponse(w http.ResponseWriter) error
+}
+
+type GetPayout200JSONResponse PayoutResponse
+
+func (response GetPayout200JSONResponse) VisitGetPayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPayout404JSONResponse ErrorResponse
+
+func (response GetPayout404JSONResponse) VisitGetPayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetPayout500JSONResponse ErrorResponse
+
+func (response GetPayout500JSONResponse) VisitGetPayoutResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPaymentRequestObject struct {
+	Params RefundPaymentParams
+	Body   *RefundPaymentJSONRequestBody
+}
+
+type RefundPaymentResponseObject interface {
+	VisitRefundPaymentResponse(w http.ResponseWriter) error
+}
+
+type RefundPayment200JSONResponse PaymentResponse
+
+func (response RefundPayment200JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment202JSONResponse PaymentResponse
+
+func (response RefundPayment202JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment400JSONResponse ErrorResponse
+
+func (response RefundPayment400JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment404JSONResponse ErrorResponse
+
+func (response RefundPayment404JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment408JSONResponse ErrorResponse
+
+func (response RefundPayment408JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment409JSONResponse ErrorResponse
+
+func (response RefundPayment409JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment429JSONResponse ErrorResponse
+
+func (response RefundPayment429JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RefundPayment500JSONResponse ErrorResponse
+
+func (response RefundPayment500JSONResponse) VisitRefundPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefundBatchRequestObject struct {
+	Params CreateRefundBatchParams
+	Body   *CreateRefundBatchJSONRequestBody
+}
+
+type CreateRefundBatchResponseObject interface {
+	VisitCreateRefundBatchResponse(w http.ResponseWriter) error
+}
+
+type CreateRefundBatch202JSONResponse BatchResponse
+
+func (response CreateRefundBatch202JSONResponse) VisitCreateRefundBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(202)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefundBatch400JSONResponse ErrorResponse
+
+func (response CreateRefundBatch400JSONResponse) VisitCreateRefundBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateRefundBatch500JSONResponse ErrorResponse
+
+func (response CreateRefundBatch500JSONResponse) VisitCreateRefundBatchResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRefundByIDRequestObject struct {
+	RefundID openapi_types.UUID `json:"refundID"`
+}
+
+type GetRefundByIDResponseObject interface {
+	VisitGetRefundByIDResponse(w http.ResponseWriter) error
+}
+
+type GetRefundByID200JSONResponse OperationResponse
+
+func (response GetRefundByID200JSONResponse) VisitGetRefundByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRefundByID404JSONResponse ErrorResponse
+
+func (response GetRefundByID404JSONResponse) VisitGetRefundByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRefundByID500JSONResponse ErrorResponse
+
+func (response GetRefundByID500JSONResponse) VisitGetRefundByIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SchedulePaymentRequestObject struct {
+	Params SchedulePaymentParams
+	Body   *SchedulePaymentJSONRequestBody
+}
+
+type SchedulePaymentResponseObject interface {
+	VisitSchedulePaymentResponse(w http.ResponseWriter) error
+}
+
+type SchedulePayment201JSONResponse PaymentResponse
+
+func (response SchedulePayment201JSONResponse) VisitSchedulePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SchedulePayment400JSONResponse ErrorResponse
+
+func (response SchedulePayment400JSONResponse) VisitSchedulePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SchedulePayment404JSONResponse ErrorResponse
+
+func (response SchedulePayment404JSONResponse) VisitSchedulePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SchedulePayment409JSONResponse ErrorResponse
+
+func (response SchedulePayment409JSONResponse) VisitSchedulePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SchedulePayment429JSONResponse ErrorResponse
+
+func (response SchedulePayment429JSONResponse) VisitSchedulePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type SchedulePayment500JSONResponse ErrorResponse
+
+func (response SchedulePayment500JSONResponse) VisitSchedulePaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscriptionRequestObject struct {
+	Body *CreateSubscriptionJSONRequestBody
+}
+
+type CreateSubscriptionResponseObject interface {
+	VisitCreateSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type CreateSubscription201JSONResponse SubscriptionResponse
+
+func (response CreateSubscription201JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscription400JSONResponse ErrorResponse
+
+func (response CreateSubscription400JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscription404JSONResponse ErrorResponse
+
+func (response CreateSubscription404JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateSubscription500JSONResponse ErrorResponse
+
+func (response CreateSubscription500JSONResponse) VisitCreateSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionRequestObject struct {
+	SubscriptionID openapi_types.UUID `json:"subscriptionID"`
+}
+
+type GetSubscriptionResponseObject interface {
+	VisitGetSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type GetSubscription200JSONResponse SubscriptionResponse
+
+func (response GetSubscription200JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription404JSONResponse ErrorResponse
+
+func (response GetSubscription404JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscription500JSONResponse ErrorResponse
+
+func (response GetSubscription500JSONResponse) VisitGetSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscriptionRequestObject struct {
+	SubscriptionID openapi_types.UUID `json:"subscriptionID"`
+}
+
+type CancelSubscriptionResponseObject interface {
+	VisitCancelSubscriptionResponse(w http.ResponseWriter) error
+}
+
+type CancelSubscription200JSONResponse SubscriptionResponse
+
+func (response CancelSubscription200JSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription404JSONResponse ErrorResponse
+
+func (response CancelSubscription404JSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription409JSONResponse ErrorResponse
+
+func (response CancelSubscription409JSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelSubscription500JSONResponse ErrorResponse
+
+func (response CancelSubscription500JSONResponse) VisitCancelSubscriptionResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPaymentRequestObject struct {
+	Params VoidPaymentParams
+	Body   *VoidPaymentJSONRequestBody
+}
+
+type VoidPaymentResponseObject interface {
+	VisitVoidPaymentResponse(w http.ResponseWriter) error
+}
+
+type VoidPayment200JSONResponse PaymentResponse
+
+func (response VoidPayment200JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPayment400JSONResponse ErrorResponse
+
+func (response VoidPayment400JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPayment404JSONResponse ErrorResponse
+
+func (response VoidPayment404JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPayment408JSONResponse ErrorResponse
+
+func (response VoidPayment408JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(408)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPayment409JSONResponse ErrorResponse
+
+func (response VoidPayment409JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPayment429JSONResponse ErrorResponse
+
+func (response VoidPayment429JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(429)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type VoidPayment500JSONResponse ErrorResponse
+
+func (response VoidPayment500JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Get acquirer routing and success rates
+	// (GET /admin/acquirers)
+	GetAcquirers(ctx context.Context, request GetAcquirersRequestObject) (GetAcquirersResponseObject, error)
+	// Set the canary percentage
+	// (PUT /admin/acquirers/canary)
+	SetCanaryPercent(ctx context.Context, request SetCanaryPercentRequestObject) (SetCanaryPercentResponseObject, error)
+	// List authorizations about to lapse uncaptured
+	// (GET /admin/authorizations/expiring)
+	GetExpiringAuthorizations(ctx context.Context, request GetExpiringAuthorizationsRequestObject) (GetExpiringAuthorizationsResponseObject, error)
+	// Record refunds the bank made
+	// (POST /admin/bank-refunds)
+	RecordBankRefunds(ctx context.Context, request RecordBankRefundsRequestObject) (RecordBankRefundsResponseObject, error)
+	// Get the test clock
+	// (GET /admin/clock)
+	GetClock(ctx context.Context, request GetClockRequestObject) (GetClockResponseObject, error)
+	// Advance the test clock
+	// (POST /admin/clock/advance)
+	AdvanceClock(ctx context.Context, request AdvanceClockRequestObject) (AdvanceClockResponseObject, error)
+	// Erase a customer's personal data
+	// (POST /admin/customers/{customerID}/erasure)
+	EraseCustomer(ctx context.Context, request EraseCustomerRequestObject) (EraseCustomerResponseObject, error)
+	// List payments the retry worker gave up on
+	// (GET /admin/dead-letters)
+	GetDeadLetters(ctx context.Context, request GetDeadLettersRequestObject) (GetDeadLettersResponseObject, error)
+	// Requeue a dead-lettered payment
+	// (POST /admin/dead-letters/{paymentID}/requeue)
+	RequeueDeadLetter(ctx context.Context, request RequeueDeadLetterRequestObject) (RequeueDeadLetterResponseObject, error)
+	// Start a bank traffic debug session
+	// (POST /admin/debug-sessions)
+	CreateDebugSession(ctx context.Context, request CreateDebugSessionRequestObject) (CreateDebugSessionResponseObject, error)
+	// End a debug session
+	// (DELETE /admin/debug-sessions/{sessionID})
+	DeleteDebugSession(ctx context.Context, request DeleteDebugSessionRequestObject) (DeleteDebugSessionResponseObject, error)
+	// Get a debug session and its captures
+	// (GET /admin/debug-sessions/{sessionID})
+	GetDebugSession(ctx context.Context, request GetDebugSessionRequestObject) (GetDebugSessionResponseObject, error)
+	// List the feature flags
+	// (GET /admin/feature-flags)
+	ListFeatureFlags(ctx context.Context, request ListFeatureFlagsRequestObject) (ListFeatureFlagsResponseObject, error)
+	// Set a feature flag's rollout
+	// (PUT /admin/feature-flags/{flag})
+	SetFeatureFlag(ctx context.Context, request SetFeatureFlagRequestObject) (SetFeatureFlagResponseObject, error)
+	// Remove a merchant's feature flag override
+	// (DELETE /admin/feature-flags/{flag}/merchants/{merchantID})
+	DeleteFeatureFlagOverride(ctx context.Context, request DeleteFeatureFlagOverrideRequestObject) (DeleteFeatureFlagOverrideResponseObject, error)
+	// Override a feature flag for a merchant
+	// (PUT /admin/feature-flags/{flag}/merchants/{merchantID})
+	SetFeatureFlagOverride(ctx context.Context, request SetFeatureFlagOverrideRequestObject) (SetFeatureFlagOverrideResponseObject, error)
+	// Get the log level
+	// (GET /admin/log-level)
+	GetLogLevel(ctx context.Context, request GetLogLevelRequestObject) (GetLogLevelResponseObject, error)
+	// Set the log level
+	// (PUT /admin/log-level)
+	SetLogLevel(ctx context.Context, request SetLogLevelRequestObject) (SetLogLevelResponseObject, error)
+	// Get a merchant's daily quota
+	// (GET /admin/merchants/{merchantID}/quota)
+	GetMerchantQuota(ctx context.Context, request GetMerchantQuotaRequestObject) (GetMerchantQuotaResponseObject, error)
+	// Set a merchant's daily quota
+	// (PUT /admin/merchants/{merchantID}/quota)
+	SetMerchantQuota(ctx context.Context, request SetMerchantQuotaRequestObject) (SetMerchantQuotaResponseObject, error)
+	// Get the outbox backlog
+	// (GET /admin/outbox)
+	GetOutbox(ctx context.Context, request GetOutboxRequestObject) (GetOutboxResponseObject, error)
+	// Pause delivery of transition events
+	// (POST /admin/outbox/pause)
+	PauseOutbox(ctx context.Context, request PauseOutboxRequestObject) (PauseOutboxResponseObject, error)
+	// Resume delivery of transition events
+	// (POST /admin/outbox/resume)
+	ResumeOutbox(ctx context.Context, request ResumeOutboxRequestObject) (ResumeOutboxResponseObject, error)
+	// Replay payments stuck mid-transition
+	// (POST /admin/reconcile)
+	ReplayPayments(ctx context.Context, request ReplayPaymentsRequestObject) (ReplayPaymentsResponseObject, error)
+	// List reconciliation issues
+	// (GET /admin/reconciliation-issues)
+	GetReconciliationIssues(ctx context.Context, request GetReconciliationIssuesRequestObject) (GetReconciliationIssuesResponseObject, error)
+	// Reconcile with the bank
+	// (POST /admin/reconciliations)
+	Reconcile(ctx context.Context, request ReconcileRequestObject) (ReconcileResponseObject, error)
+	// List refunds waiting for approval
+	// (GET /admin/refund-approvals)
+	GetRefundApprovals(ctx context.Context, request GetRefundApprovalsRequestObject) (GetRefundApprovalsResponseObject, error)
+	// Approve a refund
+	// (POST /admin/refunds/{refundID}/approve)
+	ApproveRefund(ctx context.Context, request ApproveRefundRequestObject) (ApproveRefundResponseObject, error)
+	// Reject a refund
+	// (POST /admin/refunds/{refundID}/reject)
+	RejectRefund(ctx context.Context, request RejectRefundRequestObject) (RejectRefundResponseObject, error)
+	// Get this region's standing
+	// (GET /admin/region)
+	GetRegion(ctx context.Context, request GetRegionRequestObject) (GetRegionResponseObject, error)
+	// Promote this region
+	// (POST /admin/region/promote)
+	PromoteRegion(ctx context.Context, request PromoteRegionRequestObject) (PromoteRegionResponseObject, error)
+	// List payments waiting for review
+	// (GET /admin/reviews)
+	GetReviews(ctx context.Context, request GetReviewsRequestObject) (GetReviewsResponseObject, error)
+	// Approve a payment in review
+	// (POST /admin/reviews/{paymentID}/approve)
+	ApproveReview(ctx context.Context, request ApproveReviewRequestObject) (ApproveReviewResponseObject, error)
+	// Decline a payment in review
+	// (POST /admin/reviews/{paymentID}/decline)
+	DeclineReview(ctx context.Context, request DeclineReviewRequestObject) (DeclineReviewResponseObject, error)
+	// Void abandoned orders in bulk
+	// (POST /admin/voids/batch)
+	CreateVoidBatch(ctx context.Context, request CreateVoidBatchRequestObject) (CreateVoidBatchResponseObject, error)
+	// Authorize Payment
+	// (POST /authorize)
+	AuthorizePayment(ctx context.Context, request AuthorizePaymentRequestObject) (AuthorizePaymentResponseObject, error)
+	// Get a batch
+	// (GET /batches/{batchID})
+	GetBatch(ctx context.Context, request GetBatchRequestObject) (GetBatchResponseObject, error)
+	// Capture Payment
+	// (POST /capture)
+	CapturePayment(ctx context.Context, request CapturePaymentRequestObject) (CapturePaymentResponseObject, error)
+	// Customer Payment Summary
+	// (GET /customers/{customerID}/payment-summary)
+	GetCustomerPaymentSummary(ctx context.Context, request GetCustomerPaymentSummaryRequestObject) (GetCustomerPaymentSummaryResponseObject, error)
+	// Get Operation by ID
+	// (GET /operations/{operationID})
+	GetOperationByID(ctx context.Context, request GetOperationByIDRequestObject) (GetOperationByIDResponseObject, error)
+	// Authorize a payment split across cards
+	// (POST /payment-groups)
+	AuthorizePaymentGroup(ctx context.Context, request AuthorizePaymentGroupRequestObject) (AuthorizePaymentGroupResponseObject, error)
+	// Get a payment group
+	// (GET /payment-groups/{groupID})
+	GetPaymentGroup(ctx context.Context, request GetPaymentGroupRequestObject) (GetPaymentGroupResponseObject, error)
+	// Capture a payment group
+	// (POST /payment-groups/{groupID}/captures)
+	CapturePaymentGroup(ctx context.Context, request CapturePaymentGroupRequestObject) (CapturePaymentGroupResponseObject, error)
+	// Void a payment group
+	// (POST /payment-groups/{groupID}/voids)
+	VoidPaymentGroup(ctx context.Context, request VoidPaymentGroupRequestObject) (VoidPaymentGroupResponseObject, error)
+	// Create a payment intent
+	// (POST /payment-intents)
+	CreatePaymentIntent(ctx context.Context, request CreatePaymentIntentRequestObject) (CreatePaymentIntentResponseObject, error)
+	// Get a payment intent
+	// (GET /payment-intents/{intentID})
+	GetPaymentIntent(ctx context.Context, request GetPaymentIntentRequestObject) (GetPaymentIntentResponseObject, error)
+	// Confirm a payment intent
+	// (POST /payment-intents/{intentID}/confirm)
+	ConfirmPaymentIntent(ctx context.Context, request ConfirmPaymentIntentRequestObject) (ConfirmPaymentIntentResponseObject, error)
+	// Save a payment method
+	// (POST /payment-methods)
+	CreatePaymentMethod(ctx context.Context, request CreatePaymentMethodRequestObject) (CreatePaymentMethodResponseObject, error)
+	// Get a payment method
+	// (GET /payment-methods/{paymentMethodID})
+	GetPaymentMethod(ctx context.Context, request GetPaymentMethodRequestObject) (GetPaymentMethodResponseObject, error)
+	// Get Payments by ID
+	// (POST /payments/batch-get)
+	BatchGetPayments(ctx context.Context, request BatchGetPaymentsRequestObject) (BatchGetPaymentsResponseObject, error)
+	// Get Payment by Idempotency Key
+	// (GET /payments/by-idempotency-key/{idempotencyKey})
+	GetPaymentByIdempotencyKey(ctx context.Context, request GetPaymentByIdempotencyKeyRequestObject) (GetPaymentByIdempotencyKeyResponseObject, error)
+	// List Customer Payments
+	// (GET /payments/customer/{customerID})
+	GetPaymentsByCustomer(ctx context.Context, request GetPaymentsByCustomerRequestObject) (GetPaymentsByCustomerResponseObject, error)
+	// Stream Payment Status
+	// (GET /payments/events/{paymentID})
+	GetPaymentEvents(ctx context.Context, request GetPaymentEventsRequestObject) (GetPaymentEventsResponseObject, error)
+	// Get Payment by Order ID
+	// (GET /payments/order/{orderID})
+	GetPaymentByOrder(ctx context.Context, request GetPaymentByOrderRequestObject) (GetPaymentByOrderResponseObject, error)
+	// Get Payment Receipt
+	// (GET /payments/receipts/{paymentID})
+	GetPaymentReceipt(ctx context.Context, request GetPaymentReceiptRequestObject) (GetPaymentReceiptResponseObject, error)
+	// Get Payment by ID
+	// (GET /payments/{paymentID})
+	GetPaymentByID(ctx context.Context, request GetPaymentByIDRequestObject) (GetPaymentByIDResponseObject, error)
+	// Create Capture
+	// (POST /payments/{paymentID}/captures)
+	CreateCapture(ctx context.Context, request CreateCaptureRequestObject) (CreateCaptureResponseObject, error)
+	// Create Reauthorization
+	// (POST /payments/{paymentID}/reauthorizations)
+	CreateReauthorization(ctx context.Context, request CreateReauthorizationRequestObject) (CreateReauthorizationResponseObject, error)
+	// Create Refund
+	// (POST /payments/{paymentID}/refunds)
+	CreateRefund(ctx context.Context, request CreateRefundRequestObject) (CreateRefundResponseObject, error)
+	// Create Void
+	// (POST /payments/{paymentID}/voids)
+	CreateVoid(ctx context.Context, request CreateVoidRequestObject) (CreateVoidResponseObject, error)
+	// Create a payout
+	// (POST /payouts)
+	CreatePayout(ctx context.Context, request CreatePayoutRequestObject) (CreatePayoutResponseObject, error)
+	// Get a payout
+	// (GET /payouts/{payoutID})
+	GetPayout(ctx context.Context, request GetPayoutRequestObject) (GetPayoutResponseObject, error)
+	// Refund Payment
+	// (POST /refund)
+	RefundPayment(ctx context.Context, request RefundPaymentRequestObject) (RefundPaymentResponseObject, error)
+	// Refund many payments
+	// (POST /refunds/batch)
+	CreateRefundBatch(ctx context.Context, request CreateRefundBatchRequestObject) (CreateRefundBatchResponseObject, error)
+	// Get Refund by ID
+	// (GET /refunds/{refundID})
+	GetRefundByID(ctx context.Context, request GetRefundByIDRequestObject) (GetRefundByIDResponseObject, error)
+	// Schedule a payment
+	// (POST /scheduled-payments)
+	SchedulePayment(ctx context.Context, request SchedulePaymentRequestObject) (SchedulePaymentResponseObject, error)
+	// Create a subscription
+	// (POST /subscriptions)
+	CreateSubscription(ctx context.Context, request CreateSubscriptionRequestObject) (CreateSubscriptionResponseObject, error)
+	// Get a subscription
+	// (GET /subscriptions/{subscriptionID})
+	GetSubscription(ctx context.Context, request GetSubscriptionRequestObject) (GetSubscriptionResponseObject, error)
+	// Cancel a subscription
+	// (POST /subscriptions/{subscriptionID}/cancel)
+	CancelSubscription(ctx context.Context, request CancelSubscriptionRequestObject) (CancelSubscriptionResponseObject, error)
+	// Void Authorization
+	// (POST /void)
+	VoidPayment(ctx context.Context, request VoidPaymentRequestObject) (VoidPaymentResponseObject, error)
+}
+
+type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
+type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+
+type StrictHTTPServerOptions struct {
+	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
+	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		},
+	}}
+}
+
+func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// GetAcquirers operation middleware
+func (sh *strictHandler) GetAcquirers(w http.ResponseWriter, r *http.Request) {
+	var request GetAcquirersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAcquirers(ctx, request.(GetAcquirersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAcquirers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetAcquirersResponseObject); ok {
+		if err := validResponse.VisitGetAcquirersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetCanaryPercent operation middleware
+func (sh *strictHandler) SetCanaryPercent(w http.ResponseWriter, r *http.Request) {
+	var request SetCanaryPercentRequestObject
+
+	var body SetCanaryPercentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetCanaryPercent(ctx, request.(SetCanaryPercentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetCanaryPercent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetCanaryPercentResponseObject); ok {
+		if err := validResponse.VisitSetCanaryPercentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetExpiringAuthorizations operation middleware
+func (sh *strictHandler) GetExpiringAuthorizations(w http.ResponseWriter, r *http.Request, params GetExpiringAuthorizationsParams) {
+	var request GetExpiringAuthorizationsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetExpiringAuthorizations(ctx, request.(GetExpiringAuthorizationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetExpiringAuthorizations")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetExpiringAuthorizationsResponseObject); ok {
+		if err := validResponse.VisitGetExpiringAuthorizationsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RecordBankRefunds operation middleware
+func (sh *strictHandler) RecordBankRefunds(w http.ResponseWriter, r *http.Request) {
+	var request RecordBankRefundsRequestObject
+
+	var body RecordBankRefundsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RecordBankRefunds(ctx, request.(RecordBankRefundsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RecordBankRefunds")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RecordBankRefundsResponseObject); ok {
+		if err := validResponse.VisitRecordBankRefundsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetClock operation middleware
+func (sh *strictHandler) GetClock(w http.ResponseWriter, r *http.Request) {
+	var request GetClockRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetClock(ctx, request.(GetClockRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetClock")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetClockResponseObject); ok {
+		if err := validResponse.VisitGetClockResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AdvanceClock operation middleware
+func (sh *strictHandler) AdvanceClock(w http.ResponseWriter, r *http.Request) {
+	var request AdvanceClockRequestObject
+
+	var body AdvanceClockJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AdvanceClock(ctx, request.(AdvanceClockRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AdvanceClock")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AdvanceClockResponseObject); ok {
+		if err := validResponse.VisitAdvanceClockResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// EraseCustomer operation middleware
+func (sh *strictHandler) EraseCustomer(w http.ResponseWriter, r *http.Request, customerID string) {
+	var request EraseCustomerRequestObject
+
+	request.CustomerID = customerID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.EraseCustomer(ctx, request.(EraseCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EraseCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EraseCustomerResponseObject); ok {
+		if err := validResponse.VisitEraseCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDeadLetters operation middleware
+func (sh *strictHandler) GetDeadLetters(w http.ResponseWriter, r *http.Request, params GetDeadLettersParams) {
+	var request GetDeadLettersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDeadLetters(ctx, request.(GetDeadLettersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDeadLetters")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDeadLettersResponseObject); ok {
+		if err := validResponse.VisitGetDeadLettersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RequeueDeadLetter operation middleware
+func (sh *strictHandler) RequeueDeadLetter(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID) {
+	var request RequeueDeadLetterRequestObject
+
+	request.PaymentID = paymentID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RequeueDeadLetter(ctx, request.(RequeueDeadLetterRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequeueDeadLetter")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RequeueDeadLetterResponseObject); ok {
+		if err := validResponse.VisitRequeueDeadLetterResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateDebugSession operation middleware
+func (sh *strictHandler) CreateDebugSession(w http.ResponseWriter, r *http.Request) {
+	var request CreateDebugSessionRequestObject
+
+	var body CreateDebugSessionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateDebugSession(ctx, request.(CreateDebugSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateDebugSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateDebugSessionResponseObject); ok {
+		if err := validResponse.VisitCreateDebugSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteDebugSession operation middleware
+func (sh *strictHandler) DeleteDebugSession(w http.ResponseWriter, r *http.Request, sessionID openapi_types.UUID) {
+	var request DeleteDebugSessionRequestObject
+
+	request.SessionID = sessionID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteDebugSession(ctx, request.(DeleteDebugSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteDebugSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteDebugSessionResponseObject); ok {
+		if err := validResponse.VisitDeleteDebugSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetDebugSession operation middleware
+func (sh *strictHandler) GetDebugSession(w http.ResponseWriter, r *http.Request, sessionID openapi_types.UUID) {
+	var request GetDebugSessionRequestObject
+
+	request.SessionID = sessionID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDebugSession(ctx, request.(GetDebugSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDebugSession")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetDebugSessionResponseObject); ok {
+		if err := validResponse.VisitGetDebugSessionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListFeatureFlags operation middleware
+func (sh *strictHandler) ListFeatureFlags(w http.ResponseWriter, r *http.Request) {
+	var request ListFeatureFlagsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListFeatureFlags(ctx, request.(ListFeatureFlagsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListFeatureFlags")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListFeatureFlagsResponseObject); ok {
+		if err := validResponse.VisitListFeatureFlagsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetFeatureFlag operation middleware
+func (sh *strictHandler) SetFeatureFlag(w http.ResponseWriter, r *http.Request, flag string) {
+	var request SetFeatureFlagRequestObject
+
+	request.Flag = flag
+
+	var body SetFeatureFlagJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetFeatureFlag(ctx, request.(SetFeatureFlagRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetFeatureFlag")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetFeatureFlagResponseObject); ok {
+		if err := validResponse.VisitSetFeatureFlagResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteFeatureFlagOverride operation middleware
+func (sh *strictHandler) DeleteFeatureFlagOverride(w http.ResponseWriter, r *http.Request, flag string, merchantID string) {
+	var request DeleteFeatureFlagOverrideRequestObject
+
+	request.Flag = flag
+	request.MerchantID = merchantID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteFeatureFlagOverride(ctx, request.(DeleteFeatureFlagOverrideRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteFeatureFlagOverride")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteFeatureFlagOverrideResponseObject); ok {
+		if err := validResponse.VisitDeleteFeatureFlagOverrideResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetFeatureFlagOverride operation middleware
+func (sh *strictHandler) SetFeatureFlagOverride(w http.ResponseWriter, r *http.Request, flag string, merchantID string) {
+	var request SetFeatureFlagOverrideRequestObject
+
+	request.Flag = flag
+	request.MerchantID = merchantID
+
+	var body SetFeatureFlagOverrideJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetFeatureFlagOverride(ctx, request.(SetFeatureFlagOverrideRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetFeatureFlagOverride")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetFeatureFlagOverrideResponseObject); ok {
+		if err := validResponse.VisitSetFeatureFlagOverrideResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetLogLevel operation middleware
+func (sh *strictHandler) GetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request GetLogLevelRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetLogLevel(ctx, request.(GetLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetLogLevel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetLogLevelResponseObject); ok {
+		if err := validResponse.VisitGetLogLevelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetLogLevel operation middleware
+func (sh *strictHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var request SetLogLevelRequestObject
+
+	var body SetLogLevelJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetLogLevel(ctx, request.(SetLogLevelRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetLogLevel")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetLogLevelResponseObject); ok {
+		if err := validResponse.VisitSetLogLevelResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetMerchantQuota operation middleware
+func (sh *strictHandler) GetMerchantQuota(w http.ResponseWriter, r *http.Request, merchantID string) {
+	var request GetMerchantQuotaRequestObject
+
+	request.MerchantID = merchantID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetMerchantQuota(ctx, request.(GetMerchantQuotaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetMerchantQuota")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetMerchantQuotaResponseObject); ok {
+		if err := validResponse.VisitGetMerchantQuotaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetMerchantQuota operation middleware
+func (sh *strictHandler) SetMerchantQuota(w http.ResponseWriter, r *http.Request, merchantID string) {
+	var request SetMerchantQuotaRequestObject
+
+	request.MerchantID = merchantID
+
+	var body SetMerchantQuotaJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetMerchantQuota(ctx, request.(SetMerchantQuotaRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetMerchantQuota")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetMerchantQuotaResponseObject); ok {
+		if err := validResponse.VisitSetMerchantQuotaResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOutbox operation middleware
+func (sh *strictHandler) GetOutbox(w http.ResponseWriter, r *http.Request) {
+	var request GetOutboxRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOutbox(ctx, request.(GetOutboxRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOutbox")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOutboxResponseObject); ok {
+		if err := validResponse.VisitGetOutboxResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PauseOutbox operation middleware
+func (sh *strictHandler) PauseOutbox(w http.ResponseWriter, r *http.Request) {
+	var request PauseOutboxRequestObject
+
+	var body PauseOutboxJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PauseOutbox(ctx, request.(PauseOutboxRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PauseOutbox")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PauseOutboxResponseObject); ok {
+		if err := validResponse.VisitPauseOutboxResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ResumeOutbox operation middleware
+func (sh *strictHandler) ResumeOutbox(w http.ResponseWriter, r *http.Request) {
+	var request ResumeOutboxRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ResumeOutbox(ctx, request.(ResumeOutboxRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ResumeOutbox")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ResumeOutboxResponseObject); ok {
+		if err := validResponse.VisitResumeOutboxResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ReplayPayments operation middleware
+func (sh *strictHandler) ReplayPayments(w http.ResponseWriter, r *http.Request) {
+	var request ReplayPaymentsRequestObject
+
+	var body ReplayPaymentsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReplayPayments(ctx, request.(ReplayPaymentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReplayPayments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReplayPaymentsResponseObject); ok {
+		if err := validResponse.VisitReplayPaymentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetReconciliationIssues operation middleware
+func (sh *strictHandler) GetReconciliationIssues(w http.ResponseWriter, r *http.Request, params GetReconciliationIssuesParams) {
+	var request GetReconciliationIssuesRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetReconciliationIssues(ctx, request.(GetReconciliationIssuesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetReconciliationIssues")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReconciliationIssuesResponseObject); ok {
+		if err := validResponse.VisitGetReconciliationIssuesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Reconcile operation middleware
+func (sh *strictHandler) Reconcile(w http.ResponseWriter, r *http.Request) {
+	var request ReconcileRequestObject
+
+	var body ReconcileJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Reconcile(ctx, request.(ReconcileRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Reconcile")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReconcileResponseObject); ok {
+		if err := validResponse.VisitReconcileResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRefundApprovals operation middleware
+func (sh *strictHandler) GetRefundApprovals(w http.ResponseWriter, r *http.Request, params GetRefundApprovalsParams) {
+	var request GetRefundApprovalsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRefundApprovals(ctx, request.(GetRefundApprovalsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRefundApprovals")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRefundApprovalsResponseObject); ok {
+		if err := validResponse.VisitGetRefundApprovalsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ApproveRefund operation middleware
+func (sh *strictHandler) ApproveRefund(w http.ResponseWriter, r *http.Request, refundID openapi_types.UUID) {
+	var request ApproveRefundRequestObject
+
+	request.RefundID = refundID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveRefund(ctx, request.(ApproveRefundRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveRefund")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveRefundResponseObject); ok {
+		if err := validResponse.VisitApproveRefundResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RejectRefund operation middleware
+func (sh *strictHandler) RejectRefund(w http.ResponseWriter, r *http.Request, refundID openapi_types.UUID) {
+	var request RejectRefundRequestObject
+
+	request.RefundID = refundID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RejectRefund(ctx, request.(RejectRefundRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RejectRefund")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RejectRefundResponseObject); ok {
+		if err := validResponse.VisitRejectRefundResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRegion operation middleware
+func (sh *strictHandler) GetRegion(w http.ResponseWriter, r *http.Request) {
+	var request GetRegionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRegion(ctx, request.(GetRegionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRegion")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRegionResponseObject); ok {
+		if err := validResponse.VisitGetRegionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// PromoteRegion operation middleware
+func (sh *strictHandler) PromoteRegion(w http.ResponseWriter, r *http.Request) {
+	var request PromoteRegionRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.PromoteRegion(ctx, request.(PromoteRegionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PromoteRegion")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(PromoteRegionResponseObject); ok {
+		if err := validResponse.VisitPromoteRegionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetReviews operation middleware
+func (sh *strictHandler) GetReviews(w http.ResponseWriter, r *http.Request, params GetReviewsParams) {
+	var request GetReviewsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetReviews(ctx, request.(GetReviewsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetReviews")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetReviewsResponseObject); ok {
+		if err := validResponse.VisitGetReviewsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ApproveReview operation middleware
+func (sh *strictHandler) ApproveReview(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID) {
+	var request ApproveReviewRequestObject
+
+	request.PaymentID = paymentID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ApproveReview(ctx, request.(ApproveReviewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ApproveReview")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ApproveReviewResponseObject); ok {
+		if err := validResponse.VisitApproveReviewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeclineReview operation middleware
+func (sh *strictHandler) DeclineReview(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID) {
+	var request DeclineReviewRequestObject
+
+	request.PaymentID = paymentID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeclineReview(ctx, request.(DeclineReviewRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeclineReview")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeclineReviewResponseObject); ok {
+		if err := validResponse.VisitDeclineReviewResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateVoidBatch operation middleware
+func (sh *strictHandler) CreateVoidBatch(w http.ResponseWriter, r *http.Request, params CreateVoidBatchParams) {
+	var request CreateVoidBatchRequestObject
+
+	request.Params = params
+
+	var body CreateVoidBatchJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateVoidBatch(ctx, request.(CreateVoidBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateVoidBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateVoidBatchResponseObject); ok {
+		if err := validResponse.VisitCreateVoidBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AuthorizePayment operation middleware
+func (sh *strictHandler) AuthorizePayment(w http.ResponseWriter, r *http.Request, params AuthorizePaymentParams) {
+	var request AuthorizePaymentRequestObject
+
+	request.Params = params
+
+	var body AuthorizePaymentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthorizePayment(ctx, request.(AuthorizePaymentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthorizePayment")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthorizePaymentResponseObject); ok {
+		if err := validResponse.VisitAuthorizePaymentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetBatch operation middleware
+func (sh *strictHandler) GetBatch(w http.ResponseWriter, r *http.Request, batchID openapi_types.UUID) {
+	var request GetBatchRequestObject
+
+	request.BatchID = batchID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetBatch(ctx, request.(GetBatchRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetBatch")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetBatchResponseObject); ok {
+		if err := validResponse.VisitGetBatchResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CapturePayment operation middleware
+func (sh *strictHandler) CapturePayment(w http.ResponseWriter, r *http.Request, params CapturePaymentParams) {
+	var request CapturePaymentRequestObject
+
+	request.Params = params
+
+	var body CapturePaymentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CapturePayment(ctx, request.(CapturePaymentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CapturePayment")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CapturePaymentResponseObject); ok {
+		if err := validResponse.VisitCapturePaymentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetCustomerPaymentSummary operation middleware
+func (sh *strictHandler) GetCustomerPaymentSummary(w http.ResponseWriter, r *http.Request, customerID string) {
+	var request GetCustomerPaymentSummaryRequestObject
+
+	request.CustomerID = customerID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCustomerPaymentSummary(ctx, request.(GetCustomerPaymentSummaryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCustomerPaymentSummary")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetCustomerPaymentSummaryResponseObject); ok {
+		if err := validResponse.VisitGetCustomerPaymentSummaryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetOperationByID operation middleware
+func (sh *strictHandler) GetOperationByID(w http.ResponseWriter, r *http.Request, operationID openapi_types.UUID) {
+	var request GetOperationByIDRequestObject
+
+	request.OperationID = operationID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOperationByID(ctx, request.(GetOperationByIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOperationByID")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetOperationByIDResponseObject); ok {
+		if err := validResponse.VisitGetOperationByIDResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AuthorizePaymentGroup operation middleware
+func (sh *strictHandler) AuthorizePaymentGroup(w http.ResponseWriter, r *http.Request, params AuthorizePaymentGroupParams) {
+	var request AuthorizePaymentGroupRequestObject
+
+	request.Params = params
+
+	var body AuthorizePaymentGroupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AuthorizePaymentGroup(ctx, request.(AuthorizePaymentGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AuthorizePaymentGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuthorizePaymentGroupResponseObject); ok {
+		if err := validResponse.VisitAuthorizePaymentGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentGroup operation middleware
+func (sh *strictHandler) GetPaymentGroup(w http.ResponseWriter, r *http.Request, groupID openapi_types.UUID) {
+	var request GetPaymentGroupRequestObject
+
+	request.GroupID = groupID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentGroup(ctx, request.(GetPaymentGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentGroupResponseObject); ok {
+		if err := validResponse.VisitGetPaymentGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CapturePaymentGroup operation middleware
+func (sh *strictHandler) CapturePaymentGroup(w http.ResponseWriter, r *http.Request, groupID openapi_types.UUID, params CapturePaymentGroupParams) {
+	var request CapturePaymentGroupRequestObject
+
+	request.GroupID = groupID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CapturePaymentGroup(ctx, request.(CapturePaymentGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CapturePaymentGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CapturePaymentGroupResponseObject); ok {
+		if err := validResponse.VisitCapturePaymentGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// VoidPaymentGroup operation middleware
+func (sh *strictHandler) VoidPaymentGroup(w http.ResponseWriter, r *http.Request, groupID openapi_types.UUID, params VoidPaymentGroupParams) {
+	var request VoidPaymentGroupRequestObject
+
+	request.GroupID = groupID
+	request.Params = params
+
+	var body VoidPaymentGroupJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.VoidPaymentGroup(ctx, request.(VoidPaymentGroupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VoidPaymentGroup")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(VoidPaymentGroupResponseObject); ok {
+		if err := validResponse.VisitVoidPaymentGroupResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreatePaymentIntent operation middleware
+func (sh *strictHandler) CreatePaymentIntent(w http.ResponseWriter, r *http.Request) {
+	var request CreatePaymentIntentRequestObject
+
+	var body CreatePaymentIntentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePaymentIntent(ctx, request.(CreatePaymentIntentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePaymentIntent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePaymentIntentResponseObject); ok {
+		if err := validResponse.VisitCreatePaymentIntentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentIntent operation middleware
+func (sh *strictHandler) GetPaymentIntent(w http.ResponseWriter, r *http.Request, intentID openapi_types.UUID) {
+	var request GetPaymentIntentRequestObject
+
+	request.IntentID = intentID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentIntent(ctx, request.(GetPaymentIntentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentIntent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentIntentResponseObject); ok {
+		if err := validResponse.VisitGetPaymentIntentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ConfirmPaymentIntent operation middleware
+func (sh *strictHandler) ConfirmPaymentIntent(w http.ResponseWriter, r *http.Request, intentID openapi_types.UUID, params ConfirmPaymentIntentParams) {
+	var request ConfirmPaymentIntentRequestObject
+
+	request.IntentID = intentID
+	request.Params = params
+
+	var body ConfirmPaymentIntentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ConfirmPaymentIntent(ctx, request.(ConfirmPaymentIntentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConfirmPaymentIntent")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ConfirmPaymentIntentResponseObject); ok {
+		if err := validResponse.VisitConfirmPaymentIntentResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreatePaymentMethod operation middleware
+func (sh *strictHandler) CreatePaymentMethod(w http.ResponseWriter, r *http.Request) {
+	var request CreatePaymentMethodRequestObject
+
+	var body CreatePaymentMethodJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePaymentMethod(ctx, request.(CreatePaymentMethodRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePaymentMethod")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePaymentMethodResponseObject); ok {
+		if err := validResponse.VisitCreatePaymentMethodResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentMethod operation middleware
+func (sh *strictHandler) GetPaymentMethod(w http.ResponseWriter, r *http.Request, paymentMethodID openapi_types.UUID) {
+	var request GetPaymentMethodRequestObject
+
+	request.PaymentMethodID = paymentMethodID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentMethod(ctx, request.(GetPaymentMethodRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentMethod")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentMethodResponseObject); ok {
+		if err := validResponse.VisitGetPaymentMethodResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// BatchGetPayments operation middleware
+func (sh *strictHandler) BatchGetPayments(w http.ResponseWriter, r *http.Request) {
+	var request BatchGetPaymentsRequestObject
+
+	var body BatchGetPaymentsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchGetPayments(ctx, request.(BatchGetPaymentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchGetPayments")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(BatchGetPaymentsResponseObject); ok {
+		if err := validResponse.VisitBatchGetPaymentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentByIdempotencyKey operation middleware
+func (sh *strictHandler) GetPaymentByIdempotencyKey(w http.ResponseWriter, r *http.Request, idempotencyKey string) {
+	var request GetPaymentByIdempotencyKeyRequestObject
+
+	request.IdempotencyKey = idempotencyKey
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentByIdempotencyKey(ctx, request.(GetPaymentByIdempotencyKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentByIdempotencyKey")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentByIdempotencyKeyResponseObject); ok {
+		if err := validResponse.VisitGetPaymentByIdempotencyKeyResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentsByCustomer operation middleware
+func (sh *strictHandler) GetPaymentsByCustomer(w http.ResponseWriter, r *http.Request, customerID string, params GetPaymentsByCustomerParams) {
+	var request GetPaymentsByCustomerRequestObject
+
+	request.CustomerID = customerID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentsByCustomer(ctx, request.(GetPaymentsByCustomerRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentsByCustomer")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentsByCustomerResponseObject); ok {
+		if err := validResponse.VisitGetPaymentsByCustomerResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentEvents operation middleware
+func (sh *strictHandler) GetPaymentEvents(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID) {
+	var request GetPaymentEventsRequestObject
+
+	request.PaymentID = paymentID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentEvents(ctx, request.(GetPaymentEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentEvents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentEventsResponseObject); ok {
+		if err := validResponse.VisitGetPaymentEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentByOrder operation middleware
+func (sh *strictHandler) GetPaymentByOrder(w http.ResponseWriter, r *http.Request, orderID string) {
+	var request GetPaymentByOrderRequestObject
+
+	request.OrderID = orderID
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentByOrder(ctx, request.(GetPaymentByOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentByOrder")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentByOrderResponseObject); ok {
+		if err := validResponse.VisitGetPaymentByOrderResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentReceipt operation middleware
+func (sh *strictHandler) GetPaymentReceipt(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params GetPaymentReceiptParams) {
+	var request GetPaymentReceiptRequestObject
+
+	request.PaymentID = paymentID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentReceipt(ctx, request.(GetPaymentReceiptRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentReceipt")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentReceiptResponseObject); ok {
+		if err := validResponse.VisitGetPaymentReceiptResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetPaymentByID operation middleware
+func (sh *strictHandler) GetPaymentByID(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params GetPaymentByIDParams) {
+	var request GetPaymentByIDRequestObject
+
+	request.PaymentID = paymentID
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPaymentByID(ctx, request.(GetPaymentByIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPaymentByID")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPaymentByIDResponseObject); ok {
+		if err := validResponse.VisitGetPaymentByIDResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateCapture operation middleware
+func (sh *strictHandler) CreateCapture(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateCaptureParams) {
+	var request CreateCaptureRequestObject
+
+	request.PaymentID = paymentID
+	request.Params = params
+
+	var body CreateCaptureJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCapture(ctx, request.(CreateCaptureRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCapture")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateCaptureResponseObject); ok {
+		if err := validResponse.VisitCreateCaptureResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateReauthorization operation middleware
+func (sh *strictHandler) CreateReauthorization(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateReauthorizationParams) {
+	var request CreateReauthorizationRequestObject
+
+	request.PaymentID = paymentID
+	request.Params = params
+
+	var body CreateReauthorizationJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateReauthorization(ctx, request.(CreateReauthorizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateReauthorization")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
 
-type VoidPaymentResponseObject interface {
-	VisitVoidPaymentResponse(w http.ResponseWriter) error
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateReauthorizationResponseObject); ok {
+		if err := validResponse.VisitCreateReauthorizationResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type VoidPayment200JSONResponse PaymentResponse
+// CreateRefund operation middleware
+func (sh *strictHandler) CreateRefund(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateRefundParams) {
+	var request CreateRefundRequestObject
 
-func (response VoidPayment200JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.PaymentID = paymentID
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateRefundJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type VoidPayment400JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateRefund(ctx, request.(CreateRefundRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateRefund")
+	}
 
-func (response VoidPayment400JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateRefundResponseObject); ok {
+		if err := validResponse.VisitCreateRefundResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type VoidPayment404JSONResponse ErrorResponse
+// CreateVoid operation middleware
+func (sh *strictHandler) CreateVoid(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID, params CreateVoidParams) {
+	var request CreateVoidRequestObject
 
-func (response VoidPayment404JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.PaymentID = paymentID
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body CreateVoidJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-type VoidPayment408JSONResponse ErrorResponse
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateVoid(ctx, request.(CreateVoidRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateVoid")
+	}
 
-func (response VoidPayment408JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(408)
+	response, err := handler(r.Context(), w, r, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateVoidResponseObject); ok {
+		if err := validResponse.VisitCreateVoidResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type VoidPayment409JSONResponse ErrorResponse
-
-func (response VoidPayment409JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+// CreatePayout operation middleware
+func (sh *strictHandler) CreatePayout(w http.ResponseWriter, r *http.Request, params CreatePayoutParams) {
+	var request CreatePayoutRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Params = params
 
-type VoidPayment500JSONResponse ErrorResponse
+	var body CreatePayoutJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
-func (response VoidPayment500JSONResponse) VisitVoidPaymentResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePayout(ctx, request.(CreatePayoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePayout")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(r.Context(), w, r, request)
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Authorize Payment
-	// (POST /authorize)
-	AuthorizePayment(ctx context.Context, request AuthorizePaymentRequestObject) (AuthorizePaymentResponseObject, error)
-	// Capture Payment
-	// (POST /capture)
-	CapturePayment(ctx context.Context, request CapturePaymentRequestObject) (CapturePaymentResponseObject, error)
-	// List Customer Payments
-	// (GET /payments/customer/{customerID})
-	GetPaymentsByCustomer(ctx context.Context, request GetPaymentsByCustomerRequestObject) (GetPaymentsByCustomerResponseObject, error)
-	// Get Payment by Order ID
-	// (GET /payments/order/{orderID})
-	GetPaymentByOrder(ctx context.Context, request GetPaymentByOrderRequestObject) (GetPaymentByOrderResponseObject, error)
-	// Get Payment by ID
-	// (GET /payments/{paymentID})
-	GetPaymentByID(ctx context.Context, request GetPaymentByIDRequestObject) (GetPaymentByIDResponseObject, error)
-	// Refund Payment
-	// (POST /refund)
-	RefundPayment(ctx context.Context, request RefundPaymentRequestObject) (RefundPaymentResponseObject, error)
-	// Void Authorization
-	// (POST /void)
-	VoidPayment(ctx context.Context, request VoidPaymentRequestObject) (VoidPaymentResponseObject, error)
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreatePayoutResponseObject); ok {
+		if err := validResponse.VisitCreatePayoutResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-type StrictHandlerFunc = strictnethttp.StrictHTTPHandlerFunc
-type StrictMiddlewareFunc = strictnethttp.StrictHTTPMiddlewareFunc
+// GetPayout operation middleware
+func (sh *strictHandler) GetPayout(w http.ResponseWriter, r *http.Request, payoutID openapi_types.UUID) {
+	var request GetPayoutRequestObject
 
-type StrictHTTPServerOptions struct {
-	RequestErrorHandlerFunc  func(w http.ResponseWriter, r *http.Request, err error)
-	ResponseErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
-}
+	request.PayoutID = payoutID
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: StrictHTTPServerOptions{
-		RequestErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		},
-		ResponseErrorHandlerFunc: func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		},
-	}}
-}
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPayout(ctx, request.(GetPayoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPayout")
+	}
 
-func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc, options StrictHTTPServerOptions) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
-}
+	response, err := handler(r.Context(), w, r, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetPayoutResponseObject); ok {
+		if err := validResponse.VisitGetPayoutResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
 }
 
-// AuthorizePayment operation middleware
-func (sh *strictHandler) AuthorizePayment(w http.ResponseWriter, r *http.Request, params AuthorizePaymentParams) {
-	var request AuthorizePaymentRequestObject
+// RefundPayment operation middleware
+func (sh *strictHandler) RefundPayment(w http.ResponseWriter, r *http.Request, params RefundPaymentParams) {
+	var request RefundPaymentRequestObject
 
 	request.Params = params
 
-	var body AuthorizePaymentJSONRequestBody
+	var body RefundPaymentJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -871,18 +7490,18 @@ func (sh *strictHandler) AuthorizePayment(w http.ResponseWriter, r *http.Request
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.AuthorizePayment(ctx, request.(AuthorizePaymentRequestObject))
+		return sh.ssi.RefundPayment(ctx, request.(RefundPaymentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "AuthorizePayment")
+		handler = middleware(handler, "RefundPayment")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(AuthorizePaymentResponseObject); ok {
-		if err := validResponse.VisitAuthorizePaymentResponse(w); err != nil {
+	} else if validResponse, ok := response.(RefundPaymentResponseObject); ok {
+		if err := validResponse.VisitRefundPaymentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -890,13 +7509,13 @@ func (sh *strictHandler) AuthorizePayment(w http.ResponseWriter, r *http.Request
 	}
 }
 
-// CapturePayment operation middleware
-func (sh *strictHandler) CapturePayment(w http.ResponseWriter, r *http.Request, params CapturePaymentParams) {
-	var request CapturePaymentRequestObject
+// CreateRefundBatch operation middleware
+func (sh *strictHandler) CreateRefundBatch(w http.ResponseWriter, r *http.Request, params CreateRefundBatchParams) {
+	var request CreateRefundBatchRequestObject
 
 	request.Params = params
 
-	var body CapturePaymentJSONRequestBody
+	var body CreateRefundBatchJSONRequestBody
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
 		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
 		return
@@ -904,18 +7523,18 @@ func (sh *strictHandler) CapturePayment(w http.ResponseWriter, r *http.Request,
 	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.CapturePayment(ctx, request.(CapturePaymentRequestObject))
+		return sh.ssi.CreateRefundBatch(ctx, request.(CreateRefundBatchRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CapturePayment")
+		handler = middleware(handler, "CreateRefundBatch")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(CapturePaymentResponseObject); ok {
-		if err := validResponse.VisitCapturePaymentResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateRefundBatchResponseObject); ok {
+		if err := validResponse.VisitCreateRefundBatchResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -923,26 +7542,25 @@ func (sh *strictHandler) CapturePayment(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// GetPaymentsByCustomer operation middleware
-func (sh *strictHandler) GetPaymentsByCustomer(w http.ResponseWriter, r *http.Request, customerID string, params GetPaymentsByCustomerParams) {
-	var request GetPaymentsByCustomerRequestObject
+// GetRefundByID operation middleware
+func (sh *strictHandler) GetRefundByID(w http.ResponseWriter, r *http.Request, refundID openapi_types.UUID) {
+	var request GetRefundByIDRequestObject
 
-	request.CustomerID = customerID
-	request.Params = params
+	request.RefundID = refundID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetPaymentsByCustomer(ctx, request.(GetPaymentsByCustomerRequestObject))
+		return sh.ssi.GetRefundByID(ctx, request.(GetRefundByIDRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetPaymentsByCustomer")
+		handler = middleware(handler, "GetRefundByID")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetPaymentsByCustomerResponseObject); ok {
-		if err := validResponse.VisitGetPaymentsByCustomerResponse(w); err != nil {
+	} else if validResponse, ok := response.(GetRefundByIDResponseObject); ok {
+		if err := validResponse.VisitGetRefundByIDResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -950,25 +7568,32 @@ func (sh *strictHandler) GetPaymentsByCustomer(w http.ResponseWriter, r *http.Re
 	}
 }
 
-// GetPaymentByOrder operation middleware
-func (sh *strictHandler) GetPaymentByOrder(w http.ResponseWriter, r *http.Request, orderID string) {
-	var request GetPaymentByOrderRequestObject
+// SchedulePayment operation middleware
+func (sh *strictHandler) SchedulePayment(w http.ResponseWriter, r *http.Request, params SchedulePaymentParams) {
+	var request SchedulePaymentRequestObject
 
-	request.OrderID = orderID
+	request.Params = params
+
+	var body SchedulePaymentJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetPaymentByOrder(ctx, request.(GetPaymentByOrderRequestObject))
+		return sh.ssi.SchedulePayment(ctx, request.(SchedulePaymentRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetPaymentByOrder")
+		handler = middleware(handler, "SchedulePayment")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetPaymentByOrderResponseObject); ok {
-		if err := validResponse.VisitGetPaymentByOrderResponse(w); err != nil {
+	} else if validResponse, ok := response.(SchedulePaymentResponseObject); ok {
+		if err := validResponse.VisitSchedulePaymentResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -976,25 +7601,30 @@ func (sh *strictHandler) GetPaymentByOrder(w http.ResponseWriter, r *http.Reques
 	}
 }
 
-// GetPaymentByID operation middleware
-func (sh *strictHandler) GetPaymentByID(w http.ResponseWriter, r *http.Request, paymentID openapi_types.UUID) {
-	var request GetPaymentByIDRequestObject
+// CreateSubscription operation middleware
+func (sh *strictHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	var request CreateSubscriptionRequestObject
 
-	request.PaymentID = paymentID
+	var body CreateSubscriptionJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.GetPaymentByID(ctx, request.(GetPaymentByIDRequestObject))
+		return sh.ssi.CreateSubscription(ctx, request.(CreateSubscriptionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetPaymentByID")
+		handler = middleware(handler, "CreateSubscription")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(GetPaymentByIDResponseObject); ok {
-		if err := validResponse.VisitGetPaymentByIDResponse(w); err != nil {
+	} else if validResponse, ok := response.(CreateSubscriptionResponseObject); ok {
+		if err := validResponse.VisitCreateSubscriptionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -1002,32 +7632,51 @@ func (sh *strictHandler) GetPaymentByID(w http.ResponseWriter, r *http.Request,
 	}
 }
 
-// RefundPayment operation middleware
-func (sh *strictHandler) RefundPayment(w http.ResponseWriter, r *http.Request, params RefundPaymentParams) {
-	var request RefundPaymentRequestObject
+// GetSubscription operation middleware
+func (sh *strictHandler) GetSubscription(w http.ResponseWriter, r *http.Request, subscriptionID openapi_types.UUID) {
+	var request GetSubscriptionRequestObject
 
-	request.Params = params
+	request.SubscriptionID = subscriptionID
 
-	var body RefundPaymentJSONRequestBody
-	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
-		return
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscription(ctx, request.(GetSubscriptionRequestObject))
 	}
-	request.Body = &body
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscription")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSubscriptionResponseObject); ok {
+		if err := validResponse.VisitGetSubscriptionResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CancelSubscription operation middleware
+func (sh *strictHandler) CancelSubscription(w http.ResponseWriter, r *http.Request, subscriptionID openapi_types.UUID) {
+	var request CancelSubscriptionRequestObject
+
+	request.SubscriptionID = subscriptionID
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.RefundPayment(ctx, request.(RefundPaymentRequestObject))
+		return sh.ssi.CancelSubscription(ctx, request.(CancelSubscriptionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RefundPayment")
+		handler = middleware(handler, "CancelSubscription")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(RefundPaymentResponseObject); ok {
-		if err := validResponse.VisitRefundPaymentResponse(w); err != nil {
+	} else if validResponse, ok := response.(CancelSubscriptionResponseObject); ok {
+		if err := validResponse.VisitCancelSubscriptionResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -18,55 +18,390 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xa73LbtrJ/FQzamevMUDIly2mimftBsZVUU1tyZTn3OrWPCpMrCTUJsgDohvX463mA",
-	"84jnSc4AIPhHov7YdRqfmeRLZHK5AHYXu7/9AffYi8I4YsCkwN17HBNOQpDA9V8DH8I4ksC89CdI1RMf",
-	"hMdpLGnEcBdfMPp7AugWUiQjBEwkHBCH3xMQEtHi4yY6J6GR+4PKBRLqLyt3xTjIhDOBPOItwEccRBwx",
-	"AU10xuFOzQz5SRxQj0hA3oLwOYjmFcMOhs8kjAPAXawGaxweuvCm47oNaL+9aXRafqdBfmi9bnQ6r18f",
-	"HnY6ruu62MFUTX0BxAeOHcxIqBSUltpQa3Wwmh/l4OOu5Ak4WHgLCIkyQkg+nwCbywXutg8PHRxSZv9u",
-	"OVimsVIoJKdsjh8eHuyn2qS9RC4iTv+EsVm+NjqPYuCSgpYgYZQwuWrsnn6OKEOetskeNOdNBx26rov+",
-	"F31/6DZd91XZKOqNg2cRD4lUJmLydQfr2dIwCctzpUzCHDh+cLBHuD9lSXgDfHUKR4T7yLxEe62DRust",
-	"8umcSlEZF3da1X/YwTGRErjS8Y+rK/++deC03j58j1es5WAvETIKgU+pXzOB7KUKLibpjAJHMx6F6D31",
-	"TgmXlWkoTY3O4evaUe7u1izvDjidqVijEUN3JEgA7R00OrULbbUPVtd24HTqVwafY8rTaRgxFSm1gxsR",
-	"pEXQXqvRalcGbLUdFXyZ+9rbfJkNmALhm8dTEmjv8vLysjJc2z1wS2O03XanbpiI+2vcleUHLbCTy7Rk",
-	"w5h1eR+Vd+QvxaDViHHs9qlGsnH4kguqBrrOR4xufgNPqpUdkVgmfP1WjUkaApO1a58sAGXv0eBY5UfP",
-	"aKsseMeUlW/iJNGL3Gyb0rTqVtXnPOLjLMmuLgrU69XHXuTD6ipPibegDBociE9uAkD6a6SFHQxMxc0v",
-	"eDD82DsZHE8n497wfDAZjIbYwWe9y9P+cDLt///ZYNw/Lj0ZjibT96OLoXpmP+2dji6GE+zg44uzk8FR",
-	"b9KfDo77p2ejSX94dDn9qX+JHTzu/3zRP59Mz8ajo/75+WD4ATv4dKB/TdVLNdD0/aB/UlZ9PulN+iXB",
-	"4/5Zf3is1Cqh0iCng/PT3uToR+zgyeC0P7pQ89E6empN0/54PBprxZP+eNg7yR5c1+SCEIQg8xqD/piE",
-	"hC2b00pvc3xmdite53yReB4I42gbhTMSCMhlb6IoAMK08pXPz0xorataU88CiY21C9eUpdW8otJqGMup",
-	"V18Ph6YORTPEQfIUZeKiXpctu/6U1Oj6vwWwfLf+QQQq5Mtz9YmEhqShMjJLgkC5yMKDFRffEHY7VXpq",
-	"08M7wm7/pxjHlJvB8c6Ks2SySXcm8hitHGYJ8zcpNRKP0XkX0Y0a1fsd9WUr2tGHVvrJHvQ4ELnzaEZ4",
-	"3WA1OIdzhTbrQI55k2fRvFpcnB8/HTINjpfrbj1CAbF+wdVwzcTR3g/IJ6kw6isir55s+w1wwlq9ABTb",
-	"K6SDGXyWU50p1i9PyWTZhAqkULufBH8hgNYjo5GGRDu4xOy3XYPQSj95xkISmYh1MSnzwTK5osSrkmnq",
-	"be9i8uNoPPikS/pR72xyYar7+97gRP8Y999fDI/1z4+jgflhQUBdrVQJYlcDGNknLn+pmuo42oIzp7ae",
-	"5ds5t2ElfyxXs+v1tXU9MvOJ1P3n9xxmuIu/2y969/2sxdy3Bbpa6lesJhfAkVwUXbgWBmO7XYDAWEfa",
-	"MyFjE7Z/KzDOG4U6T3yM6HMtTQXkV0b8SpiyWWRQPJPE06vKiI/e2QCdJ3Eccb306nqyaEJzIuEPkiIl",
-	"PIs4inmkQouyOVJtll2zQHLBo2S+QASFkXeLFABQQiIVEsLmFbti332HrNYTOgMv9QK4Yg2UJRD073/+",
-	"CxUpRP9pk4j+w2aPLd+YzLIsZHJQNo0S5XPFekGAwkRmhY35cUQ1yXI2Op+8QpmtEWHo1yWm6FdkqCTl",
-	"7NjwVSW6SgWO1imaV2wMiTaZ2neiQojlT+x2tJSYerFMi2nqS1KpwykrHblNPxSewg6+Ay6MJ1tNt+nq",
-	"mhQDIzHFXXzQdJsZf7HQkb2fo14d55GoybhjEMDvQCC1YwWKGCLIJkaNObnfREc68QlEilrNcj+o9AgO",
-	"umIWsC+hitwgKngcRJiPJCdMUG1GZeaSqyOe+VTHVq8WnpCZBI4yjEJniEUyx4bGmLmXBr7aEdYKNpc6",
-	"FVr0l/oEXIjsL9GmD9dms4KQ7yI/tdswa6NIbGKFRmz/N6FsnHdm2ik3RFBP/RBJGBKeauAsqFe1mvI1",
-	"CRIoE4iG/6sQenXUXAU9lkkzzZFlHFeVu2q1l8glwxQVcKdE5JQI0G21a4UbfajmOVW09QOzEbR52m7r",
-	"kQYttXbd+8JqtrhWG1ljw6VO1F3pJ3HbbXcabqvROpy03O6B23Vbn/ByD6i/apAbz9i03F7UKHA/lWGF",
-	"Bf9rvVXG7rm2drsyHf3Nrix5EcTTW0gtx34LacbP1Xq7QJBVHJjE/qa1tj5VAIt29O5xswyc9Kf1hazw",
-	"G8pGmyVBkKrRO677yEii7I4E1J8WjH0eTTmJZmizVRorJ4CsFmS0oEbLdSvG0OzMI6xRpfdqbDHIBrS1",
-	"ppTctBnePNIMmZ6pAtlRstkOBW9WGCCfRwEqlCofKWVf1BJZnqkO13HfPjYOSlslpCIk0ltsjoZ6UrEU",
-	"E4VGDRI4JAJ8Uxp9OptB1oyVHfflzVRGfRGbBdSTqgDbANaFXc3kcKed9GzRLIEzEiCNS7hhTDVALgpm",
-	"XlhQUdIlmQvduGbQFV+rb/YtSb8WAB2ZA0iFbTjc0SgRQVpOKxniaaJyLxAmQqIbUDCoBF60wZpXbMQ8",
-	"yBGJo7GP/dAjTMGVG8g6W9RAEQvSvNGvwy/ZqcXLQi9r0Em5mdqtMj0isJeOb3ZCE4+tATnJWIclVpha",
-	"Jd74nP75w5u3eInOrFTFTrdtEcBjanZee3Pa5e+pqnYhT6ypX6iuqT61RFeBmVDn75uQNY/awbMoYf7u",
-	"9fXrF7hndor2QKmRQ/qU0BSRF1kzsuSxvWJY7mPfQvP9e/trcPyg5jqH2kZacgqqkyZBUBAos4gjgkQM",
-	"Hp1RL2+tTemPyZwy2+9Vk/4HkHZe71LL+a/m/lW6ylt/PlB7lULfnomJPj/PKKRiuRuvzaxQWKuHyfpq",
-	"g71hEs1KvFKUUSJ2Br8nwNNiCgENqZpyMZoPM5IEEndbbvnShOtuvjWxOqth7WzELY3XzCWazQSsmUx5",
-	"dLdm9OsnFaRioHrCmEoIxSOY42xahHOSrjs0rjDoG6ji1d13QlVyLsz59fOy3nVyQUW+G15kStKGy8/z",
-	"8jRUZKafE+DK9UuJSbfn+/f6v91SUkHbGR5aJeylzKS1bUhD79JRJrE1BUVrDsPq7wXVJKBsZY/KPn91",
-	"pz0TdCrhgpexA4xfX2L4f4CC5r5JkT1C3R7/99mvp8f+TYqoFCipHkDrsdfHv369NfhXdKK9i4vB8aun",
-	"HBrVbI186Rs3x7bTpm+bZRnGv/TdsWFfZKetG454zNlTGDFIs7Rf4jryZi9nOq7YGq4jP7izTMfKfjHH",
-	"yN+oiupx+rMxFc++Ay3v9KI6/W+N/Vdo7M9WGMo8NihDXnZf6OVywmbHbW/v9QWO9WwwYR4EW9lgdAOz",
-	"iOck74aUuUIPoyNjYH1N0hxlWy01+fRjRF9qNv2i+bN8Y+flZs+Mw/+WO7/lzvrTnf+KzKn2Guot3T6p",
-	"y57qK62mrvc5iTwSIB/uIIhiQ9JrWezghAe4ixdSxt39/UDJLSIhu2/cNy2dlbKxVu7U5netNGjV1yso",
-	"m+sbRCFhZK7+iAvWJOuQzgoaaotGbnrEkppyk1hotHD74frhPwEAAP//p5oMDmA5AAA=",
+	"H4sIAAAAAAAC/+y9/3IbOZI/+CoIfjei3RGURMl297Qc+wdbort5LUta/XBP79BHQlUgWesiwCmAkjkO",
+	"/3sPcI94T3KRmQAKVSySRUmW6FlP7EbLZBFAAYn8nZ/83IjUZKqkkEY3Dj83pjzjE2FEhv/qxmIyVUbI",
+	"aP6HmMMnsdBRlkxNomTjsHEtk3/OBPso5swoJqSeZYJl4p8zoQ1L8h/vsks+oefuEjNmmk/y53oyE2aW",
+	"Sc0iHo1FzDKhp0pqscvOM3ELK2PxbJomETeCRWOejYTe7clGsyE+8ck0FY3DBky28/p1S/ztVau1Iw5+",
+	"udl5tR+/2uE/7/+08+rVTz+9fv3qVavVajWajQSWPhY8Flmj2ZB8AgMEr7oD79pswPqSTMSNQ5PNRLOh",
+	"o7GYcNiECf90IuTIjBuHB69fNxuTRLp/7zcbZj6FAbXJEjlqfPnyxf0Ut7Qd4ajZpeF2xzM1FZlJhKb9",
+	"jdJEipj+Dvf6iKepZmYs2A2XH1km/kdERsS0oZy9+vSJiSxT8EpDlU24gV2R5qdXDb+kRBoxElnjS7OB",
+	"j66ahhs25EmaT/DaTcBUxqS4FRnLBB2YW1S9qWnDPweHF3HJs3ljYevoDISmjaoxtJ5FkRCxiDd5Xut+",
+	"xo0o/CRWs5tU5L+Rs8kN/ORLSBb/oFcJVhmuoJmfZb7dpSk/+AnUDRwnrMkRSAVx8PCrxIgJ/vEfmRg2",
+	"Dhv/Zy+/yXuW4PaK1PbFT8ezjM/h37T1/anIIiHNIjlcjnkmmBoyKe4Yn5mxypJ/cfhSs2iWZUKadM4y",
+	"NQNSNApJoXycfsNLu1eauxm838qNubD8oeL2cMPrbokOCGDxvf8cCzMWGb6PY1Th2drV3SiVCi7x1RYX",
+	"HN9yGYmjVEUfL2iMxSVrESkZV6zgd3XHhjyDTZ2oW0E7C0OxocrueBY32WwK33I2Fzxj3DDOTIIE6a/W",
+	"T7/sH7RaFddywj8lk9mkcfhy//XLn1rwzCSR9NH+2pNzi648Jksk4pzPJ0Ka3zI1my59/WimjZqIrJ/E",
+	"JZ4w02bn1eufqriCyuKKX+CnO/sHL6t+MuWZqdjkKyTXLNa4kW7lTZZIhsM1GZcxG6s7NplFY6YkA5bX",
+	"aNa7feEOnPMMt2fCP3Xptwe45fk/ileztOP+lZuFLXMvtvIggs1ffPsprZElmk14LIjbiwSJfwBb0yfe",
+	"N8CdGES3twMQAJwNpDB3KvvYN+qjkINmT5JQuFFmTNK5xLwmalbFYdr4Oex4hKL+hdgd7TbZ61arxf6T",
+	"/cfr1m6r9WNI069b1RS9gnybjeBNqmReFjP6kr3Yf7mz/wuLk1FidGHexqv94v9w940RGYzxf/d68ef9",
+	"l839X778RxUBRtyIkcoqlKg/EhkDhx0pFZOAx8OGExmqbJe1/RkNcePdSPjoRGTRmEvTk3xm1E7Ep2aW",
+	"Cd1kWuCeDuDjvv24b3+aCD1owvj28xieHM7SlHHNtFKScd2TCRKFvxQxEgCX+k7ALwZH7fOr64vO8aCs",
+	"iuHW8ZSYjNOLfnpVtSfFy186FPsl6JHSJMNEZGyYqQl7m0Tv4DI1a3KL6PZ2yZHfiiwZglqZKMlueToT",
+	"7MXLnVeVh098pXTeL5uvqk9bfJom2bw/UdKMFyfv4LcMv2Uv9nf2D34EAjCWGTXhgtl/20vG8JKxZMiU",
+	"FHAso+RWFLZ9/yBg6vsH6+6DXSBIjqXrgy/Zi7/++uuvhy/voPUyFDEHrYNXlbphyFPWsddTevgKny2J",
+	"hUo7xd6rGvS0QpbUZcyW35VoobjzVWz7Vy4/XojhTMYVCmBNHhpYCTCQiMOX2399D/5ZUP0q9xiESeGp",
+	"1avAEXd+Hr6MqvV++MXSeWDUHzTrHiNLJB0NflCYIBO3O7/sRwfLxxdxn5tK3S9YPErFwhS5lcCN2LEq",
+	"12oqyd+nYisDWgnXtZo4LoSepRUKVdVBLbx+ovVMrLteF6DkRUma4FBd/AncspmJlLXgJNDLPxoXnaOz",
+	"i+POcaPZaJ9cdNrHf/WDj65P/T8+LD2KdYs5m4oM17FAHQ/aePcyq/daL1Vfafj69lg+ZlEX3LcKuPvn",
+	"Gm3QTbt22esMpQ0XbYmuwo78qobUr9xE48WXgKWmwvhbXHkv5SxNOdjy1ouzqB5kgq8ZY+E35BkJqC/g",
+	"lEnR+TCbJXHVEH7nax6BicZAHFV7PxUyhlErl5MJrpWsfb0u6HE4UcPNrOJAj87enZ90rjrHTMlIMKkY",
+	"vAEI/fPO6XH39LdG0zOG84uzo87lJX3of1jJBgqum8XXoE9ClvP2+hS4y/uzbtWApQuTn4F/scLJFx03",
+	"9njznXXH9WEZcf4mjDX2lvOKpMQn1pLIvdlDEq9YKoyxTLPoR84PXDxz+07omwU+wOjxN0xNEgMf3zmR",
+	"ibRAD2l2N+YGbcJEs1QMDVnTILJvFayxlrvwcW45OuD6kYpFFYua52uns2+ymU7kCD9un3d/0Nb1CQPo",
+	"OvMpd6GW6jD+CWbpkFmVyVp7TTYUJhrDLKSn7vlf6L3P/u/u8ZdGc4GW1q7PTtKvya1yZuCvtr/sl9dH",
+	"R50Oyfq37e5Jp8Z9DKb3gy+l2If5+3CIry+ikjRN5KgrjchueRruVMznjWbjTgjwjzsrwKn/ubrqvlnY",
+	"+yMy0pfyldpGgXL2/i47FkM+S+lDeu0JTyRQfGjv4wC7RTPuHrZDkdaWu6C6x8Eaw1kbNQM7a8h4OQ1W",
+	"kR66bRd3W6q76rcATsTMONEskdpwkI3ZTGqmZE2TodlQw6EWpr/eJexdwWOu2Y0QEl3EMeMQ1XK2uZ5r",
+	"YGj4YLibf/vpVeUhrvH5wosvLHHpxj3sztLef+07e6TkMMkmVnDD1ZVmuaP62R2IW+DGeiRv02ZeoYWo",
+	"UX4QtCsbu1iOUOB+43z1y9IXOxY3s9Gl0Br1+aXaqI939z9Wxfbt9jAl03nuDYkwPJxHCojh5WNBjL+x",
+	"Vt+onglUxXk+Dc1CDp5EOymxns03G8akq5loqqxup2mX3AFqZjI+HCYRuxFDlQmWGIbEJHR4WhAwC+jf",
+	"MtQN4mfhApcTaD3GVJNMHx5BeZJI3Ybe1bWb906YsYq3mKvXCoE41zu7EUC6wF5qhz+2kocXzrLI0e/F",
+	"y8/5XM1W3JEoQvN22UkfC20SSQLUPmsPvsleAS9/6aTpLjsDfpgYzVKuISQ3y+xXjGeCUSqTiAvcvdFq",
+	"tfYPXr56/dPPf/ul6ozucYcPXt/rDmcZcOnidby+PN6UZYdq+40A+eY817vswh40sG6y+FGE8DRVd2jl",
+	"Nu3DercOM5/OsqnSokasXc3MuX0Y6S1KpsmqF9AiTeGEVWYjq7Ss0Aj/QTNHq4UDpZ/uLDnOTM1MIkcB",
+	"uQUK2H6L/lcjZhC8QL4PQbTAH2ezTOELa1h+dS5EwS++9A45cpggR63c1EsORggyKqNY5gcmXWFRO1JS",
+	"hJvN7nigWuzWMuiWvhScpPUeLNOANvLABiM6P2x9/9y93bBlx95SL2T42o+h0dJVWDwynzFAYzGpjL/6",
+	"bC4ewVsQ57y43pkEzPthO71kUy9nN36zHry1lL4aW103ce6ih4ZnV6oR72baMHVX8C4yusa1tYgkcGyt",
+	"9LaV/GCBHCkwjvVsP+Wymm27lJcfNIOHgnh+4W2mmdpBJSKdL/FoZmZ1+HeYZNrYEwMXdjwrWXhS3e3e",
+	"Lx5czuEq75B9/4DX+wNYfvvfq2QNy8uN0D4ZOItvj+qNXVAh+4d+QLaYfcd6Xq0SbS61EYq8eF005PEY",
+	"7IrdfPB1t664mfR8kzwCsciIy6aCQ6L7WVFDiopy0Xv5pjyDdFs32BsWB9QIJrMaYtDFBVxC30TJANyY",
+	"xTzmBlu95UoZnlZsbk6lS+JR+EMngtQwp1fMW78TmUDBRNGIJrs8+r1zfH0CIcssjFKGLLdVLxhldz5f",
+	"WD5Iq/Ygm2jhPiNkccYlJsBa2ytXGssbvfCCC/NXch97wa3JfTmbTHg2XzzXe3kQwMrqOwa5kl274X/Q",
+	"kKwutCnolTbItoxr1Q6YRdUX/9xRoBoWFgOsgMs580Hn3IlUWZCAj9EkFXR/Ss6IkOITSkm2ExTnBr4B",
+	"kyeybsbyJY5yhO9YkWlg4N7pZWxPs6nImKOv4lKmPIk3WEeRQ3xZE++ulqaRlZzFPfUvUZ+SHxjLqBzz",
+	"qwc3jgWPT4QxIltcNjdGTKZVBPZr7uOlyU02Z5BkKTKyzHK/6IjfCjabFsRKtT7P436KK1mXb1eYLh+/",
+	"npqBjIIqoCrVRlu7RNcTHmZ2G8I3aKD3mR79B7xEJnlKo344ZLOpNpngEzaT/JYnyDDYC6KvQ/a69fLH",
+	"FX6UmsUCy8KUjWZ+bIWXrdjhDyvp4bHSwvIRnzwhDCMcNmxQ5dS1MmyTlK66aVuLYZOFZ6xpVfWVfeH+",
+	"jYrnVf4TmRhUtt3GwHNMgwyz9rcts6sYmM60xsj0IA3t3JXsZl5v+DwRZPGmz7J0fUImbmt5F/2e0SCL",
+	"8zULh/phGUnYoNdSktAbEHdAYVV1c/fIGrSRpCciywemAK35edWpBu9XSq7z27/u5B4masORvjoP6mRc",
+	"V7Ofe5CG12B84UM5AW+a8kiU9LvuscsZExnXeLkjlcW6mGcvley/HB7c/BLtx6/Ea/7q5qfob/HP4pdh",
+	"i+/fHEQv41cPIb2i80L3Yb75BHhNNZewz2/wYCYMr66JXqp1a5OkKSTiJD553wgJv2JTkSUqblSbuPah",
+	"fjQzajhcMaE95AWvCBmfwavVVV904GUs7005LCkjkYqYFX5S3oL1hmAxqkqEV7EH1SdWdTwraWHFGxaY",
+	"xYflV+1hzMEO8gR8IVPZ8qV6DbWcy16VmfqOR+NEip1M8BiVzTwLNciy7p6+b590j/tXF+3Ty+5V9+y0",
+	"0Wyct/961zm96nf+ft696BwHn5yeXfXfnlH29Nl556INvyh8SsnVhY+OO79e/9a/hGTu0sNu2Hedq9/P",
+	"ij+6vP718uiie3617Dfd06vyitxXv12cXZ+Xvzm7Lj78a/vq6PfS0t93O3+Wlt4+7p90rq46F4XP33ba",
+	"UMDYP3vfubjoHncKX16ftq+vfj+76P435bWeXfzaPT7uwM5edk7e9tvn5xdn79snjabf/svub6c4YqPZ",
+	"eNe5OPq9XXq1/7o+u2r3O3/32bLtd2fXp1f9q7Oz/uW79slJ8aOT9sVvMNbx9flJ96h91enbvYFzuzju",
+	"XPRdsc15uwvDHbUvjvvvOydnR92rv8J58IucEC46v8GBXF61T49//Qu+/719dtnvnv5fnaOrDu3r6R/9",
+	"C5jypPuuS5+516QVFtbVPe68Oz+76pwe/dX/o/MXTvFf153Lq36hAOBdF//qw5ewlP7bbuckHPryqn3V",
+	"CR487oCzDoaFh4JJ3nUv38HRN5qNq+67ztk1rAfHIGLuXFycXQQDd0/P8ZGLs+ur4jkHVNs+OTn7077q",
+	"VefitH1ix6kqV7DYDv3lZb2XwuRp8WhW2t/EIXtpMg0V/NqoTAwzJQ2LuGRGpCk81ZNeoqFb1ygWKybF",
+	"J5Pn1pu8Hg5iPANgDoM3TAtRDGT35KC86EFPBmwkVn2pTB+NcBIE2byfckN5dk5O8AiFgxckzQY8poBt",
+	"9iHyW7lbE6E1H1UwuN9nEy7L7M09fY+cBPEpgWDgyL22VY5g1EwMRQaO8iaw9DGUOhustU5GieToOuds",
+	"sHDZBnVyFKyLyRpACzIlE3ByI2EKXn3JJ2R4DfK3GhRUtz37hd6rmQC9JuhEYsNtb5WkDSSjX8aQp1rU",
+	"k31vBTezTLxN+agic5oXqwa5nsuo733QDQ9GYlMXiunxpe8qDkHdiixL4g2MvGC5Z/bH1fVV68BRXEiS",
+	"SGpIw0IQRklILSnEGlpVmudsGgc2wzL3mEpTNSN3NjqwYE4IJgOFhbU4SYoeukT7LAssIIF/CHmbZEqW",
+	"MynrRy4t5E0O2pJv+4fVFOG3eFEnknD7QzPAU1mz4fZ2IWow4dlHYdAuWrvqcJCmn2/Ngh+mbwYDfXWd",
+	"M5jrsbx7peU/qXvvRI1OxK2oCA3GYOH3c36pV5hoqRrB5Ygx80Ex/GlRbKY4SfP+JXnlXUndqr1MhUlh",
+	"BjlUjWbjjmfSoUEV2Zt9YDUV0/AfVuzYw0jW7/vXPuB39jr+10wZXrXWJJ33TcaltupGmkySCtZ4FpYf",
+	"ziQ+JaptexrzVqWzidh4uBpB3fuxqWZjpkUcvqqu4XQwKuZz9uL66ujHyrXgmPSqS1MUrLtgWjk2AiBN",
+	"EqkyNpOJqVWpuZLjLr5lcZUf1hHJwwi7MNRXp+4CLsni/pdAU14cn7dPfyQJzdkdT1Nhmq64QrAom0+N",
+	"GmV8slAKwRLZk0hY7jSP3r/fZVfFX3kFS4OdkchRGpSYamVXYT/RPckzYeH9xiKlat0JlzOeskzcJuKu",
+	"Ctspn64qpKjFT6/8moOVvbhqv3/PVMauj9pvf2yygxa7mRuhwVBSZbiQ9qiN//v146vjP//71dHB3+bX",
+	"/0Uf/WfVvRJRsriWTioikymZRCxSEyBRwRIZJxE3KsuDIRWbX0znfr2Y439Qnd+/LOMcicMVFyAch4/B",
+	"YP6so5EX+wdL6w7+9svrlz9DZnmr9fLVz3+rqDs4WFJ3UNbpfDVV/r5VNzIH4diwfrycFkXVivZ9fSlz",
+	"PUYLtm4/gXAW8KtquxvS+MNs7hzIRUnM3QedgNsvR9yIOz6nLHXyoFdedTs12pFCRqLSCP2VLHEb8Ghi",
+	"oTtTmVtM9xjTzc1YLAIqojt/iAsvfF4LxKJUJL/EgvBbnfO0poP5VBkaFg5X9N6JMsXwx9qF0JwWXqC2",
+	"t/whScJXMFhxjD6YU68W13tSLvGwfNbViHCiLBRsQ+FPOdEuZlu4sMuqP8KFLJYOlAJB9L3jHA9az4pS",
+	"BBf9qYbR8odXSL2tHSlaV1CySCBTkcHomBtZZ6Z7g554SuzfVLj02uddwjoGX5x/NGc1XmDy6TRTlNe9",
+	"9r6QVF11YZaPj5tD/xCWzTzw9vrVrH3/qmkfuBVLIWcIaDZkX/gk1BoR0gi/cRCpfmvMOBN6rNKYYao3",
+	"Qhra3E/vvafypBsRqYlwiaGk/Ydvd9Ehxzh9I5XZLfhNVyJhNBvlOdE7TgNW+krpg2UgkaGwdAuwSIwO",
+	"DKeZg+NcdHwUoyZGTgGYowyYUxDya6N25etVibvCyxKyIA+abKZJURgmEiEVgKQciuW/yhsxzPisENS0",
+	"AzWaDQ8gTqBffTXsawMgAx/KFRClHy6cT/BaDzFJCoBmX9Uc8TM9lmuosPQndQydzcyN+nTp2UTxJVQK",
+	"krTPR6Jm9Tf8Acu44wkKVQSbx6xZ+ATy6/8lMuWuvRT4cShCf3m9+7q5HjW86ZamIsyOXaMcVS/L/bYE",
+	"uhSuq57uNOVwqZYfUCzShKqTNLPPVinD9NXyN/HDoADHh6t91FJtuvZK4XRFUE25gKJn/TqWTr7wksF0",
+	"ObJaaTLQsBL4B7MdCtxhGcVuhJu0BLx50FpdWLHIHjfcRD9VQyqTY4Jokd0mkStOhlW+br3U6wFyPPpZ",
+	"xc3ydPRhzT19IJsMRvrq7OUc3ohmXIH4WOO0/M1pMj0GW9N7FBSOzm549DFVo3scWYCp/LrVqjrCitfy",
+	"CcjV/QSWg6xaVS+vAgt8hqVqu2SypIfDRq6Bej4Amwm9rBgjL5OgjPIgcXo5su0qXhYWkuTP31vJRj8C",
+	"jLPKhVD2DdQe2IF9r3dPbDLqCkReO6h3cdQeE1S/VSPC9zXHy9ODV1JboXAsL8q1PwZ36JDX7KBSyjJf",
+	"QzXu6SabKG1YJiLqnEHAA+7JEI+dgP2/mhdmg9qoxcGDArYqSy2a+xS1deVt9fBPusfkH8+huu+RkPMn",
+	"ZtCEWaKQahMrMB5nJsyjAUrWlBJSAGS8GyfRGFzjPQnvZ10vxEPpR3B0Brn9IRuECTUDdgcZqTfCP8dH",
+	"PJHNnhwEiTYDNuFzNoJU/0zNRuNcbmDHJPQM44Pwu2UpOQM2UkL7IXw1Kf46FoYnKWGVRCrL0Gxv9iQ2",
+	"dyhm8gwY1x81UajEj3GIXfYu0QiOOZOp0CWwcS3ingw2DX8OnQ3Q76nV0Oz4NCjuTe7c80O1qw5SxGSJ",
+	"iHefKkupmJ9f5YgpsGT7OHvxM4v5XNsITvjIj/e+vuCTBR6+Stso7HLeqknNvB/Q7nSTQUsNPLw+LboW",
+	"RCiWv/fXs7kiXIotmqdfsTs6VaTEe2/GKFOz6VqvIT5V2JREI2vNINjZhOYEXM7vg5W6whXqp9rIEZpX",
+	"b61gV2CpFreWAIfopHOUNGTgKdcapo932YDSgCF1jU0El5oSCV2UI9GkFMEFSwx7oYVgg4I+ZTu9QJYh",
+	"XbM+N4Mf37DBeefiXfsUBu5JGjnx63HXPOcO1joNVgqGNT1evNJ+wegts3NAFur1Zfe0c3nZv7g+Ae/W",
+	"0UkXM5p9cufbi/bl1cX1EXq/qm60FGaNQrAoFMZoaSVOGfBgRcxwCNlRSnydNmfh/i25OvCM1VHBRx+N",
+	"RTxLH6BYLu+AcZbF9eRobZifIpBI+eI5nBOjHnLz8nYI99Dq3I830urWN4YIFaegscV93e0IAHG/F3TB",
+	"n4JUAp0ZZOvQiMyyv4SneUAS7rZU5GPK7MecvFY1tmedh95tTl7ubu+4h2DIPeKNZqOQY+8aCoXOc/Jk",
+	"dxzQO/4RJrK7AWg4Sv6v9qonE9EPOiEtdRBe0hcV0tyVYBYkXNN6pPEf7PKkXWnBLqGDYGPp2OpRHT17",
+	"T5qrcvqvQVnMHf45dEQ1iH7RLl9mlS2718uuQwUfz3sFNj4s93hg47VNcxXornnVPyOMhQ2cExuZojme",
+	"VpQprfNJa851r1rDDeBH1oH31CwSDAXTJr35UKn7QRfgLnCsDVvwVUYqlvAymJe+c1TgVgHEaZgWxqQo",
+	"ADOzNRzu0a/1kpvraHNN7G+h8+G9EZQC3yPWumLXuix+MMzcdzzvLcGCzdEsHw7sXWw5+pC4QzjSE8Qd",
+	"AqDn9fKqjlxIETh0STW5N3rCsjMCwycJlOBK0J3wxuXFudxI3+mFnklC9+EikdOoz9Ci6VGl3FdEblgj",
+	"GetnTLkzA3eA23U8wfsYXov9XmzR5mX/6Oz0bffiXdvWGdt/do6fQiiFumZwJh/W3alH4QU01FMxg3ce",
+	"ROYR0SVWkXcgFtYy/Ht3PdswzzLK5XApc3F/f3H4lfgG+C+afqVYqavaOGD5RyAse9RPRFiPsuSnWyyk",
+	"Iy4udZjy0YjOaK1TmglpRGZj1/+ciZnYIN1kM+iu1ckcZahr+xIFyrZcEIoq+z6DsW4ro4afvxnu0Id1",
+	"+/tYiWHFQ3vq5DBCgF8O/u95z/qM7HtoXBjQnuISLJt9frWGnO0PUr1cIKoan+QqjJkC4dl4tg/xhVU1",
+	"tDn1Ay418smTh73cgxCwHq8zQY3+ARs15OueOigYRE3pbtKYD998TeeBlTpa8bYtvEsd8RrsVvB+1HKh",
+	"76nI9g3+sNiWIX9mYdtcn5AHikAY/WuzswsRiWRq7llqVZ2ltCKjqpwFVY8drc5kCthDRTZT/RyezbNx",
+	"7mlnggfiJuOy4l1uE82bbMK1ERl1wecT8anJ4kRHIK2b7H+iG4YFsx+lupN5IBRYYlBeuYCoDqNpwg1z",
+	"1WcUH61e3z1U6FJQNn9NlujdyokeKJk2t0CkyRKxSTcOvBwdaQhKt6xoPCTwe++I7wamfI0KpiWRz81j",
+	"mPe7C8u845cFz7hPjeK61L6WJUaLdFj1boVY1yPEsAo1K0v9C7kXIZdaZXm2YbiqIixVYIjl6FiBx+ZE",
+	"/2E59ycCfwyPYI3a1YBdu4BmWLnaqFF3Wo9VVJc42egIVVzZAqbVB4/fLp5iuKYVe/vWrjVXMf6HTKdp",
+	"PKyMIdvfPUx7sIM8hfqgZJSky/tbQoS7aEYctA5+2mnt77T2r1qtQ/y//65tKxu1ZLCDjQcrHTMuFCf4",
+	"sOJFkyWV6dFYRB9XgoeCIJzJKOXJRMRFTUUz+/M8E7MIkRzcMLefNd3DWs82E3jBW3bhx9Vy75Ppu4Us",
+	"wSHL7FAWFcqiyTmfCaKjUvnmhJBNuUQIqWwmaTPun5RMJPIgCmj68/RbuJ4oaLsWKKOsvAZemJkZ7/w8",
+	"fBkt1XmXiUevVsBTTPO5fsP4jXaZsA7ZsH3VB4zFguvHh3+XZGLGwojIsrXaZGbT/oL15hMG4ej7muAf",
+	"ExmHHBQQHK8vQ3zGxTe+Pv3j9OzP0/7VWf+39lXnz/ZfCGh5/nv7tHPcd/FuF1+4Pr3oHAHc5XHfSoUP",
+	"yzIq77VBm4RYikZM3oszx05wJeEE+rZ2+9Zl/WQFMva5XZJVb1fQUqjcEQUV2vuxX1w6HvSCerNImBVH",
+	"UfN+PpYTsianfBLhmzxCoXBxrCdYerHP4bM3EbxPr6qNjK3l1sSHpftzXMQf2azbbHsJWkcO0/GGZUET",
+	"VRC/lLjpakewkOEu0WKjLrMPwhYpLam09vq4Ik73vwdkS5X2X+uIrirtDVKIPGKFFmjyj4XnlU6o2Xwj",
+	"5H3BC2PyRD/KRJyYohuy/GSFJfHt9+msKzi7x/k6w0kbNTFtvxbYy6bXfrTkrpvkVqxjxSNMH+YfhbaY",
+	"rbqypJ4G62d+rsVdtWNBVDBxKAaC6wD+dSZNkjLunkxcSc40UxNlSuHGmd4RvBrnQkxVNK5eBH4VvNoP",
+	"/rUYD7yYDKgtsyAKmyzroJa7zf1yDZgCakNYOoOoYxttVD0VssZ5URm11HcCERzEZGrmudEV1ObAPcX0",
+	"ndEsc1ankqLeoS00kB4RPoolUnemy+n7oZrK6Gk0lGnK5856X9uvuhIk9iqEvkT2BGM+JhTsMgORVl/E",
+	"3kxsYiv9hiVSG8HjBYQfiu6BmeiSgcEecn+HicIfauECXNp6Ix+2f5g4KiltW9jEOHTQ57/AT3cokbZe",
+	"OdRa2vCVXP2hypYxJ5UH6cKXesMm8Ko3gsgC1BEzy8T9bLc12XZLeg8Xl1/FLi6FOUJA9nPCAV9+C3Ps",
+	"9LBNYJ5r3AoThltrs4XdeEsWVQE3vnRpK1DHS5OuwgsvTrrRPhx8xY0ogecuWVVtoOXzvMFu3oAbi9Up",
+	"woGdP6+vjqAS+k0OnQylf1bcFjHwW63WOm5QB7C5XBcXQBZXrJQaeQcrbRbhwF1gaP0LvLYGzoYsrpIJ",
+	"Bx1XK7oCzco0s7oqr45zr0RIeVxMzZbRU9C56XHS020Lq287K9ynVy3rpnoEulQ0A+XLpUQFfbqoYpKo",
+	"snKX6vb+u39r/kJz5WR5jMT2fiW1JQAwcXcq76S6cRIVRixomNWKPDxo5wswdH39NCLn5nlnUjRty/5N",
+	"kz03FPXYp79ehlb76Kr7voM5WZdX/ePrDpaEnR516mdmVXc6DmU3rmdVppYnlyAkXjqERdJem7YVsoiH",
+	"WRHhSF/dlvje5P8hTh2IR3yrLh2gLBHNssTMwQ6a0JG3p8kfYt6eUR1EAq89FpzqTal/UePvO+3z7s4f",
+	"IsA64/irxpcvX2ynDxTd0vDI5I2PED33cjadqsxY1OcKRutcAfAwJo5lCqgffD1YDJEbrRbeh7OJij6i",
+	"NxYe0nNtxGS3J3vy//wf5kY9SYYimkep6MkdD7rz//0//y/Ly1Pxn05pwH+4ytQ1v6GwZfkhyjeFT31d",
+	"LH6+YqDd3d3F52kc9kIj5gRugYWwyVtvFrPq45n4EcahUtkak7IXHuz3Zo7+IIRAzsqjuKV4IVN+Gre8",
+	"m3fi7cl2mrLJzFi8IBlPVQJn9+L87PLqR+dQh3jeIPgZ0NaAEdnBLZtmBADqIWxzEGC925MXYqadKxBR",
+	"ogAAs4gb5bgjpWLbJrI8GgftoHd78g8xJ/+djtQUoTsKOjQ1i7hT/gONWvVMi3yij2K+25PtYMKp4BhT",
+	"4LSssdIOQME9g34PwMbKZhKBDkfCaPaq9UtPDhbbCA5sa4zBBUj9nfYQUbMSySygA2HXnCiqnh0wLVzr",
+	"7J7Mk9VSrdgouRUS8tYGefe2gbO5oTm2u0TOlNI92eHR2C+cR0bbnhyBpYGePnWH4DiaDTy3GFAEB4sp",
+	"tUB8Lm560v0uqHHfZfkG5oBOsH2FGa1UyGcmOC5C3wo8ioE30ShPc0qKXdaWLluVEr1uFWS6wEz2DPaR",
+	"vnApdNrWNQXeCZ2MpIgPg1fc6R4PsKsdUdhHMad3Hvx95zIZSTSSBz1p25L9/q59tHP5e/vg9U9OgoYP",
+	"7lwlE6ENn0wHzeIXpyA6B03r/Gn25PVFF+eBQ2OXv7d3Dl7/1ITp8x4TH8X8B+2+gw3WhqeCGTdHk2UC",
+	"I2sSBu+BFXmXAUywdtP6LWGDhYafA0cqFyoVjkxgG0Gmc5apFDabDYhTEMibrarl8Ru8/3Sllf0SCdRa",
+	"1lzGPQmu65z3UyiQx7ajm2Mr6G5ngz0eTxI5oHHpbxw0VgDDY8aJHBUuab4/sFAWK0FuaNRU3Gu/ZAPf",
+	"A3WwyzoIc0pOfzQOerI4O+FM2TiAvVR8FicG2nLl7MmD9cIYLDFuI9FtoWGVBRP+RjBvmNOYNlYJO2JC",
+	"Z4Aa5tuVGLuXuicD6x/Q4y1pK98RDEaHd2avDn5hg2LH1sEu+xMhd7l9LtE9qYVpMoHb4VvrRzzLEqGL",
+	"WpoawkKodRM07hn8fQffcucqaIu0c4GaYyJHA3d16KH36AcJv34RODt+dPtmVdMTWJ7uyauAFeD+KdcR",
+	"Jd+mclMg2FkBBOxsBiBdKe4C/un9g/43Kit0o8bcGIIvs/qmo6NWTw7KbW89axQBjr91jMFP2KDcFXfw",
+	"hp6hTpY9mTMdPBi3G8deYiL8VcWGUOMCuCnF3B7HZFGqoRO1mSeKw2Jp/3qS6wC+E4kYaDuRjBfDODJW",
+	"d/aCOjDFoGc/Ck7WNT3phF9VJ9L82vimpTk4XPcYDm4gskxlu0FD0d2efEsQbTn7yISNM80kguxjC9qg",
+	"Ziu22Jg3giECI2FV7i5uH/Ip4hPIz+AI3WbATaOh8IIDK4RJybJs4hVAPE0WcS38phSPQWUVtObOhgYP",
+	"tIXFpsiD3MEH0tgRfcb4SDgiQciN1RdmrO7YhMt5voXwokFxMlxyFB9DlRExwyLH6q4n8XeOdHRzBXmg",
+	"PC69P6xcJLgzSNmOQIA5Vbd7Ll4NVr4Z+LpEAkalcU9yBEhEVVbzFHPXEjkS2TRL4KpTJzArRd3LYma/",
+	"V4+oORgVfS7uIHbgwK0qJdbSVefSnUqZ7eAWcuTYmsC3UqU+Mm5If9xll9jFt4D2aAOsdFEOWgcwKGnw",
+	"TdfuqScxccWFWnma+lAwIal7Y6Ag0PZIz9cD4Iahm6knhwioSlppEbdzwFzL5bifyD4NkSsLGC6tYkoz",
+	"SRrtrciw7drINfinC+OvWiGjYJe1ezKX6dy1h9VMK1CV0Dqk9KI86E2tVGR8Y1W+162XqHaHDcIHb1DZ",
+	"IKLxW2wwewYziBME20NW6zQ5cPT4CzbmSjOD6Tijnrw0fARricU0VfY6kWqJrDjlxBHxEtFu2gjtWPAM",
+	"hCtCm6DqpmZYUYXaEJZS0BUaDqkZzYI8BoXo7zu4np0uTidiZ2kRgXA6TjpapAb2+tOnnPHm/ffZoNg1",
+	"fbDLzjMVz1CO22sDqpQt5UoMOkgs8KI3zH/Lzf1Gs3ErMk3ugP3d1m4LQ5ZTIfk0aRw2Xu62di3Wzxh9",
+	"FZYwHRAafjYSpirknJt92rUsXmg/5pPPrHkI0b0c3djfHjUz2C2HhEeGZEnqj39WJ8635agaO/AAOOmV",
+	"X0KcqSkYK4rSQ4K+bD4tgtbwg3b3DRg3HUAGMl18ioSIyc7ypfK03d5A7saNQ9iUtt+kZsORBW7YQavl",
+	"vDU2PMenpDQkSu79j/VCkcdpnT/KT+IdoOgRKif/2V1ynay/NBuvH3ERHdigVQtA/z/oDtBiQNgdJX/Y",
+	"bIIY/oeN34RhvLRQJAHrjMUDgL00fKTRtQ2k2PgAo5TJco+OEdY9nVVQ55HlUuuoE5aReyVK9EkwjNYR",
+	"SexCzybCwWCiXFUTbpIIu2hD+4UFMtGlmHbDtwP7VcXzRzugZaHzL0X3pclm4stzE6tdIigStkM5kOur",
+	"pyTXYAngCIGeDkAvtI5fnm4ddGb+Miykam3lPYZemMFtmfq9XH11CxdvD/2ttgvOWvkS+LMCT6vXm8kB",
+	"WJiApXyqhXaKMQoZUojBMFFSaKvxNdEBORZzZ5v4cIvKXGlE4BMGNa8nbfAlSOzdZe2SIppmgseg3GvD",
+	"Bjms1ADE1tw5GtJEm54kRRH+jc/ZDoUq+ygyBo2ecZ4JswCOS4RRx25ocR0o2jM+EQZl+T+q8MCHPGN8",
+	"jJ4fUoexUfNYzVCwYbjinzOBwPg26EC72neP5LRn3YeNQ4Do81koPx+01qUwLCRm0W+DrPFiYh3QxpLF",
+	"UVZH5aooHcYt6/W6dJEvHx7IKh+OerOmyMQHyQpx8BXByQrVYcGAmqG+hhdoK/nPSaIN46uWHURNV/Ik",
+	"uM07ti4fz0vpSk4EHkedF/Av6f3bBEVmzLBkC5RunqJfPfIOY/BS65nIHM9xmuwPmt0o9REsFhONg54h",
+	"pNrS3+xO3IzBVoVF6p4UFDWAJcGUCThDplMhrdFIvn01LPDPN2CPG24E2baTqcqMG282he3bb7XIp6gZ",
+	"JZZA3EFG5NfoBFMmdrW58uRDzjaLPDFlgA6yqch9a51cA9/dcuBGJpu32JAZQy4OQsHFpdyEMFBP0nd0",
+	"JmhF3qlZGgfNPDPBKCpxM2cD33hogIeBpagCtgMPqydvlLXN3K67vs7B9gW+aIy7wyNgxWhsxAUuSes0",
+	"crWGAxoBY0poalJQxjaU5sbuKCgBOQaCytAjnJfNlsP7LlpPA2GCyiCvbySPb8YT7A4D1mpPDhbKHwfl",
+	"okAkVBeYqZI49OrQUslu8FdSbIMZnkmnLaxgOefCGt0bEaFvd8iC69l00t76FiFE+OQa76ny/EtlQRE2",
+	"iESGpVfuAllQF86GiUi3UwMljlzNkFcy/CiF7qx1VE64npQ/D5cAu8RmM4l4JNw6b0FvwkeFNgxHztNt",
+	"sCqkJ0mtslyYsijoSfApgc+L3PJBz6NgsEQzmyO8ROU7wrf5iqSPE6y0X3AFT201XS3sE1hNdq+21vVR",
+	"PN31ZLrH41ugu+WKyTt1KxaoZqiyO0SS0qqsJJEV0nR+TRRWLJ7ZaPBCTERb50wQgaNsPuvpRxwjUD2G",
+	"+Y0Zc/i51kizcE60KHQuT3C5dn049kchptqnaKDb9w5ld0JZN3iLmFEUxQpK4wma2N7LoAxKv7F2Uz4q",
+	"hkW0ACvICJf5hDNjUkOSoXvQXkpKpmManNfqE5qERFalXa66kPa48kv5+MKwHUzxTNKwHktgdjOe3rPT",
+	"lbc8TWIWz/Ju0t+Z00rmZKlqIwblQo97n92f3eMveyLjepaJVabUNOWRZVoBkET32FktGZexmjDEmGfU",
+	"CDmb5xkBqQ39WpcONXBPMJ4Fuwk8isBmXGn7xPPIQqdCNWR58h8cUU/a5kVSiNil4X0UUxNmI0CKxq0o",
+	"sMRd9pea4Q/DSHhP4k+5JtcSz5x1gLFxjKm7x/sZLEmKePCGwf4VQTYoSN6TLrEXsn8gwDkCo2NmXM5M",
+	"kPbmbJJm3j7OJog5IxT3lkxL/BPYHNAoBOYJVBXsF5ZIo4pr6Vb6nnDRrqnmor8JXTQQXso9NDnJNMqs",
+	"K3TblDPXF70x+494kZByVzI2tw34ws/H2YLj2Ep20kEi5uH1pmg7TzGCu5KxxILHO6kwpm7osSLV0fqD",
+	"nZaTcYlOfmxs3pMDCw/U//Ps4o/ORb9/0bm6gEyH39vXlxByRQMa1tGndQyalf3zKV/AOkXAp4sVtwkW",
+	"3u4M02Q0dg3LCokAeFNnS1X6Y8HjE/v6a1y3/x4u0lXkGGzGKqI8zmkmUF6313U5Dcseqc7IOvtHwNtn",
+	"U6Zk7Uuy99kOB/LXEhcVqq8gnSssLCn0Gu0esxfX193jHxvNKpbtJ1nJsddh4nxoLtELfufoomNx1VGS",
+	"ODJqcb+szjDMhB5jUrYa9qQrL3IaAPGKxFgvKWamWM+qHYbCuvbbjOPV5Xd8Xu33wi3OSfNr2t/l5gpV",
+	"kUy7R46vkFh69YShVLsA0CAKx7elniPcpmWktuba3cxGO1pojZG1pUruEYUebPIBBx/2v0TsdTSiSnoN",
+	"yC4HGSU+UVpVCRHS4TT1ZKCEYg5RMcuT5RFEuzzXR5pS86yMILt9wvVH9E3JmB29f08fkqLsQ50upZvq",
+	"KFQGWTadTzwyNkdMDb0CCz50ciAMglX1P0K5icMc0MJU3SXKnz6Gbb2kZX8lu/loYaKNrOf9R5Ro4RJW",
+	"ibSb2cifpU10ejaN04DTB2nv6urkWRnMEDJntzMpAs4oADAbDpOIxeExbsBb9j7bv7rHX4i/pMKIKths",
+	"NXWwyi6Zip7VZDjTJQ75QhAXLV5G+l3pMq7VIgpvuE6J8C/1UCWidD8rgOuLF4je7ellY3EV203AHYgj",
+	"r6XY5mqLDHLiIotMEL46SjVy5eAFcfIuz7bx7bQrTKJvkCRbzywyPJ1tA70zlVl9ZHujMyXSR1YKxrwl",
+	"0NVpqUPC6tmB9ljr3RZ0D+xvsF9XUFnrclXzeljyG7guSC5juvy99dxReYsaDvHpTIx4FkNscZcBkJC2",
+	"KeRO2cRYiw+FYBIreEowEGOwCvA2yZQE8Vulv0H2WgBT9FXToMN5Vp3322Bbt9gHgImDhaXWpq+9z/Cf",
+	"LxVGfgV/g0dXsrYA4wzTOfsug7zaeq+da53TZ/iqnpB38wJotD8wLBBj/dbNLPoojCYP/JjrMYYyM57k",
+	"Bek0Cfi0kZx5HOt8QucTt8lVPeliftMkolRKV2I6m7r8UO8UfNvB2tvLfv+offR7p391dTKoIn1dAOj6",
+	"ekndFShgTxzwK6xgOeVfWN7xXBnd17bkHNmpyoKs5IUM761MqOYFdkBl06ntN7YRX9jzF2Hvs/tzjRlR",
+	"5U93/raF1VRZDRUAeY3nJ0m3FOfceFaafHJd7Co8TKqTZcrtiM1IcgvbQjfdBOs4w/BOQWFSOZktmihP",
+	"KxablTPkV2/TGGeljL3yF9RtQ1HTK1zdAv7gmgusK+Etn0SglbE0t1OweS6ihfnfxUGchrbljgt/QEUR",
+	"ahsKuEuxUo6marSTiluR1go545OFStRUjTBT3iWJ+whfqqBdMovhDI2yJmZulVW5O07U6ASX8hVJ382x",
+	"autP1IjedKsTKlO/ykpBsM5cWX6UPmafCXS/6+bi6WKeQU9Sxg3ZMZUHHvBjbuyciYbSBPohJTbC8zyP",
+	"9DgoNspRDKMtFLssAG9klFdlsz6lhYJXWU+6dG6w1ZlGfzEuamSNqckS66ZAho8vCfzwT8z0N6L8Zzdm",
+	"aBUqY0apIlbIVheGrrqUOdOttlP2/jlThtfiw4jlTAgkulzphJeVkJJI+6UON/jQxOIlvbi+OvqxigUX",
+	"YK6/Jh8u4Wkv33184Jmcut+IGkBO3MBeIPL4pz3D+1gJj6zDF5JfVxEvlIXhNxbja2Yzx9CK3XUwHwBS",
+	"YouMLYyupWtw6FKqKcYEIYHUehyluLNTLuH6i5T/VYyAShz5J5YEG9695xIFLgZPx/b98q/yoNW+/LkQ",
+	"UjNzoz6tFTceqwu7GiQmocx0VAI5AvBBmkssID08y0tipYL8a9qWnoT1J7ZOFG491JXqJo5N+t9YYIK7",
+	"JokFAwubx35nUantDHMsCZ1yEGoW/oZPBCPQCNIIxSdbzcoNG+xNhMmSSA+WZJ2e0S58xdtGM1BbgpXW",
+	"Nj6HGX+pGm216aGKS11PZXt4YMvzxiibIxjapifaQ0eFvUx+FqIGaSLH1LWpzggHp2cTgpIDCCpA6fTy",
+	"AxGs5q6ikewMS9RYLC2m5o2vX+jJnLoTaUtZsSrB06RNowTpdYOF7DeCILnc77iuIuAcq9c/CWsk/GF8",
+	"E2YfpHIxdGpRTy342F2nniR4tCoSxwcDIn98oXaezxDIsy9bcKOO3Y7TJm7llcLdy2lDDRcJvc79IgJc",
+	"fsFOhFlxv0hTysHnqA3ETDYp/y6RQZie1tST2NEa05OJUP0rQPWi488XsKycmKWylBt7LD2pHIBuRb4x",
+	"vNP2MOjj4m3f1ixfWNsDCMp3M19OTJSyjq4jxPx2lrlvI1JVmYLFqAH6PhWINC1gRFWKu8XfqEp1Z3mm",
+	"ezGZWWVAUbYAt5TjjvZvMcm4kPPOMm77X3Dpa10QWwXkA+BcRh8h6KAyF3eY5CiaN1zGSoLSAt9FqeBY",
+	"gjubOsg0mlDNjINK/Q1yxSwWaeDj0gOYwGKoDyy0tplp21JPM0Ired1qBfuOeTGuTAdrcUhOvIGCPbCm",
+	"wp4edNl9kz77nTaBeJQKoNSTeCegHfTxOaPMmm0B2n3F9Q3bCm4sfGwYKmw9iH+HpO6XD1ZrUP/SbNzy",
+	"1BWDBF0L/1GvoQZeh7zdzMKMxcJMbWbRxzwTNZzcjRH0G8RrXY8fVPdl/Aq24reA1eRJk8jqGczRU3tX",
+	"u8dwPzLGcxamzLi5gC0CD26piEAq9pyZCLh422uJCALM2UHAnI1LFqtQdzT1lXXN7i3wKUd8hWahTxaV",
+	"IFYZdFWd8NflziL2gV2Bn5zYJKfqZ+ThiIliu11V1Rbicutl067sOLm+3NGu9d+k2LHqyFYmXFWRzvYm",
+	"PFZS+gYXbEW9VVt/1MWSKQfUNQuBhIsY6MNlOlpPOm8q5AX+A8yBJjPqRwLSWjKcn32UcVfhnxjbjUWq",
+	"AjaaL0yk4B8CRcWJ5qNMCI9nZnfoEHSkHTa4vGpfXV/233Uv37Wvjn4fHOYzwjlnPE4ia9t44DCxO9q1",
+	"ycG+A9NYgCXPcAHUYgnudg5sucMG77rYEqffvur/2j79I5zKN/GA6FQ42Q8lpDcc6Pr0j9OzP0/7V2d9",
+	"m10ZjoU7wVSGri6CkiEwJ9fOoDwi83tLgf08TsoNu4O/bJ0BussNLuHs4vz39mnnuO9QQ9tX3bPTxWWs",
+	"mtlNCyuomNl1HjDzaQK473PEAHP9i1xUOcq4HufldZrfImo5g+Yk6L8rTploBz2ad3rLsUaphwc9gIii",
+	"ibYVdj15nssz8KhU2xvUj+ljMp2CedpGaPhoLKKPgV7tA9E0HWjaMxmlPAEds7Ba/SYH1ybsDburE5WJ",
+	"Zk8OsA8g3CO3Uk//eL1djMJZ3iyC5C4daOTEDMn93JP0MPnjndfqU4I48PZSLcOrI4vu6ziAHDt+rmym",
+	"ojjYQHBks6cHo6OTJl5ir4RR3nDGRF342iKyoC75ch+662yJgPNLtRx4lsb2XVgmsAVaBWAdUkfR9F4j",
+	"/+C271DnCJ7eQ7ckboE+KuRcdqSmizKs0h/ht20/9cZQFG7yfxPlzDeFXKOS0Ut7iJBg07dZM1ux6vUE",
+	"qvc+0x+QvEG/2wiAgn68rk7PTfF14CcuBYpVt5aFG+PieXjdS6gS/kLbHhZNBi3GkiElnji+ICSOCkB2",
+	"vire94TBIVyvIujRaRuYkUzF+n/UNazcAvXSvEGD2yZ/eRRaKzQdKPDA/6J/M3dV+ra3JH5EyocH3sV+",
+	"Ug6EHhSCoEvTIt4dLZ+IvvEUl2/93cv3FE4pbD2C7Bbl3P7T3UGrC+QtvLDronRnTOt5+XTraRcoDrYl",
+	"oLaQvgIyejG47Jy87bfPzy/O3rdPBj8+eQ6CPdpCBsKTQvoFC6hikl4bmHqkAqe6gHEjJOZxWphmB1Td",
+	"k9uJBUgU4nnhpgKAWt99a/y/s4b9c80uOtSRyV9jMPZcQSIwl90KkwP2Yrv4I61JxNvJCr8zle3WFy9s",
+	"Y8t6zGGUKLnWaLkr9NsfkTMtx/MtdqPLc/CdS4Qbpvlcu66PdgRskeahMfEjN15CHV/ILwapVxxdONgT",
+	"MdFO61mSJ0XN9Rpf1Ywfrb3Io8Q12NvmTl8YJ6DN/0Hny11PMnvTTE2UWYV7jTSxlGJIZ6YPS+TjXXxi",
+	"qqIxdYsEJCwKoEJP9ETN3LLZXaaMoN8Q5VElCGaHQBx8lCnUmwlxGlv1wcJ70vZSjETlsISpBc43pjIW",
+	"YSB5mIMuQOjxhmtxCESKKU89aTj24bfvkRenUI4K9lH0twI6kQN7HY0t2gPStndb9uRdlhgjpN0MlxeM",
+	"O+LewQk2C+ZiF15KV6FiE+ptiHPYDkrUn50yExPNkvxIaADGfXtIdzmjSEyrk5ItMWzRzbMrip8FWTpo",
+	"wV7sFObxk2mjtjLLizYu5Axr2AE2R703DKzX4ybUTJbGW4XmWs3zaRX/69FYPRgl7kcNxLaiS4l2/xtA",
+	"Za1YdA0qLeCw3sMNth04rOQIMwsBKezo7oOY7l7RqxecY96xEPEs7knXx8Ep/U2v+sMzR+/fF0FaQyO6",
+	"7Fez6WwFh4X39iTldkxWO4D1rfRd2ePdBgTXb9B19UwokCUCfAbTDeneVV/FIoJQ7Jb7c9y9SuR9uZpt",
+	"mPztcbW32PJhOQNDVkPVA5W91Tfw+dhfbxVjcW/0nYd85yH34SHHRD8b8xDIUNF7WAq03JZ/Dw9RKKKi",
+	"s68aBhn2WHPk8uuVFtQu0fWqS43Imj2ZuGQsZ54vahi4IpblMO/WUxRliREZpvzgfBCn60mcBMdA7Aau",
+	"jevi7zjVLrv2vTMLqAyY1uSi7T1ZVKnWpaZbVwXa5t0cWHvnD1B+VE/C7gY5Mme4OYVbtrCfldnruBuU",
+	"O6TSlA1+61wxOjSh9z7jH93jLwO8K1OR7bixMqFnabXNTgl0cLK/ws8XTacqks0f2Qte9w9gEx8ekjbv",
+	"ixKKWeywuhAj2R1OgIEOnhgRZrLnz/TpmcZh46B18NNOa3+ntX/Vah3i//033h+i1opJ9VREUBVq6Tmc",
+	"AD9xWfr4j539g5cNO9jOq9c/NXAvOL5nQ81MXw372mATvvo59UfF89koY+ngEftq4tzLedOvdPPQN/Qc",
+	"qe7KcQTeZFLl3KaCUSFTKt/STECRV09az0ycDIci870agCNsJbtHIvW3xlIpsPybWbo0Y8ndjFWtv3BO",
+	"l2ypZLFFENiLu4woUxdFzXnn9Lh7+hu13m2yvMNHyUgtpFbZUIHP5Uc3THByKmNv290TbKfek4Um2K53",
+	"gk14/xkTz1gyJI+bBazGn/2JrYq5nsvoP+G6DApJn07mHLQOGNdMKyVtRzD/Sv4tdU9SnwVc9lRkoOwG",
+	"Wc2gebIF2bbLkGfDh9cXJ8w1uh+cKCKfAYP+oyIL+kO4GVPB4TDsQkI4VhoCX6rvz3VQxNZKtEeeG9ly",
+	"aHh+nCmpZtq64oP2wT3Z9jPDACNR4ZmDoWi3EskuOu+7nT99eIhK2nqysNngWkBR7b3zc1x+Jgotl7xh",
+	"rTKvC9s1aTX0+nHTRg2EgyifishoNsEqEa1ZyoEaXItvn/JKLb17sioLsIm/vhEL5jwFB7BSxlXGxcLW",
+	"cfWkIyAtDMYsfHPYAp6jAq5ITeACPCjrbncBL89/7LY6wsPPnK3Tj7gRI5XNB5AxZLJ5H991QNs6ROuJ",
+	"mnz2JBUnJtrvJDNKVbpYHOmc+9YqD9MCFipC2igZClSdTCYiTrgR6ZxUNrcIJIXy9Vnij0USq/bHDnmq",
+	"q2q3HqSh3HCdREVF4Vf4qMjeCorIRM1g7Nct8AgD6+yT37lx2Hi1X/xfo+n77fWT2HbfQ1Wi2YhubxuH",
+	"DVIxkOnN+xMlzbhxuH/gP5kLnjUOD1ovW02voDQOA/VkA83D8Vnx6P1XCiqf19PgX37XXE0f7V4/omJK",
+	"2kMrUvoRbWyrGQzSR6fDQevgFSh6+6+v9luHL1uHrf3/bjQb2KcenqVdgb92+E1Ee2orSZYN0PpvPJws",
+	"AxpvHDauL49XnZYVS8XRDg4Ky8Hf1KrvhGcL7Xoah/jJzkcxD7XO8mnnRaGNXJw2mg0LkLNis8JySDzo",
+	"+nSziRs1V+TtbMNZmqKzoZ72WqAkp3zen44elwY2Od91x2dlwVOdi91KynIpaAshm0NNuuycaTZIj8Ez",
+	"ccrNoooJOpBRbAo60bCUk2ffehVuV7NxAcJtpw0SuSo2Eimb0o6YP9ZMhNkosWFh5Dwu96W28RJSX0LY",
+	"T33H7gMaJMUdh4thqu7p+/ZJ97jffnd2fXrVaDYmQms+olXgKIxGYTv7rVbhyFGmbXDmtSGrnD8jEPu4",
+	"DX/bcBvsOH2TTISard6Hq+67ztl1cQP8OvI6KINlTDDYV90J5wAtTFfPzVigg4BRTxI9cR615dRw3Hl3",
+	"fnbVOT36y9cMFmmi1D+OTFXSCnM7tXhwX3+bggOCxIY0ibDu2BEw2n+4gwdP6Kg9zpHEFkAmxacIu0UX",
+	"6oGg9IYbYRH8FsC5fYHQNkaLvLp8vtAP0X6ircW/4CGslaaBDy+EdmFfYVfgv4nJMzgq8zGWuBQXQ1A0",
+	"15rIk1391nZ/qukkex5kUJr7W4AFvbFE44j5v2YiS4SjZevTWdHRc8yzEbmlbC5fOg8VTUuwRUPclfkk",
+	"BVc8ObF6UmV5VTbehynPvE++6NeiUt6ZDFxPZzLKe6c1C4pO3szdlhDvUKccStm1no8/xJRYk6+cRV0l",
+	"cwW9EHSI0oQqicfo2ZhpcAudn11esT13QQvhYbucapBB++Vj+QIex96uwMepq11v4m2nV3/0uuDwlRwp",
+	"VNopaKLaJ6xBwac7n+b/+vlvvzSa/reLFsqrwwNnoWxid5QAfzrHT2Rh5L0ES3bfswC2Oq1TZQUbRGxH",
+	"/9R6Wvjzq8GPfCh4AiEWnMq8qvnkmuVVPYURvNzbrDRa/rZeZXRODb332f0JcsPejh0/4hI98iQZCqAg",
+	"ZpThqaacxCCC5EPvbgePLt4dBsi9GBfgGQpk2M6eJEZFCLwT8OvDVudivZlzFEohIAHqfs+c18ZD9Epq",
+	"o5i6HAGX+ggYG26hPkLvlwu+fM7s28Nb/UtkSu+yk+SjoAEXXxLbOTQRLINLoGcb3QG/vTY+UR9b6E3U",
+	"sv6NI2GO7ND2qC7tGdRQr/0LdY+pduFtEr3jmWk0nWQqea4Wle+cCjbFV/9q+nb1fqy6Iu4XOZen32zn",
+	"XXWLdUIhP/Bq1dgTjN77nBPPamsvS8QtKsv2+jRRE2Uqs1eI+YGAQBOjXbYg0sEiOLX74Nd597gOZdrR",
+	"8llCGzCnzZ+jX8RPP/38y87Prw5e77xqxWLnl1evbnZE6+dhtD/8pcXFz9V0G2zE1hqOtYpC/UPPZEDm",
+	"82+/EXkWEm33eOmNceIMQozTFahl5xCsVlJYKG3yidypErZS0wecORslQ4N5ErkDJRMTnsgY4s+UUJGJ",
+	"ODE2maLDI2tWJmFGhXWyqDvZRIgzeEIPnOnpCLIJZXGUYIZvgmKqiKsGo1BCnBbGpGgHZ+bQRt3DXAsZ",
+	"iZ5EvAqcDKQwCU1KwQizIyi3Do1UMqbt86G57fDHd1mXFq3RNR9GpZsBTDgkV5AtjBVroUR1dVSYFGfz",
+	"dAC8CnHP3WAMc/1sg2WO+kVPDvKcl4Ffh9XmbPqDQwvLjJuGuvrMhWnaIjkXUyf92B8rovkPSvlEA2ZU",
+	"Dtl+h+pMYlwpYZ0Q/G9wkE9ve28YGg4X++hh4o2MTruE5byjYzFwM1NQGysiWM9thZZjML88vfVX4d3f",
+	"Smf+lnvmc0aup2liGI8ypSl/Ti+3vYpSae8z/reoxy0oXqu5xqLe5daFY69zvNsFbK3+VJcFnBde+nnU",
+	"qOIavgV/fIFUaqpSOdF6V/MKj719wsOUk3O9mBZP+5UA8miaohAncY3pqFPKdoTktyaZ4aSLeJ+Ajf+j",
+	"CObz/POi2qQxKTGf9E1P5qKfbST5aUU+5X+tl317L27zfkrH9kh7d9jbdNWfVKgX15HHnPwtQABkbX1k",
+	"get7a92Xy5lSTVFK9U7rKp3W8qMiH4IxLRO6r8lQLBSq4BrwwL8ry3h8OyUvnvl2jBOyfp/bCPnOLEvM",
+	"ko7l22GVVBm0KZ9McPmrPGFZEhVqfnIIf5WJYaaodgKxbrJJEWLCe3RyABhqtCgybeGPxlzGKT3NYmGA",
+	"lzrYU9oO+CpLcAUDSnroG/VRyIHF708wB+JOEnCNkpF4QxUhia/pyFeKzfeisVst+cxoC3DlgImxy9rS",
+	"feZRfLKJTcBLJDt4xcZqlmlXibS8yNLueRcHa3xNjleY6XlZn1vD+htnN9nmYG+ZF2b7VCHcpkLxnVkZ",
+	"0S1d8b3P9Ec9v4Kn2drKhj3NNdqGW8O2uxY2puLndS4E/Opb8S4skG+1e2GRevcsR17Rc8b540IGH8Tn",
+	"bSzBVrXyFKIjN/OyVHOirCf9ACSAGAogDM/8fecIP9q5Iplkiyqd9UBQGg7TMuLY0MHDIASZAzeZutMi",
+	"a9rIAWcvd47ZpYjA9onGsEI5Eg7CD+UdBmGOaCewRN4LLUIoyEHQdZ4B2c4LA1Hi2jrT4CWZmrqKTcIe",
+	"/OQloO9+EgR7MPQF+Yi+sQ1J/J50L2dzIOYeQp12vQ34jeBsDncUS0xftV6xNPko4I1mEoMybnFv4DOS",
+	"urF7W/ubX9jgvP3Xu87pVb/z9/PuRee4OtORXuVbYHLNqnUUtsuHvfJyV6vKcO2lql0glejkSywS7sqF",
+	"ThJ5IuQICw+X8OKvoNZUHNTz6jWblbfVr2h79EVsS3Qr1Om3RjBi/G+R9Tyb1WnXZ5karo4acxfNjgL3",
+	"/B6YW6NxgNxMzBx4+ZcPoQZiuco9lOiJMGO1yoF4aVRm86oyun5ui3YSmZgEmHHQ0NLmjcDbxrM0+Aqt",
+	"357EUSxcaaKZkFE2xwJN7AShqUsXlg5gogfXSN8Zi5NRYnMy0L52MmK3J08VYDrCaL7aU2Wk8JClXkxu",
+	"yYHBGGea3zqdgZIcpJJireH7DjftKQxfmul5BYRbw/pLT8REm/ps7Flljut4prJ1FsMlL6AjThw91bus",
+	"vgCGTqae4etptrZOaE+zHoqiW8q2278bE/Pz2r92Ed+S/btAzJX2r4Xo27FUuwy9yaX1zhax7hKJaXuW",
+	"Bdvm72BRZtq258QKMlQv7hKNXdrVR+odP5vib7mxHY53sbU0yoSgb6rzCDsATq8bcOhnuqwhe6GXPQTR",
+	"4KeJcYiAhFKP81EbLZJjkEoZiaAPfPglWKJ567wK6YR7+Zu/6/oriaZfS9N8b4zuaj88j3haqLjusV7s",
+	"fr61PMLvV61cZr13M98JIAMAImbvc1KIt9apCAjLVLlkZRAC8CkgDMFQZbvsRBjta1CRi6RK++i3veAv",
+	"sMmxksxiQ/yIGOC3IluAEgf2QF3zHYCvvZZLCmPsDv06L4WVa0jtMg6gLsKZZ8kokTx18xdqEkoAPFWO",
+	"n/JytqNuZgPnwfOI8dOyMEl0mQC3/bbiZQ2WTOe/5uY6J2mh5q5e9c5iaV2zIP6arnAd6BkGoLAqlKZZ",
+	"P3JPSp5l6o4aWWs1ceUDgrpKG38o3ptILavhOlMfYOyYiQn4PO5JLDTjLFLTuRP2fgBqx63SVN1p0i24",
+	"dv2hb70gj0Wa3Apvj/o217hicHuz2RQZue1V42oEXR2dxesLk/dwYno8ZskahqJ/nbsirO2usms+VYeT",
+	"oMHJ/toGJwurOq1cDTQ3X7IWNRxqsWQx4eytOrMfqcmE72gB5wjE66nb70hewDNw5fDNi87b69PjzvGg",
+	"cIoLXy95gTpIVuV1noEbZ+GqcaxOdwSdaLx2S2YF4mtUWpAxN2LH/vKeC3E9wNeswajNV/Dhf4H2i/1r",
+	"ghvw5PrvNQXRPLNUGats8f782Ae58HdscXs7EpWLdfV6aU+CLoRlWSrqL00m+ESXAPJ86TjX7BLXt3MJ",
+	"33Zuvee4mKgGZ6yxrVwBexWTqWjIAYnfppXNFAhXUtDHbCqy4tzWPa1xfSxKlRY6aKbn4ed5NIZ1MiMg",
+	"PM1Tu54XVFPYZO/Pused42ZPOn7aZDZu+6MvL8zUjF5hsGoPB00koEE17g09P2j25I2I+EwLi0zgEMwS",
+	"yX5TP2g2GBsz3cVNfTf7NDhcMx7VXwbPYMnj3mf8DyyKvVBZ+H2lpjf4EfuEuzYGC8O5xTMpEvSVJJpM",
+	"WIdS75wlo0QbATzVjjflMonyOk17WgmkrInJ1OTtDInKf9C5ukSmGqVDJJrOANISuIF4Qk8OCNjXg1eb",
+	"PCqthYz1agWLKHWDOvGqHjW5VK6LpfoVm9qskV9GfDJ0jjt0CgWp0sBvDu0V60mQYIfsc6+RxL3GYa/W",
+	"+/UazZ5VO/A3Fji012iy3d3dL0ADX2GWPDE+n2il1lOVAY1sCkkzl48lVrcdgDzbFxihbfNAEU7rXCOB",
+	"SlyqhqWZx0mHyqUAUel0sVMGZs0GxfIauAVPvQUX1Mr7FCQlhc61y6Av7B3MaMYZtElthplMPUmu3Xyy",
+	"KU9iQlIpFj2ELWXwkx+0LTif8sy8IU/V3Vil9msMiwZSZKGAZLDOFYU9XupwNlr4KpMxBASu4F32+L67",
+	"lx5B0cS9/BZ8S2eWatZf8kxEIpnWVDQp1R5/YJMWF5ETKWZEZFvIBRQTyO05xEtGAMY6b6S0FKKJ0B9u",
+	"MvgM/n8hecH6jqiCAC+qA+ISiH0bWQcVpr0h2JM2YsrGfDoVkOrAfH1pPi35l8D75WJIebuHMdceJANc",
+	"U9RviWvNBsT0/nMaDwc+zOW2KxMyFplLglRS7Ez5SLDz47e+twZr542dibNxV/gQbDPML5Uf9wVmMzrM",
+	"6Mur9lVnQErxSQIsGXMybWkDJQVBwG421YWIGl+qEtt5QCd2r4T1abbHnkUSEYF4Xs36Lmi8fxulbsEt",
+	"ApefvbB+qB8RMzAeLvPE0ODN2n2tce/e0q++Lpu2cwV8qVkYDV6qMJjfqJtEWhiqdUrduTcAca5tgVN8",
+	"hsS+qpu+9XImv8prZEwd0bJaiSyCi+UcIbf+FwrAJBt0rvhoEPr0qdcP7bOcs2ECya/F4ENPxkpo6sYk",
+	"Mk2xBCGxLzG0m8FE/u5w5xRY+DuwxLEydyQwDgr28rwnBy9br9ipMuydipNhIuIBlJKlRbdHAu9jYw2F",
+	"FlR9NRw0y4+GqEVhZ6obkcK2xbaC7QbzL6jYDaxzUIhvZkkaWPEBZKbvsoxS1aMg5No3sHxxm8QCYZ8w",
+	"nhMnejozYpe9hd3TwXCaxQrvEg3LPgoxtVGbaSYwdIqAtlS2ADJOzC3V0zbk8+YSHeV+odehSz3xT/fk",
+	"AKRcn/odcTMIJGnuIEfXuN1d583ES782anz87yusCNC9GM3XFopKxURK1pHPXly8PWIvX778pfhO1DXx",
+	"5U7rJaAMvzxwKMOV3Zj6avgIkQe417aPucXwzrHOMAzColIwJ7VudVDYlhU5FC5145sxjwoNVWBnKuAK",
+	"RKZt6/fgpH8o1ymvdMh8aTZetl4tju0W41kZ04nTl/GcEsnKO/tUC/7uClqbg3C8kfTeBK1nDcD+Qitf",
+	"O3SO9bnrYAPp+cRokQ7ZRN1STNZD7sNAttZtKAykyDN38dM5NQeUJRh++3iIcGJdUBYZiJKRuPMb6XEy",
+	"neJzPTmZpSaZprCwLBKp/tGiM7r1Y4mZRWV0DR/pm+6x7cI3y0CG9VwXAAuKaCMqlYpC4WXN2AInBy+g",
+	"IWSRqrtCzwHhOgXtsrNJYtiA/lXAIAr65qKiRDCUK8rU7QH/O8nEp+xYAPSV8LTYI9Du6fLGEVUdAw9a",
+	"rRaliMKJwctUjplji8IZ2x/nw23ca/g+PRD2nxYL96jMSrYFNeB7w4BnaBhwvtBNJeT73wCqFdIuy/nu",
+	"6nqWsvuuWKe1qixgmvLIpva6Wp/Cj63KXSyLH2ZCjynvvyjQIbW39HMv2Zc117FBfToMHDDB2F42Aj+s",
+	"Ub62rFgWcegDQonxSP24QDQHBq4nDD3dT2LwZhK2i508hRJPo3L3pou701JtJRzZqpog+KFzj2tPUhDX",
+	"pKGgr6DYp9rnDUMJPGoGhf3pyS7Jd9x824qgrKlgXvWMp4Xq/PJGU6E+wNO4HV0uzi9EWdB8F+v3EOsu",
+	"Fbwog/PNFUWso2JOe0ixBdHcbKC3Y82grto3L7sMBmksEH9dvPyNNYMSKW2zhnCxjDVti6bQpDbpbKb5",
+	"TSoqud6zKRMqK63ku3ohPS68ZbjbrEkssvzNNAqMkK5SJGwINXQAeAG2zPwv9xMpWf+BkuBtYdQSbPd/",
+	"O76Tl2GDn4Jlb411O1uSW+rWZ024btw319/pzVqtl4JdXh8ddTrHneM92+cgTYYimkepV1MyDGDAjLGY",
+	"ChkLadK5TYAMspXmgTE/kyZJQwvc79KYQ66LkHahwjnCe5I+yMPRmYBUYm1b+2ONv7Xj4bkKw5++6Mlg",
+	"WvLZ2x2bC2P31E41UoE64wXYTKYCYt6x0MbWjAyYhtcLHPSU+wPP24g2vJfTLRG8QkhKC7VuQ1BrBtjk",
+	"DsMKQ5ENXFaqS/EpxPinfK5mBqGTYH9QHRNxkGNKahYmIWqMbHgxbH8KOheqStpO7NMGQE98wzhzK8nD",
+	"+beYO0m7TxSccuM7UPSkyXj0EVILBoiX0KdWHoN8ea6KzdXAupXmUEquKrYn6ce62OsCkxkSGzl19Xh0",
+	"Xj9oisYXD5HfqFvBBr+1rzp/tv/qn3Tfda8u+33Kp+23z88vzt63TyhtIUewjOaOrVFesFF+qa43SA4r",
+	"BBt70DpgXLvb48elBAxOITygeauy9KQFvWJ8Os1Qb06sZWHbU/J4kkjHc/Y+0x/AhuwPBk1qRkSXoLpi",
+	"xym6Q3KXf9dvH6XRJiWABEVJ/SxU+DZTHOFotltfLEqnUE18TOCmTRbjkZvoKvD0u3fru3erpPt8M94t",
+	"z503UUVrobRvGoe6xQZ369XQciPo5ZIH1vFd7myh3HlG5PdajP69SpbInO9s/n89m88R578ZJm8Z4XIW",
+	"r2arwOUvhYw1I+cCxgIyESXThDJDnKEHdu4h42zCs4/CYEiDaQG5fPhQymVkk4S8LU01fmUHhVFl2F07",
+	"egjb68zhXdb2w9F7kKgYKTdOmMNCIzaL3QKi3OOPRiRBJWItHUs05b958z1oqoiThYaYc10keSNDFiFq",
+	"k1cQsAP1G2/B2aJPaZ+PPkIZiwQb39XsYUykhLFhs+xg+pKlbRMSuqf9q4v26WX3ylp9gdNiqjK019h5",
+	"G9IiVOb6RybDCju7J/3bJaZqXh8KCTfCjojmJJYDDcBJAp3zIxWLAe7hBUJHlXBkSq1YyiAwobZgF4JJ",
+	"cz1JJ2nSOdzFJeWNHvMQ2MiWNnA8Ctb4fDCJOPlKlog+lK1rCNB0The6wqFzBp355IwDou/JRdpC2CQb",
+	"XI2TITqjjJulJ79L32eQvmF7f59obovCddml+IO2Valb3xiCONBqcYwGl5rVaARRyc8qgTDVrGjdVBsr",
+	"avZQW+Ur5+nW40/PVsSoZqVLu70Ql0VCLOahEuNcCWeJ0niipJi7Rs3LA0+7bJPA0h9iSiBj4lOiUU1A",
+	"BCGiff0GMzkcsJ0eo5I106Inrfd6VQCtskSYvrM38em1g9WWt8skSOK6fogNLPJ7+ICfJenee9ds6g/0",
+	"G50/A37/RRjXwXxR6wWGOJnQ0Pm8uegiLmZegZLuteWwFum7i+G7i2GdJ/lJewqEChg3UJo9SXIoYQ8h",
+	"DJlU1rrdSoFnL23O35foXi7eiQjEKzoX2bh2juNsf4g74/wMO7CgJBLsZpaChQ4/1kHxHLyskJqsYPcj",
+	"bQvmuARa5CNBzonQ9MXFsSwZjQ3jd9zlOrglZDNselR0KTSpAp8cCy71ouRXeMOmKk17cvBb54rRFgi9",
+	"9xn/QLAkeLmpyHZy/Cg9S422fgH8aMIxpCx4hikRtoZ/KjJaNcp2TARJjJj4XXNpEtT2w4IYLTpffPQ9",
+	"0UxNEmNE3CSIJ5eEkb/acLHkE/HVCIbKKSc4oXNm9KT1ZoSG47q49q+2tGqL3QnBQjcS8wePi7S96g7j",
+	"A96JtVUuBZWxmr4C31G4J7eZCU64zOEj17PCPPWjFuKtTuQoFSz08E7zsreglrx7vHCvRsJYWt2s9tdO",
+	"Vh23q5VyW2kKuxffWlN4k6SF57GG7eTbbw3bha6uzPRtgHb89VlejomsVxd6GLHLo987x9cnvtDC2BhD",
+	"WDcIbQG1KRdc9KTN+EV5OvAr6Q9VNsDsvinXGlLfunlwpJD1R50TpYXqKdZMGFXw2Xt3PYV8B0wLlMID",
+	"GLRvBxygXiKVFZ2QUccSyqevkpluxc9mYtcjqMviMre/l52nhC1rzLs1/WWe1JRzxuQ0U5HQ2nWIA3f1",
+	"93ZwtVETLUnnvHO5lqJnN374VdwYS9n0kjI257xMuaSkcDZIYJ23PB00mTY8QxONm54c4L8A6wShY3Mj",
+	"zFej40zI1MvF7yF2L2fgv/KV6MWer34Il9pOJqGSoolOJkp9h/R6yWI+1xYnMdwL+PV5+/Kqf3zdYRPB",
+	"JVW3w++O2qdHHeD1PlebpqFqeNRsZ9PlZs9lMMtX7RkXTvRMfLi4hOVUHT63pY3Sv/f7WhuY00XKrsNx",
+	"9j6H/1wTqivdnLXWTeE+rwnbFZextRbLvS7U85guhSV8C+G8JeRbMmFWUu9exGUk0pXtU6eQCWZsx3MQ",
+	"qiC76E/G00zweM4SCRrQKBNaM22SNGXw6qkwQu8uihWc8/vluKe0wd0T23Q/nlTjLizD0Z/blKBPM2EZ",
+	"bKcAwtXWFkCQf7oKBgoGW599bzEHvf5ZP92eHVGcCtZhNVM3yteK3MNU1XF7+OZ/Y9R+4wz6Z4nZ21Tp",
+	"csT+e4T7exL98iT67/HtzUUIFqy0a4ALlPruN9rT5A8xh182Dv/x4UuTOvHjRFWa14mKeMpicStSNcUj",
+	"pWcbzcYsSxuHjbEx08O9vRSeGyttDv/W+ts+sla7moVmZo6d29h5ZrPCOUWqoC/iKIxWWZXuPG/TtWZE",
+	"cm7cBsOEAMf5iE5PXjEg5PgohYXjMLKeTacqo0K2QMaxWNzMRrDufPA2VFM3vnz48v8PAFrGk/rWCQIA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -0,0 +1,394 @@
+// Package app builds the gateway's repositories, bank clients and services from its
+// configuration. The API server, the worker process and the one-off jobs all start
+// from it, so each of them acts on payments the same way.
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/health"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/alert"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/cache"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/chaos"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/notification"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+)
+
+// App holds everything the gateway acts on payments with, built once per process
+type App struct {
+	Config *config.Config
+	Logger *slog.Logger
+	DB     *postgres.DB
+
+	Payments         *postgres.PaymentRepository
+	Idempotency      *postgres.IdempotencyRepository
+	Operations       *postgres.OperationRepository
+	BankAttempts     *postgres.BankAttemptRepository
+	DebugSessions    *postgres.DebugSessionRepository
+	Outbox           *postgres.OutboxRepository
+	MerchantSettings *postgres.MerchantSettingsRepository
+	Merchants        *postgres.MerchantRepository
+	APIKeys          *postgres.APIKeyRepository
+	// Keyring seals card numbers, account numbers and the signing secrets of API keys
+	Keyring        *vault.Keyring
+	PaymentReviews *postgres.ReviewRepository
+	DeadLetters    *postgres.DeadLetterRepository
+	// PaymentReadModel serves customer listings and summaries off the payments table
+	PaymentReadModel *postgres.PaymentReadModelRepository
+
+	// Bank records and retries every call, and routes it to its acquirer
+	Bank bank.BankClient
+	// Canary is the router behind Bank, or nil when there is no canary acquirer
+	Canary *bank.CanaryRouter
+	// SyntheticAcquirers reach each acquirer directly, past recording and retries
+	SyntheticAcquirers map[string]bank.BankClient
+	// Alerts is nil when no alert webhook is configured
+	Alerts *alert.Notifier
+	// Hooks are the modules that follow payment transitions, which the outbox worker
+	// delivers them to
+	Hooks *hooks.Registry
+
+	Authorize       *services.AuthorizeService
+	Capture         *services.CaptureService
+	Void            *services.VoidService
+	Refund          *services.RefundService
+	Reauthorize     *services.ReauthorizeService
+	PaymentMethods  *services.PaymentMethodService
+	Schedules       *services.ScheduleService
+	Reviews         *services.ReviewService
+	DeadLetterQueue *services.DeadLetterService
+	Subscriptions   *services.SubscriptionService
+	PaymentIntents  *services.PaymentIntentService
+	PaymentGroups   *services.PaymentGroupService
+	Payouts         *services.PayoutService
+	Batches         *services.BatchService
+	Erasure         *services.ErasureService
+	SigningSecrets  *services.SigningSecretService
+	Reconciliation  *services.ReconciliationService
+	BankRefunds     *services.BankRefundService
+	Features        *services.FeatureFlagService
+	OutboxControl   *services.OutboxService
+	PaymentHistory  *services.PaymentHistoryService
+	// Regions says whether this region takes writes; every process takes them when no
+	// region is configured
+	Regions *services.RegionService
+	// Clock is what services and workers tell the time by: the system clock, or
+	// TestClock when the test clock is enabled. TestClock is nil otherwise.
+	Clock     clock.Clock
+	TestClock *clock.Test
+	// APIChaos injects faults into API requests in staging; it is nil unless chaos is
+	// enabled for the API
+	APIChaos *chaos.Injector
+
+	closers []func()
+}
+
+// New connects to the database and builds everything on top of it. Queries are timed
+// into queries when it is not nil. Close releases what New opened.
+func New(ctx context.Context, cfg *config.Config, queries postgres.QueryObserver, logger *slog.Logger) (*App, error) {
+	keyring, err := vault.ParseKeyring(cfg.Vault.Keys, cfg.Vault.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("load vault keys: %w", err)
+	}
+
+	// Without the salt, authorization hashes would be keyed by the merchant ID alone
+	fingerprints, err := vault.NewFingerprinter(cfg.Vault.FingerprintSalt)
+	if err != nil {
+		return nil, fmt.Errorf("load fingerprint salt: %w", err)
+	}
+
+	amountLimits, err := domain.ParseAmountLimits(cfg.Limits.Amounts)
+	if err != nil {
+		return nil, fmt.Errorf("load amount limits: %w", err)
+	}
+
+	refundApprovals, err := domain.ParseAmountThresholds(cfg.Limits.RefundApproval)
+	if err != nil {
+		return nil, fmt.Errorf("load refund approval thresholds: %w", err)
+	}
+
+	reviewThresholds, err := domain.ParseAmountThresholds(cfg.Limits.Review)
+	if err != nil {
+		return nil, fmt.Errorf("load review thresholds: %w", err)
+	}
+
+	featureRollout, err := domain.ParseFeatureRollout(cfg.Features.Rollout)
+	if err != nil {
+		return nil, fmt.Errorf("load feature rollout: %w", err)
+	}
+
+	bankRateLimits, err := bank.ParseRateLimits(cfg.BankRateLimit.Limits)
+	if err != nil {
+		return nil, fmt.Errorf("load bank rate limits: %w", err)
+	}
+
+	eventSchemas, err := hooks.LoadSchemas()
+	if err != nil {
+		return nil, fmt.Errorf("load event schemas: %w", err)
+	}
+
+	db, err := postgres.Connect(ctx, &cfg.Database, queries, logger)
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+
+	a := &App{
+		Config:           cfg,
+		Logger:           logger,
+		DB:               db,
+		Payments:         postgres.NewPaymentRepository(db),
+		Idempotency:      postgres.NewIdempotencyRepository(db),
+		Operations:       postgres.NewOperationRepository(db),
+		BankAttempts:     postgres.NewBankAttemptRepository(db),
+		DebugSessions:    postgres.NewDebugSessionRepository(db),
+		Outbox:           postgres.NewOutboxRepository(db),
+		MerchantSettings: postgres.NewMerchantSettingsRepository(db),
+		Merchants:        postgres.NewMerchantRepository(db),
+		APIKeys:          postgres.NewAPIKeyRepository(db),
+		Keyring:          keyring,
+		PaymentReviews:   postgres.NewReviewRepository(db),
+		DeadLetters:      postgres.NewDeadLetterRepository(db),
+		PaymentReadModel: postgres.NewPaymentReadModelRepository(db),
+		closers:          []func(){db.Close},
+	}
+
+	erasureRepo := postgres.NewErasureRepository(db)
+
+	if cfg.Limits.UniqueOrders {
+		a.Payments.WithUniqueOrders()
+	}
+
+	// Every process that changes payments must share the cache, or the API keeps
+	// serving what it cached before
+	if cfg.Cache.Enabled {
+		// A cache read that takes longer than a database read is no use
+		redisClient := cache.NewRedisClient(cfg.Cache.RedisAddr, cfg.Cache.RedisPassword, cfg.Cache.RedisDB, 16, 250*time.Millisecond)
+		a.closers = append(a.closers, func() { redisClient.Close() })
+
+		paymentCache := cache.NewPaymentCache(redisClient, cfg.Cache.TTL, logger)
+		a.Payments.WithCache(paymentCache)
+		erasureRepo.WithCache(paymentCache)
+		logger.Info("payment cache enabled", "redis_addr", cfg.Cache.RedisAddr, "ttl", cfg.Cache.TTL)
+	}
+
+	// Payments carry the epoch of the region that wrote them, so a region that was
+	// demoted while it could still reach the database cannot undo the new one's writes
+	a.Regions = services.NewRegionService(postgres.NewRegionRepository(db), cfg.Region.Name, cfg.Region.Standby, cfg.Region.RefreshInterval)
+	if a.Regions.Enabled() {
+		a.Payments.WithRegionFence(a.Regions)
+		state := a.Regions.State(ctx)
+		logger.Info("region configured", "region", state.Region, "active", state.Active, "epoch", state.Epoch)
+	}
+
+	a.OutboxControl = services.NewOutboxService(a.Outbox)
+	a.PaymentHistory = services.NewPaymentHistoryService(a.Payments, a.Outbox)
+	a.Features = services.NewFeatureFlagService(postgres.NewFeatureFlagRepository(db), featureRollout, cfg.Features.CacheTTL)
+
+	if cfg.Chaos.Enabled {
+		logger.Warn("chaos injection enabled",
+			"target", cfg.Chaos.Target,
+			"latency", cfg.Chaos.Latency,
+			"latency_percent", cfg.Chaos.LatencyPercent,
+			"error_percent", cfg.Chaos.ErrorPercent,
+		)
+	}
+	if cfg.Chaos.Affects("api") {
+		a.APIChaos = chaos.NewInjector(cfg.Chaos)
+	}
+
+	a.Clock = clock.System{}
+	if cfg.Clock.Test {
+		a.TestClock = clock.NewTest()
+		a.Clock = a.TestClock
+		logger.Warn("test clock enabled; the admin API can move time forward")
+	}
+
+	a.connectBanks(bankRateLimits)
+
+	if cfg.Alerts.WebhookURL != "" {
+		alertSender := alert.NewWebhookSender(cfg.Alerts.WebhookURL, cfg.Alerts.Format, cfg.Alerts.RoutingKey, 10*time.Second)
+		a.Alerts = alert.NewNotifier(alertSender, postgres.NewAlertRepository(db), logger)
+		logger.Info("alerting enabled", "format", cfg.Alerts.Format, "stuck_after", cfg.Alerts.StuckAfter)
+	}
+
+	// Modules subscribe to payment transitions here instead of inside the services
+	a.Hooks = hooks.NewRegistry().WithSchemas(eventSchemas)
+	a.Hooks.OnAny("log", hooks.LogTransition(logger))
+	a.Hooks.OnAny("read_model", hooks.RefreshReadModel(a.PaymentReadModel))
+
+	a.Authorize = services.NewAuthorizeService(a.Payments, a.Idempotency, a.MerchantSettings, a.Bank, db, services.AuthorizeLimits{
+		Amounts:         amountLimits,
+		DuplicateWindow: cfg.Limits.DuplicateWindow,
+		CardWindow:      cfg.Limits.CardWindow,
+		CardPayments:    cfg.Limits.CardPayments,
+		CardCustomers:   cfg.Limits.CardCustomers,
+	}).WithFingerprints(fingerprints)
+	a.Capture = services.NewCaptureService(a.Payments, a.Idempotency, a.Operations, a.Bank, db)
+	a.Authorize.WithAutoCapture(a.Capture)
+	a.Hooks.On(domain.StatusAuthorized, "auto_capture", hooks.AutoCapture(a.MerchantSettings, a.Capture))
+	a.Void = services.NewVoidService(a.Payments, a.Idempotency, a.Operations, a.Bank, db)
+	a.Refund = services.NewRefundService(a.Payments, a.Idempotency, a.Operations, a.MerchantSettings, a.Bank, db).
+		WithApprovalThresholds(refundApprovals).
+		WithKeyring(keyring).
+		WithClock(a.Clock)
+	if cfg.Notifications.WebhookURL != "" {
+		notifier := notification.NewWebhookSender(cfg.Notifications.WebhookURL, cfg.Notifications.Timeout)
+		a.Hooks.OnAny("notify_customer", hooks.NotifyCustomer(a.MerchantSettings, a.Operations, notifier))
+		logger.Info("customer notifications enabled")
+	}
+	a.PaymentMethods = services.NewPaymentMethodService(postgres.NewPaymentMethodRepository(db), keyring)
+	a.SigningSecrets = services.NewSigningSecretService(a.APIKeys, keyring)
+	a.Reauthorize = services.NewReauthorizeService(
+		a.Payments,
+		a.Idempotency,
+		a.Operations,
+		a.PaymentMethods,
+		a.Bank,
+		db,
+	).WithClock(a.Clock)
+	a.Schedules = services.NewScheduleService(
+		a.Payments,
+		a.Idempotency,
+		postgres.NewScheduledPaymentRepository(db),
+		a.PaymentMethods,
+		a.Authorize,
+		db,
+	).WithClock(a.Clock)
+	a.Reviews = services.NewReviewService(
+		a.Payments,
+		a.Idempotency,
+		a.PaymentReviews,
+		a.PaymentMethods,
+		a.Authorize,
+		db,
+		reviewThresholds,
+	)
+	a.Authorize.WithReviews(a.Reviews)
+	a.DeadLetterQueue = services.NewDeadLetterService(a.Payments, a.DeadLetters, db)
+	a.Subscriptions = services.NewSubscriptionService(
+		postgres.NewSubscriptionRepository(db),
+		a.Payments,
+		a.PaymentMethods,
+		a.Authorize,
+		a.Capture,
+		domain.DefaultDunningPolicy,
+	).WithClock(a.Clock)
+	a.PaymentIntents = services.NewPaymentIntentService(postgres.NewPaymentIntentRepository(db), a.Payments, a.Authorize).
+		WithClock(a.Clock)
+	a.PaymentGroups = services.NewPaymentGroupService(postgres.NewPaymentGroupRepository(db), a.Payments, a.Authorize, a.Capture, a.Void)
+	a.Payouts = services.NewPayoutService(
+		postgres.NewPayoutRepository(db),
+		a.Payments,
+		a.Idempotency,
+		a.Bank,
+		keyring,
+		db,
+	)
+	a.Batches = services.NewBatchService(postgres.NewBatchRepository(db), a.Payments, a.Operations, a.Refund, a.Void, db)
+	a.Erasure = services.NewErasureService(erasureRepo, cfg.Retention.FinancialPeriod, db)
+	reconciliationRepo := postgres.NewReconciliationRepository(db)
+	a.Reconciliation = services.NewReconciliationService(
+		a.Payments,
+		a.BankAttempts,
+		reconciliationRepo,
+		a.Bank,
+		domain.MaxReconciliationChecks,
+	)
+	a.BankRefunds = services.NewBankRefundService(a.Payments, a.Operations, reconciliationRepo, db)
+
+	return a, nil
+}
+
+// Close releases the connections New opened, the database last
+func (a *App) Close() {
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		a.closers[i]()
+	}
+}
+
+// connectBanks builds the chain of clients the gateway reaches its acquirers through.
+// Each acquirer limits the calls of a merchant to rateLimits on its own.
+func (a *App) connectBanks(rateLimits bank.RateLimits) {
+	cfg := a.Config
+
+	// Every acquirer's client shares the one pool, which keeps connections per host
+	pool := bank.NewTransport(cfg.BankClient)
+	var transport http.RoundTripper = pool
+	if cfg.Chaos.Affects("bank") {
+		transport = chaos.NewTransport(transport, chaos.NewInjector(cfg.Chaos))
+	}
+	debugTransport := bank.NewDebugTransport(transport, a.DebugSessions, a.Logger)
+	bankClient := bank.NewBankClient(cfg.BankClient, debugTransport)
+	recordingBankClient := bank.NewRecordingBankClient(bankClient, domain.DefaultAcquirer, a.BankAttempts, a.Logger)
+	rateLimitedBankClient := bank.NewRateLimitedBankClient(recordingBankClient, rateLimits, cfg.BankRateLimit)
+	retryBankClient := bank.NewRetryBankClient(rateLimitedBankClient, cfg.Retry, a.MerchantSettings)
+
+	a.SyntheticAcquirers = map[string]bank.BankClient{domain.DefaultAcquirer: bankClient}
+
+	// The router sits above the retry decorators so every retry of a call goes to the
+	// acquirer that was picked for it
+	if cfg.Canary.BankBaseURL != "" {
+		canaryBankClient := bank.NewBankClient(config.BankConfig{
+			BankBaseURL:     cfg.Canary.BankBaseURL,
+			BankConnTimeout: cfg.BankClient.BankConnTimeout,
+		}, debugTransport)
+		a.SyntheticAcquirers[bank.AcquirerCanary] = canaryBankClient
+		canaryRecordingClient := bank.NewRecordingBankClient(canaryBankClient, bank.AcquirerCanary, a.BankAttempts, a.Logger)
+		a.Canary = bank.NewCanaryRouter(
+			retryBankClient,
+			bank.NewRetryBankClient(
+				bank.NewRateLimitedBankClient(canaryRecordingClient, rateLimits, cfg.BankRateLimit),
+				cfg.Retry,
+				a.MerchantSettings,
+			),
+			cfg.Canary,
+			a.Logger,
+		).WithFeatureFlags(a.Features)
+		retryBankClient = a.Canary
+		a.Logger.Info("canary routing enabled", "bank_base_url", cfg.Canary.BankBaseURL, "percent", cfg.Canary.Percent)
+	}
+
+	if cfg.Shadow.BankBaseURL != "" {
+		shadowTimeout := cfg.Shadow.Timeout
+		if shadowTimeout == 0 {
+			shadowTimeout = cfg.BankClient.BankConnTimeout
+		}
+		shadowBankClient := bank.NewBankClient(config.BankConfig{
+			BankBaseURL:     cfg.Shadow.BankBaseURL,
+			BankConnTimeout: shadowTimeout,
+		}, pool)
+		retryBankClient = bank.NewShadowBankClient(retryBankClient, shadowBankClient, cfg.Shadow.Percent, shadowTimeout, a.Logger)
+		a.Logger.Info("shadowing authorizations", "bank_base_url", cfg.Shadow.BankBaseURL, "percent", cfg.Shadow.Percent)
+	}
+
+	a.Bank = retryBankClient
+}
+
+// NewHealthChecker checks the database, the outbox and every acquirer. Whoever runs
+// workers or the authorize queue adds them.
+func (a *App) NewHealthChecker() *health.Checker {
+	cfg := a.Config
+
+	// The outbox is behind once an event has waited out many polls; the health check
+	// gets a bounded wait so it still answers when a dependency hangs
+	checker := health.NewChecker(a.DB, a.Outbox, 10*cfg.Worker.OutboxInterval, 2*time.Second, a.Logger)
+	checker.AddBank(domain.DefaultAcquirer, cfg.BankClient.BankBaseURL)
+	if cfg.Canary.BankBaseURL != "" {
+		checker.AddBank(bank.AcquirerCanary, cfg.Canary.BankBaseURL)
+	}
+	if a.Regions.Enabled() {
+		checker.SetRegion(a.Regions)
+	}
+	return checker
+}
@@ -0,0 +1,267 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/health"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
+)
+
+// Workers are the background workers that move payments along outside of requests.
+// They run in the API server, or in the worker process when the workers are external.
+type Workers struct {
+	cfg     *config.Config
+	regions *services.RegionService
+	logger  *slog.Logger
+
+	retry        *worker.RetryWorker
+	expiration   *worker.ExpirationWorker
+	outbox       *worker.OutboxWorker
+	scheduler    *worker.SchedulerWorker
+	subscription *worker.SubscriptionWorker
+	payout       *worker.PayoutWorker
+	batch        *worker.BatchWorker
+	// Optional workers are nil when turned off
+	stuckPayment   *worker.StuckPaymentWorker
+	synthetic      *worker.SyntheticWorker
+	reconciliation *worker.ReconciliationWorker
+}
+
+// NewWorkers builds every background worker the configuration turns on. Synthetic
+// transactions are timed into syntheticMetrics.
+func (a *App) NewWorkers(syntheticMetrics *metrics.SyntheticMetrics) (*Workers, error) {
+	cfg := a.Config
+
+	retry, err := a.RetryWorker()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workers{
+		cfg:     cfg,
+		regions: a.Regions,
+		logger:  a.Logger,
+		retry:   retry,
+		expiration: worker.NewExpirationWorker(
+			a.Payments,
+			a.Bank,
+			cfg.Worker.Interval,
+			a.Logger,
+		).WithClock(a.Clock),
+		outbox: worker.NewOutboxWorker(
+			a.Outbox,
+			a.Hooks,
+			a.DB,
+			cfg.Worker.OutboxInterval,
+			cfg.Worker.BatchSize,
+			a.Logger,
+		),
+		scheduler: worker.NewSchedulerWorker(
+			a.Schedules,
+			cfg.Worker.SchedulerInterval,
+			cfg.Worker.BatchSize,
+			a.Logger,
+		),
+		subscription: worker.NewSubscriptionWorker(
+			a.Subscriptions,
+			cfg.Worker.SchedulerInterval,
+			cfg.Worker.BatchSize,
+			a.Logger,
+		),
+		payout: worker.NewPayoutWorker(
+			a.Payouts,
+			cfg.Worker.Interval,
+			cfg.Worker.BatchSize,
+			a.Logger,
+		),
+		batch: worker.NewBatchWorker(
+			a.Batches,
+			cfg.Worker.SchedulerInterval,
+			cfg.Worker.BatchSize,
+			a.Logger,
+		),
+	}
+
+	if a.Alerts != nil {
+		w.stuckPayment = worker.NewStuckPaymentWorker(
+			a.Payments,
+			a.Alerts,
+			cfg.Alerts.StuckAfter,
+			cfg.Worker.Interval,
+			cfg.Worker.BatchSize,
+			a.Logger,
+		).WithExpiryWarning(cfg.Alerts.ExpiryWarning)
+	}
+
+	if cfg.Synthetic.Interval > 0 {
+		w.synthetic = worker.NewSyntheticWorker(
+			a.SyntheticAcquirers,
+			bank.AuthorizationRequest{
+				Amount:      cfg.Synthetic.Amount,
+				CardNumber:  cfg.Synthetic.CardNumber,
+				Cvv:         cfg.Synthetic.CVV,
+				ExpiryMonth: cfg.Synthetic.ExpiryMonth,
+				ExpiryYear:  cfg.Synthetic.ExpiryYear,
+			},
+			syntheticMetrics,
+			cfg.Synthetic.Interval,
+			a.Logger,
+		)
+	}
+
+	if cfg.Worker.ReconcileInterval > 0 {
+		reconciliation, err := a.ReconciliationWorker(cfg.Worker.ReconcileWindow, cfg.Worker.ReconcileInterval)
+		if err != nil {
+			return nil, err
+		}
+		w.reconciliation = reconciliation
+	}
+
+	return w, nil
+}
+
+// RetryWorker builds the worker that resumes stuck payments
+func (a *App) RetryWorker() (*worker.RetryWorker, error) {
+	cfg := a.Config
+	batchSizes, err := worker.ParseRetryBatchSizes(cfg.Worker.RetryBatchSizes)
+	if err != nil {
+		return nil, fmt.Errorf("load retry batch sizes: %w", err)
+	}
+	maxAttempts, err := worker.ParseRetryMaxAttempts(cfg.Worker.RetryMaxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("load retry max attempts: %w", err)
+	}
+	maxBackoffs, err := worker.ParseRetryMaxBackoffs(cfg.Worker.RetryMaxBackoffs)
+	if err != nil {
+		return nil, fmt.Errorf("load retry max backoffs: %w", err)
+	}
+	onExhausted := worker.ExhaustedAlert
+	if cfg.Worker.RetryExhausted != "" {
+		onExhausted = worker.RetryExhaustedAction(cfg.Worker.RetryExhausted)
+	}
+
+	return worker.NewRetryWorker(
+		a.Payments,
+		a.Idempotency,
+		a.Operations,
+		a.PaymentMethods,
+		a.Bank,
+		a.DB,
+		cfg.Worker.Interval,
+		cfg.Worker.BatchSize,
+		cfg.Retry.MaxRetries,
+		cfg.Retry.MaxBackoff,
+		a.Alerts,
+		a.Logger,
+	).
+		WithBatchSizes(batchSizes).
+		WithMaxAttempts(maxAttempts).
+		WithMaxBackoffs(maxBackoffs).
+		WithExhaustedAction(onExhausted, a.DeadLetters).
+		WithRefunds(a.Refund).
+		WithClock(a.Clock), nil
+}
+
+// ReconciliationWorker builds the worker that reconciles every merchant's payments of
+// the last window with the bank each interval
+func (a *App) ReconciliationWorker(window, interval time.Duration) (*worker.ReconciliationWorker, error) {
+	if window <= 0 || window > domain.MaxReconciliationRange {
+		return nil, fmt.Errorf("reconciliation window must be positive and at most %s", domain.MaxReconciliationRange)
+	}
+	if interval <= 0 {
+		return nil, fmt.Errorf("reconciliation interval must be positive")
+	}
+	return worker.NewReconciliationWorker(a.Reconciliation, a.Merchants, window, interval, a.Logger), nil
+}
+
+// Start runs every worker until ctx is done. In a region on standby they wait for it to
+// be promoted, and stop again once another region is promoted over it.
+func (w *Workers) Start(ctx context.Context) {
+	if !w.regions.Enabled() {
+		w.start(ctx)
+		return
+	}
+	go w.followRegion(ctx)
+}
+
+// followRegion starts the workers while the region is active and stops them while it
+// is not, checking every time the lease may have been reread
+func (w *Workers) followRegion(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Region.RefreshInterval)
+	defer ticker.Stop()
+
+	// The workers run under a child of ctx, so they stop with it too
+	var stop context.CancelFunc
+	for {
+		state := w.regions.State(ctx)
+		switch {
+		case state.Active && stop == nil:
+			stop = w.startCancelable(ctx)
+			w.logger.Info("region is active, workers started", "region", state.Region, "epoch", state.Epoch)
+		case !state.Active && stop != nil:
+			stop()
+			stop = nil
+			w.logger.Warn("region is on standby, workers stopped", "region", state.Region, "epoch", state.Epoch)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// startCancelable starts every worker and returns what stops them
+func (w *Workers) startCancelable(ctx context.Context) context.CancelFunc {
+	runCtx, cancel := context.WithCancel(ctx)
+	w.start(runCtx)
+	return cancel
+}
+
+func (w *Workers) start(ctx context.Context) {
+	go w.retry.Start(ctx)
+	go w.expiration.Start(ctx)
+	go w.outbox.Start(ctx)
+	go w.scheduler.Start(ctx)
+	go w.subscription.Start(ctx)
+	go w.payout.Start(ctx)
+	go w.batch.Start(ctx)
+	if w.stuckPayment != nil {
+		go w.stuckPayment.Start(ctx)
+	}
+	if w.synthetic != nil {
+		go w.synthetic.Start(ctx)
+	}
+	if w.reconciliation != nil {
+		go w.reconciliation.Start(ctx)
+	}
+}
+
+// Watch has checker report every worker that stops finishing its runs
+func (w *Workers) Watch(checker *health.Checker) {
+	checker.AddWorker("retry", w.cfg.Worker.Interval, w.retry.LastRun)
+	checker.AddWorker("expiration", w.cfg.Worker.Interval, w.expiration.LastRun)
+	checker.AddWorker("outbox", w.cfg.Worker.OutboxInterval, w.outbox.LastRun)
+	checker.AddWorker("scheduler", w.cfg.Worker.SchedulerInterval, w.scheduler.LastRun)
+	checker.AddWorker("subscription", w.cfg.Worker.SchedulerInterval, w.subscription.LastRun)
+	checker.AddWorker("payout", w.cfg.Worker.Interval, w.payout.LastRun)
+	checker.AddWorker("batch", w.cfg.Worker.SchedulerInterval, w.batch.LastRun)
+	if w.stuckPayment != nil {
+		checker.AddWorker("stuck_payment", w.cfg.Worker.Interval, w.stuckPayment.LastRun)
+	}
+	if w.synthetic != nil {
+		checker.AddWorker("synthetic", w.cfg.Synthetic.Interval, w.synthetic.LastRun)
+	}
+	if w.reconciliation != nil {
+		checker.AddWorker("reconciliation", w.cfg.Worker.ReconcileInterval, w.reconciliation.LastRun)
+	}
+}
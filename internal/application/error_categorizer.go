@@ -22,47 +22,104 @@ const (
 	CategoryInfrastructure ErrorCategory = "INFRASTRUCTURE"
 )
 
-// CategorizeError determines error category for retry and logging purposes
-func CategorizeError(err error) ErrorCategory {
-	if err == nil {
-		return ""
-	}
+// errorMapping is how the gateway reports one error raised below the application
+// layer: the code and HTTP status API callers see, and the category workers decide
+// whether to retry by
+type errorMapping struct {
+	err      error
+	code     string
+	status   int
+	category ErrorCategory
+}
 
+// errorMappings is the one table ToErrorCode, ToHTTPStatus and CategorizeError read, so
+// a handler's answer and a worker's retry decision cannot drift apart. The first entry
+// err matches wins.
+var errorMappings = []errorMapping{
 	// Context Errors (Transient - network/timeout issues)
-	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
-		return CategoryTransient
-	}
+	{context.DeadlineExceeded, ErrCodeTimeout, http.StatusRequestTimeout, CategoryTransient},
+	{context.Canceled, ErrCodeTimeout, http.StatusRequestTimeout, CategoryTransient},
 
 	// Domain Errors (Business Rules)
-	if errors.Is(err, domain.ErrPaymentExpired) {
-		return CategoryBusinessRule
-	}
+	{domain.ErrPaymentExpired, ErrCodePaymentExpired, http.StatusConflict, CategoryBusinessRule},
+	{domain.ErrInvalidAmount, ErrCodeInvalidAmount, http.StatusBadRequest, CategoryBusinessRule},
+	{domain.ErrInvalidTransition, ErrCodeInvalidTransition, http.StatusConflict, CategoryBusinessRule},
+	{domain.ErrInvalidState, ErrCodeInvalidState, http.StatusConflict, CategoryBusinessRule},
+	{postgres.ErrPaymentTerminal, ErrCodeInvalidState, http.StatusConflict, CategoryBusinessRule},
+	{domain.ErrMissingRequiredField, ErrCodeMissingRequiredField, http.StatusBadRequest, CategoryClientError},
+
+	// A concurrent request holds the key; trying again finds what it stored
+	{postgres.ErrDuplicateIdempotencyKey, ErrCodeDuplicateIdempotencyKey, http.StatusConflict, CategoryTransient},
+
+	// Persistence Errors
+	{postgres.ErrPaymentNotFound, "PAYMENT_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrOperationNotFound, "OPERATION_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrRefundNotFound, "REFUND_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrDebugSessionNotFound, "DEBUG_SESSION_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrPaymentMethodNotFound, "PAYMENT_METHOD_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrSubscriptionNotFound, "SUBSCRIPTION_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrPaymentIntentNotFound, "PAYMENT_INTENT_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrPaymentGroupNotFound, "PAYMENT_GROUP_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrPayoutNotFound, "PAYOUT_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrBatchNotFound, "BATCH_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrMerchantNotFound, "MERCHANT_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrFeatureOverrideNotFound, "FEATURE_OVERRIDE_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrReviewNotFound, "REVIEW_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+	{postgres.ErrDeadLetterNotFound, "DEAD_LETTER_NOT_FOUND", http.StatusNotFound, CategoryClientError},
+}
 
-	if errors.Is(err, domain.ErrInvalidAmount) {
-		return CategoryBusinessRule
+// serviceErrorCategories is the category of each ServiceError code, which carries its
+// own HTTP status. Codes not listed are retried.
+var serviceErrorCategories = map[string]ErrorCategory{
+	ErrCodeIdempotencyMismatch: CategoryClientError,
+	ErrCodeInvalidInput:        CategoryClientError,
+	ErrCodeUnauthorized:        CategoryClientError,
+	ErrCodeInvalidSignature:    CategoryClientError,
+	ErrCodeForbidden:           CategoryClientError,
+	ErrCodeSelfApproval:        CategoryClientError,
+	ErrCodeQuotaExceeded:       CategoryClientError,
+	ErrCodeAmountTooSmall:      CategoryClientError,
+	ErrCodeAmountTooLarge:      CategoryClientError,
+	ErrCodeDuplicatePayment:    CategoryClientError,
+	ErrCodeOrderAlreadyPaid:    CategoryClientError,
+	ErrCodeRouteNotFound:       CategoryClientError,
+	ErrCodeMethodNotAllowed:    CategoryClientError,
+	ErrCodeCardVelocity:        CategoryClientError,
+	ErrCodeInvalidState:        CategoryBusinessRule,
+	ErrCodeInvalidTransition:   CategoryBusinessRule,
+	ErrCodePaymentExpired:      CategoryBusinessRule,
+	ErrCodeInternal:            CategoryInfrastructure,
+	ErrCodeRequestProcessing:   CategoryTransient,
+	ErrCodeTimeout:             CategoryTransient,
+	ErrCodeRegionStandby:       CategoryTransient,
+	ErrCodeChaosInjected:       CategoryTransient,
+}
+
+func lookupError(err error) (errorMapping, bool) {
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.err) {
+			return mapping, true
+		}
 	}
+	return errorMapping{}, false
+}
 
-	// State/Transition errors are conflicts
-	if errors.Is(err, domain.ErrInvalidTransition) ||
-		errors.Is(err, domain.ErrInvalidState) {
-		return CategoryBusinessRule
+// CategorizeError determines error category for retry and logging purposes. The cause
+// a ServiceError wraps decides before the wrapper does, so an internal error about a
+// missing payment is not retried.
+func CategorizeError(err error) ErrorCategory {
+	if err == nil {
+		return ""
 	}
 
-	// Persistence Errors
-	if errors.Is(err, postgres.ErrPaymentNotFound) ||
-		errors.Is(err, domain.ErrMissingRequiredField) {
-		return CategoryClientError
+	if mapping, ok := lookupError(err); ok {
+		return mapping.category
 	}
 
 	// Service/Application Errors
 	if svcErr, ok := IsServiceError(err); ok {
-		switch svcErr.Code {
-		case ErrCodeIdempotencyMismatch, ErrCodeInvalidInput:
-			return CategoryClientError
-		case ErrCodeInternal:
-			return CategoryInfrastructure
-		case ErrCodeRequestProcessing, ErrCodeTimeout:
-			return CategoryTransient
+		if category, ok := serviceErrorCategories[svcErr.Code]; ok {
+			return category
 		}
 	}
 
@@ -110,6 +167,9 @@ func CategorizeError(err error) ErrorCategory {
 		// TRANSIENT: Infrastructure Issues (Retry safe)
 		case "internal_error":
 			return CategoryTransient
+		// The call was held back before reaching the bank and can be made again later
+		case bank.CodeRateLimited:
+			return CategoryTransient
 
 		default:
 			return CategoryPermanent
@@ -126,6 +186,17 @@ func IsRetryable(err error) bool {
 	return category == CategoryTransient || category == CategoryInfrastructure
 }
 
+// DeclineCategoryOf returns the category of a bank's refusal, for errors where the bank
+// declined the request for good rather than failed to answer it or could not find what
+// it was asked about
+func DeclineCategoryOf(err error) (domain.DeclineCategory, bool) {
+	bankErr, ok := bank.IsBankError(err)
+	if !ok || CategorizeError(err) != CategoryPermanent {
+		return "", false
+	}
+	return domain.ClassifyDecline(bankErr.Code), true
+}
+
 // ToHTTPStatus maps error to appropriate HTTP status code
 func ToHTTPStatus(err error) int {
 	if err == nil {
@@ -135,27 +206,9 @@ func ToHTTPStatus(err error) int {
 	if svcErr, ok := IsServiceError(err); ok {
 		return svcErr.HTTPStatus
 	}
-
-	switch {
-	case errors.Is(err, domain.ErrInvalidAmount),
-		errors.Is(err, domain.ErrMissingRequiredField):
-		return http.StatusBadRequest
-	case errors.Is(err, domain.ErrInvalidTransition),
-		errors.Is(err, domain.ErrInvalidState),
-		errors.Is(err, postgres.ErrDuplicateIdempotencyKey),
-		errors.Is(err, domain.ErrPaymentExpired):
-		return http.StatusConflict
-
-	case errors.Is(err, postgres.ErrPaymentNotFound):
-		return http.StatusNotFound
-
-	case errors.Is(err, context.DeadlineExceeded):
-		return http.StatusRequestTimeout
-
-	case errors.Is(err, context.Canceled):
-		return http.StatusRequestTimeout
+	if mapping, ok := lookupError(err); ok {
+		return mapping.status
 	}
-
 	if bankErr, ok := bank.IsBankError(err); ok {
 		return bankErr.StatusCode
 	}
@@ -169,40 +222,12 @@ func ToErrorCode(err error) string {
 	if svcErr, ok := IsServiceError(err); ok {
 		return svcErr.Code
 	}
-
-	if errors.Is(err, domain.ErrInvalidTransition) {
-		return "INVALID_TRANSITION"
-	}
-	if errors.Is(err, domain.ErrPaymentExpired) {
-		return "PAYMENT_EXPIRED"
-	}
-	if errors.Is(err, domain.ErrInvalidAmount) {
-		return "INVALID_AMOUNT"
+	if mapping, ok := lookupError(err); ok {
+		return mapping.code
 	}
-	if errors.Is(err, domain.ErrMissingRequiredField) {
-		return "MISSING_REQUIRED_FIELD"
-	}
-	if errors.Is(err, domain.ErrInvalidState) {
-		return "INVALID_STATE"
-	}
-	if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
-		return "DUPLICATE_IDEMPOTENCY_KEY"
-	}
-	if errors.Is(err, postgres.ErrPaymentNotFound) {
-		return "PAYMENT_NOT_FOUND"
-	}
-
 	if bankErr, ok := bank.IsBankError(err); ok {
 		return strings.ToUpper(bankErr.Code)
 	}
 
-	if errors.Is(err, context.DeadlineExceeded) {
-		return "TIMEOUT"
-	}
-
-	if svcErr, ok := IsServiceError(err); ok {
-		return svcErr.Code
-	}
-
-	return "INTERNAL_ERROR"
+	return ErrCodeInternal
 }
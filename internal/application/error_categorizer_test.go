@@ -0,0 +1,58 @@
+package application_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		code     string
+		status   int
+		category application.ErrorCategory
+	}{
+		{"expired deadline", fmt.Errorf("call bank: %w", context.DeadlineExceeded),
+			application.ErrCodeTimeout, http.StatusRequestTimeout, application.CategoryTransient},
+		{"canceled request", context.Canceled,
+			application.ErrCodeTimeout, http.StatusRequestTimeout, application.CategoryTransient},
+		{"closed payment", fmt.Errorf("%w: pay-1 is already closed", postgres.ErrPaymentTerminal),
+			application.ErrCodeInvalidState, http.StatusConflict, application.CategoryBusinessRule},
+		{"missing payment", postgres.ErrPaymentNotFound,
+			"PAYMENT_NOT_FOUND", http.StatusNotFound, application.CategoryClientError},
+		{"missing field", domain.ErrMissingRequiredField,
+			application.ErrCodeMissingRequiredField, http.StatusBadRequest, application.CategoryClientError},
+		{"service error", application.NewTimeoutError(),
+			application.ErrCodeTimeout, http.StatusRequestTimeout, application.CategoryTransient},
+		{"refused transition", application.NewInvalidStateError(fmt.Errorf("payment is settled")),
+			application.ErrCodeInvalidState, http.StatusConflict, application.CategoryBusinessRule},
+		{"invalid input", application.NewInvalidInputError(domain.ErrMissingRequiredField),
+			application.ErrCodeInvalidInput, http.StatusBadRequest, application.CategoryClientError},
+		{"invalid input ranked by its cause", application.NewInvalidInputError(domain.ErrInvalidAmount),
+			application.ErrCodeInvalidInput, http.StatusBadRequest, application.CategoryBusinessRule},
+		{"unknown error", fmt.Errorf("boom"),
+			application.ErrCodeInternal, http.StatusInternalServerError, application.CategoryTransient},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, application.ToErrorCode(tt.err))
+			assert.Equal(t, tt.status, application.ToHTTPStatus(tt.err))
+			assert.Equal(t, tt.category, application.CategorizeError(tt.err))
+		})
+	}
+}
+
+func TestNewInvalidInputError_KeepsCause(t *testing.T) {
+	err := application.NewInvalidInputError(domain.ErrInvalidAmount)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	assert.Contains(t, err.Error(), domain.ErrInvalidAmount.Error())
+}
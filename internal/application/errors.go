@@ -11,6 +11,8 @@ type ServiceError struct {
 	Message    string
 	HTTPStatus int
 	Err        error
+	// PaymentID is the existing payment the error refers to, if any
+	PaymentID string
 }
 
 func (e *ServiceError) Error() string {
@@ -33,6 +35,24 @@ const (
 	ErrCodeInvalidState        = "INVALID_STATE"
 	ErrCodeInvalidTransition   = "INVALID_TRANSITION"
 	ErrCodePaymentExpired      = "PAYMENT_EXPIRED"
+	ErrCodeUnauthorized        = "UNAUTHORIZED"
+	ErrCodeForbidden           = "FORBIDDEN"
+	ErrCodeInvalidSignature    = "INVALID_SIGNATURE"
+	ErrCodeQuotaExceeded       = "QUOTA_EXCEEDED"
+	ErrCodeAmountTooSmall      = "AMOUNT_TOO_SMALL"
+	ErrCodeAmountTooLarge      = "AMOUNT_TOO_LARGE"
+	ErrCodeDuplicatePayment    = "DUPLICATE_PAYMENT"
+	ErrCodeOrderAlreadyPaid    = "ORDER_ALREADY_PAID"
+	ErrCodeSelfApproval        = "SELF_APPROVAL"
+	ErrCodeRegionStandby       = "REGION_STANDBY"
+	ErrCodeChaosInjected       = "CHAOS_INJECTED"
+	ErrCodeRouteNotFound       = "ROUTE_NOT_FOUND"
+	ErrCodeMethodNotAllowed    = "METHOD_NOT_ALLOWED"
+	ErrCodeCardVelocity        = "CARD_VELOCITY_EXCEEDED"
+
+	ErrCodeInvalidAmount           = "INVALID_AMOUNT"
+	ErrCodeMissingRequiredField    = "MISSING_REQUIRED_FIELD"
+	ErrCodeDuplicateIdempotencyKey = "DUPLICATE_IDEMPOTENCY_KEY"
 )
 
 func NewIdempotencyMismatchError() *ServiceError {
@@ -43,11 +63,14 @@ func NewIdempotencyMismatchError() *ServiceError {
 	}
 }
 
-func NewRequestProcessingError() *ServiceError {
+// NewRequestProcessingError answers a request whose idempotency key is held by an
+// earlier request for paymentID that has not finished
+func NewRequestProcessingError(paymentID string) *ServiceError {
 	return &ServiceError{
 		Code:       ErrCodeRequestProcessing,
 		Message:    "Request is being processed. Please retry in a moment.",
-		HTTPStatus: http.StatusAccepted,
+		HTTPStatus: http.StatusConflict,
+		PaymentID:  paymentID,
 	}
 }
 
@@ -73,6 +96,17 @@ func NewInvalidInputError(err error) *ServiceError {
 		Code:       ErrCodeInvalidInput,
 		Message:    "Invalid input",
 		HTTPStatus: http.StatusBadRequest,
+		Err:        err,
+	}
+}
+
+// NewInvalidBodyError answers a request whose JSON body was rejected, saying why in err
+func NewInvalidBodyError(err error) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeInvalidInput,
+		Message:    "Invalid request body",
+		HTTPStatus: http.StatusBadRequest,
+		Err:        err,
 	}
 }
 
@@ -103,6 +137,141 @@ func NewPaymentExpiredError(err error) *ServiceError {
 	}
 }
 
+func NewUnauthorizedError() *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeUnauthorized,
+		Message:    "Missing or invalid API key",
+		HTTPStatus: http.StatusUnauthorized,
+	}
+}
+
+// NewInvalidSignatureError rejects a signed request; err says why, since a client
+// cannot otherwise tell a stale timestamp from a wrong signature
+func NewInvalidSignatureError(err error) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeInvalidSignature,
+		Message:    "Invalid request signature",
+		HTTPStatus: http.StatusUnauthorized,
+		Err:        err,
+	}
+}
+
+func NewForbiddenError() *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeForbidden,
+		Message:    "API key role does not allow this operation",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+// NewSelfApprovalError rejects the approval of a refund with the API key that
+// requested it
+func NewSelfApprovalError() *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeSelfApproval,
+		Message:    "A refund must be approved with another API key than the one that requested it",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+func NewQuotaExceededError() *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeQuotaExceeded,
+		Message:    "Daily transaction quota exceeded",
+		HTTPStatus: http.StatusTooManyRequests,
+	}
+}
+
+// NewCardVelocityExceededError rejects a payment with a card used too often recently;
+// err names the limit
+func NewCardVelocityExceededError(err error) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeCardVelocity,
+		Message:    "Card velocity limit exceeded",
+		HTTPStatus: http.StatusTooManyRequests,
+		Err:        err,
+	}
+}
+
+// NewAmountTooSmallError rejects a payment below its currency's minimum; err names it
+func NewAmountTooSmallError(err error) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeAmountTooSmall,
+		Message:    "Amount too small",
+		HTTPStatus: http.StatusBadRequest,
+		Err:        err,
+	}
+}
+
+// NewAmountTooLargeError rejects a payment above its currency's maximum; err names it
+func NewAmountTooLargeError(err error) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeAmountTooLarge,
+		Message:    "Amount too large",
+		HTTPStatus: http.StatusBadRequest,
+		Err:        err,
+	}
+}
+
+// NewDuplicatePaymentError rejects a payment made again under a new idempotency key
+// while paymentID, for the same order, customer and amount, is recent
+func NewDuplicatePaymentError(paymentID string) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeDuplicatePayment,
+		Message:    "A payment for this order, customer and amount was already made",
+		HTTPStatus: http.StatusConflict,
+		PaymentID:  paymentID,
+	}
+}
+
+func NewOrderAlreadyPaidError(err error) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeOrderAlreadyPaid,
+		Message:    "Order already paid",
+		HTTPStatus: http.StatusConflict,
+		Err:        err,
+	}
+}
+
+// NewRegionStandbyError rejects a change sent to a region on standby. It is answered
+// with 503 so clients that fail over on unavailability send it to the active region.
+func NewRegionStandbyError() *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeRegionStandby,
+		Message:    "This region is on standby and does not accept changes; send them to the active region",
+		HTTPStatus: http.StatusServiceUnavailable,
+	}
+}
+
+// NewChaosInjectedError fails a request on purpose, with status, while chaos injection
+// is rehearsing failures in staging
+func NewChaosInjectedError(status int) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeChaosInjected,
+		Message:    "Fault injected by the gateway for chaos testing",
+		HTTPStatus: status,
+	}
+}
+
+// NewRouteNotFoundError answers a request for a path the API does not serve
+func NewRouteNotFoundError(method, path string) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeRouteNotFound,
+		Message:    fmt.Sprintf("No route for %s %s", method, path),
+		HTTPStatus: http.StatusNotFound,
+	}
+}
+
+// NewMethodNotAllowedError answers a request for a path the API serves, but not with
+// its method
+func NewMethodNotAllowedError(method, path string) *ServiceError {
+	return &ServiceError{
+		Code:       ErrCodeMethodNotAllowed,
+		Message:    fmt.Sprintf("%s is not allowed on %s", method, path),
+		HTTPStatus: http.StatusMethodNotAllowed,
+	}
+}
+
 func IsServiceError(err error) (*ServiceError, bool) {
 	var svcErr *ServiceError
 	ok := errors.As(err, &svcErr)
@@ -0,0 +1,47 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// Capturer captures an authorized payment, as services.CaptureService does
+type Capturer interface {
+	Capture(ctx context.Context, paymentID string, amount int64, idempotencyKey string) (*domain.Payment, error)
+}
+
+// MerchantSettingsFinder returns the settings of the merchant in ctx
+type MerchantSettingsFinder interface {
+	Find(ctx context.Context) (*domain.MerchantSettings, error)
+}
+
+// AutoCapture captures a payment in full once it is authorized, for merchants that
+// have turned auto-capture on. Register it for StatusAuthorized. Only the bank's first
+// answer counts, which always comes from PENDING, since payments held for review go
+// back there first: a payment that returns to AUTHORIZED because a void or capture
+// failed or it was reauthorized is left alone. The capture is keyed by the event, so
+// a redelivered event does not capture twice; a capture that cannot succeed, such as
+// one for a payment captured or voided in the meantime, is dropped.
+func AutoCapture(settings MerchantSettingsFinder, capturer Capturer) Hook {
+	return func(ctx context.Context, event *domain.TransitionEvent) error {
+		if event.FromStatus == nil || *event.FromStatus != domain.StatusPending {
+			return nil
+		}
+
+		s, err := settings.Find(ctx)
+		if err != nil {
+			return err
+		}
+		if !s.AutoCapture {
+			return nil
+		}
+
+		_, err = capturer.Capture(ctx, event.PaymentID, 0, "auto-capture-"+event.ID)
+		if err != nil && application.IsRetryable(err) {
+			return err
+		}
+		return nil
+	}
+}
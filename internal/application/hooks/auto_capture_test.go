@@ -0,0 +1,91 @@
+package hooks_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSettings struct {
+	settings *domain.MerchantSettings
+}
+
+func (f fakeSettings) Find(context.Context) (*domain.MerchantSettings, error) {
+	return f.settings, nil
+}
+
+type fakeCapturer struct {
+	keys []string
+	err  error
+}
+
+func (f *fakeCapturer) Capture(_ context.Context, _ string, _ int64, idempotencyKey string) (*domain.Payment, error) {
+	f.keys = append(f.keys, idempotencyKey)
+	return nil, f.err
+}
+
+func TestAutoCapture(t *testing.T) {
+	pending := domain.StatusPending
+	event := &domain.TransitionEvent{ID: "evt-1", PaymentID: "pay-1", FromStatus: &pending, ToStatus: domain.StatusAuthorized}
+
+	t.Run("captures when the merchant enabled it", func(t *testing.T) {
+		capturer := &fakeCapturer{}
+		hook := hooks.AutoCapture(fakeSettings{&domain.MerchantSettings{AutoCapture: true}}, capturer)
+
+		require.NoError(t, hook(context.Background(), event))
+		assert.Equal(t, []string{"auto-capture-evt-1"}, capturer.keys)
+	})
+
+	t.Run("leaves the payment authorized otherwise", func(t *testing.T) {
+		capturer := &fakeCapturer{}
+		hook := hooks.AutoCapture(fakeSettings{&domain.MerchantSettings{}}, capturer)
+
+		require.NoError(t, hook(context.Background(), event))
+		assert.Empty(t, capturer.keys)
+	})
+
+	t.Run("drops a capture the payment no longer allows", func(t *testing.T) {
+		capturer := &fakeCapturer{err: application.NewInvalidStateError(domain.ErrInvalidTransition)}
+		hook := hooks.AutoCapture(fakeSettings{&domain.MerchantSettings{AutoCapture: true}}, capturer)
+
+		assert.NoError(t, hook(context.Background(), event))
+	})
+
+	t.Run("retries a transient failure", func(t *testing.T) {
+		capturer := &fakeCapturer{err: application.NewTimeoutError()}
+		hook := hooks.AutoCapture(fakeSettings{&domain.MerchantSettings{AutoCapture: true}}, capturer)
+
+		assert.Error(t, hook(context.Background(), event))
+	})
+
+	t.Run("leaves payments that return to AUTHORIZED alone", func(t *testing.T) {
+		tests := []struct {
+			name string
+			from domain.PaymentStatus
+		}{
+			{"void rolled back", domain.StatusVoiding},
+			{"capture rolled back", domain.StatusCapturing},
+			{"reauthorized", domain.StatusReauthorizing},
+			{"no from status", ""},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				capturer := &fakeCapturer{}
+				hook := hooks.AutoCapture(fakeSettings{&domain.MerchantSettings{AutoCapture: true}}, capturer)
+				rollback := &domain.TransitionEvent{ID: "evt-2", PaymentID: "pay-1", ToStatus: domain.StatusAuthorized}
+				if tt.from != "" {
+					rollback.FromStatus = &tt.from
+				}
+
+				require.NoError(t, hook(context.Background(), rollback))
+				assert.Empty(t, capturer.keys)
+			})
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// Notifier hands customer notifications to the notification service
+type Notifier interface {
+	Notify(ctx context.Context, n domain.CustomerNotification) error
+}
+
+// OperationFinder returns the operations of a payment of the merchant in ctx, oldest
+// first, as postgres.OperationRepository does
+type OperationFinder interface {
+	FindByPaymentID(ctx context.Context, paymentID string) ([]*domain.Operation, error)
+}
+
+// notifiedPayment is the part of an event payload a notification is made from. Both
+// the versioned payloads and the whole rows of older events name their fields so.
+type notifiedPayment struct {
+	OrderID         string  `json:"order_id"`
+	CustomerID      string  `json:"customer_id"`
+	AmountCents     int64   `json:"amount_cents"`
+	Currency        string  `json:"currency"`
+	FailureReason   *string `json:"failure_reason"`
+	AuthorizedAt    *string `json:"authorized_at"`
+	PaymentMethodID *string `json:"payment_method_id"`
+}
+
+// NotifyCustomer tells customers of merchants with customer notifications on that a
+// refund went through or that their payment failed before it was authorized, asking
+// them to update their saved card when it failed for having expired. Register
+// it with OnAny. The notification is keyed by the event, so the notification service
+// can drop one delivered again.
+func NotifyCustomer(settings MerchantSettingsFinder, operations OperationFinder, notifier Notifier) Hook {
+	return func(ctx context.Context, event *domain.TransitionEvent) error {
+		refunded := event.FromStatus != nil && *event.FromStatus == domain.StatusRefunding &&
+			(event.ToStatus == domain.StatusRefunded || event.ToStatus == domain.StatusCaptured)
+		if !refunded && event.ToStatus != domain.StatusFailed {
+			return nil
+		}
+
+		s, err := settings.Find(ctx)
+		if err != nil {
+			return err
+		}
+		if len(s.CustomerNotifications) == 0 {
+			return nil
+		}
+
+		var payment notifiedPayment
+		if err := json.Unmarshal(event.Payload, &payment); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+
+		n := domain.CustomerNotification{
+			Key:         event.ID,
+			Kind:        domain.NotificationPaymentFailed,
+			Channels:    s.CustomerNotifications,
+			MerchantID:  event.MerchantID,
+			CustomerID:  payment.CustomerID,
+			PaymentID:   event.PaymentID,
+			OrderID:     payment.OrderID,
+			AmountCents: payment.AmountCents,
+			Currency:    payment.Currency,
+			OccurredAt:  event.OccurredAt,
+		}
+
+		if refunded {
+			refund, err := completedRefund(ctx, operations, event)
+			if err != nil {
+				return err
+			}
+			// The bank rejected the refund, which the customer has no use for hearing
+			if refund == nil || refund.Status != domain.OperationSucceeded {
+				return nil
+			}
+			n.Kind = domain.NotificationRefundCompleted
+			n.AmountCents = refund.AmountCents
+			n.RefundDestination = refund.Destination
+		} else {
+			// A payment failing later, such as one a reconciliation finds missing at
+			// the bank, is for the merchant to explain
+			if payment.AuthorizedAt != nil {
+				return nil
+			}
+			n.FailureReason = payment.FailureReason
+			if payment.PaymentMethodID != nil && payment.FailureReason != nil &&
+				*payment.FailureReason == domain.FailureReasonCardExpired {
+				n.Kind = domain.NotificationCardUpdateRequired
+				n.PaymentMethodID = *payment.PaymentMethodID
+			}
+		}
+
+		return notifier.Notify(ctx, n)
+	}
+}
+
+// completedRefund returns the refund whose completion moved the payment out of
+// REFUNDING in event. A payment refunds once at a time, so it is the last refund to
+// complete of those created before the event. A refund held for approval may be
+// created long before it completes, so creation order does not tell.
+func completedRefund(ctx context.Context, operations OperationFinder, event *domain.TransitionEvent) (*domain.Operation, error) {
+	ops, err := operations.FindByPaymentID(ctx, event.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var refund *domain.Operation
+	for _, op := range ops {
+		if op.Type != domain.OperationRefund || op.CompletedAt == nil || op.CreatedAt.After(event.OccurredAt) {
+			continue
+		}
+		if op.Status != domain.OperationSucceeded && op.Status != domain.OperationFailed {
+			continue
+		}
+		if refund == nil || op.CompletedAt.After(*refund.CompletedAt) {
+			refund = op
+		}
+	}
+	return refund, nil
+}
@@ -0,0 +1,142 @@
+package hooks_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOperations struct {
+	ops []*domain.Operation
+}
+
+func (f fakeOperations) FindByPaymentID(context.Context, string) ([]*domain.Operation, error) {
+	return f.ops, nil
+}
+
+type fakeNotifier struct {
+	sent []domain.CustomerNotification
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, n domain.CustomerNotification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+func refund(status domain.OperationStatus, amount int64, created, completed time.Time) *domain.Operation {
+	return &domain.Operation{
+		Type:        domain.OperationRefund,
+		Status:      status,
+		AmountCents: amount,
+		CreatedAt:   created,
+		CompletedAt: &completed,
+	}
+}
+
+func TestNotifyCustomer(t *testing.T) {
+	now := time.Now()
+	refunding := domain.StatusRefunding
+	pending := domain.StatusPending
+	notifying := fakeSettings{&domain.MerchantSettings{CustomerNotifications: []string{domain.ChannelEmail}}}
+
+	refundEvent := func(to domain.PaymentStatus) *domain.TransitionEvent {
+		return &domain.TransitionEvent{
+			ID: "evt-1", PaymentID: "pay-1", FromStatus: &refunding, ToStatus: to, SchemaVersion: 2,
+			Payload: v1Payload(t, func(p map[string]any) { p["status"] = string(to) }), OccurredAt: now,
+		}
+	}
+	failedEvent := func(authorized bool) *domain.TransitionEvent {
+		return &domain.TransitionEvent{
+			ID: "evt-2", PaymentID: "pay-1", FromStatus: &pending, ToStatus: domain.StatusFailed, SchemaVersion: 2,
+			Payload: v1Payload(t, func(p map[string]any) {
+				p["status"] = "FAILED"
+				p["failure_reason"] = "card_expired"
+				if !authorized {
+					p["authorized_at"] = nil
+				}
+			}), OccurredAt: now,
+		}
+	}
+
+	t.Run("tells the customer a partial refund went through", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		ops := fakeOperations{[]*domain.Operation{refund(domain.OperationSucceeded, 1500, now.Add(-time.Minute), now)}}
+
+		require.NoError(t, hooks.NotifyCustomer(notifying, ops, notifier)(context.Background(), refundEvent(domain.StatusCaptured)))
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, domain.NotificationRefundCompleted, notifier.sent[0].Kind)
+		assert.Equal(t, "evt-1", notifier.sent[0].Key)
+		assert.Equal(t, int64(1500), notifier.sent[0].AmountCents)
+		assert.Equal(t, "cust-1", notifier.sent[0].CustomerID)
+		assert.Equal(t, []string{domain.ChannelEmail}, notifier.sent[0].Channels)
+	})
+
+	t.Run("picks the refund that completed last", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		// The first refund waited for approval while the second went through
+		ops := fakeOperations{[]*domain.Operation{
+			refund(domain.OperationSucceeded, 1000, now.Add(-time.Hour), now),
+			refund(domain.OperationSucceeded, 500, now.Add(-30*time.Minute), now.Add(-29*time.Minute)),
+		}}
+
+		require.NoError(t, hooks.NotifyCustomer(notifying, ops, notifier)(context.Background(), refundEvent(domain.StatusCaptured)))
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, int64(1000), notifier.sent[0].AmountCents)
+	})
+
+	t.Run("says nothing of a refund the bank rejected", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		ops := fakeOperations{[]*domain.Operation{
+			refund(domain.OperationSucceeded, 1000, now.Add(-time.Hour), now.Add(-time.Hour)),
+			refund(domain.OperationFailed, 500, now.Add(-time.Minute), now),
+		}}
+
+		require.NoError(t, hooks.NotifyCustomer(notifying, ops, notifier)(context.Background(), refundEvent(domain.StatusCaptured)))
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("tells the customer a payment failed before authorization", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+
+		require.NoError(t, hooks.NotifyCustomer(notifying, fakeOperations{}, notifier)(context.Background(), failedEvent(false)))
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, domain.NotificationPaymentFailed, notifier.sent[0].Kind)
+		assert.Equal(t, "card_expired", *notifier.sent[0].FailureReason)
+		assert.Equal(t, int64(5000), notifier.sent[0].AmountCents)
+	})
+
+	t.Run("asks the customer to update an expired saved card", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+		event := failedEvent(false)
+		event.Payload = v1Payload(t, func(p map[string]any) {
+			p["status"] = "FAILED"
+			p["failure_reason"] = "card_expired"
+			p["authorized_at"] = nil
+			p["payment_method_id"] = "6f1c2b9e-3d4a-4b5c-8d7e-9f0a1b2c3d4e"
+		})
+
+		require.NoError(t, hooks.NotifyCustomer(notifying, fakeOperations{}, notifier)(context.Background(), event))
+		require.Len(t, notifier.sent, 1)
+		assert.Equal(t, domain.NotificationCardUpdateRequired, notifier.sent[0].Kind)
+		assert.Equal(t, "6f1c2b9e-3d4a-4b5c-8d7e-9f0a1b2c3d4e", notifier.sent[0].PaymentMethodID)
+	})
+
+	t.Run("leaves the failure of an authorized payment to the merchant", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+
+		require.NoError(t, hooks.NotifyCustomer(notifying, fakeOperations{}, notifier)(context.Background(), failedEvent(true)))
+		assert.Empty(t, notifier.sent)
+	})
+
+	t.Run("sends nothing for merchants without notifications", func(t *testing.T) {
+		notifier := &fakeNotifier{}
+
+		require.NoError(t, hooks.NotifyCustomer(fakeSettings{&domain.MerchantSettings{}}, fakeOperations{}, notifier)(context.Background(), failedEvent(false)))
+		assert.Empty(t, notifier.sent)
+	})
+}
@@ -0,0 +1,22 @@
+package hooks
+
+import (
+	"context"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// ReadModelRefresher copies a payment of the merchant in ctx into the read model, as
+// postgres.PaymentReadModelRepository does
+type ReadModelRefresher interface {
+	Refresh(ctx context.Context, paymentID string) error
+}
+
+// RefreshReadModel brings the payment's read model copy up to date after each of its
+// transitions. Register it with OnAny. The copy is taken from the payment rather than
+// the event, so a redelivered or late event leaves it as current as any other.
+func RefreshReadModel(readModel ReadModelRefresher) Hook {
+	return func(ctx context.Context, event *domain.TransitionEvent) error {
+		return readModel.Refresh(ctx, event.PaymentID)
+	}
+}
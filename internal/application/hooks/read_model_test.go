@@ -0,0 +1,38 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReadModel struct {
+	refreshed []string
+	err       error
+}
+
+func (f *fakeReadModel) Refresh(_ context.Context, paymentID string) error {
+	f.refreshed = append(f.refreshed, paymentID)
+	return f.err
+}
+
+func TestRefreshReadModel(t *testing.T) {
+	event := &domain.TransitionEvent{ID: "evt-1", PaymentID: "pay-1", ToStatus: domain.StatusCaptured}
+
+	t.Run("refreshes the payment of the event", func(t *testing.T) {
+		readModel := &fakeReadModel{}
+
+		assert.NoError(t, hooks.RefreshReadModel(readModel)(context.Background(), event))
+		assert.Equal(t, []string{"pay-1"}, readModel.refreshed)
+	})
+
+	t.Run("leaves a failed refresh for redelivery", func(t *testing.T) {
+		readModel := &fakeReadModel{err: errors.New("connection reset")}
+
+		assert.Error(t, hooks.RefreshReadModel(readModel)(context.Background(), event))
+	})
+}
@@ -0,0 +1,98 @@
+// Package hooks lets modules react to payment status transitions without the services
+// knowing about them. Hooks are fed from the outbox, so they run after the transition
+// has committed and at least once; a hook must tolerate seeing the same event again.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+type Hook func(ctx context.Context, event *domain.TransitionEvent) error
+
+type subscription struct {
+	name string
+	// status is the target status the hook listens for; empty means every transition
+	status domain.PaymentStatus
+	hook   Hook
+}
+
+type Registry struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	schemas       *Schemas
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// WithSchemas makes Dispatch refuse events whose payload does not match the schema of
+// its version. They stay in the outbox instead of reaching hooks in a shape they do not
+// expect.
+func (r *Registry) WithSchemas(schemas *Schemas) *Registry {
+	r.schemas = schemas
+	return r
+}
+
+// On subscribes hook to transitions into status
+func (r *Registry) On(status domain.PaymentStatus, name string, hook Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions = append(r.subscriptions, subscription{name: name, status: status, hook: hook})
+}
+
+// OnAny subscribes hook to every transition
+func (r *Registry) OnAny(name string, hook Hook) {
+	r.On("", name, hook)
+}
+
+// Dispatch runs every matching hook in registration order. All hooks run even if one
+// fails; the returned error names each failed hook so the event can be retried.
+func (r *Registry) Dispatch(ctx context.Context, event *domain.TransitionEvent) error {
+	r.mu.RLock()
+	subscriptions := r.subscriptions
+	r.mu.RUnlock()
+
+	if r.schemas != nil {
+		if err := r.schemas.Validate(event); err != nil {
+			return fmt.Errorf("invalid event: %w", err)
+		}
+	}
+
+	var errs []error
+	for _, s := range subscriptions {
+		if s.status != "" && s.status != event.ToStatus {
+			continue
+		}
+		if err := s.hook(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("hook %s: %w", s.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LogTransition logs every transition; it is registered by default so the outbox is
+// observable before any other module subscribes.
+func LogTransition(logger *slog.Logger) Hook {
+	return func(_ context.Context, event *domain.TransitionEvent) error {
+		from := ""
+		if event.FromStatus != nil {
+			from = string(*event.FromStatus)
+		}
+		logger.Info("payment transition",
+			"event_id", event.ID,
+			"payment_id", event.PaymentID,
+			"event_type", event.EventType,
+			"from_status", from,
+			"to_status", event.ToStatus,
+			"actor", event.Actor,
+			"attempt_count", event.AttemptCount)
+		return nil
+	}
+}
@@ -0,0 +1,51 @@
+package hooks_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_DispatchRunsMatchingHooks(t *testing.T) {
+	registry := hooks.NewRegistry()
+
+	var called []string
+	record := func(name string) hooks.Hook {
+		return func(context.Context, *domain.TransitionEvent) error {
+			called = append(called, name)
+			return nil
+		}
+	}
+
+	registry.On(domain.StatusCaptured, "ledger", record("ledger"))
+	registry.On(domain.StatusRefunded, "notifications", record("notifications"))
+	registry.OnAny("webhooks", record("webhooks"))
+
+	err := registry.Dispatch(context.Background(), &domain.TransitionEvent{ToStatus: domain.StatusCaptured})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ledger", "webhooks"}, called)
+}
+
+func TestRegistry_DispatchRunsAllHooksAndJoinsErrors(t *testing.T) {
+	registry := hooks.NewRegistry()
+	errLedger := errors.New("ledger unavailable")
+
+	var webhookCalled bool
+	registry.OnAny("ledger", func(context.Context, *domain.TransitionEvent) error {
+		return errLedger
+	})
+	registry.OnAny("webhooks", func(context.Context, *domain.TransitionEvent) error {
+		webhookCalled = true
+		return nil
+	})
+
+	err := registry.Dispatch(context.Background(), &domain.TransitionEvent{ToStatus: domain.StatusVoided})
+	require.ErrorIs(t, err, errLedger)
+	assert.Contains(t, err.Error(), "hook ledger")
+	assert.True(t, webhookCalled)
+}
@@ -0,0 +1,128 @@
+package hooks
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+var schemaFileName = regexp.MustCompile(`^payment_transition\.v(\d+)\.json$`)
+
+var (
+	ErrUnknownSchemaVersion = errors.New("unknown event schema version")
+	ErrIncompatibleSchema   = errors.New("event schema is incompatible with the version before it")
+)
+
+// Schemas holds the JSON schema of every version of the transition payload, from
+// schemas/payment_transition.v<N>.json. Each version may only add optional fields to
+// the one before, so a consumer written against any version keeps working.
+type Schemas struct {
+	versions map[int]*openapi3.Schema
+}
+
+// LoadSchemas reads the schemas built into the binary and checks that each version is
+// compatible with the one before it and that the version the gateway writes is there
+func LoadSchemas() (*Schemas, error) {
+	entries, err := fs.ReadDir(schemaFiles, "schemas")
+	if err != nil {
+		return nil, fmt.Errorf("read event schemas: %w", err)
+	}
+
+	s := &Schemas{versions: map[int]*openapi3.Schema{}}
+	for _, entry := range entries {
+		match := schemaFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("event schema %s: name is not payment_transition.v<N>.json", entry.Name())
+		}
+		version, _ := strconv.Atoi(match[1]) //nolint:errcheck // the pattern only matches digits
+
+		data, err := schemaFiles.ReadFile("schemas/" + entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read event schema %s: %w", entry.Name(), err)
+		}
+		var schema openapi3.Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("parse event schema %s: %w", entry.Name(), err)
+		}
+		s.versions[version] = &schema
+	}
+
+	if _, ok := s.versions[domain.TransitionSchemaVersion]; !ok {
+		return nil, fmt.Errorf("version %d: %w", domain.TransitionSchemaVersion, ErrUnknownSchemaVersion)
+	}
+
+	versions := s.Versions()
+	for i := 1; i < len(versions); i++ {
+		if err := CheckCompatible(s.versions[versions[i-1]], s.versions[versions[i]]); err != nil {
+			return nil, fmt.Errorf("version %d: %w", versions[i], err)
+		}
+	}
+	return s, nil
+}
+
+// Versions returns the known schema versions, oldest first
+func (s *Schemas) Versions() []int {
+	versions := make([]int, 0, len(s.versions))
+	for version := range s.versions {
+		versions = append(versions, version)
+	}
+	slices.Sort(versions)
+	return versions
+}
+
+// Validate checks the payload of event against the schema of its version. Events
+// written before payloads were versioned are let through unchecked.
+func (s *Schemas) Validate(event *domain.TransitionEvent) error {
+	if event.SchemaVersion == 0 {
+		return nil
+	}
+
+	schema, ok := s.versions[event.SchemaVersion]
+	if !ok {
+		return fmt.Errorf("version %d: %w", event.SchemaVersion, ErrUnknownSchemaVersion)
+	}
+
+	var payload any
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return fmt.Errorf("decode payload: %w", err)
+	}
+	if err := schema.VisitJSON(payload); err != nil {
+		return fmt.Errorf("payload does not match schema version %d: %w", event.SchemaVersion, err)
+	}
+	return nil
+}
+
+// CheckCompatible reports whether a consumer of payloads matching previous can read
+// payloads matching next: no field is removed, retyped or made nullable, and no field
+// it could rely on becomes optional
+func CheckCompatible(previous, next *openapi3.Schema) error {
+	for name, prop := range previous.Properties {
+		nextProp, ok := next.Properties[name]
+		if !ok {
+			return fmt.Errorf("%w: field %s was removed", ErrIncompatibleSchema, name)
+		}
+		if !slices.Equal(prop.Value.Type.Slice(), nextProp.Value.Type.Slice()) {
+			return fmt.Errorf("%w: field %s changed type", ErrIncompatibleSchema, name)
+		}
+		if nextProp.Value.Nullable && !prop.Value.Nullable {
+			return fmt.Errorf("%w: field %s became nullable", ErrIncompatibleSchema, name)
+		}
+	}
+	for _, name := range previous.Required {
+		if !slices.Contains(next.Required, name) {
+			return fmt.Errorf("%w: field %s became optional", ErrIncompatibleSchema, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package hooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func v1Payload(t *testing.T, change func(map[string]any)) []byte {
+	t.Helper()
+	payload := map[string]any{
+		"id":                    "550e8400-e29b-41d4-a716-446655440000",
+		"merchant_id":           "default",
+		"order_id":              "order-1",
+		"customer_id":           "cust-1",
+		"amount_cents":          5000,
+		"currency":              "USD",
+		"status":                "AUTHORIZED",
+		"acquirer":              "primary",
+		"bank_auth_id":          "auth-1",
+		"bank_capture_id":       nil,
+		"bank_void_id":          nil,
+		"bank_refund_id":        nil,
+		"captured_amount_cents": 0,
+		"refunded_amount_cents": 0,
+		"failure_reason":        nil,
+		"payment_method_id":     nil,
+		"attempt_count":         0,
+		"created_at":            "2026-03-01T12:00:00+00:00",
+		"authorized_at":         "2026-03-01T12:00:01+00:00",
+		"captured_at":           nil,
+		"voided_at":             nil,
+		"refunded_at":           nil,
+		"expires_at":            "2026-03-08T12:00:01+00:00",
+	}
+	if change != nil {
+		change(payload)
+	}
+	data, err := json.Marshal(payload)
+	require.NoError(t, err)
+	return data
+}
+
+func TestSchemas_LoadsEveryVersionUpToTheCurrentOne(t *testing.T) {
+	schemas, err := hooks.LoadSchemas()
+
+	require.NoError(t, err)
+	assert.Contains(t, schemas.Versions(), domain.TransitionSchemaVersion)
+}
+
+func TestSchemas_Validate(t *testing.T) {
+	schemas, err := hooks.LoadSchemas()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		event   domain.TransitionEvent
+		wantErr bool
+	}{
+		{"matching payload", domain.TransitionEvent{SchemaVersion: 1, Payload: v1Payload(t, nil)}, false},
+		{"unversioned payload", domain.TransitionEvent{SchemaVersion: 0, Payload: []byte(`{"anything": true}`)}, false},
+		{"missing field", domain.TransitionEvent{SchemaVersion: 1, Payload: v1Payload(t, func(p map[string]any) {
+			delete(p, "amount_cents")
+		})}, true},
+		{"retyped field", domain.TransitionEvent{SchemaVersion: 1, Payload: v1Payload(t, func(p map[string]any) {
+			p["amount_cents"] = "5000"
+		})}, true},
+		{"field outside the schema", domain.TransitionEvent{SchemaVersion: 1, Payload: v1Payload(t, func(p map[string]any) {
+			p["card_number"] = "4111111111111111"
+		})}, true},
+		{"unknown version", domain.TransitionEvent{SchemaVersion: 99, Payload: v1Payload(t, nil)}, true},
+		{"status added in a later version", domain.TransitionEvent{SchemaVersion: 1, Payload: v1Payload(t, func(p map[string]any) {
+			p["status"] = "REVIEW"
+		})}, true},
+		{"status of its version", domain.TransitionEvent{SchemaVersion: 2, Payload: v1Payload(t, func(p map[string]any) {
+			p["status"] = "REVIEW"
+		})}, false},
+		{"field added in a later version", domain.TransitionEvent{SchemaVersion: 2, Payload: v1Payload(t, func(p map[string]any) {
+			p["decline_category"] = "try_other_card"
+		})}, true},
+		{"field of its version", domain.TransitionEvent{SchemaVersion: 3, Payload: v1Payload(t, func(p map[string]any) {
+			p["decline_category"] = nil
+		})}, false},
+		{"released amount of its version", domain.TransitionEvent{SchemaVersion: 4, Payload: v1Payload(t, func(p map[string]any) {
+			p["decline_category"] = nil
+			p["released_amount_cents"] = 300
+		})}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := schemas.Validate(&tt.event)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	previous := openapi3.NewObjectSchema().
+		WithProperty("id", openapi3.NewStringSchema()).
+		WithProperty("amount_cents", openapi3.NewIntegerSchema()).
+		WithRequired([]string{"id", "amount_cents"})
+
+	tests := []struct {
+		name    string
+		next    *openapi3.Schema
+		wantErr bool
+	}{
+		{"optional field added", openapi3.NewObjectSchema().
+			WithProperty("id", openapi3.NewStringSchema()).
+			WithProperty("amount_cents", openapi3.NewIntegerSchema()).
+			WithProperty("note", openapi3.NewStringSchema().WithNullable()).
+			WithRequired([]string{"id", "amount_cents"}), false},
+		{"field removed", openapi3.NewObjectSchema().
+			WithProperty("id", openapi3.NewStringSchema()).
+			WithRequired([]string{"id"}), true},
+		{"field retyped", openapi3.NewObjectSchema().
+			WithProperty("id", openapi3.NewStringSchema()).
+			WithProperty("amount_cents", openapi3.NewStringSchema()).
+			WithRequired([]string{"id", "amount_cents"}), true},
+		{"field made nullable", openapi3.NewObjectSchema().
+			WithProperty("id", openapi3.NewStringSchema()).
+			WithProperty("amount_cents", openapi3.NewIntegerSchema().WithNullable()).
+			WithRequired([]string{"id", "amount_cents"}), true},
+		{"field made optional", openapi3.NewObjectSchema().
+			WithProperty("id", openapi3.NewStringSchema()).
+			WithProperty("amount_cents", openapi3.NewIntegerSchema()).
+			WithRequired([]string{"id"}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := hooks.CheckCompatible(previous, tt.next)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, hooks.ErrIncompatibleSchema)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_DispatchRefusesEventsNotMatchingTheirSchema(t *testing.T) {
+	schemas, err := hooks.LoadSchemas()
+	require.NoError(t, err)
+
+	registry := hooks.NewRegistry().WithSchemas(schemas)
+	called := false
+	registry.OnAny("test", func(context.Context, *domain.TransitionEvent) error {
+		called = true
+		return nil
+	})
+
+	err = registry.Dispatch(context.Background(), &domain.TransitionEvent{
+		ToStatus:      domain.StatusAuthorized,
+		SchemaVersion: 1,
+		Payload:       []byte(`{"id": "550e8400-e29b-41d4-a716-446655440000"}`),
+	})
+
+	require.Error(t, err)
+	assert.False(t, called)
+}
@@ -3,11 +3,15 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
 	"github.com/google/uuid"
 )
 
@@ -20,31 +24,124 @@ type AuthorizeCommand struct {
 	CVV         string
 	ExpiryMonth int
 	ExpiryYear  int
+	// NetworkToken is charged instead of CardNumber and CVV when set. ExpiryMonth and
+	// ExpiryYear are then the token's.
+	NetworkToken domain.NetworkToken
+	// PaymentMethodID links the payment to the saved card it is charged to, if any
+	PaymentMethodID string
+	// GroupID makes the payment part GroupPart of a payment group. The parts of a group
+	// pay for the same order, so they are not checked for duplicates of each other.
+	GroupID   string
+	GroupPart int
+	// Category is the kind of goods the order is for, such as "digital". The payment is
+	// captured as soon as it is authorized when the merchant auto-captures the category.
+	Category string
+}
+
+// hashFields are the fields version 1 hashed, card number included. New keys store
+// AuthorizeService.requestHash instead; these only match keys stored before it.
+func (c AuthorizeCommand) hashFields() map[string]any {
+	return map[string]any{
+		"order_id":     c.OrderID,
+		"customer_id":  c.CustomerID,
+		"amount":       c.Amount,
+		"currency":     c.Currency,
+		"card_number":  c.CardNumber,
+		"expiry_month": c.ExpiryMonth,
+		"expiry_year":  c.ExpiryYear,
+		"network_token": map[string]any{
+			"number":     c.NetworkToken.Number,
+			"cryptogram": c.NetworkToken.Cryptogram,
+			"eci":        c.NetworkToken.ECI,
+		},
+		"payment_method_id": c.PaymentMethodID,
+		"group_id":          c.GroupID,
+		"group_part":        c.GroupPart,
+	}
+}
+
+// AuthorizeLimits holds the checks a new payment must pass before it is stored. The
+// zero value checks nothing.
+type AuthorizeLimits struct {
+	// Amounts bounds the amount of a payment in each currency that has a limit
+	Amounts domain.AmountLimits
+	// DuplicateWindow is how long after a payment another one for the same order,
+	// customer and amount is rejected as a duplicate, unless it reuses the first one's
+	// idempotency key. Zero allows duplicates.
+	DuplicateWindow time.Duration
+	// CardPayments bounds the payments one card may be used for within CardWindow, and
+	// CardCustomers the customers it may pay for, the new payment's included. Zero
+	// turns either off; both need fingerprints.
+	CardWindow    time.Duration
+	CardPayments  int
+	CardCustomers int
 }
 
 type AuthorizeService struct {
 	paymentRepo     *postgres.PaymentRepository
 	idempotencyRepo *postgres.IdempotencyRepository
+	settingsRepo    *postgres.MerchantSettingsRepository
 	bankClient      bank.BankClient
 	db              *postgres.DB
+	limits          AuthorizeLimits
+	reviews         *ReviewService
+	fingerprints    *vault.Fingerprinter
+	captures        *CaptureService
+
+	softDeclineDelay   time.Duration
+	softDeclineRetrier SoftDeclineRetrier
 }
 
 func NewAuthorizeService(
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	settingsRepo *postgres.MerchantSettingsRepository,
 	bankClient bank.BankClient,
 	db *postgres.DB,
+	limits AuthorizeLimits,
 ) *AuthorizeService {
 	return &AuthorizeService{
 		paymentRepo:     paymentRepo,
 		idempotencyRepo: idempotencyRepo,
+		settingsRepo:    settingsRepo,
 		bankClient:      bankClient,
 		db:              db,
+		limits:          limits,
 	}
 }
 
+// WithReviews holds the authorizations reviews flags in REVIEW instead of sending them
+// to the bank
+func (s *AuthorizeService) WithReviews(reviews *ReviewService) *AuthorizeService {
+	s.reviews = reviews
+	return s
+}
+
+// WithFingerprints stores the fingerprint of the card on each payment requested with a
+// card number, which the card limits count payments by
+func (s *AuthorizeService) WithFingerprints(fingerprints *vault.Fingerprinter) *AuthorizeService {
+	s.fingerprints = fingerprints
+	return s
+}
+
+// WithAutoCapture has captures capture the payments of the order categories their
+// merchant auto-captures as soon as they are authorized, so the authorization request
+// answers with the payment CAPTURED
+func (s *AuthorizeService) WithAutoCapture(captures *CaptureService) *AuthorizeService {
+	s.captures = captures
+	return s
+}
+
+// WithSoftDeclineRetry has retrier send an authorization the bank soft-declines once
+// more after delay, when its context allows it and its merchant has not opted out
+func (s *AuthorizeService) WithSoftDeclineRetry(delay time.Duration, retrier SoftDeclineRetrier) *AuthorizeService {
+	s.softDeclineDelay = delay
+	s.softDeclineRetrier = retrier
+	return s
+}
+
 func (s *AuthorizeService) Authorize(ctx context.Context, cmd *AuthorizeCommand, idempotencyKey string) (*domain.Payment, error) {
-	requestHash := ComputeHash(cmd)
+	requestHash := s.requestHash(ctx, cmd)
 
 	cachedPayment, isCached, err := checkIdempotency(
 		ctx,
@@ -52,6 +149,7 @@ func (s *AuthorizeService) Authorize(ctx context.Context, cmd *AuthorizeCommand,
 		s.paymentRepo,
 		idempotencyKey,
 		requestHash,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -61,26 +159,138 @@ func (s *AuthorizeService) Authorize(ctx context.Context, cmd *AuthorizeCommand,
 		return cachedPayment, nil
 	}
 
+	payment, err := s.newAuthorization(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.begin(ctx, payment, cmd, idempotencyKey, requestHash)
+	if err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, nil)
+		}
+		if errors.Is(err, postgres.ErrOrderAlreadyPaid) {
+			if err := s.orderAlreadyPaid(ctx, idempotencyKey, requestHash, err); err != nil {
+				return nil, err
+			}
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, nil)
+		}
+		return nil, err
+	}
+
+	if payment.Status == domain.StatusReview {
+		return payment, nil
+	}
+	return s.CompleteAuthorize(ctx, payment, cmd, idempotencyKey)
+}
+
+// BeginAuthorize persists a PENDING payment and locks the idempotency key without
+// contacting the bank. The returned flag is false when the key was already used, in
+// which case the existing payment is returned as-is and nothing must be enqueued, and
+// when the payment was held for review.
+func (s *AuthorizeService) BeginAuthorize(ctx context.Context, cmd *AuthorizeCommand, idempotencyKey string) (*domain.Payment, bool, error) {
+	requestHash := s.requestHash(ctx, cmd)
+
+	existing, err := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	payment, err := s.newAuthorization(ctx, cmd)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = s.begin(ctx, payment, cmd, idempotencyKey, requestHash)
+	if err != nil {
+		if errors.Is(err, postgres.ErrOrderAlreadyPaid) {
+			if err := s.orderAlreadyPaid(ctx, idempotencyKey, requestHash, err); err != nil {
+				return nil, false, err
+			}
+		} else if !errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return nil, false, err
+		}
+		existing, err := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+		if err != nil {
+			return nil, false, err
+		}
+		return existing, false, nil
+	}
+
+	return payment, payment.Status != domain.StatusReview, nil
+}
+
+// newAuthorization checks a new authorization against the card, the merchant's limits,
+// earlier payments and the card's velocity, and builds its PENDING payment
+func (s *AuthorizeService) newAuthorization(ctx context.Context, cmd *AuthorizeCommand) (*domain.Payment, error) {
+	if err := checkCard(cmd); err != nil {
+		return nil, err
+	}
+	if err := s.checkNewPayment(ctx, cmd.Amount, cmd.Currency); err != nil {
+		return nil, err
+	}
+	if err := s.checkDuplicate(ctx, cmd); err != nil {
+		return nil, err
+	}
+	fingerprint := s.fingerprint(cmd)
+	if err := s.checkCardVelocity(ctx, cmd, fingerprint); err != nil {
+		return nil, err
+	}
+
 	paymentID := uuid.New().String()
 	payment, err := domain.NewPayment(paymentID, cmd.OrderID, cmd.CustomerID, cmd.Amount, cmd.Currency)
 	if err != nil {
 		return nil, application.NewInvalidInputError(err)
 	}
+	if fingerprint != "" {
+		payment.CardFingerprint = &fingerprint
+	}
+	if cmd.PaymentMethodID != "" {
+		payment.PaymentMethodID = &cmd.PaymentMethodID
+	}
+	if cmd.GroupID != "" {
+		payment.GroupID = &cmd.GroupID
+		payment.GroupPart = &cmd.GroupPart
+	}
+	return payment, nil
+}
 
-	err = acquireIdempotencyLock(
+// begin stores a new payment and locks its idempotency key, or holds the payment for
+// review with the key settled when reviews flags it
+func (s *AuthorizeService) begin(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string, requestHash RequestHash) error {
+	if s.reviews != nil {
+		if reason := s.reviews.flag(cmd); reason != "" {
+			return s.reviews.hold(ctx, payment, cmd, reason, idempotencyKey, requestHash)
+		}
+	}
+
+	return acquireIdempotencyLock(
 		ctx,
 		s.db,
 		s.paymentRepo,
 		s.idempotencyRepo,
+		s.settingsRepo,
 		payment,
 		idempotencyKey,
 		requestHash,
 	)
-	if err != nil {
-		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
-			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey)
-		}
-		return nil, application.NewInternalError(err)
+}
+
+// CompleteAuthorize sends the authorization to the bank for a payment created by
+// BeginAuthorize and records the outcome. A soft decline that is to be retried leaves
+// the payment PENDING, without an error.
+func (s *AuthorizeService) CompleteAuthorize(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string) (*domain.Payment, error) {
+	return s.complete(ctx, payment, cmd, idempotencyKey, false)
+}
+
+// complete sends the authorization, under a key of its own when it is the retry of a
+// soft decline, which is not retried again
+func (s *AuthorizeService) complete(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string, softDeclineRetry bool) (*domain.Payment, error) {
+	if cmd.PaymentMethodID != "" && domain.CardExpired(cmd.ExpiryMonth, cmd.ExpiryYear, time.Now()) {
+		return payment, s.declineExpiredCard(ctx, payment, cmd, idempotencyKey)
 	}
 
 	bankReq := bank.AuthorizationRequest{
@@ -90,26 +300,281 @@ func (s *AuthorizeService) Authorize(ctx context.Context, cmd *AuthorizeCommand,
 		ExpiryMonth: cmd.ExpiryMonth,
 		ExpiryYear:  cmd.ExpiryYear,
 	}
+	if !cmd.NetworkToken.IsZero() {
+		bankReq.NetworkToken = cmd.NetworkToken.Number
+		bankReq.Cryptogram = cmd.NetworkToken.Cryptogram
+		bankReq.ECI = cmd.NetworkToken.ECI
+		payment.RecordCard(cmd.NetworkToken.Number)
+	} else {
+		payment.RecordCard(cmd.CardNumber)
+	}
+
+	bankKey := idempotencyKey
+	if softDeclineRetry {
+		bankKey += "-soft-retry"
+	}
 
-	bankResp, err := s.bankClient.Authorize(ctx, bankReq, idempotencyKey)
+	// Authorizations are not recorded as operations, hence the nil operation repository
+	bankResp, err := s.bankClient.Authorize(ctx, bankReq, bankKey)
 	if err != nil {
+		if !softDeclineRetry && s.retrySoftDecline(ctx, payment, cmd, idempotencyKey, err) {
+			return payment, nil
+		}
 		return payment, HandleBankFailure(
 			ctx,
 			s.db,
 			s.paymentRepo,
 			s.idempotencyRepo,
+			nil,
 			payment,
 			idempotencyKey,
 			err,
 		)
 	}
 
+	if bankResp.Acquirer != "" {
+		payment.Acquirer = bankResp.Acquirer
+	}
 	if err := payment.Authorize(bankResp.AuthorizationID, bankResp.CreatedAt, bankResp.ExpiresAt); err != nil {
 		return nil, application.NewInvalidStateError(err)
 	}
-	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, payment, idempotencyKey, bankResp); err != nil {
+	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, nil, payment, idempotencyKey, bankResp); err != nil {
 		return payment, err
 	}
 
+	return s.autoCapture(ctx, payment, cmd, idempotencyKey), nil
+}
+
+// autoCapture captures an authorized payment in full when its merchant auto-captures
+// the order's category. The authorization stands whatever becomes of the capture: one
+// the bank did not answer is left CAPTURING for the retry worker, and the payment is
+// returned as it stands.
+func (s *AuthorizeService) autoCapture(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string) *domain.Payment {
+	if s.captures == nil || cmd.Category == "" {
+		return payment
+	}
+	settings, err := s.settingsRepo.Find(ctx)
+	if err != nil || !settings.CapturesOnAuthorize(cmd.Category) {
+		return payment
+	}
+
+	captured, err := s.captures.Capture(ctx, payment.ID, 0, idempotencyKey+"-auto-capture")
+	if err == nil {
+		return captured
+	}
+	if current, err := s.paymentRepo.FindByID(ctx, payment.ID); err == nil {
+		return current
+	}
+	return payment
+}
+
+// declineExpiredCard fails a payment charged to a saved card that has expired since it
+// was saved, as the bank would have declined it, without sending it a request that
+// cannot succeed. The failure reason has the customer asked to update the card.
+func (s *AuthorizeService) declineExpiredCard(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string) error {
+	reason := domain.FailureReasonCardExpired
+	payment.FailureReason = &reason
+	payment.RecordCard(cmd.CardNumber)
+
+	decline := &bank.BankError{
+		Code:       domain.FailureReasonCardExpired,
+		Message:    "Saved card has expired",
+		StatusCode: http.StatusPaymentRequired,
+	}
+	return AbandonOperation(ctx, s.db, s.paymentRepo, s.idempotencyRepo, nil, payment, idempotencyKey, decline)
+}
+
+// requestHash hashes only the fields that identify the payment and what pays for it,
+// with the card and network token by their fingerprints, under a salt of the
+// merchant's own derived from the fingerprint salt: the hash is stored, and must not
+// give away card data to whoever can read it, nor match the same request made to
+// another merchant. The gateway does not start without the fingerprint salt; a
+// service built without fingerprints hashes neither card nor token.
+func (s *AuthorizeService) requestHash(ctx context.Context, cmd *AuthorizeCommand) RequestHash {
+	fields := requestFields{
+		"order_id":          cmd.OrderID,
+		"customer_id":       cmd.CustomerID,
+		"amount":            cmd.Amount,
+		"currency":          cmd.Currency,
+		"card_fingerprint":  s.fingerprint(cmd),
+		"token_fingerprint": s.tokenFingerprint(cmd),
+		"expiry_month":      cmd.ExpiryMonth,
+		"expiry_year":       cmd.ExpiryYear,
+		"payment_method_id": cmd.PaymentMethodID,
+		"group_id":          cmd.GroupID,
+		"group_part":        cmd.GroupPart,
+		"category":          cmd.Category,
+	}
+
+	// The fields of version 2, to match keys stored before version 3
+	v2Fields := requestFields{
+		"order_id":         cmd.OrderID,
+		"customer_id":      cmd.CustomerID,
+		"amount":           cmd.Amount,
+		"currency":         cmd.Currency,
+		"card_fingerprint": s.fingerprint(cmd),
+	}
+	if cmd.Category != "" {
+		v2Fields["category"] = cmd.Category
+	}
+
+	merchantID := postgres.MerchantFromContext(ctx)
+	salt := []byte(merchantID)
+	if s.fingerprints != nil {
+		salt = s.fingerprints.DeriveKey("request-hash:" + merchantID)
+	}
+
+	// The unsalted hashes still cover the card number, to match keys stored before
+	return ComputeHash(cmd).
+		withSalted(requestHashV2, v2Fields, salt).
+		withSalted(requestHashV3, fields, salt)
+}
+
+// checkCard rejects a request that names both a card and a network token, or neither,
+// and a malformed token. A card number is left for the bank to check, as it always was.
+func checkCard(cmd *AuthorizeCommand) error {
+	if cmd.NetworkToken.IsZero() {
+		if cmd.CardNumber == "" {
+			return application.NewInvalidInputError(domain.ErrCardAndNetworkToken)
+		}
+		return nil
+	}
+
+	if cmd.CardNumber != "" || cmd.CVV != "" {
+		return application.NewInvalidInputError(domain.ErrCardAndNetworkToken)
+	}
+	if err := cmd.NetworkToken.Validate(); err != nil {
+		return application.NewInvalidInputError(err)
+	}
+	return nil
+}
+
+// checkNewPayment rejects an amount outside its currency's limits and a currency the
+// merchant does not accept. Scheduled payments and subscriptions are checked here too
+// when they are created.
+func (s *AuthorizeService) checkNewPayment(ctx context.Context, amount int64, currency string) error {
+	err := s.limits.Amounts.Check(domain.Money{Amount: amount, Currency: currency})
+	switch {
+	case errors.Is(err, domain.ErrAmountTooSmall):
+		return application.NewAmountTooSmallError(err)
+	case errors.Is(err, domain.ErrAmountTooLarge):
+		return application.NewAmountTooLargeError(err)
+	}
+
+	settings, err := s.settingsRepo.Find(ctx)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	if err := settings.CheckCurrency(currency); err != nil {
+		return application.NewInvalidInputError(err)
+	}
+	return nil
+}
+
+// checkDuplicate rejects a payment for the same order, customer and amount as one made
+// within the duplicate window, naming that payment. Two such requests racing each
+// other may both pass; the idempotency key is what guarantees a single charge.
+func (s *AuthorizeService) checkDuplicate(ctx context.Context, cmd *AuthorizeCommand) error {
+	if s.limits.DuplicateWindow <= 0 || cmd.GroupID != "" {
+		return nil
+	}
+
+	since := time.Now().Add(-s.limits.DuplicateWindow)
+	original, err := s.paymentRepo.FindDuplicate(ctx, cmd.OrderID, cmd.CustomerID, cmd.Amount, since)
+	if errors.Is(err, postgres.ErrPaymentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	return application.NewDuplicatePaymentError(original.ID)
+}
+
+// fingerprint returns the fingerprint of the card number cmd is paid with, or "" when
+// it is paid with a network token or fingerprints are off
+func (s *AuthorizeService) fingerprint(cmd *AuthorizeCommand) string {
+	if s.fingerprints == nil || cmd.CardNumber == "" {
+		return ""
+	}
+	return s.fingerprints.Fingerprint(cmd.CardNumber)
+}
+
+// tokenFingerprint returns the fingerprint of the network token cmd is paid with, or ""
+// when it is paid with a card number or fingerprints are off. The token's cryptogram
+// changes with every payment and is left out.
+func (s *AuthorizeService) tokenFingerprint(cmd *AuthorizeCommand) string {
+	if s.fingerprints == nil || cmd.NetworkToken.Number == "" {
+		return ""
+	}
+	return s.fingerprints.Fingerprint("token:" + cmd.NetworkToken.Number)
+}
+
+// checkCardVelocity rejects a payment with a card already used for CardPayments
+// payments within the card window, or for CardCustomers customers other than this
+// payment's. Like checkDuplicate, it may let racing requests through.
+func (s *AuthorizeService) checkCardVelocity(ctx context.Context, cmd *AuthorizeCommand, fingerprint string) error {
+	limits := s.limits
+	if fingerprint == "" || limits.CardWindow <= 0 || (limits.CardPayments <= 0 && limits.CardCustomers <= 0) {
+		return nil
+	}
+
+	payments, otherCustomers, err := s.paymentRepo.CountCardUse(ctx, fingerprint, cmd.CustomerID, time.Now().Add(-limits.CardWindow))
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	if limits.CardPayments > 0 && payments >= limits.CardPayments {
+		return application.NewCardVelocityExceededError(
+			fmt.Errorf("%w: at most %d payments per card within %s", domain.ErrCardVelocityExceeded, limits.CardPayments, limits.CardWindow))
+	}
+	if limits.CardCustomers > 0 && otherCustomers >= limits.CardCustomers {
+		return application.NewCardVelocityExceededError(
+			fmt.Errorf("%w: at most %d customers per card within %s", domain.ErrCardVelocityExceeded, limits.CardCustomers, limits.CardWindow))
+	}
+	return nil
+}
+
+// orderAlreadyPaid tells apart the two requests Create refuses a payment for while
+// orders are unique. The order's payment may have been created by a concurrent request
+// with the same key, which then holds that key and is waited for like any duplicate
+// key; otherwise the order was paid for by another request and err is returned as
+// ORDER_ALREADY_PAID.
+func (s *AuthorizeService) orderAlreadyPaid(ctx context.Context, idempotencyKey string, requestHash RequestHash, err error) error {
+	existing, findErr := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if findErr != nil {
+		return findErr
+	}
+	if existing == nil {
+		return application.NewOrderAlreadyPaidError(err)
+	}
+	return nil
+}
+
+// findByIdempotencyKey returns the payment bound to the key without waiting for an
+// in-flight request to finish, or the error the key's request failed with for good.
+func (s *AuthorizeService) findByIdempotencyKey(ctx context.Context, idempotencyKey string, requestHash RequestHash) (*domain.Payment, error) {
+	existingKey, err := s.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if existingKey == nil {
+		return nil, nil
+	}
+
+	if !requestHash.Matches(existingKey.RequestHash) {
+		return nil, application.NewIdempotencyMismatchError()
+	}
+
+	if existingKey.LockedAt == nil {
+		if err := replayFailure(existingKey); err != nil {
+			return nil, err
+		}
+	}
+
+	payment, err := s.paymentRepo.FindByID(ctx, existingKey.PaymentID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
 	return payment, nil
 }
@@ -2,7 +2,11 @@ package services_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,7 +16,9 @@ import (
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -52,8 +58,10 @@ func (suite *AuthorizeServiceTestSuite) SetupTest() {
 	suite.service = services.NewAuthorizeService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
 		suite.mockBank,
 		suite.testDB.DB,
+		services.AuthorizeLimits{},
 	)
 }
 
@@ -103,6 +111,47 @@ func (suite *AuthorizeServiceTestSuite) Test_Authorize_Success() {
 	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
 	require.NoError(t, err)
 	assert.Equal(t, domain.StatusAuthorized, savedPayment.Status)
+	require.NotNil(t, savedPayment.CardLast4)
+	assert.Equal(t, "1111", *savedPayment.CardLast4)
+	assert.Equal(t, domain.CardBrandVisa, *savedPayment.CardBrand)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_NetworkToken() {
+	ctx := context.Background()
+	t := suite.T()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	cmd.CardNumber = ""
+	cmd.CVV = ""
+	cmd.NetworkToken = domain.NetworkToken{
+		Number:     "4895370012003478",
+		Cryptogram: "AgAAAAAABk4DWZ4C28yUQAAAAAA=",
+		ECI:        "05",
+	}
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, bank.AuthorizationRequest{
+			Amount:       cmd.Amount,
+			NetworkToken: "4895370012003478",
+			Cryptogram:   "AgAAAAAABk4DWZ4C28yUQAAAAAA=",
+			ECI:          "05",
+			ExpiryMonth:  cmd.ExpiryMonth,
+			ExpiryYear:   cmd.ExpiryYear,
+		}, idempotencyKey).
+		Return(&bank.AuthorizationResponse{
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+
+	payment, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, payment.Status)
+	require.NotNil(t, payment.CardLast4)
+	assert.Equal(t, "3478", *payment.CardLast4)
 }
 
 // ============================================================================
@@ -172,6 +221,127 @@ func (suite *AuthorizeServiceTestSuite) Test_Authorize_DifferentRequestSameKey_R
 	assert.Contains(t, err.Error(), "reused with different")
 }
 
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_StoredHashLeavesOutCard() {
+	t := suite.T()
+	ctx := context.Background()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.AuthorizationResponse{
+			Amount:          cmd.Amount,
+			Currency:        cmd.Currency,
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+
+	first, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+
+	var stored string
+	require.NoError(t, suite.testDB.DB.Pool.QueryRow(ctx,
+		"SELECT request_hash FROM idempotency_keys WHERE key = $1", idempotencyKey).Scan(&stored))
+	assert.True(t, strings.HasPrefix(stored, "v3:"))
+	assert.NotEqual(t, services.ComputeHash(&cmd).String(), stored)
+
+	// A key stored before hashes were salted still replays
+	_, err = suite.testDB.DB.Pool.Exec(ctx,
+		"UPDATE idempotency_keys SET request_hash = $1 WHERE key = $2",
+		services.ComputeHash(&cmd).String(), idempotencyKey)
+	require.NoError(t, err)
+
+	second, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_SameKeyWithOtherFundingSource_ReturnsError() {
+	t := suite.T()
+	ctx := context.Background()
+	fingerprints, err := vault.NewFingerprinter(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	require.NoError(t, err)
+	service := services.NewAuthorizeService(
+		suite.paymentRepo,
+		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{},
+	).WithFingerprints(fingerprints)
+
+	tokenCommand := func() services.AuthorizeCommand {
+		cmd := testhelpers.DefaultAuthorizeCommand()
+		cmd.CardNumber = ""
+		cmd.CVV = ""
+		cmd.NetworkToken = domain.NetworkToken{
+			Number:     "4895370012003478",
+			Cryptogram: "AgAAAAAABk4DWZ4C28yUQAAAAAA=",
+			ECI:        "05",
+		}
+		return cmd
+	}
+
+	tests := []struct {
+		name   string
+		first  func() services.AuthorizeCommand
+		change func(*services.AuthorizeCommand)
+	}{
+		{"another card", testhelpers.DefaultAuthorizeCommand, func(c *services.AuthorizeCommand) {
+			c.CardNumber = "5555555555554444"
+		}},
+		{"another network token", tokenCommand, func(c *services.AuthorizeCommand) {
+			c.NetworkToken.Number = "4895370012003486"
+		}},
+		{"a card instead of a network token", tokenCommand, func(c *services.AuthorizeCommand) {
+			c.NetworkToken = domain.NetworkToken{}
+			c.CardNumber = "4895370012003478"
+		}},
+		{"another saved payment method", testhelpers.DefaultAuthorizeCommand, func(c *services.AuthorizeCommand) {
+			c.PaymentMethodID = uuid.New().String()
+		}},
+		{"another expiry", testhelpers.DefaultAuthorizeCommand, func(c *services.AuthorizeCommand) {
+			c.ExpiryYear++
+		}},
+		{"another group part", testhelpers.DefaultAuthorizeCommand, func(c *services.AuthorizeCommand) {
+			c.GroupID = uuid.New().String()
+			c.GroupPart = 2
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.first()
+			idempotencyKey := "idem-" + uuid.New().String()
+			suite.mockBank.EXPECT().
+				Authorize(mock.Anything, mock.Anything, idempotencyKey).
+				Return(&bank.AuthorizationResponse{
+					Status:          "AUTHORIZED",
+					AuthorizationID: "auth-" + uuid.New().String(),
+					CreatedAt:       time.Now(),
+					ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+				}, nil).
+				Once()
+
+			first, err := service.Authorize(ctx, &cmd, idempotencyKey)
+			require.NoError(t, err)
+
+			replayed := tt.first()
+			again, err := service.Authorize(ctx, &replayed, idempotencyKey)
+			require.NoError(t, err, "the same request replays")
+			assert.Equal(t, first.ID, again.ID)
+
+			changed := tt.first()
+			tt.change(&changed)
+			_, err = service.Authorize(ctx, &changed, idempotencyKey)
+			assert.Equal(t, application.ErrCodeIdempotencyMismatch, application.ToErrorCode(err))
+		})
+	}
+}
+
 // ============================================================================
 // FAILURE RECOVERY TESTS
 // ============================================================================
@@ -204,6 +374,115 @@ func (suite *AuthorizeServiceTestSuite) Test_Authorize_BankReturns500_PaymentSta
 	require.NoError(t, err)
 	assert.Equal(t, domain.StatusPending, savedPayment.Status)
 	assert.Nil(t, savedPayment.BankAuthID) // No bank ID yet
+	require.NotNil(t, savedPayment.LastErrorCategory)
+	assert.Equal(t, string(application.CategoryTransient), *savedPayment.LastErrorCategory)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_BankDecline_IsReplayedForSameKey() {
+	t := suite.T()
+	ctx := context.Background()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	bankErr := &bank.BankError{
+		Code:       "insufficient_funds",
+		Message:    "Insufficient funds",
+		StatusCode: 402,
+	}
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, bankErr).
+		Once()
+
+	_, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+	require.ErrorIs(t, err, bankErr)
+
+	failed, err := suite.paymentRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, failed.Status)
+	require.NotNil(t, failed.DeclineCategory)
+	assert.Equal(t, domain.DeclineTryOtherCard, *failed.DeclineCategory)
+
+	// The retry is answered from the key without calling the bank again
+	_, err = suite.service.Authorize(ctx, &cmd, idempotencyKey)
+	require.Error(t, err)
+	assert.Equal(t, "INSUFFICIENT_FUNDS", application.ToErrorCode(err))
+	assert.Equal(t, 402, application.ToHTTPStatus(err))
+	assert.Equal(t, bankErr.Error(), err.Error())
+	category, ok := application.DeclineCategoryOf(err)
+	assert.True(t, ok)
+	assert.Equal(t, domain.DeclineTryOtherCard, category)
+
+	_, _, err = suite.service.BeginAuthorize(ctx, &cmd, idempotencyKey)
+	assert.Equal(t, "INSUFFICIENT_FUNDS", application.ToErrorCode(err))
+}
+
+// softDeclineRetrier records the retries it is handed instead of waiting to send them
+type softDeclineRetrier struct {
+	payments []*domain.Payment
+}
+
+func (r *softDeclineRetrier) SubmitRetry(_ time.Duration, payment *domain.Payment, _ services.AuthorizeCommand, _ string) {
+	r.payments = append(r.payments, payment)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_SoftDecline_IsRetriedOnce() {
+	t := suite.T()
+	ctx := services.AllowSoftDeclineRetry(context.Background())
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+	retrier := &softDeclineRetrier{}
+	suite.service.WithSoftDeclineRetry(time.Minute, retrier)
+
+	bankErr := &bank.BankError{Code: "issuer_unavailable", Message: "Issuer unavailable", StatusCode: 402}
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, bankErr).
+		Once()
+
+	payment, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, payment.Status)
+	require.NotNil(t, payment.DeclineCategory)
+	assert.Equal(t, domain.DeclineRetryLater, *payment.DeclineCategory)
+	require.Len(t, retrier.payments, 1)
+
+	key, err := suite.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.NotNil(t, key.LockedAt, "the key stays locked until the retry is answered")
+
+	// The retry goes under a key of its own and is not retried again
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey+"-soft-retry").
+		Return(nil, bankErr).
+		Once()
+
+	_, err = suite.service.RetrySoftDecline(ctx, payment, &cmd, idempotencyKey)
+	require.ErrorIs(t, err, bankErr)
+	assert.Len(t, retrier.payments, 1)
+
+	failed, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, failed.Status)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_SoftDecline_FailsWithoutPermission() {
+	t := suite.T()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+	retrier := &softDeclineRetrier{}
+	suite.service.WithSoftDeclineRetry(time.Minute, retrier)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, &bank.BankError{Code: "issuer_unavailable", StatusCode: 402}).
+		Once()
+
+	// Callers that act on the outcome, such as payment groups, get the decline
+	_, err := suite.service.Authorize(context.Background(), &cmd, idempotencyKey)
+	require.Error(t, err)
+	assert.Empty(t, retrier.payments)
 }
 
 func (suite *AuthorizeServiceTestSuite) Test_Authorize_ContextCancelled_PaymentStaysPending() {
@@ -277,3 +556,418 @@ func (suite *AuthorizeServiceTestSuite) Test_Authorize_ConcurrentRequests_OnlyOn
 
 	assert.Equal(t, paymentIDs[0], paymentIDs[1])
 }
+
+// ============================================================================
+// ASYNC AUTHORIZATION TESTS
+// ============================================================================
+
+func (suite *AuthorizeServiceTestSuite) Test_BeginAuthorize_StoresPendingPaymentWithoutBankCall() {
+	ctx := context.Background()
+	t := suite.T()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	payment, started, err := suite.service.BeginAuthorize(ctx, &cmd, idempotencyKey)
+
+	require.NoError(t, err)
+	assert.True(t, started)
+	assert.Equal(t, domain.StatusPending, payment.Status)
+
+	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, savedPayment.Status)
+
+	// Retrying the same request while the bank call is pending must not block
+	again, started, err := suite.service.BeginAuthorize(ctx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.False(t, started)
+	assert.Equal(t, payment.ID, again.ID)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_CompleteAuthorize_AuthorizesPendingPayment() {
+	ctx := context.Background()
+	t := suite.T()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	payment, started, err := suite.service.BeginAuthorize(ctx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	require.True(t, started)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.AuthorizationResponse{
+			Amount:          cmd.Amount,
+			Currency:        cmd.Currency,
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+
+	payment, err = suite.service.CompleteAuthorize(ctx, payment, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, payment.Status)
+
+	// The sync path replays the async result
+	replayed, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, replayed.ID)
+	assert.Equal(t, domain.StatusAuthorized, replayed.Status)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_MerchantsAreIsolated() {
+	t := suite.T()
+	_, err := suite.testDB.DB.Pool.Exec(context.Background(), "INSERT INTO merchants (id, name) VALUES ('acme', 'Acme')")
+	require.NoError(t, err)
+
+	acmeCtx := postgres.WithMerchant(context.Background(), "acme")
+	defaultCtx := context.Background()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.AuthorizationResponse{
+			Amount:          cmd.Amount,
+			Currency:        cmd.Currency,
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Twice()
+
+	acmePayment, err := suite.service.Authorize(acmeCtx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", acmePayment.MerchantID)
+
+	_, err = suite.paymentRepo.FindByID(defaultCtx, acmePayment.ID)
+	assert.ErrorIs(t, err, postgres.ErrPaymentNotFound)
+
+	// The same key from another merchant is a new request, not a replay
+	defaultPayment, err := suite.service.Authorize(defaultCtx, &cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.NotEqual(t, acmePayment.ID, defaultPayment.ID)
+	assert.Equal(t, domain.DefaultMerchantID, defaultPayment.MerchantID)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_RowLevelSecurity_HidesOtherMerchantsRows() {
+	ctx := context.Background()
+	t := suite.T()
+	_, err := suite.testDB.DB.Pool.Exec(ctx, "INSERT INTO merchants (id, name) VALUES ('acme', 'Acme')")
+	require.NoError(t, err)
+
+	testhelpers.CreateAuthorizedPayment(t, ctx, suite.service, suite.mockBank)
+	testhelpers.CreateAuthorizedPayment(t, postgres.WithMerchant(ctx, "acme"), suite.service, suite.mockBank)
+
+	// The test user is a superuser, which skips the policies, so the queries run as an
+	// ordinary role
+	_, err = suite.testDB.DB.Pool.Exec(ctx, `
+		DO $$ BEGIN CREATE ROLE rls_reader; EXCEPTION WHEN duplicate_object THEN NULL; END $$;
+		GRANT SELECT ON payments, idempotency_keys TO rls_reader;
+	`)
+	require.NoError(t, err)
+
+	count := func(ctx context.Context, table string) int {
+		tx, err := suite.testDB.DB.Begin(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback(ctx) //nolint:errcheck // read-only
+
+		_, err = tx.Exec(ctx, "SET LOCAL ROLE rls_reader")
+		require.NoError(t, err)
+
+		var n int
+		require.NoError(t, tx.QueryRow(ctx, "SELECT COUNT(*) FROM "+table).Scan(&n))
+		return n
+	}
+
+	assert.Equal(t, 1, count(ctx, "payments"), "unfiltered query sees only the default merchant")
+	assert.Equal(t, 1, count(ctx, "idempotency_keys"))
+	assert.Equal(t, 1, count(postgres.WithMerchant(ctx, "acme"), "payments"))
+	assert.Equal(t, 2, count(postgres.AcrossMerchants(ctx), "payments"))
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_RejectsCurrencyMerchantDoesNotAccept() {
+	ctx := context.Background()
+	t := suite.T()
+	_, err := suite.testDB.DB.Pool.Exec(ctx, "INSERT INTO merchant_settings (merchant_id, allowed_currencies) VALUES ('default', '{EUR}')")
+	require.NoError(t, err)
+
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	payment, err := suite.service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+
+	require.Error(t, err)
+	assert.Nil(t, payment)
+	assert.Equal(t, "INVALID_INPUT", application.ToErrorCode(err))
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_CapturesCategoryMerchantAutoCaptures() {
+	ctx := context.Background()
+	t := suite.T()
+	_, err := suite.testDB.DB.Pool.Exec(ctx, "INSERT INTO merchant_settings (merchant_id, auto_capture_categories) VALUES ('default', '{digital}')")
+	require.NoError(t, err)
+
+	suite.service.WithAutoCapture(services.NewCaptureService(
+		suite.paymentRepo,
+		suite.idempotencyRepo,
+		postgres.NewOperationRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+	))
+
+	authorize := func(category string) *domain.Payment {
+		cmd := testhelpers.DefaultAuthorizeCommand()
+		cmd.OrderID = "order-" + uuid.New().String()
+		cmd.Category = category
+		idempotencyKey := "idem-" + uuid.New().String()
+
+		suite.mockBank.EXPECT().Authorize(mock.Anything, mock.Anything, idempotencyKey).Return(&bank.AuthorizationResponse{
+			Amount:          cmd.Amount,
+			Currency:        cmd.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-" + idempotencyKey,
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).Once()
+		if category == "digital" {
+			suite.mockBank.EXPECT().Capture(mock.Anything, mock.Anything, idempotencyKey+"-auto-capture").Return(&bank.CaptureResponse{
+				Amount:          cmd.Amount,
+				Currency:        cmd.Currency,
+				AuthorizationID: "auth-" + idempotencyKey,
+				Status:          "captured",
+				CaptureID:       "cap-" + idempotencyKey,
+				CapturedAt:      time.Now(),
+			}, nil).Once()
+		}
+
+		payment, err := suite.service.Authorize(ctx, &cmd, idempotencyKey)
+		require.NoError(t, err)
+		return payment
+	}
+
+	sale := authorize("digital")
+	assert.Equal(t, domain.StatusCaptured, sale.Status)
+	assert.Equal(t, sale.AmountCents, sale.CapturedAmountCents)
+
+	assert.Equal(t, domain.StatusAuthorized, authorize("apparel").Status)
+	assert.Equal(t, domain.StatusAuthorized, authorize("").Status)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_RejectsCardWithNetworkToken() {
+	ctx := context.Background()
+	t := suite.T()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	cmd.NetworkToken = domain.NetworkToken{
+		Number:     "4895370012003478",
+		Cryptogram: "AgAAAAAABk4DWZ4C28yUQAAAAAA=",
+	}
+
+	payment, err := suite.service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+
+	require.Error(t, err)
+	assert.Nil(t, payment)
+	assert.Equal(t, "INVALID_INPUT", application.ToErrorCode(err))
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_QuotaExceeded() {
+	ctx := context.Background()
+	t := suite.T()
+	_, err := suite.testDB.DB.Pool.Exec(ctx, "INSERT INTO merchant_settings (merchant_id, daily_transaction_limit) VALUES ('default', 1)")
+	require.NoError(t, err)
+
+	first := testhelpers.CreateAuthorizedPayment(t, ctx, suite.service, suite.mockBank)
+
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	payment, err := suite.service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	require.Error(t, err)
+	assert.Nil(t, payment)
+	assert.Equal(t, "QUOTA_EXCEEDED", application.ToErrorCode(err))
+
+	quota, err := postgres.NewMerchantSettingsRepository(suite.testDB.DB).FindQuota(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, quota.UsedTransactions, "a rejected payment is not counted")
+	assert.Equal(t, first.AmountCents, quota.UsedVolumeCents)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_RejectsAmountOutsideCurrencyLimits() {
+	ctx := context.Background()
+	t := suite.T()
+	service := services.NewAuthorizeService(
+		suite.paymentRepo,
+		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{Amounts: domain.AmountLimits{"USD": {Min: 50, Max: 10000}}},
+	)
+
+	small := testhelpers.DefaultAuthorizeCommand()
+	small.Amount = 49
+	_, err := service.Authorize(ctx, &small, "idem-"+uuid.New().String())
+	assert.Equal(t, "AMOUNT_TOO_SMALL", application.ToErrorCode(err))
+	assert.ErrorContains(t, err, "0.50 USD")
+
+	large := testhelpers.DefaultAuthorizeCommand()
+	large.Amount = 10001
+	_, _, err = service.BeginAuthorize(ctx, &large, "idem-"+uuid.New().String())
+	assert.Equal(t, "AMOUNT_TOO_LARGE", application.ToErrorCode(err))
+
+	var count int
+	require.NoError(t, suite.testDB.DB.Pool.QueryRow(ctx, "SELECT COUNT(*) FROM payments").Scan(&count))
+	assert.Zero(t, count, "rejected before the payment is stored")
+	suite.mockBank.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_RejectsDuplicateWithinWindow() {
+	ctx := context.Background()
+	t := suite.T()
+	service := services.NewAuthorizeService(
+		suite.paymentRepo,
+		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{DuplicateWindow: 24 * time.Hour},
+	)
+
+	first := testhelpers.CreateAuthorizedPayment(t, ctx, service, suite.mockBank)
+
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	cmd.OrderID, cmd.CustomerID, cmd.Amount = first.OrderID, first.CustomerID, first.AmountCents
+	_, err := service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeDuplicatePayment, svcErr.Code)
+	assert.Equal(t, first.ID, svcErr.PaymentID)
+
+	cmd.Amount++
+	suite.mockBank.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything).Return(&bank.AuthorizationResponse{
+		Status: "AUTHORIZED", AuthorizationID: "auth-2", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	}, nil).Once()
+	_, err = service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.NoError(t, err, "another amount for the same order is not a duplicate")
+
+	_, err = suite.testDB.DB.Pool.Exec(ctx, "UPDATE payments SET created_at = NOW() - INTERVAL '25 hours'")
+	require.NoError(t, err)
+	_, _, err = service.BeginAuthorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.NoError(t, err, "allowed again once the window has passed")
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_CardVelocity() {
+	ctx := context.Background()
+	t := suite.T()
+	fingerprints, err := vault.NewFingerprinter(base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+	require.NoError(t, err)
+	service := services.NewAuthorizeService(
+		suite.paymentRepo,
+		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{CardWindow: time.Hour, CardPayments: 3, CardCustomers: 2},
+	).WithFingerprints(fingerprints)
+
+	first := testhelpers.CreateAuthorizedPayment(t, ctx, service, suite.mockBank)
+	require.NotNil(t, first.CardFingerprint)
+	assert.Equal(t, fingerprints.Fingerprint("4111111111111111"), *first.CardFingerprint)
+
+	suite.mockBank.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything).Return(&bank.AuthorizationResponse{
+		Status: "AUTHORIZED", AuthorizationID: "auth-2", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	}, nil).Once()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	cmd.CustomerID = "cust-" + uuid.New().String()
+	_, err = service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	require.NoError(t, err, "a second customer is within the limit")
+
+	cmd = testhelpers.DefaultAuthorizeCommand()
+	cmd.CustomerID = "cust-" + uuid.New().String()
+	_, err = service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.Equal(t, "CARD_VELOCITY_EXCEEDED", application.ToErrorCode(err), "a third customer is not")
+
+	suite.mockBank.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything).Return(&bank.AuthorizationResponse{
+		Status: "AUTHORIZED", AuthorizationID: "auth-3", CreatedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour),
+	}, nil).Once()
+	cmd = testhelpers.DefaultAuthorizeCommand()
+	cmd.CustomerID = first.CustomerID
+	_, err = service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	require.NoError(t, err, "the first customer may use the card again")
+
+	cmd = testhelpers.DefaultAuthorizeCommand()
+	cmd.CustomerID = first.CustomerID
+	_, err = service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.Equal(t, "CARD_VELOCITY_EXCEEDED", application.ToErrorCode(err), "but not for a fourth payment")
+
+	cmd = testhelpers.DefaultAuthorizeCommand()
+	cmd.CardNumber = "5555555555554444"
+	_, _, err = service.BeginAuthorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.NoError(t, err, "another card is counted apart")
+}
+
+func (suite *AuthorizeServiceTestSuite) Test_Authorize_UniqueOrders() {
+	ctx := context.Background()
+	t := suite.T()
+	service := services.NewAuthorizeService(
+		postgres.NewPaymentRepository(suite.testDB.DB).WithUniqueOrders(),
+		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	first := testhelpers.CreateAuthorizedPayment(t, ctx, service, suite.mockBank)
+
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	cmd.OrderID = first.OrderID
+	_, err := service.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.Equal(t, application.ErrCodeOrderAlreadyPaid, application.ToErrorCode(err))
+
+	_, _, err = service.BeginAuthorize(ctx, &cmd, "idem-"+uuid.New().String())
+	assert.Equal(t, application.ErrCodeOrderAlreadyPaid, application.ToErrorCode(err))
+
+	_, err = suite.testDB.DB.Pool.Exec(ctx, "UPDATE payments SET status = 'FAILED' WHERE id = $1", first.ID)
+	require.NoError(t, err)
+	payment, started, err := service.BeginAuthorize(ctx, &cmd, "idem-"+uuid.New().String())
+	require.NoError(t, err, "an order whose payment failed may be paid again")
+	assert.True(t, started)
+	assert.Equal(t, first.OrderID, payment.OrderID)
+}
+
+func TestComputeHash_IgnoresCVV(t *testing.T) {
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	other := cmd
+	other.CVV = "999"
+
+	assert.Equal(t, services.ComputeHash(&cmd), services.ComputeHash(&other))
+	assert.Equal(t, "123", cmd.CVV, "the command keeps its CVV for the bank")
+
+	other.Amount++
+	assert.NotEqual(t, services.ComputeHash(&cmd), services.ComputeHash(&other))
+}
+
+func TestComputeHash_MatchesHashesFromBeforeVersioning(t *testing.T) {
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	hash := services.ComputeHash(&cmd)
+	assert.True(t, strings.HasPrefix(hash.String(), "v1:"))
+	assert.True(t, hash.Matches(hash.String()))
+
+	// What keys stored before versioning hold
+	legacy := fmt.Sprintf("%x", sha256.Sum256([]byte(fmt.Sprintf("%+v", pci.WithoutCVV(&cmd)))))
+	assert.True(t, hash.Matches(legacy))
+
+	other := cmd
+	other.Amount++
+	assert.False(t, services.ComputeHash(&other).Matches(legacy))
+	assert.False(t, services.ComputeHash(&other).Matches(hash.String()))
+}
+
+func TestComputeHash_IgnoresTimeZone(t *testing.T) {
+	at := time.Date(2030, 1, 2, 15, 4, 5, 0, time.UTC)
+	cmd := services.ScheduleCommand{OrderID: "order-1", Amount: 5000, Currency: "USD", ScheduledFor: at}
+	local := cmd
+	local.ScheduledFor = at.In(time.FixedZone("WAT", 3600))
+
+	assert.Equal(t, services.ComputeHash(&cmd).String(), services.ComputeHash(&local).String())
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// BankRefundResult is what became of one refund the bank reported. Operation is the
+// refund recorded for it, and Issue the issue raised when it could not be.
+type BankRefundResult struct {
+	Refund    domain.BankRefund
+	Outcome   domain.BankRefundOutcome
+	Operation *domain.Operation
+	Issue     *domain.ReconciliationIssue
+}
+
+// BankRefundService records refunds the bank made without the gateway asking, such as
+// reversals forced by the issuer, so the payments match the bank's books. Each refund
+// is recorded as a SUCCEEDED refund of the payment holding its authorization, taking
+// the payment through REFUNDING as a refund the gateway made would, so hooks and
+// customer notifications see it too.
+type BankRefundService struct {
+	paymentRepo   *postgres.PaymentRepository
+	operationRepo *postgres.OperationRepository
+	issueRepo     *postgres.ReconciliationRepository
+	db            *postgres.DB
+}
+
+func NewBankRefundService(
+	paymentRepo *postgres.PaymentRepository,
+	operationRepo *postgres.OperationRepository,
+	issueRepo *postgres.ReconciliationRepository,
+	db *postgres.DB,
+) *BankRefundService {
+	return &BankRefundService{
+		paymentRepo:   paymentRepo,
+		operationRepo: operationRepo,
+		issueRepo:     issueRepo,
+		db:            db,
+	}
+}
+
+// Record records each refund against the payments of the merchant in ctx, in order. A
+// refund recorded before, whether it came from the webhook or the statement, is left
+// as it is, so reports can be sent again safely. One the gateway cannot record is
+// raised as a reconciliation issue rather than failing the others.
+func (s *BankRefundService) Record(ctx context.Context, refunds []domain.BankRefund) ([]BankRefundResult, error) {
+	if len(refunds) == 0 || len(refunds) > domain.MaxBankRefunds {
+		return nil, application.NewInvalidInputError(domain.ErrInvalidBankRefunds)
+	}
+	for _, refund := range refunds {
+		if err := refund.Validate(); err != nil {
+			return nil, application.NewInvalidInputError(err)
+		}
+	}
+	ctx = postgres.WithActor(ctx, domain.ActorBank)
+
+	results := make([]BankRefundResult, 0, len(refunds))
+	for _, refund := range refunds {
+		result, err := s.record(ctx, refund)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (s *BankRefundService) record(ctx context.Context, refund domain.BankRefund) (BankRefundResult, error) {
+	result := BankRefundResult{Refund: refund}
+	var payment *domain.Payment
+	err := runInTx(ctx, s.db, func(tx pgx.Tx) error {
+		var err error
+		payment, err = s.paymentRepo.FindByBankAuthIDForUpdate(ctx, tx, refund.BankAuthID)
+		if errors.Is(err, postgres.ErrPaymentNotFound) {
+			payment = nil
+			return nil
+		}
+		if err != nil {
+			return application.NewInternalError(err)
+		}
+
+		// Read once the payment is locked, so a report racing another sees its refund
+		existing, err := s.operationRepo.FindByIdempotencyKey(ctx, refund.IdempotencyKey())
+		switch {
+		case err == nil:
+			result.Outcome, result.Operation = domain.BankRefundAlreadyRecorded, existing
+			return nil
+		case !errors.Is(err, postgres.ErrOperationNotFound):
+			return application.NewInternalError(err)
+		}
+
+		if payment.MarkRefunding(refund.AmountCents) != nil {
+			return nil
+		}
+		if err := s.paymentRepo.Update(ctx, tx, payment); err != nil {
+			return application.NewInternalError(err)
+		}
+		if err := payment.Refund(refund.BankRefundID, refund.AmountCents, refund.RefundedAt); err != nil {
+			return application.NewInvalidStateError(err)
+		}
+		if err := s.paymentRepo.Update(ctx, tx, payment); err != nil {
+			return application.NewInternalError(err)
+		}
+
+		op, err := domain.NewBankRefund(uuid.New().String(), payment.ID, refund)
+		if err != nil {
+			return application.NewInvalidInputError(err)
+		}
+		if err := s.operationRepo.Create(ctx, tx, op); err != nil {
+			return application.NewInternalError(err)
+		}
+		result.Outcome, result.Operation = domain.BankRefundRecorded, op
+		return nil
+	})
+	if err != nil || result.Outcome != "" {
+		return result, err
+	}
+
+	issue := &domain.ReconciliationIssue{
+		ID:             uuid.New().String(),
+		Kind:           domain.IssueUnrecordedRefund,
+		BankAuthID:     refund.BankAuthID,
+		BankStatus:     string(domain.StatusRefunded),
+		LastDetectedAt: time.Now(),
+	}
+	if payment != nil {
+		issue.PaymentID = payment.ID
+		issue.GatewayStatus = payment.Status
+	}
+	if err := s.issueRepo.Record(ctx, issue); err != nil {
+		return result, application.NewInternalError(err)
+	}
+	result.Outcome, result.Issue = domain.BankRefundUnrecorded, issue
+	return result, nil
+}
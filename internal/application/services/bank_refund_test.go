@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type bankRefundServiceTestSuite struct {
+	suite.Suite
+	testDB           *testhelpers.TestDatabase
+	paymentRepo      *postgres.PaymentRepository
+	operationRepo    *postgres.OperationRepository
+	mockBank         *mocks.MockBankClient
+	authorizeService *services.AuthorizeService
+	captureService   *services.CaptureService
+	service          *services.BankRefundService
+}
+
+func TestBankRefundServiceSuite(t *testing.T) {
+	suite.Run(t, new(bankRefundServiceTestSuite))
+}
+
+func (suite *bankRefundServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+	suite.operationRepo = postgres.NewOperationRepository(suite.testDB.DB)
+}
+
+func (suite *bankRefundServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *bankRefundServiceTestSuite) SetupTest() {
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+
+	suite.authorizeService = services.NewAuthorizeService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{},
+	)
+	suite.captureService = services.NewCaptureService(suite.paymentRepo, idempotencyRepo, suite.operationRepo, suite.mockBank, suite.testDB.DB)
+	suite.service = services.NewBankRefundService(
+		suite.paymentRepo,
+		suite.operationRepo,
+		postgres.NewReconciliationRepository(suite.testDB.DB),
+		suite.testDB.DB,
+	)
+}
+
+func (suite *bankRefundServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *bankRefundServiceTestSuite) Test_Record_RefundsThePaymentOnce() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	refund := domain.BankRefund{
+		BankRefundID: "rev-1",
+		BankAuthID:   *payment.BankAuthID,
+		AmountCents:  1000,
+		RefundedAt:   time.Now().Add(-time.Minute),
+	}
+
+	results, err := suite.service.Record(ctx, []domain.BankRefund{refund})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, domain.BankRefundRecorded, results[0].Outcome)
+	require.NotNil(t, results[0].Operation)
+	assert.True(t, results[0].Operation.BankInitiated)
+
+	saved, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, saved.Status)
+	assert.Equal(t, int64(1000), saved.RefundedAmountCents)
+	assert.Equal(t, "rev-1", *saved.BankRefundID)
+
+	stored, err := suite.operationRepo.FindRefundByID(ctx, results[0].Operation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationSucceeded, stored.Status)
+	assert.True(t, stored.BankInitiated)
+
+	// The statement reports the refund the webhook already did
+	results, err = suite.service.Record(ctx, []domain.BankRefund{refund})
+	require.NoError(t, err)
+	assert.Equal(t, domain.BankRefundAlreadyRecorded, results[0].Outcome)
+	assert.Equal(t, stored.ID, results[0].Operation.ID)
+
+	saved, err = suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), saved.RefundedAmountCents)
+}
+
+func (suite *bankRefundServiceTestSuite) Test_Record_RaisesAnIssueForWhatItCannotRecord() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	refunds := []domain.BankRefund{
+		{BankRefundID: "rev-1", BankAuthID: "auth-unknown", AmountCents: 500, RefundedAt: time.Now()},
+		{BankRefundID: "rev-2", BankAuthID: *payment.BankAuthID, AmountCents: payment.CapturedAmountCents + 1, RefundedAt: time.Now()},
+	}
+
+	results, err := suite.service.Record(ctx, refunds)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, domain.BankRefundUnrecorded, results[0].Outcome)
+	require.NotNil(t, results[0].Issue)
+	assert.Equal(t, domain.IssueUnrecordedRefund, results[0].Issue.Kind)
+	assert.Empty(t, results[0].Issue.PaymentID)
+
+	assert.Equal(t, domain.BankRefundUnrecorded, results[1].Outcome)
+	require.NotNil(t, results[1].Issue)
+	assert.Equal(t, payment.ID, results[1].Issue.PaymentID)
+	assert.Equal(t, domain.StatusCaptured, results[1].Issue.GatewayStatus)
+
+	saved, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Zero(t, saved.RefundedAmountCents)
+}
+
+func (suite *bankRefundServiceTestSuite) Test_Record_RejectsAnIncompleteRefund() {
+	t := suite.T()
+
+	_, err := suite.service.Record(context.Background(), []domain.BankRefund{{BankRefundID: "rev-1", AmountCents: 500}})
+
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+}
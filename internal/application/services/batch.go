@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type RefundBatchItem struct {
+	PaymentID string
+	Amount    int64
+}
+
+type CreateRefundBatchCommand struct {
+	Reason domain.OperationReason
+	Items  []RefundBatchItem
+}
+
+func (c CreateRefundBatchCommand) hashFields() map[string]any {
+	items := make([]map[string]any, len(c.Items))
+	for i, item := range c.Items {
+		items[i] = map[string]any{"payment_id": item.PaymentID, "amount": item.Amount}
+	}
+	return map[string]any{"reason": string(c.Reason), "items": items}
+}
+
+// CreateVoidBatchCommand selects the AUTHORIZED payments to void. Every criterion
+// given must match; at least one is required.
+type CreateVoidBatchCommand struct {
+	Reason           domain.OperationReason
+	OrderIDs         []string
+	CustomerID       string
+	AuthorizedBefore time.Time
+}
+
+func (c CreateVoidBatchCommand) hashFields() map[string]any {
+	return map[string]any{
+		"reason":            string(c.Reason),
+		"order_ids":         c.OrderIDs,
+		"customer_id":       c.CustomerID,
+		"authorized_before": hashTime(c.AuthorizedBefore),
+	}
+}
+
+// BatchService accepts bulk operations over many payments and runs them item by item
+// in the background. Each item goes through the same service as a single request,
+// under an idempotency key derived from the item, so an item interrupted by a crash
+// is resumed rather than repeated.
+type BatchService struct {
+	batchRepo     *postgres.BatchRepository
+	paymentRepo   *postgres.PaymentRepository
+	operationRepo *postgres.OperationRepository
+	refundService *RefundService
+	voidService   *VoidService
+	db            *postgres.DB
+}
+
+func NewBatchService(
+	batchRepo *postgres.BatchRepository,
+	paymentRepo *postgres.PaymentRepository,
+	operationRepo *postgres.OperationRepository,
+	refundService *RefundService,
+	voidService *VoidService,
+	db *postgres.DB,
+) *BatchService {
+	return &BatchService{
+		batchRepo:     batchRepo,
+		paymentRepo:   paymentRepo,
+		operationRepo: operationRepo,
+		refundService: refundService,
+		voidService:   voidService,
+		db:            db,
+	}
+}
+
+// CreateRefundBatch stores a batch of refunds for ProcessPending to run. Nothing is
+// sent to the bank before it returns.
+func (s *BatchService) CreateRefundBatch(ctx context.Context, cmd *CreateRefundBatchCommand, idempotencyKey string) (*domain.Batch, error) {
+	requestHash := ComputeHash(cmd)
+
+	existing, err := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	items := make([]*domain.BatchItem, 0, len(cmd.Items))
+	for _, item := range cmd.Items {
+		items = append(items, &domain.BatchItem{
+			ID:          uuid.New().String(),
+			PaymentID:   item.PaymentID,
+			AmountCents: item.Amount,
+		})
+	}
+
+	batch, err := domain.NewRefundBatch(uuid.New().String(), cmd.Reason, items)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	return s.create(ctx, batch, idempotencyKey, requestHash)
+}
+
+// CreateVoidBatch stores a batch voiding the AUTHORIZED payments that match cmd, for
+// cleaning up abandoned orders. At most domain.MaxBatchItems payments are taken, oldest
+// authorization first; the rest can be voided by submitting the request again under a
+// new key.
+func (s *BatchService) CreateVoidBatch(ctx context.Context, cmd *CreateVoidBatchCommand, idempotencyKey string) (*domain.Batch, error) {
+	requestHash := ComputeHash(cmd)
+
+	existing, err := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	if len(cmd.OrderIDs) == 0 && cmd.CustomerID == "" && cmd.AuthorizedBefore.IsZero() {
+		return nil, application.NewInvalidInputError(domain.ErrInvalidBatch)
+	}
+
+	paymentIDs, err := s.paymentRepo.FindAuthorizedIDs(
+		ctx,
+		cmd.OrderIDs,
+		cmd.CustomerID,
+		cmd.AuthorizedBefore,
+		domain.MaxBatchItems,
+	)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	items := make([]*domain.BatchItem, 0, len(paymentIDs))
+	for _, paymentID := range paymentIDs {
+		items = append(items, &domain.BatchItem{
+			ID:        uuid.New().String(),
+			PaymentID: paymentID,
+		})
+	}
+
+	batch, err := domain.NewVoidBatch(uuid.New().String(), cmd.Reason, items)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	return s.create(ctx, batch, idempotencyKey, requestHash)
+}
+
+// create stores a new batch. A concurrent request that stored one under the same key
+// first wins, and its batch is returned.
+func (s *BatchService) create(ctx context.Context, batch *domain.Batch, idempotencyKey string, requestHash RequestHash) (*domain.Batch, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := s.batchRepo.Create(ctx, tx, batch, idempotencyKey, requestHash.String()); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return batch, nil
+}
+
+func (s *BatchService) Get(ctx context.Context, id string) (*domain.Batch, error) {
+	batch, err := s.batchRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrBatchNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return batch, nil
+}
+
+// ProcessPending runs up to limit pending items and completes the batches that have
+// none left. It returns how many items were settled; items that hit a retryable error
+// stay PENDING for the next run.
+func (s *BatchService) ProcessPending(ctx context.Context, limit int) (int, error) {
+	pending, err := s.batchRepo.FindPendingItems(ctx, limit)
+	if err != nil {
+		return 0, application.NewInternalError(err)
+	}
+
+	var settled int
+	var errs []error
+	for _, p := range pending {
+		ok, err := s.process(ctx, p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("batch item %s: %w", p.Item.ID, err))
+		}
+		if ok {
+			settled++
+		}
+	}
+
+	if _, err := s.batchRepo.CompleteFinished(ctx); err != nil {
+		errs = append(errs, err)
+	}
+
+	return settled, errors.Join(errs...)
+}
+
+// process runs one item and records its outcome from the operation it created, since
+// a repeated request returns the payment without saying whether its operation failed.
+// It reports whether the item was settled.
+func (s *BatchService) process(ctx context.Context, p postgres.PendingBatchItem) (bool, error) {
+	// The merchant asked for what the batch does, if not when
+	ctx = postgres.WithActor(postgres.WithMerchant(ctx, p.MerchantID), domain.ActorAPI)
+	item := p.Item
+	key := "batch-" + item.ID
+
+	// Batches are not checked against existing payments when accepted, so an unknown
+	// payment is failed here rather than retried
+	_, err := s.paymentRepo.FindByID(ctx, item.PaymentID)
+	if errors.Is(err, postgres.ErrPaymentNotFound) {
+		if err := item.Fail("", application.ToErrorCode(err), time.Now()); err != nil {
+			return false, err
+		}
+		return true, s.batchRepo.UpdateItem(ctx, item)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	switch p.Type {
+	case domain.BatchRefund:
+		_, err = s.refundService.Refund(ctx, item.PaymentID, item.AmountCents, p.Reason, key)
+	case domain.BatchVoid:
+		_, err = s.voidService.Void(ctx, item.PaymentID, p.Reason, 0, key)
+	default:
+		return false, fmt.Errorf("unknown batch type %q", p.Type)
+	}
+	if err != nil && application.IsRetryable(err) {
+		return false, err
+	}
+
+	operation, opErr := s.operationRepo.FindByIdempotencyKey(ctx, key)
+	if opErr != nil && !errors.Is(opErr, postgres.ErrOperationNotFound) {
+		return false, opErr
+	}
+
+	now := time.Now()
+	switch {
+	case operation == nil:
+		// Rejected before reaching the payment, e.g. an amount larger than what is
+		// left to refund
+		err = item.Fail("", application.ToErrorCode(err), now)
+	case operation.Status == domain.OperationPending, operation.Status == domain.OperationPendingApproval:
+		// A refund held for approval is settled once it is approved or rejected
+		return false, err
+	case operation.Status == domain.OperationSucceeded:
+		err = item.Succeed(operation.ID, now)
+	default:
+		code := "OPERATION_FAILED"
+		if err != nil {
+			code = application.ToErrorCode(err)
+		}
+		err = item.Fail(operation.ID, code, now)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.batchRepo.UpdateItem(ctx, item); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// findByIdempotencyKey returns the batch created under the key, or nil if the key is
+// unused
+func (s *BatchService) findByIdempotencyKey(ctx context.Context, idempotencyKey string, requestHash RequestHash) (*domain.Batch, error) {
+	id, existingHash, err := s.batchRepo.FindIDByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, postgres.ErrBatchNotFound) {
+			return nil, nil
+		}
+		return nil, application.NewInternalError(err)
+	}
+	if !requestHash.Matches(existingHash) {
+		return nil, application.NewIdempotencyMismatchError()
+	}
+
+	return s.Get(ctx, id)
+}
@@ -0,0 +1,211 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type BatchServiceTestSuite struct {
+	suite.Suite
+	testDB         *testhelpers.TestDatabase
+	paymentRepo    *postgres.PaymentRepository
+	mockBank       *mocks.MockBankClient
+	authService    *services.AuthorizeService
+	captureService *services.CaptureService
+	service        *services.BatchService
+}
+
+func TestBatchServiceSuite(t *testing.T) {
+	suite.Run(t, new(BatchServiceTestSuite))
+}
+
+func (suite *BatchServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *BatchServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *BatchServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	operationRepo := postgres.NewOperationRepository(suite.testDB.DB)
+	suite.authService = services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{})
+	suite.captureService = services.NewCaptureService(suite.paymentRepo, idempotencyRepo, operationRepo, suite.mockBank, suite.testDB.DB)
+	refundService := services.NewRefundService(suite.paymentRepo, idempotencyRepo, operationRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB)
+	voidService := services.NewVoidService(suite.paymentRepo, idempotencyRepo, operationRepo, suite.mockBank, suite.testDB.DB)
+	suite.service = services.NewBatchService(
+		postgres.NewBatchRepository(suite.testDB.DB),
+		suite.paymentRepo,
+		operationRepo,
+		refundService,
+		voidService,
+		suite.testDB.DB,
+	)
+}
+
+func (suite *BatchServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *BatchServiceTestSuite) Test_RefundBatch_SettlesEachItem() {
+	ctx := context.Background()
+	t := suite.T()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authService, suite.captureService, suite.mockBank)
+	unknownPaymentID := uuid.New().String()
+
+	batch, err := suite.service.CreateRefundBatch(ctx, &services.CreateRefundBatchCommand{
+		Reason: domain.ReasonCustomerRequest,
+		Items: []services.RefundBatchItem{
+			{PaymentID: payment.ID, Amount: 1000},
+			{PaymentID: unknownPaymentID},
+		},
+	}, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.BatchProcessing, batch.Status)
+	assert.Equal(t, 2, batch.Count(domain.BatchItemPending))
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, mock.Anything, "batch-"+batch.Items[0].ID).
+		Return(&bank.RefundResponse{
+			Amount:     1000,
+			Currency:   "USD",
+			CaptureID:  *payment.BankCaptureID,
+			Status:     "refunded",
+			RefundID:   "ref-batch-1",
+			RefundedAt: time.Now(),
+		}, nil).
+		Once()
+
+	settled, err := suite.service.ProcessPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, settled)
+
+	stored, err := suite.service.Get(ctx, batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BatchCompleted, stored.Status)
+	require.Len(t, stored.Items, 2)
+
+	refunded := stored.Items[0]
+	assert.Equal(t, domain.BatchItemSucceeded, refunded.Status)
+	require.NotNil(t, refunded.OperationID)
+
+	unknown := stored.Items[1]
+	assert.Equal(t, unknownPaymentID, unknown.PaymentID)
+	assert.Equal(t, domain.BatchItemFailed, unknown.Status)
+	assert.Nil(t, unknown.OperationID)
+	assert.Equal(t, "PAYMENT_NOT_FOUND", *unknown.ErrorCode)
+
+	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), savedPayment.RefundedAmountCents)
+}
+
+func (suite *BatchServiceTestSuite) Test_RefundBatch_TransientFailureStaysPending() {
+	ctx := context.Background()
+	t := suite.T()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authService, suite.captureService, suite.mockBank)
+
+	batch, err := suite.service.CreateRefundBatch(ctx, &services.CreateRefundBatchCommand{
+		Items: []services.RefundBatchItem{{PaymentID: payment.ID}},
+	}, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, mock.Anything, "batch-"+batch.Items[0].ID).
+		Return(nil, &bank.BankError{Code: "internal_error", StatusCode: 503}).
+		Once()
+
+	settled, err := suite.service.ProcessPending(ctx, 10)
+	require.Error(t, err)
+	assert.Equal(t, 0, settled)
+
+	stored, err := suite.service.Get(ctx, batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BatchProcessing, stored.Status)
+	assert.Equal(t, domain.BatchItemPending, stored.Items[0].Status)
+}
+
+func (suite *BatchServiceTestSuite) Test_CreateRefundBatch_SameKeyReturnsSameBatch() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+	cmd := &services.CreateRefundBatchCommand{
+		Items: []services.RefundBatchItem{{PaymentID: uuid.New().String()}},
+	}
+
+	first, err := suite.service.CreateRefundBatch(ctx, cmd, idempotencyKey)
+	require.NoError(t, err)
+
+	second, err := suite.service.CreateRefundBatch(ctx, cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func (suite *BatchServiceTestSuite) Test_VoidBatch_VoidsAuthorizedOrders() {
+	ctx := context.Background()
+	t := suite.T()
+
+	payment := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authService, suite.mockBank)
+	other := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authService, suite.mockBank)
+
+	batch, err := suite.service.CreateVoidBatch(ctx, &services.CreateVoidBatchCommand{
+		Reason:   domain.ReasonOutOfStock,
+		OrderIDs: []string{payment.OrderID, "order-" + uuid.New().String()},
+	}, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.BatchVoid, batch.Type)
+	require.Len(t, batch.Items, 1)
+	assert.Equal(t, payment.ID, batch.Items[0].PaymentID)
+
+	suite.mockBank.EXPECT().
+		Void(mock.Anything, mock.Anything, "batch-"+batch.Items[0].ID).
+		Return(&bank.VoidResponse{
+			AuthorizationID: *payment.BankAuthID,
+			Status:          "voided",
+			VoidID:          "void-batch-1",
+			VoidedAt:        time.Now(),
+		}, nil).
+		Once()
+
+	settled, err := suite.service.ProcessPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, settled)
+
+	stored, err := suite.service.Get(ctx, batch.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.BatchCompleted, stored.Status)
+	assert.Equal(t, domain.BatchItemSucceeded, stored.Items[0].Status)
+
+	voided, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusVoided, voided.Status)
+
+	untouched, err := suite.paymentRepo.FindByID(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, untouched.Status)
+}
+
+func (suite *BatchServiceTestSuite) Test_CreateVoidBatch_RequiresCriteria() {
+	_, err := suite.service.CreateVoidBatch(context.Background(), &services.CreateVoidBatchCommand{}, "idem-"+uuid.New().String())
+	suite.Require().Error(err)
+}
@@ -10,9 +10,19 @@ import (
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
 )
 
+type captureRequest struct {
+	PaymentID string
+	Amount    int64
+}
+
+func (r captureRequest) hashFields() map[string]any {
+	return map[string]any{"payment_id": r.PaymentID, "amount": r.Amount}
+}
+
 type CaptureService struct {
 	paymentRepo     *postgres.PaymentRepository
 	idempotencyRepo *postgres.IdempotencyRepository
+	operationRepo   *postgres.OperationRepository
 	bankClient      bank.BankClient
 	db              *postgres.DB
 }
@@ -20,19 +30,23 @@ type CaptureService struct {
 func NewCaptureService(
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
 	bankClient bank.BankClient,
 	db *postgres.DB,
 ) *CaptureService {
 	return &CaptureService{
 		paymentRepo:     paymentRepo,
 		idempotencyRepo: idempotencyRepo,
+		operationRepo:   operationRepo,
 		bankClient:      bankClient,
 		db:              db,
 	}
 }
 
-func (s *CaptureService) Capture(ctx context.Context, paymentID, idempotencyKey string) (*domain.Payment, error) {
-	requestHash := ComputeHash(paymentID)
+// Capture charges amount against the payment's authorization. An amount of zero
+// captures whatever remains of the authorization.
+func (s *CaptureService) Capture(ctx context.Context, paymentID string, amount int64, idempotencyKey string) (*domain.Payment, error) {
+	requestHash := ComputeHash(captureRequest{PaymentID: paymentID, Amount: amount})
 
 	cachedPayment, isCached, err := checkIdempotency(
 		ctx,
@@ -40,6 +54,7 @@ func (s *CaptureService) Capture(ctx context.Context, paymentID, idempotencyKey
 		s.paymentRepo,
 		idempotencyKey,
 		requestHash,
+		s.takeOverStaleLock,
 	)
 	if err != nil {
 		return nil, err
@@ -48,48 +63,57 @@ func (s *CaptureService) Capture(ctx context.Context, paymentID, idempotencyKey
 		return cachedPayment, nil
 	}
 
+	captureAmount := amount
 	payment, err := markPaymentTransitioning(
 		ctx,
 		s.db,
 		s.paymentRepo,
 		s.idempotencyRepo,
+		s.operationRepo,
 		paymentID,
 		idempotencyKey,
 		requestHash,
-		func(p *domain.Payment) error {
-			return p.MarkCapturing()
+		"",
+		func(p *domain.Payment) (int64, error) {
+			if captureAmount == 0 {
+				captureAmount = p.RemainingCaptureAmount()
+			}
+			return captureAmount, p.MarkCapturing(captureAmount)
 		},
+		nil,
 	)
 	if err != nil {
 		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
-			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey)
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, s.takeOverStaleLock)
 		}
 		return nil, err
 	}
 
 	bankReq := bank.CaptureRequest{
-		Amount:          payment.AmountCents,
+		Amount:          captureAmount,
 		AuthorizationID: *payment.BankAuthID,
 	}
 
-	bankResp, err := s.bankClient.Capture(ctx, bankReq, idempotencyKey)
+	bankResp, err := s.bankClient.Capture(bank.WithAcquirer(ctx, payment.Acquirer), bankReq, idempotencyKey)
 	if err != nil {
-		return payment, HandleBankFailure(
+		err = HandleBankFailure(
 			ctx,
 			s.db,
 			s.paymentRepo,
 			s.idempotencyRepo,
+			s.operationRepo,
 			payment,
 			idempotencyKey,
 			err,
 		)
+		return payment, wakeRetryWorkers(ctx, s.db, payment, err)
 	}
 
-	if err := payment.Capture(bankResp.Status, bankResp.CaptureID, bankResp.CapturedAt); err != nil {
+	if err := payment.Capture(bankResp.Status, bankResp.CaptureID, captureAmount, bankResp.CapturedAt); err != nil {
 		return nil, application.NewInvalidStateError(err)
 	}
 
-	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, payment, idempotencyKey, bankResp); err != nil {
+	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, s.operationRepo, payment, idempotencyKey, bankResp); err != nil {
 		return payment, err
 	}
 
@@ -25,6 +25,7 @@ type CaptureServiceTestSuite struct {
 	testDB           *testhelpers.TestDatabase
 	paymentRepo      *postgres.PaymentRepository
 	idempotencyRepo  *postgres.IdempotencyRepository
+	operationRepo    *postgres.OperationRepository
 	mockBank         *mocks.MockBankClient
 	authorizeService *services.AuthorizeService
 	captureService   *services.CaptureService
@@ -38,6 +39,7 @@ func (suite *CaptureServiceTestSuite) SetupSuite() {
 	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
 	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
 	suite.idempotencyRepo = postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.operationRepo = postgres.NewOperationRepository(suite.testDB.DB)
 }
 
 func (suite *CaptureServiceTestSuite) TearDownSuite() {
@@ -50,13 +52,16 @@ func (suite *CaptureServiceTestSuite) SetupTest() {
 	suite.authorizeService = services.NewAuthorizeService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
 		suite.mockBank,
 		suite.testDB.DB,
+		services.AuthorizeLimits{},
 	)
 
 	suite.captureService = services.NewCaptureService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		suite.operationRepo,
 		suite.mockBank,
 		suite.testDB.DB,
 	)
@@ -92,6 +97,71 @@ func (suite *CaptureServiceTestSuite) Test_Capture_Success() {
 	require.NoError(t, err)
 	assert.Equal(t, domain.StatusCaptured, savedPayment.Status)
 	assert.Equal(t, "cap-123", *savedPayment.BankCaptureID)
+
+	operations, err := suite.operationRepo.FindByPaymentID(ctx, capturedPayment.ID)
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+	assert.Equal(t, domain.OperationCapture, operations[0].Type)
+	assert.Equal(t, domain.OperationSucceeded, operations[0].Status)
+	assert.Equal(t, capturedPayment.AmountCents, operations[0].AmountCents)
+	assert.Equal(t, "cap-123", *operations[0].BankReferenceID)
+	assert.NotNil(t, operations[0].CompletedAt)
+}
+
+func (suite *CaptureServiceTestSuite) Test_Capture_MultiplePartialCaptures() {
+	ctx := context.Background()
+	t := suite.T()
+
+	payment := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+	firstKey := "idem-first-" + uuid.New().String()
+	secondKey := "idem-second-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, bank.CaptureRequest{Amount: 2000, AuthorizationID: *payment.BankAuthID}, firstKey).
+		Return(&bank.CaptureResponse{CaptureID: "cap-1", Status: "captured", CapturedAt: time.Now()}, nil).
+		Once()
+
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, bank.CaptureRequest{Amount: 3000, AuthorizationID: *payment.BankAuthID}, secondKey).
+		Return(&bank.CaptureResponse{CaptureID: "cap-2", Status: "captured", CapturedAt: time.Now()}, nil).
+		Once()
+
+	firstResult, err := suite.captureService.Capture(ctx, payment.ID, 2000, firstKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, firstResult.Status)
+	assert.Equal(t, int64(2000), firstResult.CapturedAmountCents)
+
+	// Omitting the amount captures what remains of the authorization
+	secondResult, err := suite.captureService.Capture(ctx, payment.ID, 0, secondKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5000), secondResult.CapturedAmountCents)
+	assert.Equal(t, "cap-2", *secondResult.BankCaptureID)
+
+	operations, err := suite.operationRepo.FindByPaymentID(ctx, payment.ID)
+	require.NoError(t, err)
+	require.Len(t, operations, 2)
+	assert.Equal(t, int64(2000), operations[0].AmountCents)
+	assert.Equal(t, "cap-1", *operations[0].BankReferenceID)
+	assert.Equal(t, int64(3000), operations[1].AmountCents)
+	assert.Equal(t, "cap-2", *operations[1].BankReferenceID)
+}
+
+func (suite *CaptureServiceTestSuite) Test_Capture_AmountExceedsRemaining() {
+	ctx := context.Background()
+	t := suite.T()
+
+	payment := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+
+	_, err := suite.captureService.Capture(ctx, payment.ID, payment.AmountCents+1, "idem-"+uuid.New().String())
+
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+
+	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, savedPayment.Status)
+	assert.Zero(t, savedPayment.CapturedAmountCents)
 }
 
 // ============================================================================
@@ -123,7 +193,7 @@ func (suite *CaptureServiceTestSuite) Test_Capture_CannotCapturePendingPayment()
 
 	captureKey := "idem-capture-" + uuid.New().String()
 
-	_, err = suite.captureService.Capture(ctx, payment.ID, captureKey)
+	_, err = suite.captureService.Capture(ctx, payment.ID, 0, captureKey)
 
 	svcErr, ok := application.IsServiceError(err)
 	require.True(t, ok)
@@ -152,12 +222,12 @@ func (suite *CaptureServiceTestSuite) Test_Capture_CannotCaptureAlreadyCapturedP
 		Return(captureResp, nil).
 		Once()
 
-	_, err := suite.captureService.Capture(ctx, payment.ID, firstKey)
+	_, err := suite.captureService.Capture(ctx, payment.ID, 0, firstKey)
 	require.NoError(t, err)
 
 	secondKey := "idem-second-" + uuid.New().String()
 
-	_, err = suite.captureService.Capture(ctx, payment.ID, secondKey)
+	_, err = suite.captureService.Capture(ctx, payment.ID, 0, secondKey)
 
 	require.Error(t, err)
 
@@ -188,10 +258,10 @@ func (suite *CaptureServiceTestSuite) Test_Capture_IdempotencyReturnsCache() {
 		Return(captureResp, nil).
 		Once()
 
-	firstResult, err := suite.captureService.Capture(ctx, payment.ID, idempotencyKey)
+	firstResult, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
 	require.NoError(t, err)
 
-	secondResult, err := suite.captureService.Capture(ctx, payment.ID, idempotencyKey)
+	secondResult, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
 	require.NoError(t, err)
 
 	assert.Equal(t, firstResult.ID, secondResult.ID)
@@ -205,7 +275,7 @@ func (suite *CaptureServiceTestSuite) Test_Capture_PaymentNotFound() {
 	paymentID := "non-existent-id"
 	idempotencyKey := "idem-" + uuid.New().String()
 
-	_, err := suite.captureService.Capture(ctx, paymentID, idempotencyKey)
+	_, err := suite.captureService.Capture(ctx, paymentID, 0, idempotencyKey)
 
 	svcErr, ok := application.IsServiceError(err)
 	require.True(t, ok)
@@ -235,7 +305,7 @@ func (suite *CaptureServiceTestSuite) Test_Capture_BankReturns500_PaymentStaysCa
 		Return(nil, bankErr).
 		Once()
 
-	capturedPayment, err := suite.captureService.Capture(ctx, payment.ID, idempotencyKey)
+	capturedPayment, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
 
 	require.Error(t, err)
 
@@ -248,6 +318,42 @@ func (suite *CaptureServiceTestSuite) Test_Capture_BankReturns500_PaymentStaysCa
 	assert.Nil(t, savedPayment.BankCaptureID)
 }
 
+func (suite *CaptureServiceTestSuite) Test_Capture_StaleLockIsTakenOver() {
+	ctx := context.Background()
+	t := suite.T()
+
+	payment := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, &bank.BankError{Code: "internal_error", Message: "Bank internal error", StatusCode: 500}).
+		Once()
+
+	_, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
+	require.Error(t, err)
+
+	// The request holding the lock died long ago, but the bank did capture it
+	_, err = suite.testDB.DB.Exec(ctx,
+		"UPDATE idempotency_keys SET locked_at = NOW() - INTERVAL '10 minutes' WHERE key = $1", idempotencyKey)
+	require.NoError(t, err)
+
+	suite.mockBank.EXPECT().
+		GetCapture(mock.Anything, idempotencyKey).
+		Return(&bank.CaptureResponse{CaptureID: "cap-123", Status: "captured", CapturedAt: time.Now()}, nil).
+		Once()
+
+	capturedPayment, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, capturedPayment.Status)
+	assert.Equal(t, "cap-123", *capturedPayment.BankCaptureID)
+
+	key, err := suite.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Nil(t, key.LockedAt)
+}
+
 func (suite *CaptureServiceTestSuite) Test_Capture_BankReturnsPermanentError_IsFailed() {
 	ctx := context.Background()
 	t := suite.T()
@@ -267,12 +373,17 @@ func (suite *CaptureServiceTestSuite) Test_Capture_BankReturnsPermanentError_IsF
 		Return(nil, bankErr).
 		Once()
 
-	capturedPayment, err := suite.captureService.Capture(ctx, payment.ID, idempotencyKey)
+	capturedPayment, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
 
 	require.Error(t, err)
 
 	require.NotNil(t, capturedPayment)
 	assert.Equal(t, domain.StatusFailed, capturedPayment.Status)
+
+	operation, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationFailed, operation.Status)
+	assert.Nil(t, operation.BankReferenceID)
 }
 
 func (suite *CaptureServiceTestSuite) Test_Capture_ConcurrentRequests_OnlyOneSucceeds() {
@@ -302,7 +413,7 @@ func (suite *CaptureServiceTestSuite) Test_Capture_ConcurrentRequests_OnlyOneSuc
 
 	for range 2 {
 		wg.Go(func() {
-			_, err := suite.captureService.Capture(ctx, payment.ID, idempotencyKey)
+			_, err := suite.captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
 			results <- err
 		})
 	}
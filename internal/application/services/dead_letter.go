@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// DeadLetteredPayment is a payment the retry worker gave up on, with why
+type DeadLetteredPayment struct {
+	DeadLetter *domain.DeadLetter
+	Payment    *domain.Payment
+}
+
+// DeadLetterService shows the payments the retry worker gave up on and hands them back
+// to it once the cause is dealt with, one at a time or in bulk after an incident
+type DeadLetterService struct {
+	paymentRepo    *postgres.PaymentRepository
+	deadLetterRepo *postgres.DeadLetterRepository
+	db             *postgres.DB
+}
+
+func NewDeadLetterService(
+	paymentRepo *postgres.PaymentRepository,
+	deadLetterRepo *postgres.DeadLetterRepository,
+	db *postgres.DB,
+) *DeadLetterService {
+	return &DeadLetterService{
+		paymentRepo:    paymentRepo,
+		deadLetterRepo: deadLetterRepo,
+		db:             db,
+	}
+}
+
+// List returns up to limit dead-lettered payments of the merchant in ctx, the oldest
+// first
+func (s *DeadLetterService) List(ctx context.Context, limit int) ([]*DeadLetteredPayment, error) {
+	deadLetters, err := s.deadLetterRepo.FindAll(ctx, limit)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	ids := make([]string, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		ids[i] = deadLetter.PaymentID
+	}
+	payments, err := s.paymentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	byID := make(map[string]*domain.Payment, len(payments))
+	for _, payment := range payments {
+		byID[payment.ID] = payment
+	}
+
+	listed := make([]*DeadLetteredPayment, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		if payment, ok := byID[deadLetter.PaymentID]; ok {
+			listed = append(listed, &DeadLetteredPayment{DeadLetter: deadLetter, Payment: payment})
+		}
+	}
+	return listed, nil
+}
+
+// Requeue gives a dead-lettered payment a fresh set of attempts and wakes the retry
+// worker to resume it straight away
+func (s *DeadLetterService) Requeue(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if _, err = s.deadLetterRepo.Delete(ctx, tx, paymentID); err != nil {
+		if errors.Is(err, postgres.ErrDeadLetterNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	payment, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, paymentID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if err = s.resetRetries(ctx, tx, payment); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	// The payment waits for the next poll when the notification is lost
+	s.db.Notify(ctx, postgres.RetryChannel, payment.ID) //nolint:errcheck // the poll is the fallback
+	return payment, nil
+}
+
+// Replay hands the payments of the merchant in ctx that filter picks back to the retry
+// worker at once, dead-lettered or not, without waiting out their backoff or for the
+// worker to think them abandoned. It is for cleaning up after a bank outage. It
+// returns the payments replayed; those named but not mid-transition are left alone.
+func (s *DeadLetterService) Replay(ctx context.Context, filter domain.ReplayFilter) ([]*domain.Payment, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	var candidates []*domain.Payment
+	var err error
+	if filter.Status != "" {
+		candidates, err = s.paymentRepo.FindLongestInStatus(ctx, filter.Status, domain.MaxReplay)
+	} else {
+		candidates, err = s.paymentRepo.FindByIDs(ctx, filter.PaymentIDs)
+	}
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	replayed := make([]*domain.Payment, 0, len(candidates))
+	for _, candidate := range candidates {
+		payment, err := s.replay(ctx, candidate.ID)
+		if err != nil {
+			return nil, err
+		}
+		if payment != nil {
+			replayed = append(replayed, payment)
+		}
+	}
+
+	// The payments wait for the next poll when a notification is lost
+	for _, payment := range replayed {
+		s.db.Notify(ctx, postgres.RetryChannel, payment.ID) //nolint:errcheck // the poll is the fallback
+	}
+	return replayed, nil
+}
+
+// replay resets the retries of one payment, returning nil if it has left its
+// transition since it was listed
+func (s *DeadLetterService) replay(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	var payment *domain.Payment
+	err := runInTx(ctx, s.db, func(tx pgx.Tx) error {
+		locked, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, paymentID)
+		if err != nil {
+			return application.NewInternalError(err)
+		}
+		if _, ok := domain.OperationTypeFor(locked.Status); !ok {
+			return nil
+		}
+
+		if _, err := s.deadLetterRepo.Delete(ctx, tx, paymentID); err != nil && !errors.Is(err, postgres.ErrDeadLetterNotFound) {
+			return application.NewInternalError(err)
+		}
+		if err := s.resetRetries(ctx, tx, locked); err != nil {
+			return err
+		}
+		payment = locked
+		return nil
+	})
+	return payment, err
+}
+
+func (s *DeadLetterService) resetRetries(ctx context.Context, tx pgx.Tx, payment *domain.Payment) error {
+	payment.ResetRetries()
+	if err := s.paymentRepo.Update(ctx, tx, payment); err != nil {
+		return application.NewInternalError(err)
+	}
+	return nil
+}
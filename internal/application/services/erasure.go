@@ -0,0 +1,58 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErasureService forgets customers on request. Payments younger than the financial
+// retention period are kept intact; erasing the customer again once they have aged
+// out anonymizes them too.
+type ErasureService struct {
+	erasureRepo *postgres.ErasureRepository
+	retention   time.Duration
+	db          *postgres.DB
+}
+
+func NewErasureService(erasureRepo *postgres.ErasureRepository, retention time.Duration, db *postgres.DB) *ErasureService {
+	return &ErasureService{
+		erasureRepo: erasureRepo,
+		retention:   retention,
+		db:          db,
+	}
+}
+
+// Erase anonymizes what the merchant in ctx holds about customerID and returns the
+// audit record of it. Erasing a customer with nothing left to erase still records
+// the request.
+func (s *ErasureService) Erase(ctx context.Context, customerID string) (*domain.Erasure, error) {
+	token := strings.ReplaceAll(uuid.New().String(), "-", "")
+
+	erasure, err := domain.NewErasure(uuid.New().String(), customerID, token, s.retention, time.Now())
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := s.erasureRepo.Erase(ctx, tx, erasure, customerID); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return erasure, nil
+}
@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErasureServiceTestSuite struct {
+	suite.Suite
+	testDB         *testhelpers.TestDatabase
+	paymentRepo    *postgres.PaymentRepository
+	mockBank       *mocks.MockBankClient
+	authService    *services.AuthorizeService
+	paymentMethods *services.PaymentMethodService
+	service        *services.ErasureService
+}
+
+func TestErasureServiceSuite(t *testing.T) {
+	suite.Run(t, new(ErasureServiceTestSuite))
+}
+
+func (suite *ErasureServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *ErasureServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *ErasureServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	keyring, err := vault.ParseKeyring("", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(suite.T(), err)
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.authService = services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{})
+	suite.paymentMethods = services.NewPaymentMethodService(postgres.NewPaymentMethodRepository(suite.testDB.DB), keyring)
+	suite.service = services.NewErasureService(postgres.NewErasureRepository(suite.testDB.DB), 24*time.Hour, suite.testDB.DB)
+}
+
+func (suite *ErasureServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *ErasureServiceTestSuite) Test_Erase_AnonymizesOnlyPastRetention() {
+	ctx := context.Background()
+	t := suite.T()
+	customerID := "cust-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, mock.Anything).
+		Return(&bank.AuthorizationResponse{
+			Amount:          100,
+			Currency:        "USD",
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Twice()
+
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	cmd.CustomerID = customerID
+	oldKey := "idem-" + uuid.New().String()
+	old, err := suite.authService.Authorize(ctx, &cmd, oldKey)
+	require.NoError(t, err)
+
+	cmd.OrderID = "order-" + uuid.New().String()
+	recent, err := suite.authService.Authorize(ctx, &cmd, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+
+	_, err = suite.testDB.DB.Pool.Exec(ctx, "UPDATE payments SET created_at = NOW() - INTERVAL '2 days' WHERE id = $1", old.ID)
+	require.NoError(t, err)
+	require.NoError(t, postgres.NewPaymentReadModelRepository(suite.testDB.DB).Refresh(ctx, old.ID))
+
+	debugRepo := postgres.NewDebugSessionRepository(suite.testDB.DB)
+	var debugSessions []string
+	for _, target := range []struct{ paymentID, key string }{{old.ID, ""}, {"", oldKey}} {
+		session, err := domain.NewDebugSession(uuid.New().String(), target.paymentID, target.key, time.Hour, time.Now())
+		require.NoError(t, err)
+		require.NoError(t, debugRepo.Create(ctx, session))
+		require.NoError(t, debugRepo.CreateCapture(ctx, &domain.DebugCapture{
+			ID:             uuid.New().String(),
+			SessionID:      session.ID,
+			IdempotencyKey: oldKey,
+			Method:         "POST",
+			URL:            "/api/v1/authorizations",
+			RequestBody:    `{"customer_id":"` + customerID + `"}`,
+			ResponseStatus: 201,
+			ResponseBody:   `{"status":"AUTHORIZED"}`,
+			CapturedAt:     time.Now(),
+		}))
+		debugSessions = append(debugSessions, session.ID)
+	}
+	_, err = suite.testDB.DB.Pool.Exec(ctx, `
+		INSERT INTO payment_operations (
+			id, payment_id, type, status, amount_cents, idempotency_key,
+			destination, destination_last4, destination_routing_number
+		) VALUES ($1, $2, 'REFUND', 'SUCCEEDED', 100, $3, 'bank_transfer', '6789', '021000021')
+	`, uuid.New().String(), old.ID, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+
+	card, err := suite.paymentMethods.Save(ctx, &services.SavePaymentMethodCommand{
+		CustomerID:  customerID,
+		CardNumber:  "4111111111111111",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	})
+	require.NoError(t, err)
+
+	erasure, err := suite.service.Erase(ctx, customerID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, erasure.PaymentsAnonymized)
+	assert.Equal(t, 1, erasure.PaymentsRetained)
+	assert.Equal(t, 1, erasure.PaymentMethodsAnonymized)
+	assert.NotContains(t, erasure.CustomerToken, customerID)
+
+	anonymized, err := suite.paymentRepo.FindByID(ctx, old.ID)
+	require.NoError(t, err)
+	assert.Equal(t, erasure.CustomerToken, anonymized.CustomerID)
+	assert.Nil(t, anonymized.CardLast4)
+	assert.Nil(t, anonymized.CardBrand)
+
+	var readModelCards int
+	err = suite.testDB.DB.Pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM payment_read_model WHERE id = $1 AND (card_last4 IS NOT NULL OR card_brand IS NOT NULL)", old.ID).Scan(&readModelCards)
+	require.NoError(t, err)
+	assert.Zero(t, readModelCards, "the read model keeps no copy of the card")
+
+	var refundAccounts int
+	err = suite.testDB.DB.Pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM payment_operations WHERE payment_id = $1 AND (destination_last4 IS NOT NULL OR destination_routing_number IS NOT NULL)", old.ID).Scan(&refundAccounts)
+	require.NoError(t, err)
+	assert.Zero(t, refundAccounts, "refunds keep no trace of the account they went to")
+
+	for _, sessionID := range debugSessions {
+		captures, err := debugRepo.FindCapturesBySessionID(ctx, sessionID)
+		require.NoError(t, err)
+		assert.Empty(t, captures, "debug captures of the payment's bank traffic are deleted")
+	}
+
+	kept, err := suite.paymentRepo.FindByID(ctx, recent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, customerID, kept.CustomerID)
+	assert.NotNil(t, kept.CardLast4)
+
+	var events int
+	err = suite.testDB.DB.Pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM outbox WHERE payment_id = $1 AND payload->>'customer_id' = $2", old.ID, customerID).Scan(&events)
+	require.NoError(t, err)
+	assert.Zero(t, events, "outbox events keep no copy of the customer ID")
+
+	erasedCard, err := suite.paymentMethods.Get(ctx, card.ID)
+	require.NoError(t, err)
+	assert.Equal(t, erasure.CustomerToken, erasedCard.CustomerID)
+	assert.Empty(t, erasedCard.Last4)
+}
+
+func (suite *ErasureServiceTestSuite) Test_Erase_RejectsToken() {
+	erasure, err := suite.service.Erase(context.Background(), "cust-"+uuid.New().String())
+	suite.Require().NoError(err)
+
+	_, err = suite.service.Erase(context.Background(), erasure.CustomerToken)
+	suite.Require().Error(err)
+	suite.Equal("INVALID_INPUT", application.ToErrorCode(err))
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// FeatureFlagService decides which features are on for a merchant, so risky ones can
+// reach merchants gradually without a redeploy. Each process reads the flags at most
+// once per ttl, so a change takes up to that long to reach all of them.
+type FeatureFlagService struct {
+	flagRepo *postgres.FeatureFlagRepository
+	rollout  domain.FeatureRollout
+	ttl      time.Duration
+
+	mu       sync.Mutex
+	flags    map[string]*domain.FeatureFlag
+	loadedAt time.Time
+}
+
+// NewFeatureFlagService serves flags not changed at runtime with their percent in
+// rollout
+func NewFeatureFlagService(flagRepo *postgres.FeatureFlagRepository, rollout domain.FeatureRollout, ttl time.Duration) *FeatureFlagService {
+	return &FeatureFlagService{
+		flagRepo: flagRepo,
+		rollout:  rollout,
+		ttl:      ttl,
+	}
+}
+
+// Enabled reports whether the feature is on for the merchant in ctx. While the flags
+// cannot be read it keeps to the ones it read last, or to the configured rollout.
+func (s *FeatureFlagService) Enabled(ctx context.Context, name string) bool {
+	flag, ok := s.cached(ctx)[name]
+	return ok && flag.EnabledFor(postgres.MerchantFromContext(ctx))
+}
+
+func (s *FeatureFlagService) cached(ctx context.Context) map[string]*domain.FeatureFlag {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.flags != nil && time.Since(s.loadedAt) < s.ttl {
+		return s.flags
+	}
+
+	flags, err := s.flagRepo.FindAll(ctx, s.rollout)
+	switch {
+	case err == nil:
+		s.flags = make(map[string]*domain.FeatureFlag, len(flags))
+		for _, flag := range flags {
+			s.flags[flag.Name] = flag
+		}
+	case s.flags == nil:
+		s.flags = make(map[string]*domain.FeatureFlag, len(s.rollout))
+		for name, percent := range s.rollout {
+			s.flags[name] = &domain.FeatureFlag{Name: name, Percent: percent}
+		}
+	}
+	// A failed read is not retried before the ttl is up either, so a database outage
+	// does not cost every request a failing query
+	s.loadedAt = time.Now()
+	return s.flags
+}
+
+// List returns every flag as stored, sorted by name
+func (s *FeatureFlagService) List(ctx context.Context) ([]*domain.FeatureFlag, error) {
+	flags, err := s.flagRepo.FindAll(ctx, s.rollout)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return flags, nil
+}
+
+// SetPercent changes the share of merchants a flag is on for
+func (s *FeatureFlagService) SetPercent(ctx context.Context, name string, percent int) (*domain.FeatureFlag, error) {
+	if _, err := domain.NewFeatureFlag(name, percent); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	if err := s.flagRepo.SetPercent(ctx, name, percent); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return s.changed(ctx, name)
+}
+
+// SetOverride turns a flag on or off for one merchant whatever its percent
+func (s *FeatureFlagService) SetOverride(ctx context.Context, name, merchantID string, enabled bool) (*domain.FeatureFlag, error) {
+	if _, known := domain.DefaultFeatureRollout[name]; !known {
+		return nil, application.NewInvalidInputError(domain.ErrUnknownFeatureFlag)
+	}
+
+	if err := s.flagRepo.SetOverride(ctx, name, merchantID, enabled); err != nil {
+		if errors.Is(err, postgres.ErrMerchantNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return s.changed(ctx, name)
+}
+
+// DeleteOverride returns a merchant to the flag's percent
+func (s *FeatureFlagService) DeleteOverride(ctx context.Context, name, merchantID string) (*domain.FeatureFlag, error) {
+	if _, known := domain.DefaultFeatureRollout[name]; !known {
+		return nil, application.NewInvalidInputError(domain.ErrUnknownFeatureFlag)
+	}
+
+	if err := s.flagRepo.DeleteOverride(ctx, name, merchantID); err != nil {
+		if errors.Is(err, postgres.ErrFeatureOverrideNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return s.changed(ctx, name)
+}
+
+// changed drops the cache, so this process applies a change at once, and returns the
+// flag as now stored
+func (s *FeatureFlagService) changed(ctx context.Context, name string) (*domain.FeatureFlag, error) {
+	s.mu.Lock()
+	s.flags = nil
+	s.mu.Unlock()
+
+	flags, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, flag := range flags {
+		if flag.Name == name {
+			return flag, nil
+		}
+	}
+	return nil, application.NewInternalError(domain.ErrUnknownFeatureFlag)
+}
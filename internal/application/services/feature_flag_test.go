@@ -0,0 +1,97 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type FeatureFlagServiceTestSuite struct {
+	suite.Suite
+	testDB   *testhelpers.TestDatabase
+	flagRepo *postgres.FeatureFlagRepository
+}
+
+func TestFeatureFlagServiceSuite(t *testing.T) {
+	suite.Run(t, new(FeatureFlagServiceTestSuite))
+}
+
+func (suite *FeatureFlagServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.flagRepo = postgres.NewFeatureFlagRepository(suite.testDB.DB)
+}
+
+func (suite *FeatureFlagServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *FeatureFlagServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *FeatureFlagServiceTestSuite) TestOverridesWinOverRollout() {
+	ctx := context.Background()
+	t := suite.T()
+	service := services.NewFeatureFlagService(suite.flagRepo, domain.FeatureRollout{domain.FlagCanaryRouting: 0}, 0)
+
+	assert.False(t, service.Enabled(ctx, domain.FlagCanaryRouting))
+
+	flag, err := service.SetOverride(ctx, domain.FlagCanaryRouting, domain.DefaultMerchantID, true)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{domain.DefaultMerchantID: true}, flag.Overrides)
+	assert.True(t, service.Enabled(ctx, domain.FlagCanaryRouting))
+	assert.False(t, service.Enabled(postgres.WithMerchant(ctx, "acme"), domain.FlagCanaryRouting))
+
+	flag, err = service.SetPercent(ctx, domain.FlagCanaryRouting, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 100, flag.Percent)
+	assert.NotNil(t, flag.UpdatedAt)
+	assert.True(t, service.Enabled(postgres.WithMerchant(ctx, "acme"), domain.FlagCanaryRouting))
+
+	_, err = service.DeleteOverride(ctx, domain.FlagCanaryRouting, domain.DefaultMerchantID)
+	require.NoError(t, err)
+	_, err = service.DeleteOverride(ctx, domain.FlagCanaryRouting, domain.DefaultMerchantID)
+	assert.ErrorIs(t, err, postgres.ErrFeatureOverrideNotFound)
+}
+
+func (suite *FeatureFlagServiceTestSuite) TestCachesFlagsForTTL() {
+	ctx := context.Background()
+	t := suite.T()
+	service := services.NewFeatureFlagService(suite.flagRepo, domain.FeatureRollout{domain.FlagAsyncAuthorize: 100}, time.Hour)
+
+	assert.True(t, service.Enabled(ctx, domain.FlagAsyncAuthorize))
+
+	// Another process turning the flag off is not seen until the cache expires
+	require.NoError(t, suite.flagRepo.SetPercent(ctx, domain.FlagAsyncAuthorize, 0))
+	assert.True(t, service.Enabled(ctx, domain.FlagAsyncAuthorize))
+
+	// A change made through this process applies at once
+	_, err := service.SetPercent(ctx, domain.FlagAsyncAuthorize, 0)
+	require.NoError(t, err)
+	assert.False(t, service.Enabled(ctx, domain.FlagAsyncAuthorize))
+}
+
+func (suite *FeatureFlagServiceTestSuite) TestRejectsInvalidChanges() {
+	ctx := context.Background()
+	t := suite.T()
+	service := services.NewFeatureFlagService(suite.flagRepo, domain.DefaultFeatureRollout, 0)
+
+	for name, percent := range map[string]int{"teleport": 50, domain.FlagCanaryRouting: 101} {
+		_, err := service.SetPercent(ctx, name, percent)
+		svcErr, ok := application.IsServiceError(err)
+		require.True(t, ok)
+		assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+	}
+
+	_, err := service.SetOverride(ctx, domain.FlagCanaryRouting, "nobody", true)
+	assert.ErrorIs(t, err, postgres.ErrMerchantNotFound)
+}
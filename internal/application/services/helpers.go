@@ -2,31 +2,26 @@ package services
 
 import (
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
-func ComputeHash(v any) string {
-	data := fmt.Sprintf("%+v", v)
-	hash := sha256.Sum256([]byte(data))
-	return fmt.Sprintf("%x", hash)
-}
-
 // checkIdempotency checks if request was already processed and returns cached result if available
 func checkIdempotency(
 	ctx context.Context,
 	idempotencyRepo *postgres.IdempotencyRepository,
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyKey string,
-	requestHash string,
+	requestHash RequestHash,
+	takeOver staleLockTakeover,
 ) (*domain.Payment, bool, error) {
 	existingKey, err := idempotencyRepo.FindByKey(ctx, idempotencyKey)
 	if err != nil {
@@ -37,27 +32,41 @@ func checkIdempotency(
 		return nil, false, nil
 	}
 
-	if existingKey.RequestHash != requestHash {
+	if !requestHash.Matches(existingKey.RequestHash) {
 		return nil, false, application.NewIdempotencyMismatchError()
 	}
 
 	if existingKey.LockedAt != nil {
-		payment, err := waitForCompletion(ctx, idempotencyRepo, paymentRepo, idempotencyKey)
+		// Its errors are the answers a retry is meant to get, such as a replayed failure
+		payment, err := waitForCompletion(ctx, idempotencyRepo, paymentRepo, idempotencyKey, takeOver)
 		if err != nil {
-			return nil, false, application.NewInternalError(err)
+			return nil, false, err
 		}
 		return payment, true, nil
 	}
 
+	if err := replayFailure(existingKey); err != nil {
+		return nil, false, err
+	}
+
 	return nil, false, nil
 }
 
-// waitForCompletion polls for operation completion when another request is processing the same idempotency key
+// staleLockTakeover settles the request holding a stale idempotency lock and returns
+// its payment. A nil payment without an error means another request took it over first.
+type staleLockTakeover func(ctx context.Context, key *postgres.IdempotencyKey) (*domain.Payment, error)
+
+// staleLockAfter is how long a lock may be held before its request is presumed dead
+const staleLockAfter = 5 * time.Minute
+
+// waitForCompletion polls for operation completion when another request is processing the same idempotency key.
+// A lock held past staleLockAfter is handed to takeOver, or fails the wait when takeOver is nil.
 func waitForCompletion(
 	ctx context.Context,
 	idempotencyRepo *postgres.IdempotencyRepository,
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyKey string,
+	takeOver staleLockTakeover,
 ) (*domain.Payment, error) {
 	ticker := time.NewTicker(100 * time.Millisecond)
 	defer ticker.Stop()
@@ -76,6 +85,9 @@ func waitForCompletion(
 			}
 
 			if key.LockedAt == nil {
+				if err := replayFailure(key); err != nil {
+					return nil, err
+				}
 				payment, err := paymentRepo.FindByID(ctx, key.PaymentID)
 				if err != nil {
 					return nil, application.NewInternalError(err)
@@ -83,83 +95,147 @@ func waitForCompletion(
 				return payment, nil
 			}
 
-			if time.Since(*key.LockedAt) > 5*time.Minute {
-				return nil, application.NewRequestProcessingError()
+			if time.Since(*key.LockedAt) > staleLockAfter {
+				if takeOver == nil {
+					return nil, application.NewRequestProcessingError(key.PaymentID)
+				}
+				payment, err := takeOver(ctx, key)
+				if err != nil || payment != nil {
+					return payment, err
+				}
 			}
 		}
 	}
 }
 
-// acquireIdempotencyLock creates payment and locks idempotency key in a single transaction
+// acquireIdempotencyLock creates payment, locks idempotency key and counts the payment
+// against the merchant's quota in a single transaction
 func acquireIdempotencyLock(
 	ctx context.Context,
 	db *postgres.DB,
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	settingsRepo *postgres.MerchantSettingsRepository,
 	payment *domain.Payment,
 	idempotencyKey string,
-	requestHash string,
+	requestHash RequestHash,
 ) error {
-	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
-	if err != nil {
-		return application.NewInternalError(err)
-	}
-	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+	return runInTx(ctx, db, func(tx pgx.Tx) error {
+		if err := paymentRepo.Create(ctx, tx, payment); err != nil {
+			if errors.Is(err, postgres.ErrOrderAlreadyPaid) {
+				return err
+			}
+			return application.NewInternalError(err)
+		}
 
-	if err := paymentRepo.Create(ctx, tx, payment); err != nil {
-		return application.NewInternalError(err)
-	}
+		if err := idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, payment.ID, requestHash.String()); err != nil {
+			if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+				return err
+			}
+			return application.NewInternalError(err)
+		}
 
-	if err := idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, payment.ID, requestHash); err != nil {
+		return consumeQuota(ctx, tx, settingsRepo, payment)
+	})
+}
+
+// runInTx runs fn in a read-committed transaction that is run again when Postgres
+// aborts it to break a deadlock with a concurrent one. Read committed never fails with
+// a serialization failure, so there is nothing else to retry. fn's errors are returned
+// unchanged; failing to begin or commit the transaction is an internal error.
+func runInTx(ctx context.Context, db *postgres.DB, fn func(tx pgx.Tx) error) error {
+	var fnFailed bool
+	err := db.RunInTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+		err := fn(tx)
+		fnFailed = err != nil
 		return err
+	})
+	if err != nil && !fnFailed {
+		return application.NewInternalError(err)
 	}
+	return err
+}
 
-	if err := tx.Commit(ctx); err != nil {
+// consumeQuota counts a new payment against the merchant's daily quota within tx
+func consumeQuota(ctx context.Context, tx pgx.Tx, settingsRepo *postgres.MerchantSettingsRepository, payment *domain.Payment) error {
+	quota, err := settingsRepo.ConsumeQuota(ctx, tx, payment.AmountCents)
+	if err != nil {
 		return application.NewInternalError(err)
 	}
-
+	if err := quota.Check(); err != nil {
+		return application.NewQuotaExceededError()
+	}
 	return nil
 }
 
 // markPaymentTransitioning updates payment to intermediate state (CAPTURING, VOIDING, etc.)
+// and records the PENDING operation in the same transaction. transitionFn returns the
+// amount the operation moves; prepareOp, when set, adds to the operation once it is
+// stored, in the same transaction.
 func markPaymentTransitioning(
 	ctx context.Context,
 	db *postgres.DB,
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
 	paymentID string,
 	idempotencyKey string,
-	requestHash string,
-	transitionFn func(*domain.Payment) error,
+	requestHash RequestHash,
+	reason domain.OperationReason,
+	transitionFn func(*domain.Payment) (int64, error),
+	prepareOp func(tx pgx.Tx, op *domain.Operation) error,
 ) (*domain.Payment, error) {
-	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
-	if err != nil {
-		return nil, application.NewInternalError(err)
-	}
-	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+	var payment *domain.Payment
+	err := runInTx(ctx, db, func(tx pgx.Tx) error {
+		if err := idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, paymentID, requestHash.String()); err != nil {
+			if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+				return err
+			}
+			return application.NewInternalError(err)
+		}
 
-	if err = idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, paymentID, requestHash); err != nil {
-		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
-			return nil, err
+		var err error
+		payment, err = paymentRepo.FindByIDForUpdate(ctx, tx, paymentID)
+		if err != nil {
+			return application.NewInternalError(err)
 		}
-		return nil, application.NewInternalError(err)
-	}
 
-	payment, err := paymentRepo.FindByIDForUpdate(ctx, tx, paymentID)
-	if err != nil {
-		return nil, application.NewInternalError(err)
-	}
+		amount, err := transitionFn(payment)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidAmount) {
+				return application.NewInvalidInputError(err)
+			}
+			return application.NewInvalidStateError(err)
+		}
 
-	if err = transitionFn(payment); err != nil {
-		return nil, application.NewInvalidStateError(err)
-	}
+		if err = paymentRepo.Update(ctx, tx, payment); err != nil {
+			return application.NewInternalError(err)
+		}
 
-	if err = paymentRepo.Update(ctx, tx, payment); err != nil {
-		return nil, application.NewInternalError(err)
-	}
+		opType, ok := domain.OperationTypeFor(payment.Status)
+		if !ok {
+			return application.NewInvalidStateError(domain.ErrInvalidState)
+		}
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, application.NewInternalError(err)
+		op, err := domain.NewOperation(uuid.New().String(), payment.ID, opType, amount, idempotencyKey)
+		if err != nil {
+			return application.NewInvalidInputError(err)
+		}
+
+		if err = op.SetReason(reason); err != nil {
+			return application.NewInvalidInputError(err)
+		}
+
+		if err = operationRepo.Create(ctx, tx, op); err != nil {
+			return application.NewInternalError(err)
+		}
+		if prepareOp != nil {
+			return prepareOp(tx, op)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return payment, nil
@@ -171,43 +247,172 @@ func HandleBankFailure(
 	db *postgres.DB,
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
 	payment *domain.Payment,
 	idempotencyKey string,
 	bankErr error,
 ) error {
+	// A rate-limited call never left the gateway, so only a real answer moves the key on
+	if answer, ok := bank.IsBankError(bankErr); ok && answer.Code != bank.CodeRateLimited {
+		if err := idempotencyRepo.MarkBankResponded(ctx, nil, idempotencyKey); err != nil {
+			return application.NewInternalError(err)
+		}
+	}
+
 	category := application.CategorizeError(bankErr)
+	payment.RecordError(string(category))
 	if category != application.CategoryPermanent {
+		// Stored even when the client gave up, so callers can see the payment is retried
+		paymentRepo.Update(context.WithoutCancel(ctx), nil, payment) //nolint:errcheck // the bank's error is what the caller needs
 		return bankErr
 	}
 
-	if err := payment.Fail(); err != nil {
+	return AbandonOperation(ctx, db, paymentRepo, idempotencyRepo, operationRepo, payment, idempotencyKey, bankErr)
+}
+
+// AbandonOperation fails the payment's operation for good because of cause, settling
+// its idempotency key with cause as the response. It returns cause.
+func AbandonOperation(
+	ctx context.Context,
+	db *postgres.DB,
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	payment *domain.Payment,
+	idempotencyKey string,
+	cause error,
+) error {
+	if err := failPayment(payment, cause); err != nil {
 		return application.NewInvalidStateError(err)
 	}
 
-	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	responsePayload, err := json.Marshal(newStoredFailure(cause))
 	if err != nil {
 		return application.NewInternalError(err)
 	}
-	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+	return settleFailedOperation(ctx, db, paymentRepo, idempotencyRepo, operationRepo, payment, idempotencyKey, responsePayload, cause)
+}
 
-	if err = paymentRepo.Update(ctx, tx, payment); err != nil {
-		return application.NewInternalError(err)
+// RollBackOperation undoes the payment's operation because the bank never received it,
+// returning the payment to the status it left and settling its idempotency key with
+// cause as the response. It returns cause.
+func RollBackOperation(
+	ctx context.Context,
+	db *postgres.DB,
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	payment *domain.Payment,
+	idempotencyKey string,
+	cause error,
+) error {
+	if err := payment.RollBack(); err != nil {
+		return application.NewInvalidStateError(err)
 	}
 
-	responsePayload, err := json.Marshal(bankErr)
+	// Stored as it is rather than as a failure, so a retry with the key starts over
+	responsePayload, err := json.Marshal(cause)
 	if err != nil {
 		return application.NewInternalError(err)
 	}
+	return settleFailedOperation(ctx, db, paymentRepo, idempotencyRepo, operationRepo, payment, idempotencyKey, responsePayload, cause)
+}
 
-	if err = idempotencyRepo.StoreResponse(ctx, tx, idempotencyKey, responsePayload); err != nil {
-		return application.NewInternalError(err)
+// settleFailedOperation stores a payment whose operation failed because of cause,
+// settles its idempotency key with responsePayload and marks the operation failed. It
+// returns cause.
+func settleFailedOperation(
+	ctx context.Context,
+	db *postgres.DB,
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	payment *domain.Payment,
+	idempotencyKey string,
+	responsePayload []byte,
+	cause error,
+) error {
+	err := runInTx(ctx, db, func(tx pgx.Tx) error {
+		if err := paymentRepo.Update(ctx, tx, payment); err != nil {
+			return application.NewInternalError(err)
+		}
+
+		if err := idempotencyRepo.StoreResponse(ctx, tx, idempotencyKey, responsePayload); err != nil {
+			return application.NewInternalError(err)
+		}
+
+		if err := completeOperation(ctx, tx, operationRepo, payment, idempotencyKey, true); err != nil {
+			return application.NewInternalError(err)
+		}
+
+		if err := idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
+			return application.NewInternalError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return application.NewInternalError(err)
+	return cause
+}
+
+// storedFailure is the response stored on the idempotency key of a request that failed
+// for good, so that a retry with the key is answered with the same error
+type storedFailure struct {
+	Failure struct {
+		// BankCode is set when the bank refused the request, and replays it as a bank error
+		BankCode   string `json:"bank_code,omitempty"`
+		Code       string `json:"code"`
+		Message    string `json:"message"`
+		StatusCode int    `json:"status_code"`
+	} `json:"failure"`
+}
+
+func newStoredFailure(cause error) storedFailure {
+	var f storedFailure
+	if bankErr, ok := bank.IsBankError(cause); ok {
+		f.Failure.BankCode = bankErr.Code
+		f.Failure.Message = bankErr.Message
+	} else {
+		f.Failure.Message = cause.Error()
+	}
+	f.Failure.Code = application.ToErrorCode(cause)
+	f.Failure.StatusCode = application.ToHTTPStatus(cause)
+	return f
+}
+
+// replayFailure returns the error stored on a settled idempotency key by a request that
+// failed for good, or nil if the key holds any other response
+func replayFailure(key *postgres.IdempotencyKey) error {
+	if key.ResponsePayload == nil {
+		return nil
+	}
+
+	var f storedFailure
+	if err := json.Unmarshal(*key.ResponsePayload, &f); err != nil || f.Failure.Code == "" {
+		return nil
+	}
+
+	if f.Failure.BankCode != "" {
+		return &bank.BankError{Code: f.Failure.BankCode, Message: f.Failure.Message, StatusCode: f.Failure.StatusCode}
 	}
+	return &application.ServiceError{
+		Code:       f.Failure.Code,
+		Message:    f.Failure.Message,
+		HTTPStatus: f.Failure.StatusCode,
+	}
+}
 
-	return bankErr
+// wakeRetryWorkers tells the retry workers that a transient bank failure, err, left the
+// payment mid-transition, so they resume it now instead of at their next poll. It
+// returns err.
+func wakeRetryWorkers(ctx context.Context, db *postgres.DB, payment *domain.Payment, err error) error {
+	if application.IsRetryable(err) {
+		// The payment waits for the next poll when the notification is lost
+		db.Notify(ctx, postgres.RetryChannel, payment.ID) //nolint:errcheck // the poll is the fallback
+	}
+	return err
 }
 
 // FinalizePayment stores successful bank response and releases lock
@@ -216,19 +421,13 @@ func FinalizePayment(
 	db *postgres.DB,
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
 	payment *domain.Payment,
 	idempotencyKey string,
 	bankResponse any,
 ) error {
-	tx, err := db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
-	if err != nil {
-		return application.NewInternalError(err)
-	}
-	defer func() {
-		_ = tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
-	}()
-
-	if err = paymentRepo.Update(ctx, tx, payment); err != nil {
+	// Recorded on its own first, so the bank's answer is known even if storing it fails
+	if err := idempotencyRepo.MarkBankResponded(ctx, nil, idempotencyKey); err != nil {
 		return application.NewInternalError(err)
 	}
 
@@ -237,17 +436,108 @@ func FinalizePayment(
 		return application.NewInternalError(err)
 	}
 
-	if err = idempotencyRepo.StoreResponse(ctx, tx, idempotencyKey, responsePayload); err != nil {
-		return application.NewInternalError(err)
-	}
+	return runInTx(ctx, db, func(tx pgx.Tx) error {
+		if err := paymentRepo.Update(ctx, tx, payment); err != nil {
+			return application.NewInternalError(err)
+		}
 
-	if err = idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
-		return application.NewInternalError(err)
+		if err := idempotencyRepo.StoreResponse(ctx, tx, idempotencyKey, responsePayload); err != nil {
+			return application.NewInternalError(err)
+		}
+
+		if err := completeOperation(ctx, tx, operationRepo, payment, idempotencyKey, false); err != nil {
+			return application.NewInternalError(err)
+		}
+
+		if err := idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
+			return application.NewInternalError(err)
+		}
+		return nil
+	})
+}
+
+// failPayment records a permanent rejection, cause. A rejected refund, or void of what
+// a partial capture left, only fails that operation and leaves the captured payment
+// intact, and a declined reauthorization leaves the payment EXPIRED; anything else
+// fails the payment. A refusal by the bank, other than of a refund or such a void, is
+// recorded as a decline; running out of retries is not one.
+func failPayment(payment *domain.Payment, cause error) error {
+	if payment.Status == domain.StatusVoiding && payment.CapturedAmountCents > 0 {
+		return payment.FailRelease()
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return application.NewInternalError(err)
+	//nolint:exhaustive // every other status simply fails
+	switch payment.Status {
+	case domain.StatusRefunding:
+		return payment.FailRefund()
+	case domain.StatusReauthorizing:
+		if err := payment.FailReauthorization(); err != nil {
+			return err
+		}
+	default:
+		if err := payment.Fail(); err != nil {
+			return err
+		}
 	}
 
+	if bankErr, ok := bank.IsBankError(cause); ok && !bankErr.IsRetryable() && bankErr.Code != bank.CodeRateLimited {
+		payment.RecordDecline(bankErr.Code)
+	}
 	return nil
 }
+
+// completeOperation settles the operation started with the idempotency key.
+// Authorizations are not operations, so a nil repository or a key without an
+// operation is a no-op.
+func completeOperation(
+	ctx context.Context,
+	tx pgx.Tx,
+	operationRepo *postgres.OperationRepository,
+	payment *domain.Payment,
+	idempotencyKey string,
+	failed bool,
+) error {
+	if operationRepo == nil {
+		return nil
+	}
+
+	op, err := operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, postgres.ErrOperationNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	if failed {
+		err = op.Fail(now)
+	} else {
+		err = op.Succeed(bankReferenceFor(payment, op.Type), now)
+	}
+	if err != nil {
+		return err
+	}
+
+	return operationRepo.Update(ctx, tx, op)
+}
+
+// bankReferenceFor returns the bank's ID for the given operation on the payment
+func bankReferenceFor(payment *domain.Payment, opType domain.OperationType) string {
+	var ref *string
+	switch opType {
+	case domain.OperationCapture:
+		ref = payment.BankCaptureID
+	case domain.OperationVoid:
+		ref = payment.BankVoidID
+	case domain.OperationRefund:
+		ref = payment.BankRefundID
+	case domain.OperationReauthorize:
+		ref = payment.BankAuthID
+	}
+
+	if ref == nil {
+		return ""
+	}
+	return *ref
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+)
+
+// KeyRotationService re-seals stored card and account numbers and the signing secrets
+// of API keys with the primary vault key. Reads re-seal the cards they touch already;
+// this catches the rows nobody reads, so a retired key can be removed from the keyring
+// once a batch comes back empty.
+type KeyRotationService struct {
+	paymentMethodRepo *postgres.PaymentMethodRepository
+	payoutRepo        *postgres.PayoutRepository
+	operationRepo     *postgres.OperationRepository
+	apiKeyRepo        *postgres.APIKeyRepository
+	keyring           *vault.Keyring
+}
+
+func NewKeyRotationService(
+	paymentMethodRepo *postgres.PaymentMethodRepository,
+	payoutRepo *postgres.PayoutRepository,
+	operationRepo *postgres.OperationRepository,
+	apiKeyRepo *postgres.APIKeyRepository,
+	keyring *vault.Keyring,
+) *KeyRotationService {
+	return &KeyRotationService{
+		paymentMethodRepo: paymentMethodRepo,
+		payoutRepo:        payoutRepo,
+		operationRepo:     operationRepo,
+		apiKeyRepo:        apiKeyRepo,
+		keyring:           keyring,
+	}
+}
+
+// RotateBatch re-seals up to limit cards, up to limit payout accounts, up to limit
+// accounts of bank transfer refunds still in flight and up to limit signing secrets
+// that are not sealed with the primary key. It returns how many it found, counting those a read
+// re-sealed first, so zero means nothing is left on a retired key.
+func (s *KeyRotationService) RotateBatch(ctx context.Context, limit int) (int, error) {
+	primaryKeyID := s.keyring.PrimaryKeyID()
+
+	cards, err := s.paymentMethodRepo.FindStaleCards(ctx, primaryKeyID, limit)
+	if err != nil {
+		return 0, application.NewInternalError(err)
+	}
+	accounts, err := s.payoutRepo.FindStaleAccounts(ctx, primaryKeyID, limit)
+	if err != nil {
+		return 0, application.NewInternalError(err)
+	}
+
+	refundAccounts, err := s.operationRepo.FindStaleDestinationAccounts(ctx, primaryKeyID, limit)
+	if err != nil {
+		return 0, application.NewInternalError(err)
+	}
+
+	signingSecrets, err := s.apiKeyRepo.FindStaleSigningSecrets(ctx, primaryKeyID, limit)
+	if err != nil {
+		return 0, application.NewInternalError(err)
+	}
+
+	var rotated int
+	for _, card := range cards {
+		if err := s.reseal(ctx, card, s.paymentMethodRepo.ReplaceCardCiphertext); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	for _, account := range accounts {
+		if err := s.reseal(ctx, account, s.payoutRepo.ReplaceAccountCiphertext); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	for _, account := range refundAccounts {
+		if err := s.reseal(ctx, account, s.operationRepo.ReplaceDestinationAccount); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+	for _, secret := range signingSecrets {
+		if err := s.reseal(ctx, secret, s.apiKeyRepo.ReplaceSigningSecret); err != nil {
+			return rotated, err
+		}
+		rotated++
+	}
+
+	return rotated, nil
+}
+
+func (s *KeyRotationService) reseal(
+	ctx context.Context,
+	stale postgres.StaleCiphertext,
+	replace func(ctx context.Context, id string, old, resealed vault.Sealed) (bool, error),
+) error {
+	resealed, err := s.keyring.Reseal(stale.Sealed)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+
+	// Not replacing means a read re-sealed the row since it was found
+	if _, err := replace(postgres.WithMerchant(ctx, stale.MerchantID), stale.ID, stale.Sealed, resealed); err != nil {
+		return application.NewInternalError(err)
+	}
+	return nil
+}
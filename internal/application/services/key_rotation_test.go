@@ -0,0 +1,114 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyRotationServiceTestSuite struct {
+	suite.Suite
+	testDB            *testhelpers.TestDatabase
+	paymentMethodRepo *postgres.PaymentMethodRepository
+	legacyCards       *services.PaymentMethodService
+	cards             *services.PaymentMethodService
+	service           *services.KeyRotationService
+}
+
+func TestKeyRotationServiceSuite(t *testing.T) {
+	suite.Run(t, new(KeyRotationServiceTestSuite))
+}
+
+func (suite *KeyRotationServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentMethodRepo = postgres.NewPaymentMethodRepository(suite.testDB.DB)
+}
+
+func (suite *KeyRotationServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *KeyRotationServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+
+	legacyKey := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	legacy, err := vault.ParseKeyring("", legacyKey)
+	require.NoError(suite.T(), err)
+
+	rotated, err := vault.ParseKeyring("k2:"+base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")), legacyKey)
+	require.NoError(suite.T(), err)
+
+	suite.legacyCards = services.NewPaymentMethodService(suite.paymentMethodRepo, legacy)
+	suite.cards = services.NewPaymentMethodService(suite.paymentMethodRepo, rotated)
+	suite.service = services.NewKeyRotationService(suite.paymentMethodRepo, postgres.NewPayoutRepository(suite.testDB.DB), postgres.NewOperationRepository(suite.testDB.DB), postgres.NewAPIKeyRepository(suite.testDB.DB), rotated)
+}
+
+func (suite *KeyRotationServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *KeyRotationServiceTestSuite) saveLegacyCard(ctx context.Context) string {
+	pm, err := suite.legacyCards.Save(ctx, &services.SavePaymentMethodCommand{
+		CustomerID:  "cust-123",
+		CardNumber:  "4111111111111111",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	})
+	require.NoError(suite.T(), err)
+	return pm.ID
+}
+
+func (suite *KeyRotationServiceTestSuite) Test_CardNumber_ResealsOnRead() {
+	ctx := context.Background()
+	t := suite.T()
+	id := suite.saveLegacyCard(ctx)
+
+	number, err := suite.cards.CardNumber(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "4111111111111111", number)
+
+	card, err := suite.paymentMethodRepo.FindCardCiphertext(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", card.KeyID)
+
+	number, err = suite.cards.CardNumber(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "4111111111111111", number)
+}
+
+func (suite *KeyRotationServiceTestSuite) Test_RotateBatch_ResealsUntilNothingIsLeft() {
+	ctx := context.Background()
+	t := suite.T()
+	first := suite.saveLegacyCard(ctx)
+	second := suite.saveLegacyCard(ctx)
+
+	rotated, err := suite.service.RotateBatch(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rotated)
+
+	rotated, err = suite.service.RotateBatch(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rotated)
+
+	rotated, err = suite.service.RotateBatch(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, rotated)
+
+	for _, id := range []string{first, second} {
+		card, err := suite.paymentMethodRepo.FindCardCiphertext(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "k2", card.KeyID)
+
+		number, err := suite.cards.CardNumber(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "4111111111111111", number)
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// OutboxStatus is how far delivery of transition events has fallen behind
+type OutboxStatus struct {
+	Pending int
+	// OldestOccurredAt is when the longest waiting event was written; nil when none wait
+	OldestOccurredAt *time.Time
+	// Pause is nil while events are delivered
+	Pause *domain.OutboxPause
+}
+
+// OutboxService lets an operator hold back delivery of transition events while a
+// consumer downstream is failing, rather than have every event retried against it.
+// Events keep being written while delivery is paused, and the outbox worker delivers
+// them in order once it resumes.
+type OutboxService struct {
+	outboxRepo *postgres.OutboxRepository
+}
+
+func NewOutboxService(outboxRepo *postgres.OutboxRepository) *OutboxService {
+	return &OutboxService{outboxRepo: outboxRepo}
+}
+
+func (s *OutboxService) Status(ctx context.Context) (*OutboxStatus, error) {
+	pending, oldest, err := s.outboxRepo.Backlog(ctx)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	pause, err := s.outboxRepo.FindPause(ctx)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return &OutboxStatus{Pending: pending, OldestOccurredAt: oldest, Pause: pause}, nil
+}
+
+// Pause stops delivery from the worker's next run on, under the API key in ctx. A
+// batch being delivered as it is paused is still delivered. Pausing again keeps the
+// reason given first.
+func (s *OutboxService) Pause(ctx context.Context, reason string) (*OutboxStatus, error) {
+	pause := &domain.OutboxPause{PausedAt: time.Now(), Reason: reason}
+	if key := postgres.APIKeyFromContext(ctx); key != nil {
+		pause.PausedBy = key.ID
+	}
+
+	if err := s.outboxRepo.Pause(ctx, pause); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return s.Status(ctx)
+}
+
+// Resume lets the worker deliver again, starting with the events held back
+func (s *OutboxService) Resume(ctx context.Context) (*OutboxStatus, error) {
+	if err := s.outboxRepo.Resume(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return s.Status(ctx)
+}
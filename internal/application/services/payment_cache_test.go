@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCache is a PaymentCache kept in a map, without expiry
+type memoryCache struct {
+	mu       sync.Mutex
+	payments map[string]domain.Payment
+}
+
+func (c *memoryCache) ByID(_ context.Context, merchantID, id string) (*domain.Payment, bool) {
+	return c.get("id:" + merchantID + ":" + id)
+}
+
+func (c *memoryCache) ByOrderID(_ context.Context, merchantID, orderID string) (*domain.Payment, bool) {
+	return c.get("order:" + merchantID + ":" + orderID)
+}
+
+func (c *memoryCache) StoreByID(_ context.Context, payment *domain.Payment) {
+	c.set("id:"+payment.MerchantID+":"+payment.ID, payment)
+}
+
+func (c *memoryCache) StoreByOrderID(_ context.Context, payment *domain.Payment) {
+	c.set("order:"+payment.MerchantID+":"+payment.OrderID, payment)
+}
+
+func (c *memoryCache) Invalidate(_ context.Context, merchantID, id, orderID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.payments, "id:"+merchantID+":"+id)
+	delete(c.payments, "order:"+merchantID+":"+orderID)
+}
+
+func (c *memoryCache) get(key string) (*domain.Payment, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	payment, ok := c.payments[key]
+	return &payment, ok
+}
+
+func (c *memoryCache) set(key string, payment *domain.Payment) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.payments[key] = *payment
+}
+
+func TestPaymentLookup_SeesUpdateOnceCommitted(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB).WithCache(&memoryCache{payments: map[string]domain.Payment{}})
+	mockBank := mocks.NewMockBankClient(t)
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		postgres.NewIdempotencyRepository(testDB.DB),
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	tests := []struct {
+		name   string
+		lookup func(ctx context.Context, p *domain.Payment) (*domain.Payment, error)
+	}{
+		{"by ID", func(ctx context.Context, p *domain.Payment) (*domain.Payment, error) {
+			return paymentRepo.LookupByID(ctx, p.ID)
+		}},
+		{"by order", func(ctx context.Context, p *domain.Payment) (*domain.Payment, error) {
+			return paymentRepo.LookupByOrderID(ctx, p.OrderID)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := testhelpers.CreateAuthorizedPayment(t, ctx, authService, mockBank)
+
+			tx, err := testDB.DB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+			require.NoError(t, err)
+			defer tx.Rollback(ctx) //nolint:errcheck // rolled back only if the test fails first
+
+			payment.Status = domain.StatusCaptured
+			require.NoError(t, paymentRepo.Update(ctx, tx, payment))
+
+			// A lookup while the update is uncommitted reads, and caches, the old row
+			during, err := tt.lookup(ctx, payment)
+			require.NoError(t, err)
+			assert.Equal(t, domain.StatusAuthorized, during.Status)
+
+			require.NoError(t, tx.Commit(ctx))
+
+			after, err := tt.lookup(ctx, payment)
+			require.NoError(t, err)
+			assert.Equal(t, domain.StatusCaptured, after.Status, "the commit drops the copy cached before it")
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+)
+
+// GroupPartCard is the card one part of a payment group is authorized on, and how much
+type GroupPartCard struct {
+	Amount      int64
+	CardNumber  string
+	CVV         string
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+type AuthorizeGroupCommand struct {
+	OrderID    string
+	CustomerID string
+	Currency   string
+	Parts      []GroupPartCard
+}
+
+func (c AuthorizeGroupCommand) hashFields() map[string]any {
+	parts := make([]map[string]any, len(c.Parts))
+	for i, part := range c.Parts {
+		parts[i] = map[string]any{
+			"amount":       part.Amount,
+			"card_number":  part.CardNumber,
+			"expiry_month": part.ExpiryMonth,
+			"expiry_year":  part.ExpiryYear,
+		}
+	}
+	return map[string]any{
+		"order_id":    c.OrderID,
+		"customer_id": c.CustomerID,
+		"currency":    c.Currency,
+		"parts":       parts,
+	}
+}
+
+// PaymentGroupService splits an order's amount across several cards. Each part is an
+// ordinary payment made through the same services as a single request, under an
+// idempotency key derived from the group's, so a request repeated after a crash resumes
+// the parts rather than repeating them.
+type PaymentGroupService struct {
+	groupRepo      *postgres.PaymentGroupRepository
+	paymentRepo    *postgres.PaymentRepository
+	authService    *AuthorizeService
+	captureService *CaptureService
+	voidService    *VoidService
+}
+
+func NewPaymentGroupService(
+	groupRepo *postgres.PaymentGroupRepository,
+	paymentRepo *postgres.PaymentRepository,
+	authService *AuthorizeService,
+	captureService *CaptureService,
+	voidService *VoidService,
+) *PaymentGroupService {
+	return &PaymentGroupService{
+		groupRepo:      groupRepo,
+		paymentRepo:    paymentRepo,
+		authService:    authService,
+		captureService: captureService,
+		voidService:    voidService,
+	}
+}
+
+// partKey is the idempotency key of one part's operation within a request on the group
+func partKey(idempotencyKey string, part int) string {
+	return fmt.Sprintf("%s-part-%d", idempotencyKey, part)
+}
+
+// Authorize authorizes the parts one after the other. If a part fails, the parts
+// authorized before it are voided, so the customer is not left with a hold for an
+// order that was not paid; the group is FAILED and the part's error is returned.
+func (s *PaymentGroupService) Authorize(ctx context.Context, cmd *AuthorizeGroupCommand, idempotencyKey string) (*domain.PaymentGroup, error) {
+	requestHash := ComputeHash(cmd)
+
+	group, err := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if err != nil {
+		return nil, err
+	}
+	if group == nil {
+		group, err = s.create(ctx, cmd, idempotencyKey, requestHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, part := range cmd.Parts {
+		authCmd := &AuthorizeCommand{
+			OrderID:     cmd.OrderID,
+			CustomerID:  cmd.CustomerID,
+			Amount:      part.Amount,
+			Currency:    cmd.Currency,
+			CardNumber:  part.CardNumber,
+			CVV:         part.CVV,
+			ExpiryMonth: part.ExpiryMonth,
+			ExpiryYear:  part.ExpiryYear,
+			GroupID:     group.ID,
+			GroupPart:   i + 1,
+		}
+
+		payment, err := s.authService.Authorize(ctx, authCmd, partKey(idempotencyKey, i+1))
+		if err != nil {
+			if payment != nil && payment.Status == domain.StatusPending {
+				// The bank's answer is not known yet; the retry worker settles the part
+				return nil, err
+			}
+			return nil, errors.Join(err, s.voidAuthorized(ctx, group.ID, idempotencyKey))
+		}
+	}
+
+	return s.Get(ctx, group.ID)
+}
+
+// create stores a new group. A concurrent request that stored one under the same key
+// first wins, and its group is returned.
+func (s *PaymentGroupService) create(ctx context.Context, cmd *AuthorizeGroupCommand, idempotencyKey string, requestHash RequestHash) (*domain.PaymentGroup, error) {
+	if err := s.authService.checkNewPayment(ctx, sumParts(cmd.Parts), cmd.Currency); err != nil {
+		return nil, err
+	}
+
+	amounts := make([]int64, 0, len(cmd.Parts))
+	for _, part := range cmd.Parts {
+		amounts = append(amounts, part.Amount)
+	}
+
+	group, err := domain.NewPaymentGroup(uuid.New().String(), cmd.OrderID, cmd.CustomerID, cmd.Currency, amounts, time.Now())
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	if err := s.groupRepo.Create(ctx, group, idempotencyKey, requestHash.String()); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	return group, nil
+}
+
+func sumParts(parts []GroupPartCard) int64 {
+	var total int64
+	for _, part := range parts {
+		total += part.Amount
+	}
+	return total
+}
+
+// voidAuthorized voids the parts of a group that failed to authorize in full
+func (s *PaymentGroupService) voidAuthorized(ctx context.Context, groupID, idempotencyKey string) error {
+	parts, err := s.paymentRepo.FindByGroupID(ctx, groupID)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+
+	var errs []error
+	for _, part := range parts {
+		if part.Status != domain.StatusAuthorized {
+			continue
+		}
+		if _, err := s.voidService.Void(ctx, part.ID, "", 0, partKey(idempotencyKey+"-void", *part.GroupPart)); err != nil {
+			errs = append(errs, fmt.Errorf("void part %d: %w", *part.GroupPart, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *PaymentGroupService) Get(ctx context.Context, id string) (*domain.PaymentGroup, error) {
+	group, err := s.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentGroupNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	group.Parts, err = s.paymentRepo.FindByGroupID(ctx, id)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return group, nil
+}
+
+// Capture captures every part of the group in full. The group must be AUTHORIZED as a
+// whole; a part that was captured already, such as by an earlier attempt of the same
+// request, is left as it is.
+func (s *PaymentGroupService) Capture(ctx context.Context, id, idempotencyKey string) (*domain.PaymentGroup, error) {
+	return s.apply(ctx, id, idempotencyKey, domain.StatusCaptured, func(part *domain.Payment, key string) error {
+		_, err := s.captureService.Capture(ctx, part.ID, part.AmountCents, key)
+		return err
+	})
+}
+
+// Void voids every part of the group, with reason recorded on each void
+func (s *PaymentGroupService) Void(ctx context.Context, id string, reason domain.OperationReason, idempotencyKey string) (*domain.PaymentGroup, error) {
+	if err := reason.Validate(); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	return s.apply(ctx, id, idempotencyKey, domain.StatusVoided, func(part *domain.Payment, key string) error {
+		_, err := s.voidService.Void(ctx, part.ID, reason, 0, key)
+		return err
+	})
+}
+
+// apply runs op on each part of the group that has not reached target yet. The group
+// must be AUTHORIZED, unless a repeat of the request finds some parts at target
+// already. The first part op fails on stops the rest; repeating the request resumes.
+func (s *PaymentGroupService) apply(
+	ctx context.Context,
+	id, idempotencyKey string,
+	target domain.PaymentStatus,
+	op func(part *domain.Payment, key string) error,
+) (*domain.PaymentGroup, error) {
+	group, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if status := group.Status(); status != domain.StatusAuthorized && status != target {
+		return nil, application.NewInvalidStateError(fmt.Errorf("%w: payment group is %s", domain.ErrInvalidTransition, status))
+	}
+
+	for _, part := range group.Parts {
+		if part.Status == target {
+			continue
+		}
+		if err := op(part, partKey(idempotencyKey, *part.GroupPart)); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.Get(ctx, id)
+}
+
+// findByIdempotencyKey returns the group created under the key, or nil if the key is
+// unused
+func (s *PaymentGroupService) findByIdempotencyKey(ctx context.Context, idempotencyKey string, requestHash RequestHash) (*domain.PaymentGroup, error) {
+	id, existingHash, err := s.groupRepo.FindIDByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentGroupNotFound) {
+			return nil, nil
+		}
+		return nil, application.NewInternalError(err)
+	}
+	if !requestHash.Matches(existingHash) {
+		return nil, application.NewIdempotencyMismatchError()
+	}
+
+	return s.Get(ctx, id)
+}
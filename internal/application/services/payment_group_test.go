@@ -0,0 +1,202 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PaymentGroupServiceTestSuite struct {
+	suite.Suite
+	testDB   *testhelpers.TestDatabase
+	mockBank *mocks.MockBankClient
+	service  *services.PaymentGroupService
+}
+
+func TestPaymentGroupServiceSuite(t *testing.T) {
+	suite.Run(t, new(PaymentGroupServiceTestSuite))
+}
+
+func (suite *PaymentGroupServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+}
+
+func (suite *PaymentGroupServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *PaymentGroupServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	paymentRepo := postgres.NewPaymentRepository(suite.testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	operationRepo := postgres.NewOperationRepository(suite.testDB.DB)
+	suite.service = services.NewPaymentGroupService(
+		postgres.NewPaymentGroupRepository(suite.testDB.DB),
+		paymentRepo,
+		services.NewAuthorizeService(paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{}),
+		services.NewCaptureService(paymentRepo, idempotencyRepo, operationRepo, suite.mockBank, suite.testDB.DB),
+		services.NewVoidService(paymentRepo, idempotencyRepo, operationRepo, suite.mockBank, suite.testDB.DB),
+	)
+}
+
+func (suite *PaymentGroupServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func giftCardAndCreditCard() *services.AuthorizeGroupCommand {
+	return &services.AuthorizeGroupCommand{
+		OrderID:    "order-" + uuid.New().String(),
+		CustomerID: "cust-456",
+		Currency:   "USD",
+		Parts: []services.GroupPartCard{
+			{Amount: 2000, CardNumber: "6011000000000004", CVV: "123", ExpiryMonth: 12, ExpiryYear: 2030},
+			{Amount: 3000, CardNumber: "4111111111111111", CVV: "123", ExpiryMonth: 12, ExpiryYear: 2030},
+		},
+	}
+}
+
+func (suite *PaymentGroupServiceTestSuite) expectAuthorize(idempotencyKey string, authID string) {
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.AuthorizationResponse{
+			Status:          "authorized",
+			AuthorizationID: authID,
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+}
+
+func (suite *PaymentGroupServiceTestSuite) Test_Authorize_ThenCaptureAll() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.expectAuthorize(idempotencyKey+"-part-1", "auth-1")
+	suite.expectAuthorize(idempotencyKey+"-part-2", "auth-2")
+
+	group, err := suite.service.Authorize(ctx, giftCardAndCreditCard(), idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, group.Status())
+	assert.Equal(t, int64(5000), group.AmountCents)
+	require.Len(t, group.Parts, 2)
+	assert.Equal(t, int64(2000), group.Parts[0].AmountCents)
+
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, req bank.CaptureRequest, _ string) (*bank.CaptureResponse, error) {
+			return &bank.CaptureResponse{
+				Amount:          req.Amount,
+				AuthorizationID: req.AuthorizationID,
+				Status:          "captured",
+				CaptureID:       "cap-" + req.AuthorizationID,
+				CapturedAt:      time.Now(),
+			}, nil
+		}).
+		Twice()
+
+	captured, err := suite.service.Capture(ctx, group.ID, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, captured.Status())
+	assert.Equal(t, int64(5000), captured.CapturedAmountCents())
+}
+
+func (suite *PaymentGroupServiceTestSuite) Test_Authorize_DeclinedRemainderVoidsFirstPart() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.expectAuthorize(idempotencyKey+"-part-1", "auth-1")
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey+"-part-2").
+		Return(nil, &bank.BankError{Code: "insufficient_funds", StatusCode: 402}).
+		Once()
+	suite.mockBank.EXPECT().
+		Void(mock.Anything, bank.VoidRequest{AuthorizationID: "auth-1"}, mock.Anything).
+		Return(&bank.VoidResponse{
+			AuthorizationID: "auth-1",
+			Status:          "voided",
+			VoidID:          "void-1",
+			VoidedAt:        time.Now(),
+		}, nil).
+		Once()
+
+	cmd := giftCardAndCreditCard()
+	_, err := suite.service.Authorize(ctx, cmd, idempotencyKey)
+	require.Error(t, err)
+
+	// The retry is answered from the parts without calling the bank again
+	_, err = suite.service.Authorize(ctx, cmd, idempotencyKey)
+	require.Error(t, err)
+}
+
+func (suite *PaymentGroupServiceTestSuite) Test_Void_RejectsCapturedGroup() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.expectAuthorize(idempotencyKey+"-part-1", "auth-1")
+	suite.expectAuthorize(idempotencyKey+"-part-2", "auth-2")
+
+	group, err := suite.service.Authorize(ctx, giftCardAndCreditCard(), idempotencyKey)
+	require.NoError(t, err)
+
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, req bank.CaptureRequest, _ string) (*bank.CaptureResponse, error) {
+			return &bank.CaptureResponse{
+				Amount:          req.Amount,
+				AuthorizationID: req.AuthorizationID,
+				Status:          "captured",
+				CaptureID:       "cap-" + req.AuthorizationID,
+				CapturedAt:      time.Now(),
+			}, nil
+		}).
+		Twice()
+
+	_, err = suite.service.Capture(ctx, group.ID, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+
+	_, err = suite.service.Void(ctx, group.ID, domain.ReasonCustomerRequest, "idem-"+uuid.New().String())
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+}
+
+func (suite *PaymentGroupServiceTestSuite) Test_FindByOrderID_ReturnsFirstPart() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.expectAuthorize(idempotencyKey+"-part-1", "auth-1")
+	suite.expectAuthorize(idempotencyKey+"-part-2", "auth-2")
+
+	cmd := giftCardAndCreditCard()
+	group, err := suite.service.Authorize(ctx, cmd, idempotencyKey)
+	require.NoError(t, err)
+	require.Len(t, group.Parts, 2)
+
+	// Both parts share the order ID; the second must not win even when it sorts first
+	_, err = suite.testDB.DB.Pool.Exec(ctx,
+		`UPDATE payments SET created_at = created_at - INTERVAL '1 minute' WHERE id = $1`, group.Parts[1].ID)
+	require.NoError(t, err)
+
+	payments := postgres.NewPaymentRepository(suite.testDB.DB)
+	for range 5 {
+		found, err := payments.FindByOrderID(ctx, cmd.OrderID)
+		require.NoError(t, err)
+		assert.Equal(t, group.Parts[0].ID, found.ID)
+	}
+}
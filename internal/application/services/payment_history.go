@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// recordedPayment is the part of a transition's payload a payment is rebuilt from.
+// Both the versioned payloads and the whole rows of older events name their fields so.
+type recordedPayment struct {
+	OrderID             string                  `json:"order_id"`
+	CustomerID          string                  `json:"customer_id"`
+	AmountCents         int64                   `json:"amount_cents"`
+	Currency            string                  `json:"currency"`
+	Status              domain.PaymentStatus    `json:"status"`
+	Acquirer            string                  `json:"acquirer"`
+	BankAuthID          *string                 `json:"bank_auth_id"`
+	BankCaptureID       *string                 `json:"bank_capture_id"`
+	BankVoidID          *string                 `json:"bank_void_id"`
+	BankRefundID        *string                 `json:"bank_refund_id"`
+	CapturedAmountCents int64                   `json:"captured_amount_cents"`
+	RefundedAmountCents int64                   `json:"refunded_amount_cents"`
+	ReleasedAmountCents int64                   `json:"released_amount_cents"`
+	FailureReason       *string                 `json:"failure_reason"`
+	DeclineCategory     *domain.DeclineCategory `json:"decline_category"`
+	PaymentMethodID     *string                 `json:"payment_method_id"`
+	AttemptCount        int                     `json:"attempt_count"`
+	AuthorizedAt        *time.Time              `json:"authorized_at"`
+	CapturedAt          *time.Time              `json:"captured_at"`
+	VoidedAt            *time.Time              `json:"voided_at"`
+	RefundedAt          *time.Time              `json:"refunded_at"`
+	ExpiresAt           *time.Time              `json:"expires_at"`
+}
+
+// PaymentHistoryService shows what the gateway believed of a payment at a moment in
+// the past, such as for evidence in a dispute, from the transitions in the outbox
+type PaymentHistoryService struct {
+	paymentRepo *postgres.PaymentRepository
+	outboxRepo  *postgres.OutboxRepository
+}
+
+func NewPaymentHistoryService(paymentRepo *postgres.PaymentRepository, outboxRepo *postgres.OutboxRepository) *PaymentHistoryService {
+	return &PaymentHistoryService{paymentRepo: paymentRepo, outboxRepo: outboxRepo}
+}
+
+// AsOf returns the payment of the merchant in ctx as it stood at at, right after the
+// last transition before it. Fields transitions do not record keep their present value
+// where they never change, such as the card, and are left out otherwise, such as the
+// retry schedule. It returns postgres.ErrPaymentNotFound for a payment created after at.
+func (s *PaymentHistoryService) AsOf(ctx context.Context, paymentID string, at time.Time) (*domain.Payment, error) {
+	payment, err := s.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.outboxRepo.FindByPaymentID(ctx, paymentID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	event := domain.LatestTransition(events, at)
+	if event == nil {
+		return nil, postgres.ErrPaymentNotFound
+	}
+
+	var recorded recordedPayment
+	if err := json.Unmarshal(event.Payload, &recorded); err != nil {
+		return nil, application.NewInternalError(fmt.Errorf("decode payload of event %s: %w", event.ID, err))
+	}
+
+	payment.OrderID = recorded.OrderID
+	payment.CustomerID = recorded.CustomerID
+	payment.AmountCents = recorded.AmountCents
+	payment.Currency = recorded.Currency
+	payment.Status = recorded.Status
+	payment.Acquirer = recorded.Acquirer
+	payment.BankAuthID = recorded.BankAuthID
+	payment.BankCaptureID = recorded.BankCaptureID
+	payment.BankVoidID = recorded.BankVoidID
+	payment.BankRefundID = recorded.BankRefundID
+	payment.CapturedAmountCents = recorded.CapturedAmountCents
+	payment.RefundedAmountCents = recorded.RefundedAmountCents
+	payment.ReleasedAmountCents = recorded.ReleasedAmountCents
+	payment.FailureReason = recorded.FailureReason
+	payment.DeclineCategory = recorded.DeclineCategory
+	payment.PaymentMethodID = recorded.PaymentMethodID
+	payment.AttemptCount = recorded.AttemptCount
+	payment.AuthorizedAt = recorded.AuthorizedAt
+	payment.CapturedAt = recorded.CapturedAt
+	payment.VoidedAt = recorded.VoidedAt
+	payment.RefundedAt = recorded.RefundedAt
+	payment.ExpiresAt = recorded.ExpiresAt
+
+	payment.NextRetryAt = nil
+	payment.LastErrorCategory = nil
+	if payment.FirstCapturedAt != nil && payment.FirstCapturedAt.After(at) {
+		payment.FirstCapturedAt = nil
+	}
+	return payment, nil
+}
@@ -0,0 +1,103 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type paymentHistoryServiceTestSuite struct {
+	suite.Suite
+	testDB           *testhelpers.TestDatabase
+	paymentRepo      *postgres.PaymentRepository
+	mockBank         *mocks.MockBankClient
+	authorizeService *services.AuthorizeService
+	captureService   *services.CaptureService
+	service          *services.PaymentHistoryService
+}
+
+func TestPaymentHistoryServiceSuite(t *testing.T) {
+	suite.Run(t, new(paymentHistoryServiceTestSuite))
+}
+
+func (suite *paymentHistoryServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *paymentHistoryServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *paymentHistoryServiceTestSuite) SetupTest() {
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+
+	suite.authorizeService = services.NewAuthorizeService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{},
+	)
+	suite.captureService = services.NewCaptureService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewOperationRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+	)
+	suite.service = services.NewPaymentHistoryService(suite.paymentRepo, postgres.NewOutboxRepository(suite.testDB.DB))
+}
+
+func (suite *paymentHistoryServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *paymentHistoryServiceTestSuite) Test_AsOf_ShowsThePaymentAsItStood() {
+	t := suite.T()
+	ctx := context.Background()
+	beforeCreation := time.Now().Add(-time.Second)
+
+	authorized := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+	betweenTransitions := time.Now()
+	// Transitions are stamped by the database clock
+	time.Sleep(10 * time.Millisecond)
+	suite.mockBank.EXPECT().Capture(mock.Anything, mock.Anything, "idem-capture").Return(&bank.CaptureResponse{
+		Amount:          authorized.AmountCents,
+		Currency:        authorized.Currency,
+		AuthorizationID: *authorized.BankAuthID,
+		Status:          "captured",
+		CaptureID:       "cap-123",
+		CapturedAt:      time.Now(),
+	}, nil).Once()
+	captured, err := suite.captureService.Capture(ctx, authorized.ID, 0, "idem-capture")
+	require.NoError(t, err)
+
+	then, err := suite.service.AsOf(ctx, authorized.ID, betweenTransitions)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, then.Status)
+	assert.Zero(t, then.CapturedAmountCents)
+	assert.Nil(t, then.CapturedAt)
+	assert.Equal(t, authorized.BankAuthID, then.BankAuthID)
+
+	now, err := suite.service.AsOf(ctx, authorized.ID, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, now.Status)
+	assert.Equal(t, captured.CapturedAmountCents, now.CapturedAmountCents)
+
+	_, err = suite.service.AsOf(ctx, authorized.ID, beforeCreation)
+	assert.ErrorIs(t, err, postgres.ErrPaymentNotFound)
+}
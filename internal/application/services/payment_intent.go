@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+)
+
+type CreatePaymentIntentCommand struct {
+	OrderID    string
+	CustomerID string
+	Amount     int64
+	Currency   string
+}
+
+// ConfirmPaymentIntentCommand carries the card a storefront confirms an intent with
+type ConfirmPaymentIntentCommand struct {
+	IntentID    string
+	ClientToken string
+	CardNumber  string
+	CVV         string
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+// PaymentIntentService lets the merchant price a payment and hand its confirmation to
+// the storefront. The merchant creates the intent with its API key and passes the
+// client token on; the storefront then confirms the intent with the card, which is
+// authorized like any other payment.
+type PaymentIntentService struct {
+	intentRepo  *postgres.PaymentIntentRepository
+	paymentRepo *postgres.PaymentRepository
+	authService *AuthorizeService
+	clock       clock.Clock
+}
+
+func NewPaymentIntentService(
+	intentRepo *postgres.PaymentIntentRepository,
+	paymentRepo *postgres.PaymentRepository,
+	authService *AuthorizeService,
+) *PaymentIntentService {
+	return &PaymentIntentService{
+		intentRepo:  intentRepo,
+		paymentRepo: paymentRepo,
+		authService: authService,
+		clock:       clock.System{},
+	}
+}
+
+// WithClock starts and expires intents by c rather than the system clock
+func (s *PaymentIntentService) WithClock(c clock.Clock) *PaymentIntentService {
+	s.clock = c
+	return s
+}
+
+// Create stores an intent awaiting confirmation. Its client token is returned in
+// ClientToken this once; only its hash is kept.
+func (s *PaymentIntentService) Create(ctx context.Context, cmd *CreatePaymentIntentCommand) (*domain.PaymentIntent, error) {
+	if err := s.authService.checkNewPayment(ctx, cmd.Amount, cmd.Currency); err != nil {
+		return nil, err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	clientToken := hex.EncodeToString(secret)
+
+	intent, err := domain.NewPaymentIntent(
+		uuid.New().String(),
+		cmd.OrderID,
+		cmd.CustomerID,
+		cmd.Amount,
+		cmd.Currency,
+		postgres.HashAPIKey(clientToken),
+		s.clock.Now(),
+	)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	if err := s.intentRepo.Create(ctx, intent); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	intent.ClientToken = clientToken
+	return intent, nil
+}
+
+func (s *PaymentIntentService) Get(ctx context.Context, id string) (*domain.PaymentIntent, error) {
+	intent, err := s.intentRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentIntentNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return intent, nil
+}
+
+// Confirm authorizes the intent's payment with the card given, for the merchant that
+// created the intent. A wrong client token gets the same answer as an unknown intent.
+// Confirming an intent again returns its payment. A declined card leaves the intent
+// open for another card until it expires.
+func (s *PaymentIntentService) Confirm(ctx context.Context, cmd *ConfirmPaymentIntentCommand) (*domain.Payment, error) {
+	intent, err := s.intentRepo.FindByClientToken(ctx, cmd.IntentID, cmd.ClientToken)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentIntentNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	ctx = postgres.WithMerchant(ctx, intent.MerchantID)
+
+	if intent.Status == domain.IntentConfirmed && intent.PaymentID != nil {
+		payment, err := s.paymentRepo.FindByID(ctx, *intent.PaymentID)
+		if err != nil {
+			return nil, application.NewInternalError(err)
+		}
+		return payment, nil
+	}
+
+	now := s.clock.Now()
+	if err := intent.CanConfirm(now); err != nil {
+		if errors.Is(err, domain.ErrPaymentIntentExpired) {
+			return nil, application.NewPaymentExpiredError(err)
+		}
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	intent, err = s.skipDeclined(ctx, intent)
+	if err != nil {
+		return nil, err
+	}
+
+	authCmd := &AuthorizeCommand{
+		OrderID:     intent.OrderID,
+		CustomerID:  intent.CustomerID,
+		Amount:      intent.AmountCents,
+		Currency:    intent.Currency,
+		CardNumber:  cmd.CardNumber,
+		CVV:         cmd.CVV,
+		ExpiryMonth: cmd.ExpiryMonth,
+		ExpiryYear:  cmd.ExpiryYear,
+	}
+
+	payment, err := s.authService.Authorize(ctx, authCmd, intent.IdempotencyKey())
+	if err != nil {
+		return payment, err
+	}
+	if payment.Status == domain.StatusFailed {
+		return payment, nil
+	}
+
+	if err := intent.Confirm(payment.ID, now); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+	if err := s.intentRepo.Confirm(ctx, intent); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return payment, nil
+}
+
+// skipDeclined moves the intent past its current confirmation if the bank declined it,
+// so this one is authorized as a new payment rather than answered with the decline
+func (s *PaymentIntentService) skipDeclined(ctx context.Context, intent *domain.PaymentIntent) (*domain.PaymentIntent, error) {
+	previous, err := s.paymentRepo.FindByIdempotencyKey(ctx, intent.IdempotencyKey())
+	if errors.Is(err, postgres.ErrPaymentNotFound) {
+		return intent, nil
+	}
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if previous.Status != domain.StatusFailed {
+		return intent, nil
+	}
+
+	if err := s.intentRepo.NextAttempt(ctx, intent); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	intent, err = s.intentRepo.FindByID(ctx, intent.ID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return intent, nil
+}
@@ -0,0 +1,151 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PaymentIntentServiceTestSuite struct {
+	suite.Suite
+	testDB      *testhelpers.TestDatabase
+	paymentRepo *postgres.PaymentRepository
+	mockBank    *mocks.MockBankClient
+	service     *services.PaymentIntentService
+}
+
+func TestPaymentIntentServiceSuite(t *testing.T) {
+	suite.Run(t, new(PaymentIntentServiceTestSuite))
+}
+
+func (suite *PaymentIntentServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *PaymentIntentServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *PaymentIntentServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.service = services.NewPaymentIntentService(
+		postgres.NewPaymentIntentRepository(suite.testDB.DB),
+		suite.paymentRepo,
+		services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{}),
+	)
+}
+
+func (suite *PaymentIntentServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *PaymentIntentServiceTestSuite) createIntent(ctx context.Context) *domain.PaymentIntent {
+	intent, err := suite.service.Create(ctx, &services.CreatePaymentIntentCommand{
+		OrderID:    "order-" + uuid.New().String(),
+		CustomerID: "cust-456",
+		Amount:     5000,
+		Currency:   "USD",
+	})
+	require.NoError(suite.T(), err)
+	require.NotEmpty(suite.T(), intent.ClientToken)
+	return intent
+}
+
+func confirmCommand(intent *domain.PaymentIntent, cardNumber string) *services.ConfirmPaymentIntentCommand {
+	return &services.ConfirmPaymentIntentCommand{
+		IntentID:    intent.ID,
+		ClientToken: intent.ClientToken,
+		CardNumber:  cardNumber,
+		CVV:         "123",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	}
+}
+
+func (suite *PaymentIntentServiceTestSuite) expectAuthorized() {
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, mock.Anything).
+		Return(&bank.AuthorizationResponse{
+			Amount:          5000,
+			Currency:        "USD",
+			Status:          "authorized",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+}
+
+func (suite *PaymentIntentServiceTestSuite) Test_Confirm_AuthorizesOnce() {
+	ctx := context.Background()
+	t := suite.T()
+	intent := suite.createIntent(ctx)
+	suite.expectAuthorized()
+
+	payment, err := suite.service.Confirm(ctx, confirmCommand(intent, "4111111111111111"))
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, payment.Status)
+	assert.Equal(t, intent.OrderID, payment.OrderID)
+	assert.Equal(t, intent.AmountCents, payment.AmountCents)
+
+	stored, err := suite.service.Get(ctx, intent.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.IntentConfirmed, stored.Status)
+	require.NotNil(t, stored.PaymentID)
+	assert.Equal(t, payment.ID, *stored.PaymentID)
+	assert.Empty(t, stored.ClientToken)
+
+	again, err := suite.service.Confirm(ctx, confirmCommand(intent, "4111111111111111"))
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, again.ID)
+}
+
+func (suite *PaymentIntentServiceTestSuite) Test_Confirm_WrongTokenIsNotFound() {
+	ctx := context.Background()
+	intent := suite.createIntent(ctx)
+
+	cmd := confirmCommand(intent, "4111111111111111")
+	cmd.ClientToken = "not-the-token"
+
+	_, err := suite.service.Confirm(ctx, cmd)
+	assert.ErrorIs(suite.T(), err, postgres.ErrPaymentIntentNotFound)
+}
+
+func (suite *PaymentIntentServiceTestSuite) Test_Confirm_DeclinedCardCanBeReplaced() {
+	ctx := context.Background()
+	t := suite.T()
+	intent := suite.createIntent(ctx)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &bank.BankError{Code: "insufficient_funds", StatusCode: 402}).
+		Once()
+
+	declined, err := suite.service.Confirm(ctx, confirmCommand(intent, "4000000000000002"))
+	require.Error(t, err)
+	require.NotNil(t, declined)
+	assert.Equal(t, domain.StatusFailed, declined.Status)
+
+	suite.expectAuthorized()
+
+	payment, err := suite.service.Confirm(ctx, confirmCommand(intent, "4111111111111111"))
+	require.NoError(t, err)
+	assert.NotEqual(t, declined.ID, payment.ID)
+	assert.Equal(t, domain.StatusAuthorized, payment.Status)
+}
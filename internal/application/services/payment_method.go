@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+)
+
+type SavePaymentMethodCommand struct {
+	CustomerID  string
+	CardNumber  string
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+// PaymentMethodService saves cards for merchant-initiated payments. Card numbers are
+// encrypted before they are stored and only decrypted to send an authorization.
+type PaymentMethodService struct {
+	paymentMethodRepo *postgres.PaymentMethodRepository
+	keyring           *vault.Keyring
+}
+
+func NewPaymentMethodService(
+	paymentMethodRepo *postgres.PaymentMethodRepository,
+	keyring *vault.Keyring,
+) *PaymentMethodService {
+	return &PaymentMethodService{
+		paymentMethodRepo: paymentMethodRepo,
+		keyring:           keyring,
+	}
+}
+
+func (s *PaymentMethodService) Save(ctx context.Context, cmd *SavePaymentMethodCommand) (*domain.PaymentMethod, error) {
+	pm, err := domain.NewPaymentMethod(
+		uuid.New().String(),
+		cmd.CustomerID,
+		cmd.CardNumber,
+		cmd.ExpiryMonth,
+		cmd.ExpiryYear,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	card, err := s.keyring.Encrypt([]byte(cmd.CardNumber))
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if err := s.paymentMethodRepo.Create(ctx, pm, card); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return pm, nil
+}
+
+func (s *PaymentMethodService) Get(ctx context.Context, id string) (*domain.PaymentMethod, error) {
+	pm, err := s.paymentMethodRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentMethodNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return pm, nil
+}
+
+// CardNumber decrypts the card number of a saved payment method. A card sealed with a
+// retired key is re-sealed with the primary key on the way.
+func (s *PaymentMethodService) CardNumber(ctx context.Context, id string) (string, error) {
+	card, err := s.paymentMethodRepo.FindCardCiphertext(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := s.keyring.Decrypt(card)
+	if err != nil {
+		return "", err
+	}
+
+	if s.keyring.NeedsRotation(card) {
+		// The card was read fine; failing to re-seal it only leaves it for the
+		// rotation command
+		if resealed, err := s.keyring.Encrypt(plaintext); err == nil {
+			_, _ = s.paymentMethodRepo.ReplaceCardCiphertext(ctx, id, card, resealed)
+		}
+	}
+
+	return string(plaintext), nil
+}
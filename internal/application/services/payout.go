@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type CreatePayoutCommand struct {
+	RecipientID   string
+	Purpose       domain.PayoutPurpose
+	PaymentID     string
+	Amount        int64
+	Currency      string
+	AccountNumber string
+	RoutingNumber string
+}
+
+func (c CreatePayoutCommand) hashFields() map[string]any {
+	return map[string]any{
+		"recipient_id":   c.RecipientID,
+		"purpose":        string(c.Purpose),
+		"payment_id":     c.PaymentID,
+		"amount":         c.Amount,
+		"currency":       c.Currency,
+		"account_number": c.AccountNumber,
+		"routing_number": c.RoutingNumber,
+	}
+}
+
+// PayoutService sends funds to recipients' bank accounts. A payout is stored PENDING
+// and its idempotency key locked before the bank is called, like an authorization, but
+// the account number is kept encrypted so an interrupted payout can be resent.
+type PayoutService struct {
+	payoutRepo      *postgres.PayoutRepository
+	paymentRepo     *postgres.PaymentRepository
+	idempotencyRepo *postgres.IdempotencyRepository
+	bankClient      bank.BankClient
+	keyring         *vault.Keyring
+	db              *postgres.DB
+}
+
+func NewPayoutService(
+	payoutRepo *postgres.PayoutRepository,
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	bankClient bank.BankClient,
+	keyring *vault.Keyring,
+	db *postgres.DB,
+) *PayoutService {
+	return &PayoutService{
+		payoutRepo:      payoutRepo,
+		paymentRepo:     paymentRepo,
+		idempotencyRepo: idempotencyRepo,
+		bankClient:      bankClient,
+		keyring:         keyring,
+		db:              db,
+	}
+}
+
+// Create stores the payout and sends it to the bank. A repeated request returns the
+// payout as it currently stands instead of waiting for it to settle, since payouts can
+// stay IN_TRANSIT for days.
+func (s *PayoutService) Create(ctx context.Context, cmd *CreatePayoutCommand, idempotencyKey string) (*domain.Payout, error) {
+	requestHash := ComputeHash(cmd)
+
+	existing, err := s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	payout, err := domain.NewPayout(
+		uuid.New().String(),
+		cmd.RecipientID,
+		cmd.Purpose,
+		cmd.PaymentID,
+		cmd.Amount,
+		cmd.Currency,
+		cmd.AccountNumber,
+		cmd.RoutingNumber,
+	)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	account, err := s.keyring.Encrypt([]byte(cmd.AccountNumber))
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if err := s.createPayout(ctx, payout, account, idempotencyKey, requestHash); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return s.findByIdempotencyKey(ctx, idempotencyKey, requestHash)
+		}
+		return nil, err
+	}
+
+	return s.send(ctx, payout, cmd.AccountNumber, idempotencyKey)
+}
+
+func (s *PayoutService) Get(ctx context.Context, id string) (*domain.Payout, error) {
+	payout, err := s.payoutRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPayoutNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return payout, nil
+}
+
+// ResumeStuck resends PENDING payouts whose bank call has not completed for longer
+// than olderThan, under their original idempotency key so the bank cannot pay twice.
+// It returns how many were resent.
+func (s *PayoutService) ResumeStuck(ctx context.Context, olderThan time.Duration, limit int) (int, error) {
+	stuck, err := s.payoutRepo.FindStuck(ctx, olderThan, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var resumed int
+	for _, sp := range stuck {
+		ctx := postgres.WithMerchant(ctx, sp.Payout.MerchantID)
+
+		account, err := s.payoutRepo.FindAccountCiphertext(ctx, sp.Payout.ID)
+		if err != nil {
+			return resumed, err
+		}
+		accountNumber, err := s.keyring.Decrypt(account)
+		if err != nil {
+			return resumed, err
+		}
+		if s.keyring.NeedsRotation(account) {
+			// Best effort: an account left on a retired key is picked up by the
+			// rotation command
+			if resealed, err := s.keyring.Encrypt(accountNumber); err == nil {
+				_, _ = s.payoutRepo.ReplaceAccountCiphertext(ctx, sp.Payout.ID, account, resealed)
+			}
+		}
+
+		if _, err := s.send(ctx, sp.Payout, string(accountNumber), sp.IdempotencyKey); err != nil {
+			if application.IsRetryable(err) {
+				continue
+			}
+			return resumed, err
+		}
+		resumed++
+	}
+
+	return resumed, nil
+}
+
+// Reconcile asks the bank about payouts still IN_TRANSIT and records the ones that
+// were paid or returned since. It returns how many changed status.
+func (s *PayoutService) Reconcile(ctx context.Context, limit int) (int, error) {
+	inTransit, err := s.payoutRepo.FindInTransit(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var settled int
+	for _, payout := range inTransit {
+		ctx := postgres.WithMerchant(ctx, payout.MerchantID)
+
+		resp, err := s.bankClient.GetPayout(ctx, *payout.BankPayoutID)
+		if err != nil {
+			if application.IsRetryable(err) {
+				continue
+			}
+			return settled, err
+		}
+
+		if err := applyPayoutResponse(payout, resp); err != nil {
+			return settled, err
+		}
+		if payout.Status == domain.PayoutInTransit {
+			continue
+		}
+
+		if err := s.update(ctx, payout); err != nil {
+			return settled, err
+		}
+		settled++
+	}
+
+	return settled, nil
+}
+
+// createPayout stores the payout and locks its idempotency key in one transaction. A
+// refund payout locks its payment so concurrent refunds cannot exceed what was captured.
+func (s *PayoutService) createPayout(
+	ctx context.Context,
+	payout *domain.Payout,
+	account vault.Sealed,
+	idempotencyKey string,
+	requestHash RequestHash,
+) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if payout.PaymentID != nil {
+		if err := s.checkRefund(ctx, tx, payout); err != nil {
+			return err
+		}
+	}
+
+	if err := s.payoutRepo.Create(ctx, tx, payout, account); err != nil {
+		if errors.Is(err, postgres.ErrPaymentNotFound) {
+			return err
+		}
+		return application.NewInternalError(err)
+	}
+
+	if err := s.idempotencyRepo.AcquirePayoutLock(ctx, tx, idempotencyKey, payout.ID, requestHash.String()); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// checkRefund makes sure a refund payout goes to the payment's customer and fits in the
+// captured amount not yet refunded, to the card or by earlier payouts
+func (s *PayoutService) checkRefund(ctx context.Context, tx pgx.Tx, payout *domain.Payout) error {
+	payment, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, *payout.PaymentID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentNotFound) {
+			return err
+		}
+		return application.NewInternalError(err)
+	}
+	if payment.CustomerID != payout.RecipientID || payment.Currency != payout.Currency {
+		return application.NewInvalidInputError(domain.ErrInvalidPayout)
+	}
+
+	refunded, err := s.payoutRepo.SumOpenRefunds(ctx, tx, payment.ID)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	if payout.AmountCents > payment.RefundableAmount()-refunded {
+		return application.NewInvalidInputError(domain.ErrInvalidAmount)
+	}
+
+	return nil
+}
+
+// send calls the bank and records the outcome. A declined payout is returned FAILED
+// rather than as an error, since it was created; transient failures leave it PENDING
+// with its key locked for ResumeStuck.
+func (s *PayoutService) send(ctx context.Context, payout *domain.Payout, accountNumber, idempotencyKey string) (*domain.Payout, error) {
+	bankReq := bank.PayoutRequest{
+		Amount:        payout.AmountCents,
+		Currency:      payout.Currency,
+		AccountNumber: accountNumber,
+		RoutingNumber: payout.RoutingNumber,
+	}
+
+	bankResp, bankErr := s.bankClient.Payout(ctx, bankReq, idempotencyKey)
+	if bankErr != nil {
+		if application.CategorizeError(bankErr) != application.CategoryPermanent {
+			return payout, bankErr
+		}
+		if err := payout.Fail(bankErrorCode(bankErr), time.Now()); err != nil {
+			return nil, application.NewInvalidStateError(err)
+		}
+		if err := s.settle(ctx, payout, idempotencyKey, bankErr); err != nil {
+			return payout, err
+		}
+		return payout, nil
+	}
+
+	if err := applyPayoutResponse(payout, bankResp); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+	if err := s.settle(ctx, payout, idempotencyKey, bankResp); err != nil {
+		return payout, err
+	}
+
+	return payout, nil
+}
+
+// settle stores the payout's new status and the bank response, and releases the key
+func (s *PayoutService) settle(ctx context.Context, payout *domain.Payout, idempotencyKey string, response any) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := s.payoutRepo.Update(ctx, tx, payout); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	responsePayload, err := json.Marshal(response)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	if err := s.idempotencyRepo.StoreResponse(ctx, tx, idempotencyKey, responsePayload); err != nil {
+		return application.NewInternalError(err)
+	}
+	if err := s.idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return application.NewInternalError(err)
+	}
+	return nil
+}
+
+func (s *PayoutService) update(ctx context.Context, payout *domain.Payout) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := s.payoutRepo.Update(ctx, tx, payout); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return application.NewInternalError(err)
+	}
+	return nil
+}
+
+// findByIdempotencyKey returns the payout bound to the key, or nil if the key is unused
+func (s *PayoutService) findByIdempotencyKey(ctx context.Context, idempotencyKey string, requestHash RequestHash) (*domain.Payout, error) {
+	existingKey, err := s.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if existingKey == nil {
+		return nil, nil
+	}
+	if !requestHash.Matches(existingKey.RequestHash) || existingKey.PayoutID == "" {
+		return nil, application.NewIdempotencyMismatchError()
+	}
+
+	return s.Get(ctx, existingKey.PayoutID)
+}
+
+// applyPayoutResponse moves the payout to the status the bank reported. A payout the
+// bank still reports as pending stays or becomes IN_TRANSIT.
+func applyPayoutResponse(payout *domain.Payout, resp *bank.PayoutResponse) error {
+	switch strings.ToLower(resp.Status) {
+	case "paid":
+		paidAt := time.Now()
+		if resp.PaidAt != nil {
+			paidAt = *resp.PaidAt
+		}
+		return payout.MarkPaid(resp.PayoutID, paidAt)
+	case "failed", "returned":
+		code := resp.FailureCode
+		if code == "" {
+			code = strings.ToLower(resp.Status)
+		}
+		return payout.Fail(code, time.Now())
+	default:
+		if payout.Status == domain.PayoutInTransit {
+			return nil
+		}
+		return payout.MarkInTransit(resp.PayoutID)
+	}
+}
+
+func bankErrorCode(err error) string {
+	if bankErr, ok := bank.IsBankError(err); ok {
+		return bankErr.Code
+	}
+	return "bank_error"
+}
@@ -0,0 +1,180 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PayoutServiceTestSuite struct {
+	suite.Suite
+	testDB     *testhelpers.TestDatabase
+	payoutRepo *postgres.PayoutRepository
+	mockBank   *mocks.MockBankClient
+	service    *services.PayoutService
+}
+
+func TestPayoutServiceSuite(t *testing.T) {
+	suite.Run(t, new(PayoutServiceTestSuite))
+}
+
+func (suite *PayoutServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.payoutRepo = postgres.NewPayoutRepository(suite.testDB.DB)
+}
+
+func (suite *PayoutServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *PayoutServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	keyring, err := vault.ParseKeyring("", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(suite.T(), err)
+
+	suite.service = services.NewPayoutService(
+		suite.payoutRepo,
+		postgres.NewPaymentRepository(suite.testDB.DB),
+		postgres.NewIdempotencyRepository(suite.testDB.DB),
+		suite.mockBank,
+		keyring,
+		suite.testDB.DB,
+	)
+}
+
+func (suite *PayoutServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func sellerPayoutCommand() *services.CreatePayoutCommand {
+	return &services.CreatePayoutCommand{
+		RecipientID:   "seller-" + uuid.New().String(),
+		Purpose:       domain.PurposeSellerPayout,
+		Amount:        2500,
+		Currency:      "USD",
+		AccountNumber: "000123456789",
+		RoutingNumber: "110000000",
+	}
+}
+
+func (suite *PayoutServiceTestSuite) Test_Create_InTransitThenReconciledPaid() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Payout(mock.Anything, mock.Anything, idempotencyKey).
+		Run(func(_ context.Context, req bank.PayoutRequest, _ string) {
+			assert.Equal(t, "000123456789", req.AccountNumber)
+			assert.Equal(t, int64(2500), req.Amount)
+		}).
+		Return(&bank.PayoutResponse{PayoutID: "bank-po-1", Amount: 2500, Currency: "USD", Status: "pending"}, nil).
+		Once()
+
+	payout, err := suite.service.Create(ctx, sellerPayoutCommand(), idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PayoutInTransit, payout.Status)
+	assert.Equal(t, "6789", payout.AccountLast4)
+
+	paidAt := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+	suite.mockBank.EXPECT().
+		GetPayout(mock.Anything, "bank-po-1").
+		Return(&bank.PayoutResponse{PayoutID: "bank-po-1", Status: "paid", PaidAt: &paidAt}, nil).
+		Once()
+
+	settled, err := suite.service.Reconcile(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, settled)
+
+	stored, err := suite.service.Get(ctx, payout.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PayoutPaid, stored.Status)
+	assert.True(t, paidAt.Equal(*stored.PaidAt))
+}
+
+func (suite *PayoutServiceTestSuite) Test_Create_DeclinedPayoutIsFailed() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Payout(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, &bank.BankError{Code: "invalid_account", StatusCode: 400}).
+		Once()
+
+	payout, err := suite.service.Create(ctx, sellerPayoutCommand(), idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PayoutFailed, payout.Status)
+	assert.Equal(t, "invalid_account", *payout.FailureCode)
+}
+
+func (suite *PayoutServiceTestSuite) Test_Create_SameKeyReturnsExistingPayout() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+	cmd := sellerPayoutCommand()
+
+	suite.mockBank.EXPECT().
+		Payout(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.PayoutResponse{PayoutID: "bank-po-1", Status: "pending"}, nil).
+		Once()
+
+	first, err := suite.service.Create(ctx, cmd, idempotencyKey)
+	require.NoError(t, err)
+
+	second, err := suite.service.Create(ctx, cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	other := sellerPayoutCommand()
+	_, err = suite.service.Create(ctx, other, idempotencyKey)
+	require.Error(t, err)
+}
+
+func (suite *PayoutServiceTestSuite) Test_ResumeStuck_ResendsWithSameKey() {
+	ctx := context.Background()
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Payout(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, &bank.BankError{Code: "internal_error", StatusCode: 503}).
+		Once()
+
+	payout, err := suite.service.Create(ctx, sellerPayoutCommand(), idempotencyKey)
+	require.Error(t, err)
+	require.NotNil(t, payout)
+	assert.Equal(t, domain.PayoutPending, payout.Status)
+
+	suite.mockBank.EXPECT().
+		Payout(mock.Anything, mock.Anything, idempotencyKey).
+		Run(func(_ context.Context, req bank.PayoutRequest, _ string) {
+			assert.Equal(t, "000123456789", req.AccountNumber)
+		}).
+		Return(&bank.PayoutResponse{PayoutID: "bank-po-1", Status: "pending"}, nil).
+		Once()
+
+	resumed, err := suite.service.ResumeStuck(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+
+	stored, err := suite.service.Get(ctx, payout.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PayoutInTransit, stored.Status)
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+type reauthorizeRequest struct {
+	PaymentID       string
+	PaymentMethodID string
+}
+
+func (r reauthorizeRequest) hashFields() map[string]any {
+	return map[string]any{"payment_id": r.PaymentID, "payment_method_id": r.PaymentMethodID}
+}
+
+// ReauthorizeService replaces the expired authorization of a payment with a fresh one,
+// charged to a saved card, so the order can still be captured without starting over.
+type ReauthorizeService struct {
+	paymentRepo     *postgres.PaymentRepository
+	idempotencyRepo *postgres.IdempotencyRepository
+	operationRepo   *postgres.OperationRepository
+	paymentMethods  *PaymentMethodService
+	bankClient      bank.BankClient
+	db              *postgres.DB
+	clock           clock.Clock
+}
+
+func NewReauthorizeService(
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	paymentMethods *PaymentMethodService,
+	bankClient bank.BankClient,
+	db *postgres.DB,
+) *ReauthorizeService {
+	return &ReauthorizeService{
+		paymentRepo:     paymentRepo,
+		idempotencyRepo: idempotencyRepo,
+		operationRepo:   operationRepo,
+		paymentMethods:  paymentMethods,
+		bankClient:      bankClient,
+		db:              db,
+		clock:           clock.System{},
+	}
+}
+
+// WithClock checks saved cards for expiry by c rather than the system clock
+func (s *ReauthorizeService) WithClock(c clock.Clock) *ReauthorizeService {
+	s.clock = c
+	return s
+}
+
+// Reauthorize asks the bank for a new authorization of an EXPIRED payment. The card is
+// the saved payment method the payment was made with, or paymentMethodID when given,
+// which must belong to the same customer. A declined reauthorization leaves the
+// payment EXPIRED.
+func (s *ReauthorizeService) Reauthorize(
+	ctx context.Context,
+	paymentID string,
+	paymentMethodID string,
+	idempotencyKey string,
+) (*domain.Payment, error) {
+	requestHash := ComputeHash(reauthorizeRequest{PaymentID: paymentID, PaymentMethodID: paymentMethodID})
+
+	cachedPayment, isCached, err := checkIdempotency(
+		ctx,
+		s.idempotencyRepo,
+		s.paymentRepo,
+		idempotencyKey,
+		requestHash,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if isCached {
+		return cachedPayment, nil
+	}
+
+	existing, err := s.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	paymentMethod, err := s.paymentMethodFor(ctx, existing, paymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The card is decrypted before the payment moves to REAUTHORIZING so a vault
+	// failure cannot leave it stuck there
+	cardNumber, err := s.paymentMethods.CardNumber(ctx, paymentMethod.ID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	payment, err := markPaymentTransitioning(
+		ctx,
+		s.db,
+		s.paymentRepo,
+		s.idempotencyRepo,
+		s.operationRepo,
+		paymentID,
+		idempotencyKey,
+		requestHash,
+		"",
+		func(p *domain.Payment) (int64, error) {
+			if err := p.MarkReauthorizing(); err != nil {
+				return 0, err
+			}
+			p.PaymentMethodID = &paymentMethod.ID
+			return p.AmountCents, nil
+		},
+		nil,
+	)
+	if err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, nil)
+		}
+		return nil, err
+	}
+
+	bankReq := bank.AuthorizationRequest{
+		Amount:      payment.AmountCents,
+		CardNumber:  cardNumber,
+		ExpiryMonth: paymentMethod.ExpiryMonth,
+		ExpiryYear:  paymentMethod.ExpiryYear,
+	}
+
+	bankResp, err := s.bankClient.Authorize(ctx, bankReq, idempotencyKey)
+	if err != nil {
+		return payment, HandleBankFailure(
+			ctx,
+			s.db,
+			s.paymentRepo,
+			s.idempotencyRepo,
+			s.operationRepo,
+			payment,
+			idempotencyKey,
+			err,
+		)
+	}
+
+	if bankResp.Acquirer != "" {
+		payment.Acquirer = bankResp.Acquirer
+	}
+	if err := payment.Authorize(bankResp.AuthorizationID, bankResp.CreatedAt, bankResp.ExpiresAt); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, s.operationRepo, payment, idempotencyKey, bankResp); err != nil {
+		return payment, err
+	}
+
+	return payment, nil
+}
+
+// paymentMethodFor picks the card to reauthorize with and checks it can still be charged
+func (s *ReauthorizeService) paymentMethodFor(
+	ctx context.Context,
+	payment *domain.Payment,
+	paymentMethodID string,
+) (*domain.PaymentMethod, error) {
+	if paymentMethodID == "" {
+		if payment.PaymentMethodID == nil {
+			return nil, application.NewInvalidInputError(domain.ErrInvalidPaymentMethod)
+		}
+		paymentMethodID = *payment.PaymentMethodID
+	}
+
+	paymentMethod, err := s.paymentMethods.Get(ctx, paymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentMethod.CustomerID != payment.CustomerID {
+		return nil, application.NewInvalidInputError(domain.ErrInvalidPaymentMethod)
+	}
+	if paymentMethod.IsExpired(s.clock.Now()) {
+		return nil, application.NewInvalidInputError(domain.ErrPaymentMethodExpired)
+	}
+
+	return paymentMethod, nil
+}
@@ -0,0 +1,223 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReauthorizeServiceTestSuite struct {
+	suite.Suite
+	testDB         *testhelpers.TestDatabase
+	paymentRepo    *postgres.PaymentRepository
+	operationRepo  *postgres.OperationRepository
+	mockBank       *mocks.MockBankClient
+	paymentMethods *services.PaymentMethodService
+	authService    *services.AuthorizeService
+	service        *services.ReauthorizeService
+}
+
+func TestReauthorizeServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReauthorizeServiceTestSuite))
+}
+
+func (suite *ReauthorizeServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+	suite.operationRepo = postgres.NewOperationRepository(suite.testDB.DB)
+}
+
+func (suite *ReauthorizeServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *ReauthorizeServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	keyring, err := vault.ParseKeyring("", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(suite.T(), err)
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.paymentMethods = services.NewPaymentMethodService(postgres.NewPaymentMethodRepository(suite.testDB.DB), keyring)
+	suite.authService = services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{})
+	suite.service = services.NewReauthorizeService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		suite.operationRepo,
+		suite.paymentMethods,
+		suite.mockBank,
+		suite.testDB.DB,
+	)
+}
+
+func (suite *ReauthorizeServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+// saveCard saves a card for the customer
+func (suite *ReauthorizeServiceTestSuite) saveCard(ctx context.Context, customerID string) *domain.PaymentMethod {
+	pm, err := suite.paymentMethods.Save(ctx, &services.SavePaymentMethodCommand{
+		CustomerID:  customerID,
+		CardNumber:  "4111111111111111",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	})
+	require.NoError(suite.T(), err)
+	return pm
+}
+
+// authorizedPayment authorizes a payment on a saved card
+func (suite *ReauthorizeServiceTestSuite) authorizedPayment(ctx context.Context, pm *domain.PaymentMethod) *domain.Payment {
+	t := suite.T()
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.AuthorizationResponse{
+			Amount:          5000,
+			Currency:        "USD",
+			Status:          "authorized",
+			AuthorizationID: "auth-old",
+			CreatedAt:       time.Now().Add(-8 * 24 * time.Hour),
+			ExpiresAt:       time.Now().Add(-24 * time.Hour),
+		}, nil).
+		Once()
+
+	payment, err := suite.authService.Authorize(ctx, &services.AuthorizeCommand{
+		OrderID:         "order-" + uuid.New().String(),
+		CustomerID:      pm.CustomerID,
+		Amount:          5000,
+		Currency:        "USD",
+		CardNumber:      "4111111111111111",
+		CVV:             "123",
+		ExpiryMonth:     pm.ExpiryMonth,
+		ExpiryYear:      pm.ExpiryYear,
+		PaymentMethodID: pm.ID,
+	}, idempotencyKey)
+	require.NoError(t, err)
+
+	return payment
+}
+
+// expiredPayment authorizes a payment on a saved card and lets its authorization expire
+func (suite *ReauthorizeServiceTestSuite) expiredPayment(ctx context.Context, pm *domain.PaymentMethod) *domain.Payment {
+	t := suite.T()
+	payment := suite.authorizedPayment(ctx, pm)
+
+	require.NoError(t, payment.MarkExpired())
+	require.NoError(t, suite.paymentRepo.Update(ctx, nil, payment))
+
+	return payment
+}
+
+func (suite *ReauthorizeServiceTestSuite) Test_Reauthorize_WithOriginalCard() {
+	ctx := context.Background()
+	t := suite.T()
+	pm := suite.saveCard(ctx, "cust-"+uuid.New().String())
+	payment := suite.expiredPayment(ctx, pm)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Run(func(_ context.Context, req bank.AuthorizationRequest, _ string) {
+			assert.Equal(t, "4111111111111111", req.CardNumber)
+			assert.Equal(t, payment.AmountCents, req.Amount)
+			assert.Empty(t, req.Cvv)
+		}).
+		Return(&bank.AuthorizationResponse{
+			Amount:          payment.AmountCents,
+			Currency:        payment.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-new",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+
+	reauthorized, err := suite.service.Reauthorize(ctx, payment.ID, "", idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ID, reauthorized.ID)
+	assert.Equal(t, domain.StatusAuthorized, reauthorized.Status)
+
+	stored, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, stored.Status)
+	assert.Equal(t, "auth-new", *stored.BankAuthID)
+	assert.True(t, stored.ExpiresAt.After(time.Now()))
+
+	operation, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationReauthorize, operation.Type)
+	assert.Equal(t, domain.OperationSucceeded, operation.Status)
+	assert.Equal(t, "auth-new", *operation.BankReferenceID)
+}
+
+func (suite *ReauthorizeServiceTestSuite) Test_Reauthorize_DeclineLeavesPaymentExpired() {
+	ctx := context.Background()
+	t := suite.T()
+	pm := suite.saveCard(ctx, "cust-"+uuid.New().String())
+	payment := suite.expiredPayment(ctx, pm)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, idempotencyKey).
+		Return(nil, &bank.BankError{Code: "insufficient_funds", StatusCode: 402}).
+		Once()
+
+	_, err := suite.service.Reauthorize(ctx, payment.ID, "", idempotencyKey)
+	require.Error(t, err)
+
+	stored, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusExpired, stored.Status)
+	assert.Equal(t, "auth-old", *stored.BankAuthID)
+
+	operation, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationFailed, operation.Status)
+}
+
+func (suite *ReauthorizeServiceTestSuite) Test_Reauthorize_RejectsAnotherCustomersCard() {
+	ctx := context.Background()
+	t := suite.T()
+	pm := suite.saveCard(ctx, "cust-"+uuid.New().String())
+	payment := suite.expiredPayment(ctx, pm)
+	other := suite.saveCard(ctx, "cust-"+uuid.New().String())
+
+	_, err := suite.service.Reauthorize(ctx, payment.ID, other.ID, "idem-"+uuid.New().String())
+	require.Error(t, err)
+
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+}
+
+func (suite *ReauthorizeServiceTestSuite) Test_Reauthorize_RequiresExpiredPayment() {
+	ctx := context.Background()
+	t := suite.T()
+	pm := suite.saveCard(ctx, "cust-"+uuid.New().String())
+	payment := suite.authorizedPayment(ctx, pm)
+
+	_, err := suite.service.Reauthorize(ctx, payment.ID, "", "idem-"+uuid.New().String())
+	require.Error(t, err)
+
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidState, svcErr.Code)
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+)
+
+// Reconciliation is the outcome of one reconciliation run. NextFrom is set when the
+// period held more than one run checks; reconciling again from it covers the rest.
+type Reconciliation struct {
+	From     time.Time
+	To       time.Time
+	Checked  int
+	NextFrom time.Time
+	Issues   []*domain.ReconciliationIssue
+}
+
+// ReconciliationService compares what the gateway recorded with what the bank says
+// about the same authorizations and records every disagreement. The bank can only be
+// asked about one authorization at a time, so authorizations the gateway lacks are
+// found through the attempts ledger, which keeps every one the bank granted. Those
+// granted for a payment that failed are orphaned and voided, as nothing will ever
+// capture them.
+type ReconciliationService struct {
+	paymentRepo *postgres.PaymentRepository
+	attemptRepo *postgres.BankAttemptRepository
+	issueRepo   *postgres.ReconciliationRepository
+	bankClient  bank.BankClient
+	batchSize   int
+}
+
+func NewReconciliationService(
+	paymentRepo *postgres.PaymentRepository,
+	attemptRepo *postgres.BankAttemptRepository,
+	issueRepo *postgres.ReconciliationRepository,
+	bankClient bank.BankClient,
+	batchSize int,
+) *ReconciliationService {
+	return &ReconciliationService{
+		paymentRepo: paymentRepo,
+		attemptRepo: attemptRepo,
+		issueRepo:   issueRepo,
+		bankClient:  bankClient,
+		batchSize:   batchSize,
+	}
+}
+
+// Reconcile checks with the bank the payments of the merchant in ctx created in
+// [from, to) and the authorizations granted in it, up to batchSize of each, and
+// records the issues it finds. Issues found before a bank call fails stay recorded,
+// so reconciling the same period again picks up where it stopped. The payments are
+// leased for the run, so a run overlapping it, such as another instance's worker,
+// leaves them out rather than asking the bank about them twice.
+func (s *ReconciliationService) Reconcile(ctx context.Context, from, to time.Time) (*Reconciliation, error) {
+	if err := domain.CheckReconciliationRange(from, to); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	ctx = postgres.WithActor(ctx, domain.ActorReconciler)
+
+	statuses := domain.ReconciledStatuses()
+	payments, err := s.paymentRepo.ClaimForReconciliation(ctx, from, to, statuses, s.batchSize+1, domain.ReconciliationLease)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer s.releaseLeases(ctx, payments)
+	granted, err := s.attemptRepo.FindUnclaimedAuthorizations(ctx, from, to, statuses, s.batchSize+1)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	// When either list is cut short, both stop where the earlier one was cut so the
+	// next run starts with nothing skipped
+	result := &Reconciliation{From: from, To: to, Issues: []*domain.ReconciliationIssue{}}
+	end := to
+	if len(payments) > s.batchSize && payments[s.batchSize].CreatedAt.Before(end) {
+		end = payments[s.batchSize].CreatedAt
+	}
+	if len(granted) > s.batchSize && granted[s.batchSize].GrantedAt.Before(end) {
+		end = granted[s.batchSize].GrantedAt
+	}
+	if end.Before(to) {
+		result.NextFrom = end
+	}
+
+	now := time.Now()
+	for _, payment := range payments {
+		if !payment.CreatedAt.Before(end) {
+			break
+		}
+		issue, err := s.checkPayment(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+		result.Checked++
+		if issue != nil {
+			if err := s.record(ctx, issue, now); err != nil {
+				return nil, err
+			}
+			result.Issues = append(result.Issues, issue)
+		}
+	}
+
+	for _, auth := range granted {
+		if !auth.GrantedAt.Before(end) {
+			break
+		}
+		issue, err := s.checkUnclaimed(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		result.Checked++
+		if issue != nil {
+			if err := s.record(ctx, issue, now); err != nil {
+				return nil, err
+			}
+			if issue.Kind == domain.IssueOrphanedAuthorization && issue.VoidedAt == nil {
+				if err := s.release(ctx, auth.Acquirer, issue); err != nil {
+					return nil, err
+				}
+			}
+			result.Issues = append(result.Issues, issue)
+		}
+	}
+
+	return result, nil
+}
+
+// Issues returns up to limit issues of the merchant in ctx detected since since, most
+// recent first
+func (s *ReconciliationService) Issues(ctx context.Context, since time.Time, limit int) ([]*domain.ReconciliationIssue, error) {
+	issues, err := s.issueRepo.FindDetectedSince(ctx, since, limit)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return issues, nil
+}
+
+func (s *ReconciliationService) checkPayment(ctx context.Context, payment *domain.Payment) (*domain.ReconciliationIssue, error) {
+	issue := &domain.ReconciliationIssue{
+		BankAuthID:    *payment.BankAuthID,
+		PaymentID:     payment.ID,
+		GatewayStatus: payment.Status,
+	}
+
+	bankStatus, found, err := s.bankStatus(ctx, payment.Acquirer, *payment.BankAuthID)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		issue.Kind = domain.IssueMissingAtBank
+		return issue, nil
+	}
+	if domain.AgreesWithBank(payment.Status, bankStatus) {
+		return nil, nil
+	}
+	issue.Kind = domain.IssueStatusMismatch
+	issue.BankStatus = bankStatus
+	return issue, nil
+}
+
+func (s *ReconciliationService) checkUnclaimed(ctx context.Context, auth postgres.GrantedAuthorization) (*domain.ReconciliationIssue, error) {
+	bankStatus, found, err := s.bankStatus(ctx, auth.Acquirer, auth.BankAuthID)
+	if err != nil {
+		return nil, err
+	}
+	// An authorization that lapsed or was voided no longer holds the customer's money
+	if !found || !domain.HoldsFunds(bankStatus) {
+		return nil, nil
+	}
+	kind := domain.IssueUnknownToGateway
+	if auth.PaymentStatus == domain.StatusFailed {
+		kind = domain.IssueOrphanedAuthorization
+	}
+	return &domain.ReconciliationIssue{
+		Kind:          kind,
+		BankAuthID:    auth.BankAuthID,
+		PaymentID:     auth.PaymentID,
+		GatewayStatus: auth.PaymentStatus,
+		BankStatus:    bankStatus,
+	}, nil
+}
+
+// releaseLeases hands back the payments a run claimed. A lease it fails to drop runs
+// out on its own, so the failure only delays the next run's check of them.
+func (s *ReconciliationService) releaseLeases(ctx context.Context, payments []*domain.Payment) {
+	ids := make([]string, len(payments))
+	for i, payment := range payments {
+		ids[i] = payment.ID
+	}
+	_ = s.paymentRepo.ReleaseReconciliation(ctx, ids)
+}
+
+// release voids an orphaned authorization at the acquirer that granted it. The key is
+// derived from the authorization, so a run that fails after the bank voided it voids
+// it again harmlessly.
+func (s *ReconciliationService) release(ctx context.Context, acquirer string, issue *domain.ReconciliationIssue) error {
+	resp, err := s.bankClient.Void(
+		bank.WithAcquirer(ctx, acquirer),
+		bank.VoidRequest{AuthorizationID: issue.BankAuthID},
+		"orphan-void-"+issue.BankAuthID,
+	)
+	if err != nil {
+		return application.NewInternalError(fmt.Errorf("void orphaned authorization %s: %w", issue.BankAuthID, err))
+	}
+
+	voidedAt := resp.VoidedAt
+	if voidedAt.IsZero() {
+		voidedAt = time.Now()
+	}
+	if err := s.issueRepo.MarkVoided(ctx, issue.ID, voidedAt); err != nil {
+		return application.NewInternalError(err)
+	}
+	issue.VoidedAt = &voidedAt
+	return nil
+}
+
+// bankStatus asks the acquirer that granted an authorization for its status. found is
+// false when the bank does not know it; an authorization that expired is reported as
+// EXPIRED.
+func (s *ReconciliationService) bankStatus(ctx context.Context, acquirer, authID string) (string, bool, error) {
+	auth, err := s.bankClient.GetAuthorization(bank.WithAcquirer(ctx, acquirer), authID)
+	if err == nil {
+		return strings.ToUpper(auth.Status), true, nil
+	}
+
+	if bankErr, ok := bank.IsBankError(err); ok {
+		switch {
+		case bankErr.Code == "authorization_expired":
+			return string(domain.StatusExpired), true, nil
+		case bankErr.StatusCode == http.StatusNotFound:
+			return "", false, nil
+		}
+	}
+	return "", false, application.NewInternalError(fmt.Errorf("get authorization %s: %w", authID, err))
+}
+
+func (s *ReconciliationService) record(ctx context.Context, issue *domain.ReconciliationIssue, detectedAt time.Time) error {
+	issue.ID = uuid.New().String()
+	issue.LastDetectedAt = detectedAt
+	if err := s.issueRepo.Record(ctx, issue); err != nil {
+		return application.NewInternalError(err)
+	}
+	return nil
+}
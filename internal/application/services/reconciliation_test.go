@@ -0,0 +1,264 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type reconciliationServiceTestSuite struct {
+	suite.Suite
+	testDB           *testhelpers.TestDatabase
+	paymentRepo      *postgres.PaymentRepository
+	mockBank         *mocks.MockBankClient
+	authorizeService *services.AuthorizeService
+	captureService   *services.CaptureService
+}
+
+func TestReconciliationServiceSuite(t *testing.T) {
+	suite.Run(t, new(reconciliationServiceTestSuite))
+}
+
+func (suite *reconciliationServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *reconciliationServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *reconciliationServiceTestSuite) SetupTest() {
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+
+	suite.authorizeService = services.NewAuthorizeService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+		services.AuthorizeLimits{},
+	)
+	suite.captureService = services.NewCaptureService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewOperationRepository(suite.testDB.DB),
+		suite.mockBank,
+		suite.testDB.DB,
+	)
+}
+
+func (suite *reconciliationServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *reconciliationServiceTestSuite) newService(batchSize int) *services.ReconciliationService {
+	return services.NewReconciliationService(
+		suite.paymentRepo,
+		postgres.NewBankAttemptRepository(suite.testDB.DB),
+		postgres.NewReconciliationRepository(suite.testDB.DB),
+		suite.mockBank,
+		batchSize,
+	)
+}
+
+func (suite *reconciliationServiceTestSuite) bankSays(authID, status string) {
+	suite.mockBank.EXPECT().
+		GetAuthorization(mock.Anything, authID).
+		Return(&bank.AuthorizationResponse{AuthorizationID: authID, Status: status}, nil)
+}
+
+// recordGrant adds an authorization the bank granted for paymentID to the attempts
+// ledger, as the recording client would
+func (suite *reconciliationServiceTestSuite) recordGrant(paymentID, authID string) {
+	_, err := suite.testDB.DB.Pool.Exec(context.Background(), `
+		INSERT INTO bank_attempts (id, payment_id, operation, status_code, latency_ms, response_payload, attempted_at)
+		VALUES ($1, $2, 'AUTHORIZE', 200, 10, jsonb_build_object('authorization_id', $3::text, 'status', 'AUTHORIZED'), NOW())
+	`, uuid.New().String(), paymentID, authID)
+	require.NoError(suite.T(), err)
+}
+
+func (suite *reconciliationServiceTestSuite) Test_Reconcile_RecordsDisagreements() {
+	t := suite.T()
+	ctx := context.Background()
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	agreeing := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+	voidedAtBank := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	unknownAtBank := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+
+	suite.bankSays(*agreeing.BankAuthID, "authorized")
+	suite.bankSays(*voidedAtBank.BankAuthID, "VOIDED")
+	suite.mockBank.EXPECT().
+		GetAuthorization(mock.Anything, *unknownAtBank.BankAuthID).
+		Return(nil, &bank.BankError{Code: "not_found", StatusCode: 404})
+
+	// The bank granted two more authorizations for the first payment that it never saved
+	suite.recordGrant(agreeing.ID, "auth-lost")
+	suite.bankSays("auth-lost", "AUTHORIZED")
+	suite.recordGrant(agreeing.ID, "auth-lapsed")
+	suite.mockBank.EXPECT().
+		GetAuthorization(mock.Anything, "auth-lapsed").
+		Return(nil, &bank.BankError{Code: "authorization_expired", StatusCode: 400})
+
+	result, err := suite.newService(100).Reconcile(ctx, from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, result.Checked)
+	assert.True(t, result.NextFrom.IsZero())
+	require.Len(t, result.Issues, 3)
+
+	byKind := map[domain.ReconciliationIssueKind]*domain.ReconciliationIssue{}
+	for _, issue := range result.Issues {
+		byKind[issue.Kind] = issue
+	}
+
+	mismatch := byKind[domain.IssueStatusMismatch]
+	require.NotNil(t, mismatch)
+	assert.Equal(t, voidedAtBank.ID, mismatch.PaymentID)
+	assert.Equal(t, domain.StatusCaptured, mismatch.GatewayStatus)
+	assert.Equal(t, "VOIDED", mismatch.BankStatus)
+
+	missing := byKind[domain.IssueMissingAtBank]
+	require.NotNil(t, missing)
+	assert.Equal(t, *unknownAtBank.BankAuthID, missing.BankAuthID)
+	assert.Empty(t, missing.BankStatus)
+
+	unknown := byKind[domain.IssueUnknownToGateway]
+	require.NotNil(t, unknown)
+	assert.Equal(t, "auth-lost", unknown.BankAuthID)
+	assert.Equal(t, agreeing.ID, unknown.PaymentID)
+	assert.Equal(t, "AUTHORIZED", unknown.BankStatus)
+
+	// Found again, the issues keep their records
+	again, err := suite.newService(100).Reconcile(ctx, from, to)
+	require.NoError(t, err)
+	require.Len(t, again.Issues, 3)
+
+	issues, err := suite.newService(100).Issues(ctx, from, 10)
+	require.NoError(t, err)
+	require.Len(t, issues, 3)
+	for _, issue := range issues {
+		assert.Equal(t, byKind[issue.Kind].ID, issue.ID)
+		assert.True(t, issue.LastDetectedAt.After(issue.FirstDetectedAt))
+	}
+}
+
+func (suite *reconciliationServiceTestSuite) Test_Reconcile_VoidsOrphanedAuthorizations() {
+	t := suite.T()
+	ctx := context.Background()
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	// The gateway crashed after the bank authorized but before saving it, and the
+	// payment was later timed out as FAILED
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	payment, created, err := suite.authorizeService.BeginAuthorize(ctx, &cmd, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+	require.True(t, created)
+	_, err = suite.testDB.DB.Pool.Exec(ctx, `UPDATE payments SET status = 'FAILED' WHERE id = $1`, payment.ID)
+	require.NoError(t, err)
+	suite.recordGrant(payment.ID, "auth-orphan")
+
+	suite.bankSays("auth-orphan", "AUTHORIZED")
+	voidedAt := time.Now().Truncate(time.Microsecond)
+	suite.mockBank.EXPECT().
+		Void(mock.Anything, bank.VoidRequest{AuthorizationID: "auth-orphan"}, "orphan-void-auth-orphan").
+		Return(&bank.VoidResponse{AuthorizationID: "auth-orphan", Status: "VOIDED", VoidedAt: voidedAt}, nil).
+		Once()
+
+	result, err := suite.newService(100).Reconcile(ctx, from, to)
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+
+	issue := result.Issues[0]
+	assert.Equal(t, domain.IssueOrphanedAuthorization, issue.Kind)
+	assert.Equal(t, payment.ID, issue.PaymentID)
+	assert.Equal(t, domain.StatusFailed, issue.GatewayStatus)
+	require.NotNil(t, issue.VoidedAt)
+	assert.True(t, voidedAt.Equal(*issue.VoidedAt))
+
+	issues, err := suite.newService(100).Issues(ctx, from, 10)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	require.NotNil(t, issues[0].VoidedAt)
+	assert.True(t, voidedAt.Equal(*issues[0].VoidedAt))
+}
+
+func (suite *reconciliationServiceTestSuite) Test_Reconcile_ContinuesFromWhereABatchStopped() {
+	t := suite.T()
+	ctx := context.Background()
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	first := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+	second := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+	suite.bankSays(*first.BankAuthID, "AUTHORIZED")
+	suite.bankSays(*second.BankAuthID, "AUTHORIZED")
+
+	service := suite.newService(1)
+
+	result, err := service.Reconcile(ctx, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Checked)
+	require.False(t, result.NextFrom.IsZero())
+
+	rest, err := service.Reconcile(ctx, result.NextFrom, to)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rest.Checked)
+	assert.True(t, rest.NextFrom.IsZero())
+	assert.Empty(t, rest.Issues)
+}
+
+func (suite *reconciliationServiceTestSuite) Test_Reconcile_SkipsPaymentsAnotherRunHolds() {
+	t := suite.T()
+	ctx := context.Background()
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	payment := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+
+	// Another instance's run is checking the payment
+	held, err := suite.paymentRepo.ClaimForReconciliation(ctx, from, to, domain.ReconciledStatuses(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, held, 1)
+
+	result, err := suite.newService(100).Reconcile(ctx, from, to)
+	require.NoError(t, err)
+	assert.Zero(t, result.Checked)
+
+	require.NoError(t, suite.paymentRepo.ReleaseReconciliation(ctx, []string{payment.ID}))
+	suite.bankSays(*payment.BankAuthID, "AUTHORIZED")
+
+	result, err = suite.newService(100).Reconcile(ctx, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Checked)
+}
+
+func (suite *reconciliationServiceTestSuite) Test_Reconcile_RejectsInvalidRange() {
+	t := suite.T()
+	now := time.Now()
+
+	for _, r := range [][2]time.Time{
+		{now, now},
+		{now, now.Add(-time.Hour)},
+		{now.Add(-32 * 24 * time.Hour), now},
+	} {
+		_, err := suite.newService(100).Reconcile(context.Background(), r[0], r[1])
+
+		svcErr, ok := application.IsServiceError(err)
+		require.True(t, ok)
+		assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+	}
+}
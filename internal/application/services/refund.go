@@ -3,36 +3,127 @@ package services
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 )
 
+type refundRequest struct {
+	PaymentID   string
+	Amount      int64
+	Reason      domain.OperationReason
+	Destination domain.RefundDestination
+}
+
+func (r refundRequest) hashFields() map[string]any {
+	return map[string]any{
+		"payment_id": r.PaymentID,
+		"amount":     r.Amount,
+		"reason":     string(r.Reason),
+		"destination": map[string]any{
+			"type":           string(r.Destination.Type),
+			"account_number": r.Destination.AccountNumber,
+			"routing_number": r.Destination.RoutingNumber,
+		},
+	}
+}
+
 type RefundService struct {
 	paymentRepo     *postgres.PaymentRepository
 	idempotencyRepo *postgres.IdempotencyRepository
+	operationRepo   *postgres.OperationRepository
+	settingsRepo    *postgres.MerchantSettingsRepository
 	bankClient      bank.BankClient
 	db              *postgres.DB
+	approvals       domain.AmountThresholds
+	keyring         *vault.Keyring
+	clock           clock.Clock
 }
 
 func NewRefundService(
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	settingsRepo *postgres.MerchantSettingsRepository,
 	bankClient bank.BankClient,
 	db *postgres.DB,
 ) *RefundService {
 	return &RefundService{
 		paymentRepo:     paymentRepo,
 		idempotencyRepo: idempotencyRepo,
+		operationRepo:   operationRepo,
+		settingsRepo:    settingsRepo,
 		bankClient:      bankClient,
 		db:              db,
+		clock:           clock.System{},
 	}
 }
 
-func (s *RefundService) Refund(ctx context.Context, paymentID, idempotencyKey string) (*domain.Payment, error) {
-	requestHash := ComputeHash(paymentID)
+// WithClock checks the merchant's refund window by c rather than the system clock
+func (s *RefundService) WithClock(c clock.Clock) *RefundService {
+	s.clock = c
+	return s
+}
+
+// WithApprovalThresholds holds refunds above their currency's threshold until another
+// API key than the one that requested them approves them
+func (s *RefundService) WithApprovalThresholds(thresholds domain.AmountThresholds) *RefundService {
+	s.approvals = thresholds
+	return s
+}
+
+// WithKeyring lets refunds be sent by bank transfer, encrypting the account number
+// with keyring until the transfer completes
+func (s *RefundService) WithKeyring(keyring *vault.Keyring) *RefundService {
+	s.keyring = keyring
+	return s
+}
+
+// Refund returns amount of the captured funds to the card. An amount of zero refunds
+// everything not refunded yet. reason is optional and is recorded on the operation for
+// finance categorization. Payments captured longer ago than the merchant's refund
+// window are rejected. A refund above the approval threshold is recorded as
+// PENDING_APPROVAL and the payment returned unchanged; Approve sends it to the bank.
+func (s *RefundService) Refund(
+	ctx context.Context,
+	paymentID string,
+	amount int64,
+	reason domain.OperationReason,
+	idempotencyKey string,
+) (*domain.Payment, error) {
+	return s.RefundTo(ctx, paymentID, amount, reason, domain.RefundDestination{}, idempotencyKey)
+}
+
+// RefundTo refunds like Refund, but sends the funds to destination, for a card that has
+// expired or been closed since the payment. A bank transfer goes through the bank's
+// payout API and completes once the bank accepts it; a transfer the receiving bank
+// returns later is not tracked. Store credit completes without calling the bank.
+func (s *RefundService) RefundTo(
+	ctx context.Context,
+	paymentID string,
+	amount int64,
+	reason domain.OperationReason,
+	destination domain.RefundDestination,
+	idempotencyKey string,
+) (*domain.Payment, error) {
+	if err := reason.Validate(); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	if err := destination.Validate(); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	if destination.Type == domain.DestinationBankTransfer && s.keyring == nil {
+		return nil, application.NewInvalidStateError(errors.New("bank transfer refunds are not configured"))
+	}
+
+	requestHash := ComputeHash(refundRequest{PaymentID: paymentID, Amount: amount, Reason: reason, Destination: destination})
 
 	cachedPayment, isCached, err := checkIdempotency(
 		ctx,
@@ -40,6 +131,7 @@ func (s *RefundService) Refund(ctx context.Context, paymentID, idempotencyKey st
 		s.paymentRepo,
 		idempotencyKey,
 		requestHash,
+		s.takeOverStaleLock,
 	)
 	if err != nil {
 		return nil, err
@@ -48,48 +140,427 @@ func (s *RefundService) Refund(ctx context.Context, paymentID, idempotencyKey st
 		return cachedPayment, nil
 	}
 
+	settings, err := s.settingsRepo.Find(ctx)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	held, err := s.requiresApproval(ctx, paymentID, amount)
+	if err != nil {
+		return nil, err
+	}
+	if held {
+		payment, err := s.holdForApproval(ctx, paymentID, amount, reason, destination, idempotencyKey, requestHash, settings)
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, s.takeOverStaleLock)
+		}
+		return payment, err
+	}
+
+	refundAmount := amount
 	payment, err := markPaymentTransitioning(
 		ctx,
 		s.db,
 		s.paymentRepo,
 		s.idempotencyRepo,
+		s.operationRepo,
 		paymentID,
 		idempotencyKey,
 		requestHash,
-		func(p *domain.Payment) error {
-			return p.MarkRefunding()
+		reason,
+		func(p *domain.Payment) (int64, error) {
+			if err := settings.CheckRefundWindow(p, s.clock.Now()); err != nil {
+				return 0, err
+			}
+			if refundAmount == 0 {
+				refundAmount = p.RefundableAmount()
+			}
+			return refundAmount, p.MarkRefunding(refundAmount)
+		},
+		func(tx pgx.Tx, op *domain.Operation) error {
+			return s.storeDestination(ctx, tx, op, destination)
 		},
 	)
 	if err != nil {
 		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
-			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey)
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, s.takeOverStaleLock)
 		}
 		return nil, err
 	}
-	bankReq := bank.RefundRequest{
-		Amount:    payment.AmountCents,
-		CaptureID: *payment.BankCaptureID,
+
+	return s.sendRefund(ctx, payment, refundAmount, destination, idempotencyKey)
+}
+
+// StoreCreditResponse stands in for the bank's answer to a refund to store credit,
+// which never reaches the bank
+type StoreCreditResponse struct {
+	CreditID   string    `json:"credit_id"`
+	Amount     int64     `json:"amount"`
+	Currency   string    `json:"currency"`
+	CreditedAt time.Time `json:"credited_at"`
+}
+
+// NewStoreCreditResponse records a refund to store credit as completed now, under a
+// reference of its own
+func NewStoreCreditResponse(payment *domain.Payment, amount int64) *StoreCreditResponse {
+	return &StoreCreditResponse{
+		CreditID:   "credit_" + uuid.New().String(),
+		Amount:     amount,
+		Currency:   payment.Currency,
+		CreditedAt: time.Now(),
 	}
+}
 
-	bankResp, err := s.bankClient.Refund(ctx, bankReq, idempotencyKey)
+// sendRefund sends a refund the payment was marked REFUNDING for to its destination and
+// records the outcome
+func (s *RefundService) sendRefund(
+	ctx context.Context,
+	payment *domain.Payment,
+	refundAmount int64,
+	destination domain.RefundDestination,
+	idempotencyKey string,
+) (*domain.Payment, error) {
+	ctx = bank.WithAcquirer(ctx, payment.Acquirer)
+
+	var (
+		response    any
+		referenceID string
+		refundedAt  time.Time
+		err         error
+	)
+	switch destination.Type {
+	case domain.DestinationStoreCredit:
+		credit := NewStoreCreditResponse(payment, refundAmount)
+		response, referenceID, refundedAt = credit, credit.CreditID, credit.CreditedAt
+	case domain.DestinationBankTransfer:
+		var payout *bank.PayoutResponse
+		payout, err = s.bankClient.Payout(ctx, bank.PayoutRequest{
+			Amount:        refundAmount,
+			Currency:      payment.Currency,
+			AccountNumber: destination.AccountNumber,
+			RoutingNumber: destination.RoutingNumber,
+		}, idempotencyKey)
+		if err == nil {
+			response, referenceID, refundedAt = payout, payout.PayoutID, payout.CreatedAt
+		}
+	default:
+		var refund *bank.RefundResponse
+		refund, err = s.bankClient.Refund(ctx, bank.RefundRequest{
+			Amount:    refundAmount,
+			CaptureID: *payment.BankCaptureID,
+		}, idempotencyKey)
+		if err == nil {
+			response, referenceID, refundedAt = refund, refund.RefundID, refund.RefundedAt
+		}
+	}
 	if err != nil {
-		return payment, HandleBankFailure(
+		err = HandleBankFailure(
 			ctx,
 			s.db,
 			s.paymentRepo,
 			s.idempotencyRepo,
+			s.operationRepo,
 			payment,
 			idempotencyKey,
 			err,
 		)
+		return payment, wakeRetryWorkers(ctx, s.db, payment, err)
 	}
-	if err := payment.Refund(bankResp.RefundID, bankResp.RefundedAt); err != nil {
+	if err := payment.Refund(referenceID, refundAmount, refundedAt); err != nil {
 		return nil, application.NewInvalidStateError(err)
 	}
 
-	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, payment, idempotencyKey, bankResp); err != nil {
+	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, s.operationRepo, payment, idempotencyKey, response); err != nil {
 		return payment, err
 	}
 
 	return payment, nil
 }
+
+// storeDestination records on a refund created in tx where it sends the funds
+func (s *RefundService) storeDestination(ctx context.Context, tx pgx.Tx, op *domain.Operation, destination domain.RefundDestination) error {
+	if err := op.SetDestination(destination); err != nil {
+		return application.NewInvalidInputError(err)
+	}
+
+	var account *vault.Sealed
+	if destination.Type == domain.DestinationBankTransfer {
+		sealed, err := s.keyring.Encrypt([]byte(destination.AccountNumber))
+		if err != nil {
+			return application.NewInternalError(err)
+		}
+		account = &sealed
+	}
+
+	if err := s.operationRepo.SetDestination(ctx, tx, op, account); err != nil {
+		return application.NewInternalError(err)
+	}
+	return nil
+}
+
+// Destination returns where a refund sends the funds, with the account number of a bank
+// transfer decrypted, so a refund that was held or interrupted is sent where it was
+// asked to go
+func (s *RefundService) Destination(ctx context.Context, op *domain.Operation) (domain.RefundDestination, error) {
+	destination := domain.RefundDestination{Type: op.Destination}
+	if op.Destination != domain.DestinationBankTransfer {
+		return destination, nil
+	}
+	if s.keyring == nil {
+		return destination, application.NewInvalidStateError(errors.New("bank transfer refunds are not configured"))
+	}
+
+	account, err := s.operationRepo.FindDestinationAccount(ctx, op.ID)
+	if err != nil {
+		return destination, application.NewInternalError(err)
+	}
+	accountNumber, err := s.keyring.Decrypt(account)
+	if err != nil {
+		return destination, application.NewInternalError(err)
+	}
+
+	destination.AccountNumber = string(accountNumber)
+	if op.DestinationRoutingNumber != nil {
+		destination.RoutingNumber = *op.DestinationRoutingNumber
+	}
+	return destination, nil
+}
+
+// requiresApproval reports whether a refund of amount, or of everything left when it
+// is zero, is above the approval threshold of the payment's currency
+func (s *RefundService) requiresApproval(ctx context.Context, paymentID string, amount int64) (bool, error) {
+	if len(s.approvals) == 0 {
+		return false, nil
+	}
+
+	payment, err := s.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentNotFound) {
+			return false, err
+		}
+		return false, application.NewInternalError(err)
+	}
+
+	if amount == 0 {
+		amount = payment.RefundableAmount()
+	}
+	return s.approvals.Exceeds(domain.Money{Amount: amount, Currency: payment.Currency}), nil
+}
+
+// holdForApproval records a refund as PENDING_APPROVAL after the same checks Refund
+// makes, and answers the request. The payment stays as it is until the refund is
+// approved, so nothing is retried in the meantime.
+func (s *RefundService) holdForApproval(
+	ctx context.Context,
+	paymentID string,
+	amount int64,
+	reason domain.OperationReason,
+	destination domain.RefundDestination,
+	idempotencyKey string,
+	requestHash RequestHash,
+	settings *domain.MerchantSettings,
+) (*domain.Payment, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err = s.idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, paymentID, requestHash.String()); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	payment, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, paymentID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if err = settings.CheckRefundWindow(payment, s.clock.Now()); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+	if amount == 0 {
+		amount = payment.RefundableAmount()
+	}
+	if err = payment.CheckRefund(amount); err != nil {
+		if errors.Is(err, domain.ErrInvalidAmount) {
+			return nil, application.NewInvalidInputError(err)
+		}
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	op, err := domain.NewOperation(uuid.New().String(), payment.ID, domain.OperationRefund, amount, idempotencyKey)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	if err = op.SetReason(reason); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	var requestedBy string
+	if apiKey := postgres.APIKeyFromContext(ctx); apiKey != nil {
+		requestedBy = apiKey.ID
+	}
+	if err = op.HoldForApproval(requestedBy); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	if err = s.operationRepo.Create(ctx, tx, op); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if err = s.storeDestination(ctx, tx, op, destination); err != nil {
+		return nil, err
+	}
+	if err = s.idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return payment, nil
+}
+
+// Approve sends a refund held for approval to the bank, as Refund would have, and
+// returns it with its outcome. It must be approved with another API key than the one
+// that requested it. The refund window is not checked again: it was open when the
+// refund was requested.
+func (s *RefundService) Approve(ctx context.Context, refundID string) (*domain.Operation, error) {
+	apiKey := postgres.APIKeyFromContext(ctx)
+	if apiKey == nil {
+		return nil, application.NewUnauthorizedError()
+	}
+
+	op, payment, err := s.approve(ctx, refundID, apiKey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	destination, err := s.Destination(ctx, op)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.sendRefund(ctx, payment, op.AmountCents, destination, op.IdempotencyKey); err != nil {
+		return nil, err
+	}
+
+	return s.findRefund(ctx, refundID)
+}
+
+// approve marks the payment REFUNDING for an approved refund, under the refund's
+// idempotency key, so the retry worker finishes it if the bank call is interrupted
+func (s *RefundService) approve(ctx context.Context, refundID, reviewedBy string) (*domain.Operation, *domain.Payment, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	op, err := s.lockRefund(ctx, tx, refundID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = op.Approve(reviewedBy, time.Now()); err != nil {
+		if errors.Is(err, domain.ErrSelfApproval) {
+			return nil, nil, application.NewSelfApprovalError()
+		}
+		return nil, nil, application.NewInvalidStateError(err)
+	}
+
+	if err = s.idempotencyRepo.ReacquireLock(ctx, tx, op.IdempotencyKey); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return nil, nil, application.NewRequestProcessingError(op.PaymentID)
+		}
+		return nil, nil, application.NewInternalError(err)
+	}
+
+	payment, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, op.PaymentID)
+	if err != nil {
+		return nil, nil, application.NewInternalError(err)
+	}
+	// Refunds made since it was requested may have left too little to refund
+	if err = payment.MarkRefunding(op.AmountCents); err != nil {
+		return nil, nil, application.NewInvalidStateError(err)
+	}
+
+	if err = s.paymentRepo.Update(ctx, tx, payment); err != nil {
+		return nil, nil, application.NewInternalError(err)
+	}
+	if err = s.operationRepo.Update(ctx, tx, op); err != nil {
+		return nil, nil, application.NewInternalError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, application.NewInternalError(err)
+	}
+
+	return op, payment, nil
+}
+
+// Reject turns down a refund held for approval. The bank is never called.
+func (s *RefundService) Reject(ctx context.Context, refundID string) (*domain.Operation, error) {
+	apiKey := postgres.APIKeyFromContext(ctx)
+	if apiKey == nil {
+		return nil, application.NewUnauthorizedError()
+	}
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	op, err := s.lockRefund(ctx, tx, refundID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = op.Reject(apiKey.ID, time.Now()); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+	if err = s.operationRepo.Update(ctx, tx, op); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return op, nil
+}
+
+// PendingApproval returns up to limit refunds waiting for approval, oldest first
+func (s *RefundService) PendingApproval(ctx context.Context, limit int) ([]*domain.Operation, error) {
+	ops, err := s.operationRepo.FindPendingApproval(ctx, limit)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	return ops, nil
+}
+
+// lockRefund loads a refund for review, locked so that two reviews of it cannot both
+// go ahead
+func (s *RefundService) lockRefund(ctx context.Context, tx pgx.Tx, refundID string) (*domain.Operation, error) {
+	op, err := s.operationRepo.FindRefundByIDForUpdate(ctx, tx, refundID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrRefundNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return op, nil
+}
+
+func (s *RefundService) findRefund(ctx context.Context, refundID string) (*domain.Operation, error) {
+	op, err := s.operationRepo.FindRefundByID(ctx, refundID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrRefundNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return op, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -24,6 +25,7 @@ type RefundServiceTestSuite struct {
 	testDB           *testhelpers.TestDatabase
 	paymentRepo      *postgres.PaymentRepository
 	idempotencyRepo  *postgres.IdempotencyRepository
+	operationRepo    *postgres.OperationRepository
 	mockBank         *mocks.MockBankClient
 	authorizeService *services.AuthorizeService
 	captureService   *services.CaptureService
@@ -38,6 +40,7 @@ func (suite *RefundServiceTestSuite) SetupSuite() {
 	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
 	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
 	suite.idempotencyRepo = postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.operationRepo = postgres.NewOperationRepository(suite.testDB.DB)
 }
 
 func (suite *RefundServiceTestSuite) TearDownSuite() {
@@ -50,13 +53,16 @@ func (suite *RefundServiceTestSuite) SetupTest() {
 	suite.authorizeService = services.NewAuthorizeService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
 		suite.mockBank,
 		suite.testDB.DB,
+		services.AuthorizeLimits{},
 	)
 
 	suite.captureService = services.NewCaptureService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		suite.operationRepo,
 		suite.mockBank,
 		suite.testDB.DB,
 	)
@@ -64,6 +70,8 @@ func (suite *RefundServiceTestSuite) SetupTest() {
 	suite.refundService = services.NewRefundService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		suite.operationRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
 		suite.mockBank,
 		suite.testDB.DB,
 	)
@@ -102,6 +110,79 @@ func (suite *RefundServiceTestSuite) Test_Refund_Success() {
 	assert.Equal(t, "ref-123", *savedPayment.BankRefundID)
 }
 
+func (suite *RefundServiceTestSuite) Test_Refund_MultiplePartialRefunds() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	firstKey := "idem-first-" + uuid.New().String()
+	secondKey := "idem-second-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, bank.RefundRequest{Amount: 1500, CaptureID: *payment.BankCaptureID}, firstKey).
+		Return(&bank.RefundResponse{RefundID: "ref-1", Status: "refunded", RefundedAt: time.Now()}, nil).
+		Once()
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, bank.RefundRequest{Amount: 3500, CaptureID: *payment.BankCaptureID}, secondKey).
+		Return(&bank.RefundResponse{RefundID: "ref-2", Status: "refunded", RefundedAt: time.Now()}, nil).
+		Once()
+
+	firstResult, err := suite.refundService.Refund(ctx, payment.ID, 1500, "", firstKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, firstResult.Status)
+	assert.Equal(t, int64(1500), firstResult.RefundedAmountCents)
+
+	secondResult, err := suite.refundService.Refund(ctx, payment.ID, 0, "", secondKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusRefunded, secondResult.Status)
+	assert.Equal(t, int64(5000), secondResult.RefundedAmountCents)
+
+	firstRefund, err := suite.operationRepo.FindByIdempotencyKey(ctx, firstKey)
+	require.NoError(t, err)
+
+	refund, err := suite.operationRepo.FindRefundByID(ctx, firstRefund.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationSucceeded, refund.Status)
+	assert.Equal(t, int64(1500), refund.AmountCents)
+	assert.Equal(t, "ref-1", *refund.BankReferenceID)
+}
+
+func (suite *RefundServiceTestSuite) Test_Refund_FindRefundByIDIgnoresOtherOperations() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+
+	operations, err := suite.operationRepo.FindByPaymentID(ctx, payment.ID)
+	require.NoError(t, err)
+	require.Len(t, operations, 1)
+
+	_, err = suite.operationRepo.FindRefundByID(ctx, operations[0].ID)
+	assert.ErrorIs(t, err, postgres.ErrRefundNotFound)
+}
+
+func (suite *RefundServiceTestSuite) Test_Refund_RecordsReason() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, mock.Anything, idempotencyKey).
+		Return(&bank.RefundResponse{RefundID: "ref-123", Status: "refunded", RefundedAt: time.Now()}, nil).
+		Once()
+
+	_, err := suite.refundService.Refund(ctx, payment.ID, 0, domain.ReasonOutOfStock, idempotencyKey)
+	require.NoError(t, err)
+
+	operation, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	require.NotNil(t, operation.Reason)
+	assert.Equal(t, domain.ReasonOutOfStock, *operation.Reason)
+}
+
 // ============================================================================
 // EDGE CASE TESTS
 // ============================================================================
@@ -131,7 +212,7 @@ func (suite *RefundServiceTestSuite) Test_Refund_CannotRefundPendingPayment() {
 
 	refundKey := "idem-Refund-" + uuid.New().String()
 
-	_, err = suite.refundService.Refund(ctx, payment.ID, refundKey)
+	_, err = suite.refundService.Refund(ctx, payment.ID, 0, "", refundKey)
 
 	svcErr, ok := application.IsServiceError(err)
 	require.True(t, ok)
@@ -166,12 +247,12 @@ func (suite *RefundServiceTestSuite) Test_Refund_CannotRefundAlreadyRefundedPaym
 		Return(refundResp, nil).
 		Once()
 
-	_, err := suite.refundService.Refund(ctx, payment.ID, firstKey)
+	_, err := suite.refundService.Refund(ctx, payment.ID, 0, "", firstKey)
 	require.NoError(t, err)
 
 	secondKey := "idem-second-" + uuid.New().String()
 
-	_, err = suite.refundService.Refund(ctx, payment.ID, secondKey)
+	_, err = suite.refundService.Refund(ctx, payment.ID, 0, "", secondKey)
 
 	require.Error(t, err)
 
@@ -208,10 +289,10 @@ func (suite *RefundServiceTestSuite) Test_Refund_IdempotencyReturnsCache() {
 		Return(refundResp, nil).
 		Once()
 
-	firstResult, err := suite.refundService.Refund(ctx, payment.ID, idempotencyKey)
+	firstResult, err := suite.refundService.Refund(ctx, payment.ID, 0, "", idempotencyKey)
 	require.NoError(t, err)
 
-	secondResult, err := suite.refundService.Refund(ctx, payment.ID, idempotencyKey)
+	secondResult, err := suite.refundService.Refund(ctx, payment.ID, 0, "", idempotencyKey)
 	require.NoError(t, err)
 
 	assert.Equal(t, firstResult.ID, secondResult.ID)
@@ -226,13 +307,193 @@ func (suite *RefundServiceTestSuite) Test_Refund_PaymentNotFound() {
 
 	idempotencyKey := "idem-" + uuid.New().String()
 
-	_, err := suite.refundService.Refund(ctx, paymentID, idempotencyKey)
+	_, err := suite.refundService.Refund(ctx, paymentID, 0, "", idempotencyKey)
 
 	svcErr, ok := application.IsServiceError(err)
 	require.True(t, ok)
 	assert.Equal(t, application.ErrCodeInternal, svcErr.Code)
 }
 
+func (suite *RefundServiceTestSuite) Test_Refund_RejectsUnknownReason() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+
+	_, err := suite.refundService.Refund(ctx, payment.ID, 0, "changed_mind", "idem-"+uuid.New().String())
+
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+
+	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, savedPayment.Status)
+}
+
+// ============================================================================
+// APPROVAL TESTS
+// ============================================================================
+
+func withKey(ctx context.Context, id string) context.Context {
+	return postgres.WithAPIKey(ctx, &domain.APIKey{ID: id, MerchantID: domain.DefaultMerchantID, Role: domain.RoleAdmin})
+}
+
+func (suite *RefundServiceTestSuite) Test_Refund_AboveThreshold_WaitsForSecondKey() {
+	t := suite.T()
+	ctx := context.Background()
+	suite.refundService.WithApprovalThresholds(domain.AmountThresholds{"USD": 2000})
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	heldPayment, err := suite.refundService.Refund(withKey(ctx, "key-requester"), payment.ID, 0, "", idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, heldPayment.Status)
+
+	refund, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationPendingApproval, refund.Status)
+	assert.Equal(t, "key-requester", *refund.RequestedBy)
+
+	pending, err := suite.refundService.PendingApproval(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, refund.ID, pending[0].ID)
+
+	_, err = suite.refundService.Approve(withKey(ctx, "key-requester"), refund.ID)
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeSelfApproval, svcErr.Code)
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, bank.RefundRequest{Amount: 5000, CaptureID: *payment.BankCaptureID}, idempotencyKey).
+		Return(&bank.RefundResponse{RefundID: "ref-approved", Status: "refunded", RefundedAt: time.Now()}, nil).
+		Once()
+
+	approved, err := suite.refundService.Approve(withKey(ctx, "key-approver"), refund.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationSucceeded, approved.Status)
+	assert.Equal(t, "key-approver", *approved.ReviewedBy)
+	assert.NotNil(t, approved.ReviewedAt)
+
+	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusRefunded, savedPayment.Status)
+
+	_, err = suite.refundService.Approve(withKey(ctx, "key-approver"), refund.ID)
+	svcErr, ok = application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidState, svcErr.Code)
+}
+
+func (suite *RefundServiceTestSuite) Test_Refund_RejectedRefundNeverReachesBank() {
+	t := suite.T()
+	ctx := context.Background()
+	suite.refundService.WithApprovalThresholds(domain.AmountThresholds{"USD": 2000})
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	_, err := suite.refundService.Refund(withKey(ctx, "key-requester"), payment.ID, 3000, "", idempotencyKey)
+	require.NoError(t, err)
+	refund, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+
+	rejected, err := suite.refundService.Reject(withKey(ctx, "key-approver"), refund.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationRejected, rejected.Status)
+
+	// A retry of the request gets the payment as it is
+	replayed, err := suite.refundService.Refund(withKey(ctx, "key-requester"), payment.ID, 3000, "", idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, replayed.Status)
+
+	_, err = suite.refundService.Approve(withKey(ctx, "key-approver"), refund.ID)
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidState, svcErr.Code)
+}
+
+func (suite *RefundServiceTestSuite) Test_Refund_AtThreshold_GoesStraightToBank() {
+	t := suite.T()
+	ctx := context.Background()
+	suite.refundService.WithApprovalThresholds(domain.AmountThresholds{"USD": 5000})
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Refund(mock.Anything, bank.RefundRequest{Amount: 5000, CaptureID: *payment.BankCaptureID}, idempotencyKey).
+		Return(&bank.RefundResponse{RefundID: "ref-123", Status: "refunded", RefundedAt: time.Now()}, nil).
+		Once()
+
+	refunded, err := suite.refundService.Refund(ctx, payment.ID, 5000, "", idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusRefunded, refunded.Status)
+}
+
+func (suite *RefundServiceTestSuite) Test_RefundTo_StoreCreditNeverReachesBank() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	refunded, err := suite.refundService.RefundTo(ctx, payment.ID, 2000, "",
+		domain.RefundDestination{Type: domain.DestinationStoreCredit}, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2000), refunded.RefundedAmountCents)
+
+	op, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DestinationStoreCredit, op.Destination)
+	assert.Equal(t, domain.OperationSucceeded, op.Status)
+}
+
+func (suite *RefundServiceTestSuite) Test_RefundTo_BankTransferPaysOutToAccount() {
+	t := suite.T()
+	ctx := context.Background()
+	keyring, err := vault.ParseKeyring("", "MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY=")
+	require.NoError(t, err)
+	suite.refundService.WithKeyring(keyring)
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+	idempotencyKey := "idem-" + uuid.New().String()
+
+	suite.mockBank.EXPECT().
+		Payout(mock.Anything, bank.PayoutRequest{Amount: 5000, Currency: "USD", AccountNumber: "000123456789", RoutingNumber: "110000000"}, idempotencyKey).
+		Return(&bank.PayoutResponse{PayoutID: "po-123", Amount: 5000, Currency: "USD", Status: "pending", CreatedAt: time.Now()}, nil).
+		Once()
+
+	destination := domain.RefundDestination{Type: domain.DestinationBankTransfer, AccountNumber: "000123456789", RoutingNumber: "110000000"}
+	refunded, err := suite.refundService.RefundTo(ctx, payment.ID, 0, "", destination, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusRefunded, refunded.Status)
+
+	op, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, "po-123", *op.BankReferenceID)
+	assert.Equal(t, "6789", *op.DestinationLast4)
+
+	// The account number is not kept once the payout has been made
+	_, err = suite.operationRepo.FindDestinationAccount(ctx, op.ID)
+	assert.ErrorIs(t, err, postgres.ErrOperationNotFound)
+}
+
+func (suite *RefundServiceTestSuite) Test_RefundTo_BankTransferNeedsAccount() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateCapturedPayment(t, ctx, suite.authorizeService, suite.captureService, suite.mockBank)
+
+	_, err := suite.refundService.RefundTo(ctx, payment.ID, 0, "",
+		domain.RefundDestination{Type: domain.DestinationBankTransfer}, "idem-"+uuid.New().String())
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+}
+
 // ============================================================================
 // FAILURE RECOVERY TESTS
 // ============================================================================
@@ -262,7 +523,7 @@ func (suite *RefundServiceTestSuite) Test_Refund_BankReturns500_PaymentStaysRefu
 		Return(nil, bankErr).
 		Once()
 
-	RefundedPayment, err := suite.refundService.Refund(ctx, payment.ID, idempotencyKey)
+	RefundedPayment, err := suite.refundService.Refund(ctx, payment.ID, 0, "", idempotencyKey)
 
 	require.Error(t, err)
 
@@ -275,7 +536,8 @@ func (suite *RefundServiceTestSuite) Test_Refund_BankReturns500_PaymentStaysRefu
 	assert.Nil(t, savedPayment.BankRefundID)
 }
 
-func (suite *RefundServiceTestSuite) Test_Refund_BankReturnsPermanentError_PaymentFails() {
+// A rejected refund fails only that refund; the captured payment can still be refunded
+func (suite *RefundServiceTestSuite) Test_Refund_BankReturnsPermanentError_RefundFails() {
 	t := suite.T()
 	ctx := context.Background()
 
@@ -300,12 +562,21 @@ func (suite *RefundServiceTestSuite) Test_Refund_BankReturnsPermanentError_Payme
 		Return(nil, bankErr).
 		Once()
 
-	RefundedPayment, err := suite.refundService.Refund(ctx, payment.ID, idempotencyKey)
+	RefundedPayment, err := suite.refundService.Refund(ctx, payment.ID, 0, "", idempotencyKey)
 
 	require.Error(t, err)
 
 	require.NotNil(t, RefundedPayment)
-	assert.Equal(t, domain.StatusFailed, RefundedPayment.Status)
+	assert.Equal(t, domain.StatusCaptured, RefundedPayment.Status)
+
+	savedPayment, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, savedPayment.Status)
+	assert.Zero(t, savedPayment.RefundedAmountCents)
+
+	refund, err := suite.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationFailed, refund.Status)
 }
 
 func (suite *RefundServiceTestSuite) Test_Refund_ConcurrentRequests_OnlyOneSucceeds() {
@@ -345,7 +616,7 @@ func (suite *RefundServiceTestSuite) Test_Refund_ConcurrentRequests_OnlyOneSucce
 	for i := range 2 {
 		go func(goroutineID int) {
 
-			payment, err := suite.refundService.Refund(ctx, payment.ID, idempotencyKey)
+			payment, err := suite.refundService.Refund(ctx, payment.ID, 0, "", idempotencyKey)
 			results <- result{payment, err}
 		}(i)
 	}
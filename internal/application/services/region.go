@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// RegionService tells whether this region takes writes when the gateway runs
+// active-passive across regions, and promotes it when the active one is lost. Each
+// process reads the lease at most once per ttl, so a promotion takes up to that long to
+// reach every process but the one that made it. Without a region name every process
+// takes writes.
+type RegionService struct {
+	regionRepo *postgres.RegionRepository
+	region     string
+	standby    bool
+	ttl        time.Duration
+
+	mu       sync.Mutex
+	state    *domain.RegionState
+	loadedAt time.Time
+}
+
+// NewRegionService places the region named region, which stays read-only until it is
+// promoted when standby is set
+func NewRegionService(regionRepo *postgres.RegionRepository, region string, standby bool, ttl time.Duration) *RegionService {
+	return &RegionService{
+		regionRepo: regionRepo,
+		region:     region,
+		standby:    standby,
+		ttl:        ttl,
+	}
+}
+
+// Enabled reports whether the gateway runs across regions at all
+func (s *RegionService) Enabled() bool {
+	return s.region != ""
+}
+
+// State returns how this region stands. While the lease cannot be read it keeps to the
+// state it read last, or to how the region was started.
+func (s *RegionService) State(ctx context.Context) domain.RegionState {
+	if !s.Enabled() {
+		return domain.RegionState{Active: true}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state != nil && time.Since(s.loadedAt) < s.ttl {
+		return *s.state
+	}
+
+	lease, err := s.regionRepo.FindLease(ctx)
+	switch {
+	case err == nil || errors.Is(err, postgres.ErrRegionLeaseNotFound):
+		state := domain.NewRegionState(s.region, s.standby, lease)
+		s.state = &state
+	case s.state == nil:
+		state := domain.NewRegionState(s.region, s.standby, nil)
+		s.state = &state
+	}
+	// As with feature flags, a failed read waits out the ttl before it is tried again
+	s.loadedAt = time.Now()
+	return *s.state
+}
+
+// Epoch returns the epoch this region's writes are recorded under, or
+// domain.ErrRegionStandby while it is on standby
+func (s *RegionService) Epoch(ctx context.Context) (int64, error) {
+	state := s.State(ctx)
+	if !state.Active {
+		return 0, domain.ErrRegionStandby
+	}
+	return state.Epoch, nil
+}
+
+// Promote makes this region the one that takes writes, under an epoch above any the
+// previous region wrote under. The previous region must already be cut off from the
+// database or stopped: it keeps writing until it next reads the lease, although never
+// over a payment this region has written since.
+func (s *RegionService) Promote(ctx context.Context) (domain.RegionState, error) {
+	if !s.Enabled() {
+		return domain.RegionState{}, application.NewInvalidStateError(errors.New("the gateway is not configured with a region"))
+	}
+
+	lease, err := s.regionRepo.Promote(ctx, s.region)
+	if err != nil {
+		return domain.RegionState{}, application.NewInternalError(err)
+	}
+
+	state := domain.NewRegionState(s.region, s.standby, lease)
+	s.mu.Lock()
+	s.state = &state
+	s.loadedAt = time.Now()
+	s.mu.Unlock()
+	return state, nil
+}
@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// staleFence is a region that was demoted but has not reread the lease yet
+type staleFence struct{ epoch int64 }
+
+func (f staleFence) Epoch(context.Context) (int64, error) { return f.epoch, nil }
+
+type RegionServiceTestSuite struct {
+	suite.Suite
+	testDB     *testhelpers.TestDatabase
+	regionRepo *postgres.RegionRepository
+}
+
+func TestRegionServiceSuite(t *testing.T) {
+	suite.Run(t, new(RegionServiceTestSuite))
+}
+
+func (suite *RegionServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.regionRepo = postgres.NewRegionRepository(suite.testDB.DB)
+}
+
+func (suite *RegionServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *RegionServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+func (suite *RegionServiceTestSuite) TestPromotionMovesWritesToTheNewRegion() {
+	ctx := context.Background()
+	t := suite.T()
+	primary := services.NewRegionService(suite.regionRepo, "eu-west", false, 0)
+	secondary := services.NewRegionService(suite.regionRepo, "us-east", true, 0)
+
+	assert.True(t, primary.State(ctx).Active)
+	_, err := secondary.Epoch(ctx)
+	assert.ErrorIs(t, err, domain.ErrRegionStandby)
+
+	state, err := secondary.Promote(ctx)
+	require.NoError(t, err)
+	assert.True(t, state.Active)
+	assert.Equal(t, int64(1), state.Epoch)
+
+	// Repeating the promotion keeps the epoch
+	state, err = secondary.Promote(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), state.Epoch)
+
+	assert.False(t, primary.State(ctx).Active)
+	assert.Equal(t, "us-east", primary.State(ctx).Lease.ActiveRegion)
+
+	state, err = primary.Promote(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), state.Epoch)
+	assert.False(t, secondary.State(ctx).Active)
+}
+
+func (suite *RegionServiceTestSuite) TestDemotedRegionCannotOverwritePayments() {
+	ctx := context.Background()
+	t := suite.T()
+	primary := services.NewRegionService(suite.regionRepo, "eu-west", false, 0)
+	secondary := services.NewRegionService(suite.regionRepo, "us-east", true, 0)
+	primaryPayments := postgres.NewPaymentRepository(suite.testDB.DB).WithRegionFence(primary)
+	secondaryPayments := postgres.NewPaymentRepository(suite.testDB.DB).WithRegionFence(secondary)
+
+	payment, err := domain.NewPayment(uuid.New().String(), "order-1", "cust-1", 5000, "USD")
+	require.NoError(t, err)
+	tx, err := suite.testDB.DB.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, primaryPayments.Create(ctx, tx, payment))
+	require.NoError(t, tx.Commit(ctx))
+
+	_, err = secondary.Promote(ctx)
+	require.NoError(t, err)
+	require.NoError(t, payment.Authorize("auth-1", payment.CreatedAt, payment.CreatedAt.Add(7*24*time.Hour)))
+	require.NoError(t, secondaryPayments.Update(ctx, nil, payment))
+
+	assert.ErrorIs(t, primaryPayments.Update(ctx, nil, payment), domain.ErrRegionStandby)
+
+	stalePayments := postgres.NewPaymentRepository(suite.testDB.DB).WithRegionFence(staleFence{epoch: 0})
+	assert.ErrorIs(t, stalePayments.Update(ctx, nil, payment), domain.ErrStaleRegionEpoch)
+}
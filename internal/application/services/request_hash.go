@@ -0,0 +1,120 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
+)
+
+// The version prefixes of stored hashes. A hash without a prefix was stored before
+// hashes were versioned.
+const (
+	// requestHashV1 is the SHA-256 of the request's hash fields
+	requestHashV1 = "v1:"
+	// requestHashV2 is an HMAC-SHA256 of the request's hash fields under a salt of its
+	// merchant's own
+	requestHashV2 = "v2:"
+	// requestHashV3 is requestHashV2 over fields that name every part of the funding
+	// source, not only the card number
+	requestHashV3 = "v3:"
+)
+
+// hashedRequest is a request an idempotency key is bound to. hashFields names the
+// fields that identify it as they are hashed, so renaming, reordering or retyping the
+// struct's own fields between releases leaves stored hashes valid. Values must be
+// strings, numbers, bools, or slices and maps of them.
+type hashedRequest interface {
+	hashFields() map[string]any
+}
+
+// requestFields is a request that is only its hashed fields
+type requestFields map[string]any
+
+func (f requestFields) hashFields() map[string]any {
+	return f
+}
+
+// RequestHash fingerprints a request so that a key reused for a different request is
+// caught. The CVV is never hashed: the hash is stored, and three digits next to an
+// otherwise known request are quickly brute-forced.
+type RequestHash struct {
+	current string
+	v1      string
+	legacy  string
+	// salted holds the salted hashes by their version prefix
+	salted map[string]string
+}
+
+// ComputeHash hashes the canonical JSON of r's hash fields
+func ComputeHash(r hashedRequest) RequestHash {
+	v1 := requestHashV1 + sha256Hex(canonicalJSON(r))
+	return RequestHash{
+		current: v1,
+		v1:      v1,
+		legacy:  sha256Hex([]byte(fmt.Sprintf("%+v", pci.WithoutCVV(r)))),
+	}
+}
+
+// withSalted returns h with the HMAC of fields under salt, prefixed with version, as
+// the hash new keys store. h's earlier versions are still matched, for keys stored
+// before.
+func (h RequestHash) withSalted(version string, fields hashedRequest, salt []byte) RequestHash {
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(canonicalJSON(fields))
+
+	salted := make(map[string]string, len(h.salted)+1)
+	for v, hash := range h.salted {
+		salted[v] = hash
+	}
+	salted[version] = version + fmt.Sprintf("%x", mac.Sum(nil))
+
+	h.salted = salted
+	h.current = salted[version]
+	return h
+}
+
+// canonicalJSON encodes r's hash fields, whose keys encoding/json sorts at every level
+func canonicalJSON(r hashedRequest) []byte {
+	data, err := json.Marshal(r.hashFields())
+	if err != nil {
+		// Only values of the kinds hashFields allows are ever hashed
+		panic(fmt.Sprintf("hash request fields: %v", err))
+	}
+	return data
+}
+
+// String returns the hash stored with a new idempotency key
+func (h RequestHash) String() string {
+	return h.current
+}
+
+// Matches reports whether stored, the hash kept with an idempotency key, is this
+// request's under the version it was computed with. A hash from before versioning
+// was the request struct printed with %+v, which only matches while the struct is
+// unchanged since.
+func (h RequestHash) Matches(stored string) bool {
+	switch {
+	case strings.HasPrefix(stored, requestHashV3):
+		return stored == h.salted[requestHashV3]
+	case strings.HasPrefix(stored, requestHashV2):
+		return stored == h.salted[requestHashV2]
+	case strings.HasPrefix(stored, requestHashV1):
+		return stored == h.v1
+	default:
+		return stored == h.legacy
+	}
+}
+
+func sha256Hex(data []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(data))
+}
+
+// hashTime renders t the same way in every time zone
+func hashTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// QueuedPayment is a payment waiting in the review queue, with why it was flagged
+type QueuedPayment struct {
+	Review  *domain.PaymentReview
+	Payment *domain.Payment
+}
+
+// ReviewService keeps risk-flagged authorizations from the bank until a person approves
+// or declines them. The card of a held payment is saved as a payment method, so an
+// approved payment is authorized as a scheduled one is, without the CVV.
+type ReviewService struct {
+	paymentRepo     *postgres.PaymentRepository
+	idempotencyRepo *postgres.IdempotencyRepository
+	reviewRepo      *postgres.ReviewRepository
+	paymentMethods  *PaymentMethodService
+	authService     *AuthorizeService
+	db              *postgres.DB
+	thresholds      domain.AmountThresholds
+	metrics         *metrics.ReviewMetrics
+}
+
+// NewReviewService flags authorizations above their currency's amount in thresholds.
+// authService only holds them once given the service with WithReviews.
+func NewReviewService(
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	reviewRepo *postgres.ReviewRepository,
+	paymentMethods *PaymentMethodService,
+	authService *AuthorizeService,
+	db *postgres.DB,
+	thresholds domain.AmountThresholds,
+) *ReviewService {
+	return &ReviewService{
+		paymentRepo:     paymentRepo,
+		idempotencyRepo: idempotencyRepo,
+		reviewRepo:      reviewRepo,
+		paymentMethods:  paymentMethods,
+		authService:     authService,
+		db:              db,
+		thresholds:      thresholds,
+	}
+}
+
+// WithMetrics records each decision and how long the payment waited for it
+func (s *ReviewService) WithMetrics(m *metrics.ReviewMetrics) *ReviewService {
+	s.metrics = m
+	return s
+}
+
+// flag returns why an authorization must be reviewed, or "" when it goes straight to
+// the bank. Charges of a saved card the merchant makes, such as subscription
+// renewals, are never flagged, and neither are network token payments: their
+// cryptogram is good for one authorization only, which cannot wait for a reviewer.
+func (s *ReviewService) flag(cmd *AuthorizeCommand) string {
+	if cmd.PaymentMethodID != "" || !cmd.NetworkToken.IsZero() {
+		return ""
+	}
+	if s.thresholds.Exceeds(domain.Money{Amount: cmd.Amount, Currency: cmd.Currency}) {
+		return domain.ReviewReasonAmount
+	}
+	return ""
+}
+
+// hold saves the card of a flagged payment, then stores the payment in REVIEW, queues
+// it and settles the idempotency key in one transaction. Like acquireIdempotencyLock,
+// it returns ErrOrderAlreadyPaid and ErrDuplicateIdempotencyKey as they are.
+func (s *ReviewService) hold(
+	ctx context.Context,
+	payment *domain.Payment,
+	cmd *AuthorizeCommand,
+	reason string,
+	idempotencyKey string,
+	requestHash RequestHash,
+) error {
+	// A replay would otherwise save the card again before finding the key taken
+	existingKey, err := s.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	if existingKey != nil {
+		return postgres.ErrDuplicateIdempotencyKey
+	}
+
+	paymentMethod, err := s.paymentMethods.Save(ctx, &SavePaymentMethodCommand{
+		CustomerID:  cmd.CustomerID,
+		CardNumber:  cmd.CardNumber,
+		ExpiryMonth: cmd.ExpiryMonth,
+		ExpiryYear:  cmd.ExpiryYear,
+	})
+	if err != nil {
+		return err
+	}
+	payment.PaymentMethodID = &paymentMethod.ID
+
+	if err := payment.HoldForReview(); err != nil {
+		return application.NewInvalidStateError(err)
+	}
+	review := domain.NewPaymentReview(payment.ID, reason, time.Now())
+
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := s.paymentRepo.Create(ctx, tx, payment); err != nil {
+		if errors.Is(err, postgres.ErrOrderAlreadyPaid) {
+			return err
+		}
+		return application.NewInternalError(err)
+	}
+
+	if err := s.reviewRepo.Create(ctx, tx, review); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := s.idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, payment.ID, requestHash.String()); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return err
+		}
+		return application.NewInternalError(err)
+	}
+
+	if err := consumeQuota(ctx, tx, s.authService.settingsRepo, payment); err != nil {
+		return err
+	}
+
+	if err := s.idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	return nil
+}
+
+// Queue returns up to limit payments waiting for review, the longest waiting first
+func (s *ReviewService) Queue(ctx context.Context, limit int) ([]*QueuedPayment, error) {
+	reviews, err := s.reviewRepo.FindQueued(ctx, limit)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	ids := make([]string, len(reviews))
+	for i, review := range reviews {
+		ids[i] = review.PaymentID
+	}
+	payments, err := s.paymentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	byID := make(map[string]*domain.Payment, len(payments))
+	for _, payment := range payments {
+		byID[payment.ID] = payment
+	}
+
+	queued := make([]*QueuedPayment, 0, len(reviews))
+	for _, review := range reviews {
+		if payment, ok := byID[review.PaymentID]; ok {
+			queued = append(queued, &QueuedPayment{Review: review, Payment: payment})
+		}
+	}
+	return queued, nil
+}
+
+// Approve sends a held payment's authorization to the bank with its saved card and
+// returns it with the outcome, a decline included. The payment becomes PENDING under its own idempotency
+// key first, so one interrupted on the way to the bank is timed out by the
+// RetryWorker like any other.
+func (s *ReviewService) Approve(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	apiKey := postgres.APIKeyFromContext(ctx)
+	if apiKey == nil {
+		return nil, application.NewUnauthorizedError()
+	}
+
+	payment, err := s.decide(ctx, paymentID, domain.ReviewApproved, apiKey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentMethod, err := s.paymentMethods.Get(ctx, *payment.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	cardNumber, err := s.paymentMethods.CardNumber(ctx, paymentMethod.ID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	cmd := &AuthorizeCommand{
+		OrderID:         payment.OrderID,
+		CustomerID:      payment.CustomerID,
+		Amount:          payment.AmountCents,
+		Currency:        payment.Currency,
+		CardNumber:      cardNumber,
+		ExpiryMonth:     paymentMethod.ExpiryMonth,
+		ExpiryYear:      paymentMethod.ExpiryYear,
+		PaymentMethodID: paymentMethod.ID,
+	}
+
+	payment, err = s.authService.CompleteAuthorize(ctx, payment, cmd, reviewIdempotencyKey(payment.ID))
+	// A decline is an outcome like any other, which the FAILED payment shows
+	if err != nil && (payment == nil || !payment.IsTerminal()) {
+		return nil, err
+	}
+	return payment, nil
+}
+
+// Decline fails a held payment with reason declined_in_review. The bank never sees it.
+func (s *ReviewService) Decline(ctx context.Context, paymentID string) (*domain.Payment, error) {
+	apiKey := postgres.APIKeyFromContext(ctx)
+	if apiKey == nil {
+		return nil, application.NewUnauthorizedError()
+	}
+
+	return s.decide(ctx, paymentID, domain.ReviewDeclined, apiKey.ID)
+}
+
+// decide records the decision on a payment's review and moves the payment on in one
+// transaction. The review is locked, so two reviewers cannot both decide it.
+func (s *ReviewService) decide(
+	ctx context.Context,
+	paymentID string,
+	decision domain.ReviewDecision,
+	reviewedBy string,
+) (*domain.Payment, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	review, err := s.reviewRepo.FindForUpdate(ctx, tx, paymentID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrReviewNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	if err = review.Decide(decision, reviewedBy, time.Now()); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	payment, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, paymentID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if decision == domain.ReviewApproved {
+		err = payment.MarkPending()
+	} else {
+		err = payment.FailWithReason(domain.FailureReasonDeclinedInReview)
+	}
+	if err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	if err = s.paymentRepo.Update(ctx, tx, payment); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if err = s.reviewRepo.Update(ctx, tx, review); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if decision == domain.ReviewApproved {
+		key := reviewIdempotencyKey(payment.ID)
+		requestHash := ComputeHash(requestFields{
+			"payment_id": review.PaymentID,
+			"reason":     review.Reason,
+			"flagged_at": hashTime(review.FlaggedAt),
+		})
+		if err = s.idempotencyRepo.AcquireLock(ctx, tx, key, payment.ID, requestHash.String()); err != nil {
+			return nil, application.NewInternalError(err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	if s.metrics != nil {
+		s.metrics.Observe(string(decision), review.Wait(time.Now()))
+	}
+	return payment, nil
+}
+
+// reviewIdempotencyKey is the key an approved payment is authorized under
+func reviewIdempotencyKey(paymentID string) string {
+	return "review-" + paymentID
+}
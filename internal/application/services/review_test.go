@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReviewServiceTestSuite struct {
+	suite.Suite
+	testDB      *testhelpers.TestDatabase
+	paymentRepo *postgres.PaymentRepository
+	mockBank    *mocks.MockBankClient
+	authService *services.AuthorizeService
+	service     *services.ReviewService
+}
+
+func TestReviewServiceSuite(t *testing.T) {
+	suite.Run(t, new(ReviewServiceTestSuite))
+}
+
+func (suite *ReviewServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *ReviewServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *ReviewServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	keyring, err := vault.ParseKeyring("", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(suite.T(), err)
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	paymentMethods := services.NewPaymentMethodService(postgres.NewPaymentMethodRepository(suite.testDB.DB), keyring)
+	suite.authService = services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{})
+	suite.service = services.NewReviewService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewReviewRepository(suite.testDB.DB),
+		paymentMethods,
+		suite.authService,
+		suite.testDB.DB,
+		domain.AmountThresholds{"USD": 5000},
+	)
+	suite.authService.WithReviews(suite.service)
+}
+
+func (suite *ReviewServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+// flaggedCommand is an authorization just above the review threshold
+func flaggedCommand() *services.AuthorizeCommand {
+	return &services.AuthorizeCommand{
+		OrderID:     "order-" + uuid.New().String(),
+		CustomerID:  "cust-" + uuid.New().String(),
+		Amount:      5001,
+		Currency:    "USD",
+		CardNumber:  "4111111111111111",
+		CVV:         "123",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	}
+}
+
+func (suite *ReviewServiceTestSuite) hold(ctx context.Context, cmd *services.AuthorizeCommand, idempotencyKey string) *domain.Payment {
+	t := suite.T()
+
+	payment, err := suite.authService.Authorize(ctx, cmd, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusReview, payment.Status)
+	require.NotNil(t, payment.PaymentMethodID, "the card is kept for the approval")
+
+	return payment
+}
+
+func (suite *ReviewServiceTestSuite) Test_Authorize_AboveThreshold_WaitsForApproval() {
+	ctx := context.Background()
+	t := suite.T()
+	cmd := flaggedCommand()
+	idempotencyKey := "idem-" + uuid.New().String()
+	payment := suite.hold(ctx, cmd, idempotencyKey)
+
+	// A retry of the request gets the held payment, and the mock fails the test on any
+	// bank call made so far
+	replayed := suite.hold(ctx, cmd, idempotencyKey)
+	assert.Equal(t, payment.ID, replayed.ID)
+
+	queued, err := suite.service.Queue(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, payment.ID, queued[0].Payment.ID)
+	assert.Equal(t, domain.ReviewReasonAmount, queued[0].Review.Reason)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "review-"+payment.ID).
+		Run(func(_ context.Context, req bank.AuthorizationRequest, _ string) {
+			assert.Equal(t, "4111111111111111", req.CardNumber)
+			assert.Empty(t, req.Cvv)
+		}).
+		Return(&bank.AuthorizationResponse{
+			Amount:          payment.AmountCents,
+			Currency:        payment.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+
+	approved, err := suite.service.Approve(withKey(ctx, "key-reviewer"), payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, approved.Status)
+
+	queued, err = suite.service.Queue(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, queued)
+
+	_, err = suite.service.Decline(withKey(ctx, "key-reviewer"), payment.ID)
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidState, svcErr.Code)
+}
+
+func (suite *ReviewServiceTestSuite) Test_Decline_FailsWithoutBank() {
+	ctx := context.Background()
+	t := suite.T()
+	payment := suite.hold(ctx, flaggedCommand(), "idem-"+uuid.New().String())
+
+	_, err := suite.service.Decline(ctx, payment.ID)
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeUnauthorized, svcErr.Code)
+
+	declined, err := suite.service.Decline(withKey(ctx, "key-reviewer"), payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, declined.Status)
+	assert.Equal(t, domain.FailureReasonDeclinedInReview, *declined.FailureReason)
+
+	_, err = suite.service.Approve(withKey(ctx, "key-reviewer"), payment.ID)
+	svcErr, ok = application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidState, svcErr.Code)
+}
+
+func (suite *ReviewServiceTestSuite) Test_Approve_UnknownPayment() {
+	_, err := suite.service.Approve(withKey(context.Background(), "key-reviewer"), uuid.New().String())
+	assert.ErrorIs(suite.T(), err, postgres.ErrReviewNotFound)
+}
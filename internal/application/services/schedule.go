@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type ScheduleCommand struct {
+	OrderID         string
+	CustomerID      string
+	Amount          int64
+	Currency        string
+	PaymentMethodID string
+	ScheduledFor    time.Time
+}
+
+func (c ScheduleCommand) hashFields() map[string]any {
+	return map[string]any{
+		"order_id":          c.OrderID,
+		"customer_id":       c.CustomerID,
+		"amount":            c.Amount,
+		"currency":          c.Currency,
+		"payment_method_id": c.PaymentMethodID,
+		"scheduled_for":     hashTime(c.ScheduledFor),
+	}
+}
+
+// ScheduleService creates payments that are authorized later against a saved
+// payment method, and runs their authorizations once they are due.
+type ScheduleService struct {
+	paymentRepo          *postgres.PaymentRepository
+	idempotencyRepo      *postgres.IdempotencyRepository
+	scheduledPaymentRepo *postgres.ScheduledPaymentRepository
+	paymentMethods       *PaymentMethodService
+	authService          *AuthorizeService
+	db                   *postgres.DB
+	clock                clock.Clock
+}
+
+func NewScheduleService(
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	scheduledPaymentRepo *postgres.ScheduledPaymentRepository,
+	paymentMethods *PaymentMethodService,
+	authService *AuthorizeService,
+	db *postgres.DB,
+) *ScheduleService {
+	return &ScheduleService{
+		paymentRepo:          paymentRepo,
+		idempotencyRepo:      idempotencyRepo,
+		scheduledPaymentRepo: scheduledPaymentRepo,
+		paymentMethods:       paymentMethods,
+		authService:          authService,
+		db:                   db,
+		clock:                clock.System{},
+	}
+}
+
+// WithClock decides which scheduled payments are due, and whether their cards have
+// expired, by c rather than the system clock
+func (s *ScheduleService) WithClock(c clock.Clock) *ScheduleService {
+	s.clock = c
+	return s
+}
+
+// Schedule stores a SCHEDULED payment. Nothing is sent to the bank until RunDue picks
+// it up at its scheduled time.
+func (s *ScheduleService) Schedule(ctx context.Context, cmd *ScheduleCommand, idempotencyKey string) (*domain.Payment, error) {
+	requestHash := ComputeHash(cmd)
+
+	cachedPayment, isCached, err := checkIdempotency(
+		ctx,
+		s.idempotencyRepo,
+		s.paymentRepo,
+		idempotencyKey,
+		requestHash,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if isCached {
+		return cachedPayment, nil
+	}
+
+	if err := s.authService.checkNewPayment(ctx, cmd.Amount, cmd.Currency); err != nil {
+		return nil, err
+	}
+
+	paymentMethod, err := s.paymentMethods.Get(ctx, cmd.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentMethod.CustomerID != cmd.CustomerID {
+		return nil, application.NewInvalidInputError(domain.ErrInvalidPaymentMethod)
+	}
+
+	payment, err := domain.NewScheduledPaymentEntity(uuid.New().String(), cmd.OrderID, cmd.CustomerID, cmd.Amount, cmd.Currency)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	payment.PaymentMethodID = &paymentMethod.ID
+
+	scheduled, err := domain.NewScheduledPayment(payment.ID, paymentMethod.ID, cmd.ScheduledFor, s.clock.Now())
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	if err := s.createScheduled(ctx, payment, scheduled, idempotencyKey, requestHash); err != nil {
+		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, nil)
+		}
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// createScheduled stores the payment and its schedule and settles the idempotency key
+// in one transaction; there is no bank call to wait for.
+func (s *ScheduleService) createScheduled(
+	ctx context.Context,
+	payment *domain.Payment,
+	scheduled *domain.ScheduledPayment,
+	idempotencyKey string,
+	requestHash RequestHash,
+) error {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := s.paymentRepo.Create(ctx, tx, payment); err != nil {
+		if errors.Is(err, postgres.ErrOrderAlreadyPaid) {
+			return application.NewOrderAlreadyPaidError(err)
+		}
+		return application.NewInternalError(err)
+	}
+
+	if err := s.scheduledPaymentRepo.Create(ctx, tx, scheduled); err != nil {
+		if errors.Is(err, postgres.ErrPaymentMethodNotFound) {
+			return err
+		}
+		return application.NewInternalError(err)
+	}
+
+	if err := s.idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, payment.ID, requestHash.String()); err != nil {
+		return err
+	}
+
+	if err := consumeQuota(ctx, tx, s.authService.settingsRepo, payment); err != nil {
+		return err
+	}
+
+	responsePayload, err := json.Marshal(scheduled)
+	if err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := s.idempotencyRepo.StoreResponse(ctx, tx, idempotencyKey, responsePayload); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := s.idempotencyRepo.ReleaseLock(ctx, tx, idempotencyKey); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return application.NewInternalError(err)
+	}
+
+	return nil
+}
+
+type dueAuthorization struct {
+	payment        *domain.Payment
+	paymentMethod  *domain.PaymentMethod
+	idempotencyKey string
+}
+
+// RunDue authorizes up to limit scheduled payments whose time has come. A payment
+// whose card expired in the meantime is failed with reason card_expired instead,
+// which reaches hooks as a payment.failed event. It returns how many authorizations
+// it started.
+func (s *ScheduleService) RunDue(ctx context.Context, limit int) (int, error) {
+	due, err := s.claimDue(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, d := range due {
+		if err := s.authorizeDue(ctx, d); err != nil {
+			errs = append(errs, fmt.Errorf("payment %s: %w", d.payment.ID, err))
+		}
+	}
+
+	return len(due), errors.Join(errs...)
+}
+
+// claimDue moves due payments out of SCHEDULED in one transaction, so each is picked
+// up by exactly one worker. Payments that can still be authorized become PENDING with
+// their authorization's idempotency key locked, as a request-driven authorization
+// would be.
+func (s *ScheduleService) claimDue(ctx context.Context, limit int) ([]*dueAuthorization, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	now := s.clock.Now()
+	claimed, err := s.scheduledPaymentRepo.ClaimDue(ctx, tx, now, limit)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	var due []*dueAuthorization
+	for _, scheduled := range claimed {
+		ctx := postgres.WithMerchant(ctx, scheduled.MerchantID)
+
+		payment, err := s.paymentRepo.FindByIDForUpdate(ctx, tx, scheduled.PaymentID)
+		if err != nil {
+			return nil, application.NewInternalError(err)
+		}
+
+		paymentMethod, err := s.paymentMethods.Get(ctx, scheduled.PaymentMethodID)
+		if err != nil {
+			return nil, err
+		}
+
+		if paymentMethod.IsExpired(now) {
+			if err := payment.FailWithReason(domain.FailureReasonCardExpired); err != nil {
+				return nil, application.NewInvalidStateError(err)
+			}
+			if err := s.paymentRepo.Update(ctx, tx, payment); err != nil {
+				return nil, application.NewInternalError(err)
+			}
+			continue
+		}
+
+		if err := payment.MarkPending(); err != nil {
+			return nil, application.NewInvalidStateError(err)
+		}
+		if err := s.paymentRepo.Update(ctx, tx, payment); err != nil {
+			return nil, application.NewInternalError(err)
+		}
+
+		idempotencyKey := "scheduled-" + payment.ID
+		requestHash := ComputeHash(requestFields{
+			"payment_id":        scheduled.PaymentID,
+			"payment_method_id": scheduled.PaymentMethodID,
+			"scheduled_for":     hashTime(scheduled.ScheduledFor),
+		})
+		if err := s.idempotencyRepo.AcquireLock(ctx, tx, idempotencyKey, payment.ID, requestHash.String()); err != nil {
+			return nil, application.NewInternalError(err)
+		}
+
+		due = append(due, &dueAuthorization{
+			payment:        payment,
+			paymentMethod:  paymentMethod,
+			idempotencyKey: idempotencyKey,
+		})
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	return due, nil
+}
+
+// authorizeDue sends a claimed payment's authorization to the bank. Saved cards carry
+// no CVV. A payment that fails here before reaching the bank stays PENDING and is
+// timed out by the RetryWorker like any orphaned authorization.
+func (s *ScheduleService) authorizeDue(ctx context.Context, d *dueAuthorization) error {
+	ctx = postgres.WithMerchant(ctx, d.payment.MerchantID)
+
+	cardNumber, err := s.paymentMethods.CardNumber(ctx, d.paymentMethod.ID)
+	if err != nil {
+		return err
+	}
+
+	cmd := &AuthorizeCommand{
+		OrderID:     d.payment.OrderID,
+		CustomerID:  d.payment.CustomerID,
+		Amount:      d.payment.AmountCents,
+		Currency:    d.payment.Currency,
+		CardNumber:  cardNumber,
+		ExpiryMonth: d.paymentMethod.ExpiryMonth,
+		ExpiryYear:  d.paymentMethod.ExpiryYear,
+	}
+
+	_, err = s.authService.CompleteAuthorize(ctx, d.payment, cmd, d.idempotencyKey)
+	return err
+}
@@ -0,0 +1,180 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ScheduleServiceTestSuite struct {
+	suite.Suite
+	testDB         *testhelpers.TestDatabase
+	paymentRepo    *postgres.PaymentRepository
+	mockBank       *mocks.MockBankClient
+	paymentMethods *services.PaymentMethodService
+	service        *services.ScheduleService
+}
+
+func TestScheduleServiceSuite(t *testing.T) {
+	suite.Run(t, new(ScheduleServiceTestSuite))
+}
+
+func (suite *ScheduleServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *ScheduleServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *ScheduleServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	keyring, err := vault.ParseKeyring("", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(suite.T(), err)
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.paymentMethods = services.NewPaymentMethodService(postgres.NewPaymentMethodRepository(suite.testDB.DB), keyring)
+	authService := services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{})
+	suite.service = services.NewScheduleService(
+		suite.paymentRepo,
+		idempotencyRepo,
+		postgres.NewScheduledPaymentRepository(suite.testDB.DB),
+		suite.paymentMethods,
+		authService,
+		suite.testDB.DB,
+	)
+}
+
+func (suite *ScheduleServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+// schedule saves a card and schedules a payment against it, then makes it due
+func (suite *ScheduleServiceTestSuite) schedule(ctx context.Context) *domain.Payment {
+	t := suite.T()
+	customerID := "cust-" + uuid.New().String()
+
+	pm, err := suite.paymentMethods.Save(ctx, &services.SavePaymentMethodCommand{
+		CustomerID:  customerID,
+		CardNumber:  "4111111111111111",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	})
+	require.NoError(t, err)
+
+	payment, err := suite.service.Schedule(ctx, &services.ScheduleCommand{
+		OrderID:         "order-" + uuid.New().String(),
+		CustomerID:      customerID,
+		Amount:          5000,
+		Currency:        "USD",
+		PaymentMethodID: pm.ID,
+		ScheduledFor:    time.Now().Add(time.Hour),
+	}, "idem-"+uuid.New().String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusScheduled, payment.Status)
+
+	_, err = suite.testDB.DB.Pool.Exec(ctx,
+		"UPDATE scheduled_payments SET scheduled_for = NOW() - INTERVAL '1 minute' WHERE payment_id = $1", payment.ID)
+	require.NoError(t, err)
+
+	return payment
+}
+
+func (suite *ScheduleServiceTestSuite) Test_RunDue_AuthorizesWithSavedCard() {
+	ctx := context.Background()
+	t := suite.T()
+	payment := suite.schedule(ctx)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "scheduled-"+payment.ID).
+		Run(func(_ context.Context, req bank.AuthorizationRequest, _ string) {
+			assert.Equal(t, "4111111111111111", req.CardNumber)
+			assert.Empty(t, req.Cvv)
+		}).
+		Return(&bank.AuthorizationResponse{
+			Amount:          payment.AmountCents,
+			Currency:        payment.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+
+	count, err := suite.service.RunDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	stored, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, stored.Status)
+
+	count, err = suite.service.RunDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func (suite *ScheduleServiceTestSuite) Test_RunDue_FailsExpiredCard() {
+	ctx := context.Background()
+	t := suite.T()
+	payment := suite.schedule(ctx)
+
+	_, err := suite.testDB.DB.Pool.Exec(ctx, "UPDATE payment_methods SET expiry_year = 2020")
+	require.NoError(t, err)
+
+	count, err := suite.service.RunDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	stored, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, stored.Status)
+	require.NotNil(t, stored.FailureReason)
+	assert.Equal(t, domain.FailureReasonCardExpired, *stored.FailureReason)
+
+	var events int
+	err = suite.testDB.DB.Pool.QueryRow(ctx,
+		"SELECT COUNT(*) FROM outbox WHERE payment_id = $1 AND event_type = 'payment.failed'", payment.ID).Scan(&events)
+	require.NoError(t, err)
+	assert.Equal(t, 1, events)
+}
+
+func (suite *ScheduleServiceTestSuite) Test_Schedule_RejectsAnotherCustomersCard() {
+	ctx := context.Background()
+	t := suite.T()
+
+	pm, err := suite.paymentMethods.Save(ctx, &services.SavePaymentMethodCommand{
+		CustomerID:  "cust-owner",
+		CardNumber:  "4111111111111111",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	})
+	require.NoError(t, err)
+
+	_, err = suite.service.Schedule(ctx, &services.ScheduleCommand{
+		OrderID:         "order-" + uuid.New().String(),
+		CustomerID:      "cust-other",
+		Amount:          5000,
+		Currency:        "USD",
+		PaymentMethodID: pm.ID,
+		ScheduledFor:    time.Now().Add(time.Hour),
+	}, "idem-"+uuid.New().String())
+	assert.Error(t, err)
+}
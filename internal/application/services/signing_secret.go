@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+)
+
+// signingSecretBytes is the size of the random secret signed requests are keyed by
+const signingSecretBytes = 32
+
+// SigningSecretService issues the secrets API keys sign requests with. A secret is
+// shown once when it is issued and kept only sealed by the vault.
+type SigningSecretService struct {
+	apiKeyRepo *postgres.APIKeyRepository
+	keyring    *vault.Keyring
+}
+
+func NewSigningSecretService(apiKeyRepo *postgres.APIKeyRepository, keyring *vault.Keyring) *SigningSecretService {
+	return &SigningSecretService{apiKeyRepo: apiKeyRepo, keyring: keyring}
+}
+
+// Issue gives an API key a new signing secret and returns it, hex-encoded. Requests
+// signed with the secret it replaces are rejected from then on. It returns
+// postgres.ErrAPIKeyNotFound for a key that is unknown or revoked.
+func (s *SigningSecretService) Issue(ctx context.Context, keyID string) (string, error) {
+	raw := make([]byte, signingSecretBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", application.NewInternalError(err)
+	}
+	secret := hex.EncodeToString(raw)
+
+	sealed, err := s.keyring.Encrypt([]byte(secret))
+	if err != nil {
+		return "", application.NewInternalError(err)
+	}
+
+	if err := s.apiKeyRepo.SetSigningSecret(ctx, keyID, sealed); err != nil {
+		if errors.Is(err, postgres.ErrAPIKeyNotFound) {
+			return "", err
+		}
+		return "", application.NewInternalError(err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// SoftDeclineRetrier sends the authorization of a soft-declined payment to
+// AuthorizeService.RetrySoftDecline once delay has passed. The card stays in memory
+// until then, so a retry lost to a restart leaves the payment PENDING.
+type SoftDeclineRetrier interface {
+	SubmitRetry(delay time.Duration, payment *domain.Payment, cmd AuthorizeCommand, idempotencyKey string)
+}
+
+type softDeclineRetryKey struct{}
+
+// AllowSoftDeclineRetry lets an authorization made with ctx stay PENDING after a soft
+// decline while it is retried. Only callers that answer with the payment as it stands,
+// rather than acting on its outcome, such as the payments API, should allow it.
+func AllowSoftDeclineRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, softDeclineRetryKey{}, true)
+}
+
+func softDeclineRetryAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(softDeclineRetryKey{}).(bool)
+	return allowed
+}
+
+// retrySoftDecline keeps the payment PENDING with its idempotency key locked and hands
+// it to the retrier, if bankErr is a soft decline that may be retried. It reports
+// whether it did; the payment is otherwise left for HandleBankFailure.
+func (s *AuthorizeService) retrySoftDecline(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string, bankErr error) bool {
+	if s.softDeclineRetrier == nil || !softDeclineRetryAllowed(ctx) {
+		return false
+	}
+	// A network token's cryptogram is good for one authorization only
+	if !cmd.NetworkToken.IsZero() {
+		return false
+	}
+	answer, ok := bank.IsBankError(bankErr)
+	if !ok || !answer.IsSoftDecline() {
+		return false
+	}
+	settings, err := s.settingsRepo.Find(ctx)
+	if err != nil || settings.SkipSoftDeclineRetry {
+		return false
+	}
+
+	payment.RecordError(string(application.CategoryTransient))
+	payment.RecordDecline(answer.Code)
+	payment.ScheduleRetry(s.softDeclineDelay, time.Now())
+	if err := s.paymentRepo.Update(ctx, nil, payment); err != nil {
+		return false
+	}
+
+	s.softDeclineRetrier.SubmitRetry(s.softDeclineDelay, payment, *cmd, idempotencyKey)
+	return true
+}
+
+// RetrySoftDecline sends the authorization of a payment the bank soft-declined once
+// more, and records the outcome for good. The bank would answer its first idempotency
+// key with the first decline, so the retry goes under a key of its own. A payment that
+// is no longer PENDING, such as one the retry worker timed out, is returned as it is.
+func (s *AuthorizeService) RetrySoftDecline(ctx context.Context, payment *domain.Payment, cmd *AuthorizeCommand, idempotencyKey string) (*domain.Payment, error) {
+	current, err := s.paymentRepo.FindByID(ctx, payment.ID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrPaymentNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	if current.Status != domain.StatusPending {
+		return current, nil
+	}
+
+	return s.complete(ctx, current, cmd, idempotencyKey, true)
+}
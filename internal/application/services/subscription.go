@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+)
+
+type CreateSubscriptionCommand struct {
+	CustomerID      string
+	PaymentMethodID string
+	Plan            string
+	Amount          int64
+	Currency        string
+	Interval        domain.BillingInterval
+	StartAt         time.Time
+}
+
+// SubscriptionService charges saved payment methods on a schedule. Every charge is a
+// sale made through AuthorizeService and CaptureService, so it gets the same
+// idempotency and recovery guarantees as a request-driven payment.
+type SubscriptionService struct {
+	subscriptionRepo *postgres.SubscriptionRepository
+	paymentRepo      *postgres.PaymentRepository
+	paymentMethods   *PaymentMethodService
+	authService      *AuthorizeService
+	captureService   *CaptureService
+	dunning          domain.DunningPolicy
+	clock            clock.Clock
+}
+
+func NewSubscriptionService(
+	subscriptionRepo *postgres.SubscriptionRepository,
+	paymentRepo *postgres.PaymentRepository,
+	paymentMethods *PaymentMethodService,
+	authService *AuthorizeService,
+	captureService *CaptureService,
+	dunning domain.DunningPolicy,
+) *SubscriptionService {
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		paymentRepo:      paymentRepo,
+		paymentMethods:   paymentMethods,
+		authService:      authService,
+		captureService:   captureService,
+		dunning:          dunning,
+		clock:            clock.System{},
+	}
+}
+
+// WithClock decides which charges are due, and books the next ones, by c rather than
+// the system clock
+func (s *SubscriptionService) WithClock(c clock.Clock) *SubscriptionService {
+	s.clock = c
+	return s
+}
+
+func (s *SubscriptionService) Create(ctx context.Context, cmd *CreateSubscriptionCommand) (*domain.Subscription, error) {
+	if err := s.authService.checkNewPayment(ctx, cmd.Amount, cmd.Currency); err != nil {
+		return nil, err
+	}
+
+	paymentMethod, err := s.paymentMethods.Get(ctx, cmd.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+	if paymentMethod.CustomerID != cmd.CustomerID {
+		return nil, application.NewInvalidInputError(domain.ErrInvalidPaymentMethod)
+	}
+
+	sub, err := domain.NewSubscription(
+		uuid.New().String(),
+		cmd.CustomerID,
+		paymentMethod.ID,
+		cmd.Plan,
+		cmd.Amount,
+		cmd.Currency,
+		cmd.Interval,
+		cmd.StartAt,
+		s.clock.Now(),
+	)
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, sub); err != nil {
+		if errors.Is(err, postgres.ErrPaymentMethodNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	return sub, nil
+}
+
+func (s *SubscriptionService) Get(ctx context.Context, id string) (*domain.Subscription, error) {
+	sub, err := s.subscriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, postgres.ErrSubscriptionNotFound) {
+			return nil, err
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return sub, nil
+}
+
+// Cancel stops future charges. A charge already in flight still completes.
+func (s *SubscriptionService) Cancel(ctx context.Context, id string) (*domain.Subscription, error) {
+	sub, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	readNextChargeAt := sub.NextChargeAt
+	if err := sub.Cancel(time.Now()); err != nil {
+		return nil, application.NewInvalidStateError(err)
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, sub, readNextChargeAt); err != nil {
+		if errors.Is(err, postgres.ErrSubscriptionConflict) {
+			return nil, application.NewInvalidStateError(err)
+		}
+		return nil, application.NewInternalError(err)
+	}
+
+	return sub, nil
+}
+
+// ChargeDue runs the charges of up to limit due subscriptions and returns how many
+// were settled, whether charged or declined.
+func (s *SubscriptionService) ChargeDue(ctx context.Context, limit int) (int, error) {
+	due, err := s.subscriptionRepo.FindDue(ctx, s.clock.Now(), limit)
+	if err != nil {
+		return 0, application.NewInternalError(err)
+	}
+
+	var settled int
+	var errs []error
+	for _, sub := range due {
+		ok, err := s.charge(ctx, sub)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", sub.ID, err))
+		}
+		if ok {
+			settled++
+		}
+	}
+
+	return settled, errors.Join(errs...)
+}
+
+// charge drives the current charge of sub one step further. The charge is resumed
+// from the payment its key already points to, so a crash or a transient bank error
+// only delays it: the next run picks up where this one stopped. It reports whether
+// the charge was settled.
+func (s *SubscriptionService) charge(ctx context.Context, sub *domain.Subscription) (bool, error) {
+	ctx = postgres.WithMerchant(ctx, sub.MerchantID)
+	readNextChargeAt := sub.NextChargeAt
+	key := sub.ChargeKey()
+
+	payment, err := s.paymentRepo.FindByIdempotencyKey(ctx, key+"-auth")
+	if err != nil && !errors.Is(err, postgres.ErrPaymentNotFound) {
+		return false, err
+	}
+
+	if payment == nil {
+		payment, err = s.authorize(ctx, sub, key)
+		if payment == nil {
+			return false, err
+		}
+	}
+
+	if payment.Status == domain.StatusAuthorized {
+		captured, err := s.captureService.Capture(ctx, payment.ID, 0, key+"-capture")
+		if captured == nil {
+			return false, err
+		}
+		payment = captured
+	}
+
+	now := s.clock.Now()
+	switch payment.Status {
+	case domain.StatusCaptured, domain.StatusRefunding, domain.StatusRefunded:
+		err = sub.RecordCharge(payment.ID, now)
+	case domain.StatusFailed, domain.StatusVoided, domain.StatusExpired:
+		err = sub.RecordDecline(payment.ID, s.dunning, now)
+	default:
+		// Still in flight; the retry worker or a later run finishes it
+		return false, err
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := s.subscriptionRepo.Update(ctx, sub, readNextChargeAt); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// authorize starts the sale for the charge identified by key. A decline comes back as
+// a FAILED payment alongside the bank error.
+func (s *SubscriptionService) authorize(ctx context.Context, sub *domain.Subscription, key string) (*domain.Payment, error) {
+	paymentMethod, err := s.paymentMethods.Get(ctx, sub.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+
+	cardNumber, err := s.paymentMethods.CardNumber(ctx, sub.PaymentMethodID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := &AuthorizeCommand{
+		OrderID:         key,
+		CustomerID:      sub.CustomerID,
+		Amount:          sub.AmountCents,
+		Currency:        sub.Currency,
+		CardNumber:      cardNumber,
+		ExpiryMonth:     paymentMethod.ExpiryMonth,
+		ExpiryYear:      paymentMethod.ExpiryYear,
+		PaymentMethodID: paymentMethod.ID,
+	}
+
+	return s.authService.Authorize(ctx, cmd, key+"-auth")
+}
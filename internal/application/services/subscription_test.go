@@ -0,0 +1,206 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SubscriptionServiceTestSuite struct {
+	suite.Suite
+	testDB         *testhelpers.TestDatabase
+	paymentRepo    *postgres.PaymentRepository
+	mockBank       *mocks.MockBankClient
+	paymentMethods *services.PaymentMethodService
+	service        *services.SubscriptionService
+}
+
+func TestSubscriptionServiceSuite(t *testing.T) {
+	suite.Run(t, new(SubscriptionServiceTestSuite))
+}
+
+func (suite *SubscriptionServiceTestSuite) SetupSuite() {
+	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
+	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
+}
+
+func (suite *SubscriptionServiceTestSuite) TearDownSuite() {
+	suite.testDB.Cleanup(suite.T())
+}
+
+func (suite *SubscriptionServiceTestSuite) SetupTest() {
+	suite.testDB.CleanTables(suite.T())
+	suite.mockBank = mocks.NewMockBankClient(suite.T())
+
+	keyring, err := vault.ParseKeyring("", base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	require.NoError(suite.T(), err)
+
+	idempotencyRepo := postgres.NewIdempotencyRepository(suite.testDB.DB)
+	operationRepo := postgres.NewOperationRepository(suite.testDB.DB)
+	suite.paymentMethods = services.NewPaymentMethodService(postgres.NewPaymentMethodRepository(suite.testDB.DB), keyring)
+	suite.service = services.NewSubscriptionService(
+		postgres.NewSubscriptionRepository(suite.testDB.DB),
+		suite.paymentRepo,
+		suite.paymentMethods,
+		services.NewAuthorizeService(suite.paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(suite.testDB.DB), suite.mockBank, suite.testDB.DB, services.AuthorizeLimits{}),
+		services.NewCaptureService(suite.paymentRepo, idempotencyRepo, operationRepo, suite.mockBank, suite.testDB.DB),
+		domain.DunningPolicy{RetryDelays: []time.Duration{time.Hour}},
+	)
+}
+
+func (suite *SubscriptionServiceTestSuite) TearDownTest() {
+	suite.testDB.CleanTables(suite.T())
+}
+
+// subscribe creates a monthly subscription that is due right away
+func (suite *SubscriptionServiceTestSuite) subscribe(ctx context.Context) *domain.Subscription {
+	t := suite.T()
+	customerID := "cust-" + uuid.New().String()
+
+	pm, err := suite.paymentMethods.Save(ctx, &services.SavePaymentMethodCommand{
+		CustomerID:  customerID,
+		CardNumber:  "4111111111111111",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	})
+	require.NoError(t, err)
+
+	sub, err := suite.service.Create(ctx, &services.CreateSubscriptionCommand{
+		CustomerID:      customerID,
+		PaymentMethodID: pm.ID,
+		Plan:            "pro-monthly",
+		Amount:          1500,
+		Currency:        "USD",
+		Interval:        domain.IntervalMonth,
+		StartAt:         time.Now().Add(-time.Minute),
+	})
+	require.NoError(t, err)
+
+	return sub
+}
+
+func (suite *SubscriptionServiceTestSuite) Test_ChargeDue_SellsAndAdvances() {
+	ctx := context.Background()
+	t := suite.T()
+	sub := suite.subscribe(ctx)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, mock.Anything).
+		Return(&bank.AuthorizationResponse{
+			Amount:          sub.AmountCents,
+			Currency:        sub.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-123",
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, mock.Anything, mock.Anything).
+		Return(&bank.CaptureResponse{
+			Amount:          sub.AmountCents,
+			Currency:        sub.Currency,
+			AuthorizationID: "auth-123",
+			Status:          "captured",
+			CaptureID:       "cap-123",
+			CapturedAt:      time.Now(),
+		}, nil).
+		Once()
+
+	settled, err := suite.service.ChargeDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, settled)
+
+	stored, err := suite.service.Get(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionActive, stored.Status)
+	assert.True(t, stored.NextChargeAt.After(time.Now()))
+	require.NotNil(t, stored.LastPaymentID)
+
+	payment, err := suite.paymentRepo.FindByID(ctx, *stored.LastPaymentID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, payment.Status)
+
+	settled, err = suite.service.ChargeDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, settled)
+}
+
+func (suite *SubscriptionServiceTestSuite) Test_ChargeDue_DeclineStartsDunning() {
+	ctx := context.Background()
+	t := suite.T()
+	sub := suite.subscribe(ctx)
+
+	suite.mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &bank.BankError{Code: "insufficient_funds", StatusCode: 402}).
+		Once()
+
+	settled, err := suite.service.ChargeDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, settled)
+
+	stored, err := suite.service.Get(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionPastDue, stored.Status)
+	assert.Equal(t, 1, stored.FailedAttempts)
+	assert.True(t, stored.NextChargeAt.After(time.Now()))
+}
+
+func (suite *SubscriptionServiceTestSuite) Test_ChargeDue_ExpiredCardIsDeclinedLocally() {
+	ctx := context.Background()
+	t := suite.T()
+	sub := suite.subscribe(ctx)
+
+	// The card expired after it was saved; the bank mock fails the test if it is asked
+	_, err := suite.testDB.DB.Pool.Exec(ctx, "UPDATE payment_methods SET expiry_year = 2020 WHERE id = $1", sub.PaymentMethodID)
+	require.NoError(t, err)
+
+	settled, err := suite.service.ChargeDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, settled)
+
+	stored, err := suite.service.Get(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionPastDue, stored.Status)
+	require.NotNil(t, stored.LastPaymentID)
+
+	payment, err := suite.paymentRepo.FindByID(ctx, *stored.LastPaymentID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusFailed, payment.Status)
+	require.NotNil(t, payment.FailureReason)
+	assert.Equal(t, domain.FailureReasonCardExpired, *payment.FailureReason)
+	require.NotNil(t, payment.DeclineCategory)
+	assert.Equal(t, domain.DeclineCustomerActionRequired, *payment.DeclineCategory)
+}
+
+func (suite *SubscriptionServiceTestSuite) Test_Cancel() {
+	ctx := context.Background()
+	t := suite.T()
+	sub := suite.subscribe(ctx)
+
+	canceled, err := suite.service.Cancel(ctx, sub.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.SubscriptionCanceled, canceled.Status)
+
+	settled, err := suite.service.ChargeDue(ctx, 10)
+	require.NoError(t, err)
+	assert.Zero(t, settled)
+
+	_, err = suite.service.Cancel(ctx, sub.ID)
+	assert.Error(t, err)
+}
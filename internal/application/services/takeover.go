@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// takeOverStaleLock settles a capture or refund whose request died holding its
+// idempotency lock, so the client retrying it is answered instead of told to wait.
+func (s *CaptureService) takeOverStaleLock(ctx context.Context, key *postgres.IdempotencyKey) (*domain.Payment, error) {
+	return takeOverStaleLock(ctx, s.db, s.paymentRepo, s.idempotencyRepo, s.operationRepo, s.bankClient, key)
+}
+
+func (s *RefundService) takeOverStaleLock(ctx context.Context, key *postgres.IdempotencyKey) (*domain.Payment, error) {
+	return takeOverStaleLock(ctx, s.db, s.paymentRepo, s.idempotencyRepo, s.operationRepo, s.bankClient, key)
+}
+
+// takeOverStaleLock claims a stale lock and asks the bank what became of the request
+// that held it. A capture or refund the bank carried out is completed; one it has no
+// record of, whose key never got past CALLING_BANK, is rolled back. Either way the lock
+// is released and the payment returned. A payment that already left its intermediate
+// state only has its lock released. Anything the bank cannot confirm is left to the
+// retry worker, and the caller is told the request is still processing.
+func takeOverStaleLock(
+	ctx context.Context,
+	db *postgres.DB,
+	paymentRepo *postgres.PaymentRepository,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	bankClient bank.BankClient,
+	key *postgres.IdempotencyKey,
+) (*domain.Payment, error) {
+	payment, err := paymentRepo.FindByID(ctx, key.PaymentID)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	//nolint:exhaustive // every other status is already settled
+	switch payment.Status {
+	case domain.StatusCapturing, domain.StatusRefunding:
+	case domain.StatusPending, domain.StatusVoiding, domain.StatusReauthorizing:
+		// The bank cannot be asked about these, so they stay with the retry worker
+		return nil, application.NewRequestProcessingError(payment.ID)
+	default:
+		return releaseStaleLock(ctx, db, idempotencyRepo, key, payment)
+	}
+
+	claimed, err := idempotencyRepo.TakeOverLock(ctx, key.Key, *key.LockedAt)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	op, err := operationRepo.FindByIdempotencyKey(ctx, key.Key)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	ctx = bank.WithAcquirer(ctx, payment.Acquirer)
+	var resp any
+	if payment.Status == domain.StatusCapturing {
+		var capture *bank.CaptureResponse
+		if capture, err = bankClient.GetCapture(ctx, key.Key); err == nil {
+			resp = capture
+			err = payment.Capture(capture.Status, capture.CaptureID, op.AmountCents, capture.CapturedAt)
+		}
+	} else {
+		var refund *bank.RefundResponse
+		if refund, err = bankClient.GetRefund(ctx, key.Key); err == nil {
+			resp = refund
+			err = payment.Refund(refund.RefundID, op.AmountCents, refund.RefundedAt)
+		}
+	}
+
+	if err == nil {
+		if err := FinalizePayment(ctx, db, paymentRepo, idempotencyRepo, operationRepo, payment, key.Key, resp); err != nil {
+			return nil, err
+		}
+		return payment, nil
+	}
+
+	if bankErr, ok := bank.IsBankError(err); ok && bankErr.StatusCode == http.StatusNotFound &&
+		key.RecoveryPoint == postgres.RecoveryCallingBank {
+		if rbErr := RollBackOperation(ctx, db, paymentRepo, idempotencyRepo, operationRepo, payment, key.Key, err); !errors.Is(rbErr, err) {
+			return nil, rbErr
+		}
+		return payment, nil
+	}
+
+	return nil, application.NewRequestProcessingError(payment.ID)
+}
+
+// releaseStaleLock frees a lock whose request has been settled and returns its payment
+func releaseStaleLock(
+	ctx context.Context,
+	db *postgres.DB,
+	idempotencyRepo *postgres.IdempotencyRepository,
+	key *postgres.IdempotencyKey,
+	payment *domain.Payment,
+) (*domain.Payment, error) {
+	err := runInTx(ctx, db, func(tx pgx.Tx) error {
+		if err := idempotencyRepo.ReleaseLock(ctx, tx, key.Key); err != nil {
+			return application.NewInternalError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payment, nil
+}
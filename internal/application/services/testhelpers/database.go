@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"testing"
 	"time"
 
@@ -68,7 +69,7 @@ func SetupTestDatabase(t *testing.T) *TestDatabase {
 		Level: slog.LevelError,
 	}))
 
-	db, err := postgres.Connect(ctx, dbConfig, logger)
+	db, err := postgres.Connect(ctx, dbConfig, nil, logger)
 	require.NoError(t, err)
 
 	err = runMigrations(ctx, db)
@@ -90,7 +91,10 @@ func (td *TestDatabase) Cleanup(t *testing.T) {
 func (td *TestDatabase) CleanTables(t *testing.T) {
 	ctx := context.Background()
 
-	_, err := td.DB.Pool.Exec(ctx, "TRUNCATE TABLE idempotency_keys, payments RESTART IDENTITY CASCADE;")
+	_, err := td.DB.Pool.Exec(ctx, "TRUNCATE TABLE idempotency_keys, payments, payment_groups, payment_methods, subscriptions, payment_intents, payouts, payment_batches, api_keys, merchant_settings, merchant_quota_usage, erasures, audit_log, feature_flags, feature_flag_overrides, region_lease, outbox_pause RESTART IDENTITY CASCADE;")
+	require.NoError(t, err)
+
+	_, err = td.DB.Pool.Exec(ctx, "DELETE FROM merchants WHERE id <> 'default';")
 	require.NoError(t, err)
 }
 
@@ -101,16 +105,22 @@ func getProjectRoot() string {
 
 func runMigrations(ctx context.Context, db *postgres.DB) error {
 	root := getProjectRoot()
-	migrationPath := filepath.Join(root, "db", "migrations", "001_init.up.sql")
-
-	migrationSQL, err := os.ReadFile(migrationPath) //nolint:gosec // test helper, controlled path
+	migrationPaths, err := filepath.Glob(filepath.Join(root, "db", "migrations", "*.up.sql"))
 	if err != nil {
-		return fmt.Errorf("read migration file from %s: %w", migrationPath, err)
+		return fmt.Errorf("list migration files: %w", err)
 	}
-
-	_, err = db.Pool.Exec(ctx, string(migrationSQL))
-	if err != nil {
-		return fmt.Errorf("execute migration: %w", err)
+	sort.Strings(migrationPaths)
+
+	for _, migrationPath := range migrationPaths {
+		migrationSQL, err := os.ReadFile(migrationPath) //nolint:gosec // test helper, controlled path
+		if err != nil {
+			return fmt.Errorf("read migration file from %s: %w", migrationPath, err)
+		}
+
+		_, err = db.Pool.Exec(ctx, string(migrationSQL))
+		if err != nil {
+			return fmt.Errorf("execute migration %s: %w", filepath.Base(migrationPath), err)
+		}
 	}
 
 	return nil
@@ -82,7 +82,7 @@ func CreateCapturedPayment(
 		Return(captureResp, nil).
 		Once()
 
-	capturedPayment, err := captureService.Capture(ctx, payment.ID, idempotencyKey)
+	capturedPayment, err := captureService.Capture(ctx, payment.ID, 0, idempotencyKey)
 	require.NoError(t, err)
 
 	return capturedPayment
@@ -111,7 +111,7 @@ func CreateVoidedPayment(
 		Return(voidResp, nil).
 		Once()
 
-	voidPayment, err := voidService.Void(ctx, payment.ID, idempotencyKey)
+	voidPayment, err := voidService.Void(ctx, payment.ID, "", 0, idempotencyKey)
 	require.NoError(t, err)
 
 	return voidPayment
@@ -143,7 +143,7 @@ func CreateRefundedPayment(
 		Return(refundResp, nil).
 		Once()
 
-	refundedPayment, err := refundService.Refund(ctx, payment.ID, idempotencyKey)
+	refundedPayment, err := refundService.Refund(ctx, payment.ID, 0, "", idempotencyKey)
 	require.NoError(t, err)
 
 	return refundedPayment
@@ -0,0 +1,72 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	raiseSerializationFailure = `DO $$ BEGIN RAISE EXCEPTION 'concurrent update' USING ERRCODE = 'serialization_failure'; END $$`
+	raiseDeadlock             = `DO $$ BEGIN RAISE EXCEPTION 'deadlock' USING ERRCODE = 'deadlock_detected'; END $$`
+)
+
+func TestRunInTx_RunsAbortedTransactionsAgain(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	for _, tt := range []struct {
+		name  string
+		raise string
+	}{
+		{"a deadlock is run again", raiseDeadlock},
+		{"a serialization failure is run again", raiseSerializationFailure},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int
+			err := testDB.DB.RunInTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+				runs++
+				if runs == 1 {
+					_, err := tx.Exec(ctx, tt.raise)
+					return err
+				}
+				return nil
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, 2, runs)
+		})
+	}
+
+	t.Run("gives up after three runs", func(t *testing.T) {
+		var runs int
+		err := testDB.DB.RunInTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			runs++
+			_, err := tx.Exec(ctx, raiseSerializationFailure)
+			return err
+		})
+
+		assert.True(t, postgres.IsSerializationFailure(err))
+		assert.Equal(t, 3, runs)
+	})
+
+	t.Run("other errors are returned at once", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		var runs int
+		err := testDB.DB.RunInTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+			runs++
+			return errBoom
+		})
+
+		assert.ErrorIs(t, err, errBoom)
+		assert.Equal(t, 1, runs)
+	})
+}
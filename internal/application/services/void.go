@@ -10,9 +10,25 @@ import (
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
 )
 
+type voidRequest struct {
+	PaymentID string
+	Reason    domain.OperationReason
+	Amount    int64
+}
+
+func (r voidRequest) hashFields() map[string]any {
+	fields := map[string]any{"payment_id": r.PaymentID, "reason": string(r.Reason)}
+	// Left out when zero so that keys stored before voids took an amount still match
+	if r.Amount != 0 {
+		fields["amount"] = r.Amount
+	}
+	return fields
+}
+
 type VoidService struct {
 	paymentRepo     *postgres.PaymentRepository
 	idempotencyRepo *postgres.IdempotencyRepository
+	operationRepo   *postgres.OperationRepository
 	bankClient      bank.BankClient
 	db              *postgres.DB
 }
@@ -20,19 +36,35 @@ type VoidService struct {
 func NewVoidService(
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
 	bankClient bank.BankClient,
 	db *postgres.DB,
 ) *VoidService {
 	return &VoidService{
 		paymentRepo:     paymentRepo,
 		idempotencyRepo: idempotencyRepo,
+		operationRepo:   operationRepo,
 		bankClient:      bankClient,
 		db:              db,
 	}
 }
 
-func (s *VoidService) Void(ctx context.Context, paymentID, idempotencyKey string) (*domain.Payment, error) {
-	requestHash := ComputeHash(paymentID)
+// Void voids the payment. reason is optional and is recorded on the operation for
+// finance categorization. On a partially captured payment, Void releases amount of
+// what was left to capture instead, or all of it when amount is zero; otherwise
+// amount must be zero or the whole authorization.
+func (s *VoidService) Void(
+	ctx context.Context,
+	paymentID string,
+	reason domain.OperationReason,
+	amount int64,
+	idempotencyKey string,
+) (*domain.Payment, error) {
+	if err := reason.Validate(); err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+
+	requestHash := ComputeHash(voidRequest{PaymentID: paymentID, Reason: reason, Amount: amount})
 
 	cachedPayment, isCached, err := checkIdempotency(
 		ctx,
@@ -40,6 +72,7 @@ func (s *VoidService) Void(ctx context.Context, paymentID, idempotencyKey string
 		s.paymentRepo,
 		idempotencyKey,
 		requestHash,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -48,21 +81,34 @@ func (s *VoidService) Void(ctx context.Context, paymentID, idempotencyKey string
 		return cachedPayment, nil
 	}
 
+	voidAmount := amount
 	payment, err := markPaymentTransitioning(
 		ctx,
 		s.db,
 		s.paymentRepo,
 		s.idempotencyRepo,
+		s.operationRepo,
 		paymentID,
 		idempotencyKey,
 		requestHash,
-		func(p *domain.Payment) error {
-			return p.MarkVoiding()
+		reason,
+		func(p *domain.Payment) (int64, error) {
+			if p.CapturedAmountCents > 0 {
+				if voidAmount == 0 {
+					voidAmount = p.RemainingCaptureAmount()
+				}
+				return voidAmount, p.MarkReleasing(voidAmount)
+			}
+			if voidAmount != 0 && voidAmount != p.AmountCents {
+				return 0, domain.ErrInvalidAmount
+			}
+			return p.AmountCents, p.MarkVoiding()
 		},
+		nil,
 	)
 	if err != nil {
 		if errors.Is(err, postgres.ErrDuplicateIdempotencyKey) {
-			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey)
+			return waitForCompletion(ctx, s.idempotencyRepo, s.paymentRepo, idempotencyKey, nil)
 		}
 		return nil, err
 	}
@@ -70,24 +116,35 @@ func (s *VoidService) Void(ctx context.Context, paymentID, idempotencyKey string
 	bankReq := bank.VoidRequest{
 		AuthorizationID: *payment.BankAuthID,
 	}
+	releasing := payment.CapturedAmountCents > 0
+	if releasing {
+		bankReq.Amount = voidAmount
+	}
 
-	bankResp, err := s.bankClient.Void(ctx, bankReq, idempotencyKey)
+	bankResp, err := s.bankClient.Void(bank.WithAcquirer(ctx, payment.Acquirer), bankReq, idempotencyKey)
 	if err != nil {
-		return payment, HandleBankFailure(
+		err = HandleBankFailure(
 			ctx,
 			s.db,
 			s.paymentRepo,
 			s.idempotencyRepo,
+			s.operationRepo,
 			payment,
 			idempotencyKey,
 			err,
 		)
+		return payment, wakeRetryWorkers(ctx, s.db, payment, err)
 	}
-	if err := payment.Void(bankResp.Status, bankResp.VoidID, bankResp.VoidedAt); err != nil {
+	if releasing {
+		err = payment.Release(bankResp.Status, bankResp.VoidID, voidAmount, bankResp.VoidedAt)
+	} else {
+		err = payment.Void(bankResp.Status, bankResp.VoidID, bankResp.VoidedAt)
+	}
+	if err != nil {
 		return nil, application.NewInvalidStateError(err)
 	}
 
-	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, payment, idempotencyKey, bankResp); err != nil {
+	if err := FinalizePayment(ctx, s.db, s.paymentRepo, s.idempotencyRepo, s.operationRepo, payment, idempotencyKey, bankResp); err != nil {
 		return payment, err
 	}
 
@@ -25,6 +25,7 @@ type voidServiceTestSuite struct {
 	testDB           *testhelpers.TestDatabase
 	paymentRepo      *postgres.PaymentRepository
 	idempotencyRepo  *postgres.IdempotencyRepository
+	operationRepo    *postgres.OperationRepository
 	mockBank         *mocks.MockBankClient
 	authorizeService *services.AuthorizeService
 	voidService      *services.VoidService
@@ -38,6 +39,7 @@ func (suite *voidServiceTestSuite) SetupSuite() {
 	suite.testDB = testhelpers.SetupTestDatabase(suite.T())
 	suite.paymentRepo = postgres.NewPaymentRepository(suite.testDB.DB)
 	suite.idempotencyRepo = postgres.NewIdempotencyRepository(suite.testDB.DB)
+	suite.operationRepo = postgres.NewOperationRepository(suite.testDB.DB)
 }
 
 func (suite *voidServiceTestSuite) TearDownSuite() {
@@ -50,13 +52,16 @@ func (suite *voidServiceTestSuite) SetupTest() {
 	suite.authorizeService = services.NewAuthorizeService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(suite.testDB.DB),
 		suite.mockBank,
 		suite.testDB.DB,
+		services.AuthorizeLimits{},
 	)
 
 	suite.voidService = services.NewVoidService(
 		suite.paymentRepo,
 		suite.idempotencyRepo,
+		suite.operationRepo,
 		suite.mockBank,
 		suite.testDB.DB,
 	)
@@ -123,7 +128,7 @@ func (suite *voidServiceTestSuite) Test_Void_CannotVoidPendingPayment() {
 
 	VoidKey := "idem-Void-" + uuid.New().String()
 
-	_, err = suite.voidService.Void(ctx, payment.ID, VoidKey)
+	_, err = suite.voidService.Void(ctx, payment.ID, "", 0, VoidKey)
 
 	svcErr, ok := application.IsServiceError(err)
 	require.True(t, ok)
@@ -150,12 +155,12 @@ func (suite *voidServiceTestSuite) Test_Void_CannotVoidAlreadyVoidedPayment() {
 		Return(VoidResp, nil).
 		Once()
 
-	_, err := suite.voidService.Void(ctx, payment.ID, firstKey)
+	_, err := suite.voidService.Void(ctx, payment.ID, "", 0, firstKey)
 	require.NoError(t, err)
 
 	secondKey := "idem-second-" + uuid.New().String()
 
-	_, err = suite.voidService.Void(ctx, payment.ID, secondKey)
+	_, err = suite.voidService.Void(ctx, payment.ID, "", 0, secondKey)
 
 	require.Error(t, err)
 
@@ -164,6 +169,85 @@ func (suite *voidServiceTestSuite) Test_Void_CannotVoidAlreadyVoidedPayment() {
 	assert.Equal(t, application.ErrCodeInvalidState, svcErr.Code)
 }
 
+func (suite *voidServiceTestSuite) Test_Void_ReleasesRemainderAfterPartialCapture() {
+	t := suite.T()
+	ctx := context.Background()
+
+	payment := testhelpers.CreateAuthorizedPayment(t, ctx, suite.authorizeService, suite.mockBank)
+	captureService := services.NewCaptureService(suite.paymentRepo, suite.idempotencyRepo, suite.operationRepo, suite.mockBank, suite.testDB.DB)
+	captured := payment.AmountCents / 2
+
+	captureKey := "idem-capt-" + uuid.New().String()
+	suite.mockBank.EXPECT().
+		Capture(mock.Anything, mock.Anything, captureKey).
+		Return(&bank.CaptureResponse{
+			Amount:          captured,
+			AuthorizationID: *payment.BankAuthID,
+			Status:          "captured",
+			CaptureID:       "cap-123",
+			CapturedAt:      time.Now(),
+		}, nil).
+		Once()
+	_, err := captureService.Capture(ctx, payment.ID, captured, captureKey)
+	require.NoError(t, err)
+
+	// The whole authorization can no longer be voided once part of it was captured
+	_, err = suite.voidService.Void(ctx, payment.ID, "", payment.AmountCents, "idem-"+uuid.New().String())
+	require.Error(t, err)
+
+	voidKey := "idem-void-" + uuid.New().String()
+	suite.mockBank.EXPECT().
+		Void(mock.Anything, bank.VoidRequest{AuthorizationID: *payment.BankAuthID, Amount: payment.AmountCents - captured}, voidKey).
+		Return(&bank.VoidResponse{
+			AuthorizationID: *payment.BankAuthID,
+			VoidID:          "void-123",
+			Status:          "voided",
+			VoidedAt:        time.Now(),
+		}, nil).
+		Once()
+
+	released, err := suite.voidService.Void(ctx, payment.ID, "", 0, voidKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, released.Status)
+	assert.Equal(t, payment.AmountCents-captured, released.ReleasedAmountCents)
+	assert.Zero(t, released.RemainingCaptureAmount())
+
+	saved, err := suite.paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, saved.Status)
+	assert.Equal(t, captured, saved.CapturedAmountCents)
+	assert.Equal(t, payment.AmountCents-captured, saved.ReleasedAmountCents)
+	assert.Equal(t, "void-123", *saved.BankVoidID)
+}
+
+func (suite *voidServiceTestSuite) Test_Void_VoidedPaymentCannotBeResurrected() {
+	t := suite.T()
+	ctx := context.Background()
+
+	voided := testhelpers.CreateVoidedPayment(t, ctx, suite.authorizeService, suite.voidService, suite.mockBank)
+
+	// A worker holding a stale copy writes it back as AUTHORIZED
+	voided.Status = domain.StatusAuthorized
+	err := suite.paymentRepo.Update(ctx, nil, voided)
+	require.ErrorIs(t, err, postgres.ErrPaymentTerminal)
+
+	_, err = suite.testDB.DB.Pool.Exec(ctx, `UPDATE payments SET status = 'CAPTURED' WHERE id = $1`, voided.ID)
+	require.Error(t, err, "the database refuses it whichever statement tries")
+
+	saved, err := suite.paymentRepo.FindByID(ctx, voided.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusVoided, saved.Status)
+
+	// An operator's correction turns the guard off for its own transaction
+	tx, err := suite.testDB.DB.Pool.Begin(ctx)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `SET LOCAL app.allow_terminal_update = 'on'`)
+	require.NoError(t, err)
+	_, err = tx.Exec(ctx, `UPDATE payments SET status = 'AUTHORIZED' WHERE id = $1`, voided.ID)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit(ctx))
+}
+
 func (suite *voidServiceTestSuite) Test_Void_IdempotencyReturnsCache() {
 	t := suite.T()
 	ctx := context.Background()
@@ -184,10 +268,10 @@ func (suite *voidServiceTestSuite) Test_Void_IdempotencyReturnsCache() {
 		Return(VoidResp, nil).
 		Once()
 
-	firstResult, err := suite.voidService.Void(ctx, payment.ID, idempotencyKey)
+	firstResult, err := suite.voidService.Void(ctx, payment.ID, "", 0, idempotencyKey)
 	require.NoError(t, err)
 
-	secondResult, err := suite.voidService.Void(ctx, payment.ID, idempotencyKey)
+	secondResult, err := suite.voidService.Void(ctx, payment.ID, "", 0, idempotencyKey)
 	require.NoError(t, err)
 
 	assert.Equal(t, firstResult.ID, secondResult.ID)
@@ -202,7 +286,7 @@ func (suite *voidServiceTestSuite) Test_Void_PaymentNotFound() {
 
 	idempotencyKey := "idem-" + uuid.New().String()
 
-	_, err := suite.voidService.Void(ctx, paymentID, idempotencyKey)
+	_, err := suite.voidService.Void(ctx, paymentID, "", 0, idempotencyKey)
 
 	svcErr, ok := application.IsServiceError(err)
 	require.True(t, ok)
@@ -232,7 +316,7 @@ func (suite *voidServiceTestSuite) Test_Void_BankReturns500_PaymentStaysVoiding(
 		Return(nil, bankErr).
 		Once()
 
-	voidedPayment, err := suite.voidService.Void(ctx, payment.ID, idempotencyKey)
+	voidedPayment, err := suite.voidService.Void(ctx, payment.ID, "", 0, idempotencyKey)
 
 	require.Error(t, err)
 
@@ -264,7 +348,7 @@ func (suite *voidServiceTestSuite) Test_Void_BankReturnsPermanentError_PaymentFa
 		Return(nil, bankErr).
 		Once()
 
-	voidedPayment, err := suite.voidService.Void(ctx, payment.ID, idempotencyKey)
+	voidedPayment, err := suite.voidService.Void(ctx, payment.ID, "", 0, idempotencyKey)
 
 	require.Error(t, err)
 
@@ -297,7 +381,7 @@ func (suite *voidServiceTestSuite) Test_Void_ConcurrentRequests_OnlyOneSucceeds(
 
 	for range 2 {
 		wg.Go(func() {
-			_, err := suite.voidService.Void(ctx, payment.ID, idempotencyKey)
+			_, err := suite.voidService.Void(ctx, payment.ID, "", 0, idempotencyKey)
 			results <- err
 		})
 	}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"log/slog"
 	"os"
 	"strings"
@@ -13,47 +14,120 @@ import (
 )
 
 type Config struct {
-	Primary    Primary        `koanf:"primary"`
-	Server     ServerConfig   `koanf:"server"`
-	Database   DatabaseConfig `koanf:"database"`
-	BankClient BankConfig     `koanf:"bank_client"`
-	Retry      RetryConfig    `koanf:"retry"`
-	Logger     LoggerConfig   `koanf:"logger"`
-	Worker     WorkerConfig   `koanf:"worker"`
+	Primary       Primary             `koanf:"primary"`
+	Server        ServerConfig        `koanf:"server"`
+	Database      DatabaseConfig      `koanf:"database"`
+	BankClient    BankConfig          `koanf:"bank_client"`
+	BankRateLimit BankRateLimitConfig `koanf:"bank_rate_limit"`
+	Retry         RetryConfig         `koanf:"retry"`
+	Shadow        ShadowConfig        `koanf:"shadow"`
+	Canary        CanaryConfig        `koanf:"canary"`
+	Synthetic     SyntheticConfig     `koanf:"synthetic"`
+	Alerts        AlertConfig         `koanf:"alerts"`
+	Notifications NotificationConfig  `koanf:"notifications"`
+	Cache         CacheConfig         `koanf:"cache"`
+	Vault         VaultConfig         `koanf:"vault"`
+	Logger        LoggerConfig        `koanf:"logger"`
+	Worker        WorkerConfig        `koanf:"worker"`
+	Auth          AuthConfig          `koanf:"auth"`
+	Retention     RetentionConfig     `koanf:"retention"`
+	Limits        LimitsConfig        `koanf:"limits"`
+	Features      FeaturesConfig      `koanf:"features"`
+	Region        RegionConfig        `koanf:"region"`
+	Chaos         ChaosConfig         `koanf:"chaos"`
+	Clock         ClockConfig         `koanf:"clock"`
 }
 
 type WorkerConfig struct {
 	Interval  time.Duration `koanf:"interval" validate:"required"`
 	BatchSize int           `koanf:"batch_size" validate:"required"`
+	// RetryBatchSizes caps the payments of each status the retry worker resumes per
+	// pass, as status:size entries such as capturing:100,refunding:20; statuses left
+	// out take BatchSize
+	RetryBatchSizes string `koanf:"retry_batch_sizes"`
+	// RetryMaxAttempts caps the bank calls the retry worker makes for a payment of each
+	// status, as status:attempts entries; statuses left out take Retry.MaxRetries.
+	// RetryExhausted is what then happens to it: alert, the default, fail or dead_letter.
+	RetryMaxAttempts string `koanf:"retry_max_attempts"`
+	RetryExhausted   string `koanf:"retry_exhausted" validate:"omitempty,oneof=alert fail dead_letter"`
+	// RetryMaxBackoffs caps the wait between the retry worker's attempts at a payment of
+	// each status, as status:duration entries such as capturing:24h,voiding:4m; statuses
+	// left out take Retry.MaxBackoff minutes
+	RetryMaxBackoffs     string `koanf:"retry_max_backoffs"`
+	AuthorizeQueueSize   int    `koanf:"authorize_queue_size" validate:"required"`
+	AuthorizeConcurrency int    `koanf:"authorize_concurrency" validate:"required"`
+	// SoftDeclineRetryDelay is how long after a soft decline, one the bank says may
+	// pass later, an API authorization is sent once more before the payment fails.
+	// Zero fails it at once.
+	SoftDeclineRetryDelay time.Duration `koanf:"soft_decline_retry_delay"`
+	OutboxInterval        time.Duration `koanf:"outbox_interval" validate:"required"`
+	SchedulerInterval     time.Duration `koanf:"scheduler_interval" validate:"required"`
+	// External moves the background workers out of the API server into the worker
+	// process; the server then only runs the authorize queue its requests feed
+	External bool `koanf:"external"`
+	// ReconcileInterval is how often every merchant's payments of the last
+	// ReconcileWindow are reconciled with the bank. Zero turns it off.
+	ReconcileInterval time.Duration `koanf:"reconcile_interval"`
+	ReconcileWindow   time.Duration `koanf:"reconcile_window" validate:"required_with=ReconcileInterval"`
 }
 
 type Primary struct {
 	Env string `koanf:"env" validate:"required"`
 }
 
+// Production reports whether Env names a production deployment
+func (p Primary) Production() bool {
+	env := strings.ToLower(p.Env)
+	return env == "production" || env == "prod"
+}
+
+// ServerConfig holds the API server's settings. DebugPort, when set, serves the
+// runtime profiles to admin keys on a port of its own.
 type ServerConfig struct {
 	Port         string        `koanf:"port" validate:"required"`
+	DebugPort    string        `koanf:"debug_port"`
 	ReadTimeout  time.Duration `koanf:"read_timeout" validate:"required"`
 	WriteTimeout time.Duration `koanf:"write_timeout" validate:"required"`
 	IdleTimeout  time.Duration `koanf:"idle_timeout" validate:"required"`
 }
 
+// DatabaseConfig holds the connection pool's settings. Queries taking at least
+// SlowQueryThreshold are logged; zero logs none.
 type DatabaseConfig struct {
-	Host            string        `koanf:"host" validate:"required"`
-	Port            int           `koanf:"port" validate:"required"`
-	User            string        `koanf:"user" validate:"required"`
-	Password        string        `koanf:"password" validate:"required"`
-	Name            string        `koanf:"name" validate:"required"`
-	SSLMode         string        `koanf:"ssl_mode" validate:"required"`
-	MaxOpenConns    int           `koanf:"max_open_conns" validate:"required"`
-	MaxIdleConns    int           `koanf:"max_idle_conns" validate:"required"`
-	ConnMaxLifetime time.Duration `koanf:"conn_max_lifetime" validate:"required"`
-	ConnMaxIdleTime time.Duration `koanf:"conn_max_idle_time" validate:"required"`
+	Host               string        `koanf:"host" validate:"required"`
+	Port               int           `koanf:"port" validate:"required"`
+	User               string        `koanf:"user" validate:"required"`
+	Password           string        `koanf:"password" validate:"required"`
+	Name               string        `koanf:"name" validate:"required"`
+	SSLMode            string        `koanf:"ssl_mode" validate:"required"`
+	MaxOpenConns       int           `koanf:"max_open_conns" validate:"required"`
+	MaxIdleConns       int           `koanf:"max_idle_conns" validate:"required"`
+	ConnMaxLifetime    time.Duration `koanf:"conn_max_lifetime" validate:"required"`
+	ConnMaxIdleTime    time.Duration `koanf:"conn_max_idle_time" validate:"required"`
+	SlowQueryThreshold time.Duration `koanf:"slow_query_threshold"`
 }
 
+// BankConfig is how the gateway reaches its acquirer. The pool settings apply to the
+// connections kept open to every acquirer, and are left at their defaults when zero.
 type BankConfig struct {
 	BankBaseURL     string        `koanf:"bank_base_url" validate:"required"`
 	BankConnTimeout time.Duration `koanf:"bank_conn_timeout" validate:"required"`
+	// MaxIdleConnsPerHost is how many idle connections are kept open to each acquirer
+	// for the next calls to reuse
+	MaxIdleConnsPerHost int           `koanf:"max_idle_conns_per_host" validate:"min=0"`
+	IdleConnTimeout     time.Duration `koanf:"idle_conn_timeout" validate:"min=0"`
+	TLSHandshakeTimeout time.Duration `koanf:"tls_handshake_timeout" validate:"min=0"`
+}
+
+// BankRateLimitConfig keeps each merchant's calls to the bank under the bank's TPS
+// limits. Limits lists operation:per-second entries, such as authorize:20,refund:5, and
+// operations left out are not limited. A call over its limit waits up to MaxWait for a
+// turn and otherwise fails with BANK_RATE_LIMITED. Burst is how many calls may go at once
+// after a quiet spell, at least one.
+type BankRateLimitConfig struct {
+	Limits  string        `koanf:"limits"`
+	Burst   int           `koanf:"burst" validate:"min=0"`
+	MaxWait time.Duration `koanf:"max_wait" validate:"min=0"`
 }
 
 type RetryConfig struct {
@@ -62,6 +136,157 @@ type RetryConfig struct {
 	MaxBackoff int32 `koanf:"max_backoff" validate:"required"`
 }
 
+// ShadowConfig mirrors a share of authorizations to a candidate acquirer.
+// Shadowing is off while BankBaseURL is empty.
+type ShadowConfig struct {
+	BankBaseURL string        `koanf:"bank_base_url"`
+	Percent     int           `koanf:"percent" validate:"min=0,max=100"`
+	Timeout     time.Duration `koanf:"timeout"`
+}
+
+// CanaryConfig routes a share of new authorizations to a second acquirer and rolls
+// the share back to zero when the canary declines or errors too often. Routing is off
+// while BankBaseURL is empty; a zero rate threshold disables that check.
+type CanaryConfig struct {
+	BankBaseURL    string  `koanf:"bank_base_url"`
+	Percent        int     `koanf:"percent" validate:"min=0,max=100"`
+	MaxDeclineRate float64 `koanf:"max_decline_rate" validate:"min=0,max=1"`
+	MaxErrorRate   float64 `koanf:"max_error_rate" validate:"min=0,max=1"`
+	WindowSize     int     `koanf:"window_size" validate:"min=0"`
+}
+
+// SyntheticConfig describes the test card authorized and voided with each acquirer
+// every Interval to check it end to end. It is off while Interval is zero.
+type SyntheticConfig struct {
+	Interval    time.Duration `koanf:"interval"`
+	Amount      int64         `koanf:"amount" validate:"required_with=Interval"`
+	CardNumber  string        `koanf:"card_number" validate:"required_with=Interval"`
+	CVV         string        `koanf:"cvv"`
+	ExpiryMonth int           `koanf:"expiry_month" validate:"required_with=Interval"`
+	ExpiryYear  int           `koanf:"expiry_year" validate:"required_with=Interval"`
+}
+
+// AlertConfig posts alerts about payments that need someone to a Slack incoming
+// webhook or the PagerDuty Events API, whose integration key is RoutingKey. Alerting
+// is off while WebhookURL is empty. StuckAfter is how long a payment may stay in a
+// processing status, such as CAPTURING, before it is alerted about. With ExpiryWarning
+// set, an authorization still uncaptured that long before it lapses is alerted about too.
+type AlertConfig struct {
+	WebhookURL    string        `koanf:"webhook_url"`
+	Format        string        `koanf:"format" validate:"omitempty,oneof=slack pagerduty"`
+	RoutingKey    string        `koanf:"routing_key"`
+	StuckAfter    time.Duration `koanf:"stuck_after" validate:"required_with=WebhookURL"`
+	ExpiryWarning time.Duration `koanf:"expiry_warning"`
+}
+
+// NotificationConfig points customer notifications at the notification service. Nothing is
+// sent while WebhookURL is empty, whatever the merchants chose.
+type NotificationConfig struct {
+	WebhookURL string        `koanf:"webhook_url"`
+	Timeout    time.Duration `koanf:"timeout" validate:"required_with=WebhookURL"`
+}
+
+// CacheConfig keeps the payments read by ID or order, which storefronts poll during
+// checkout, in Redis for TTL. Caching is off while Enabled is false.
+type CacheConfig struct {
+	Enabled       bool          `koanf:"enabled"`
+	RedisAddr     string        `koanf:"redis_addr" validate:"required_with=Enabled"`
+	RedisPassword string        `koanf:"redis_password"`
+	RedisDB       int           `koanf:"redis_db" validate:"min=0"`
+	TTL           time.Duration `koanf:"ttl" validate:"required_with=Enabled"`
+}
+
+// VaultConfig holds the keys used to encrypt saved card and account numbers. Keys is a
+// comma-separated list of id:key pairs whose first pair encrypts new data; the others
+// only decrypt until the rotation command has moved their data over. EncryptionKey is
+// the key used before keys had IDs. FingerprintSalt, base64 like the keys, salts the
+// card fingerprints stored on payments and keys the hashes idempotency keys are checked
+// with, so it is required.
+type VaultConfig struct {
+	EncryptionKey   string `koanf:"encryption_key" validate:"required_without=Keys"`
+	Keys            string `koanf:"keys"`
+	FingerprintSalt string `koanf:"fingerprint_salt" validate:"required"`
+}
+
+// AuthConfig controls API key authentication. RequireAPIKey is true unless set
+// otherwise; while it is false, requests without a key act for the default merchant
+// everywhere but /admin. SignatureWindow is how far a signed
+// request's timestamp may be from the gateway's clock, either way.
+type AuthConfig struct {
+	RequireAPIKey   bool          `koanf:"require_api_key"`
+	SignatureWindow time.Duration `koanf:"signature_window" validate:"required"`
+}
+
+// RetentionConfig holds how long payments are kept as financial records before a
+// customer erasure may anonymize them
+type RetentionConfig struct {
+	FinancialPeriod time.Duration `koanf:"financial_period" validate:"required"`
+}
+
+// LimitsConfig holds the checks new payments must pass. Amounts bounds a single
+// payment per currency as a comma-separated list of currency:min:max entries in the
+// major unit, such as USD:0.50:10000; an empty bound is not enforced, and unlisted
+// currencies have no limit. DuplicateWindow rejects a payment for the same order,
+// customer and amount as one made that recently under another idempotency key; zero
+// turns the check off. UniqueOrders lets the database hold at most one payment per
+// order that has not failed. RefundApproval holds refunds above a currency's amount
+// for a second API key to approve, as currency:amount entries in the major unit, such
+// as USD:1000. CardPayments and CardCustomers bound how many payments, and for how many
+// customers, one card may be used within CardWindow; they need the vault's fingerprint
+// salt, and zero turns either off.
+type LimitsConfig struct {
+	Amounts         string        `koanf:"amounts"`
+	DuplicateWindow time.Duration `koanf:"duplicate_window" validate:"min=0"`
+	UniqueOrders    bool          `koanf:"unique_orders"`
+	RefundApproval  string        `koanf:"refund_approval"`
+	Review          string        `koanf:"review"`
+	CardWindow      time.Duration `koanf:"card_window" validate:"min=0"`
+	CardPayments    int           `koanf:"card_payments" validate:"min=0"`
+	CardCustomers   int           `koanf:"card_customers" validate:"min=0"`
+}
+
+// FeaturesConfig holds the rollout of feature flags not changed at runtime, as
+// flag:percent entries, and how long each process caches the flags it reads
+type FeaturesConfig struct {
+	Rollout  string        `koanf:"rollout"`
+	CacheTTL time.Duration `koanf:"cache_ttl" validate:"min=0"`
+}
+
+// RegionConfig places the gateway in an active-passive deployment across regions, where
+// only the region holding the lease in the database takes writes. Standby keeps a region
+// read-only before any region has been promoted. Each process rereads the lease every
+// RefreshInterval. It is off while Name is empty.
+type RegionConfig struct {
+	Name            string        `koanf:"name"`
+	Standby         bool          `koanf:"standby"`
+	RefreshInterval time.Duration `koanf:"refresh_interval" validate:"required_with=Name"`
+}
+
+// ChaosConfig injects latency and failures into API requests and bank calls so client
+// timeouts and retries can be rehearsed in staging. LatencyPercent of calls are held
+// for Latency first, and ErrorPercent of them fail with a random 5xx. Target limits it
+// to api or bank calls; left empty it affects both. It is off while Enabled is false,
+// and the gateway refuses to start with it in production.
+type ChaosConfig struct {
+	Enabled        bool          `koanf:"enabled"`
+	Target         string        `koanf:"target" validate:"omitempty,oneof=api bank"`
+	Latency        time.Duration `koanf:"latency" validate:"min=0"`
+	LatencyPercent int           `koanf:"latency_percent" validate:"min=0,max=100"`
+	ErrorPercent   int           `koanf:"error_percent" validate:"min=0,max=100"`
+}
+
+// Affects reports whether chaos is injected into target, api or bank
+func (c ChaosConfig) Affects(target string) bool {
+	return c.Enabled && (c.Target == "" || c.Target == target)
+}
+
+// ClockConfig lets a sandbox run the gateway on a test clock that the admin API can
+// advance, so authorization expiry, retries and scheduled payments come due without
+// waiting for them. The gateway refuses to start with it in production.
+type ClockConfig struct {
+	Test bool `koanf:"test"`
+}
+
 type LoggerConfig struct {
 	Level string `koanf:"level"`
 }
@@ -84,7 +309,8 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
-	mainConfig := &Config{}
+	// Keys are required unless GATEWAY_AUTH__REQUIRE_API_KEY turns them off
+	mainConfig := &Config{Auth: AuthConfig{RequireAPIKey: true}}
 
 	err = k.Unmarshal("", mainConfig)
 	if err != nil {
@@ -100,5 +326,24 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if mainConfig.Chaos.Enabled && mainConfig.Primary.Production() {
+		err = errors.New("chaos injection cannot be enabled in production")
+		logger.Error("config validation failed", "error", err)
+		return nil, err
+	}
+
+	// A retry must be sent while a replay of its request still waits on the lock
+	if mainConfig.Worker.SoftDeclineRetryDelay < 0 || mainConfig.Worker.SoftDeclineRetryDelay >= 5*time.Minute {
+		err = errors.New("the soft decline retry delay must be under 5m")
+		logger.Error("config validation failed", "error", err)
+		return nil, err
+	}
+
+	if mainConfig.Clock.Test && mainConfig.Primary.Production() {
+		err = errors.New("the test clock cannot be enabled in production")
+		logger.Error("config validation failed", "error", err)
+		return nil, err
+	}
+
 	return mainConfig, nil
 }
@@ -4,22 +4,34 @@ import (
 	"log/slog"
 	"os"
 	"strings"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/logging"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
 )
 
 // NewLogger creates a new structured logger based on configuration
 func (c *LoggerConfig) NewLogger() *slog.Logger {
+	logger, _ := c.NewControlledLogger()
+	return logger
+}
+
+// NewControlledLogger creates a logger whose level can be changed while it is in use
+// through the returned controller. The configured level is where it starts.
+func (c *LoggerConfig) NewControlledLogger() (*slog.Logger, *logging.Controller) {
 	var handler slog.Handler
 
 	level := parseLogLevel(c.Level)
+	control := logging.NewController(level)
 
+	// The controller filters by level, so the JSON handler has to let debug through
 	opts := &slog.HandlerOptions{
-		Level:     level,
+		Level:     slog.LevelDebug,
 		AddSource: level == slog.LevelDebug || level == slog.LevelError,
 	}
 
-	handler = slog.NewJSONHandler(os.Stdout, opts)
+	handler = control.Handler(pci.NewLogHandler(slog.NewJSONHandler(os.Stdout, opts)))
 
-	return slog.New(handler)
+	return slog.New(handler), control
 }
 
 func parseLogLevel(level string) slog.Level {
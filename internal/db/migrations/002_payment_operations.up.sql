@@ -0,0 +1,16 @@
+-- Operations (captures, voids, refunds) requested against a payment
+CREATE TABLE IF NOT EXISTS payment_operations (
+    id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    idempotency_key TEXT NOT NULL UNIQUE,
+
+    bank_reference_id TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_operations_payment_id ON payment_operations(payment_id);
@@ -0,0 +1,6 @@
+-- Running total of captures, so one authorization can be captured in several parts
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS captured_amount_cents BIGINT NOT NULL DEFAULT 0;
+
+UPDATE payments
+SET captured_amount_cents = amount_cents
+WHERE status IN ('CAPTURED', 'REFUNDING', 'REFUNDED');
@@ -0,0 +1 @@
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS reason;
@@ -0,0 +1,2 @@
+-- Why a void or refund was requested (fraud, customer_request, duplicate, out_of_stock)
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS reason TEXT;
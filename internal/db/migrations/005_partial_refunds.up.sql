@@ -0,0 +1,6 @@
+-- Running total of refunds, so a capture can be refunded in several parts
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount_cents BIGINT NOT NULL DEFAULT 0;
+
+UPDATE payments
+SET refunded_amount_cents = captured_amount_cents
+WHERE status = 'REFUNDED';
@@ -0,0 +1,19 @@
+-- Every request sent to the bank, for support and dispute investigations
+CREATE TABLE IF NOT EXISTS bank_attempts (
+    id UUID PRIMARY KEY,
+    payment_id UUID REFERENCES payments(id) ON DELETE CASCADE,
+    operation TEXT NOT NULL,
+    idempotency_key TEXT,
+
+    status_code INT NOT NULL,
+    error_code TEXT,
+    latency_ms BIGINT NOT NULL,
+
+    request_payload JSONB,
+    response_payload JSONB,
+
+    attempted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_attempts_payment_id ON bank_attempts(payment_id);
+CREATE INDEX IF NOT EXISTS idx_bank_attempts_idempotency_key ON bank_attempts(idempotency_key);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS bank_debug_captures;
+DROP TABLE IF EXISTS debug_sessions;
@@ -0,0 +1,31 @@
+-- Opt-in capture of raw bank traffic for one payment or idempotency key
+CREATE TABLE IF NOT EXISTS debug_sessions (
+    id UUID PRIMARY KEY,
+    payment_id UUID REFERENCES payments(id) ON DELETE CASCADE,
+    idempotency_key TEXT,
+
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT debug_sessions_single_target CHECK ((payment_id IS NULL) <> (idempotency_key IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_debug_sessions_payment_id ON debug_sessions(payment_id);
+CREATE INDEX IF NOT EXISTS idx_debug_sessions_idempotency_key ON debug_sessions(idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_debug_sessions_expires_at ON debug_sessions(expires_at);
+
+CREATE TABLE IF NOT EXISTS bank_debug_captures (
+    id UUID PRIMARY KEY,
+    session_id UUID NOT NULL REFERENCES debug_sessions(id) ON DELETE CASCADE,
+    idempotency_key TEXT NOT NULL,
+
+    method TEXT NOT NULL,
+    url TEXT NOT NULL,
+    request_body TEXT,
+    response_status INT NOT NULL,
+    response_body TEXT,
+
+    captured_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_bank_debug_captures_session_id ON bank_debug_captures(session_id);
@@ -0,0 +1,3 @@
+ALTER TABLE bank_attempts DROP COLUMN IF EXISTS acquirer;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS acquirer;
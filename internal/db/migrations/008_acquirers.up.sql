@@ -0,0 +1,4 @@
+-- Payments remember which acquirer authorized them so follow-up operations go to the same bank
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS acquirer TEXT NOT NULL DEFAULT 'primary';
+
+ALTER TABLE bank_attempts ADD COLUMN IF NOT EXISTS acquirer TEXT NOT NULL DEFAULT 'primary';
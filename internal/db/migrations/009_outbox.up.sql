@@ -0,0 +1,18 @@
+-- Payment status transitions, written in the same statement as the transition and
+-- delivered to hooks by the outbox worker
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    payment_id UUID NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    processed_at TIMESTAMP WITH TIME ZONE,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unprocessed ON outbox(occurred_at) WHERE processed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_payment_id ON outbox(payment_id);
@@ -0,0 +1,4 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS failure_reason;
+
+DROP TABLE IF EXISTS scheduled_payments;
+DROP TABLE IF EXISTS payment_methods;
@@ -0,0 +1,25 @@
+-- Cards saved for merchant-initiated payments. The card number is encrypted by the
+-- vault before it reaches the database and the CVV is never stored.
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id UUID PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    card_number_ciphertext BYTEA NOT NULL,
+    last4 TEXT NOT NULL,
+    expiry_month INT NOT NULL,
+    expiry_year INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_customer_id ON payment_methods(customer_id);
+
+-- Payments waiting in SCHEDULED until their authorization is due
+CREATE TABLE IF NOT EXISTS scheduled_payments (
+    payment_id UUID PRIMARY KEY REFERENCES payments(id) ON DELETE CASCADE,
+    payment_method_id UUID NOT NULL REFERENCES payment_methods(id),
+    scheduled_for TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduled_payments_scheduled_for ON scheduled_payments(scheduled_for);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS failure_reason TEXT;
@@ -0,0 +1,23 @@
+-- Recurring charges of a saved payment method
+CREATE TABLE IF NOT EXISTS subscriptions (
+    id UUID PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    payment_method_id UUID NOT NULL REFERENCES payment_methods(id),
+    plan TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    billing_interval TEXT NOT NULL,
+    status TEXT NOT NULL,
+
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    next_charge_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    failed_attempts INT NOT NULL DEFAULT 0,
+    last_payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    canceled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_subscriptions_customer_id ON subscriptions(customer_id);
+CREATE INDEX IF NOT EXISTS idx_subscriptions_due ON subscriptions(next_charge_at)
+WHERE status IN ('ACTIVE', 'PAST_DUE');
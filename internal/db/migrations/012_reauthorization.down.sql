@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_payments_retry_worker;
+CREATE INDEX IF NOT EXISTS idx_payments_retry_worker ON payments(next_retry_at)
+WHERE status IN ('CAPTURING', 'VOIDING', 'REFUNDING');
+
+ALTER TABLE payments DROP COLUMN IF EXISTS payment_method_id;
@@ -0,0 +1,8 @@
+-- The saved card a payment was made with, used to reauthorize it after its
+-- authorization expires
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS payment_method_id UUID REFERENCES payment_methods(id);
+
+-- REAUTHORIZING is resumed by the retry worker like the other intermediate states
+DROP INDEX IF EXISTS idx_payments_retry_worker;
+CREATE INDEX IF NOT EXISTS idx_payments_retry_worker ON payments(next_retry_at)
+WHERE status IN ('CAPTURING', 'VOIDING', 'REFUNDING', 'REAUTHORIZING');
@@ -0,0 +1,4 @@
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_single_owner;
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS payout_id;
+
+DROP TABLE IF EXISTS payouts;
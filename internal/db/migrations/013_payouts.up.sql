@@ -0,0 +1,32 @@
+-- Funds sent from the merchant to a recipient's bank account. The account number is
+-- encrypted by the vault so a payout interrupted by a crash can be resent.
+CREATE TABLE IF NOT EXISTS payouts (
+    id UUID PRIMARY KEY,
+    recipient_id TEXT NOT NULL,
+    purpose TEXT NOT NULL,
+    payment_id UUID REFERENCES payments(id),
+    amount_cents BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    status TEXT NOT NULL,
+
+    account_number_ciphertext BYTEA NOT NULL,
+    account_last4 TEXT NOT NULL,
+    routing_number TEXT NOT NULL,
+    bank_payout_id TEXT,
+    failure_code TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payouts_recipient_id ON payouts(recipient_id);
+CREATE INDEX IF NOT EXISTS idx_payouts_payment_id ON payouts(payment_id) WHERE payment_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_payouts_open ON payouts(created_at)
+WHERE status IN ('PENDING', 'IN_TRANSIT');
+
+-- Payout requests share the idempotency table with payments; a key belongs to one or
+-- the other
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS payout_id UUID REFERENCES payouts(id) ON DELETE CASCADE;
+ALTER TABLE idempotency_keys ADD CONSTRAINT idempotency_keys_single_owner
+    CHECK (payment_id IS NULL OR payout_id IS NULL);
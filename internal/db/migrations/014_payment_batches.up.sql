@@ -0,0 +1,32 @@
+-- Bulk operations over many payments, processed in the background item by item
+CREATE TABLE IF NOT EXISTS payment_batches (
+    id UUID PRIMARY KEY,
+    type TEXT NOT NULL,
+    reason TEXT,
+    status TEXT NOT NULL,
+
+    idempotency_key TEXT NOT NULL UNIQUE,
+    request_hash TEXT NOT NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+-- payment_id has no foreign key: an unknown payment fails its item instead of the batch
+CREATE TABLE IF NOT EXISTS payment_batch_items (
+    id UUID PRIMARY KEY,
+    batch_id UUID NOT NULL REFERENCES payment_batches(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    payment_id UUID NOT NULL,
+    amount_cents BIGINT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL,
+    operation_id UUID,
+    error_code TEXT,
+    completed_at TIMESTAMP WITH TIME ZONE,
+
+    UNIQUE (batch_id, payment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_batch_items_batch_id ON payment_batch_items(batch_id, position);
+CREATE INDEX IF NOT EXISTS idx_payment_batch_items_pending ON payment_batch_items(batch_id)
+WHERE status = 'PENDING';
@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS idx_payments_order_id;
+DROP INDEX IF EXISTS idx_payments_customer_id;
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(order_id);
+CREATE INDEX IF NOT EXISTS idx_payments_customer_id ON payments(customer_id);
+
+DROP INDEX IF EXISTS idx_payment_operations_idempotency_key;
+ALTER TABLE payment_operations DROP CONSTRAINT payment_operations_idempotency_key_key;
+ALTER TABLE payment_operations ADD CONSTRAINT payment_operations_idempotency_key_key UNIQUE (idempotency_key);
+ALTER TABLE payment_batches DROP CONSTRAINT payment_batches_idempotency_key_key;
+ALTER TABLE payment_batches ADD CONSTRAINT payment_batches_idempotency_key_key UNIQUE (idempotency_key);
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (key);
+
+ALTER TABLE idempotency_keys DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE debug_sessions DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE payment_batches DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE payouts DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS merchant_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS merchant_id;
+
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS merchants;
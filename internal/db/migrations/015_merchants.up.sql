@@ -0,0 +1,51 @@
+-- FicMart business units served by the gateway. Rows created before merchants existed
+-- belong to the default merchant.
+CREATE TABLE IF NOT EXISTS merchants (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO merchants (id, name) VALUES ('default', 'FicMart') ON CONFLICT (id) DO NOTHING;
+
+-- Only the SHA-256 of a key is stored, never the key itself
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    key_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_merchant_id ON api_keys(merchant_id);
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+ALTER TABLE payment_methods ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+ALTER TABLE payouts ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+ALTER TABLE payment_batches ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+ALTER TABLE debug_sessions ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS merchant_id TEXT NOT NULL DEFAULT 'default' REFERENCES merchants(id);
+
+-- The gateway always sets the merchant on new rows; the defaults only backfill
+ALTER TABLE payments ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE payment_methods ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE subscriptions ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE payouts ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE payment_batches ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE debug_sessions ALTER COLUMN merchant_id DROP DEFAULT;
+ALTER TABLE idempotency_keys ALTER COLUMN merchant_id DROP DEFAULT;
+
+-- Merchants choose their idempotency keys independently, so keys are unique per merchant
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (merchant_id, key);
+ALTER TABLE payment_batches DROP CONSTRAINT payment_batches_idempotency_key_key;
+ALTER TABLE payment_batches ADD CONSTRAINT payment_batches_idempotency_key_key UNIQUE (merchant_id, idempotency_key);
+ALTER TABLE payment_operations DROP CONSTRAINT payment_operations_idempotency_key_key;
+ALTER TABLE payment_operations ADD CONSTRAINT payment_operations_idempotency_key_key UNIQUE (payment_id, idempotency_key);
+CREATE INDEX IF NOT EXISTS idx_payment_operations_idempotency_key ON payment_operations(idempotency_key);
+
+DROP INDEX IF EXISTS idx_payments_order_id;
+DROP INDEX IF EXISTS idx_payments_customer_id;
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments(merchant_id, order_id);
+CREATE INDEX IF NOT EXISTS idx_payments_customer_id ON payments(merchant_id, customer_id);
@@ -0,0 +1,13 @@
+-- Per-merchant overrides of the gateway defaults. A merchant without a row, or a NULL
+-- column, gets the default: any currency, the retry policy from the environment, no
+-- refund window and manual capture.
+CREATE TABLE IF NOT EXISTS merchant_settings (
+    merchant_id TEXT PRIMARY KEY REFERENCES merchants(id),
+    -- ISO 4217 codes accepted for new payments; empty accepts any
+    allowed_currencies TEXT[] NOT NULL DEFAULT '{}',
+    max_retries INTEGER CHECK (max_retries > 0),
+    retry_base_delay_seconds INTEGER CHECK (retry_base_delay_seconds >= 0),
+    refund_window_days INTEGER CHECK (refund_window_days > 0),
+    auto_capture BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
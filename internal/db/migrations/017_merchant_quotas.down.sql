@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS merchant_quota_usage;
+
+ALTER TABLE merchant_settings
+    DROP COLUMN IF EXISTS daily_volume_limit_cents,
+    DROP COLUMN IF EXISTS daily_transaction_limit;
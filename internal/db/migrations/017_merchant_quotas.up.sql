@@ -0,0 +1,14 @@
+-- Daily caps on the payments a merchant may create; NULL is unlimited
+ALTER TABLE merchant_settings
+    ADD COLUMN IF NOT EXISTS daily_transaction_limit INTEGER CHECK (daily_transaction_limit > 0),
+    ADD COLUMN IF NOT EXISTS daily_volume_limit_cents BIGINT CHECK (daily_volume_limit_cents > 0);
+
+-- Payments created per merchant and UTC day. The row is updated in the transaction
+-- that creates each payment, so it also serializes concurrent checks of the quota.
+CREATE TABLE IF NOT EXISTS merchant_quota_usage (
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    day DATE NOT NULL,
+    transaction_count INTEGER NOT NULL DEFAULT 0,
+    volume_cents BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (merchant_id, day)
+);
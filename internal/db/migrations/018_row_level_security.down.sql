@@ -0,0 +1,7 @@
+DROP POLICY IF EXISTS merchant_isolation ON idempotency_keys;
+ALTER TABLE idempotency_keys NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys DISABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS merchant_isolation ON payments;
+ALTER TABLE payments NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE payments DISABLE ROW LEVEL SECURITY;
@@ -0,0 +1,20 @@
+-- Defense in depth for merchant isolation: even a query that forgets its merchant_id
+-- filter only sees rows of the merchant the gateway set on the connection. Workers
+-- that scan every merchant turn app.all_merchants on. FORCE applies the policies to
+-- the table owner too; superusers and BYPASSRLS roles still skip them, so the gateway
+-- should connect as an ordinary role.
+ALTER TABLE payments ENABLE ROW LEVEL SECURITY;
+ALTER TABLE payments FORCE ROW LEVEL SECURITY;
+CREATE POLICY merchant_isolation ON payments
+    USING (
+        current_setting('app.all_merchants', true) = 'on'
+        OR merchant_id = current_setting('app.merchant_id', true)
+    );
+
+ALTER TABLE idempotency_keys ENABLE ROW LEVEL SECURITY;
+ALTER TABLE idempotency_keys FORCE ROW LEVEL SECURITY;
+CREATE POLICY merchant_isolation ON idempotency_keys
+    USING (
+        current_setting('app.all_merchants', true) = 'on'
+        OR merchant_id = current_setting('app.merchant_id', true)
+    );
@@ -0,0 +1,15 @@
+-- One row per customer erasure. The customer appears only as the token that replaced
+-- their ID, so the audit trail does not keep what was erased.
+CREATE TABLE IF NOT EXISTS erasures (
+    id UUID PRIMARY KEY,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    customer_token TEXT NOT NULL,
+    retention_cutoff TIMESTAMP WITH TIME ZONE NOT NULL,
+    payments_anonymized INT NOT NULL,
+    payments_retained INT NOT NULL,
+    payment_methods_anonymized INT NOT NULL,
+    subscriptions_anonymized INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasures_merchant_id ON erasures(merchant_id, created_at);
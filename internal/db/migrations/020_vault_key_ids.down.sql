@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_payouts_account_number_key_id;
+DROP INDEX IF EXISTS idx_payment_methods_card_number_key_id;
+
+ALTER TABLE payouts DROP COLUMN IF EXISTS account_number_key_id;
+ALTER TABLE payment_methods DROP COLUMN IF EXISTS card_number_key_id;
@@ -0,0 +1,10 @@
+-- The vault key each ciphertext was sealed with, so keys can be rotated one row at a
+-- time. Everything sealed before keys had IDs used the legacy key.
+ALTER TABLE payment_methods ADD COLUMN IF NOT EXISTS card_number_key_id TEXT NOT NULL DEFAULT 'legacy';
+ALTER TABLE payouts ADD COLUMN IF NOT EXISTS account_number_key_id TEXT NOT NULL DEFAULT 'legacy';
+
+ALTER TABLE payment_methods ALTER COLUMN card_number_key_id DROP DEFAULT;
+ALTER TABLE payouts ALTER COLUMN account_number_key_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_card_number_key_id ON payment_methods(card_number_key_id);
+CREATE INDEX IF NOT EXISTS idx_payouts_account_number_key_id ON payouts(account_number_key_id);
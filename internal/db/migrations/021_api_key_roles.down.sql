@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS audit_log;
+
+ALTER TABLE api_keys DROP COLUMN IF EXISTS role;
@@ -0,0 +1,21 @@
+-- Keys issued before roles existed could call everything, so they become admins; new
+-- keys must be given a role
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS role TEXT NOT NULL DEFAULT 'admin'
+    CHECK (role IN ('viewer', 'operator', 'admin'));
+ALTER TABLE api_keys ALTER COLUMN role DROP DEFAULT;
+
+-- Privileged requests with the key that made them. api_key_id is NULL for requests
+-- made without a key while keys are optional.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id UUID PRIMARY KEY,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    api_key_id UUID REFERENCES api_keys(id),
+    role TEXT NOT NULL,
+    action TEXT NOT NULL,
+    target TEXT NOT NULL,
+    status INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_merchant_id ON audit_log(merchant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_api_key_id ON audit_log(api_key_id);
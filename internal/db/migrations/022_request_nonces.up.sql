@@ -0,0 +1,10 @@
+-- Nonces of signed requests, kept until their timestamp falls out of the freshness
+-- window, after which the request is rejected as stale anyway
+CREATE TABLE IF NOT EXISTS request_nonces (
+    api_key_id UUID NOT NULL REFERENCES api_keys(id) ON DELETE CASCADE,
+    nonce TEXT NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (api_key_id, nonce)
+);
+
+CREATE INDEX IF NOT EXISTS idx_request_nonces_expires_at ON request_nonces(api_key_id, expires_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sent_alerts;
+DROP INDEX IF EXISTS idx_payments_processing_since;
+ALTER TABLE payments DROP COLUMN IF EXISTS status_changed_at;
@@ -0,0 +1,22 @@
+-- When each payment entered its current status, so a payment stuck in a processing
+-- status can be told apart from one a retry just touched. Existing payments take the
+-- time of the transition the outbox recorded for them.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE payments p SET status_changed_at = COALESCE(
+    (SELECT MAX(o.occurred_at) FROM outbox o WHERE o.payment_id = p.id AND o.to_status = p.status),
+    p.created_at
+);
+
+ALTER TABLE payments ALTER COLUMN status_changed_at SET DEFAULT NOW();
+ALTER TABLE payments ALTER COLUMN status_changed_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_payments_processing_since ON payments(status_changed_at)
+    WHERE status IN ('CAPTURING', 'VOIDING', 'REFUNDING', 'REAUTHORIZING');
+
+-- Alerts sent, by the key that identifies what they are about, so each is sent once
+-- however many gateway instances notice it
+CREATE TABLE IF NOT EXISTS sent_alerts (
+    key TEXT PRIMARY KEY,
+    sent_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
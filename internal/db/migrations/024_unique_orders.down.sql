@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_unique_order;
+ALTER TABLE payments DROP COLUMN IF EXISTS unique_order;
@@ -0,0 +1,7 @@
+-- Payments created while the gateway enforces one payment per order. Among them, an
+-- order may have any number of FAILED payments but only one other; payments created
+-- before keep whatever duplicates they have.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS unique_order BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_unique_order ON payments(merchant_id, order_id)
+    WHERE unique_order AND status <> 'FAILED';
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS idx_payments_customer_id ON payments(merchant_id, customer_id);
+DROP INDEX IF EXISTS idx_payments_customer_created_at;
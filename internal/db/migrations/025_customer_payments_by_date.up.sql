@@ -0,0 +1,4 @@
+-- Customer payment listings are newest first and may be limited to a date range, so
+-- the customer index carries created_at too. It still serves lookups by customer alone.
+CREATE INDEX IF NOT EXISTS idx_payments_customer_created_at ON payments(merchant_id, customer_id, created_at);
+DROP INDEX IF EXISTS idx_payments_customer_id;
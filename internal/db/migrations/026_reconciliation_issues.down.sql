@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payments_created_at;
+DROP INDEX IF EXISTS idx_bank_attempts_authorizations;
+DROP TABLE IF EXISTS reconciliation_issues;
@@ -0,0 +1,22 @@
+-- Disagreements between the gateway and the bank found by reconciliation. A finding
+-- seen again on a later run updates its row, so each authorization is listed once per
+-- kind of disagreement with when it was first and last seen.
+CREATE TABLE IF NOT EXISTS reconciliation_issues (
+    id UUID PRIMARY KEY,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    kind TEXT NOT NULL,
+    bank_auth_id TEXT NOT NULL,
+    payment_id UUID REFERENCES payments(id) ON DELETE CASCADE,
+    gateway_status TEXT,
+    bank_status TEXT,
+    first_detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (merchant_id, kind, bank_auth_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliation_issues_last_detected ON reconciliation_issues(merchant_id, last_detected_at);
+
+-- Reconciliation walks the payments and the authorizations the bank granted in a period
+CREATE INDEX IF NOT EXISTS idx_payments_created_at ON payments(merchant_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_bank_attempts_authorizations ON bank_attempts(attempted_at)
+    WHERE operation = 'AUTHORIZE' AND status_code = 200;
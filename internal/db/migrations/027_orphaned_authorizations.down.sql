@@ -0,0 +1 @@
+ALTER TABLE reconciliation_issues DROP COLUMN IF EXISTS voided_at;
@@ -0,0 +1,2 @@
+-- Orphaned authorizations are voided by reconciliation; the issue records when
+ALTER TABLE reconciliation_issues ADD COLUMN IF NOT EXISTS voided_at TIMESTAMP WITH TIME ZONE;
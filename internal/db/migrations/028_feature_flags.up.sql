@@ -0,0 +1,16 @@
+-- Feature flags changed at runtime. A flag without a row keeps the rollout from the
+-- environment.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    name TEXT PRIMARY KEY,
+    percent INTEGER NOT NULL CHECK (percent BETWEEN 0 AND 100),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Merchants a flag is turned on or off for whatever its rollout percent
+CREATE TABLE IF NOT EXISTS feature_flag_overrides (
+    name TEXT NOT NULL,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (name, merchant_id)
+);
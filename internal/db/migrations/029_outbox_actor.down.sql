@@ -0,0 +1,2 @@
+ALTER TABLE outbox DROP COLUMN IF EXISTS attempt_count;
+ALTER TABLE outbox DROP COLUMN IF EXISTS actor;
@@ -0,0 +1,6 @@
+-- What made each transition, and how many retries the payment had been through by then.
+-- Events written before are left without an actor.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS actor TEXT;
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS attempt_count INT NOT NULL DEFAULT 0;
+
+UPDATE outbox SET attempt_count = (payload->>'attempt_count')::int WHERE payload ? 'attempt_count';
@@ -0,0 +1,3 @@
+-- The version of the schema each event's payload follows. Events written before are
+-- version 0 and hold the whole payment row.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS schema_version INT NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payment_operations_pending_approval;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS requested_by;
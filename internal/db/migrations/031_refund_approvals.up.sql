@@ -0,0 +1,7 @@
+-- Refunds above the approval threshold wait in PENDING_APPROVAL until a second API key
+-- approves or rejects them. requested_by and reviewed_by are the API key IDs involved.
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS requested_by TEXT;
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS reviewed_by TEXT;
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_payment_operations_pending_approval ON payment_operations(created_at) WHERE status = 'PENDING_APPROVAL';
@@ -0,0 +1,13 @@
+-- Risk-flagged authorizations wait in REVIEW until a person approves or declines them.
+-- A review leaves the queue once its decision is set; reviewed_by is the API key ID.
+CREATE TABLE IF NOT EXISTS payment_reviews (
+    payment_id UUID PRIMARY KEY REFERENCES payments(id) ON DELETE CASCADE,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    reason TEXT NOT NULL,
+    flagged_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    decision TEXT,
+    reviewed_by TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_reviews_queue ON payment_reviews(merchant_id, flagged_at) WHERE decision IS NULL;
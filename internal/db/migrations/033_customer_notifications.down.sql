@@ -0,0 +1 @@
+ALTER TABLE merchant_settings DROP COLUMN IF EXISTS customer_notifications;
@@ -0,0 +1,4 @@
+-- Channels the customers of a merchant are notified on when a refund completes or a
+-- payment fails. Empty, the default, notifies no one.
+ALTER TABLE merchant_settings ADD COLUMN IF NOT EXISTS customer_notifications TEXT[] NOT NULL DEFAULT '{}'
+    CHECK (customer_notifications <@ ARRAY['email', 'sms']);
@@ -0,0 +1,2 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS card_brand;
+ALTER TABLE payments DROP COLUMN IF EXISTS card_last4;
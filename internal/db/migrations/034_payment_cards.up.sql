@@ -0,0 +1,4 @@
+-- The last four digits and brand of the card each payment was authorized with, for
+-- receipts. Payments authorized before this migration have neither.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS card_last4 TEXT;
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS card_brand TEXT;
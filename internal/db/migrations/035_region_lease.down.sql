@@ -0,0 +1,2 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS region_epoch;
+DROP TABLE IF EXISTS region_lease;
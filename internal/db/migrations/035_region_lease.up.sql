@@ -0,0 +1,12 @@
+-- The region that takes writes in an active-passive deployment. There is at most one
+-- row; each promotion moves it to another region and raises the epoch.
+CREATE TABLE IF NOT EXISTS region_lease (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    active_region TEXT NOT NULL,
+    epoch BIGINT NOT NULL CHECK (epoch > 0),
+    promoted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- The epoch each payment was last written under. A region writing under an earlier
+-- epoch than the payment's cannot overwrite it.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS region_epoch BIGINT NOT NULL DEFAULT 0;
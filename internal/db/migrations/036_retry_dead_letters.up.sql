@@ -0,0 +1,14 @@
+-- Payments whose retries ran out while GATEWAY_WORKER__RETRY_EXHAUSTED is dead_letter.
+-- The payment keeps its status, and the retry worker passes it over until it is
+-- requeued, which deletes its row here.
+CREATE TABLE IF NOT EXISTS retry_dead_letters (
+    payment_id UUID PRIMARY KEY REFERENCES payments(id) ON DELETE CASCADE,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    status TEXT NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    attempts INTEGER NOT NULL,
+    last_error TEXT NOT NULL,
+    dead_lettered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_retry_dead_letters_merchant ON retry_dead_letters(merchant_id, dead_lettered_at);
@@ -0,0 +1,4 @@
+-- How far a locked payment request got with the bank: CALLING_BANK from the moment the
+-- key is locked, BANK_RESPONDED once the bank has answered. Released keys have none.
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS recovery_point TEXT
+    CHECK (recovery_point IN ('CALLING_BANK', 'BANK_RESPONDED'));
@@ -0,0 +1,3 @@
+-- How the payment's last failed bank call was classified (TRANSIENT, PERMANENT, ...),
+-- so callers can tell a payment being retried from one that failed for good
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS last_error_category TEXT;
@@ -0,0 +1,22 @@
+-- Payments a storefront confirms with the card itself, holding only a client token
+-- until then
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id UUID PRIMARY KEY,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    order_id TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    status TEXT NOT NULL,
+    client_token_hash TEXT NOT NULL,
+
+    -- attempts counts declined confirmations, each of which was a payment of its own
+    attempts INT NOT NULL DEFAULT 0,
+    payment_id UUID REFERENCES payments(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    confirmed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_merchant_order ON payment_intents(merchant_id, order_id);
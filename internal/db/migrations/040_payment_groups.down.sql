@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_group_id;
+ALTER TABLE payments DROP COLUMN IF EXISTS group_part;
+ALTER TABLE payments DROP COLUMN IF EXISTS group_id;
+DROP TABLE IF EXISTS payment_groups;
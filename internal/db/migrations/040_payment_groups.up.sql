@@ -0,0 +1,22 @@
+-- Orders paid by several authorizations, such as a gift card with the remainder on a
+-- credit card. A group's status is derived from its parts.
+CREATE TABLE IF NOT EXISTS payment_groups (
+    id UUID PRIMARY KEY,
+    merchant_id TEXT NOT NULL REFERENCES merchants(id),
+    order_id TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    idempotency_key TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+
+    UNIQUE (merchant_id, idempotency_key)
+);
+
+-- group_part numbers the parts of a group from 1. Only the first counts as the order's
+-- payment where orders may only be paid once.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS group_id UUID REFERENCES payment_groups(id);
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS group_part INT;
+
+CREATE INDEX IF NOT EXISTS idx_payments_group_id ON payments(group_id) WHERE group_id IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_card_fingerprint;
+ALTER TABLE payments DROP COLUMN IF EXISTS card_fingerprint;
@@ -0,0 +1,6 @@
+-- A salted HMAC of the card number a payment was made with, so payments of the same
+-- card can be counted without storing the number
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS card_fingerprint TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_payments_card_fingerprint
+    ON payments(merchant_id, card_fingerprint, created_at) WHERE card_fingerprint IS NOT NULL;
@@ -0,0 +1,8 @@
+-- Set while an operator holds back delivery of outbox events, such as during an
+-- incident at a downstream consumer. There is at most one row; resuming deletes it.
+CREATE TABLE IF NOT EXISTS outbox_pause (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    paused_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    paused_by TEXT,
+    reason TEXT NOT NULL DEFAULT ''
+);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_payment_operations_destination_account_key_id;
+
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS destination_account_key_id;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS destination_account_ciphertext;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS destination_routing_number;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS destination_last4;
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS destination;
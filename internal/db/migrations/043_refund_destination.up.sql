@@ -0,0 +1,11 @@
+-- Where a refund sends the funds when not back to the card. A bank transfer's account
+-- number is encrypted by the vault so an interrupted transfer can be resent, and is
+-- cleared once the refund completes.
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS destination TEXT;
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS destination_last4 TEXT;
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS destination_routing_number TEXT;
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS destination_account_ciphertext BYTEA;
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS destination_account_key_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_payment_operations_destination_account_key_id
+    ON payment_operations(destination_account_key_id) WHERE destination_account_key_id IS NOT NULL;
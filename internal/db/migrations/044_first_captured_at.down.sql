@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_authorized_expires_at;
+ALTER TABLE payments DROP COLUMN IF EXISTS first_captured_at;
@@ -0,0 +1,16 @@
+-- When a payment's first capture went through, to time captures from authorization.
+-- Payments captured before the column existed take it from their earliest capture
+-- operation, or from captured_at when they have none.
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS first_captured_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE payments p
+SET first_captured_at = COALESCE(
+    (SELECT min(o.completed_at) FROM payment_operations o
+     WHERE o.payment_id = p.id AND o.type = 'CAPTURE' AND o.status = 'SUCCEEDED'),
+    p.captured_at
+)
+WHERE p.captured_at IS NOT NULL AND p.first_captured_at IS NULL;
+
+-- Authorizations running out uncaptured, for the at-risk list and expiry alerts
+CREATE INDEX IF NOT EXISTS idx_payments_authorized_expires_at
+    ON payments(expires_at) WHERE status = 'AUTHORIZED';
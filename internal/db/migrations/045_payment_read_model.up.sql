@@ -0,0 +1,70 @@
+-- A copy of each payment for listing and totalling, refreshed by the read_model hook
+-- as the outbox delivers its transitions, so those queries never wait on or hold up
+-- the row locks the write path takes on payments. as_of is the status change the
+-- copy was taken at; an older copy never overwrites a newer one. The card
+-- fingerprint is left behind, so erasure has one copy fewer to scrub.
+CREATE TABLE IF NOT EXISTS payment_read_model (
+    id UUID PRIMARY KEY REFERENCES payments(id) ON DELETE CASCADE,
+    merchant_id TEXT NOT NULL,
+    order_id TEXT NOT NULL,
+    customer_id TEXT NOT NULL,
+    amount_cents BIGINT NOT NULL,
+    currency TEXT NOT NULL,
+    status TEXT NOT NULL,
+
+    bank_auth_id TEXT,
+    bank_capture_id TEXT,
+    bank_void_id TEXT,
+    bank_refund_id TEXT,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    authorized_at TIMESTAMP WITH TIME ZONE,
+    captured_at TIMESTAMP WITH TIME ZONE,
+    voided_at TIMESTAMP WITH TIME ZONE,
+    refunded_at TIMESTAMP WITH TIME ZONE,
+    expires_at TIMESTAMP WITH TIME ZONE,
+    first_captured_at TIMESTAMP WITH TIME ZONE,
+
+    attempt_count INT NOT NULL,
+    next_retry_at TIMESTAMP WITH TIME ZONE,
+    captured_amount_cents BIGINT NOT NULL,
+    refunded_amount_cents BIGINT NOT NULL,
+    acquirer TEXT NOT NULL,
+    failure_reason TEXT,
+    payment_method_id UUID,
+    card_last4 TEXT,
+    card_brand TEXT,
+    last_error_category TEXT,
+    group_id UUID,
+    group_part INT,
+
+    as_of TIMESTAMP WITH TIME ZONE NOT NULL,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_read_model_customer
+    ON payment_read_model(merchant_id, customer_id, created_at DESC);
+
+ALTER TABLE payment_read_model ENABLE ROW LEVEL SECURITY;
+ALTER TABLE payment_read_model FORCE ROW LEVEL SECURITY;
+CREATE POLICY merchant_isolation ON payment_read_model
+    USING (
+        current_setting('app.all_merchants', true) = 'on'
+        OR merchant_id = current_setting('app.merchant_id', true)
+    );
+
+INSERT INTO payment_read_model (
+    id, merchant_id, order_id, customer_id, amount_cents, currency, status,
+    bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+    created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at, first_captured_at,
+    attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+    payment_method_id, card_last4, card_brand, last_error_category, group_id, group_part, as_of
+)
+SELECT id, merchant_id, order_id, customer_id, amount_cents, currency, status,
+       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at, first_captured_at,
+       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+       payment_method_id, card_last4, card_brand, last_error_category, group_id, group_part,
+       COALESCE(status_changed_at, created_at)
+FROM payments
+ON CONFLICT (id) DO NOTHING;
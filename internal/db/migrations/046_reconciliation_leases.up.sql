@@ -0,0 +1,7 @@
+-- Payments a reconciliation run is checking, so that runs overlapping it, such as the
+-- workers of two instances, skip them. A run deletes its leases when it ends; a lease
+-- left by a run that crashed stops counting at leased_until.
+CREATE TABLE IF NOT EXISTS reconciliation_leases (
+    payment_id UUID PRIMARY KEY REFERENCES payments(id) ON DELETE CASCADE,
+    leased_until TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,2 @@
+ALTER TABLE payment_read_model DROP COLUMN IF EXISTS decline_category;
+ALTER TABLE payments DROP COLUMN IF EXISTS decline_category;
@@ -0,0 +1,4 @@
+-- What the customer can do about a payment the bank refused for good (do_not_retry,
+-- retry_later, customer_action_required, try_other_card), for storefront messaging
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS decline_category TEXT;
+ALTER TABLE payment_read_model ADD COLUMN IF NOT EXISTS decline_category TEXT;
@@ -0,0 +1 @@
+ALTER TABLE merchant_settings DROP COLUMN IF EXISTS skip_soft_decline_retry;
@@ -0,0 +1,3 @@
+-- Merchants that would rather show a soft decline to the customer at once than have
+-- the gateway send the authorization once more after GATEWAY_WORKER__SOFT_DECLINE_RETRY_DELAY
+ALTER TABLE merchant_settings ADD COLUMN IF NOT EXISTS skip_soft_decline_retry BOOLEAN NOT NULL DEFAULT FALSE;
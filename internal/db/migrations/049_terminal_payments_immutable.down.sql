@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS payments_terminal_immutable ON payments;
+DROP FUNCTION IF EXISTS guard_terminal_payment();
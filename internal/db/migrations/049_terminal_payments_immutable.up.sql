@@ -0,0 +1,23 @@
+-- Defense in depth against a worker bug resurrecting a closed payment: once FAILED,
+-- VOIDED or REFUNDED, a payment's status and amounts stay as they are, whichever
+-- statement tries to change them. Other columns, such as those erasure scrubs, may
+-- still change. An operator correcting a payment by hand turns the guard off for
+-- their transaction first with SET LOCAL app.allow_terminal_update = 'on'.
+CREATE OR REPLACE FUNCTION guard_terminal_payment() RETURNS trigger AS $$
+BEGIN
+    IF OLD.status IN ('FAILED', 'VOIDED', 'REFUNDED')
+        AND (NEW.status, NEW.captured_amount_cents, NEW.refunded_amount_cents)
+            IS DISTINCT FROM (OLD.status, OLD.captured_amount_cents, OLD.refunded_amount_cents)
+        AND current_setting('app.allow_terminal_update', true) IS DISTINCT FROM 'on'
+    THEN
+        RAISE EXCEPTION 'payment % is % and cannot be changed', OLD.id, OLD.status
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'payments_terminal_immutable';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS payments_terminal_immutable ON payments;
+CREATE TRIGGER payments_terminal_immutable
+    BEFORE UPDATE ON payments
+    FOR EACH ROW EXECUTE FUNCTION guard_terminal_payment();
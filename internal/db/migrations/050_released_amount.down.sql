@@ -0,0 +1,16 @@
+CREATE OR REPLACE FUNCTION guard_terminal_payment() RETURNS trigger AS $$
+BEGIN
+    IF OLD.status IN ('FAILED', 'VOIDED', 'REFUNDED')
+        AND (NEW.status, NEW.captured_amount_cents, NEW.refunded_amount_cents)
+            IS DISTINCT FROM (OLD.status, OLD.captured_amount_cents, OLD.refunded_amount_cents)
+        AND current_setting('app.allow_terminal_update', true) IS DISTINCT FROM 'on'
+    THEN
+        RAISE EXCEPTION 'payment % is % and cannot be changed', OLD.id, OLD.status
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'payments_terminal_immutable';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE payment_read_model DROP COLUMN IF EXISTS released_amount_cents;
+ALTER TABLE payments DROP COLUMN IF EXISTS released_amount_cents;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_payments_bank_auth_id;
+
+ALTER TABLE payment_operations DROP COLUMN IF EXISTS bank_initiated;
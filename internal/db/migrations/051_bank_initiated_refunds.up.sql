@@ -0,0 +1,6 @@
+-- Refunds the bank made on its own, reported through its webhook or statement and
+-- recorded after the fact. They are matched to their payment by authorization.
+ALTER TABLE payment_operations ADD COLUMN IF NOT EXISTS bank_initiated BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_payments_bank_auth_id ON payments(merchant_id, bank_auth_id)
+    WHERE bank_auth_id IS NOT NULL;
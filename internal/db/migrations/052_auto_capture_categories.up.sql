@@ -0,0 +1,3 @@
+-- Order categories, such as digital goods, a merchant ships the moment the payment is
+-- authorized, so the authorization is captured at once within the same request
+ALTER TABLE merchant_settings ADD COLUMN IF NOT EXISTS auto_capture_categories TEXT[] NOT NULL DEFAULT '{}';
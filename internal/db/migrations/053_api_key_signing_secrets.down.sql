@@ -0,0 +1,2 @@
+ALTER TABLE api_keys DROP COLUMN IF EXISTS signing_secret_key_id;
+ALTER TABLE api_keys DROP COLUMN IF EXISTS signing_secret_ciphertext;
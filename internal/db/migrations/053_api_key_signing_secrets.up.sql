@@ -0,0 +1,4 @@
+-- A key signs requests with a secret of its own, sealed by the vault, rather than with
+-- key_hash, which anyone who reads this table would know. Keys without one cannot sign.
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS signing_secret_ciphertext BYTEA;
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS signing_secret_key_id TEXT;
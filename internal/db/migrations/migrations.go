@@ -0,0 +1,10 @@
+// Package migrations embeds the database migrations, so the gateway binary can apply
+// them without the source tree.
+package migrations
+
+import "embed"
+
+// Files holds every NNN_name.up.sql and NNN_name.down.sql migration
+//
+//go:embed *.sql
+var Files embed.FS
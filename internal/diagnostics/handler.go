@@ -0,0 +1,65 @@
+// Package diagnostics serves the Go runtime's profiles and a summary of its state, for
+// finding goroutine leaks and memory growth in a running gateway. The handler exposes
+// internals and must only be served behind admin authentication, away from the API.
+package diagnostics
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+)
+
+// RuntimeStats is a snapshot of the runtime, cheap enough to poll while a goroutine
+// or heap pileup builds up
+type RuntimeStats struct {
+	Goroutines     int       `json:"goroutines"`
+	GOMAXPROCS     int       `json:"gomaxprocs"`
+	HeapAllocBytes uint64    `json:"heap_alloc_bytes"`
+	HeapObjects    uint64    `json:"heap_objects"`
+	HeapSysBytes   uint64    `json:"heap_sys_bytes"`
+	NumGC          uint32    `json:"num_gc"`
+	LastGCPauseNs  uint64    `json:"last_gc_pause_ns"`
+	StartedAt      time.Time `json:"started_at"`
+	UptimeSeconds  float64   `json:"uptime_seconds"`
+}
+
+// NewHandler serves:
+//
+// GET /debug/runtime → RuntimeStats as JSON
+//
+// /debug/pprof/...   → net/http/pprof, e.g. /debug/pprof/goroutine?debug=2 for every
+// goroutine's stack and /debug/pprof/heap for a heap profile
+func NewHandler(startedAt time.Time) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /debug/runtime", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ReadRuntimeStats(startedAt)) //nolint:errcheck // client went away
+	})
+	return mux
+}
+
+// ReadRuntimeStats takes a snapshot of the runtime. It stops the world briefly to read
+// the memory statistics.
+func ReadRuntimeStats(startedAt time.Time) RuntimeStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	return RuntimeStats{
+		Goroutines:     runtime.NumGoroutine(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapObjects:    mem.HeapObjects,
+		HeapSysBytes:   mem.HeapSys,
+		NumGC:          mem.NumGC,
+		LastGCPauseNs:  mem.PauseNs[(mem.NumGC+255)%256],
+		StartedAt:      startedAt,
+		UptimeSeconds:  time.Since(startedAt).Seconds(),
+	}
+}
@@ -0,0 +1,36 @@
+package diagnostics_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/diagnostics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler_ServesRuntimeStats(t *testing.T) {
+	startedAt := time.Now().Add(-time.Minute)
+	rec := httptest.NewRecorder()
+
+	diagnostics.NewHandler(startedAt).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/runtime", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var stats diagnostics.RuntimeStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &stats))
+	assert.Positive(t, stats.Goroutines)
+	assert.Positive(t, stats.HeapAllocBytes)
+	assert.GreaterOrEqual(t, stats.UptimeSeconds, 60.0)
+}
+
+func TestHandler_ServesGoroutineDump(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	diagnostics.NewHandler(time.Now()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/pprof/goroutine?debug=2", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "goroutine ")
+}
@@ -0,0 +1,48 @@
+package domain
+
+import "time"
+
+// Role is what an API key may do. Each role may also do everything the roles below it
+// may.
+type Role string
+
+const (
+	// RoleViewer reads payments and the resources around them
+	RoleViewer Role = "viewer"
+	// RoleOperator also moves money and reads the operational views under /admin
+	RoleOperator Role = "operator"
+	// RoleAdmin also changes gateway and merchant configuration and erases customers
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:   1,
+	RoleOperator: 2,
+	RoleAdmin:    3,
+}
+
+// Allows reports whether r may do what required may. An unknown role allows nothing.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// APIKey is a credential issued to a merchant, acting in one role
+type APIKey struct {
+	ID         string
+	MerchantID string
+	Role       Role
+}
+
+// AuditEntry records a privileged request: who made it, what it was and how it ended.
+// APIKeyID is nil for requests made without a key while keys are optional.
+type AuditEntry struct {
+	ID         string
+	MerchantID string
+	APIKeyID   *string
+	Role       Role
+	Action     string
+	Target     string
+	Status     int
+	CreatedAt  time.Time
+}
@@ -0,0 +1,17 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, domain.RoleAdmin.Allows(domain.RoleOperator))
+	assert.True(t, domain.RoleOperator.Allows(domain.RoleOperator))
+	assert.True(t, domain.RoleOperator.Allows(domain.RoleViewer))
+	assert.False(t, domain.RoleViewer.Allows(domain.RoleOperator))
+	assert.False(t, domain.RoleOperator.Allows(domain.RoleAdmin))
+	assert.False(t, domain.Role("owner").Allows(domain.RoleViewer), "unknown roles allow nothing")
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Backoff is how long to wait before retrying something that failed: Base before the
+// first retry, doubled after each one up to Max, plus up to Jitter at random so that
+// callers failing together do not retry together. A zero Max leaves the wait uncapped.
+// The bank client's retries within a call and the retry worker's retries across
+// passes both wait by it.
+type Backoff struct {
+	Base   time.Duration
+	Max    time.Duration
+	Jitter time.Duration
+}
+
+// Delay returns the wait after the given number of failed attempts, counted from zero
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.Base
+	for range max(attempt, 0) {
+		if b.Max > 0 && delay >= b.Max {
+			break
+		}
+		// Doubling past a few hundred years overflows; no retry waits that long
+		if delay > time.Duration(1<<62) {
+			break
+		}
+		delay *= 2
+	}
+	if b.Max > 0 {
+		delay = min(delay, b.Max)
+	}
+
+	if b.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(b.Jitter))) //nolint:gosec // not cryptographic
+	}
+	return delay
+}
+
+// WithMax returns the backoff capped at limit instead
+func (b Backoff) WithMax(limit time.Duration) Backoff {
+	b.Max = limit
+	return b
+}
@@ -0,0 +1,30 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	b := domain.Backoff{Base: time.Minute, Max: 10 * time.Minute}
+
+	assert.Equal(t, time.Minute, b.Delay(0))
+	assert.Equal(t, 2*time.Minute, b.Delay(1))
+	assert.Equal(t, 8*time.Minute, b.Delay(3))
+	assert.Equal(t, 10*time.Minute, b.Delay(4))
+	assert.Equal(t, 10*time.Minute, b.Delay(500))
+
+	uncapped := domain.Backoff{Base: time.Second}
+	assert.Equal(t, 1024*time.Second, uncapped.Delay(10))
+	assert.Positive(t, uncapped.Delay(500))
+
+	jittered := domain.Backoff{Base: time.Second, Jitter: time.Second}
+	for range 20 {
+		delay := jittered.Delay(1)
+		assert.GreaterOrEqual(t, delay, 2*time.Second)
+		assert.Less(t, delay, 3*time.Second)
+	}
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+// BankAttempt is one request sent to the bank and what came back. Payloads are
+// stored redacted; card numbers and CVVs never reach this record.
+type BankAttempt struct {
+	ID              string
+	Acquirer        string
+	Operation       string
+	IdempotencyKey  string
+	StatusCode      int
+	ErrorCode       string
+	Latency         time.Duration
+	RequestPayload  []byte
+	ResponsePayload []byte
+	AttemptedAt     time.Time
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// MaxBankRefunds caps how many bank refunds one request may record
+const MaxBankRefunds = 100
+
+// BankRefund is a refund the bank reports having made on its own, through its webhook
+// or a line of its statement, against one of the gateway's authorizations
+type BankRefund struct {
+	BankRefundID string
+	BankAuthID   string
+	AmountCents  int64
+	RefundedAt   time.Time
+}
+
+func (r BankRefund) Validate() error {
+	if r.BankRefundID == "" || r.BankAuthID == "" || r.RefundedAt.IsZero() {
+		return ErrMissingRequiredField
+	}
+	if r.AmountCents <= 0 {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// IdempotencyKey is the key the refund's operation is stored under. The bank's webhook
+// and its statement report the same refund, and it is recorded once for both.
+func (r BankRefund) IdempotencyKey() string {
+	return "bank-refund:" + r.BankRefundID
+}
+
+// BankRefundOutcome says what became of a refund the bank reported
+type BankRefundOutcome string
+
+const (
+	// BankRefundRecorded refunds the payment holding the authorization
+	BankRefundRecorded BankRefundOutcome = "RECORDED"
+	// BankRefundAlreadyRecorded is a refund recorded before, by an earlier report
+	BankRefundAlreadyRecorded BankRefundOutcome = "ALREADY_RECORDED"
+	// BankRefundUnrecorded could not be recorded and was raised as an
+	// IssueUnrecordedRefund for someone to look into
+	BankRefundUnrecorded BankRefundOutcome = "UNRECORDED"
+)
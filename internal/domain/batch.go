@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxBatchItems caps how many payments one batch may touch
+const MaxBatchItems = 100
+
+// BatchType is the operation a batch applies to each of its payments
+type BatchType string
+
+const (
+	BatchRefund BatchType = "REFUND"
+	BatchVoid   BatchType = "VOID"
+)
+
+type BatchStatus string
+
+const (
+	// BatchProcessing has items the worker has not settled yet
+	BatchProcessing BatchStatus = "PROCESSING"
+	BatchCompleted  BatchStatus = "COMPLETED"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemPending   BatchItemStatus = "PENDING"
+	BatchItemSucceeded BatchItemStatus = "SUCCEEDED"
+	BatchItemFailed    BatchItemStatus = "FAILED"
+)
+
+// Batch applies one operation to many payments in the background, for bulk actions
+// such as compensating customers after an incident. Each item succeeds or fails on
+// its own; the batch is COMPLETED once none is pending.
+type Batch struct {
+	CreatedAt   time.Time
+	ID          string
+	Type        BatchType
+	Reason      OperationReason
+	Status      BatchStatus
+	CompletedAt *time.Time
+	Items       []*BatchItem
+}
+
+// BatchItem is one payment of a batch with its own outcome
+type BatchItem struct {
+	ID        string
+	BatchID   string
+	PaymentID string
+	// AmountCents is the amount to refund; zero refunds whatever is left
+	AmountCents int64
+	Status      BatchItemStatus
+	// OperationID is the operation the item created, once it reached the payment
+	OperationID *string
+	// ErrorCode is the error that failed the item, as returned by the API
+	ErrorCode   *string
+	CompletedAt *time.Time
+}
+
+// NewRefundBatch creates a batch that refunds each item's payment. A payment may only
+// appear once, so a batch cannot refund it twice by mistake.
+func NewRefundBatch(id string, reason OperationReason, items []*BatchItem) (*Batch, error) {
+	for _, item := range items {
+		if item.AmountCents < 0 {
+			return nil, ErrInvalidAmount
+		}
+	}
+	return newBatch(id, BatchRefund, reason, items)
+}
+
+// NewVoidBatch creates a batch that voids each item's payment in full
+func NewVoidBatch(id string, reason OperationReason, items []*BatchItem) (*Batch, error) {
+	for _, item := range items {
+		if item.AmountCents != 0 {
+			return nil, ErrInvalidAmount
+		}
+	}
+	return newBatch(id, BatchVoid, reason, items)
+}
+
+func newBatch(id string, batchType BatchType, reason OperationReason, items []*BatchItem) (*Batch, error) {
+	if id == "" {
+		return nil, errors.New("batch ID is required")
+	}
+	if len(items) == 0 || len(items) > MaxBatchItems {
+		return nil, ErrInvalidBatch
+	}
+	if err := reason.Validate(); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item.ID == "" || item.PaymentID == "" {
+			return nil, ErrMissingRequiredField
+		}
+		if seen[item.PaymentID] {
+			return nil, ErrInvalidBatch
+		}
+		seen[item.PaymentID] = true
+
+		item.BatchID = id
+		item.Status = BatchItemPending
+	}
+
+	return &Batch{
+		ID:        id,
+		Type:      batchType,
+		Reason:    reason,
+		Status:    BatchProcessing,
+		Items:     items,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Succeed records that the item's operation went through
+func (i *BatchItem) Succeed(operationID string, at time.Time) error {
+	if i.Status != BatchItemPending {
+		return ErrInvalidTransition
+	}
+	i.Status = BatchItemSucceeded
+	i.OperationID = &operationID
+	i.CompletedAt = &at
+	return nil
+}
+
+// Fail records the error that stopped the item's operation. The operation ID is empty
+// when the item was rejected before an operation was created.
+func (i *BatchItem) Fail(operationID, errorCode string, at time.Time) error {
+	if i.Status != BatchItemPending {
+		return ErrInvalidTransition
+	}
+	i.Status = BatchItemFailed
+	if operationID != "" {
+		i.OperationID = &operationID
+	}
+	i.ErrorCode = &errorCode
+	i.CompletedAt = &at
+	return nil
+}
+
+// Count returns how many items have the given status
+func (b *Batch) Count(status BatchItemStatus) int {
+	var n int
+	for _, item := range b.Items {
+		if item.Status == status {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batchItems(n int) []*domain.BatchItem {
+	items := make([]*domain.BatchItem, 0, n)
+	for i := range n {
+		items = append(items, &domain.BatchItem{
+			ID:        fmt.Sprintf("item-%d", i),
+			PaymentID: fmt.Sprintf("pay-%d", i),
+		})
+	}
+	return items
+}
+
+func TestNewRefundBatch(t *testing.T) {
+	t.Run("starts with every item pending", func(t *testing.T) {
+		batch, err := domain.NewRefundBatch("batch-123", domain.ReasonDuplicate, batchItems(3))
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.BatchProcessing, batch.Status)
+		assert.Equal(t, 3, batch.Count(domain.BatchItemPending))
+		assert.Equal(t, "batch-123", batch.Items[0].BatchID)
+	})
+
+	t.Run("rejects an empty or oversized batch", func(t *testing.T) {
+		_, err := domain.NewRefundBatch("batch-123", "", nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidBatch)
+
+		_, err = domain.NewRefundBatch("batch-123", "", batchItems(domain.MaxBatchItems+1))
+		assert.ErrorIs(t, err, domain.ErrInvalidBatch)
+	})
+
+	t.Run("rejects a payment listed twice", func(t *testing.T) {
+		items := batchItems(2)
+		items[1].PaymentID = items[0].PaymentID
+
+		_, err := domain.NewRefundBatch("batch-123", "", items)
+		assert.ErrorIs(t, err, domain.ErrInvalidBatch)
+	})
+
+	t.Run("rejects a negative amount", func(t *testing.T) {
+		items := batchItems(1)
+		items[0].AmountCents = -1
+
+		_, err := domain.NewRefundBatch("batch-123", "", items)
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+}
+
+func TestNewVoidBatch(t *testing.T) {
+	t.Run("voids each payment in full", func(t *testing.T) {
+		batch, err := domain.NewVoidBatch("batch-123", domain.ReasonOutOfStock, batchItems(2))
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.BatchVoid, batch.Type)
+		assert.Equal(t, 2, batch.Count(domain.BatchItemPending))
+	})
+
+	t.Run("rejects an amount", func(t *testing.T) {
+		items := batchItems(1)
+		items[0].AmountCents = 500
+
+		_, err := domain.NewVoidBatch("batch-123", "", items)
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+
+	t.Run("rejects an empty batch", func(t *testing.T) {
+		_, err := domain.NewVoidBatch("batch-123", "", nil)
+		assert.ErrorIs(t, err, domain.ErrInvalidBatch)
+	})
+}
+
+func TestBatchItem_Outcome(t *testing.T) {
+	batch, err := domain.NewRefundBatch("batch-123", "", batchItems(2))
+	require.NoError(t, err)
+	now := time.Now()
+
+	require.NoError(t, batch.Items[0].Succeed("op-1", now))
+	require.NoError(t, batch.Items[1].Fail("", "PAYMENT_NOT_FOUND", now))
+
+	assert.Equal(t, 1, batch.Count(domain.BatchItemSucceeded))
+	assert.Equal(t, 1, batch.Count(domain.BatchItemFailed))
+	assert.Nil(t, batch.Items[1].OperationID)
+	assert.ErrorIs(t, batch.Items[0].Fail("op-1", "INTERNAL_ERROR", now), domain.ErrInvalidTransition)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// DeadLetter is a payment the retry worker gave up on. The payment stays in Status,
+// mid-transition, until someone looks into LastError and requeues it.
+type DeadLetter struct {
+	PaymentID      string
+	MerchantID     string
+	Status         PaymentStatus
+	IdempotencyKey string
+	Attempts       int
+	LastError      string
+	DeadLetteredAt time.Time
+}
+
+func NewDeadLetter(payment *Payment, idempotencyKey string, lastErr error) *DeadLetter {
+	return &DeadLetter{
+		PaymentID:      payment.ID,
+		MerchantID:     payment.MerchantID,
+		Status:         payment.Status,
+		IdempotencyKey: idempotencyKey,
+		Attempts:       payment.AttemptCount,
+		LastError:      lastErr.Error(),
+		DeadLetteredAt: time.Now(),
+	}
+}
+
+// MaxReplay caps how many payments one replay hands back to the retry worker
+const MaxReplay = 500
+
+// MaxReplayIDs caps how many payments a replay may name
+const MaxReplayIDs = 100
+
+// ReplayFilter picks the payments a replay hands back to the retry worker: those named
+// by PaymentIDs, or those mid-transition in Status. Exactly one must be given.
+type ReplayFilter struct {
+	PaymentIDs []string
+	Status     PaymentStatus
+}
+
+func (f ReplayFilter) Validate() error {
+	if (len(f.PaymentIDs) == 0) == (f.Status == "") || len(f.PaymentIDs) > MaxReplayIDs {
+		return ErrInvalidReplay
+	}
+	if _, ok := OperationTypeFor(f.Status); f.Status != "" && !ok {
+		return ErrInvalidReplay
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package domain_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplayFilter_Validate(t *testing.T) {
+	ids := func(n int) []string {
+		out := make([]string, n)
+		for i := range out {
+			out[i] = fmt.Sprintf("pay-%d", i)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name    string
+		filter  domain.ReplayFilter
+		wantErr bool
+	}{
+		{"payment ids", domain.ReplayFilter{PaymentIDs: ids(2)}, false},
+		{"processing status", domain.ReplayFilter{Status: domain.StatusCapturing}, false},
+		{"nothing given", domain.ReplayFilter{}, true},
+		{"both given", domain.ReplayFilter{PaymentIDs: ids(1), Status: domain.StatusVoiding}, true},
+		{"too many ids", domain.ReplayFilter{PaymentIDs: ids(domain.MaxReplayIDs + 1)}, true},
+		{"settled status", domain.ReplayFilter{Status: domain.StatusCaptured}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.filter.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidReplay)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// MaxDebugSessionTTL bounds how long raw bank traffic is kept for a single session
+const MaxDebugSessionTTL = 24 * time.Hour
+
+// DebugSession turns on bank traffic capture for one payment or one idempotency key
+// until it expires. Captures are deleted together with the session.
+type DebugSession struct {
+	ID             string
+	PaymentID      string
+	IdempotencyKey string
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+}
+
+func NewDebugSession(id, paymentID, idempotencyKey string, ttl time.Duration, now time.Time) (*DebugSession, error) {
+	if (paymentID == "") == (idempotencyKey == "") {
+		return nil, ErrInvalidDebugTarget
+	}
+	if ttl <= 0 || ttl > MaxDebugSessionTTL {
+		return nil, ErrInvalidDebugTTL
+	}
+
+	return &DebugSession{
+		ID:             id,
+		PaymentID:      paymentID,
+		IdempotencyKey: idempotencyKey,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}, nil
+}
+
+// DebugCapture is one sanitized HTTP exchange with the bank recorded under a session
+type DebugCapture struct {
+	ID             string
+	SessionID      string
+	IdempotencyKey string
+	Method         string
+	URL            string
+	RequestBody    string
+	ResponseStatus int
+	ResponseBody   string
+	CapturedAt     time.Time
+}
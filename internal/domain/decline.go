@@ -0,0 +1,58 @@
+package domain
+
+// DeclineCategory sorts the bank's decline codes, which differ between acquirers and
+// number in the dozens, into what a storefront can tell the customer to do next
+type DeclineCategory string
+
+const (
+	// DeclineDoNotRetry will be declined again whatever the customer does, such as a
+	// card reported stolen or an operation the payment's state rules out
+	DeclineDoNotRetry DeclineCategory = "do_not_retry"
+	// DeclineRetryLater may go through if the same card is tried again later, such as
+	// when the issuer could not be reached
+	DeclineRetryLater DeclineCategory = "retry_later"
+	// DeclineCustomerActionRequired goes through once the customer corrects the card
+	// details, such as a mistyped CVV or expiry
+	DeclineCustomerActionRequired DeclineCategory = "customer_action_required"
+	// DeclineTryOtherCard asks for a different card, such as after insufficient funds
+	DeclineTryOtherCard DeclineCategory = "try_other_card"
+)
+
+var declineCategories = map[string]DeclineCategory{
+	"lost_card":                  DeclineDoNotRetry,
+	"stolen_card":                DeclineDoNotRetry,
+	"pickup_card":                DeclineDoNotRetry,
+	"fraudulent":                 DeclineDoNotRetry,
+	"restricted_card":            DeclineDoNotRetry,
+	"invalid_amount":             DeclineDoNotRetry,
+	"authorization_already_used": DeclineDoNotRetry,
+	"authorization_expired":      DeclineDoNotRetry,
+	"already_captured":           DeclineDoNotRetry,
+	"already_voided":             DeclineDoNotRetry,
+	"already_refunded":           DeclineDoNotRetry,
+
+	"issuer_unavailable": DeclineRetryLater,
+	"try_again_later":    DeclineRetryLater,
+	"processing_error":   DeclineRetryLater,
+
+	"invalid_card":            DeclineCustomerActionRequired,
+	"incorrect_number":        DeclineCustomerActionRequired,
+	"invalid_cvv":             DeclineCustomerActionRequired,
+	"card_expired":            DeclineCustomerActionRequired,
+	"authentication_required": DeclineCustomerActionRequired,
+
+	"insufficient_funds":     DeclineTryOtherCard,
+	"do_not_honor":           DeclineTryOtherCard,
+	"card_not_supported":     DeclineTryOtherCard,
+	"currency_not_supported": DeclineTryOtherCard,
+	"generic_decline":        DeclineTryOtherCard,
+}
+
+// ClassifyDecline returns the category of a bank decline code. A code it does not know
+// asks for another card, which is what a storefront can offer for any decline.
+func ClassifyDecline(code string) DeclineCategory {
+	if category, ok := declineCategories[code]; ok {
+		return category
+	}
+	return DeclineTryOtherCard
+}
@@ -0,0 +1,34 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyDecline(t *testing.T) {
+	tests := map[string]domain.DeclineCategory{
+		"stolen_card":           domain.DeclineDoNotRetry,
+		"authorization_expired": domain.DeclineDoNotRetry,
+		"issuer_unavailable":    domain.DeclineRetryLater,
+		"invalid_cvv":           domain.DeclineCustomerActionRequired,
+		"card_expired":          domain.DeclineCustomerActionRequired,
+		"insufficient_funds":    domain.DeclineTryOtherCard,
+		"something_new":         domain.DeclineTryOtherCard,
+	}
+	for code, want := range tests {
+		assert.Equal(t, want, domain.ClassifyDecline(code), code)
+	}
+}
+
+func TestPayment_RecordDecline(t *testing.T) {
+	payment, err := domain.NewPayment("pay-123", "order-456", "cust-789", 500, "USD")
+	require.NoError(t, err)
+
+	payment.RecordDecline("insufficient_funds")
+
+	require.NotNil(t, payment.DeclineCategory)
+	assert.Equal(t, domain.DeclineTryOtherCard, *payment.DeclineCategory)
+}
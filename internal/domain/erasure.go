@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// CustomerTokenPrefix marks a customer ID that an erasure has replaced
+const CustomerTokenPrefix = "anon_"
+
+// Erasure records one request to forget a customer. Payments created before
+// RetentionCutoff lose their customer ID and card details; newer ones are financial
+// records the gateway must keep and are counted in PaymentsRetained instead. The
+// customer is known only by CustomerToken, which replaces their ID wherever it was
+// erased and cannot be turned back into it.
+type Erasure struct {
+	ID                       string
+	MerchantID               string
+	CustomerToken            string
+	RetentionCutoff          time.Time
+	PaymentsAnonymized       int
+	PaymentsRetained         int
+	PaymentMethodsAnonymized int
+	SubscriptionsAnonymized  int
+	CreatedAt                time.Time
+}
+
+// NewErasure starts the erasure of a customer's data older than retention. token
+// must be random: it is the only link left between the erased records.
+func NewErasure(id, customerID, token string, retention time.Duration, now time.Time) (*Erasure, error) {
+	if id == "" || customerID == "" || token == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if strings.HasPrefix(customerID, CustomerTokenPrefix) {
+		return nil, ErrInvalidErasure
+	}
+
+	return &Erasure{
+		ID:              id,
+		CustomerToken:   CustomerTokenPrefix + token,
+		RetentionCutoff: now.Add(-retention),
+		CreatedAt:       now,
+	}, nil
+}
@@ -0,0 +1,32 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewErasure(t *testing.T) {
+	t.Run("erases what is older than retention", func(t *testing.T) {
+		now := time.Now()
+
+		erasure, err := domain.NewErasure("erasure-123", "cust-123", "3f2b9c1d", 24*time.Hour, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, "anon_3f2b9c1d", erasure.CustomerToken)
+		assert.Equal(t, now.Add(-24*time.Hour), erasure.RetentionCutoff)
+	})
+
+	t.Run("rejects a missing customer", func(t *testing.T) {
+		_, err := domain.NewErasure("erasure-123", "", "3f2b9c1d", 24*time.Hour, time.Now())
+		assert.ErrorIs(t, err, domain.ErrMissingRequiredField)
+	})
+
+	t.Run("rejects a customer that is already a token", func(t *testing.T) {
+		_, err := domain.NewErasure("erasure-123", "anon_3f2b9c1d", "5e6f7a8b", 24*time.Hour, time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvalidErasure)
+	})
+}
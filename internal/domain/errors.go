@@ -5,9 +5,40 @@ import (
 )
 
 var (
-	ErrInvalidTransition    = errors.New("invalid transition")
-	ErrPaymentExpired       = errors.New("payment expired")
-	ErrInvalidAmount        = errors.New("invalid amount")
-	ErrMissingRequiredField = errors.New("missing required fields")
-	ErrInvalidState         = errors.New("invalid state")
+	ErrInvalidTransition          = errors.New("invalid transition")
+	ErrPaymentExpired             = errors.New("payment expired")
+	ErrInvalidAmount              = errors.New("invalid amount")
+	ErrMissingRequiredField       = errors.New("missing required fields")
+	ErrInvalidState               = errors.New("invalid state")
+	ErrInvalidReason              = errors.New("invalid reason")
+	ErrInvalidDebugTarget         = errors.New("debug session must target exactly one payment or idempotency key")
+	ErrInvalidDebugTTL            = errors.New("debug session ttl out of range")
+	ErrInvalidPaymentMethod       = errors.New("invalid payment method")
+	ErrPaymentMethodExpired       = errors.New("payment method expired")
+	ErrInvalidSchedule            = errors.New("scheduled time must be in the future")
+	ErrInvalidInterval            = errors.New("invalid billing interval")
+	ErrInvalidPayout              = errors.New("invalid payout")
+	ErrInvalidBatch               = errors.New("batch must have between 1 and 100 distinct payments")
+	ErrCurrencyNotAllowed         = errors.New("currency not accepted by merchant")
+	ErrRefundWindowClosed         = errors.New("refund window has closed")
+	ErrQuotaExceeded              = errors.New("daily quota exceeded")
+	ErrInvalidErasure             = errors.New("customer id is already an erasure token")
+	ErrInvalidBatchGet            = errors.New("between 1 and 100 payment ids may be fetched at once")
+	ErrAmountTooSmall             = errors.New("amount below the minimum")
+	ErrAmountTooLarge             = errors.New("amount above the maximum")
+	ErrInvalidPaymentFilter       = errors.New("invalid payment filter")
+	ErrInvalidReconciliationRange = errors.New("reconciliation range must be at most 31 days with from before to")
+	ErrSelfApproval               = errors.New("a refund must be approved by someone other than who requested it")
+	ErrReviewDecided              = errors.New("payment review has already been decided")
+	ErrNoReceipt                  = errors.New("payment has no receipt until it is authorized")
+	ErrRegionStandby              = errors.New("region is on standby and does not take writes")
+	ErrStaleRegionEpoch           = errors.New("payment was written under a later region epoch")
+	ErrPaymentIntentExpired       = errors.New("payment intent expired")
+	ErrInvalidPaymentGroup        = errors.New("a payment group must be split into 2 parts of a positive amount")
+	ErrInvalidNetworkToken        = errors.New("network token needs a 12-19 digit number and a 20-byte base64 cryptogram")
+	ErrCardAndNetworkToken        = errors.New("a payment is made with either a card number and cvv or a network token")
+	ErrCardVelocityExceeded       = errors.New("card used too often")
+	ErrInvalidRefundDestination   = errors.New("a bank transfer refund needs an account and routing number, and other destinations neither")
+	ErrInvalidBankRefunds         = errors.New("between 1 and 100 bank refunds may be recorded at once")
+	ErrInvalidReplay              = errors.New("a replay takes between 1 and 100 payment ids or a processing status, not both")
 )
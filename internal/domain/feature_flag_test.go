@@ -0,0 +1,62 @@
+package domain_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureRollout(t *testing.T) {
+	rollout, err := domain.ParseFeatureRollout(" canary_routing:10 ,")
+	require.NoError(t, err)
+	assert.Equal(t, 10, rollout[domain.FlagCanaryRouting])
+	assert.Equal(t, domain.DefaultFeatureRollout[domain.FlagAsyncAuthorize], rollout[domain.FlagAsyncAuthorize])
+
+	for _, s := range []string{"canary_routing", "canary_routing:101", "canary_routing:-1", "canary_routing:1,canary_routing:2"} {
+		_, err := domain.ParseFeatureRollout(s)
+		assert.Error(t, err, s)
+	}
+	_, err = domain.ParseFeatureRollout("teleport:50")
+	assert.ErrorIs(t, err, domain.ErrUnknownFeatureFlag)
+}
+
+func TestFeatureFlag_EnabledFor(t *testing.T) {
+	_, err := domain.NewFeatureFlag("teleport", 50)
+	assert.ErrorIs(t, err, domain.ErrUnknownFeatureFlag)
+	_, err = domain.NewFeatureFlag(domain.FlagCanaryRouting, 101)
+	assert.ErrorIs(t, err, domain.ErrInvalidRolloutPercent)
+
+	flag, err := domain.NewFeatureFlag(domain.FlagCanaryRouting, 0)
+	require.NoError(t, err)
+	assert.False(t, flag.EnabledFor("merchant-1"))
+
+	flag.Overrides["merchant-1"] = true
+	assert.True(t, flag.EnabledFor("merchant-1"))
+
+	flag.Percent = 100
+	flag.Overrides["merchant-2"] = false
+	assert.True(t, flag.EnabledFor("merchant-3"))
+	assert.False(t, flag.EnabledFor("merchant-2"))
+
+	// Raising the percent keeps every merchant that already had the feature
+	enabledAt := func(percent int) map[string]bool {
+		flag := &domain.FeatureFlag{Name: domain.FlagAsyncAuthorize, Percent: percent}
+		enabled := map[string]bool{}
+		for i := range 200 {
+			merchantID := fmt.Sprintf("merchant-%d", i)
+			if flag.EnabledFor(merchantID) {
+				enabled[merchantID] = true
+			}
+		}
+		return enabled
+	}
+	low, high := enabledAt(20), enabledAt(60)
+	assert.NotEmpty(t, low)
+	assert.Greater(t, len(high), len(low))
+	for merchantID := range low {
+		assert.True(t, high[merchantID], merchantID)
+	}
+}
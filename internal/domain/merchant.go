@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// DefaultMerchantID owns everything created without a merchant: requests made without
+// an API key while keys are optional, and all data from before the gateway served
+// several FicMart business units
+const DefaultMerchantID = "default"
+
+// MerchantSettings are the policies a merchant has chosen in place of the gateway
+// defaults. The zero value, used for merchants without settings, changes nothing.
+type MerchantSettings struct {
+	MerchantID string
+	// AllowedCurrencies lists the currencies new payments may use; empty allows any
+	AllowedCurrencies []string
+	// MaxRetries and RetryBaseDelay override the retry policy of bank calls when set
+	MaxRetries     *int
+	RetryBaseDelay *time.Duration
+	// RefundWindow is how long after capture a payment may be refunded; nil is forever
+	RefundWindow *time.Duration
+	// AutoCapture captures each payment in full as soon as it is authorized
+	AutoCapture bool
+	// AutoCaptureCategories lists the order categories whose payments are captured in
+	// full within the authorization request, turning it into a sale
+	AutoCaptureCategories []string
+	// CustomerNotifications lists the channels customers are told about refunds and
+	// failed payments on; empty sends nothing
+	CustomerNotifications []string
+	// SkipSoftDeclineRetry fails a payment the bank soft-declines at once, rather than
+	// sending its authorization once more after the gateway's soft decline delay
+	SkipSoftDeclineRetry bool
+}
+
+// CheckCurrency returns ErrCurrencyNotAllowed unless new payments may use currency
+func (s *MerchantSettings) CheckCurrency(currency string) error {
+	if len(s.AllowedCurrencies) == 0 || slices.Contains(s.AllowedCurrencies, currency) {
+		return nil
+	}
+	return ErrCurrencyNotAllowed
+}
+
+// CapturesOnAuthorize reports whether a payment for an order in category is captured as
+// soon as it is authorized, within the same request
+func (s *MerchantSettings) CapturesOnAuthorize(category string) bool {
+	return category != "" && slices.Contains(s.AutoCaptureCategories, category)
+}
+
+// CheckRefundWindow returns ErrRefundWindowClosed if p was captured longer ago than
+// the refund window
+func (s *MerchantSettings) CheckRefundWindow(p *Payment, now time.Time) error {
+	if s.RefundWindow == nil || p.CapturedAt == nil {
+		return nil
+	}
+	if now.After(p.CapturedAt.Add(*s.RefundWindow)) {
+		return ErrRefundWindowClosed
+	}
+	return nil
+}
+
+// MerchantQuota caps the payments a merchant may create per UTC day, and tracks what
+// it has used today. A nil limit is unlimited. Volume adds up amounts in minor units
+// whatever their currency.
+type MerchantQuota struct {
+	MerchantID            string
+	DailyTransactionLimit *int
+	DailyVolumeLimitCents *int64
+	UsedTransactions      int
+	UsedVolumeCents       int64
+}
+
+// Check returns ErrQuotaExceeded if today's usage is over either limit
+func (q *MerchantQuota) Check() error {
+	if q.DailyTransactionLimit != nil && q.UsedTransactions > *q.DailyTransactionLimit {
+		return ErrQuotaExceeded
+	}
+	if q.DailyVolumeLimitCents != nil && q.UsedVolumeCents > *q.DailyVolumeLimitCents {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
+
+// RemainingTransactions returns how many more payments may be created today, or nil
+// without a limit
+func (q *MerchantQuota) RemainingTransactions() *int {
+	if q.DailyTransactionLimit == nil {
+		return nil
+	}
+	remaining := max(*q.DailyTransactionLimit-q.UsedTransactions, 0)
+	return &remaining
+}
+
+// RemainingVolumeCents returns how much more may be charged today, or nil without a
+// limit
+func (q *MerchantQuota) RemainingVolumeCents() *int64 {
+	if q.DailyVolumeLimitCents == nil {
+		return nil
+	}
+	remaining := max(*q.DailyVolumeLimitCents-q.UsedVolumeCents, 0)
+	return &remaining
+}
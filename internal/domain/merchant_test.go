@@ -0,0 +1,67 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerchantSettings_CheckCurrency(t *testing.T) {
+	assert.NoError(t, (&domain.MerchantSettings{}).CheckCurrency("JPY"), "no list accepts any currency")
+
+	settings := &domain.MerchantSettings{AllowedCurrencies: []string{"USD", "EUR"}}
+	assert.NoError(t, settings.CheckCurrency("EUR"))
+	assert.ErrorIs(t, settings.CheckCurrency("JPY"), domain.ErrCurrencyNotAllowed)
+}
+
+func TestMerchantSettings_CheckRefundWindow(t *testing.T) {
+	now := time.Now()
+	capturedAt := now.Add(-10 * 24 * time.Hour)
+	payment := &domain.Payment{CapturedAt: &capturedAt}
+
+	assert.NoError(t, (&domain.MerchantSettings{}).CheckRefundWindow(payment, now))
+
+	month := 30 * 24 * time.Hour
+	assert.NoError(t, (&domain.MerchantSettings{RefundWindow: &month}).CheckRefundWindow(payment, now))
+
+	week := 7 * 24 * time.Hour
+	assert.ErrorIs(t, (&domain.MerchantSettings{RefundWindow: &week}).CheckRefundWindow(payment, now), domain.ErrRefundWindowClosed)
+}
+
+func TestMerchantSettings_CapturesOnAuthorize(t *testing.T) {
+	settings := &domain.MerchantSettings{AutoCaptureCategories: []string{"digital"}}
+
+	assert.True(t, settings.CapturesOnAuthorize("digital"))
+	assert.False(t, settings.CapturesOnAuthorize("apparel"))
+	assert.False(t, settings.CapturesOnAuthorize(""), "orders without a category are left authorized")
+	assert.False(t, (&domain.MerchantSettings{}).CapturesOnAuthorize("digital"))
+}
+
+func TestMerchantQuota(t *testing.T) {
+	t.Run("unlimited without limits", func(t *testing.T) {
+		quota := &domain.MerchantQuota{UsedTransactions: 1000, UsedVolumeCents: 1_000_000}
+
+		assert.NoError(t, quota.Check())
+		assert.Nil(t, quota.RemainingTransactions())
+		assert.Nil(t, quota.RemainingVolumeCents())
+	})
+
+	t.Run("exceeded past either limit", func(t *testing.T) {
+		transactions, volume := 2, int64(10_000)
+		quota := &domain.MerchantQuota{DailyTransactionLimit: &transactions, DailyVolumeLimitCents: &volume}
+
+		quota.UsedTransactions, quota.UsedVolumeCents = 2, 10_000
+		assert.NoError(t, quota.Check(), "reaching a limit is allowed")
+		assert.Equal(t, 0, *quota.RemainingTransactions())
+
+		quota.UsedTransactions = 3
+		assert.ErrorIs(t, quota.Check(), domain.ErrQuotaExceeded)
+		assert.Equal(t, 0, *quota.RemainingTransactions())
+
+		quota.UsedTransactions, quota.UsedVolumeCents = 1, 10_001
+		assert.ErrorIs(t, quota.Check(), domain.ErrQuotaExceeded)
+		assert.Equal(t, int64(0), *quota.RemainingVolumeCents())
+	})
+}
@@ -0,0 +1,217 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in the minor unit of its currency, e.g. cents for USD or yen for JPY
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// currencyExponents lists the ISO 4217 currencies whose minor unit is not a hundredth
+// of the major one. Every other currency has two decimals.
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyExponent returns how many decimals the currency's major unit is written with
+func CurrencyExponent(currency string) int {
+	if exp, ok := currencyExponents[strings.ToUpper(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// FormatAmount writes the amount in the major unit followed by the currency, such as
+// "50.00 USD" for 5000 or "5000 JPY" for 5000
+func (m Money) FormatAmount() string {
+	exp := CurrencyExponent(m.Currency)
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absInt64(amount), 10)
+	if exp == 0 {
+		return sign + digits + " " + m.Currency
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	split := len(digits) - exp
+	return sign + digits[:split] + "." + digits[split:] + " " + m.Currency
+}
+
+func (m Money) String() string {
+	return m.FormatAmount()
+}
+
+// ParseAmount reads an amount written by FormatAmount. The decimals may be left out or
+// shortened ("50 USD", "50.5 USD") but not exceed the currency's exponent.
+func ParseAmount(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return Money{}, fmt.Errorf("%w: %q is not an amount followed by a currency", ErrInvalidAmount, s)
+	}
+	number, currency := fields[0], strings.ToUpper(fields[1])
+	if len(currency) != 3 || strings.IndexFunc(currency, func(r rune) bool { return r < 'A' || r > 'Z' }) >= 0 {
+		return Money{}, fmt.Errorf("%w: %q is not a currency code", ErrInvalidAmount, fields[1])
+	}
+
+	negative := strings.HasPrefix(number, "-")
+	number = strings.TrimPrefix(number, "-")
+	whole, fraction, hasPoint := strings.Cut(number, ".")
+	exp := CurrencyExponent(currency)
+	if whole == "" || (hasPoint && fraction == "") || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q is not a number", ErrInvalidAmount, fields[0])
+	}
+	if len(fraction) > exp {
+		return Money{}, fmt.Errorf("%w: %s has %d decimals", ErrInvalidAmount, currency, exp)
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", exp-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q is out of range", ErrInvalidAmount, fields[0])
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// AmountLimit bounds the amount of one payment in a currency, in its minor unit. A zero
+// bound is not enforced.
+type AmountLimit struct {
+	Min int64
+	Max int64
+}
+
+// AmountLimits holds the limit of each currency that has one
+type AmountLimits map[string]AmountLimit
+
+// ParseAmountLimits reads a comma-separated list of currency:min:max entries, with the
+// bounds in the major unit, such as "USD:0.50:10000,JPY:50:1000000". Either bound may
+// be left empty.
+func ParseAmountLimits(s string) (AmountLimits, error) {
+	limits := AmountLimits{}
+	for i, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("limit %d: expected currency:min:max", i+1)
+		}
+		currency := strings.ToUpper(parts[0])
+		if _, exists := limits[currency]; exists {
+			return nil, fmt.Errorf("limit %q: listed twice", currency)
+		}
+
+		var bounds [2]int64
+		for j, bound := range parts[1:] {
+			if bound == "" {
+				continue
+			}
+			m, err := ParseAmount(bound + " " + currency)
+			if err != nil {
+				return nil, fmt.Errorf("limit %q: %w", currency, err)
+			}
+			if m.Amount < 0 {
+				return nil, fmt.Errorf("limit %q: bounds must not be negative", currency)
+			}
+			bounds[j] = m.Amount
+		}
+		limit := AmountLimit{Min: bounds[0], Max: bounds[1]}
+		if limit.Max > 0 && limit.Min > limit.Max {
+			return nil, fmt.Errorf("limit %q: minimum is above the maximum", currency)
+		}
+		limits[currency] = limit
+	}
+	return limits, nil
+}
+
+// Check returns ErrAmountTooSmall or ErrAmountTooLarge if m is outside its currency's
+// limit. Currencies without a limit accept any amount.
+func (l AmountLimits) Check(m Money) error {
+	limit, ok := l[strings.ToUpper(m.Currency)]
+	if !ok {
+		return nil
+	}
+	if limit.Min > 0 && m.Amount < limit.Min {
+		return fmt.Errorf("%w of %s", ErrAmountTooSmall, Money{Amount: limit.Min, Currency: m.Currency})
+	}
+	if limit.Max > 0 && m.Amount > limit.Max {
+		return fmt.Errorf("%w of %s", ErrAmountTooLarge, Money{Amount: limit.Max, Currency: m.Currency})
+	}
+	return nil
+}
+
+// AmountThresholds holds, per currency, the largest amount in its minor unit that goes
+// through without a person looking at it first, such as a refund needing a second
+// approver or an authorization held for review
+type AmountThresholds map[string]int64
+
+// ParseAmountThresholds reads a comma-separated list of currency:amount entries, with
+// the amount in the major unit, such as "USD:1000,JPY:150000"
+func ParseAmountThresholds(s string) (AmountThresholds, error) {
+	thresholds := AmountThresholds{}
+	for i, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		currency, amount, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("threshold %d: expected currency:amount", i+1)
+		}
+		currency = strings.ToUpper(currency)
+		if _, exists := thresholds[currency]; exists {
+			return nil, fmt.Errorf("threshold %q: listed twice", currency)
+		}
+
+		m, err := ParseAmount(amount + " " + currency)
+		if err != nil {
+			return nil, fmt.Errorf("threshold %q: %w", currency, err)
+		}
+		if m.Amount < 0 {
+			return nil, fmt.Errorf("threshold %q: must not be negative", currency)
+		}
+		thresholds[currency] = m.Amount
+	}
+	return thresholds, nil
+}
+
+// Exceeds reports whether m is above its currency's threshold. Amounts in currencies
+// without one never are.
+func (t AmountThresholds) Exceeds(m Money) bool {
+	threshold, ok := t[strings.ToUpper(m.Currency)]
+	return ok && m.Amount > threshold
+}
+
+// Amount returns what the payment is for
+func (p *Payment) Amount() Money {
+	return Money{Amount: p.AmountCents, Currency: p.Currency}
+}
+
+func isDigits(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
+
+func absInt64(n int64) uint64 {
+	if n == math.MinInt64 {
+		return uint64(math.MaxInt64) + 1
+	}
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
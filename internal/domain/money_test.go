@@ -0,0 +1,111 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoney_FormatAmount(t *testing.T) {
+	tests := []struct {
+		money domain.Money
+		want  string
+	}{
+		{domain.Money{Amount: 5000, Currency: "USD"}, "50.00 USD"},
+		{domain.Money{Amount: 5, Currency: "EUR"}, "0.05 EUR"},
+		{domain.Money{Amount: 0, Currency: "GBP"}, "0.00 GBP"},
+		{domain.Money{Amount: -150, Currency: "USD"}, "-1.50 USD"},
+		{domain.Money{Amount: 5000, Currency: "JPY"}, "5000 JPY"},
+		{domain.Money{Amount: 1234, Currency: "KWD"}, "1.234 KWD"},
+		{domain.Money{Amount: 7, Currency: "BHD"}, "0.007 BHD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.money.FormatAmount())
+
+			parsed, err := domain.ParseAmount(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.money, parsed)
+		})
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	t.Run("accepts fewer decimals than the currency has", func(t *testing.T) {
+		m, err := domain.ParseAmount("50.5 usd")
+		require.NoError(t, err)
+		assert.Equal(t, domain.Money{Amount: 5050, Currency: "USD"}, m)
+
+		m, err = domain.ParseAmount("50 USD")
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), m.Amount)
+	})
+
+	t.Run("rejects malformed amounts", func(t *testing.T) {
+		for _, s := range []string{
+			"", "50.00", "USD", "50.00 US", "50.00 U5D", "50.001 USD", "50.5 JPY",
+			"5O.00 USD", ".50 USD", "50. USD", "+50 USD", "1.2.3 USD", "99999999999999999999 USD",
+		} {
+			_, err := domain.ParseAmount(s)
+			assert.ErrorIs(t, err, domain.ErrInvalidAmount, s)
+		}
+	})
+}
+
+func TestAmountLimits(t *testing.T) {
+	limits, err := domain.ParseAmountLimits("USD:0.50:10000, jpy:50:, KWD::1.5")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AmountLimits{
+		"USD": {Min: 50, Max: 1000000},
+		"JPY": {Min: 50},
+		"KWD": {Max: 1500},
+	}, limits)
+
+	t.Run("checks the payment's currency", func(t *testing.T) {
+		assert.NoError(t, limits.Check(domain.Money{Amount: 50, Currency: "USD"}))
+		assert.NoError(t, limits.Check(domain.Money{Amount: 1000000, Currency: "USD"}))
+		assert.NoError(t, limits.Check(domain.Money{Amount: 10_000_000, Currency: "JPY"}))
+		assert.NoError(t, limits.Check(domain.Money{Amount: 1, Currency: "EUR"}), "currencies without a limit accept any amount")
+
+		err := limits.Check(domain.Money{Amount: 49, Currency: "USD"})
+		assert.ErrorIs(t, err, domain.ErrAmountTooSmall)
+		assert.EqualError(t, err, "amount below the minimum of 0.50 USD")
+
+		err = limits.Check(domain.Money{Amount: 1501, Currency: "KWD"})
+		assert.ErrorIs(t, err, domain.ErrAmountTooLarge)
+		assert.EqualError(t, err, "amount above the maximum of 1.500 KWD")
+	})
+
+	t.Run("empty list has no limits", func(t *testing.T) {
+		limits, err := domain.ParseAmountLimits("")
+		require.NoError(t, err)
+		assert.Empty(t, limits)
+	})
+
+	t.Run("rejects invalid lists", func(t *testing.T) {
+		for _, s := range []string{"USD:1", "USD:1:2:3", "USD:1:2,usd:3:4", "USD:0.505:10", "USD:-1:10", "USD:10:5", "JPY:1.5:"} {
+			_, err := domain.ParseAmountLimits(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
+
+func TestAmountThresholds(t *testing.T) {
+	thresholds, err := domain.ParseAmountThresholds("USD:1000, jpy:150000")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AmountThresholds{"USD": 100000, "JPY": 150000}, thresholds)
+
+	assert.False(t, thresholds.Exceeds(domain.Money{Amount: 100000, Currency: "USD"}), "an amount at the threshold is not over it")
+	assert.True(t, thresholds.Exceeds(domain.Money{Amount: 100001, Currency: "usd"}))
+	assert.False(t, thresholds.Exceeds(domain.Money{Amount: 1_000_000_000, Currency: "EUR"}), "currencies without a threshold never exceed it")
+
+	t.Run("rejects invalid lists", func(t *testing.T) {
+		for _, s := range []string{"USD", "USD:1:2", "USD:1,usd:2", "USD:-1", "JPY:1.5"} {
+			_, err := domain.ParseAmountThresholds(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Channels a merchant may have customers notified on, in
+// merchant_settings.customer_notifications
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+type NotificationKind string
+
+const (
+	// NotificationRefundCompleted tells the customer the bank has refunded them
+	NotificationRefundCompleted NotificationKind = "refund_completed"
+	// NotificationPaymentFailed tells the customer their payment did not go through
+	NotificationPaymentFailed NotificationKind = "payment_failed"
+	// NotificationCardUpdateRequired asks the customer to update a saved card that
+	// expired, after a payment charged to it failed for that
+	NotificationCardUpdateRequired NotificationKind = "card_update_required"
+)
+
+// CustomerNotification is a message for a customer about their payment, which the
+// notification service sends on each of Channels
+type CustomerNotification struct {
+	// Key is the same every time one notification is delivered, so the notification
+	// service can drop repeats
+	Key         string
+	Kind        NotificationKind
+	Channels    []string
+	MerchantID  string
+	CustomerID  string
+	PaymentID   string
+	OrderID     string
+	AmountCents int64
+	Currency    string
+	// FailureReason is set on a failed payment that failed for a known reason
+	FailureReason *string
+	// PaymentMethodID is the saved card a card update is asked for
+	PaymentMethodID string
+	// RefundDestination is where a completed refund was sent, so the customer is told
+	// to look for it on their card, their bank account or as store credit
+	RefundDestination RefundDestinationType
+	OccurredAt        time.Time
+}
@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+type OperationType string
+
+const (
+	OperationCapture OperationType = "CAPTURE"
+	OperationVoid    OperationType = "VOID"
+	OperationRefund  OperationType = "REFUND"
+	// OperationReauthorize replaces the expired authorization of a payment with a new one
+	OperationReauthorize OperationType = "REAUTHORIZE"
+)
+
+type OperationStatus string
+
+const (
+	OperationPending   OperationStatus = "PENDING"
+	OperationSucceeded OperationStatus = "SUCCEEDED"
+	OperationFailed    OperationStatus = "FAILED"
+	// OperationPendingApproval is a refund held until a second person approves it;
+	// the bank has not been called
+	OperationPendingApproval OperationStatus = "PENDING_APPROVAL"
+	// OperationRejected is a held refund that was turned down and never reached the bank
+	OperationRejected OperationStatus = "REJECTED"
+)
+
+// OperationReason explains why a void or refund was requested
+type OperationReason string
+
+const (
+	ReasonFraud           OperationReason = "fraud"
+	ReasonCustomerRequest OperationReason = "customer_request"
+	ReasonDuplicate       OperationReason = "duplicate"
+	ReasonOutOfStock      OperationReason = "out_of_stock"
+)
+
+// Validate accepts the known reasons and the empty reason, which means none was given
+func (r OperationReason) Validate() error {
+	switch r {
+	case "", ReasonFraud, ReasonCustomerRequest, ReasonDuplicate, ReasonOutOfStock:
+		return nil
+	}
+	return ErrInvalidReason
+}
+
+// RefundDestinationType says where a refund sends the funds
+type RefundDestinationType string
+
+const (
+	// DestinationCard returns the funds to the card the payment was made with
+	DestinationCard RefundDestinationType = "card"
+	// DestinationBankTransfer sends them to a bank account through the bank's payout
+	// API, for a card that has expired or been closed since
+	DestinationBankTransfer RefundDestinationType = "bank_transfer"
+	// DestinationStoreCredit keeps them with the merchant, which credits the customer
+	// when it receives the refund's event; the bank is not called
+	DestinationStoreCredit RefundDestinationType = "store_credit"
+)
+
+// RefundDestination is where a refund is sent. The zero value is the card.
+type RefundDestination struct {
+	Type          RefundDestinationType
+	AccountNumber string
+	RoutingNumber string
+}
+
+func (d RefundDestination) Validate() error {
+	switch d.Type {
+	case "", DestinationCard, DestinationStoreCredit:
+		if d.AccountNumber != "" || d.RoutingNumber != "" {
+			return ErrInvalidRefundDestination
+		}
+		return nil
+	case DestinationBankTransfer:
+		if !validAccountNumber(d.AccountNumber) || d.RoutingNumber == "" {
+			return ErrInvalidRefundDestination
+		}
+		return nil
+	}
+	return ErrInvalidRefundDestination
+}
+
+// Operation is a single capture, void or refund requested against a payment.
+// The payment carries the resulting state; the operation records the request itself.
+type Operation struct {
+	CreatedAt       time.Time
+	ID              string
+	PaymentID       string
+	Type            OperationType
+	Status          OperationStatus
+	AmountCents     int64
+	IdempotencyKey  string
+	Reason          *OperationReason
+	BankReferenceID *string
+	CompletedAt     *time.Time
+	// RequestedBy is the API key that asked for a refund held for approval, and
+	// ReviewedBy the one that approved or rejected it
+	RequestedBy *string
+	ReviewedBy  *string
+	ReviewedAt  *time.Time
+	// Destination is where a refund sends the funds, and empty for other operations.
+	// A bank transfer carries the account's last four digits and routing number; the
+	// account number itself is kept encrypted outside the entity until it completes.
+	Destination              RefundDestinationType
+	DestinationLast4         *string
+	DestinationRoutingNumber *string
+	// BankInitiated is set on a refund the bank made without being asked, which the
+	// gateway only records
+	BankInitiated bool
+}
+
+func NewOperation(
+	id string,
+	paymentID string,
+	opType OperationType,
+	amount int64,
+	idempotencyKey string,
+) (*Operation, error) {
+	if id == "" {
+		return nil, errors.New("operation ID is required")
+	}
+	if paymentID == "" || idempotencyKey == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if amount < 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	return &Operation{
+		ID:             id,
+		PaymentID:      paymentID,
+		Type:           opType,
+		Status:         OperationPending,
+		AmountCents:    amount,
+		IdempotencyKey: idempotencyKey,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// NewBankRefund records a refund the bank already made on its own, such as a reversal
+// forced by the issuer. Its idempotency key is derived from the bank's refund ID, so
+// the same refund cannot be recorded twice.
+func NewBankRefund(id, paymentID string, refund BankRefund) (*Operation, error) {
+	if err := refund.Validate(); err != nil {
+		return nil, err
+	}
+	op, err := NewOperation(id, paymentID, OperationRefund, refund.AmountCents, refund.IdempotencyKey())
+	if err != nil {
+		return nil, err
+	}
+	op.BankInitiated = true
+	if err := op.Succeed(refund.BankRefundID, refund.RefundedAt); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+// OperationTypeFor returns the operation an intermediate payment status belongs to
+func OperationTypeFor(status PaymentStatus) (OperationType, bool) {
+	//nolint:exhaustive // only intermediate statuses start an operation
+	switch status {
+	case StatusCapturing:
+		return OperationCapture, true
+	case StatusVoiding:
+		return OperationVoid, true
+	case StatusRefunding:
+		return OperationRefund, true
+	case StatusReauthorizing:
+		return OperationReauthorize, true
+	default:
+		return "", false
+	}
+}
+
+// SetReason records why a void or refund was requested. Captures and reauthorizations take no reason.
+func (o *Operation) SetReason(reason OperationReason) error {
+	if reason == "" {
+		o.Reason = nil
+		return nil
+	}
+	if err := reason.Validate(); err != nil {
+		return err
+	}
+	if o.Type == OperationCapture || o.Type == OperationReauthorize {
+		return ErrInvalidReason
+	}
+	o.Reason = &reason
+	return nil
+}
+
+// SetDestination records where a refund sends the funds
+func (o *Operation) SetDestination(destination RefundDestination) error {
+	if o.Type != OperationRefund {
+		return ErrInvalidRefundDestination
+	}
+	if err := destination.Validate(); err != nil {
+		return err
+	}
+
+	o.Destination = destination.Type
+	if o.Destination == "" {
+		o.Destination = DestinationCard
+	}
+	o.DestinationLast4, o.DestinationRoutingNumber = nil, nil
+	if destination.Type == DestinationBankTransfer {
+		last4 := destination.AccountNumber[len(destination.AccountNumber)-4:]
+		o.DestinationLast4 = &last4
+		o.DestinationRoutingNumber = &destination.RoutingNumber
+	}
+	return nil
+}
+
+// HoldForApproval keeps a new refund from reaching the bank until it is approved.
+// requestedBy is the API key that asked for it, empty if it was made without one.
+func (o *Operation) HoldForApproval(requestedBy string) error {
+	if o.Type != OperationRefund || o.Status != OperationPending {
+		return ErrInvalidTransition
+	}
+	o.Status = OperationPendingApproval
+	if requestedBy != "" {
+		o.RequestedBy = &requestedBy
+	}
+	return nil
+}
+
+// Approve releases a held refund to the bank. It must be approved with another API key
+// than the one that requested it.
+func (o *Operation) Approve(reviewedBy string, reviewedAt time.Time) error {
+	if o.Status != OperationPendingApproval {
+		return ErrInvalidTransition
+	}
+	if o.RequestedBy != nil && *o.RequestedBy == reviewedBy {
+		return ErrSelfApproval
+	}
+	o.Status = OperationPending
+	o.ReviewedBy = &reviewedBy
+	o.ReviewedAt = &reviewedAt
+	return nil
+}
+
+// Reject ends a held refund without calling the bank
+func (o *Operation) Reject(reviewedBy string, reviewedAt time.Time) error {
+	if o.Status != OperationPendingApproval {
+		return ErrInvalidTransition
+	}
+	o.Status = OperationRejected
+	o.ReviewedBy = &reviewedBy
+	o.ReviewedAt = &reviewedAt
+	o.CompletedAt = &reviewedAt
+	return nil
+}
+
+func (o *Operation) Succeed(bankReferenceID string, completedAt time.Time) error {
+	if o.Status != OperationPending {
+		return ErrInvalidTransition
+	}
+	o.Status = OperationSucceeded
+	o.BankReferenceID = &bankReferenceID
+	o.CompletedAt = &completedAt
+	return nil
+}
+
+func (o *Operation) Fail(completedAt time.Time) error {
+	if o.Status != OperationPending {
+		return ErrInvalidTransition
+	}
+	o.Status = OperationFailed
+	o.CompletedAt = &completedAt
+	return nil
+}
@@ -0,0 +1,220 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOperation(t *testing.T) {
+	t.Run("creates pending operation", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationCapture, 500, "idem-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperationPending, op.Status)
+		assert.Equal(t, domain.OperationCapture, op.Type)
+		assert.Equal(t, int64(500), op.AmountCents)
+		assert.Nil(t, op.CompletedAt)
+	})
+
+	t.Run("rejects missing idempotency key", func(t *testing.T) {
+		_, err := domain.NewOperation("op-123", "pay-123", domain.OperationCapture, 500, "")
+		assert.ErrorIs(t, err, domain.ErrMissingRequiredField)
+	})
+}
+
+func TestOperation_Lifecycle(t *testing.T) {
+	t.Run("PENDING -> SUCCEEDED records bank reference", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationRefund, 500, "idem-123")
+		require.NoError(t, err)
+
+		require.NoError(t, op.Succeed("ref-123", time.Now()))
+
+		assert.Equal(t, domain.OperationSucceeded, op.Status)
+		assert.Equal(t, "ref-123", *op.BankReferenceID)
+		assert.NotNil(t, op.CompletedAt)
+	})
+
+	t.Run("completed operation cannot change", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationVoid, 500, "idem-123")
+		require.NoError(t, err)
+		require.NoError(t, op.Fail(time.Now()))
+
+		assert.ErrorIs(t, op.Succeed("void-123", time.Now()), domain.ErrInvalidTransition)
+	})
+}
+
+func TestNewBankRefund(t *testing.T) {
+	refundedAt := time.Now().Add(-time.Hour)
+	refund := domain.BankRefund{BankRefundID: "rev-1", BankAuthID: "auth-1", AmountCents: 1500, RefundedAt: refundedAt}
+
+	t.Run("records a completed refund the bank made", func(t *testing.T) {
+		op, err := domain.NewBankRefund("op-123", "pay-123", refund)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.OperationRefund, op.Type)
+		assert.Equal(t, domain.OperationSucceeded, op.Status)
+		assert.True(t, op.BankInitiated)
+		assert.Equal(t, "rev-1", *op.BankReferenceID)
+		assert.Equal(t, refundedAt, *op.CompletedAt)
+		assert.Equal(t, "bank-refund:rev-1", op.IdempotencyKey)
+	})
+
+	t.Run("rejects a refund missing the bank's IDs", func(t *testing.T) {
+		missing := refund
+		missing.BankAuthID = ""
+
+		_, err := domain.NewBankRefund("op-123", "pay-123", missing)
+		assert.ErrorIs(t, err, domain.ErrMissingRequiredField)
+	})
+
+	t.Run("rejects a refund of nothing", func(t *testing.T) {
+		empty := refund
+		empty.AmountCents = 0
+
+		_, err := domain.NewBankRefund("op-123", "pay-123", empty)
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+}
+
+func TestOperation_Approval(t *testing.T) {
+	held := func(t *testing.T) *domain.Operation {
+		t.Helper()
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationRefund, 500_000, "idem-123")
+		require.NoError(t, err)
+		require.NoError(t, op.HoldForApproval("key-requester"))
+		return op
+	}
+
+	t.Run("approved refund goes back to PENDING for the bank", func(t *testing.T) {
+		op := held(t)
+		assert.Equal(t, domain.OperationPendingApproval, op.Status)
+
+		require.NoError(t, op.Approve("key-approver", time.Now()))
+
+		assert.Equal(t, domain.OperationPending, op.Status)
+		assert.Equal(t, "key-requester", *op.RequestedBy)
+		assert.Equal(t, "key-approver", *op.ReviewedBy)
+		assert.Nil(t, op.CompletedAt)
+	})
+
+	t.Run("requester cannot approve their own refund", func(t *testing.T) {
+		op := held(t)
+
+		assert.ErrorIs(t, op.Approve("key-requester", time.Now()), domain.ErrSelfApproval)
+		assert.Equal(t, domain.OperationPendingApproval, op.Status)
+	})
+
+	t.Run("rejected refund is completed", func(t *testing.T) {
+		op := held(t)
+
+		require.NoError(t, op.Reject("key-approver", time.Now()))
+
+		assert.Equal(t, domain.OperationRejected, op.Status)
+		assert.NotNil(t, op.CompletedAt)
+		assert.ErrorIs(t, op.Approve("key-approver", time.Now()), domain.ErrInvalidTransition)
+	})
+
+	t.Run("only refunds are held", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationCapture, 500, "idem-123")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, op.HoldForApproval("key-requester"), domain.ErrInvalidTransition)
+	})
+}
+
+func TestOperationTypeFor(t *testing.T) {
+	opType, ok := domain.OperationTypeFor(domain.StatusCapturing)
+	assert.True(t, ok)
+	assert.Equal(t, domain.OperationCapture, opType)
+
+	opType, ok = domain.OperationTypeFor(domain.StatusReauthorizing)
+	assert.True(t, ok)
+	assert.Equal(t, domain.OperationReauthorize, opType)
+
+	_, ok = domain.OperationTypeFor(domain.StatusAuthorized)
+	assert.False(t, ok)
+}
+
+func TestOperation_SetReason(t *testing.T) {
+	t.Run("records reason on refund", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationRefund, 500, "idem-123")
+		require.NoError(t, err)
+
+		require.NoError(t, op.SetReason(domain.ReasonOutOfStock))
+
+		assert.Equal(t, domain.ReasonOutOfStock, *op.Reason)
+	})
+
+	t.Run("empty reason leaves it unset", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationVoid, 500, "idem-123")
+		require.NoError(t, err)
+
+		require.NoError(t, op.SetReason(""))
+
+		assert.Nil(t, op.Reason)
+	})
+
+	t.Run("rejects unknown reason", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationVoid, 500, "idem-123")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, op.SetReason("changed_mind"), domain.ErrInvalidReason)
+	})
+
+	t.Run("captures take no reason", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationCapture, 500, "idem-123")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, op.SetReason(domain.ReasonFraud), domain.ErrInvalidReason)
+	})
+}
+
+func TestOperation_SetDestination(t *testing.T) {
+	refund := func(t *testing.T) *domain.Operation {
+		t.Helper()
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationRefund, 500, "idem-123")
+		require.NoError(t, err)
+		return op
+	}
+
+	t.Run("defaults to the card", func(t *testing.T) {
+		op := refund(t)
+		require.NoError(t, op.SetDestination(domain.RefundDestination{}))
+		assert.Equal(t, domain.DestinationCard, op.Destination)
+		assert.Nil(t, op.DestinationLast4)
+	})
+
+	t.Run("bank transfer keeps only the last four digits", func(t *testing.T) {
+		op := refund(t)
+		require.NoError(t, op.SetDestination(domain.RefundDestination{
+			Type:          domain.DestinationBankTransfer,
+			AccountNumber: "000123456789",
+			RoutingNumber: "110000000",
+		}))
+		assert.Equal(t, domain.DestinationBankTransfer, op.Destination)
+		assert.Equal(t, "6789", *op.DestinationLast4)
+		assert.Equal(t, "110000000", *op.DestinationRoutingNumber)
+	})
+
+	t.Run("rejects a bank transfer without an account", func(t *testing.T) {
+		op := refund(t)
+		err := op.SetDestination(domain.RefundDestination{Type: domain.DestinationBankTransfer, RoutingNumber: "110000000"})
+		assert.ErrorIs(t, err, domain.ErrInvalidRefundDestination)
+	})
+
+	t.Run("rejects an account for store credit", func(t *testing.T) {
+		op := refund(t)
+		err := op.SetDestination(domain.RefundDestination{Type: domain.DestinationStoreCredit, AccountNumber: "000123456789"})
+		assert.ErrorIs(t, err, domain.ErrInvalidRefundDestination)
+	})
+
+	t.Run("only refunds have a destination", func(t *testing.T) {
+		op, err := domain.NewOperation("op-123", "pay-123", domain.OperationCapture, 500, "idem-123")
+		require.NoError(t, err)
+		assert.ErrorIs(t, op.SetDestination(domain.RefundDestination{}), domain.ErrInvalidRefundDestination)
+	})
+}
@@ -0,0 +1,115 @@
+package domain
+
+import "time"
+
+// Actor is what made a payment change status, so consumers of its events can tell an
+// automatic recovery from something a merchant or operator did
+type Actor string
+
+const (
+	// ActorAPI is a merchant request, including the work it queued for later, such as an
+	// asynchronous authorization or a batch
+	ActorAPI Actor = "api"
+	// ActorAdmin is an operator request under /admin
+	ActorAdmin Actor = "admin"
+	// ActorRetryWorker resumes payments a transient bank failure left mid-transition
+	ActorRetryWorker Actor = "retry_worker"
+	// ActorReconciler compares payments with the bank's records
+	ActorReconciler Actor = "reconciler"
+	// ActorBank is the bank acting on its own, such as a refund an issuer forced that
+	// the gateway records after the fact
+	ActorBank Actor = "bank"
+	// ActorSystem is every other background job, such as expiring authorizations or
+	// charging scheduled payments
+	ActorSystem Actor = "system"
+)
+
+// TransitionSchemaVersion is the version of the payload schema new transition events
+// are written with. Bump it with a new schema in internal/application/hooks/schemas
+// whenever the payload changes.
+const TransitionSchemaVersion = 4
+
+// TransitionEvent records that a payment moved from one status to another. Events are
+// written to the outbox in the same statement as the transition and delivered to hooks
+// at least once after it commits.
+type TransitionEvent struct {
+	ID         string
+	PaymentID  string
+	MerchantID string
+	EventType  string
+	FromStatus *PaymentStatus
+	ToStatus   PaymentStatus
+	// Actor is empty for events recorded before actors were
+	Actor Actor
+	// AttemptCount is how many retries of its bank call the payment had been through
+	// by the transition
+	AttemptCount int
+	// Payload is the payment as JSON right after the transition, in the shape of the
+	// schema with SchemaVersion. Events written before payloads were versioned have
+	// version 0 and hold the whole payment row.
+	Payload       []byte
+	SchemaVersion int
+	OccurredAt    time.Time
+	Attempts      int
+}
+
+// OutboxPause holds back delivery of every merchant's transition events until it is
+// lifted. Events keep being written meanwhile and are delivered in order afterwards.
+type OutboxPause struct {
+	PausedAt time.Time
+	// PausedBy is the API key that paused delivery; empty when it was paused without one
+	PausedBy string
+	Reason   string
+}
+
+// LatestTransition returns the last of a payment's transitions, given oldest first,
+// that occurred at or before at, or nil if it had none by then. Transitions made in
+// one transaction share their time, so those are put back in order by following each
+// one's status on to the next, from the status the payment had before them.
+func LatestTransition(events []*TransitionEvent, at time.Time) *TransitionEvent {
+	var before *PaymentStatus
+	var tied []*TransitionEvent
+	for _, event := range events {
+		if event.OccurredAt.After(at) {
+			continue
+		}
+		if len(tied) > 0 && event.OccurredAt.After(tied[0].OccurredAt) {
+			before = &lastOf(tied, before).ToStatus
+			tied = tied[:0]
+		}
+		tied = append(tied, event)
+	}
+	if len(tied) == 0 {
+		return nil
+	}
+	return lastOf(tied, before)
+}
+
+// lastOf returns the last of transitions made together, following them on from the
+// status the payment had before them, nil for a new payment
+func lastOf(tied []*TransitionEvent, before *PaymentStatus) *TransitionEvent {
+	last := tied[len(tied)-1]
+	used := make([]bool, len(tied))
+	for status := before; ; {
+		next := -1
+		for i, event := range tied {
+			if !used[i] && sameStatus(event.FromStatus, status) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return last
+		}
+		used[next] = true
+		last = tied[next]
+		status = &last.ToStatus
+	}
+}
+
+func sameStatus(a, b *PaymentStatus) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func transition(id string, from *domain.PaymentStatus, to domain.PaymentStatus, at time.Time) *domain.TransitionEvent {
+	return &domain.TransitionEvent{ID: id, FromStatus: from, ToStatus: to, OccurredAt: at}
+}
+
+func TestLatestTransition(t *testing.T) {
+	start := time.Date(2026, 3, 3, 14, 0, 0, 0, time.UTC)
+	pending, authorized := domain.StatusPending, domain.StatusAuthorized
+	captured, refunding := domain.StatusCaptured, domain.StatusRefunding
+
+	events := []*domain.TransitionEvent{
+		transition("created", nil, domain.StatusPending, start),
+		transition("authorized", &pending, domain.StatusAuthorized, start.Add(time.Second)),
+		transition("capturing", &authorized, domain.StatusCapturing, start.Add(time.Hour)),
+	}
+
+	assert.Nil(t, domain.LatestTransition(events, start.Add(-time.Minute)), "nothing before the payment existed")
+	assert.Equal(t, "created", domain.LatestTransition(events, start).ID)
+	assert.Equal(t, "authorized", domain.LatestTransition(events, start.Add(32*time.Minute)).ID)
+	assert.Equal(t, "capturing", domain.LatestTransition(events, start.Add(24*time.Hour)).ID)
+
+	t.Run("picks the end of transitions made together", func(t *testing.T) {
+		at := start.Add(2 * time.Hour)
+		// Listed out of order, as a sort on their shared time may return them
+		history := []*domain.TransitionEvent{
+			transition("created", nil, domain.StatusPending, start),
+			transition("captured", &pending, domain.StatusCaptured, start.Add(time.Second)),
+			transition("refunded", &refunding, domain.StatusCaptured, at),
+			transition("refunding", &captured, domain.StatusRefunding, at),
+		}
+
+		assert.Equal(t, "refunded", domain.LatestTransition(history, at).ID)
+	})
+}
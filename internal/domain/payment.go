@@ -11,6 +11,7 @@ import (
 type PaymentStatus string
 
 const (
+	StatusScheduled  PaymentStatus = "SCHEDULED"
 	StatusPending    PaymentStatus = "PENDING"
 	StatusAuthorized PaymentStatus = "AUTHORIZED"
 	StatusCapturing  PaymentStatus = "CAPTURING"
@@ -21,11 +22,24 @@ const (
 	StatusVoiding    PaymentStatus = "VOIDING"
 	StatusVoided     PaymentStatus = "VOIDED"
 	StatusExpired    PaymentStatus = "EXPIRED"
+	// StatusReauthorizing is an expired payment asking the bank for a fresh authorization
+	StatusReauthorizing PaymentStatus = "REAUTHORIZING"
+	// StatusReview is a risk-flagged payment waiting for a person to approve or decline
+	// it before it is sent to the bank
+	StatusReview PaymentStatus = "REVIEW"
 )
 
+// DefaultAcquirer is the bank every payment goes to unless canary routing picks another
+const DefaultAcquirer = "primary"
+
+// MaxBatchGetPayments caps how many payments one request may fetch by ID
+const MaxBatchGetPayments = 100
+
 type Payment struct {
-	CreatedAt     time.Time
-	ID            string
+	CreatedAt time.Time
+	ID        string
+	// MerchantID is the FicMart business unit the payment was made for
+	MerchantID    string
 	OrderID       string
 	CustomerID    string
 	AmountCents   int64
@@ -42,6 +56,44 @@ type Payment struct {
 	ExpiresAt     *time.Time
 	AttemptCount  int
 	NextRetryAt   *time.Time
+	// FirstCapturedAt is when the first of the payment's captures went through, which
+	// CapturedAt is moved on from by later partial captures
+	FirstCapturedAt *time.Time
+	// CapturedAmountCents is the total of all successful captures. An authorization
+	// can be captured in several parts until it reaches AmountCents.
+	CapturedAmountCents int64
+	// RefundedAmountCents is the total of all successful refunds. A capture can be
+	// refunded in several parts until it reaches CapturedAmountCents.
+	RefundedAmountCents int64
+	// ReleasedAmountCents is the part of the authorization voided after a partial
+	// capture, which the customer's bank frees up and which can no longer be captured
+	ReleasedAmountCents int64
+	// Acquirer is the bank that authorized the payment. Captures, voids and refunds
+	// must be sent to the same one.
+	Acquirer string
+	// FailureReason says why a payment failed before reaching the bank, e.g. card_expired
+	FailureReason *string
+	// PaymentMethodID is the saved card the payment was made with, if any. Only such
+	// payments can be reauthorized after their authorization expires.
+	PaymentMethodID *string
+	// CardLast4 and CardBrand describe the card sent to the bank, for receipts. They
+	// are unset until the authorization is sent.
+	CardLast4 *string
+	CardBrand *string
+	// CardFingerprint identifies the card number the payment was requested with across
+	// payments, without revealing it. It is unset for network token payments and
+	// while the gateway has no fingerprint salt.
+	CardFingerprint *string
+	// LastErrorCategory is how the payment's last failed bank call was classified:
+	// TRANSIENT while it is retried, PERMANENT once it failed for good
+	LastErrorCategory *string
+	// DeclineCategory is what the customer can do about the bank's refusal of the
+	// payment, once the bank has refused it for good
+	DeclineCategory *DeclineCategory
+	// GroupID is the payment group the payment is a part of, if any, and GroupPart its
+	// position in the group from 1
+	GroupID   *string
+	GroupPart *int
 }
 
 func NewPayment(
@@ -69,19 +121,159 @@ func NewPayment(
 		Status:       StatusPending,
 		CreatedAt:    time.Now(),
 		AttemptCount: 0,
+		Acquirer:     DefaultAcquirer,
 	}, nil
 }
 
-func (p *Payment) MarkCapturing() error {
-	return p.transition(StatusCapturing)
+// NewScheduledPaymentEntity creates a payment that waits in SCHEDULED until its
+// authorization is due
+func NewScheduledPaymentEntity(
+	id string,
+	orderID string,
+	customerID string,
+	amount int64, currency string,
+) (*Payment, error) {
+	p, err := NewPayment(id, orderID, customerID, amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	p.Status = StatusScheduled
+	return p, nil
+}
+
+// RecordCard keeps what may be shown of the card the payment is authorized with
+func (p *Payment) RecordCard(cardNumber string) {
+	if len(cardNumber) < 4 {
+		return
+	}
+	last4 := cardNumber[len(cardNumber)-4:]
+	brand := CardBrandOf(cardNumber)
+	p.CardLast4 = &last4
+	p.CardBrand = &brand
+}
+
+// ClaimsOrder reports whether the payment counts as its order's payment where an order
+// may only be paid once. Of a group only the first part does, as the rest pay for the
+// same order alongside it.
+func (p *Payment) ClaimsOrder() bool {
+	return p.GroupPart == nil || *p.GroupPart == 1
+}
+
+// HoldForReview keeps a new payment from the bank until it is reviewed
+func (p *Payment) HoldForReview() error {
+	return p.transition(StatusReview)
 }
 
+// MarkPending starts the authorization of a scheduled or reviewed payment
+func (p *Payment) MarkPending() error {
+	return p.transition(StatusPending)
+}
+
+// FailWithReason fails the payment for a reason found locally, without a bank call
+func (p *Payment) FailWithReason(reason string) error {
+	if err := p.transition(StatusFailed); err != nil {
+		return err
+	}
+	p.FailureReason = &reason
+	return nil
+}
+
+// MarkCapturing starts a capture of amount, which must fit in the remaining authorized amount
+func (p *Payment) MarkCapturing(amount int64) error {
+	if err := p.canTransitionTo(StatusCapturing); err != nil {
+		return err
+	}
+	if err := p.checkCaptureAmount(amount); err != nil {
+		return err
+	}
+	p.Status = StatusCapturing
+	return nil
+}
+
+// MarkVoiding starts a void of the whole authorization. Once part of it is captured,
+// only what is left can be voided, with MarkReleasing.
 func (p *Payment) MarkVoiding() error {
+	if p.CapturedAmountCents > 0 {
+		return ErrInvalidTransition
+	}
 	return p.transition(StatusVoiding)
 }
 
-func (p *Payment) MarkRefunding() error {
-	return p.transition(StatusRefunding)
+// MarkReleasing starts a void of amount of what a partially captured payment has left
+// to capture
+func (p *Payment) MarkReleasing(amount int64) error {
+	if p.Status != StatusCaptured {
+		return ErrInvalidTransition
+	}
+	if err := p.canTransitionTo(StatusVoiding); err != nil {
+		return err
+	}
+	if err := p.checkCaptureAmount(amount); err != nil {
+		return err
+	}
+	p.Status = StatusVoiding
+	return nil
+}
+
+// MarkRefunding starts a refund of amount, which must fit in the captured amount not yet refunded
+func (p *Payment) MarkRefunding(amount int64) error {
+	if err := p.CheckRefund(amount); err != nil {
+		return err
+	}
+	p.Status = StatusRefunding
+	return nil
+}
+
+// CheckRefund returns the error MarkRefunding would, without starting the refund
+func (p *Payment) CheckRefund(amount int64) error {
+	if err := p.canTransitionTo(StatusRefunding); err != nil {
+		return err
+	}
+	return p.checkRefundAmount(amount)
+}
+
+// FailRefund ends a refund the bank rejected. The captured funds are untouched,
+// so the payment goes back to CAPTURED and can be refunded again.
+func (p *Payment) FailRefund() error {
+	if p.Status != StatusRefunding {
+		return ErrInvalidTransition
+	}
+	return p.transition(StatusCaptured)
+}
+
+// FailRelease ends a void of the uncaptured remainder the bank rejected. What was
+// captured is untouched, so the payment goes back to CAPTURED.
+func (p *Payment) FailRelease() error {
+	if p.Status != StatusVoiding || p.CapturedAmountCents == 0 {
+		return ErrInvalidTransition
+	}
+	return p.transition(StatusCaptured)
+}
+
+// RollBack returns a payment to the status it left for an operation the bank never
+// received: CAPTURED for a further partial capture or a void of what is left after
+// one, and AUTHORIZED for a first capture or a void. A refund or reauthorization is
+// undone as if the bank had rejected it.
+func (p *Payment) RollBack() error {
+	//nolint:exhaustive // only intermediate states can be rolled back
+	switch p.Status {
+	case StatusCapturing:
+		if p.CapturedAmountCents > 0 {
+			return p.transition(StatusCaptured)
+		}
+		return p.transition(StatusAuthorized)
+	case StatusVoiding:
+		if p.CapturedAmountCents > 0 {
+			return p.FailRelease()
+		}
+		return p.transition(StatusAuthorized)
+	case StatusRefunding:
+		return p.FailRefund()
+	case StatusReauthorizing:
+		return p.FailReauthorization()
+	default:
+		return ErrInvalidTransition
+	}
 }
 
 func (p *Payment) Fail() error {
@@ -92,6 +284,20 @@ func (p *Payment) MarkExpired() error {
 	return p.transition(StatusExpired)
 }
 
+// MarkReauthorizing starts a fresh authorization of an expired payment
+func (p *Payment) MarkReauthorizing() error {
+	return p.transition(StatusReauthorizing)
+}
+
+// FailReauthorization ends a reauthorization the bank declined. The old authorization
+// is still gone, so the payment goes back to EXPIRED and can be reauthorized again.
+func (p *Payment) FailReauthorization() error {
+	if p.Status != StatusReauthorizing {
+		return ErrInvalidTransition
+	}
+	return p.transition(StatusExpired)
+}
+
 func (p *Payment) transition(target PaymentStatus) error {
 	if err := p.canTransitionTo(target); err != nil {
 		return err
@@ -102,19 +308,33 @@ func (p *Payment) transition(target PaymentStatus) error {
 
 func (p *Payment) canTransitionTo(target PaymentStatus) error {
 	switch p.Status {
+	case StatusScheduled:
+		return p.allow(target, StatusPending, StatusFailed)
 	case StatusPending:
-		return p.allow(target, StatusAuthorized, StatusFailed)
+		return p.allow(target, StatusAuthorized, StatusReview, StatusFailed)
+	case StatusReview:
+		return p.allow(target, StatusPending, StatusFailed)
 	case StatusAuthorized:
 		return p.allow(target, StatusCapturing, StatusVoiding, StatusExpired, StatusFailed)
 	case StatusCapturing:
-		return p.allow(target, StatusCaptured, StatusFailed)
+		return p.allow(target, StatusCaptured, StatusAuthorized, StatusFailed)
 	case StatusCaptured:
+		if p.RemainingCaptureAmount() > 0 {
+			return p.allow(target, StatusCapturing, StatusVoiding, StatusRefunding, StatusFailed)
+		}
 		return p.allow(target, StatusRefunding, StatusFailed)
 	case StatusRefunding:
-		return p.allow(target, StatusRefunded, StatusFailed)
+		return p.allow(target, StatusRefunded, StatusCaptured, StatusFailed)
 	case StatusVoiding:
-		return p.allow(target, StatusVoided, StatusFailed)
-	case StatusFailed, StatusRefunded, StatusVoided, StatusExpired:
+		if p.CapturedAmountCents > 0 {
+			return p.allow(target, StatusCaptured)
+		}
+		return p.allow(target, StatusVoided, StatusAuthorized, StatusFailed)
+	case StatusExpired:
+		return p.allow(target, StatusReauthorizing)
+	case StatusReauthorizing:
+		return p.allow(target, StatusAuthorized, StatusExpired)
+	case StatusFailed, StatusRefunded, StatusVoided:
 		return ErrInvalidTransition
 	}
 	return ErrInvalidTransition
@@ -137,16 +357,48 @@ func (p *Payment) Authorize(bankAuthID string, authorizedAt, expiresAt time.Time
 	return nil
 }
 
-func (p *Payment) Capture(status, bankCaptureID string, capturedAt time.Time) error {
+// Capture records a successful capture of amount. BankCaptureID and CapturedAt
+// always refer to the most recent capture.
+func (p *Payment) Capture(status, bankCaptureID string, amount int64, capturedAt time.Time) error {
 	if strings.EqualFold(status, "authorization_expired") {
 		return ErrPaymentExpired
 	}
 
+	if err := p.checkCaptureAmount(amount); err != nil {
+		return err
+	}
 	if err := p.transition(StatusCaptured); err != nil {
 		return err
 	}
+	p.CapturedAmountCents += amount
 	p.BankCaptureID = &bankCaptureID
 	p.CapturedAt = &capturedAt
+	if p.FirstCapturedAt == nil {
+		p.FirstCapturedAt = &capturedAt
+	}
+	return nil
+}
+
+// TimeToCapture returns how long the payment stayed authorized before it was first
+// captured, or nil if it has not been captured
+func (p *Payment) TimeToCapture() *time.Duration {
+	if p.AuthorizedAt == nil || p.FirstCapturedAt == nil {
+		return nil
+	}
+	d := p.FirstCapturedAt.Sub(*p.AuthorizedAt)
+	return &d
+}
+
+// RemainingCaptureAmount returns how much of the authorization has been neither
+// captured nor released yet
+func (p *Payment) RemainingCaptureAmount() int64 {
+	return p.AmountCents - p.CapturedAmountCents - p.ReleasedAmountCents
+}
+
+func (p *Payment) checkCaptureAmount(amount int64) error {
+	if amount <= 0 || amount > p.RemainingCaptureAmount() {
+		return ErrInvalidAmount
+	}
 	return nil
 }
 
@@ -162,27 +414,102 @@ func (p *Payment) Void(status, bankVoidID string, voidedAt time.Time) error {
 	return nil
 }
 
-func (p *Payment) Refund(bankRefundID string, refundedAt time.Time) error {
-	if err := p.transition(StatusRefunded); err != nil {
+// Release records a successful void of amount of what a partially captured payment
+// had left to capture. The payment goes back to CAPTURED. BankVoidID and VoidedAt
+// refer to the void.
+func (p *Payment) Release(status, bankVoidID string, amount int64, voidedAt time.Time) error {
+	if strings.EqualFold(status, "authorization_expired") {
+		return ErrPaymentExpired
+	}
+	if p.Status != StatusVoiding {
+		return ErrInvalidTransition
+	}
+	if err := p.checkCaptureAmount(amount); err != nil {
 		return err
 	}
+	if err := p.transition(StatusCaptured); err != nil {
+		return err
+	}
+	p.ReleasedAmountCents += amount
+	p.BankVoidID = &bankVoidID
+	p.VoidedAt = &voidedAt
+	return nil
+}
+
+// Refund records a successful refund of amount. The payment only becomes REFUNDED once
+// everything captured has been refunded; a partial refund leaves it CAPTURED.
+// BankRefundID and RefundedAt always refer to the most recent refund.
+func (p *Payment) Refund(bankRefundID string, amount int64, refundedAt time.Time) error {
+	if p.Status != StatusRefunding {
+		return ErrInvalidTransition
+	}
+	if err := p.checkRefundAmount(amount); err != nil {
+		return err
+	}
+
+	target := StatusRefunded
+	if p.RefundedAmountCents+amount < p.CapturedAmountCents {
+		target = StatusCaptured
+	}
+	if err := p.transition(target); err != nil {
+		return err
+	}
+	p.RefundedAmountCents += amount
 	p.BankRefundID = &bankRefundID
 	p.RefundedAt = &refundedAt
 	return nil
 }
 
+// NetAmount returns what the customer has paid so far, refunds taken off. It is read
+// from the running totals each capture and refund keeps, not summed from operations.
+func (p *Payment) NetAmount() int64 {
+	return p.CapturedAmountCents - p.RefundedAmountCents
+}
+
+// RefundableAmount returns how much of the captured amount has not been refunded yet
+func (p *Payment) RefundableAmount() int64 {
+	return p.NetAmount()
+}
+
+func (p *Payment) checkRefundAmount(amount int64) error {
+	if amount <= 0 || amount > p.RefundableAmount() {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
 func (p *Payment) IsTerminal() bool {
 	switch p.Status {
 	case StatusVoided, StatusRefunded, StatusExpired, StatusFailed:
 		return true
-	case StatusPending, StatusAuthorized, StatusCapturing, StatusCaptured, StatusRefunding, StatusVoiding:
+	case StatusScheduled, StatusPending, StatusAuthorized, StatusCapturing, StatusCaptured, StatusRefunding, StatusVoiding,
+		StatusReauthorizing, StatusReview:
 		return false
 	}
 	return false
 }
 
-func (p *Payment) ScheduleRetry(backoff time.Duration) {
+// RecordError notes how the payment's latest failed bank call was classified
+func (p *Payment) RecordError(category string) {
+	p.LastErrorCategory = &category
+}
+
+// RecordDecline notes the category of the code the bank refused the payment with
+func (p *Payment) RecordDecline(code string) {
+	category := ClassifyDecline(code)
+	p.DeclineCategory = &category
+}
+
+// ScheduleRetry counts a failed attempt and books the next backoff after now
+func (p *Payment) ScheduleRetry(backoff time.Duration, now time.Time) {
 	p.AttemptCount++
-	next := time.Now().Add(backoff)
+	next := now.Add(backoff)
 	p.NextRetryAt = &next
 }
+
+// ResetRetries gives a payment the retry worker gave up on a fresh set of attempts,
+// starting at once
+func (p *Payment) ResetRetries() {
+	p.AttemptCount = 0
+	p.NextRetryAt = nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// paymentStatuses lists every status a payment can have
+var paymentStatuses = []PaymentStatus{
+	StatusScheduled, StatusPending, StatusAuthorized, StatusCapturing, StatusCaptured,
+	StatusFailed, StatusRefunded, StatusRefunding, StatusVoiding, StatusVoided,
+	StatusExpired, StatusReauthorizing, StatusReview,
+}
+
+// CustomerPaymentFilter narrows a customer's payments to the given statuses and to those
+// created in [From, To). Empty fields are not filtered on.
+type CustomerPaymentFilter struct {
+	Statuses []PaymentStatus
+	From     time.Time
+	To       time.Time
+}
+
+// NewCustomerPaymentFilter builds a filter from a comma-separated status list such as
+// "CAPTURED,REFUNDED" and a creation time range, either end of which may be zero
+func NewCustomerPaymentFilter(statuses string, from, to time.Time) (CustomerPaymentFilter, error) {
+	filter := CustomerPaymentFilter{From: from, To: to}
+	for _, s := range strings.Split(statuses, ",") {
+		status := PaymentStatus(strings.ToUpper(strings.TrimSpace(s)))
+		if status == "" {
+			continue
+		}
+		if !slices.Contains(paymentStatuses, status) {
+			return CustomerPaymentFilter{}, fmt.Errorf("%w: unknown status %q", ErrInvalidPaymentFilter, s)
+		}
+		if !slices.Contains(filter.Statuses, status) {
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return CustomerPaymentFilter{}, fmt.Errorf("%w: from must be before to", ErrInvalidPaymentFilter)
+	}
+	return filter, nil
+}
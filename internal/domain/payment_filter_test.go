@@ -0,0 +1,33 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomerPaymentFilter(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	filter, err := domain.NewCustomerPaymentFilter(" captured, REFUNDED,,CAPTURED", from, to)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CustomerPaymentFilter{
+		Statuses: []domain.PaymentStatus{domain.StatusCaptured, domain.StatusRefunded},
+		From:     from,
+		To:       to,
+	}, filter)
+
+	filter, err = domain.NewCustomerPaymentFilter("", time.Time{}, to)
+	require.NoError(t, err)
+	assert.Empty(t, filter.Statuses)
+
+	_, err = domain.NewCustomerPaymentFilter("CAPTURED,SETTLED", time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, domain.ErrInvalidPaymentFilter)
+
+	_, err = domain.NewCustomerPaymentFilter("", to, from)
+	assert.ErrorIs(t, err, domain.ErrInvalidPaymentFilter)
+}
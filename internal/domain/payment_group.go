@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// PaymentGroupParts is how many authorizations a group splits its order into, such as
+// a gift card and a credit card for the remainder
+const PaymentGroupParts = 2
+
+// groupStatusOrder ranks statuses from the least settled. A group takes the status of
+// its least settled part, so it is only CAPTURED once every part is, and FAILED as soon
+// as one part is.
+var groupStatusOrder = []PaymentStatus{
+	StatusFailed,
+	StatusScheduled,
+	StatusReview,
+	StatusPending,
+	StatusReauthorizing,
+	StatusCapturing,
+	StatusVoiding,
+	StatusRefunding,
+	StatusExpired,
+	StatusAuthorized,
+	StatusCaptured,
+	StatusVoided,
+	StatusRefunded,
+}
+
+// PaymentGroup pays one order with several authorizations. Captures and voids apply to
+// all of its parts.
+type PaymentGroup struct {
+	CreatedAt   time.Time
+	ID          string
+	MerchantID  string
+	OrderID     string
+	CustomerID  string
+	AmountCents int64
+	Currency    string
+	// Parts are the group's payments in order; they are created one by one, so a
+	// group may have fewer than PaymentGroupParts while it is being authorized
+	Parts []*Payment
+}
+
+func NewPaymentGroup(id, orderID, customerID, currency string, amounts []int64, now time.Time) (*PaymentGroup, error) {
+	if id == "" || orderID == "" || customerID == "" || currency == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if len(amounts) != PaymentGroupParts {
+		return nil, ErrInvalidPaymentGroup
+	}
+
+	var total int64
+	for _, amount := range amounts {
+		if amount <= 0 {
+			return nil, ErrInvalidPaymentGroup
+		}
+		total += amount
+	}
+
+	return &PaymentGroup{
+		CreatedAt:   now,
+		ID:          id,
+		OrderID:     orderID,
+		CustomerID:  customerID,
+		AmountCents: total,
+		Currency:    currency,
+	}, nil
+}
+
+// Status is the status of the group's least settled part. A group whose parts are not
+// all created yet is at most PENDING.
+func (g *PaymentGroup) Status() PaymentStatus {
+	for _, status := range groupStatusOrder {
+		if status == StatusPending && len(g.Parts) < PaymentGroupParts {
+			return StatusPending
+		}
+		for _, part := range g.Parts {
+			if part.Status == status {
+				return status
+			}
+		}
+	}
+	return StatusPending
+}
+
+// CapturedAmountCents is the total captured across the group's parts
+func (g *PaymentGroup) CapturedAmountCents() int64 {
+	var total int64
+	for _, part := range g.Parts {
+		total += part.CapturedAmountCents
+	}
+	return total
+}
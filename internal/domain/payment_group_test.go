@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPaymentGroup(t *testing.T) {
+	t.Run("totals its parts", func(t *testing.T) {
+		group, err := domain.NewPaymentGroup("grp-123", "order-456", "cust-789", "USD", []int64{2000, 3000}, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5000), group.AmountCents)
+	})
+
+	t.Run("rejects a single part", func(t *testing.T) {
+		_, err := domain.NewPaymentGroup("grp-123", "order-456", "cust-789", "USD", []int64{5000}, time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvalidPaymentGroup)
+	})
+
+	t.Run("rejects a zero part", func(t *testing.T) {
+		_, err := domain.NewPaymentGroup("grp-123", "order-456", "cust-789", "USD", []int64{5000, 0}, time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvalidPaymentGroup)
+	})
+}
+
+func TestPaymentGroup_Status(t *testing.T) {
+	group := func(statuses ...domain.PaymentStatus) *domain.PaymentGroup {
+		g := &domain.PaymentGroup{}
+		for _, status := range statuses {
+			g.Parts = append(g.Parts, &domain.Payment{Status: status})
+		}
+		return g
+	}
+
+	tests := []struct {
+		name     string
+		group    *domain.PaymentGroup
+		expected domain.PaymentStatus
+	}{
+		{"pending until every part exists", group(domain.StatusAuthorized), domain.StatusPending},
+		{"authorized once every part is", group(domain.StatusAuthorized, domain.StatusAuthorized), domain.StatusAuthorized},
+		{"capturing while a part is", group(domain.StatusCaptured, domain.StatusCapturing), domain.StatusCapturing},
+		{"authorized while a part is not captured", group(domain.StatusCaptured, domain.StatusAuthorized), domain.StatusAuthorized},
+		{"captured once every part is", group(domain.StatusCaptured, domain.StatusCaptured), domain.StatusCaptured},
+		{"failed when a part failed", group(domain.StatusVoided, domain.StatusFailed), domain.StatusFailed},
+		{"failed when the first part failed", group(domain.StatusFailed), domain.StatusFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.group.Status())
+		})
+	}
+}
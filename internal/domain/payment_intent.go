@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type PaymentIntentStatus string
+
+const (
+	IntentRequiresConfirmation PaymentIntentStatus = "REQUIRES_CONFIRMATION"
+	IntentConfirmed            PaymentIntentStatus = "CONFIRMED"
+)
+
+// PaymentIntentTTL is how long a storefront has to confirm an intent
+const PaymentIntentTTL = 24 * time.Hour
+
+// PaymentIntent is a payment the merchant has priced but not yet charged. The
+// storefront confirms it with the card, proving it may by the intent's client token,
+// so the merchant's servers never handle card details.
+type PaymentIntent struct {
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+	ID          string
+	MerchantID  string
+	OrderID     string
+	CustomerID  string
+	AmountCents int64
+	Currency    string
+	Status      PaymentIntentStatus
+	// ClientTokenHash is how the client token is stored. The token itself is only
+	// known in ClientToken while the intent is being created.
+	ClientTokenHash string
+	ClientToken     string
+	// Attempts counts confirmations the bank declined
+	Attempts    int
+	PaymentID   *string
+	ConfirmedAt *time.Time
+}
+
+func NewPaymentIntent(id, orderID, customerID string, amount int64, currency, clientTokenHash string, now time.Time) (*PaymentIntent, error) {
+	if id == "" || orderID == "" || customerID == "" || currency == "" || clientTokenHash == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	return &PaymentIntent{
+		CreatedAt:       now,
+		ExpiresAt:       now.Add(PaymentIntentTTL),
+		ID:              id,
+		OrderID:         orderID,
+		CustomerID:      customerID,
+		AmountCents:     amount,
+		Currency:        currency,
+		Status:          IntentRequiresConfirmation,
+		ClientTokenHash: clientTokenHash,
+	}, nil
+}
+
+// CanConfirm reports why the intent may not be confirmed at now, if it may not
+func (i *PaymentIntent) CanConfirm(now time.Time) error {
+	if i.Status != IntentRequiresConfirmation {
+		return ErrInvalidTransition
+	}
+	if !now.Before(i.ExpiresAt) {
+		return ErrPaymentIntentExpired
+	}
+	return nil
+}
+
+// IdempotencyKey is the key the intent's current confirmation is authorized under.
+// Confirmations repeated while one is in flight share it; each declined one moves on
+// to a fresh key, so the customer can try another card.
+func (i *PaymentIntent) IdempotencyKey() string {
+	return fmt.Sprintf("intent-%s-%d", i.ID, i.Attempts)
+}
+
+// Confirm records the payment the intent was confirmed with
+func (i *PaymentIntent) Confirm(paymentID string, now time.Time) error {
+	if err := i.CanConfirm(now); err != nil {
+		return err
+	}
+	i.Status = IntentConfirmed
+	i.PaymentID = &paymentID
+	i.ConfirmedAt = &now
+	return nil
+}
@@ -0,0 +1,75 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPaymentIntent(t *testing.T, now time.Time) *domain.PaymentIntent {
+	t.Helper()
+	intent, err := domain.NewPaymentIntent("pi-123", "order-456", "cust-789", 5000, "USD", "token-hash", now)
+	require.NoError(t, err)
+	return intent
+}
+
+func TestNewPaymentIntent(t *testing.T) {
+	t.Run("requires confirmation until it expires", func(t *testing.T) {
+		now := time.Now()
+		intent := createTestPaymentIntent(t, now)
+
+		assert.Equal(t, domain.IntentRequiresConfirmation, intent.Status)
+		assert.Equal(t, now.Add(domain.PaymentIntentTTL), intent.ExpiresAt)
+	})
+
+	t.Run("rejects a zero amount", func(t *testing.T) {
+		_, err := domain.NewPaymentIntent("pi-123", "order-456", "cust-789", 0, "USD", "token-hash", time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+
+	t.Run("rejects a missing order", func(t *testing.T) {
+		_, err := domain.NewPaymentIntent("pi-123", "", "cust-789", 5000, "USD", "token-hash", time.Now())
+		assert.ErrorIs(t, err, domain.ErrMissingRequiredField)
+	})
+}
+
+func TestPaymentIntent_Confirm(t *testing.T) {
+	t.Run("records the payment", func(t *testing.T) {
+		now := time.Now()
+		intent := createTestPaymentIntent(t, now)
+
+		require.NoError(t, intent.Confirm("pay-1", now.Add(time.Minute)))
+
+		assert.Equal(t, domain.IntentConfirmed, intent.Status)
+		require.NotNil(t, intent.PaymentID)
+		assert.Equal(t, "pay-1", *intent.PaymentID)
+	})
+
+	t.Run("rejects a second confirmation", func(t *testing.T) {
+		now := time.Now()
+		intent := createTestPaymentIntent(t, now)
+		require.NoError(t, intent.Confirm("pay-1", now))
+
+		assert.ErrorIs(t, intent.Confirm("pay-2", now), domain.ErrInvalidTransition)
+	})
+
+	t.Run("rejects an expired intent", func(t *testing.T) {
+		now := time.Now()
+		intent := createTestPaymentIntent(t, now)
+
+		err := intent.Confirm("pay-1", now.Add(domain.PaymentIntentTTL))
+		assert.ErrorIs(t, err, domain.ErrPaymentIntentExpired)
+	})
+}
+
+func TestPaymentIntent_IdempotencyKey(t *testing.T) {
+	intent := createTestPaymentIntent(t, time.Now())
+	first := intent.IdempotencyKey()
+
+	intent.Attempts++
+
+	assert.NotEqual(t, first, intent.IdempotencyKey())
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+	"time"
+	"unicode"
+)
+
+// FailureReasonCardExpired fails a payment charged to a saved card that expired before
+// the payment was authorized, without asking the bank
+const FailureReasonCardExpired = "card_expired"
+
+// PaymentMethod is a card saved for merchant-initiated payments. The card number is
+// held encrypted by the vault; the entity only carries what is safe to show.
+type PaymentMethod struct {
+	ID          string
+	CustomerID  string
+	Last4       string
+	ExpiryMonth int
+	ExpiryYear  int
+	CreatedAt   time.Time
+}
+
+func NewPaymentMethod(id, customerID, cardNumber string, expiryMonth, expiryYear int, now time.Time) (*PaymentMethod, error) {
+	if id == "" || customerID == "" || cardNumber == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if !validCardNumber(cardNumber) || expiryMonth < 1 || expiryMonth > 12 || expiryYear < 2000 {
+		return nil, ErrInvalidPaymentMethod
+	}
+
+	pm := &PaymentMethod{
+		ID:          id,
+		CustomerID:  customerID,
+		Last4:       cardNumber[len(cardNumber)-4:],
+		ExpiryMonth: expiryMonth,
+		ExpiryYear:  expiryYear,
+		CreatedAt:   now,
+	}
+	if pm.IsExpired(now) {
+		return nil, ErrPaymentMethodExpired
+	}
+	return pm, nil
+}
+
+// IsExpired reports whether the card's expiry month has ended by now
+func (pm *PaymentMethod) IsExpired(now time.Time) bool {
+	return CardExpired(pm.ExpiryMonth, pm.ExpiryYear, now)
+}
+
+// CardExpired reports whether a card expiring in the given month has expired by now
+func CardExpired(expiryMonth, expiryYear int, now time.Time) bool {
+	firstOfNextMonth := time.Date(expiryYear, time.Month(expiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.Before(firstOfNextMonth)
+}
+
+func validCardNumber(number string) bool {
+	if len(number) < 12 || len(number) > 19 {
+		return false
+	}
+	for _, r := range number {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// NetworkToken is a card number issued by the card network in place of the customer's
+// (a DPAN), as wallets hand them out, with the cryptogram that authenticates a single
+// payment made with it. The cryptogram takes the place of the CVV.
+type NetworkToken struct {
+	Number     string
+	Cryptogram string
+	// ECI is the electronic commerce indicator the wallet returned with the cryptogram,
+	// if any
+	ECI string
+}
+
+// IsZero reports whether no network token was given
+func (t NetworkToken) IsZero() bool {
+	return t == NetworkToken{}
+}
+
+func (t NetworkToken) Validate() error {
+	if !validCardNumber(t.Number) {
+		return ErrInvalidNetworkToken
+	}
+	if cryptogram, err := base64.StdEncoding.DecodeString(t.Cryptogram); err != nil || len(cryptogram) != 20 {
+		return ErrInvalidNetworkToken
+	}
+	if t.ECI != "" && (len(t.ECI) != 2 || !unicode.IsDigit(rune(t.ECI[0])) || !unicode.IsDigit(rune(t.ECI[1]))) {
+		return ErrInvalidNetworkToken
+	}
+	return nil
+}
+
+// Card brands, as told by the leading digits of a card number
+const (
+	CardBrandVisa       = "visa"
+	CardBrandMastercard = "mastercard"
+	CardBrandAmex       = "amex"
+	CardBrandDiscover   = "discover"
+	CardBrandJCB        = "jcb"
+	CardBrandUnknown    = "unknown"
+)
+
+// CardBrandOf returns the brand that issues cardNumber, from its first digits
+func CardBrandOf(cardNumber string) string {
+	prefix := func(n int) int {
+		if len(cardNumber) < n {
+			return -1
+		}
+		v, err := strconv.Atoi(cardNumber[:n])
+		if err != nil {
+			return -1
+		}
+		return v
+	}
+
+	switch {
+	case prefix(1) == 4:
+		return CardBrandVisa
+	case prefix(2) >= 51 && prefix(2) <= 55, prefix(4) >= 2221 && prefix(4) <= 2720:
+		return CardBrandMastercard
+	case prefix(2) == 34, prefix(2) == 37:
+		return CardBrandAmex
+	case prefix(4) == 6011, prefix(2) == 65, prefix(3) >= 644 && prefix(3) <= 649:
+		return CardBrandDiscover
+	case prefix(4) >= 3528 && prefix(4) <= 3589:
+		return CardBrandJCB
+	default:
+		return CardBrandUnknown
+	}
+}
@@ -55,7 +55,7 @@ func TestPayment_StateTransitions(t *testing.T) {
 	t.Run("AUTHORIZED -> CAPTURING transition", func(t *testing.T) {
 		payment := createAuthorizedPayment(t)
 
-		err := payment.MarkCapturing()
+		err := payment.MarkCapturing(payment.AmountCents)
 
 		require.NoError(t, err)
 		assert.Equal(t, domain.StatusCapturing, payment.Status)
@@ -64,7 +64,7 @@ func TestPayment_StateTransitions(t *testing.T) {
 	t.Run("CAPTURING -> CAPTURED transition", func(t *testing.T) {
 		payment := createCapturingPayment(t)
 
-		err := payment.Capture("captured", "cap-123", time.Now())
+		err := payment.Capture("captured", "cap-123", payment.AmountCents, time.Now())
 
 		require.NoError(t, err)
 		assert.Equal(t, domain.StatusCaptured, payment.Status)
@@ -95,7 +95,7 @@ func TestPayment_StateTransitions(t *testing.T) {
 	t.Run("CAPTURED -> REFUNDING transition", func(t *testing.T) {
 		payment := createCapturedPayment(t)
 
-		err := payment.MarkRefunding()
+		err := payment.MarkRefunding(payment.RefundableAmount())
 
 		require.NoError(t, err)
 		assert.Equal(t, domain.StatusRefunding, payment.Status)
@@ -104,7 +104,7 @@ func TestPayment_StateTransitions(t *testing.T) {
 	t.Run("REFUNDING -> REFUNDED transition", func(t *testing.T) {
 		payment := createRefundingPayment(t)
 
-		err := payment.Refund("ref-123", time.Now())
+		err := payment.Refund("ref-123", payment.RefundableAmount(), time.Now())
 
 		require.NoError(t, err)
 		assert.Equal(t, domain.StatusRefunded, payment.Status)
@@ -117,7 +117,7 @@ func TestPayment_InvalidStateTransitions(t *testing.T) {
 	t.Run("cannot capture from PENDING", func(t *testing.T) {
 		payment := createTestPayment(t)
 
-		err := payment.MarkCapturing()
+		err := payment.MarkCapturing(payment.AmountCents)
 
 		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
 	})
@@ -133,7 +133,7 @@ func TestPayment_InvalidStateTransitions(t *testing.T) {
 	t.Run("cannot capture from VOIDED", func(t *testing.T) {
 		payment := createVoidedPayment(t)
 
-		err := payment.MarkCapturing()
+		err := payment.MarkCapturing(payment.AmountCents)
 
 		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
 	})
@@ -141,12 +141,246 @@ func TestPayment_InvalidStateTransitions(t *testing.T) {
 	t.Run("cannot refund from AUTHORIZED", func(t *testing.T) {
 		payment := createAuthorizedPayment(t)
 
-		err := payment.MarkRefunding()
+		err := payment.MarkRefunding(payment.RefundableAmount())
 
 		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
 	})
 }
 
+func TestPayment_PartialCaptures(t *testing.T) {
+	t.Run("captures an authorization in several parts", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+
+		require.NoError(t, payment.MarkCapturing(200))
+		require.NoError(t, payment.Capture("captured", "cap-1", 200, time.Now()))
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Equal(t, int64(200), payment.CapturedAmountCents)
+		assert.Equal(t, int64(300), payment.RemainingCaptureAmount())
+
+		require.NoError(t, payment.MarkCapturing(300))
+		require.NoError(t, payment.Capture("captured", "cap-2", 300, time.Now()))
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Equal(t, int64(500), payment.CapturedAmountCents)
+		assert.Equal(t, "cap-2", *payment.BankCaptureID)
+	})
+
+	t.Run("times the capture from the first part", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+		assert.Nil(t, payment.TimeToCapture())
+
+		first := payment.AuthorizedAt.Add(2 * time.Hour)
+		require.NoError(t, payment.MarkCapturing(200))
+		require.NoError(t, payment.Capture("captured", "cap-1", 200, first))
+		require.NoError(t, payment.MarkCapturing(300))
+		require.NoError(t, payment.Capture("captured", "cap-2", 300, first.Add(24*time.Hour)))
+
+		require.NotNil(t, payment.TimeToCapture())
+		assert.Equal(t, 2*time.Hour, *payment.TimeToCapture())
+		assert.Equal(t, first, *payment.FirstCapturedAt)
+	})
+
+	t.Run("cannot capture more than the remaining amount", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+		require.NoError(t, payment.MarkCapturing(400))
+		require.NoError(t, payment.Capture("captured", "cap-1", 400, time.Now()))
+
+		err := payment.MarkCapturing(101)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+	})
+
+	t.Run("rejects non-positive amounts", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+
+		assert.ErrorIs(t, payment.MarkCapturing(0), domain.ErrInvalidAmount)
+		assert.ErrorIs(t, payment.MarkCapturing(-1), domain.ErrInvalidAmount)
+	})
+
+	t.Run("cannot capture again once fully captured", func(t *testing.T) {
+		payment := createCapturedPayment(t)
+
+		err := payment.MarkCapturing(1)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+	})
+
+	t.Run("can refund a partially captured payment", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+		require.NoError(t, payment.MarkCapturing(200))
+		require.NoError(t, payment.Capture("captured", "cap-1", 200, time.Now()))
+
+		require.NoError(t, payment.MarkRefunding(payment.RefundableAmount()))
+	})
+}
+
+func TestPayment_PartialVoids(t *testing.T) {
+	partiallyCaptured := func(t *testing.T) *domain.Payment {
+		payment := createAuthorizedPayment(t)
+		require.NoError(t, payment.MarkCapturing(200))
+		require.NoError(t, payment.Capture("captured", "cap-1", 200, time.Now()))
+		return payment
+	}
+
+	t.Run("releases what is left after a partial capture", func(t *testing.T) {
+		payment := partiallyCaptured(t)
+
+		require.NoError(t, payment.MarkReleasing(300))
+		assert.Equal(t, domain.StatusVoiding, payment.Status)
+		require.NoError(t, payment.Release("voided", "void-1", 300, time.Now()))
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Equal(t, int64(300), payment.ReleasedAmountCents)
+		assert.Equal(t, "void-1", *payment.BankVoidID)
+		assert.Zero(t, payment.RemainingCaptureAmount())
+		assert.ErrorIs(t, payment.MarkCapturing(1), domain.ErrInvalidTransition)
+		require.NoError(t, payment.MarkRefunding(200), "what was captured can still be refunded")
+	})
+
+	t.Run("leaves the rest of a part release capturable", func(t *testing.T) {
+		payment := partiallyCaptured(t)
+
+		require.NoError(t, payment.MarkReleasing(100))
+		require.NoError(t, payment.Release("voided", "void-1", 100, time.Now()))
+
+		assert.Equal(t, int64(200), payment.RemainingCaptureAmount())
+		require.NoError(t, payment.MarkCapturing(200))
+	})
+
+	t.Run("cannot release more than is left", func(t *testing.T) {
+		payment := partiallyCaptured(t)
+
+		assert.ErrorIs(t, payment.MarkReleasing(301), domain.ErrInvalidAmount)
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+	})
+
+	t.Run("cannot void the whole authorization once part is captured", func(t *testing.T) {
+		payment := partiallyCaptured(t)
+
+		assert.ErrorIs(t, payment.MarkVoiding(), domain.ErrInvalidTransition)
+	})
+
+	t.Run("only a partially captured payment releases", func(t *testing.T) {
+		assert.ErrorIs(t, createAuthorizedPayment(t).MarkReleasing(100), domain.ErrInvalidTransition)
+		assert.ErrorIs(t, createCapturedPayment(t).MarkReleasing(1), domain.ErrInvalidTransition)
+	})
+
+	t.Run("a rejected release returns to CAPTURED", func(t *testing.T) {
+		payment := partiallyCaptured(t)
+		require.NoError(t, payment.MarkReleasing(300))
+
+		require.NoError(t, payment.FailRelease())
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Zero(t, payment.ReleasedAmountCents)
+		assert.Equal(t, int64(300), payment.RemainingCaptureAmount())
+	})
+}
+
+func TestPayment_PartialRefunds(t *testing.T) {
+	t.Run("refunds a capture in several parts", func(t *testing.T) {
+		payment := createCapturedPayment(t)
+
+		require.NoError(t, payment.MarkRefunding(200))
+		require.NoError(t, payment.Refund("ref-1", 200, time.Now()))
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Equal(t, int64(200), payment.RefundedAmountCents)
+		assert.Equal(t, int64(300), payment.RefundableAmount())
+		assert.Equal(t, int64(300), payment.NetAmount())
+
+		require.NoError(t, payment.MarkRefunding(300))
+		require.NoError(t, payment.Refund("ref-2", 300, time.Now()))
+
+		assert.Equal(t, domain.StatusRefunded, payment.Status)
+		assert.Equal(t, "ref-2", *payment.BankRefundID)
+		assert.Zero(t, payment.NetAmount())
+	})
+
+	t.Run("cannot refund more than was captured", func(t *testing.T) {
+		payment := createCapturedPayment(t)
+
+		err := payment.MarkRefunding(payment.CapturedAmountCents + 1)
+
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+	})
+
+	t.Run("failed refund returns payment to CAPTURED", func(t *testing.T) {
+		payment := createRefundingPayment(t)
+
+		require.NoError(t, payment.FailRefund())
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Zero(t, payment.RefundedAmountCents)
+	})
+
+	t.Run("only a refunding payment can fail a refund", func(t *testing.T) {
+		payment := createCapturingPayment(t)
+
+		assert.ErrorIs(t, payment.FailRefund(), domain.ErrInvalidTransition)
+	})
+}
+
+func TestPayment_RollBack(t *testing.T) {
+	t.Run("a first capture returns to AUTHORIZED", func(t *testing.T) {
+		payment := createCapturingPayment(t)
+
+		require.NoError(t, payment.RollBack())
+
+		assert.Equal(t, domain.StatusAuthorized, payment.Status)
+		assert.Zero(t, payment.CapturedAmountCents)
+	})
+
+	t.Run("a further partial capture returns to CAPTURED", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+		require.NoError(t, payment.MarkCapturing(200))
+		require.NoError(t, payment.Capture("captured", "cap-1", 200, time.Now()))
+		require.NoError(t, payment.MarkCapturing(300))
+
+		require.NoError(t, payment.RollBack())
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Equal(t, int64(200), payment.CapturedAmountCents)
+	})
+
+	t.Run("a void returns to AUTHORIZED", func(t *testing.T) {
+		payment := createVoidingPayment(t)
+
+		require.NoError(t, payment.RollBack())
+
+		assert.Equal(t, domain.StatusAuthorized, payment.Status)
+	})
+
+	t.Run("a void of what is left after a partial capture returns to CAPTURED", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+		require.NoError(t, payment.MarkCapturing(200))
+		require.NoError(t, payment.Capture("captured", "cap-1", 200, time.Now()))
+		require.NoError(t, payment.MarkReleasing(300))
+
+		require.NoError(t, payment.RollBack())
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+	})
+
+	t.Run("a refund returns to CAPTURED", func(t *testing.T) {
+		payment := createRefundingPayment(t)
+
+		require.NoError(t, payment.RollBack())
+
+		assert.Equal(t, domain.StatusCaptured, payment.Status)
+		assert.Zero(t, payment.RefundedAmountCents)
+	})
+
+	t.Run("only an intermediate state can be rolled back", func(t *testing.T) {
+		payment := createCapturedPayment(t)
+
+		assert.ErrorIs(t, payment.RollBack(), domain.ErrInvalidTransition)
+	})
+}
+
 func TestPayment_IsTerminal(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -177,24 +411,34 @@ func TestPayment_ScheduleRetry(t *testing.T) {
 		payment := createTestPayment(t)
 		backoff := 2 * time.Minute
 
-		payment.ScheduleRetry(backoff)
+		now := time.Now()
+
+		payment.ScheduleRetry(backoff, now)
 
 		assert.Equal(t, 1, payment.AttemptCount)
 		assert.NotNil(t, payment.NextRetryAt)
-
-		expectedRetryTime := time.Now().Add(backoff)
-		assert.WithinDuration(t, expectedRetryTime, *payment.NextRetryAt, time.Second)
+		assert.Equal(t, now.Add(backoff), *payment.NextRetryAt)
 	})
 
 	t.Run("increments attempt count on multiple retries", func(t *testing.T) {
 		payment := createTestPayment(t)
 
-		payment.ScheduleRetry(1 * time.Minute)
-		payment.ScheduleRetry(2 * time.Minute)
-		payment.ScheduleRetry(4 * time.Minute)
+		payment.ScheduleRetry(1*time.Minute, time.Now())
+		payment.ScheduleRetry(2*time.Minute, time.Now())
+		payment.ScheduleRetry(4*time.Minute, time.Now())
 
 		assert.Equal(t, 3, payment.AttemptCount)
 	})
+
+	t.Run("reset starts the attempts over", func(t *testing.T) {
+		payment := createTestPayment(t)
+
+		payment.ScheduleRetry(1*time.Minute, time.Now())
+		payment.ResetRetries()
+
+		assert.Zero(t, payment.AttemptCount)
+		assert.Nil(t, payment.NextRetryAt)
+	})
 }
 
 func createTestPayment(t *testing.T) *domain.Payment {
@@ -217,7 +461,7 @@ func createAuthorizedPayment(t *testing.T) *domain.Payment {
 func createCapturingPayment(t *testing.T) *domain.Payment {
 	t.Helper()
 	payment := createAuthorizedPayment(t)
-	err := payment.MarkCapturing()
+	err := payment.MarkCapturing(payment.AmountCents)
 	require.NoError(t, err)
 	return payment
 }
@@ -225,7 +469,7 @@ func createCapturingPayment(t *testing.T) *domain.Payment {
 func createCapturedPayment(t *testing.T) *domain.Payment {
 	t.Helper()
 	payment := createCapturingPayment(t)
-	err := payment.Capture("captured", "cap-123", time.Now())
+	err := payment.Capture("captured", "cap-123", payment.AmountCents, time.Now())
 	require.NoError(t, err)
 	return payment
 }
@@ -248,7 +492,7 @@ func createVoidedPayment(t *testing.T) *domain.Payment {
 func createRefundingPayment(t *testing.T) *domain.Payment {
 	t.Helper()
 	payment := createCapturedPayment(t)
-	err := payment.MarkRefunding()
+	err := payment.MarkRefunding(payment.RefundableAmount())
 	require.NoError(t, err)
 	return payment
 }
@@ -284,3 +528,147 @@ func createPaymentWithStatus(t *testing.T, status domain.PaymentStatus) *domain.
 		NextRetryAt:   nil,
 	}
 }
+
+func TestPayment_Scheduled(t *testing.T) {
+	t.Run("SCHEDULED -> PENDING -> AUTHORIZED", func(t *testing.T) {
+		payment, err := domain.NewScheduledPaymentEntity("pay-123", "order-456", "cust-789", 500, "USD")
+		require.NoError(t, err)
+		assert.Equal(t, domain.StatusScheduled, payment.Status)
+		assert.False(t, payment.IsTerminal())
+
+		require.NoError(t, payment.MarkPending())
+		require.NoError(t, payment.Authorize("auth-123", time.Now(), time.Now().Add(7*24*time.Hour)))
+		assert.Equal(t, domain.StatusAuthorized, payment.Status)
+	})
+
+	t.Run("SCHEDULED -> FAILED records the reason", func(t *testing.T) {
+		payment, err := domain.NewScheduledPaymentEntity("pay-123", "order-456", "cust-789", 500, "USD")
+		require.NoError(t, err)
+
+		require.NoError(t, payment.FailWithReason(domain.FailureReasonCardExpired))
+		assert.Equal(t, domain.StatusFailed, payment.Status)
+		require.NotNil(t, payment.FailureReason)
+		assert.Equal(t, domain.FailureReasonCardExpired, *payment.FailureReason)
+	})
+
+	t.Run("cannot capture before authorization", func(t *testing.T) {
+		payment, err := domain.NewScheduledPaymentEntity("pay-123", "order-456", "cust-789", 500, "USD")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, payment.MarkCapturing(0), domain.ErrInvalidTransition)
+	})
+
+	t.Run("rejects a schedule in the past", func(t *testing.T) {
+		now := time.Now()
+		_, err := domain.NewScheduledPayment("pay-123", "pm-123", now.Add(-time.Minute), now)
+		assert.ErrorIs(t, err, domain.ErrInvalidSchedule)
+	})
+}
+
+func TestPayment_Reauthorize(t *testing.T) {
+	expired := func(t *testing.T) *domain.Payment {
+		t.Helper()
+		payment := createAuthorizedPayment(t)
+		require.NoError(t, payment.MarkExpired())
+		return payment
+	}
+
+	t.Run("EXPIRED -> REAUTHORIZING -> AUTHORIZED", func(t *testing.T) {
+		payment := expired(t)
+
+		require.NoError(t, payment.MarkReauthorizing())
+		assert.False(t, payment.IsTerminal())
+
+		expiresAt := time.Now().Add(7 * 24 * time.Hour)
+		require.NoError(t, payment.Authorize("auth-new", time.Now(), expiresAt))
+		assert.Equal(t, domain.StatusAuthorized, payment.Status)
+		assert.Equal(t, "auth-new", *payment.BankAuthID)
+		assert.Equal(t, expiresAt, *payment.ExpiresAt)
+	})
+
+	t.Run("declined reauthorization returns to EXPIRED", func(t *testing.T) {
+		payment := expired(t)
+		require.NoError(t, payment.MarkReauthorizing())
+
+		require.NoError(t, payment.FailReauthorization())
+		assert.Equal(t, domain.StatusExpired, payment.Status)
+		require.NoError(t, payment.MarkReauthorizing())
+	})
+
+	t.Run("cannot reauthorize a live authorization", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+
+		assert.ErrorIs(t, payment.MarkReauthorizing(), domain.ErrInvalidTransition)
+		assert.ErrorIs(t, payment.FailReauthorization(), domain.ErrInvalidTransition)
+	})
+}
+
+func TestPaymentMethod_IsExpired(t *testing.T) {
+	now := time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	pm, err := domain.NewPaymentMethod("pm-123", "cust-789", "4111111111111111", 4, 2026, now)
+	require.NoError(t, err)
+	assert.Equal(t, "1111", pm.Last4)
+
+	assert.False(t, pm.IsExpired(time.Date(2026, time.April, 30, 23, 59, 0, 0, time.UTC)))
+	assert.True(t, pm.IsExpired(time.Date(2026, time.May, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = domain.NewPaymentMethod("pm-123", "cust-789", "4111111111111111", 2, 2026, now)
+	assert.ErrorIs(t, err, domain.ErrPaymentMethodExpired)
+
+	_, err = domain.NewPaymentMethod("pm-123", "cust-789", "4111-1111", 4, 2026, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidPaymentMethod)
+}
+
+func TestNetworkToken_Validate(t *testing.T) {
+	valid := domain.NetworkToken{
+		Number:     "4895370012003478",
+		Cryptogram: "AgAAAAAABk4DWZ4C28yUQAAAAAA=",
+		ECI:        "05",
+	}
+	require.NoError(t, valid.Validate())
+
+	withoutECI := valid
+	withoutECI.ECI = ""
+	require.NoError(t, withoutECI.Validate())
+
+	tests := map[string]func(*domain.NetworkToken){
+		"short number":         func(tok *domain.NetworkToken) { tok.Number = "48953700" },
+		"missing cryptogram":   func(tok *domain.NetworkToken) { tok.Cryptogram = "" },
+		"cryptogram not b64":   func(tok *domain.NetworkToken) { tok.Cryptogram = "not-a-cryptogram!" },
+		"cryptogram too short": func(tok *domain.NetworkToken) { tok.Cryptogram = "AgAAAAAABk4=" },
+		"eci not two digits":   func(tok *domain.NetworkToken) { tok.ECI = "5" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			tok := valid
+			mutate(&tok)
+			assert.ErrorIs(t, tok.Validate(), domain.ErrInvalidNetworkToken)
+		})
+	}
+}
+
+func TestCardBrandOf(t *testing.T) {
+	tests := map[string]string{
+		"4111111111111111": domain.CardBrandVisa,
+		"5555555555554444": domain.CardBrandMastercard,
+		"2223003122003222": domain.CardBrandMastercard,
+		"378282246310005":  domain.CardBrandAmex,
+		"6011111111111117": domain.CardBrandDiscover,
+		"3530111333300000": domain.CardBrandJCB,
+		"9999999999999999": domain.CardBrandUnknown,
+	}
+	for number, brand := range tests {
+		assert.Equal(t, brand, domain.CardBrandOf(number), number)
+	}
+}
+
+func TestPayment_RecordCard(t *testing.T) {
+	payment, err := domain.NewPayment("pay-123", "order-456", "cust-789", 500, "USD")
+	require.NoError(t, err)
+
+	payment.RecordCard("5555555555554444")
+
+	assert.Equal(t, "4444", *payment.CardLast4)
+	assert.Equal(t, domain.CardBrandMastercard, *payment.CardBrand)
+}
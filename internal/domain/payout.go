@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"time"
+	"unicode"
+)
+
+type PayoutStatus string
+
+const (
+	// PayoutPending is stored before the bank is asked to send the funds
+	PayoutPending PayoutStatus = "PENDING"
+	// PayoutInTransit has been accepted by the bank but has not reached the account yet
+	PayoutInTransit PayoutStatus = "IN_TRANSIT"
+	PayoutPaid      PayoutStatus = "PAID"
+	PayoutFailed    PayoutStatus = "FAILED"
+)
+
+// PayoutPurpose says why funds are sent out
+type PayoutPurpose string
+
+const (
+	// PurposeSellerPayout settles a marketplace seller's balance
+	PurposeSellerPayout PayoutPurpose = "seller_payout"
+	// PurposeRefund returns a payment to the customer's bank account instead of the card
+	PurposeRefund PayoutPurpose = "refund"
+)
+
+func (p PayoutPurpose) Validate() error {
+	switch p {
+	case PurposeSellerPayout, PurposeRefund:
+		return nil
+	}
+	return ErrInvalidPayout
+}
+
+// Payout sends funds from the merchant to a recipient's bank account. The account
+// number itself is kept encrypted outside the entity; only its last four digits are
+// carried here.
+type Payout struct {
+	CreatedAt   time.Time
+	ID          string
+	MerchantID  string
+	RecipientID string
+	Purpose     PayoutPurpose
+	// PaymentID is the payment a refund payout returns
+	PaymentID     *string
+	AmountCents   int64
+	Currency      string
+	Status        PayoutStatus
+	AccountLast4  string
+	RoutingNumber string
+	BankPayoutID  *string
+	// FailureCode is the bank's reason for a declined or returned payout
+	FailureCode *string
+	PaidAt      *time.Time
+	FailedAt    *time.Time
+}
+
+func NewPayout(
+	id string,
+	recipientID string,
+	purpose PayoutPurpose,
+	paymentID string,
+	amount int64, currency string,
+	accountNumber string,
+	routingNumber string,
+) (*Payout, error) {
+	if id == "" {
+		return nil, errors.New("payout ID is required")
+	}
+	if recipientID == "" || currency == "" || routingNumber == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if err := purpose.Validate(); err != nil {
+		return nil, err
+	}
+	if (purpose == PurposeRefund) != (paymentID != "") {
+		return nil, ErrInvalidPayout
+	}
+	if !validAccountNumber(accountNumber) {
+		return nil, ErrInvalidPayout
+	}
+
+	payout := &Payout{
+		ID:            id,
+		RecipientID:   recipientID,
+		Purpose:       purpose,
+		AmountCents:   amount,
+		Currency:      currency,
+		Status:        PayoutPending,
+		AccountLast4:  accountNumber[len(accountNumber)-4:],
+		RoutingNumber: routingNumber,
+		CreatedAt:     time.Now(),
+	}
+	if paymentID != "" {
+		payout.PaymentID = &paymentID
+	}
+	return payout, nil
+}
+
+// MarkInTransit records that the bank accepted the payout
+func (p *Payout) MarkInTransit(bankPayoutID string) error {
+	if err := p.transition(PayoutInTransit); err != nil {
+		return err
+	}
+	p.BankPayoutID = &bankPayoutID
+	return nil
+}
+
+// MarkPaid records that the funds reached the destination account
+func (p *Payout) MarkPaid(bankPayoutID string, paidAt time.Time) error {
+	if err := p.transition(PayoutPaid); err != nil {
+		return err
+	}
+	p.BankPayoutID = &bankPayoutID
+	p.PaidAt = &paidAt
+	return nil
+}
+
+// Fail records a payout the bank declined, or one the receiving bank returned
+func (p *Payout) Fail(failureCode string, failedAt time.Time) error {
+	if err := p.transition(PayoutFailed); err != nil {
+		return err
+	}
+	p.FailureCode = &failureCode
+	p.FailedAt = &failedAt
+	return nil
+}
+
+func (p *Payout) IsTerminal() bool {
+	return p.Status == PayoutPaid || p.Status == PayoutFailed
+}
+
+func (p *Payout) transition(target PayoutStatus) error {
+	var allowed []PayoutStatus
+	switch p.Status {
+	case PayoutPending:
+		allowed = []PayoutStatus{PayoutInTransit, PayoutPaid, PayoutFailed}
+	case PayoutInTransit:
+		allowed = []PayoutStatus{PayoutPaid, PayoutFailed}
+	case PayoutPaid, PayoutFailed:
+	}
+	if !slices.Contains(allowed, target) {
+		return ErrInvalidTransition
+	}
+	p.Status = target
+	return nil
+}
+
+func validAccountNumber(number string) bool {
+	if len(number) < 4 || len(number) > 34 {
+		return false
+	}
+	for _, r := range number {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
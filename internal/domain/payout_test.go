@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestPayout(t *testing.T) *domain.Payout {
+	t.Helper()
+	payout, err := domain.NewPayout("po-123", "seller-789", domain.PurposeSellerPayout, "", 2500, "USD", "000123456789", "110000000")
+	require.NoError(t, err)
+	return payout
+}
+
+func TestNewPayout(t *testing.T) {
+	t.Run("keeps only the last four digits of the account", func(t *testing.T) {
+		payout := createTestPayout(t)
+
+		assert.Equal(t, domain.PayoutPending, payout.Status)
+		assert.Equal(t, "6789", payout.AccountLast4)
+		assert.Nil(t, payout.PaymentID)
+	})
+
+	t.Run("requires a payment for a refund", func(t *testing.T) {
+		_, err := domain.NewPayout("po-123", "cust-789", domain.PurposeRefund, "", 2500, "USD", "000123456789", "110000000")
+		assert.ErrorIs(t, err, domain.ErrInvalidPayout)
+
+		payout, err := domain.NewPayout("po-123", "cust-789", domain.PurposeRefund, "pay-123", 2500, "USD", "000123456789", "110000000")
+		require.NoError(t, err)
+		require.NotNil(t, payout.PaymentID)
+		assert.Equal(t, "pay-123", *payout.PaymentID)
+	})
+
+	t.Run("rejects a payment on a seller payout", func(t *testing.T) {
+		_, err := domain.NewPayout("po-123", "seller-789", domain.PurposeSellerPayout, "pay-123", 2500, "USD", "000123456789", "110000000")
+		assert.ErrorIs(t, err, domain.ErrInvalidPayout)
+	})
+
+	t.Run("rejects a malformed account number", func(t *testing.T) {
+		_, err := domain.NewPayout("po-123", "seller-789", domain.PurposeSellerPayout, "", 2500, "USD", "12-34", "110000000")
+		assert.ErrorIs(t, err, domain.ErrInvalidPayout)
+	})
+
+	t.Run("rejects a zero amount", func(t *testing.T) {
+		_, err := domain.NewPayout("po-123", "seller-789", domain.PurposeSellerPayout, "", 0, "USD", "000123456789", "110000000")
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+}
+
+func TestPayout_Transitions(t *testing.T) {
+	paidAt := time.Date(2026, time.May, 4, 12, 0, 0, 0, time.UTC)
+
+	t.Run("in transit then paid", func(t *testing.T) {
+		payout := createTestPayout(t)
+
+		require.NoError(t, payout.MarkInTransit("bank-po-1"))
+		require.NoError(t, payout.MarkPaid("bank-po-1", paidAt))
+
+		assert.Equal(t, domain.PayoutPaid, payout.Status)
+		assert.Equal(t, paidAt, *payout.PaidAt)
+		assert.True(t, payout.IsTerminal())
+	})
+
+	t.Run("returned while in transit", func(t *testing.T) {
+		payout := createTestPayout(t)
+
+		require.NoError(t, payout.MarkInTransit("bank-po-1"))
+		require.NoError(t, payout.Fail("account_closed", paidAt))
+
+		assert.Equal(t, domain.PayoutFailed, payout.Status)
+		assert.Equal(t, "account_closed", *payout.FailureCode)
+	})
+
+	t.Run("terminal payouts cannot change", func(t *testing.T) {
+		payout := createTestPayout(t)
+		require.NoError(t, payout.Fail("invalid_account", paidAt))
+
+		assert.ErrorIs(t, payout.MarkInTransit("bank-po-1"), domain.ErrInvalidTransition)
+		assert.ErrorIs(t, payout.MarkPaid("bank-po-1", paidAt), domain.ErrInvalidTransition)
+	})
+}
@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// Receipt is what the customer is shown for a payment: what was charged, to which
+// card, and what has been given back since
+type Receipt struct {
+	PaymentID   string
+	MerchantID  string
+	OrderID     string
+	CustomerID  string
+	Status      PaymentStatus
+	Currency    string
+	AmountCents int64
+	// CapturedCents and RefundedCents are the totals so far; NetCents is what the
+	// customer has paid once refunds are taken off
+	CapturedCents int64
+	RefundedCents int64
+	// CardBrand and CardLast4 are empty for payments authorized before cards were
+	// recorded on them
+	CardBrand    string
+	CardLast4    string
+	BankAuthID   string
+	CreatedAt    time.Time
+	AuthorizedAt time.Time
+	CapturedAt   *time.Time
+	VoidedAt     *time.Time
+	RefundedAt   *time.Time
+	// Entries are the captures and refunds the bank settled, oldest first
+	Entries []ReceiptEntry
+}
+
+// ReceiptEntry is one capture or refund on a receipt
+type ReceiptEntry struct {
+	Type            OperationType
+	AmountCents     int64
+	BankReferenceID string
+	CompletedAt     time.Time
+}
+
+// NewReceipt builds the receipt of p from its operations. A payment the bank never
+// authorized has none, and returns ErrNoReceipt.
+func NewReceipt(p *Payment, ops []*Operation) (*Receipt, error) {
+	if p.AuthorizedAt == nil || p.BankAuthID == nil {
+		return nil, ErrNoReceipt
+	}
+
+	r := &Receipt{
+		PaymentID:     p.ID,
+		MerchantID:    p.MerchantID,
+		OrderID:       p.OrderID,
+		CustomerID:    p.CustomerID,
+		Status:        p.Status,
+		Currency:      p.Currency,
+		AmountCents:   p.AmountCents,
+		CapturedCents: p.CapturedAmountCents,
+		RefundedCents: p.RefundedAmountCents,
+		BankAuthID:    *p.BankAuthID,
+		CreatedAt:     p.CreatedAt,
+		AuthorizedAt:  *p.AuthorizedAt,
+		CapturedAt:    p.CapturedAt,
+		VoidedAt:      p.VoidedAt,
+		RefundedAt:    p.RefundedAt,
+		Entries:       []ReceiptEntry{},
+	}
+	if p.CardLast4 != nil {
+		r.CardLast4 = *p.CardLast4
+	}
+	if p.CardBrand != nil {
+		r.CardBrand = *p.CardBrand
+	}
+
+	for _, op := range ops {
+		if op.Status != OperationSucceeded || op.CompletedAt == nil {
+			continue
+		}
+		if op.Type != OperationCapture && op.Type != OperationRefund {
+			continue
+		}
+		entry := ReceiptEntry{Type: op.Type, AmountCents: op.AmountCents, CompletedAt: *op.CompletedAt}
+		if op.BankReferenceID != nil {
+			entry.BankReferenceID = *op.BankReferenceID
+		}
+		r.Entries = append(r.Entries, entry)
+	}
+	return r, nil
+}
+
+// NetCents is what the customer has paid, refunds taken off
+func (r *Receipt) NetCents() int64 {
+	return r.CapturedCents - r.RefundedCents
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReceipt(t *testing.T) {
+	t.Run("lists settled captures and refunds", func(t *testing.T) {
+		payment := createAuthorizedPayment(t)
+		payment.RecordCard("4111111111111111")
+		payment.CapturedAmountCents = 500
+		payment.RefundedAmountCents = 200
+		completed := time.Now()
+		captureRef := "cap-1"
+		ops := []*domain.Operation{
+			{Type: domain.OperationCapture, Status: domain.OperationSucceeded, AmountCents: 500, BankReferenceID: &captureRef, CompletedAt: &completed},
+			{Type: domain.OperationRefund, Status: domain.OperationFailed, AmountCents: 300, CompletedAt: &completed},
+			{Type: domain.OperationRefund, Status: domain.OperationSucceeded, AmountCents: 200, CompletedAt: &completed},
+			{Type: domain.OperationRefund, Status: domain.OperationPendingApproval, AmountCents: 100},
+		}
+
+		receipt, err := domain.NewReceipt(payment, ops)
+
+		require.NoError(t, err)
+		assert.Equal(t, "1111", receipt.CardLast4)
+		assert.Equal(t, domain.CardBrandVisa, receipt.CardBrand)
+		assert.Equal(t, int64(300), receipt.NetCents())
+		require.Len(t, receipt.Entries, 2)
+		assert.Equal(t, domain.OperationCapture, receipt.Entries[0].Type)
+		assert.Equal(t, "cap-1", receipt.Entries[0].BankReferenceID)
+		assert.Equal(t, int64(200), receipt.Entries[1].AmountCents)
+	})
+
+	t.Run("leaves out an unrecorded card", func(t *testing.T) {
+		receipt, err := domain.NewReceipt(createAuthorizedPayment(t), nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, receipt.CardLast4)
+		assert.Empty(t, receipt.Entries)
+	})
+
+	t.Run("none before authorization", func(t *testing.T) {
+		payment, err := domain.NewPayment("pay-123", "order-456", "cust-789", 500, "USD")
+		require.NoError(t, err)
+
+		_, err = domain.NewReceipt(payment, nil)
+		assert.ErrorIs(t, err, domain.ErrNoReceipt)
+	})
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxReconciliationRange caps the period one reconciliation may cover
+const MaxReconciliationRange = 31 * 24 * time.Hour
+
+// MaxReconciliationChecks caps how many payments, and how many authorizations no
+// payment holds, one reconciliation checks with the bank
+const MaxReconciliationChecks = 500
+
+// ReconciliationLease is how long a reconciliation holds the payments it is checking.
+// A run releases them when it ends; the lease only runs out for a run that crashed.
+const ReconciliationLease = 10 * time.Minute
+
+// ReconciliationIssueKind says how the gateway and the bank disagree about an
+// authorization
+type ReconciliationIssueKind string
+
+const (
+	// IssueStatusMismatch is a payment whose status the bank's authorization contradicts,
+	// such as a CAPTURED payment the bank has voided
+	IssueStatusMismatch ReconciliationIssueKind = "STATUS_MISMATCH"
+	// IssueMissingAtBank is a payment whose authorization the bank does not know
+	IssueMissingAtBank ReconciliationIssueKind = "MISSING_AT_BANK"
+	// IssueUnknownToGateway is a live authorization the bank granted for a payment that
+	// went on without it and holds another one or none
+	IssueUnknownToGateway ReconciliationIssueKind = "UNKNOWN_TO_GATEWAY"
+	// IssueOrphanedAuthorization is a live authorization the bank granted for a payment
+	// that failed, typically because the gateway crashed before saving it. Reconciliation
+	// voids it to release the customer's funds.
+	IssueOrphanedAuthorization ReconciliationIssueKind = "ORPHANED_AUTHORIZATION"
+	// IssueUnrecordedRefund is a refund the bank made on its own that the gateway could
+	// not record, because no payment holds the authorization or the payment has less
+	// left to refund than the bank returned
+	IssueUnrecordedRefund ReconciliationIssueKind = "UNRECORDED_REFUND"
+)
+
+// ReconciliationIssue is one disagreement found between the gateway and the bank.
+// PaymentID is the payment the authorization belongs to, or was requested for when the
+// gateway lacks it. BankStatus is empty for IssueMissingAtBank, and VoidedAt is set once
+// an orphaned authorization has been voided.
+type ReconciliationIssue struct {
+	ID              string
+	MerchantID      string
+	Kind            ReconciliationIssueKind
+	BankAuthID      string
+	PaymentID       string
+	GatewayStatus   PaymentStatus
+	BankStatus      string
+	FirstDetectedAt time.Time
+	LastDetectedAt  time.Time
+	VoidedAt        *time.Time
+}
+
+// bankStatusesFor lists the statuses the bank may report for the authorization of a
+// payment in each settled status. The bank keeps a refunded capture as CAPTURED, and
+// a failed payment may still hold an authorization the bank let lapse or voided.
+var bankStatusesFor = map[PaymentStatus][]string{
+	StatusAuthorized: {"AUTHORIZED"},
+	StatusCaptured:   {"CAPTURED"},
+	StatusRefunded:   {"CAPTURED", "REFUNDED"},
+	StatusVoided:     {"VOIDED"},
+	StatusExpired:    {"EXPIRED", "VOIDED"},
+	StatusFailed:     {"EXPIRED", "VOIDED"},
+}
+
+// ReconciledStatuses are the statuses a reconciliation compares with the bank. Payments
+// in a processing status are still talking to the bank and are left to the workers.
+func ReconciledStatuses() []PaymentStatus {
+	return []PaymentStatus{StatusAuthorized, StatusCaptured, StatusRefunded, StatusVoided, StatusExpired, StatusFailed}
+}
+
+// AgreesWithBank reports whether bankStatus is what the bank should say about the
+// authorization of a payment in status
+func AgreesWithBank(status PaymentStatus, bankStatus string) bool {
+	return slices.Contains(bankStatusesFor[status], strings.ToUpper(bankStatus))
+}
+
+// HoldsFunds reports whether an authorization in bankStatus still reserves or has taken
+// the customer's money, so the gateway must know about it
+func HoldsFunds(bankStatus string) bool {
+	switch strings.ToUpper(bankStatus) {
+	case "AUTHORIZED", "CAPTURED":
+		return true
+	default:
+		return false
+	}
+}
+
+// CheckReconciliationRange validates the period [from, to) of a reconciliation
+func CheckReconciliationRange(from, to time.Time) error {
+	if from.IsZero() || to.IsZero() || !from.Before(to) || to.Sub(from) > MaxReconciliationRange {
+		return ErrInvalidReconciliationRange
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgreesWithBank(t *testing.T) {
+	tests := []struct {
+		status     domain.PaymentStatus
+		bankStatus string
+		want       bool
+	}{
+		{domain.StatusAuthorized, "authorized", true},
+		{domain.StatusAuthorized, "CAPTURED", false},
+		{domain.StatusCaptured, "CAPTURED", true},
+		{domain.StatusCaptured, "VOIDED", false},
+		{domain.StatusRefunded, "CAPTURED", true},
+		{domain.StatusRefunded, "REFUNDED", true},
+		{domain.StatusVoided, "AUTHORIZED", false},
+		{domain.StatusExpired, "EXPIRED", true},
+		{domain.StatusFailed, "AUTHORIZED", false},
+		{domain.StatusCapturing, "CAPTURED", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status)+"/"+tt.bankStatus, func(t *testing.T) {
+			assert.Equal(t, tt.want, domain.AgreesWithBank(tt.status, tt.bankStatus))
+		})
+	}
+}
+
+func TestCheckReconciliationRange(t *testing.T) {
+	now := time.Now()
+
+	assert.NoError(t, domain.CheckReconciliationRange(now.Add(-24*time.Hour), now))
+	assert.NoError(t, domain.CheckReconciliationRange(now.Add(-domain.MaxReconciliationRange), now))
+	assert.ErrorIs(t, domain.CheckReconciliationRange(now, now), domain.ErrInvalidReconciliationRange)
+	assert.ErrorIs(t, domain.CheckReconciliationRange(time.Time{}, now), domain.ErrInvalidReconciliationRange)
+	assert.ErrorIs(t, domain.CheckReconciliationRange(now.Add(-32*24*time.Hour), now), domain.ErrInvalidReconciliationRange)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// RegionLease names the region that takes writes when the gateway runs active-passive
+// across regions. Every promotion raises Epoch, so writes made by a region that was
+// since demoted can be told apart and refused.
+type RegionLease struct {
+	ActiveRegion string
+	Epoch        int64
+	PromotedAt   time.Time
+}
+
+// RegionState is how one region stands with respect to the lease
+type RegionState struct {
+	Region string
+	Active bool
+	// Epoch is what this region's writes are recorded under; zero until a region is
+	// first promoted
+	Epoch int64
+	// Lease is nil until a region is first promoted
+	Lease *RegionLease
+}
+
+// NewRegionState places region with respect to lease. A region holds the lease if it
+// is named in it. Before any region has been promoted, every region not started as a
+// standby takes writes.
+func NewRegionState(region string, standby bool, lease *RegionLease) RegionState {
+	if lease == nil {
+		return RegionState{Region: region, Active: !standby}
+	}
+	return RegionState{
+		Region: region,
+		Active: lease.ActiveRegion == region,
+		Epoch:  lease.Epoch,
+		Lease:  lease,
+	}
+}
@@ -0,0 +1,26 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRegionState(t *testing.T) {
+	assert.True(t, domain.NewRegionState("eu-west", false, nil).Active)
+	assert.False(t, domain.NewRegionState("us-east", true, nil).Active)
+	assert.Zero(t, domain.NewRegionState("eu-west", false, nil).Epoch)
+
+	lease := &domain.RegionLease{ActiveRegion: "us-east", Epoch: 2, PromotedAt: time.Now()}
+
+	promoted := domain.NewRegionState("us-east", true, lease)
+	assert.True(t, promoted.Active)
+	assert.Equal(t, int64(2), promoted.Epoch)
+
+	// Once a region has been promoted, being started as standby no longer matters
+	demoted := domain.NewRegionState("eu-west", false, lease)
+	assert.False(t, demoted.Active)
+	assert.Same(t, lease, demoted.Lease)
+}
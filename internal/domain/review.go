@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+// ReviewDecision is what a reviewer made of a flagged payment
+type ReviewDecision string
+
+const (
+	// ReviewApproved sends the payment on to the bank
+	ReviewApproved ReviewDecision = "APPROVED"
+	// ReviewDeclined fails the payment without the bank seeing it
+	ReviewDeclined ReviewDecision = "DECLINED"
+)
+
+// ReviewReasonAmount flags an authorization above its currency's review threshold
+const ReviewReasonAmount = "amount_over_threshold"
+
+// FailureReasonDeclinedInReview fails a flagged payment a reviewer declined
+const FailureReasonDeclinedInReview = "declined_in_review"
+
+// PaymentReview is the place in the review queue of a payment held in REVIEW. It
+// leaves the queue once Decision is set; ReviewedBy is the API key ID that set it.
+type PaymentReview struct {
+	PaymentID  string
+	MerchantID string
+	Reason     string
+	FlaggedAt  time.Time
+	Decision   *ReviewDecision
+	ReviewedBy *string
+	ReviewedAt *time.Time
+}
+
+func NewPaymentReview(paymentID, reason string, flaggedAt time.Time) *PaymentReview {
+	return &PaymentReview{
+		PaymentID: paymentID,
+		Reason:    reason,
+		FlaggedAt: flaggedAt,
+	}
+}
+
+// Decide records the reviewer's decision. A review is only decided once.
+func (r *PaymentReview) Decide(decision ReviewDecision, reviewedBy string, at time.Time) error {
+	if r.Decision != nil {
+		return ErrReviewDecided
+	}
+	r.Decision = &decision
+	r.ReviewedBy = &reviewedBy
+	r.ReviewedAt = &at
+	return nil
+}
+
+// Wait returns how long the payment sat in the queue, up to now while it is undecided
+func (r *PaymentReview) Wait(now time.Time) time.Duration {
+	if r.ReviewedAt != nil {
+		return r.ReviewedAt.Sub(r.FlaggedAt)
+	}
+	return now.Sub(r.FlaggedAt)
+}
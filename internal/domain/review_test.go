@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayment_Review(t *testing.T) {
+	held := func(t *testing.T) *domain.Payment {
+		t.Helper()
+		payment, err := domain.NewPayment("pay-123", "order-456", "cust-789", 500, "USD")
+		require.NoError(t, err)
+		require.NoError(t, payment.HoldForReview())
+		return payment
+	}
+
+	t.Run("REVIEW -> PENDING -> AUTHORIZED", func(t *testing.T) {
+		payment := held(t)
+		assert.Equal(t, domain.StatusReview, payment.Status)
+		assert.False(t, payment.IsTerminal())
+
+		require.NoError(t, payment.MarkPending())
+		require.NoError(t, payment.Authorize("auth-123", time.Now(), time.Now().Add(7*24*time.Hour)))
+		assert.Equal(t, domain.StatusAuthorized, payment.Status)
+	})
+
+	t.Run("REVIEW -> FAILED records the reason", func(t *testing.T) {
+		payment := held(t)
+
+		require.NoError(t, payment.FailWithReason(domain.FailureReasonDeclinedInReview))
+		assert.Equal(t, domain.StatusFailed, payment.Status)
+		assert.Equal(t, domain.FailureReasonDeclinedInReview, *payment.FailureReason)
+	})
+
+	t.Run("cannot be authorized or held again while in review", func(t *testing.T) {
+		payment := held(t)
+
+		assert.ErrorIs(t, payment.Authorize("auth-123", time.Now(), time.Now()), domain.ErrInvalidTransition)
+		assert.ErrorIs(t, payment.HoldForReview(), domain.ErrInvalidTransition)
+	})
+}
+
+func TestPaymentReview_Decide(t *testing.T) {
+	flaggedAt := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	review := domain.NewPaymentReview("pay-123", domain.ReviewReasonAmount, flaggedAt)
+	assert.Equal(t, 5*time.Minute, review.Wait(flaggedAt.Add(5*time.Minute)))
+
+	reviewedAt := flaggedAt.Add(time.Hour)
+	require.NoError(t, review.Decide(domain.ReviewApproved, "key-1", reviewedAt))
+	assert.Equal(t, domain.ReviewApproved, *review.Decision)
+	assert.Equal(t, "key-1", *review.ReviewedBy)
+	assert.Equal(t, time.Hour, review.Wait(reviewedAt.Add(time.Hour)), "the wait stops at the decision")
+
+	assert.ErrorIs(t, review.Decide(domain.ReviewDeclined, "key-2", reviewedAt), domain.ErrReviewDecided)
+	assert.Equal(t, domain.ReviewApproved, *review.Decision)
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// ScheduledPayment ties a SCHEDULED payment to the saved card it will be authorized
+// with and the time the authorization is due.
+type ScheduledPayment struct {
+	PaymentID       string
+	PaymentMethodID string
+	ScheduledFor    time.Time
+	CreatedAt       time.Time
+	// MerchantID is the merchant of the payment, which the schedule belongs to
+	MerchantID string
+}
+
+func NewScheduledPayment(paymentID, paymentMethodID string, scheduledFor, now time.Time) (*ScheduledPayment, error) {
+	if paymentID == "" || paymentMethodID == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if !scheduledFor.After(now) {
+		return nil, ErrInvalidSchedule
+	}
+
+	return &ScheduledPayment{
+		PaymentID:       paymentID,
+		PaymentMethodID: paymentMethodID,
+		ScheduledFor:    scheduledFor,
+		CreatedAt:       now,
+	}, nil
+}
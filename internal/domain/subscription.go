@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"time"
+)
+
+type SubscriptionStatus string
+
+const (
+	SubscriptionActive   SubscriptionStatus = "ACTIVE"
+	SubscriptionPastDue  SubscriptionStatus = "PAST_DUE"
+	SubscriptionCanceled SubscriptionStatus = "CANCELED"
+)
+
+// BillingInterval is how often a subscription is charged
+type BillingInterval string
+
+const (
+	IntervalDay   BillingInterval = "day"
+	IntervalWeek  BillingInterval = "week"
+	IntervalMonth BillingInterval = "month"
+	IntervalYear  BillingInterval = "year"
+)
+
+func (i BillingInterval) Validate() error {
+	switch i {
+	case IntervalDay, IntervalWeek, IntervalMonth, IntervalYear:
+		return nil
+	}
+	return ErrInvalidInterval
+}
+
+// After returns the time one interval after t
+func (i BillingInterval) After(t time.Time) time.Time {
+	switch i {
+	case IntervalDay:
+		return t.AddDate(0, 0, 1)
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return t.AddDate(0, 1, 0)
+	case IntervalYear:
+		return t.AddDate(1, 0, 0)
+	}
+	return t
+}
+
+// DunningPolicy decides how a subscription reacts to declined charges. A decline is
+// retried after each delay in turn; once they are used up the subscription is canceled.
+type DunningPolicy struct {
+	RetryDelays []time.Duration
+}
+
+// DefaultDunningPolicy retries a declined charge after one, three and seven days
+var DefaultDunningPolicy = DunningPolicy{
+	RetryDelays: []time.Duration{24 * time.Hour, 72 * time.Hour, 7 * 24 * time.Hour},
+}
+
+// Subscription charges a saved payment method for a plan every interval. Each charge
+// is a sale: an authorization followed by a full capture.
+type Subscription struct {
+	CreatedAt       time.Time
+	ID              string
+	MerchantID      string
+	CustomerID      string
+	PaymentMethodID string
+	Plan            string
+	AmountCents     int64
+	Currency        string
+	Interval        BillingInterval
+	Status          SubscriptionStatus
+	// PeriodStart is when the period being charged began; NextChargeAt moves past it
+	// while a declined charge is retried.
+	PeriodStart  time.Time
+	NextChargeAt time.Time
+	// FailedAttempts counts consecutive declines of the current charge
+	FailedAttempts int
+	LastPaymentID  *string
+	CanceledAt     *time.Time
+}
+
+func NewSubscription(
+	id string,
+	customerID string,
+	paymentMethodID string,
+	plan string,
+	amount int64,
+	currency string,
+	interval BillingInterval,
+	startAt time.Time,
+	now time.Time,
+) (*Subscription, error) {
+	if id == "" || customerID == "" || paymentMethodID == "" || plan == "" {
+		return nil, ErrMissingRequiredField
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+	if err := interval.Validate(); err != nil {
+		return nil, err
+	}
+	if startAt.IsZero() {
+		startAt = now
+	}
+
+	return &Subscription{
+		ID:              id,
+		CustomerID:      customerID,
+		PaymentMethodID: paymentMethodID,
+		Plan:            plan,
+		AmountCents:     amount,
+		Currency:        currency,
+		Interval:        interval,
+		Status:          SubscriptionActive,
+		PeriodStart:     startAt,
+		NextChargeAt:    startAt,
+		CreatedAt:       now,
+	}, nil
+}
+
+// ChargeKey identifies the current charge attempt. It changes whenever the charge is
+// rescheduled, so a retry after a decline is a new bank request while a crashed
+// attempt resumes under the same key.
+func (s *Subscription) ChargeKey() string {
+	return "subscription-" + s.ID + "-" + s.NextChargeAt.UTC().Format("20060102T150405Z")
+}
+
+// RecordCharge books a successful charge and moves on to the next period. Periods are
+// counted from PeriodStart, so dunning retries do not shift the billing cycle; periods
+// that already ended are skipped rather than charged back to back.
+func (s *Subscription) RecordCharge(paymentID string, now time.Time) error {
+	if s.Status == SubscriptionCanceled {
+		return ErrInvalidTransition
+	}
+
+	next := s.Interval.After(s.PeriodStart)
+	for !next.After(now) {
+		next = s.Interval.After(next)
+	}
+
+	s.Status = SubscriptionActive
+	s.FailedAttempts = 0
+	s.PeriodStart = next
+	s.NextChargeAt = next
+	s.LastPaymentID = &paymentID
+	return nil
+}
+
+// RecordDecline applies the dunning policy to a declined charge
+func (s *Subscription) RecordDecline(paymentID string, policy DunningPolicy, now time.Time) error {
+	if s.Status == SubscriptionCanceled {
+		return ErrInvalidTransition
+	}
+
+	s.LastPaymentID = &paymentID
+	if s.FailedAttempts >= len(policy.RetryDelays) {
+		s.FailedAttempts++
+		return s.Cancel(now)
+	}
+
+	s.NextChargeAt = now.Add(policy.RetryDelays[s.FailedAttempts])
+	s.FailedAttempts++
+	s.Status = SubscriptionPastDue
+	return nil
+}
+
+func (s *Subscription) Cancel(now time.Time) error {
+	if s.Status == SubscriptionCanceled {
+		return ErrInvalidTransition
+	}
+	s.Status = SubscriptionCanceled
+	s.CanceledAt = &now
+	return nil
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSubscription(t *testing.T, interval domain.BillingInterval, startAt time.Time) *domain.Subscription {
+	t.Helper()
+	sub, err := domain.NewSubscription("sub-123", "cust-789", "pm-123", "pro", 1500, "USD", interval, startAt, startAt)
+	require.NoError(t, err)
+	return sub
+}
+
+func TestNewSubscription(t *testing.T) {
+	t.Run("starts now when no start is given", func(t *testing.T) {
+		now := time.Now()
+		sub, err := domain.NewSubscription("sub-123", "cust-789", "pm-123", "pro", 1500, "USD", domain.IntervalMonth, time.Time{}, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.SubscriptionActive, sub.Status)
+		assert.Equal(t, now, sub.NextChargeAt)
+	})
+
+	t.Run("rejects an unknown interval", func(t *testing.T) {
+		_, err := domain.NewSubscription("sub-123", "cust-789", "pm-123", "pro", 1500, "USD", "fortnight", time.Time{}, time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvalidInterval)
+	})
+
+	t.Run("rejects a zero amount", func(t *testing.T) {
+		_, err := domain.NewSubscription("sub-123", "cust-789", "pm-123", "pro", 0, "USD", domain.IntervalMonth, time.Time{}, time.Now())
+		assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+	})
+}
+
+func TestSubscription_RecordCharge(t *testing.T) {
+	start := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.UTC)
+	sub := createTestSubscription(t, domain.IntervalWeek, start)
+
+	require.NoError(t, sub.RecordCharge("pay-1", start.Add(time.Minute)))
+
+	assert.Equal(t, start.AddDate(0, 0, 7), sub.NextChargeAt)
+	require.NotNil(t, sub.LastPaymentID)
+	assert.Equal(t, "pay-1", *sub.LastPaymentID)
+}
+
+func TestSubscription_Dunning(t *testing.T) {
+	start := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	policy := domain.DunningPolicy{RetryDelays: []time.Duration{24 * time.Hour, 72 * time.Hour}}
+
+	t.Run("retries a decline and keeps the billing cycle", func(t *testing.T) {
+		sub := createTestSubscription(t, domain.IntervalMonth, start)
+		firstKey := sub.ChargeKey()
+
+		require.NoError(t, sub.RecordDecline("pay-1", policy, start))
+		assert.Equal(t, domain.SubscriptionPastDue, sub.Status)
+		assert.Equal(t, start.Add(24*time.Hour), sub.NextChargeAt)
+		assert.Equal(t, 1, sub.FailedAttempts)
+		assert.NotEqual(t, firstKey, sub.ChargeKey())
+
+		require.NoError(t, sub.RecordCharge("pay-2", sub.NextChargeAt))
+		assert.Equal(t, domain.SubscriptionActive, sub.Status)
+		assert.Zero(t, sub.FailedAttempts)
+		assert.Equal(t, start.AddDate(0, 1, 0), sub.NextChargeAt)
+	})
+
+	t.Run("cancels once the retries are used up", func(t *testing.T) {
+		sub := createTestSubscription(t, domain.IntervalMonth, start)
+
+		now := start
+		for range policy.RetryDelays {
+			require.NoError(t, sub.RecordDecline("pay", policy, now))
+			now = sub.NextChargeAt
+		}
+		require.NoError(t, sub.RecordDecline("pay", policy, now))
+
+		assert.Equal(t, domain.SubscriptionCanceled, sub.Status)
+		assert.NotNil(t, sub.CanceledAt)
+		assert.ErrorIs(t, sub.RecordCharge("pay", now), domain.ErrInvalidTransition)
+	})
+}
@@ -0,0 +1,60 @@
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// maxRequestBytes bounds a request body; queries are small, variables smaller
+const maxRequestBytes = 64 << 10
+
+// Request is a GraphQL request as POSTed by clients
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// ServeHTTP answers a request POSTed as JSON. Requests that cannot be parsed, do not
+// fit the schema or go over its limits are answered with 400; once a query runs the
+// answer is 200, with the fields that failed listed under errors.
+func (s *Schema) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := graphql.StartOperationTrace(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var req Request
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.UseNumber() // variables are coerced to the schema's Int by the executor
+	if err := decoder.Decode(&req); err != nil {
+		reject(w, gqlerror.List{gqlerror.Errorf("request body must be a JSON object with a query")})
+		return
+	}
+
+	params := &graphql.RawParams{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+		Headers:       r.Header,
+	}
+	opCtx, errs := s.exec.CreateOperationContext(ctx, params)
+	if errs != nil {
+		reject(w, errs)
+		return
+	}
+
+	responses, ctx := s.exec.DispatchOperation(ctx, opCtx)
+	json.NewEncoder(w).Encode(responses(ctx)) //nolint:errcheck // client went away
+}
+
+// reject answers a request that was not executed. Unlike graphql.Response it leaves
+// data out altogether, as the spec asks, so clients can tell it from a null result.
+func reject(w http.ResponseWriter, errs gqlerror.List) {
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(struct { //nolint:errcheck // client went away
+		Errors gqlerror.List `json:"errors"`
+	}{errs})
+}
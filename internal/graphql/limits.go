@@ -0,0 +1,82 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// depthLimit rejects an operation whose selections nest deeper than limit, counting a
+// top-level field as depth 1. Fragments count as the fields they spread. gqlgen
+// validates the document first, so fragment cycles never reach it.
+type depthLimit int
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = depthLimit(0)
+
+func (d depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d depthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	if depth := selectionDepth(opCtx.Operation.SelectionSet); depth > int(d) {
+		err := gqlerror.Errorf("operation is nested %d fields deep, which exceeds the limit of %d", depth, int(d))
+		errcode.Set(err, errcode.ValidationFailed)
+		return err
+	}
+	return nil
+}
+
+func selectionDepth(set ast.SelectionSet) int {
+	deepest := 0
+	for _, sel := range set {
+		var depth int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			depth = 1 + selectionDepth(sel.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			depth = selectionDepth(sel.Definition.SelectionSet)
+		}
+		deepest = max(deepest, depth)
+	}
+	return deepest
+}
+
+// noIntrospection rejects queries for __schema or __type up front. gqlgen would only
+// answer them with a field error, which reads like a fault of the gateway.
+type noIntrospection struct{}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = noIntrospection{}
+
+func (noIntrospection) ExtensionName() string {
+	return "NoIntrospection"
+}
+
+func (noIntrospection) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (noIntrospection) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	for _, field := range graphql.CollectFields(opCtx, opCtx.Operation.SelectionSet, nil) {
+		if field.Name == "__schema" || field.Name == "__type" {
+			err := gqlerror.ErrorPosf(field.Position, "introspection is not supported")
+			errcode.Set(err, errcode.ValidationFailed)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// A schema with a cycle, which api/schema.graphqls does not have yet
+var cyclicSchema = gqlparser.MustLoadSchema(&ast.Source{Input: `
+	type Query { payment: Payment }
+	type Payment { id: ID! group: Group }
+	type Group { id: ID! parts: [Payment!]! }
+`})
+
+func TestDepthLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		rejected bool
+	}{
+		{"at the limit", `{ payment { group { parts { group { id } } } } }`, false},
+		{"past the limit", `{ payment { group { parts { group { parts { id } } } } } }`, true},
+		{"past the limit through a fragment", `
+			{ payment { group { ...Parts } } }
+			fragment Parts on Group { parts { group { parts { id } } } }`, true},
+		{"past the limit through an inline fragment", `
+			{ payment { group { ... on Group { parts { group { parts { id } } } } } } }`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, errs := gqlparser.LoadQuery(cyclicSchema, tt.query)
+			require.Empty(t, errs)
+			opCtx := &graphql.OperationContext{Doc: doc, Operation: doc.Operations[0]}
+
+			err := depthLimit(5).MutateOperationContext(context.Background(), opCtx)
+
+			if !tt.rejected {
+				assert.Nil(t, err)
+				return
+			}
+			require.NotNil(t, err)
+			assert.Contains(t, err.Message, "nested 6 fields deep, which exceeds the limit of 5")
+		})
+	}
+}
@@ -0,0 +1,230 @@
+// Package graphql answers the support dashboard's read-only queries. The executor is
+// generated by gqlgen from api/schema.graphqls; see api/cfg/gqlgen.yaml.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	// maxPaymentsPerQuery caps how many payments one list field returns, since each may
+	// have its events and operations fetched as well
+	maxPaymentsPerQuery = 100
+	// defaultPaymentsPerQuery is the limit of paymentsByCustomer when none is given
+	defaultPaymentsPerQuery = 10
+	// maxDepth is how deeply selections may nest. The schema has no cycles today, so
+	// this only bites once a type links back to another.
+	maxDepth = 5
+	// maxComplexity bounds the fields one query may resolve, counting each field of a
+	// listed payment once per payment the limit asks for
+	maxComplexity = 2000
+)
+
+type PaymentReader interface {
+	FindByID(ctx context.Context, id string) (*domain.Payment, error)
+	FindByOrderID(ctx context.Context, orderID string) (*domain.Payment, error)
+	FindByCustomerID(ctx context.Context, customerID string, filter domain.CustomerPaymentFilter, limit, offset int) ([]*domain.Payment, error)
+}
+
+type OperationReader interface {
+	FindByPaymentID(ctx context.Context, paymentID string) ([]*domain.Operation, error)
+	FindRefundByID(ctx context.Context, id string) (*domain.Operation, error)
+}
+
+type EventReader interface {
+	FindByPaymentID(ctx context.Context, paymentID string) ([]*domain.TransitionEvent, error)
+}
+
+// QueryError is an error whose message is meant for the client, such as an argument
+// out of range. Other errors from resolvers are logged and reported as internal.
+type QueryError struct {
+	Message string
+}
+
+func (e *QueryError) Error() string {
+	return e.Message
+}
+
+// Schema answers queries from the Query type of api/schema.graphqls. It has no
+// mutations, and introspection is off.
+type Schema struct {
+	exec *executor.Executor
+}
+
+// NewPaymentSchema builds the schema the support dashboard queries: payments by ID,
+// order or customer, and refunds by ID, each payment with its status changes and the
+// captures, voids and refunds requested against it. Everything is scoped to the
+// merchant in the context.
+func NewPaymentSchema(payments PaymentReader, operations OperationReader, events EventReader, logger *slog.Logger) *Schema {
+	cfg := Config{Resolvers: &resolver{payments: payments, operations: operations, events: events}}
+	cfg.Complexity.Query.PaymentsByCustomer = func(childComplexity int, _ string, limit *int, _ *int) int {
+		n := defaultPaymentsPerQuery
+		if limit != nil {
+			n = max(1, min(*limit, maxPaymentsPerQuery))
+		}
+		return 1 + n*childComplexity
+	}
+
+	exec := executor.New(NewExecutableSchema(cfg))
+	exec.Use(extension.FixedComplexityLimit(maxComplexity))
+	exec.Use(depthLimit(maxDepth))
+	exec.Use(noIntrospection{})
+	exec.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+		presented := graphql.DefaultErrorPresenter(ctx, err)
+		var queryErr *QueryError
+		if errors.As(err, &queryErr) {
+			return presented
+		}
+		logger.Error("graphql field failed", "path", presented.Path.String(), "error", err)
+		presented.Message = "internal error"
+		return presented
+	})
+	return &Schema{exec: exec}
+}
+
+type resolver struct {
+	payments   PaymentReader
+	operations OperationReader
+	events     EventReader
+}
+
+func (r *resolver) Query() QueryResolver               { return queryResolver{r} }
+func (r *resolver) Payment() PaymentResolver           { return paymentResolver{r} }
+func (r *resolver) PaymentEvent() PaymentEventResolver { return eventResolver{} }
+func (r *resolver) Operation() OperationResolver       { return operationResolver{} }
+
+type queryResolver struct{ *resolver }
+
+func (r queryResolver) Payment(ctx context.Context, id string) (*domain.Payment, error) {
+	if uuid.Validate(id) != nil {
+		return nil, nil
+	}
+	return orNull(r.payments.FindByID(ctx, id))
+}
+
+func (r queryResolver) PaymentByOrder(ctx context.Context, orderID string) (*domain.Payment, error) {
+	return orNull(r.payments.FindByOrderID(ctx, orderID))
+}
+
+func (r queryResolver) PaymentsByCustomer(ctx context.Context, customerID string, limit, offset *int) ([]*domain.Payment, error) {
+	n, skip := defaultPaymentsPerQuery, 0
+	if limit != nil {
+		n = *limit
+	}
+	if offset != nil {
+		skip = *offset
+	}
+	if n < 1 || n > maxPaymentsPerQuery {
+		return nil, &QueryError{Message: fmt.Sprintf("limit must be between 1 and %d", maxPaymentsPerQuery)}
+	}
+	if skip < 0 {
+		return nil, &QueryError{Message: "offset must not be negative"}
+	}
+	return r.payments.FindByCustomerID(ctx, customerID, domain.CustomerPaymentFilter{}, n, skip)
+}
+
+func (r queryResolver) Refund(ctx context.Context, id string) (*domain.Operation, error) {
+	if uuid.Validate(id) != nil {
+		return nil, nil
+	}
+	return orNull(r.operations.FindRefundByID(ctx, id))
+}
+
+type paymentResolver struct{ *resolver }
+
+func (paymentResolver) Amount(_ context.Context, p *domain.Payment) (string, error) {
+	return p.Amount().FormatAmount(), nil
+}
+
+func (paymentResolver) Status(_ context.Context, p *domain.Payment) (string, error) {
+	return string(p.Status), nil
+}
+
+func (paymentResolver) DeclineCategory(_ context.Context, p *domain.Payment) (*string, error) {
+	return optional(p.DeclineCategory), nil
+}
+
+func (r paymentResolver) Events(ctx context.Context, p *domain.Payment) ([]*domain.TransitionEvent, error) {
+	return r.events.FindByPaymentID(ctx, p.ID)
+}
+
+func (r paymentResolver) Operations(ctx context.Context, p *domain.Payment) ([]*domain.Operation, error) {
+	return r.operations.FindByPaymentID(ctx, p.ID)
+}
+
+func (r paymentResolver) Refunds(ctx context.Context, p *domain.Payment) ([]*domain.Operation, error) {
+	found, err := r.operations.FindByPaymentID(ctx, p.ID)
+	if err != nil {
+		return nil, err
+	}
+	refunds := make([]*domain.Operation, 0, len(found))
+	for _, op := range found {
+		if op.Type == domain.OperationRefund {
+			refunds = append(refunds, op)
+		}
+	}
+	return refunds, nil
+}
+
+type eventResolver struct{}
+
+func (eventResolver) FromStatus(_ context.Context, e *domain.TransitionEvent) (*string, error) {
+	return optional(e.FromStatus), nil
+}
+
+func (eventResolver) ToStatus(_ context.Context, e *domain.TransitionEvent) (string, error) {
+	return string(e.ToStatus), nil
+}
+
+// Actor is null for events recorded before actors were
+func (eventResolver) Actor(_ context.Context, e *domain.TransitionEvent) (*string, error) {
+	if e.Actor == "" {
+		return nil, nil
+	}
+	return optional(&e.Actor), nil
+}
+
+type operationResolver struct{}
+
+func (operationResolver) Type(_ context.Context, o *domain.Operation) (string, error) {
+	return string(o.Type), nil
+}
+
+func (operationResolver) Status(_ context.Context, o *domain.Operation) (string, error) {
+	return string(o.Status), nil
+}
+
+func (operationResolver) Reason(_ context.Context, o *domain.Operation) (*string, error) {
+	return optional(o.Reason), nil
+}
+
+// optional turns a pointer to one of the domain's string types into the *string the
+// schema's String fields are resolved with
+func optional[T ~string](p *T) *string {
+	if p == nil {
+		return nil
+	}
+	s := string(*p)
+	return &s
+}
+
+// orNull answers a lookup of something that does not exist, or belongs to another
+// merchant, with null rather than an error. IDs that are not UUIDs are answered the
+// same way before reaching the database.
+func orNull[T any](found *T, err error) (*T, error) {
+	if errors.Is(err, postgres.ErrPaymentNotFound) || errors.Is(err, postgres.ErrRefundNotFound) {
+		return nil, nil
+	}
+	return found, err
+}
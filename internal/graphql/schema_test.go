@@ -0,0 +1,264 @@
+package graphql_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/graphql"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const paymentID = "550e8400-e29b-41d4-a716-446655440000"
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+type fakePayments struct {
+	payments     map[string]*domain.Payment
+	customerErr  error
+	customerArgs []int
+}
+
+func (f *fakePayments) FindByID(_ context.Context, id string) (*domain.Payment, error) {
+	if p, ok := f.payments[id]; ok {
+		return p, nil
+	}
+	return nil, postgres.ErrPaymentNotFound
+}
+
+func (f *fakePayments) FindByOrderID(_ context.Context, orderID string) (*domain.Payment, error) {
+	for _, p := range f.payments {
+		if p.OrderID == orderID {
+			return p, nil
+		}
+	}
+	return nil, postgres.ErrPaymentNotFound
+}
+
+func (f *fakePayments) FindByCustomerID(_ context.Context, _ string, _ domain.CustomerPaymentFilter, limit, offset int) ([]*domain.Payment, error) {
+	f.customerArgs = []int{limit, offset}
+	if f.customerErr != nil {
+		return nil, f.customerErr
+	}
+	return []*domain.Payment{f.payments[paymentID]}, nil
+}
+
+type fakeOperations struct{ ops []*domain.Operation }
+
+func (f fakeOperations) FindByPaymentID(context.Context, string) ([]*domain.Operation, error) {
+	return f.ops, nil
+}
+
+func (f fakeOperations) FindRefundByID(context.Context, string) (*domain.Operation, error) {
+	return nil, postgres.ErrRefundNotFound
+}
+
+type fakeEvents struct{ events []*domain.TransitionEvent }
+
+func (f fakeEvents) FindByPaymentID(context.Context, string) ([]*domain.TransitionEvent, error) {
+	return f.events, nil
+}
+
+func newSchema() (*graphql.Schema, *fakePayments) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	pending := domain.StatusPending
+	payments := &fakePayments{payments: map[string]*domain.Payment{
+		paymentID: {
+			ID: paymentID, OrderID: "order-1", CustomerID: "cust-1", AmountCents: 5000,
+			Currency: "USD", Status: domain.StatusCaptured, CreatedAt: at, CapturedAt: &at,
+		},
+	}}
+	operations := fakeOperations{ops: []*domain.Operation{
+		{ID: "op-1", PaymentID: paymentID, Type: domain.OperationCapture, Status: domain.OperationSucceeded, AmountCents: 5000},
+		{ID: "op-2", PaymentID: paymentID, Type: domain.OperationRefund, Status: domain.OperationPending, AmountCents: 1000},
+	}}
+	events := fakeEvents{events: []*domain.TransitionEvent{
+		{ID: "ev-1", EventType: "payment.authorized", FromStatus: &pending, ToStatus: domain.StatusAuthorized,
+			Actor: domain.ActorRetryWorker, AttemptCount: 2, OccurredAt: at},
+	}}
+	return graphql.NewPaymentSchema(payments, operations, events, discard), payments
+}
+
+func query(t *testing.T, s *graphql.Schema, req graphql.Request) (int, string) {
+	t.Helper()
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+	return post(t, s, string(body))
+}
+
+func post(t *testing.T, s *graphql.Schema, body string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+	return rec.Code, strings.TrimSpace(rec.Body.String())
+}
+
+func TestSchema_NestedPaymentQuery(t *testing.T) {
+	s, _ := newSchema()
+
+	code, body := query(t, s, graphql.Request{
+		Query: `query Support($id: ID!) {
+			p: payment(id: $id) { id status amount capturedAt voidedAt
+				events { type fromStatus toStatus actor attemptCount }
+				refunds { id amountCents status }
+			}
+		}`,
+		Variables: map[string]any{"id": paymentID},
+	})
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"data": {"p": {
+		"id": "`+paymentID+`", "status": "CAPTURED", "amount": "50.00 USD", "capturedAt": "2026-03-01T12:00:00Z", "voidedAt": null,
+		"events": [{"type": "payment.authorized", "fromStatus": "PENDING", "toStatus": "AUTHORIZED", "actor": "retry_worker", "attemptCount": 2}],
+		"refunds": [{"id": "op-2", "amountCents": 1000, "status": "PENDING"}]
+	}}}`, body)
+	assert.True(t, strings.HasPrefix(body, `{"data":{"p":{"id":`), "fields keep the order they were selected in")
+}
+
+func TestSchema_UnknownPaymentIsNull(t *testing.T) {
+	s, _ := newSchema()
+
+	code, body := post(t, s, `{"query": "{ payment(id: \"7c9e6679-7425-40de-944b-e07fc1f90ae7\") { id } byOrder: paymentByOrder(orderId: \"nope\") { id } notAnID: payment(id: \"x\") { id } refund(id: \"`+paymentID+`\") { id } }"}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"data": {"payment": null, "byOrder": null, "notAnID": null, "refund": null}}`, body)
+}
+
+func TestSchema_ArgumentsAndDefaults(t *testing.T) {
+	s, payments := newSchema()
+
+	_, body := post(t, s, `{"query": "{ paymentsByCustomer(customerId: \"cust-1\") { orderId __typename } }"}`)
+	assert.JSONEq(t, `{"data": {"paymentsByCustomer": [{"orderId": "order-1", "__typename": "Payment"}]}}`, body)
+	assert.Equal(t, []int{10, 0}, payments.customerArgs)
+
+	_, body = post(t, s, `{"query": "query($n: Int) { paymentsByCustomer(customerId: \"cust-1\", limit: $n, offset: 5) { id } }", "variables": {"n": 25}}`)
+	assert.Contains(t, body, paymentID)
+	assert.Equal(t, []int{25, 5}, payments.customerArgs)
+}
+
+func TestSchema_FieldErrorsKeepTheRestOfTheQuery(t *testing.T) {
+	s, payments := newSchema()
+	payments.customerErr = errors.New("connection reset")
+
+	code, body := post(t, s, `{"query": "{ payment(id: \"`+paymentID+`\") { id } tooMany: paymentsByCustomer(customerId: \"c\", limit: 500) { id } broken: paymentsByCustomer(customerId: \"c\") { id } }"}`)
+
+	assert.Equal(t, http.StatusOK, code)
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []any           `json:"errors"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &resp))
+	assert.JSONEq(t, `{"payment": {"id": "`+paymentID+`"}, "tooMany": null, "broken": null}`, string(resp.Data))
+	// Top-level fields resolve concurrently, so their errors come in no set order
+	assert.ElementsMatch(t, []any{
+		map[string]any{"message": "limit must be between 1 and 100", "path": []any{"tooMany"}},
+		map[string]any{"message": "internal error", "path": []any{"broken"}},
+	}, resp.Errors)
+}
+
+func TestSchema_RejectsInvalidQueries(t *testing.T) {
+	s, _ := newSchema()
+
+	tests := map[string]struct {
+		body    string
+		message string
+	}{
+		"malformed body":      {`not json`, "request body must be a JSON object"},
+		"syntax error":        {`{"query": "{ payment(id: ) { id } }"}`, "Unexpected )"},
+		"unknown field":       {`{"query": "{ payment(id: \"x\") { cardNumber } }"}`, `Cannot query field \"cardNumber\" on type \"Payment\"`},
+		"missing argument":    {`{"query": "{ payment { id } }"}`, `argument \"id\" of type \"ID!\" is required`},
+		"unknown argument":    {`{"query": "{ payment(id: \"x\", merchant: \"m\") { id } }"}`, `Unknown argument \"merchant\"`},
+		"missing selection":   {`{"query": "{ payment(id: \"x\") }"}`, "must have a selection of subfields"},
+		"selection on scalar": {`{"query": "{ payment(id: \"x\") { id { x } } }"}`, "must not have a selection"},
+		"undeclared variable": {`{"query": "{ payment(id: $id) { id } }"}`, `Variable \"$id\" is not defined`},
+		"wrong variable type": {`{"query": "query($n: Int) { paymentsByCustomer(customerId: \"c\", limit: $n) { id } }", "variables": {"n": "ten"}}`, "cannot use string as Int"},
+		"mutation":            {`{"query": "mutation { payment(id: \"x\") { id } }"}`, `does not support operation type \"mutation\"`},
+		"unknown fragment":    {`{"query": "{ payment(id: \"x\") { ...F } }"}`, `Unknown fragment \"F\"`},
+		"conflicting aliases": {`{"query": "{ p: payment(id: \"x\") { id } p: refund(id: \"y\") { id } }"}`, "are different fields"},
+		"introspection":       {`{"query": "{ __schema { types { name } } }"}`, "introspection is not supported"},
+		"ambiguous operation": {`{"query": "query A { refund(id: \"x\") { id } } query B { refund(id: \"y\") { id } }"}`, "operation  not found"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, body := post(t, s, tt.body)
+
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.NotContains(t, body, `"data"`)
+			assert.Contains(t, body, tt.message)
+		})
+	}
+}
+
+func TestSchema_Fragments(t *testing.T) {
+	s, _ := newSchema()
+
+	_, body := query(t, s, graphql.Request{
+		Query: `query { payment(id: "` + paymentID + `") { ...Summary refunds { ... on Operation { id } } } }
+			fragment Summary on Payment { id status }`,
+	})
+
+	assert.JSONEq(t, `{"data": {"payment": {"id": "`+paymentID+`", "status": "CAPTURED", "refunds": [{"id": "op-2"}]}}}`, body)
+}
+
+func TestSchema_RejectsQueriesOverTheComplexityLimit(t *testing.T) {
+	s, _ := newSchema()
+
+	const everything = `id merchantId orderId customerId amountCents currency amount status acquirer
+		capturedAmountCents refundedAmountCents releasedAmountCents failureReason attemptCount nextRetryAt
+		lastErrorCategory declineCategory createdAt authorizedAt capturedAt voidedAt refundedAt expiresAt
+		events { id type fromStatus toStatus actor attemptCount occurredAt }
+		operations { id paymentId type status amountCents idempotencyKey reason bankReferenceId createdAt completedAt bankInitiated }
+		refunds { id paymentId type status amountCents idempotencyKey reason bankReferenceId createdAt completedAt bankInitiated }`
+
+	aliased := make([]string, 40)
+	for i := range aliased {
+		aliased[i] = fmt.Sprintf(`p%d: payment(id: "%s") { %s }`, i, paymentID, everything)
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		rejected bool
+	}{
+		{"a page of payments", `{ paymentsByCustomer(customerId: "cust-1") { ` + everything + ` } }`, false},
+		{"the largest page of payments", `{ paymentsByCustomer(customerId: "cust-1", limit: 100) { ` + everything + ` } }`, true},
+		{"the largest page of a few fields", `{ paymentsByCustomer(customerId: "cust-1", limit: 100) { id status amount } }`, false},
+		{"one payment aliased many times", `{ ` + strings.Join(aliased, " ") + ` }`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := query(t, s, graphql.Request{Query: tt.query})
+
+			if !tt.rejected {
+				assert.Equal(t, http.StatusOK, code, body)
+				return
+			}
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.Contains(t, body, "exceeds the limit of 2000")
+		})
+	}
+}
+
+func TestSchema_PicksOperationByName(t *testing.T) {
+	s, _ := newSchema()
+
+	_, body := query(t, s, graphql.Request{
+		Query:         `query A { a: refund(id: "x") { id } } query B { b: refund(id: "y") { id } }`,
+		OperationName: "B",
+	})
+
+	assert.JSONEq(t, `{"data": {"b": null}}`, body)
+}
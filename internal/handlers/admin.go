@@ -0,0 +1,682 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/logging"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+)
+
+func (h *Handlers) CreateDebugSession(
+	ctx context.Context,
+	request api.CreateDebugSessionRequestObject,
+) (api.CreateDebugSessionResponseObject, error) {
+	var paymentID string
+	if request.Body.PaymentId != uuid.Nil {
+		paymentID = request.Body.PaymentId.String()
+	}
+
+	session, err := domain.NewDebugSession(
+		uuid.New().String(),
+		paymentID,
+		request.Body.IdempotencyKey,
+		time.Duration(request.Body.TtlSeconds)*time.Second,
+		time.Now(),
+	)
+	if err != nil {
+		return mapCreateDebugSessionErrorToAPIResponse(application.NewInvalidInputError(err))
+	}
+
+	if err := h.debugRepo.Create(ctx, session); err != nil {
+		return mapCreateDebugSessionErrorToAPIResponse(err)
+	}
+
+	h.logger.Warn("bank traffic debug session started",
+		"session_id", session.ID,
+		"payment_id", session.PaymentID,
+		"idempotency_key", session.IdempotencyKey,
+		"expires_at", session.ExpiresAt)
+
+	apiSession, err := ToAPIDebugSession(session, nil)
+	if err != nil {
+		return mapCreateDebugSessionErrorToAPIResponse(err)
+	}
+
+	return api.CreateDebugSession201JSONResponse{
+		Success: true,
+		Data:    apiSession,
+	}, nil
+}
+
+func (h *Handlers) GetDebugSession(
+	ctx context.Context,
+	request api.GetDebugSessionRequestObject,
+) (api.GetDebugSessionResponseObject, error) {
+
+	session, err := h.debugRepo.FindByID(ctx, request.SessionID.String())
+	if err != nil {
+		return mapGetDebugSessionErrorToAPIResponse(err)
+	}
+
+	captures, err := h.debugRepo.FindCapturesBySessionID(ctx, session.ID)
+	if err != nil {
+		return mapGetDebugSessionErrorToAPIResponse(err)
+	}
+
+	apiSession, err := ToAPIDebugSession(session, captures)
+	if err != nil {
+		return mapGetDebugSessionErrorToAPIResponse(err)
+	}
+
+	return api.GetDebugSession200JSONResponse{
+		Success: true,
+		Data:    apiSession,
+	}, nil
+}
+
+func (h *Handlers) DeleteDebugSession(
+	ctx context.Context,
+	request api.DeleteDebugSessionRequestObject,
+) (api.DeleteDebugSessionResponseObject, error) {
+
+	if err := h.debugRepo.Delete(ctx, request.SessionID.String()); err != nil {
+		return mapDeleteDebugSessionErrorToAPIResponse(err)
+	}
+
+	return api.DeleteDebugSession204Response{}, nil
+}
+
+func (h *Handlers) GetAcquirers(
+	ctx context.Context,
+	request api.GetAcquirersRequestObject,
+) (api.GetAcquirersResponseObject, error) {
+
+	return api.GetAcquirers200JSONResponse{
+		Success: true,
+		Data:    h.acquirers(),
+	}, nil
+}
+
+func (h *Handlers) SetCanaryPercent(
+	ctx context.Context,
+	request api.SetCanaryPercentRequestObject,
+) (api.SetCanaryPercentResponseObject, error) {
+	if h.canaryRouter == nil {
+		return mapSetCanaryPercentErrorToAPIResponse(
+			application.NewInvalidStateError(errors.New("canary routing is not configured")),
+		)
+	}
+
+	if err := h.canaryRouter.SetPercent(request.Body.Percent); err != nil {
+		return mapSetCanaryPercentErrorToAPIResponse(application.NewInvalidInputError(err))
+	}
+
+	return api.SetCanaryPercent200JSONResponse{
+		Success: true,
+		Data:    h.acquirers(),
+	}, nil
+}
+
+func (h *Handlers) GetLogLevel(
+	ctx context.Context,
+	request api.GetLogLevelRequestObject,
+) (api.GetLogLevelResponseObject, error) {
+
+	return api.GetLogLevel200JSONResponse{
+		Success: true,
+		Data:    h.logLevel(),
+	}, nil
+}
+
+func (h *Handlers) SetLogLevel(
+	ctx context.Context,
+	request api.SetLogLevelRequestObject,
+) (api.SetLogLevelResponseObject, error) {
+	level, err := logging.ParseLevel(string(request.Body.Level))
+	if err != nil {
+		return mapSetLogLevelErrorToAPIResponse(application.NewInvalidInputError(err))
+	}
+
+	paymentIDs := make([]string, len(request.Body.DebugPaymentIds))
+	for i, id := range request.Body.DebugPaymentIds {
+		paymentIDs[i] = id.String()
+	}
+	if err := h.logControl.SetDebugPayments(paymentIDs); err != nil {
+		return mapSetLogLevelErrorToAPIResponse(application.NewInvalidInputError(err))
+	}
+	h.logControl.SetLevel(level)
+
+	// Logged at warn so the change shows up at any level
+	h.logger.Warn("log level changed", "level", level.String(), "debug_payment_ids", paymentIDs)
+
+	return api.SetLogLevel200JSONResponse{
+		Success: true,
+		Data:    h.logLevel(),
+	}, nil
+}
+
+func (h *Handlers) logLevel() api.LogLevel {
+	paymentIDs := h.logControl.DebugPayments()
+	apiPaymentIDs := make([]uuid.UUID, 0, len(paymentIDs))
+	for _, id := range paymentIDs {
+		if parsed, err := uuid.Parse(id); err == nil {
+			apiPaymentIDs = append(apiPaymentIDs, parsed)
+		}
+	}
+
+	return api.LogLevel{
+		Level:           api.LogLevelLevel(strings.ToLower(h.logControl.Level().String())),
+		DebugPaymentIds: apiPaymentIDs,
+	}
+}
+
+func (h *Handlers) GetClock(
+	ctx context.Context,
+	request api.GetClockRequestObject,
+) (api.GetClockResponseObject, error) {
+	if h.testClock == nil {
+		return mapGetClockErrorToAPIResponse(
+			application.NewInvalidStateError(errors.New("the test clock is not enabled")),
+		)
+	}
+
+	return api.GetClock200JSONResponse{
+		Success: true,
+		Data:    h.clock(h.testClock.Now()),
+	}, nil
+}
+
+func (h *Handlers) AdvanceClock(
+	ctx context.Context,
+	request api.AdvanceClockRequestObject,
+) (api.AdvanceClockResponseObject, error) {
+	if h.testClock == nil {
+		return mapAdvanceClockErrorToAPIResponse(
+			application.NewInvalidStateError(errors.New("the test clock is not enabled")),
+		)
+	}
+
+	now, err := h.testClock.Advance(time.Duration(request.Body.Seconds) * time.Second)
+	if err != nil {
+		return mapAdvanceClockErrorToAPIResponse(application.NewInvalidInputError(err))
+	}
+
+	// Logged at warn so that moving time shows up next to whatever it set off
+	h.logger.Warn("test clock advanced", "seconds", request.Body.Seconds, "now", now)
+
+	return api.AdvanceClock200JSONResponse{
+		Success: true,
+		Data:    h.clock(now),
+	}, nil
+}
+
+func (h *Handlers) clock(now time.Time) api.Clock {
+	return api.Clock{
+		Now:           now,
+		OffsetSeconds: int64(h.testClock.Offset() / time.Second),
+	}
+}
+
+func (h *Handlers) GetRegion(
+	ctx context.Context,
+	request api.GetRegionRequestObject,
+) (api.GetRegionResponseObject, error) {
+
+	return api.GetRegion200JSONResponse{
+		Success: true,
+		Data:    ToAPIRegion(h.regions.State(ctx)),
+	}, nil
+}
+
+func (h *Handlers) PromoteRegion(
+	ctx context.Context,
+	request api.PromoteRegionRequestObject,
+) (api.PromoteRegionResponseObject, error) {
+	state, err := h.regions.Promote(ctx)
+	if err != nil {
+		return mapPromoteRegionErrorToAPIResponse(err)
+	}
+
+	// Logged at warn so a failover shows up at any level
+	h.logger.Warn("region promoted", "region", state.Region, "epoch", state.Epoch)
+
+	return api.PromoteRegion200JSONResponse{
+		Success: true,
+		Data:    ToAPIRegion(state),
+	}, nil
+}
+
+func (h *Handlers) GetOutbox(
+	ctx context.Context,
+	request api.GetOutboxRequestObject,
+) (api.GetOutboxResponseObject, error) {
+	status, err := h.outboxService.Status(ctx)
+	if err != nil {
+		return mapGetOutboxErrorToAPIResponse(err)
+	}
+
+	return api.GetOutbox200JSONResponse{
+		Success: true,
+		Data:    ToAPIOutboxStatus(status),
+	}, nil
+}
+
+func (h *Handlers) PauseOutbox(
+	ctx context.Context,
+	request api.PauseOutboxRequestObject,
+) (api.PauseOutboxResponseObject, error) {
+	var reason string
+	if request.Body != nil {
+		reason = request.Body.Reason
+	}
+
+	status, err := h.outboxService.Pause(ctx, reason)
+	if err != nil {
+		return mapPauseOutboxErrorToAPIResponse(err)
+	}
+
+	// Events stop reaching the notification service, so this shows at any level
+	h.logger.Warn("outbox delivery paused", "reason", status.Pause.Reason, "pending", status.Pending)
+
+	return api.PauseOutbox200JSONResponse{
+		Success: true,
+		Data:    ToAPIOutboxStatus(status),
+	}, nil
+}
+
+func (h *Handlers) ResumeOutbox(
+	ctx context.Context,
+	request api.ResumeOutboxRequestObject,
+) (api.ResumeOutboxResponseObject, error) {
+	status, err := h.outboxService.Resume(ctx)
+	if err != nil {
+		return mapResumeOutboxErrorToAPIResponse(err)
+	}
+
+	h.logger.Warn("outbox delivery resumed", "pending", status.Pending)
+
+	return api.ResumeOutbox200JSONResponse{
+		Success: true,
+		Data:    ToAPIOutboxStatus(status),
+	}, nil
+}
+
+// GetMerchantQuota and SetMerchantQuota act on the merchant named in the path rather
+// than the caller's, since quotas are set by whoever operates the gateway
+func (h *Handlers) GetMerchantQuota(
+	ctx context.Context,
+	request api.GetMerchantQuotaRequestObject,
+) (api.GetMerchantQuotaResponseObject, error) {
+	quota, err := h.merchantSettingsRepo.FindQuota(postgres.WithMerchant(ctx, request.MerchantID))
+	if err != nil {
+		return mapGetMerchantQuotaErrorToAPIResponse(err)
+	}
+
+	return api.GetMerchantQuota200JSONResponse{
+		Success: true,
+		Data:    ToAPIMerchantQuota(quota),
+	}, nil
+}
+
+func (h *Handlers) SetMerchantQuota(
+	ctx context.Context,
+	request api.SetMerchantQuotaRequestObject,
+) (api.SetMerchantQuotaResponseObject, error) {
+	if request.Body.DailyTransactionLimit < 0 || request.Body.DailyVolumeLimit < 0 {
+		return mapSetMerchantQuotaErrorToAPIResponse(application.NewInvalidInputError(domain.ErrInvalidAmount))
+	}
+
+	// Zero is how an omitted limit arrives, and means unlimited
+	var transactionLimit *int
+	if request.Body.DailyTransactionLimit > 0 {
+		transactionLimit = &request.Body.DailyTransactionLimit
+	}
+	var volumeLimit *int64
+	if request.Body.DailyVolumeLimit > 0 {
+		volumeLimit = &request.Body.DailyVolumeLimit
+	}
+
+	ctx = postgres.WithMerchant(ctx, request.MerchantID)
+	if err := h.merchantSettingsRepo.UpdateQuota(ctx, transactionLimit, volumeLimit); err != nil {
+		return mapSetMerchantQuotaErrorToAPIResponse(err)
+	}
+
+	h.logger.Info("merchant quota updated",
+		"merchant_id", request.MerchantID,
+		"daily_transaction_limit", request.Body.DailyTransactionLimit,
+		"daily_volume_limit", request.Body.DailyVolumeLimit)
+
+	quota, err := h.merchantSettingsRepo.FindQuota(ctx)
+	if err != nil {
+		return mapSetMerchantQuotaErrorToAPIResponse(err)
+	}
+
+	return api.SetMerchantQuota200JSONResponse{
+		Success: true,
+		Data:    ToAPIMerchantQuota(quota),
+	}, nil
+}
+
+func (h *Handlers) ListFeatureFlags(
+	ctx context.Context,
+	request api.ListFeatureFlagsRequestObject,
+) (api.ListFeatureFlagsResponseObject, error) {
+	flags, err := h.featureFlags.List(ctx)
+	if err != nil {
+		return mapListFeatureFlagsErrorToAPIResponse(err)
+	}
+
+	apiFlags := make([]api.FeatureFlag, 0, len(flags))
+	for _, flag := range flags {
+		apiFlags = append(apiFlags, ToAPIFeatureFlag(flag))
+	}
+
+	return api.ListFeatureFlags200JSONResponse{
+		Success: true,
+		Data:    apiFlags,
+	}, nil
+}
+
+func (h *Handlers) SetFeatureFlag(
+	ctx context.Context,
+	request api.SetFeatureFlagRequestObject,
+) (api.SetFeatureFlagResponseObject, error) {
+	flag, err := h.featureFlags.SetPercent(ctx, request.Flag, request.Body.Percent)
+	if err != nil {
+		return mapSetFeatureFlagErrorToAPIResponse(err)
+	}
+
+	// Logged at warn so the change shows up at any level
+	h.logger.Warn("feature flag rollout changed", "flag", flag.Name, "percent", flag.Percent)
+
+	return api.SetFeatureFlag200JSONResponse{
+		Success: true,
+		Data:    ToAPIFeatureFlag(flag),
+	}, nil
+}
+
+// SetFeatureFlagOverride and DeleteFeatureFlagOverride act on the merchant named in the
+// path, like the quota endpoints
+func (h *Handlers) SetFeatureFlagOverride(
+	ctx context.Context,
+	request api.SetFeatureFlagOverrideRequestObject,
+) (api.SetFeatureFlagOverrideResponseObject, error) {
+	flag, err := h.featureFlags.SetOverride(ctx, request.Flag, request.MerchantID, request.Body.Enabled)
+	if err != nil {
+		return mapSetFeatureFlagOverrideErrorToAPIResponse(err)
+	}
+
+	h.logger.Warn("feature flag overridden",
+		"flag", flag.Name,
+		"merchant_id", request.MerchantID,
+		"enabled", request.Body.Enabled)
+
+	return api.SetFeatureFlagOverride200JSONResponse{
+		Success: true,
+		Data:    ToAPIFeatureFlag(flag),
+	}, nil
+}
+
+func (h *Handlers) DeleteFeatureFlagOverride(
+	ctx context.Context,
+	request api.DeleteFeatureFlagOverrideRequestObject,
+) (api.DeleteFeatureFlagOverrideResponseObject, error) {
+	flag, err := h.featureFlags.DeleteOverride(ctx, request.Flag, request.MerchantID)
+	if err != nil {
+		return mapDeleteFeatureFlagOverrideErrorToAPIResponse(err)
+	}
+
+	h.logger.Warn("feature flag override removed", "flag", flag.Name, "merchant_id", request.MerchantID)
+
+	return api.DeleteFeatureFlagOverride200JSONResponse{
+		Success: true,
+		Data:    ToAPIFeatureFlag(flag),
+	}, nil
+}
+
+func (h *Handlers) EraseCustomer(
+	ctx context.Context,
+	request api.EraseCustomerRequestObject,
+) (api.EraseCustomerResponseObject, error) {
+	erasure, err := h.erasureService.Erase(ctx, request.CustomerID)
+	if err != nil {
+		return mapEraseCustomerErrorToAPIResponse(err)
+	}
+
+	// The customer ID is deliberately not logged
+	h.logger.Info("customer erased",
+		"erasure_id", erasure.ID,
+		"merchant_id", erasure.MerchantID,
+		"customer_token", erasure.CustomerToken,
+		"payments_anonymized", erasure.PaymentsAnonymized,
+		"payments_retained", erasure.PaymentsRetained)
+
+	apiErasure, err := ToAPIErasure(erasure)
+	if err != nil {
+		return mapEraseCustomerErrorToAPIResponse(err)
+	}
+
+	return api.EraseCustomer201JSONResponse{
+		Success: true,
+		Data:    apiErasure,
+	}, nil
+}
+
+// acquirers reports the routing state; without canary routing there is nothing to report
+func (h *Handlers) acquirers() api.Acquirers {
+	result := api.Acquirers{Acquirers: []api.AcquirerStats{}}
+	if h.canaryRouter == nil {
+		return result
+	}
+
+	result.CanaryPercent = h.canaryRouter.Percent()
+	stats := h.canaryRouter.Stats()
+	for _, name := range slices.Sorted(maps.Keys(stats)) {
+		s := stats[name]
+		result.Acquirers = append(result.Acquirers, api.AcquirerStats{
+			Name:        name,
+			Requests:    s.Requests,
+			Succeeded:   s.Succeeded,
+			Declined:    s.Declined,
+			Errored:     s.Errored,
+			SuccessRate: s.SuccessRate(),
+		})
+	}
+	return result
+}
+
+func mapCreateDebugSessionErrorToAPIResponse(err error) (api.CreateDebugSessionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateDebugSession400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreateDebugSession404JSONResponse(errorResponse), nil
+	default:
+		return api.CreateDebugSession500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetDebugSessionErrorToAPIResponse(err error) (api.GetDebugSessionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetDebugSession404JSONResponse(errorResponse), nil
+	default:
+		return api.GetDebugSession500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapDeleteDebugSessionErrorToAPIResponse(err error) (api.DeleteDebugSessionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.DeleteDebugSession404JSONResponse(errorResponse), nil
+	default:
+		return api.DeleteDebugSession500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapSetCanaryPercentErrorToAPIResponse(err error) (api.SetCanaryPercentResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.SetCanaryPercent400JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.SetCanaryPercent409JSONResponse(errorResponse), nil
+	default:
+		return api.SetCanaryPercent500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapSetLogLevelErrorToAPIResponse(err error) (api.SetLogLevelResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.SetLogLevel400JSONResponse(errorResponse), nil
+	default:
+		return api.SetLogLevel500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetClockErrorToAPIResponse(err error) (api.GetClockResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusConflict:
+		return api.GetClock409JSONResponse(errorResponse), nil
+	default:
+		return api.GetClock500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapAdvanceClockErrorToAPIResponse(err error) (api.AdvanceClockResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.AdvanceClock400JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.AdvanceClock409JSONResponse(errorResponse), nil
+	default:
+		return api.AdvanceClock500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetMerchantQuotaErrorToAPIResponse(err error) (api.GetMerchantQuotaResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetMerchantQuota404JSONResponse(errorResponse), nil
+	default:
+		return api.GetMerchantQuota500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapSetMerchantQuotaErrorToAPIResponse(err error) (api.SetMerchantQuotaResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.SetMerchantQuota400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.SetMerchantQuota404JSONResponse(errorResponse), nil
+	default:
+		return api.SetMerchantQuota500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapEraseCustomerErrorToAPIResponse(err error) (api.EraseCustomerResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.EraseCustomer400JSONResponse(errorResponse), nil
+	default:
+		return api.EraseCustomer500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapListFeatureFlagsErrorToAPIResponse(err error) (api.ListFeatureFlagsResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.ListFeatureFlags500JSONResponse(errorResponse), nil
+}
+
+func mapSetFeatureFlagErrorToAPIResponse(err error) (api.SetFeatureFlagResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.SetFeatureFlag400JSONResponse(errorResponse), nil
+	default:
+		return api.SetFeatureFlag500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapSetFeatureFlagOverrideErrorToAPIResponse(err error) (api.SetFeatureFlagOverrideResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.SetFeatureFlagOverride400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.SetFeatureFlagOverride404JSONResponse(errorResponse), nil
+	default:
+		return api.SetFeatureFlagOverride500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapDeleteFeatureFlagOverrideErrorToAPIResponse(err error) (api.DeleteFeatureFlagOverrideResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.DeleteFeatureFlagOverride400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.DeleteFeatureFlagOverride404JSONResponse(errorResponse), nil
+	default:
+		return api.DeleteFeatureFlagOverride500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapPromoteRegionErrorToAPIResponse(err error) (api.PromoteRegionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusConflict:
+		return api.PromoteRegion409JSONResponse(errorResponse), nil
+	default:
+		return api.PromoteRegion500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetOutboxErrorToAPIResponse(err error) (api.GetOutboxResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetOutbox500JSONResponse(errorResponse), nil
+}
+
+func mapPauseOutboxErrorToAPIResponse(err error) (api.PauseOutboxResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.PauseOutbox500JSONResponse(errorResponse), nil
+}
+
+func mapResumeOutboxErrorToAPIResponse(err error) (api.ResumeOutboxResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.ResumeOutbox500JSONResponse(errorResponse), nil
+}
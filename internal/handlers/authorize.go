@@ -6,6 +6,7 @@ import (
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 )
 
 func (h *Handlers) AuthorizePayment(
@@ -25,6 +26,20 @@ func (h *Handlers) AuthorizePayment(
 		CVV:         req.Cvv,
 		ExpiryMonth: req.ExpiryMonth,
 		ExpiryYear:  req.ExpiryYear,
+		NetworkToken: domain.NetworkToken{
+			Number:     req.NetworkToken.Number,
+			Cryptogram: req.NetworkToken.Cryptogram,
+			ECI:        req.NetworkToken.Eci,
+		},
+		Category: req.Category,
+	}
+
+	// The answer shows a soft-declined payment as PENDING while it is retried
+	ctx = services.AllowSoftDeclineRetry(ctx)
+
+	// Merchants the feature has not reached yet are answered once the bank has
+	if request.Params.Async && h.featureFlags.Enabled(ctx, domain.FlagAsyncAuthorize) {
+		return h.authorizeAsync(ctx, &cmd, idempotencyKey)
 	}
 
 	payment, err := h.authService.Authorize(ctx, &cmd, idempotencyKey)
@@ -37,12 +52,67 @@ func (h *Handlers) AuthorizePayment(
 		return mapAuthServiceErrorToAPIResponse(err)
 	}
 
+	if payment.Status == domain.StatusReview || payment.Status == domain.StatusPending {
+		return authorizeAccepted(payment.ID, apiPayment), nil
+	}
+
 	return api.AuthorizePayment201JSONResponse{
 		Success: true,
 		Data:    apiPayment,
 	}, nil
 }
 
+// authorizeAsync stores the PENDING payment and hands the bank call to the
+// authorize worker, answering with 202 and the URL to poll.
+func (h *Handlers) authorizeAsync(
+	ctx context.Context,
+	cmd *services.AuthorizeCommand,
+	idempotencyKey string,
+) (api.AuthorizePaymentResponseObject, error) {
+	payment, started, err := h.authService.BeginAuthorize(ctx, cmd, idempotencyKey)
+	if err != nil {
+		return mapAuthServiceErrorToAPIResponse(err)
+	}
+
+	// Convert before submitting: the worker mutates the payment once it picks up the job.
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapAuthServiceErrorToAPIResponse(err)
+	}
+
+	if started && !h.authorizeWorker.Submit(payment, *cmd, idempotencyKey) {
+		h.logger.Warn("authorize queue full, authorizing synchronously", "payment_id", payment.ID)
+
+		payment, err = h.authService.CompleteAuthorize(ctx, payment, cmd, idempotencyKey)
+		if err != nil {
+			return mapAuthServiceErrorToAPIResponse(err)
+		}
+
+		apiPayment, err = ToAPIPayment(payment)
+		if err != nil {
+			return mapAuthServiceErrorToAPIResponse(err)
+		}
+	}
+
+	return authorizeAccepted(payment.ID, apiPayment), nil
+}
+
+// authorizeAccepted answers with a payment the bank has not decided on yet, because
+// the worker is still calling it, the payment is held for review or a soft decline is
+// being retried
+func authorizeAccepted(paymentID string, apiPayment api.Payment) api.AuthorizePayment202JSONResponse {
+	return api.AuthorizePayment202JSONResponse{
+		Body: api.PaymentResponse{
+			Success: true,
+			Data:    apiPayment,
+		},
+		Headers: api.AuthorizePayment202ResponseHeaders{
+			Location:   paymentURL(paymentID),
+			RetryAfter: retryAfterSeconds,
+		},
+	}
+}
+
 func mapAuthServiceErrorToAPIResponse(err error) (api.AuthorizePaymentResponseObject, error) {
 	statusCode, errorResponse := BuildErrorResponse(err)
 
@@ -56,6 +126,9 @@ func mapAuthServiceErrorToAPIResponse(err error) (api.AuthorizePaymentResponseOb
 	case http.StatusConflict:
 		return api.AuthorizePayment409JSONResponse(errorResponse), nil
 
+	case http.StatusTooManyRequests:
+		return api.AuthorizePayment429JSONResponse(errorResponse), nil
+
 	case http.StatusInternalServerError:
 		return api.AuthorizePayment500JSONResponse(errorResponse), nil
 
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+func (h *Handlers) CreateRefundBatch(
+	ctx context.Context,
+	request api.CreateRefundBatchRequestObject,
+) (api.CreateRefundBatchResponseObject, error) {
+	req := request.Body
+
+	cmd := services.CreateRefundBatchCommand{
+		Reason: domain.OperationReason(req.Reason),
+		Items:  make([]services.RefundBatchItem, 0, len(req.Items)),
+	}
+	for _, item := range req.Items {
+		cmd.Items = append(cmd.Items, services.RefundBatchItem{
+			PaymentID: item.PaymentId.String(),
+			Amount:    item.Amount,
+		})
+	}
+
+	batch, err := h.batchService.CreateRefundBatch(ctx, &cmd, request.Params.IdempotencyKey)
+	if err != nil {
+		return mapCreateRefundBatchErrorToAPIResponse(err)
+	}
+
+	apiBatch, err := ToAPIBatch(batch)
+	if err != nil {
+		return mapCreateRefundBatchErrorToAPIResponse(err)
+	}
+
+	return api.CreateRefundBatch202JSONResponse{
+		Success: true,
+		Data:    apiBatch,
+	}, nil
+}
+
+func (h *Handlers) CreateVoidBatch(
+	ctx context.Context,
+	request api.CreateVoidBatchRequestObject,
+) (api.CreateVoidBatchResponseObject, error) {
+	req := request.Body
+
+	cmd := services.CreateVoidBatchCommand{
+		Reason:           domain.OperationReason(req.Reason),
+		OrderIDs:         req.OrderIds,
+		CustomerID:       req.CustomerId,
+		AuthorizedBefore: req.AuthorizedBefore,
+	}
+
+	batch, err := h.batchService.CreateVoidBatch(ctx, &cmd, request.Params.IdempotencyKey)
+	if err != nil {
+		return mapCreateVoidBatchErrorToAPIResponse(err)
+	}
+
+	apiBatch, err := ToAPIBatch(batch)
+	if err != nil {
+		return mapCreateVoidBatchErrorToAPIResponse(err)
+	}
+
+	return api.CreateVoidBatch202JSONResponse{
+		Success: true,
+		Data:    apiBatch,
+	}, nil
+}
+
+func (h *Handlers) GetBatch(
+	ctx context.Context,
+	request api.GetBatchRequestObject,
+) (api.GetBatchResponseObject, error) {
+	batch, err := h.batchService.Get(ctx, request.BatchID.String())
+	if err != nil {
+		return mapGetBatchErrorToAPIResponse(err)
+	}
+
+	apiBatch, err := ToAPIBatch(batch)
+	if err != nil {
+		return mapGetBatchErrorToAPIResponse(err)
+	}
+
+	return api.GetBatch200JSONResponse{
+		Success: true,
+		Data:    apiBatch,
+	}, nil
+}
+
+func mapCreateRefundBatchErrorToAPIResponse(err error) (api.CreateRefundBatchResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateRefundBatch400JSONResponse(errorResponse), nil
+	default:
+		return api.CreateRefundBatch500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapCreateVoidBatchErrorToAPIResponse(err error) (api.CreateVoidBatchResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateVoidBatch400JSONResponse(errorResponse), nil
+	default:
+		return api.CreateVoidBatch500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetBatchErrorToAPIResponse(err error) (api.GetBatchResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetBatch404JSONResponse(errorResponse), nil
+	default:
+		return api.GetBatch500JSONResponse(errorResponse), nil
+	}
+}
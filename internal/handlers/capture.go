@@ -15,7 +15,7 @@ func (h *Handlers) CapturePayment(
 	idempotencyKey := request.Params.IdempotencyKey
 
 	paymentID := req.PaymentId.String()
-	payment, err := h.captureService.Capture(ctx, paymentID, idempotencyKey)
+	payment, err := h.captureService.Capture(ctx, paymentID, req.Amount, idempotencyKey)
 	if err != nil {
 		return mapCaptureServiceErrorToAPIResponse(err)
 	}
@@ -43,6 +43,8 @@ func mapCaptureServiceErrorToAPIResponse(err error) (api.CapturePaymentResponseO
 		return api.CapturePayment408JSONResponse(errorResponse), nil
 	case http.StatusConflict:
 		return api.CapturePayment409JSONResponse(errorResponse), nil
+	case http.StatusTooManyRequests:
+		return api.CapturePayment429JSONResponse(errorResponse), nil
 	case http.StatusInternalServerError:
 		return api.CapturePayment500JSONResponse(errorResponse), nil
 	default:
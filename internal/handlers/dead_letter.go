@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+const (
+	defaultDeadLetters = 100
+	maxDeadLetters     = 500
+)
+
+func (h *Handlers) GetDeadLetters(
+	ctx context.Context,
+	request api.GetDeadLettersRequestObject,
+) (api.GetDeadLettersResponseObject, error) {
+	limit := request.Params.Limit
+	if limit <= 0 {
+		limit = defaultDeadLetters
+	}
+	limit = min(limit, maxDeadLetters)
+
+	listed, err := h.deadLetterService.List(ctx, limit)
+	if err != nil {
+		return mapGetDeadLettersErrorToAPIResponse(err)
+	}
+
+	apiDeadLetters, err := ToAPIDeadLetters(listed)
+	if err != nil {
+		return mapGetDeadLettersErrorToAPIResponse(err)
+	}
+
+	return api.GetDeadLetters200JSONResponse{
+		Success: true,
+		Data:    apiDeadLetters,
+	}, nil
+}
+
+func (h *Handlers) RequeueDeadLetter(
+	ctx context.Context,
+	request api.RequeueDeadLetterRequestObject,
+) (api.RequeueDeadLetterResponseObject, error) {
+	payment, err := h.deadLetterService.Requeue(ctx, request.PaymentID.String())
+	if err != nil {
+		return mapRequeueDeadLetterErrorToAPIResponse(err)
+	}
+
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapRequeueDeadLetterErrorToAPIResponse(err)
+	}
+
+	return api.RequeueDeadLetter200JSONResponse{
+		Success: true,
+		Data:    apiPayment,
+	}, nil
+}
+
+func (h *Handlers) ReplayPayments(
+	ctx context.Context,
+	request api.ReplayPaymentsRequestObject,
+) (api.ReplayPaymentsResponseObject, error) {
+	filter := domain.ReplayFilter{Status: domain.PaymentStatus(request.Body.Status)}
+	for _, id := range request.Body.PaymentIds {
+		filter.PaymentIDs = append(filter.PaymentIDs, id.String())
+	}
+
+	replayed, err := h.deadLetterService.Replay(ctx, filter)
+	if err != nil {
+		return mapReplayPaymentsErrorToAPIResponse(err)
+	}
+
+	apiPayments, err := ToAPIPayments(replayed)
+	if err != nil {
+		return mapReplayPaymentsErrorToAPIResponse(err)
+	}
+
+	return api.ReplayPayments200JSONResponse{
+		Success: true,
+		Data:    apiPayments,
+	}, nil
+}
+
+func mapGetDeadLettersErrorToAPIResponse(err error) (api.GetDeadLettersResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetDeadLetters500JSONResponse(errorResponse), nil
+}
+
+func mapRequeueDeadLetterErrorToAPIResponse(err error) (api.RequeueDeadLetterResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.RequeueDeadLetter404JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.RequeueDeadLetter500JSONResponse(errorResponse), nil
+	default:
+		return api.RequeueDeadLetter500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapReplayPaymentsErrorToAPIResponse(err error) (api.ReplayPaymentsResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.ReplayPayments400JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.ReplayPayments500JSONResponse(errorResponse), nil
+	default:
+		return api.ReplayPayments500JSONResponse(errorResponse), nil
+	}
+}
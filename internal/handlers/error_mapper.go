@@ -9,21 +9,24 @@ import (
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
 )
 
-// WriteError maps application errors to HTTP responses using OpenAPI-generated types
-func WriteError(w http.ResponseWriter, err error, logger *slog.Logger) {
-	statusCode := application.ToHTTPStatus(err)
-	errorCode := application.ToErrorCode(err)
-
-	response := api.ErrorResponse{
-		Success: false,
-		Error: struct {
-			Code    api.ErrorResponseErrorCode `json:"code"`
-			Message string                     `json:"message"`
-		}{
-			Code:    api.ErrorResponseErrorCode(errorCode),
-			Message: err.Error(),
+// StrictServerOptions answers requests the generated server cannot decode, and
+// responses it cannot encode, with the error body of every other failure instead of
+// plain text
+func StrictServerOptions(logger *slog.Logger) api.StrictHTTPServerOptions {
+	return api.StrictHTTPServerOptions{
+		RequestErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, err error) {
+			WriteError(w, application.NewInvalidBodyError(err), logger)
+		},
+		ResponseErrorHandlerFunc: func(w http.ResponseWriter, _ *http.Request, err error) {
+			logger.Error("failed to write response", "error", err)
+			WriteError(w, application.NewInternalError(err), logger)
 		},
 	}
+}
+
+// WriteError maps application errors to HTTP responses using OpenAPI-generated types
+func WriteError(w http.ResponseWriter, err error, logger *slog.Logger) {
+	statusCode, response := BuildErrorResponse(err)
 
 	body, marshalErr := json.Marshal(response)
 	if marshalErr != nil {
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+const (
+	eventPollInterval      = time.Second
+	eventHeartbeatInterval = 15 * time.Second
+)
+
+func (h *Handlers) GetPaymentEvents(
+	ctx context.Context,
+	request api.GetPaymentEventsRequestObject,
+) (api.GetPaymentEventsResponseObject, error) {
+
+	payment, err := h.paymentRepo.FindByID(ctx, request.PaymentID.String())
+	if err != nil {
+		return mapEventsErrorToAPIResponse(err)
+	}
+
+	return paymentEventStream{
+		ctx:         ctx,
+		paymentRepo: h.paymentRepo,
+		payment:     payment,
+		logger:      h.logger,
+	}, nil
+}
+
+// paymentEventStream writes status changes as Server-Sent Events. It is used instead
+// of the generated io.Reader response so each event is flushed as soon as it happens.
+type paymentEventStream struct {
+	ctx         context.Context
+	paymentRepo *postgres.PaymentRepository
+	payment     *domain.Payment
+	logger      *slog.Logger
+}
+
+func (s paymentEventStream) VisitGetPaymentEventsResponse(w http.ResponseWriter) error {
+	rc := http.NewResponseController(w)
+
+	// The stream is expected to outlive the server's write timeout
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	payment := s.payment
+	if err := writeStatusEvent(w, rc, payment); err != nil {
+		s.logger.Warn("payment event stream closed", "payment_id", payment.ID, "error", err)
+		return nil
+	}
+
+	poll := time.NewTicker(eventPollInterval)
+	defer poll.Stop()
+	heartbeat := time.NewTicker(eventHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for !payment.IsTerminal() {
+		select {
+		case <-s.ctx.Done():
+			return nil
+
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			if err := rc.Flush(); err != nil {
+				return nil
+			}
+
+		case <-poll.C:
+			current, err := s.paymentRepo.FindByID(s.ctx, payment.ID)
+			if err != nil {
+				if s.ctx.Err() == nil {
+					s.logger.Error("failed to load payment for event stream", "payment_id", payment.ID, "error", err)
+				}
+				return nil
+			}
+
+			if current.Status == payment.Status {
+				continue
+			}
+
+			payment = current
+			if err := writeStatusEvent(w, rc, payment); err != nil {
+				s.logger.Warn("payment event stream closed", "payment_id", payment.ID, "error", err)
+				return nil
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeStatusEvent(w http.ResponseWriter, rc *http.ResponseController, payment *domain.Payment) error {
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(apiPayment)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: status\ndata: %s\n\n", data); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
+
+func mapEventsErrorToAPIResponse(err error) (api.GetPaymentEventsResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPaymentEvents404JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.GetPaymentEvents500JSONResponse(errorResponse), nil
+	default:
+		return api.GetPaymentEvents500JSONResponse(errorResponse), nil
+	}
+}
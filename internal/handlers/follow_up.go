@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/google/uuid"
+)
+
+// retryAfterSeconds is how long clients are asked to wait before following up on a
+// payment that is still being processed
+const retryAfterSeconds = 1
+
+var errorResponseType = reflect.TypeOf(api.ErrorResponse{})
+
+// paymentURL is where the payment with id is fetched
+func paymentURL(id string) string {
+	return "/payments/" + id
+}
+
+// FollowUpHeaders tells a client whose idempotency key is still held by an earlier
+// request when and where to follow up, with the Retry-After and Location headers.
+// The 409 it gets shares its type with every other conflict of the operation, so the
+// headers cannot be declared on the response itself.
+func FollowUpHeaders(f api.StrictHandlerFunc, _ string) api.StrictHandlerFunc {
+	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request any) (any, error) {
+		response, err := f(ctx, w, r, request)
+		if err != nil || response == nil {
+			return response, err
+		}
+
+		// Every error response of an operation is a named ErrorResponse
+		value := reflect.ValueOf(response)
+		if value.Type().ConvertibleTo(errorResponseType) {
+			errorResponse, _ := value.Convert(errorResponseType).Interface().(api.ErrorResponse)
+			if errorResponse.Error.Code == api.REQUESTPROCESSING && errorResponse.Error.PaymentId != uuid.Nil {
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+				w.Header().Set("Location", paymentURL(errorResponse.Error.PaymentId.String()))
+			}
+		}
+		return response, err
+	}
+}
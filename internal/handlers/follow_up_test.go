@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const followUpPaymentID = "5f0c6a52-3b8e-4d47-9a3c-1d2e3f405162"
+
+func TestFollowUpHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		response func() (any, error)
+		location string
+	}{
+		{"authorize still processing", func() (any, error) {
+			return mapAuthServiceErrorToAPIResponse(application.NewRequestProcessingError(followUpPaymentID))
+		}, "/payments/" + followUpPaymentID},
+		{"capture still processing", func() (any, error) {
+			return mapCaptureServiceErrorToAPIResponse(application.NewRequestProcessingError(followUpPaymentID))
+		}, "/payments/" + followUpPaymentID},
+		{"refund still processing", func() (any, error) {
+			return mapRefundServiceErrorToAPIResponse(application.NewRequestProcessingError(followUpPaymentID))
+		}, "/payments/" + followUpPaymentID},
+		{"void still processing", func() (any, error) {
+			return mapVoidServiceErrorToAPIResponse(application.NewRequestProcessingError(followUpPaymentID))
+		}, "/payments/" + followUpPaymentID},
+		{"still processing without a payment", func() (any, error) {
+			return mapAuthServiceErrorToAPIResponse(application.NewRequestProcessingError(""))
+		}, ""},
+		{"other conflict naming a payment", func() (any, error) {
+			return mapAuthServiceErrorToAPIResponse(application.NewDuplicatePaymentError(followUpPaymentID))
+		}, ""},
+		{"success", func() (any, error) {
+			return api.AuthorizePayment201JSONResponse{Success: true}, nil
+		}, ""},
+		{"handler error", func() (any, error) {
+			return nil, errors.New("boom")
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := FollowUpHeaders(func(context.Context, http.ResponseWriter, *http.Request, any) (any, error) {
+				return tt.response()
+			}, "AuthorizePayment")
+			w := httptest.NewRecorder()
+
+			_, _ = handler(context.Background(), w, httptest.NewRequest(http.MethodPost, "/payments", nil), nil)
+
+			assert.Equal(t, tt.location, w.Header().Get("Location"))
+			if tt.location == "" {
+				assert.Empty(t, w.Header().Get("Retry-After"))
+			} else {
+				assert.Equal(t, "1", w.Header().Get("Retry-After"))
+			}
+		})
+	}
+}
+
+func TestBuildErrorResponse_NamesThePayment(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		status    int
+		paymentID uuid.UUID
+		statusURL string
+	}{
+		{"still processing", application.NewRequestProcessingError(followUpPaymentID),
+			http.StatusConflict, uuid.MustParse(followUpPaymentID), "/payments/" + followUpPaymentID},
+		{"still processing without a payment", application.NewRequestProcessingError(""),
+			http.StatusConflict, uuid.Nil, ""},
+		{"payment ID that is not a UUID", application.NewRequestProcessingError("pay-1"),
+			http.StatusConflict, uuid.Nil, ""},
+		{"timeout", application.NewTimeoutError(),
+			http.StatusRequestTimeout, uuid.Nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := BuildErrorResponse(tt.err)
+
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.paymentID, response.Error.PaymentId)
+			assert.Equal(t, tt.statusURL, response.Error.StatusUrl)
+		})
+	}
+}
+
+func TestAuthorizeAccepted_SetsFollowUpHeaders(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	err := authorizeAccepted(followUpPaymentID, api.Payment{}).VisitAuthorizePaymentResponse(w)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "/payments/"+followUpPaymentID, w.Header().Get("Location"))
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+}
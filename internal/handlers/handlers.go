@@ -5,17 +5,46 @@ import (
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/logging"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
 )
 
 // Handlers implements the OpenAPI StrictServerInterface
 type Handlers struct {
-	authService    *services.AuthorizeService
-	captureService *services.CaptureService
-	voidService    *services.VoidService
-	refundService  *services.RefundService
-	paymentRepo    *postgres.PaymentRepository
-	logger         *slog.Logger
+	authService           *services.AuthorizeService
+	captureService        *services.CaptureService
+	voidService           *services.VoidService
+	refundService         *services.RefundService
+	reauthorizeService    *services.ReauthorizeService
+	paymentMethodService  *services.PaymentMethodService
+	scheduleService       *services.ScheduleService
+	reviewService         *services.ReviewService
+	deadLetterService     *services.DeadLetterService
+	subscriptionService   *services.SubscriptionService
+	paymentIntentService  *services.PaymentIntentService
+	paymentGroupService   *services.PaymentGroupService
+	payoutService         *services.PayoutService
+	batchService          *services.BatchService
+	erasureService        *services.ErasureService
+	reconciliationService *services.ReconciliationService
+	bankRefundService     *services.BankRefundService
+	featureFlags          *services.FeatureFlagService
+	regions               *services.RegionService
+	outboxService         *services.OutboxService
+	paymentHistoryService *services.PaymentHistoryService
+	paymentRepo           *postgres.PaymentRepository
+	readModel             *postgres.PaymentReadModelRepository
+	operationRepo         *postgres.OperationRepository
+	debugRepo             *postgres.DebugSessionRepository
+	merchantSettingsRepo  *postgres.MerchantSettingsRepository
+	canaryRouter          *bank.CanaryRouter
+	logControl            *logging.Controller
+	testClock             *clock.Test
+	authorizeWorker       *worker.AuthorizeWorker
+	logger                *slog.Logger
 }
 
 func NewHandlers(
@@ -23,16 +52,66 @@ func NewHandlers(
 	captureService *services.CaptureService,
 	voidService *services.VoidService,
 	refundService *services.RefundService,
+	reauthorizeService *services.ReauthorizeService,
+	paymentMethodService *services.PaymentMethodService,
+	scheduleService *services.ScheduleService,
+	reviewService *services.ReviewService,
+	deadLetterService *services.DeadLetterService,
+	subscriptionService *services.SubscriptionService,
+	paymentIntentService *services.PaymentIntentService,
+	paymentGroupService *services.PaymentGroupService,
+	payoutService *services.PayoutService,
+	batchService *services.BatchService,
+	erasureService *services.ErasureService,
+	reconciliationService *services.ReconciliationService,
+	bankRefundService *services.BankRefundService,
+	featureFlags *services.FeatureFlagService,
+	regions *services.RegionService,
+	outboxService *services.OutboxService,
+	paymentHistoryService *services.PaymentHistoryService,
 	paymentRepo *postgres.PaymentRepository,
+	readModel *postgres.PaymentReadModelRepository,
+	operationRepo *postgres.OperationRepository,
+	debugRepo *postgres.DebugSessionRepository,
+	merchantSettingsRepo *postgres.MerchantSettingsRepository,
+	canaryRouter *bank.CanaryRouter,
+	logControl *logging.Controller,
+	testClock *clock.Test,
+	authorizeWorker *worker.AuthorizeWorker,
 	logger *slog.Logger,
 ) *Handlers {
 	return &Handlers{
-		authService:    authService,
-		captureService: captureService,
-		voidService:    voidService,
-		refundService:  refundService,
-		paymentRepo:    paymentRepo,
-		logger:         logger,
+		authService:           authService,
+		captureService:        captureService,
+		voidService:           voidService,
+		refundService:         refundService,
+		reauthorizeService:    reauthorizeService,
+		paymentMethodService:  paymentMethodService,
+		scheduleService:       scheduleService,
+		reviewService:         reviewService,
+		deadLetterService:     deadLetterService,
+		subscriptionService:   subscriptionService,
+		paymentIntentService:  paymentIntentService,
+		paymentGroupService:   paymentGroupService,
+		payoutService:         payoutService,
+		batchService:          batchService,
+		erasureService:        erasureService,
+		reconciliationService: reconciliationService,
+		bankRefundService:     bankRefundService,
+		featureFlags:          featureFlags,
+		regions:               regions,
+		outboxService:         outboxService,
+		paymentHistoryService: paymentHistoryService,
+		paymentRepo:           paymentRepo,
+		readModel:             readModel,
+		operationRepo:         operationRepo,
+		debugRepo:             debugRepo,
+		merchantSettingsRepo:  merchantSettingsRepo,
+		canaryRouter:          canaryRouter,
+		logControl:            logControl,
+		testClock:             testClock,
+		authorizeWorker:       authorizeWorker,
+		logger:                logger,
 	}
 }
 
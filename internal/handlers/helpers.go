@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 	"github.com/google/uuid"
 )
@@ -16,14 +20,19 @@ func ToAPIPayment(p *domain.Payment) (api.Payment, error) {
 	}
 
 	apiPayment := api.Payment{
-		AmountCents:  p.AmountCents,
-		CreatedAt:    p.CreatedAt,
-		Currency:     p.Currency,
-		CustomerId:   p.CustomerID,
-		Id:           parsedID,
-		OrderId:      p.OrderID,
-		Status:       api.PaymentStatus(p.Status),
-		AttemptCount: p.AttemptCount,
+		Acquirer:            p.Acquirer,
+		AmountCents:         p.AmountCents,
+		CapturedAmountCents: p.CapturedAmountCents,
+		RefundedAmountCents: p.RefundedAmountCents,
+		ReleasedAmountCents: p.ReleasedAmountCents,
+		NetAmountCents:      p.NetAmount(),
+		CreatedAt:           p.CreatedAt,
+		Currency:            p.Currency,
+		CustomerId:          p.CustomerID,
+		Id:                  parsedID,
+		OrderId:             p.OrderID,
+		Status:              api.PaymentStatus(p.Status),
+		AttemptCount:        p.AttemptCount,
 	}
 
 	if p.AuthorizedAt != nil {
@@ -41,6 +50,12 @@ func ToAPIPayment(p *domain.Payment) (api.Payment, error) {
 	if p.ExpiresAt != nil {
 		apiPayment.ExpiresAt = *p.ExpiresAt
 	}
+	if p.FirstCapturedAt != nil {
+		apiPayment.FirstCapturedAt = *p.FirstCapturedAt
+	}
+	if d := p.TimeToCapture(); d != nil {
+		apiPayment.TimeToCaptureSeconds = int64(d.Seconds())
+	}
 	if p.BankAuthID != nil {
 		apiPayment.BankAuthId = *p.BankAuthID
 	}
@@ -56,10 +71,353 @@ func ToAPIPayment(p *domain.Payment) (api.Payment, error) {
 	if p.NextRetryAt != nil {
 		apiPayment.NextRetryAt = *p.NextRetryAt
 	}
+	if p.FailureReason != nil {
+		apiPayment.FailureReason = *p.FailureReason
+	}
+	if p.LastErrorCategory != nil {
+		apiPayment.LastErrorCategory = api.PaymentLastErrorCategory(*p.LastErrorCategory)
+	}
+	if p.DeclineCategory != nil {
+		apiPayment.DeclineCategory = api.PaymentDeclineCategory(*p.DeclineCategory)
+	}
+	if p.PaymentMethodID != nil {
+		parsedPaymentMethodID, err := uuid.Parse(*p.PaymentMethodID)
+		if err != nil {
+			return api.Payment{}, fmt.Errorf("failed to parse payment method ID '%s' as UUID: %w", *p.PaymentMethodID, err)
+		}
+		apiPayment.PaymentMethodId = parsedPaymentMethodID
+	}
+	if p.GroupID != nil {
+		parsedGroupID, err := uuid.Parse(*p.GroupID)
+		if err != nil {
+			return api.Payment{}, fmt.Errorf("failed to parse payment group ID '%s' as UUID: %w", *p.GroupID, err)
+		}
+		apiPayment.GroupId = parsedGroupID
+	}
 
 	return apiPayment, nil
 }
 
+func ToAPIPaymentGroup(group *domain.PaymentGroup) (api.PaymentGroup, error) {
+	parsedID, err := uuid.Parse(group.ID)
+	if err != nil {
+		return api.PaymentGroup{}, fmt.Errorf("failed to parse payment group ID '%s' as UUID: %w", group.ID, err)
+	}
+
+	parts := make([]api.Payment, 0, len(group.Parts))
+	for _, part := range group.Parts {
+		apiPart, err := ToAPIPayment(part)
+		if err != nil {
+			return api.PaymentGroup{}, err
+		}
+		parts = append(parts, apiPart)
+	}
+
+	return api.PaymentGroup{
+		Id:                  parsedID,
+		OrderId:             group.OrderID,
+		CustomerId:          group.CustomerID,
+		AmountCents:         group.AmountCents,
+		Currency:            group.Currency,
+		Status:              api.PaymentGroupStatus(group.Status()),
+		CapturedAmountCents: group.CapturedAmountCents(),
+		Parts:               parts,
+		CreatedAt:           group.CreatedAt,
+	}, nil
+}
+
+func ToAPIPaymentMethod(pm *domain.PaymentMethod) (api.PaymentMethod, error) {
+	parsedID, err := uuid.Parse(pm.ID)
+	if err != nil {
+		return api.PaymentMethod{}, fmt.Errorf("failed to parse payment method ID '%s' as UUID: %w", pm.ID, err)
+	}
+
+	return api.PaymentMethod{
+		Id:          parsedID,
+		CustomerId:  pm.CustomerID,
+		Last4:       pm.Last4,
+		ExpiryMonth: pm.ExpiryMonth,
+		ExpiryYear:  pm.ExpiryYear,
+		CreatedAt:   pm.CreatedAt,
+	}, nil
+}
+
+func ToAPISubscription(sub *domain.Subscription) (api.Subscription, error) {
+	parsedID, err := uuid.Parse(sub.ID)
+	if err != nil {
+		return api.Subscription{}, fmt.Errorf("failed to parse subscription ID '%s' as UUID: %w", sub.ID, err)
+	}
+	paymentMethodID, err := uuid.Parse(sub.PaymentMethodID)
+	if err != nil {
+		return api.Subscription{}, fmt.Errorf("failed to parse payment method ID '%s' as UUID: %w", sub.PaymentMethodID, err)
+	}
+
+	apiSubscription := api.Subscription{
+		Id:              parsedID,
+		CustomerId:      sub.CustomerID,
+		PaymentMethodId: paymentMethodID,
+		Plan:            sub.Plan,
+		AmountCents:     sub.AmountCents,
+		Currency:        sub.Currency,
+		Interval:        api.BillingInterval(sub.Interval),
+		Status:          api.SubscriptionStatus(sub.Status),
+		NextChargeAt:    sub.NextChargeAt,
+		FailedAttempts:  sub.FailedAttempts,
+		CreatedAt:       sub.CreatedAt,
+	}
+
+	if sub.LastPaymentID != nil {
+		lastPaymentID, err := uuid.Parse(*sub.LastPaymentID)
+		if err != nil {
+			return api.Subscription{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", *sub.LastPaymentID, err)
+		}
+		apiSubscription.LastPaymentId = lastPaymentID
+	}
+	if sub.CanceledAt != nil {
+		apiSubscription.CanceledAt = *sub.CanceledAt
+	}
+
+	return apiSubscription, nil
+}
+
+func ToAPIPaymentIntent(intent *domain.PaymentIntent) (api.PaymentIntent, error) {
+	parsedID, err := uuid.Parse(intent.ID)
+	if err != nil {
+		return api.PaymentIntent{}, fmt.Errorf("failed to parse payment intent ID '%s' as UUID: %w", intent.ID, err)
+	}
+
+	apiIntent := api.PaymentIntent{
+		Id:          parsedID,
+		OrderId:     intent.OrderID,
+		CustomerId:  intent.CustomerID,
+		AmountCents: intent.AmountCents,
+		Currency:    intent.Currency,
+		Status:      api.PaymentIntentStatus(intent.Status),
+		ClientToken: intent.ClientToken,
+		CreatedAt:   intent.CreatedAt,
+		ExpiresAt:   intent.ExpiresAt,
+	}
+
+	if intent.PaymentID != nil {
+		paymentID, err := uuid.Parse(*intent.PaymentID)
+		if err != nil {
+			return api.PaymentIntent{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", *intent.PaymentID, err)
+		}
+		apiIntent.PaymentId = paymentID
+	}
+	if intent.ConfirmedAt != nil {
+		apiIntent.ConfirmedAt = *intent.ConfirmedAt
+	}
+
+	return apiIntent, nil
+}
+
+func ToAPIPayout(payout *domain.Payout) (api.Payout, error) {
+	parsedID, err := uuid.Parse(payout.ID)
+	if err != nil {
+		return api.Payout{}, fmt.Errorf("failed to parse payout ID '%s' as UUID: %w", payout.ID, err)
+	}
+
+	apiPayout := api.Payout{
+		Id:            parsedID,
+		RecipientId:   payout.RecipientID,
+		Purpose:       api.PayoutPurpose(payout.Purpose),
+		AmountCents:   payout.AmountCents,
+		Currency:      payout.Currency,
+		Status:        api.PayoutStatus(payout.Status),
+		AccountLast4:  payout.AccountLast4,
+		RoutingNumber: payout.RoutingNumber,
+		CreatedAt:     payout.CreatedAt,
+	}
+
+	if payout.PaymentID != nil {
+		paymentID, err := uuid.Parse(*payout.PaymentID)
+		if err != nil {
+			return api.Payout{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", *payout.PaymentID, err)
+		}
+		apiPayout.PaymentId = paymentID
+	}
+	if payout.BankPayoutID != nil {
+		apiPayout.BankPayoutId = *payout.BankPayoutID
+	}
+	if payout.FailureCode != nil {
+		apiPayout.FailureCode = *payout.FailureCode
+	}
+	if payout.PaidAt != nil {
+		apiPayout.PaidAt = *payout.PaidAt
+	}
+	if payout.FailedAt != nil {
+		apiPayout.FailedAt = *payout.FailedAt
+	}
+
+	return apiPayout, nil
+}
+
+func ToAPIBatch(batch *domain.Batch) (api.Batch, error) {
+	parsedID, err := uuid.Parse(batch.ID)
+	if err != nil {
+		return api.Batch{}, fmt.Errorf("failed to parse batch ID '%s' as UUID: %w", batch.ID, err)
+	}
+
+	apiBatch := api.Batch{
+		Id:        parsedID,
+		Type:      api.BatchType(batch.Type),
+		Reason:    api.OperationReason(batch.Reason),
+		Status:    api.BatchStatus(batch.Status),
+		CreatedAt: batch.CreatedAt,
+		Succeeded: batch.Count(domain.BatchItemSucceeded),
+		Failed:    batch.Count(domain.BatchItemFailed),
+		Pending:   batch.Count(domain.BatchItemPending),
+		Items:     make([]api.BatchItem, 0, len(batch.Items)),
+	}
+	if batch.CompletedAt != nil {
+		apiBatch.CompletedAt = *batch.CompletedAt
+	}
+
+	for _, item := range batch.Items {
+		paymentID, err := uuid.Parse(item.PaymentID)
+		if err != nil {
+			return api.Batch{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", item.PaymentID, err)
+		}
+
+		apiItem := api.BatchItem{
+			PaymentId:   paymentID,
+			AmountCents: item.AmountCents,
+			Status:      api.BatchItemStatus(item.Status),
+		}
+		if item.OperationID != nil {
+			operationID, err := uuid.Parse(*item.OperationID)
+			if err != nil {
+				return api.Batch{}, fmt.Errorf("failed to parse operation ID '%s' as UUID: %w", *item.OperationID, err)
+			}
+			apiItem.OperationId = operationID
+		}
+		if item.ErrorCode != nil {
+			apiItem.ErrorCode = *item.ErrorCode
+		}
+		if item.CompletedAt != nil {
+			apiItem.CompletedAt = *item.CompletedAt
+		}
+		apiBatch.Items = append(apiBatch.Items, apiItem)
+	}
+
+	return apiBatch, nil
+}
+
+func ToAPIErasure(erasure *domain.Erasure) (api.Erasure, error) {
+	parsedID, err := uuid.Parse(erasure.ID)
+	if err != nil {
+		return api.Erasure{}, fmt.Errorf("failed to parse erasure ID '%s' as UUID: %w", erasure.ID, err)
+	}
+
+	return api.Erasure{
+		Id:                       parsedID,
+		CustomerToken:            erasure.CustomerToken,
+		RetentionCutoff:          erasure.RetentionCutoff,
+		PaymentsAnonymized:       erasure.PaymentsAnonymized,
+		PaymentsRetained:         erasure.PaymentsRetained,
+		PaymentMethodsAnonymized: erasure.PaymentMethodsAnonymized,
+		SubscriptionsAnonymized:  erasure.SubscriptionsAnonymized,
+		CreatedAt:                erasure.CreatedAt,
+	}, nil
+}
+
+func ToAPIReconciliationIssues(issues []*domain.ReconciliationIssue) ([]api.ReconciliationIssue, error) {
+	apiIssues := make([]api.ReconciliationIssue, 0, len(issues))
+	for _, issue := range issues {
+		parsedID, err := uuid.Parse(issue.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse reconciliation issue ID '%s' as UUID: %w", issue.ID, err)
+		}
+
+		apiIssue := api.ReconciliationIssue{
+			Id:              parsedID,
+			Kind:            api.ReconciliationIssueKind(issue.Kind),
+			BankAuthId:      issue.BankAuthID,
+			GatewayStatus:   string(issue.GatewayStatus),
+			BankStatus:      issue.BankStatus,
+			FirstDetectedAt: issue.FirstDetectedAt,
+			LastDetectedAt:  issue.LastDetectedAt,
+		}
+		if issue.PaymentID != "" {
+			parsedPaymentID, err := uuid.Parse(issue.PaymentID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", issue.PaymentID, err)
+			}
+			apiIssue.PaymentId = parsedPaymentID
+		}
+		if issue.VoidedAt != nil {
+			apiIssue.VoidedAt = *issue.VoidedAt
+		}
+		apiIssues = append(apiIssues, apiIssue)
+	}
+	return apiIssues, nil
+}
+
+func ToAPIMerchantQuota(quota *domain.MerchantQuota) api.MerchantQuota {
+	apiQuota := api.MerchantQuota{
+		MerchantId:       quota.MerchantID,
+		UsedTransactions: quota.UsedTransactions,
+		UsedVolume:       quota.UsedVolumeCents,
+	}
+	if quota.DailyTransactionLimit != nil {
+		apiQuota.DailyTransactionLimit = *quota.DailyTransactionLimit
+	}
+	if quota.DailyVolumeLimitCents != nil {
+		apiQuota.DailyVolumeLimit = *quota.DailyVolumeLimitCents
+	}
+	return apiQuota
+}
+
+// ToAPIFeatureFlag lists the overrides by merchant ID
+func ToAPIFeatureFlag(flag *domain.FeatureFlag) api.FeatureFlag {
+	apiFlag := api.FeatureFlag{
+		Name:      api.FeatureFlagName(flag.Name),
+		Percent:   flag.Percent,
+		Overrides: make([]api.FeatureFlagOverride, 0, len(flag.Overrides)),
+	}
+	for _, merchantID := range slices.Sorted(maps.Keys(flag.Overrides)) {
+		apiFlag.Overrides = append(apiFlag.Overrides, api.FeatureFlagOverride{
+			MerchantId: merchantID,
+			Enabled:    flag.Overrides[merchantID],
+		})
+	}
+	if flag.UpdatedAt != nil {
+		apiFlag.UpdatedAt = *flag.UpdatedAt
+	}
+	return apiFlag
+}
+
+func ToAPIRegion(state domain.RegionState) api.Region {
+	apiRegion := api.Region{
+		Region: state.Region,
+		Active: state.Active,
+		Epoch:  state.Epoch,
+	}
+	if state.Lease != nil {
+		apiRegion.ActiveRegion = state.Lease.ActiveRegion
+		apiRegion.PromotedAt = state.Lease.PromotedAt
+	}
+	return apiRegion
+}
+
+func ToAPIOutboxStatus(status *services.OutboxStatus) api.OutboxStatus {
+	apiStatus := api.OutboxStatus{
+		Pending: status.Pending,
+		Paused:  status.Pause != nil,
+	}
+	if status.OldestOccurredAt != nil {
+		apiStatus.OldestOccurredAt = *status.OldestOccurredAt
+		apiStatus.OldestAgeSeconds = time.Since(*status.OldestOccurredAt).Seconds()
+	}
+	if status.Pause != nil {
+		apiStatus.PausedAt = status.Pause.PausedAt
+		apiStatus.PausedBy = status.Pause.PausedBy
+		apiStatus.Reason = status.Pause.Reason
+	}
+	return apiStatus
+}
+
 func ToAPIPayments(payments []*domain.Payment) ([]api.Payment, error) {
 	apiPayments := make([]api.Payment, 0, len(payments))
 	for _, p := range payments {
@@ -72,18 +430,205 @@ func ToAPIPayments(payments []*domain.Payment) ([]api.Payment, error) {
 	return apiPayments, nil
 }
 
+// ToAPIPaymentReviews converts the payments waiting in the review queue
+func ToAPIPaymentReviews(queued []*services.QueuedPayment) ([]api.PaymentReview, error) {
+	apiReviews := make([]api.PaymentReview, 0, len(queued))
+	for _, q := range queued {
+		apiPayment, err := ToAPIPayment(q.Payment)
+		if err != nil {
+			return nil, err
+		}
+		apiReviews = append(apiReviews, api.PaymentReview{
+			Payment:   apiPayment,
+			Reason:    q.Review.Reason,
+			FlaggedAt: q.Review.FlaggedAt,
+		})
+	}
+	return apiReviews, nil
+}
+
+func ToAPIDeadLetters(listed []*services.DeadLetteredPayment) ([]api.DeadLetter, error) {
+	apiDeadLetters := make([]api.DeadLetter, 0, len(listed))
+	for _, l := range listed {
+		apiPayment, err := ToAPIPayment(l.Payment)
+		if err != nil {
+			return nil, err
+		}
+		apiDeadLetters = append(apiDeadLetters, api.DeadLetter{
+			Payment:        apiPayment,
+			Attempts:       l.DeadLetter.Attempts,
+			LastError:      l.DeadLetter.LastError,
+			DeadLetteredAt: l.DeadLetter.DeadLetteredAt,
+		})
+	}
+	return apiDeadLetters, nil
+}
+
+func ToAPIOperation(o *domain.Operation) (api.Operation, error) {
+	parsedID, err := uuid.Parse(o.ID)
+	if err != nil {
+		return api.Operation{}, fmt.Errorf("failed to parse operation ID '%s' as UUID: %w", o.ID, err)
+	}
+
+	parsedPaymentID, err := uuid.Parse(o.PaymentID)
+	if err != nil {
+		return api.Operation{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", o.PaymentID, err)
+	}
+
+	apiOperation := api.Operation{
+		AmountCents:   o.AmountCents,
+		CreatedAt:     o.CreatedAt,
+		Id:            parsedID,
+		PaymentId:     parsedPaymentID,
+		Status:        api.OperationStatus(o.Status),
+		Type:          api.OperationType(o.Type),
+		BankInitiated: o.BankInitiated,
+	}
+
+	if o.Reason != nil {
+		apiOperation.Reason = api.OperationReason(*o.Reason)
+	}
+	if o.BankReferenceID != nil {
+		apiOperation.BankReferenceId = *o.BankReferenceID
+	}
+	if o.CompletedAt != nil {
+		apiOperation.CompletedAt = *o.CompletedAt
+	}
+	if o.RequestedBy != nil {
+		apiOperation.RequestedBy = *o.RequestedBy
+	}
+	if o.ReviewedBy != nil {
+		apiOperation.ReviewedBy = *o.ReviewedBy
+	}
+	if o.ReviewedAt != nil {
+		apiOperation.ReviewedAt = *o.ReviewedAt
+	}
+	apiOperation.Destination = api.RefundDestinationType(o.Destination)
+	if o.DestinationLast4 != nil {
+		apiOperation.DestinationLast4 = *o.DestinationLast4
+	}
+	if o.DestinationRoutingNumber != nil {
+		apiOperation.DestinationRoutingNumber = *o.DestinationRoutingNumber
+	}
+
+	return apiOperation, nil
+}
+
+func ToAPIOperations(ops []*domain.Operation) ([]api.Operation, error) {
+	apiOperations := make([]api.Operation, 0, len(ops))
+	for _, op := range ops {
+		apiOperation, err := ToAPIOperation(op)
+		if err != nil {
+			return nil, err
+		}
+		apiOperations = append(apiOperations, apiOperation)
+	}
+	return apiOperations, nil
+}
+
+func ToAPIReceipt(r *domain.Receipt) (api.Receipt, error) {
+	parsedPaymentID, err := uuid.Parse(r.PaymentID)
+	if err != nil {
+		return api.Receipt{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", r.PaymentID, err)
+	}
+
+	apiReceipt := api.Receipt{
+		AmountCents:         r.AmountCents,
+		AuthorizedAt:        r.AuthorizedAt,
+		BankAuthId:          r.BankAuthID,
+		CapturedAmountCents: r.CapturedCents,
+		CardBrand:           r.CardBrand,
+		CardLast4:           r.CardLast4,
+		CreatedAt:           r.CreatedAt,
+		Currency:            r.Currency,
+		CustomerId:          r.CustomerID,
+		Entries:             make([]api.ReceiptEntry, 0, len(r.Entries)),
+		NetAmountCents:      r.NetCents(),
+		OrderId:             r.OrderID,
+		PaymentId:           parsedPaymentID,
+		RefundedAmountCents: r.RefundedCents,
+		Status:              string(r.Status),
+	}
+
+	if r.CapturedAt != nil {
+		apiReceipt.CapturedAt = *r.CapturedAt
+	}
+	if r.VoidedAt != nil {
+		apiReceipt.VoidedAt = *r.VoidedAt
+	}
+	if r.RefundedAt != nil {
+		apiReceipt.RefundedAt = *r.RefundedAt
+	}
+	for _, entry := range r.Entries {
+		apiReceipt.Entries = append(apiReceipt.Entries, api.ReceiptEntry{
+			AmountCents:     entry.AmountCents,
+			BankReferenceId: entry.BankReferenceID,
+			CompletedAt:     entry.CompletedAt,
+			Type:            string(entry.Type),
+		})
+	}
+
+	return apiReceipt, nil
+}
+
 func BuildErrorResponse(err error) (int, api.ErrorResponse) {
 	statusCode := application.ToHTTPStatus(err)
-	errorCode := application.ToErrorCode(err)
-
-	return statusCode, api.ErrorResponse{
-		Success: false,
-		Error: struct {
-			Code    api.ErrorResponseErrorCode `json:"code"`
-			Message string                     `json:"message"`
-		}{
-			Code:    api.ErrorResponseErrorCode(errorCode),
-			Message: err.Error(),
-		},
+
+	response := api.ErrorResponse{Success: false}
+	response.Error.Code = api.ErrorResponseErrorCode(application.ToErrorCode(err))
+	response.Error.Message = err.Error()
+
+	// An error about an existing payment, such as a duplicate, names it
+	if svcErr, ok := application.IsServiceError(err); ok && svcErr.PaymentID != "" {
+		if paymentID, parseErr := uuid.Parse(svcErr.PaymentID); parseErr == nil {
+			response.Error.PaymentId = paymentID
+			response.Error.StatusUrl = paymentURL(svcErr.PaymentID)
+		}
+	}
+	if category, ok := application.DeclineCategoryOf(err); ok {
+		response.Error.DeclineCategory = api.ErrorResponseErrorDeclineCategory(category)
+	}
+	return statusCode, response
+}
+
+func ToAPIDebugSession(s *domain.DebugSession, captures []*domain.DebugCapture) (api.DebugSession, error) {
+	parsedID, err := uuid.Parse(s.ID)
+	if err != nil {
+		return api.DebugSession{}, fmt.Errorf("failed to parse debug session ID '%s' as UUID: %w", s.ID, err)
 	}
+
+	apiSession := api.DebugSession{
+		Captures:       make([]api.DebugCapture, 0, len(captures)),
+		CreatedAt:      s.CreatedAt,
+		ExpiresAt:      s.ExpiresAt,
+		Id:             parsedID,
+		IdempotencyKey: s.IdempotencyKey,
+	}
+
+	if s.PaymentID != "" {
+		parsedPaymentID, err := uuid.Parse(s.PaymentID)
+		if err != nil {
+			return api.DebugSession{}, fmt.Errorf("failed to parse payment ID '%s' as UUID: %w", s.PaymentID, err)
+		}
+		apiSession.PaymentId = parsedPaymentID
+	}
+
+	for _, c := range captures {
+		parsedCaptureID, err := uuid.Parse(c.ID)
+		if err != nil {
+			return api.DebugSession{}, fmt.Errorf("failed to parse debug capture ID '%s' as UUID: %w", c.ID, err)
+		}
+		apiSession.Captures = append(apiSession.Captures, api.DebugCapture{
+			CapturedAt:     c.CapturedAt,
+			Id:             parsedCaptureID,
+			IdempotencyKey: c.IdempotencyKey,
+			Method:         c.Method,
+			RequestBody:    c.RequestBody,
+			ResponseBody:   c.ResponseBody,
+			ResponseStatus: c.ResponseStatus,
+			Url:            c.URL,
+		})
+	}
+
+	return apiSession, nil
 }
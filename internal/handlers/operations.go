@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (h *Handlers) CreateCapture(
+	ctx context.Context,
+	request api.CreateCaptureRequestObject,
+) (api.CreateCaptureResponseObject, error) {
+	idempotencyKey := request.Params.IdempotencyKey
+	amount := request.Body.Amount
+
+	if _, err := h.captureService.Capture(ctx, request.PaymentID.String(), amount, idempotencyKey); err != nil {
+		return mapCreateCaptureErrorToAPIResponse(err)
+	}
+
+	operation, err := h.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return mapCreateCaptureErrorToAPIResponse(err)
+	}
+
+	apiOperation, err := ToAPIOperation(operation)
+	if err != nil {
+		return mapCreateCaptureErrorToAPIResponse(err)
+	}
+
+	return api.CreateCapture201JSONResponse{
+		Success: true,
+		Data:    apiOperation,
+	}, nil
+}
+
+func (h *Handlers) CreateVoid(
+	ctx context.Context,
+	request api.CreateVoidRequestObject,
+) (api.CreateVoidResponseObject, error) {
+	idempotencyKey := request.Params.IdempotencyKey
+	reason := domain.OperationReason(request.Body.Reason)
+
+	if _, err := h.voidService.Void(ctx, request.PaymentID.String(), reason, request.Body.Amount, idempotencyKey); err != nil {
+		return mapCreateVoidErrorToAPIResponse(err)
+	}
+
+	operation, err := h.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return mapCreateVoidErrorToAPIResponse(err)
+	}
+
+	apiOperation, err := ToAPIOperation(operation)
+	if err != nil {
+		return mapCreateVoidErrorToAPIResponse(err)
+	}
+
+	return api.CreateVoid201JSONResponse{
+		Success: true,
+		Data:    apiOperation,
+	}, nil
+}
+
+func (h *Handlers) CreateRefund(
+	ctx context.Context,
+	request api.CreateRefundRequestObject,
+) (api.CreateRefundResponseObject, error) {
+	idempotencyKey := request.Params.IdempotencyKey
+	amount := request.Body.Amount
+	reason := domain.OperationReason(request.Body.Reason)
+	destination := domain.RefundDestination{
+		Type:          domain.RefundDestinationType(request.Body.Destination.Type),
+		AccountNumber: request.Body.Destination.AccountNumber,
+		RoutingNumber: request.Body.Destination.RoutingNumber,
+	}
+
+	if _, err := h.refundService.RefundTo(ctx, request.PaymentID.String(), amount, reason, destination, idempotencyKey); err != nil {
+		return mapCreateRefundErrorToAPIResponse(err)
+	}
+
+	operation, err := h.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return mapCreateRefundErrorToAPIResponse(err)
+	}
+
+	apiOperation, err := ToAPIOperation(operation)
+	if err != nil {
+		return mapCreateRefundErrorToAPIResponse(err)
+	}
+
+	if operation.Status == domain.OperationPendingApproval {
+		return api.CreateRefund202JSONResponse{
+			Success: true,
+			Data:    apiOperation,
+		}, nil
+	}
+	return api.CreateRefund201JSONResponse{
+		Success: true,
+		Data:    apiOperation,
+	}, nil
+}
+
+func (h *Handlers) GetOperationByID(
+	ctx context.Context,
+	request api.GetOperationByIDRequestObject,
+) (api.GetOperationByIDResponseObject, error) {
+
+	operation, err := h.operationRepo.FindByID(ctx, request.OperationID.String())
+	if err != nil {
+		return mapOperationErrorToAPIResponse(err)
+	}
+
+	apiOperation, err := ToAPIOperation(operation)
+	if err != nil {
+		return mapOperationErrorToAPIResponse(err)
+	}
+
+	return api.GetOperationByID200JSONResponse{
+		Success: true,
+		Data:    apiOperation,
+	}, nil
+}
+
+func (h *Handlers) GetRefundByID(
+	ctx context.Context,
+	request api.GetRefundByIDRequestObject,
+) (api.GetRefundByIDResponseObject, error) {
+
+	refund, err := h.operationRepo.FindRefundByID(ctx, request.RefundID.String())
+	if err != nil {
+		return mapRefundErrorToAPIResponse(err)
+	}
+
+	apiRefund, err := ToAPIOperation(refund)
+	if err != nil {
+		return mapRefundErrorToAPIResponse(err)
+	}
+
+	return api.GetRefundByID200JSONResponse{
+		Success: true,
+		Data:    apiRefund,
+	}, nil
+}
+
+func mapCreateCaptureErrorToAPIResponse(err error) (api.CreateCaptureResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateCapture400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreateCapture404JSONResponse(errorResponse), nil
+	case http.StatusRequestTimeout:
+		return api.CreateCapture408JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CreateCapture409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.CreateCapture500JSONResponse(errorResponse), nil
+	default:
+		return api.CreateCapture500JSONResponse(errorResponse), nil
+	}
+}
+
+func (h *Handlers) CreateReauthorization(
+	ctx context.Context,
+	request api.CreateReauthorizationRequestObject,
+) (api.CreateReauthorizationResponseObject, error) {
+	idempotencyKey := request.Params.IdempotencyKey
+
+	var paymentMethodID string
+	if request.Body.PaymentMethodId != uuid.Nil {
+		paymentMethodID = request.Body.PaymentMethodId.String()
+	}
+
+	if _, err := h.reauthorizeService.Reauthorize(ctx, request.PaymentID.String(), paymentMethodID, idempotencyKey); err != nil {
+		return mapCreateReauthorizationErrorToAPIResponse(err)
+	}
+
+	operation, err := h.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return mapCreateReauthorizationErrorToAPIResponse(err)
+	}
+
+	apiOperation, err := ToAPIOperation(operation)
+	if err != nil {
+		return mapCreateReauthorizationErrorToAPIResponse(err)
+	}
+
+	return api.CreateReauthorization201JSONResponse{
+		Success: true,
+		Data:    apiOperation,
+	}, nil
+}
+
+func mapCreateVoidErrorToAPIResponse(err error) (api.CreateVoidResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateVoid400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreateVoid404JSONResponse(errorResponse), nil
+	case http.StatusRequestTimeout:
+		return api.CreateVoid408JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CreateVoid409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.CreateVoid500JSONResponse(errorResponse), nil
+	default:
+		return api.CreateVoid500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapCreateRefundErrorToAPIResponse(err error) (api.CreateRefundResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateRefund400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreateRefund404JSONResponse(errorResponse), nil
+	case http.StatusRequestTimeout:
+		return api.CreateRefund408JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CreateRefund409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.CreateRefund500JSONResponse(errorResponse), nil
+	default:
+		return api.CreateRefund500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapCreateReauthorizationErrorToAPIResponse(err error) (api.CreateReauthorizationResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateReauthorization400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreateReauthorization404JSONResponse(errorResponse), nil
+	case http.StatusRequestTimeout:
+		return api.CreateReauthorization408JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CreateReauthorization409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.CreateReauthorization500JSONResponse(errorResponse), nil
+	default:
+		return api.CreateReauthorization500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapOperationErrorToAPIResponse(err error) (api.GetOperationByIDResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetOperationByID404JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.GetOperationByID500JSONResponse(errorResponse), nil
+	default:
+		return api.GetOperationByID500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapRefundErrorToAPIResponse(err error) (api.GetRefundByIDResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetRefundByID404JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.GetRefundByID500JSONResponse(errorResponse), nil
+	default:
+		return api.GetRefundByID500JSONResponse(errorResponse), nil
+	}
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+func (h *Handlers) AuthorizePaymentGroup(
+	ctx context.Context,
+	request api.AuthorizePaymentGroupRequestObject,
+) (api.AuthorizePaymentGroupResponseObject, error) {
+	req := request.Body
+
+	cmd := services.AuthorizeGroupCommand{
+		OrderID:    req.OrderId,
+		CustomerID: req.CustomerId,
+		Currency:   "USD",
+	}
+	for _, part := range req.Parts {
+		cmd.Parts = append(cmd.Parts, services.GroupPartCard{
+			Amount:      part.Amount,
+			CardNumber:  part.CardNumber,
+			CVV:         part.Cvv,
+			ExpiryMonth: part.ExpiryMonth,
+			ExpiryYear:  part.ExpiryYear,
+		})
+	}
+
+	group, err := h.paymentGroupService.Authorize(ctx, &cmd, request.Params.IdempotencyKey)
+	if err != nil {
+		return mapAuthorizePaymentGroupErrorToAPIResponse(err)
+	}
+
+	apiGroup, err := ToAPIPaymentGroup(group)
+	if err != nil {
+		return mapAuthorizePaymentGroupErrorToAPIResponse(err)
+	}
+
+	return api.AuthorizePaymentGroup201JSONResponse{
+		Success: true,
+		Data:    apiGroup,
+	}, nil
+}
+
+func (h *Handlers) GetPaymentGroup(
+	ctx context.Context,
+	request api.GetPaymentGroupRequestObject,
+) (api.GetPaymentGroupResponseObject, error) {
+	group, err := h.paymentGroupService.Get(ctx, request.GroupID.String())
+	if err != nil {
+		return mapGetPaymentGroupErrorToAPIResponse(err)
+	}
+
+	apiGroup, err := ToAPIPaymentGroup(group)
+	if err != nil {
+		return mapGetPaymentGroupErrorToAPIResponse(err)
+	}
+
+	return api.GetPaymentGroup200JSONResponse{
+		Success: true,
+		Data:    apiGroup,
+	}, nil
+}
+
+func (h *Handlers) CapturePaymentGroup(
+	ctx context.Context,
+	request api.CapturePaymentGroupRequestObject,
+) (api.CapturePaymentGroupResponseObject, error) {
+	group, err := h.paymentGroupService.Capture(ctx, request.GroupID.String(), request.Params.IdempotencyKey)
+	if err != nil {
+		return mapCapturePaymentGroupErrorToAPIResponse(err)
+	}
+
+	apiGroup, err := ToAPIPaymentGroup(group)
+	if err != nil {
+		return mapCapturePaymentGroupErrorToAPIResponse(err)
+	}
+
+	return api.CapturePaymentGroup201JSONResponse{
+		Success: true,
+		Data:    apiGroup,
+	}, nil
+}
+
+func (h *Handlers) VoidPaymentGroup(
+	ctx context.Context,
+	request api.VoidPaymentGroupRequestObject,
+) (api.VoidPaymentGroupResponseObject, error) {
+	reason := domain.OperationReason(request.Body.Reason)
+
+	group, err := h.paymentGroupService.Void(ctx, request.GroupID.String(), reason, request.Params.IdempotencyKey)
+	if err != nil {
+		return mapVoidPaymentGroupErrorToAPIResponse(err)
+	}
+
+	apiGroup, err := ToAPIPaymentGroup(group)
+	if err != nil {
+		return mapVoidPaymentGroupErrorToAPIResponse(err)
+	}
+
+	return api.VoidPaymentGroup201JSONResponse{
+		Success: true,
+		Data:    apiGroup,
+	}, nil
+}
+
+func mapAuthorizePaymentGroupErrorToAPIResponse(err error) (api.AuthorizePaymentGroupResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.AuthorizePaymentGroup400JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.AuthorizePaymentGroup409JSONResponse(errorResponse), nil
+	case http.StatusTooManyRequests:
+		return api.AuthorizePaymentGroup429JSONResponse(errorResponse), nil
+	default:
+		return api.AuthorizePaymentGroup500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetPaymentGroupErrorToAPIResponse(err error) (api.GetPaymentGroupResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPaymentGroup404JSONResponse(errorResponse), nil
+	default:
+		return api.GetPaymentGroup500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapCapturePaymentGroupErrorToAPIResponse(err error) (api.CapturePaymentGroupResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.CapturePaymentGroup404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CapturePaymentGroup409JSONResponse(errorResponse), nil
+	default:
+		return api.CapturePaymentGroup500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapVoidPaymentGroupErrorToAPIResponse(err error) (api.VoidPaymentGroupResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.VoidPaymentGroup400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.VoidPaymentGroup404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.VoidPaymentGroup409JSONResponse(errorResponse), nil
+	default:
+		return api.VoidPaymentGroup500JSONResponse(errorResponse), nil
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+func (h *Handlers) CreatePaymentIntent(
+	ctx context.Context,
+	request api.CreatePaymentIntentRequestObject,
+) (api.CreatePaymentIntentResponseObject, error) {
+	req := request.Body
+
+	cmd := services.CreatePaymentIntentCommand{
+		OrderID:    req.OrderId,
+		CustomerID: req.CustomerId,
+		Amount:     req.Amount,
+		Currency:   "USD",
+	}
+
+	intent, err := h.paymentIntentService.Create(ctx, &cmd)
+	if err != nil {
+		return mapCreatePaymentIntentErrorToAPIResponse(err)
+	}
+
+	apiIntent, err := ToAPIPaymentIntent(intent)
+	if err != nil {
+		return mapCreatePaymentIntentErrorToAPIResponse(err)
+	}
+
+	return api.CreatePaymentIntent201JSONResponse{
+		Success: true,
+		Data:    apiIntent,
+	}, nil
+}
+
+func (h *Handlers) GetPaymentIntent(
+	ctx context.Context,
+	request api.GetPaymentIntentRequestObject,
+) (api.GetPaymentIntentResponseObject, error) {
+	intent, err := h.paymentIntentService.Get(ctx, request.IntentID.String())
+	if err != nil {
+		return mapGetPaymentIntentErrorToAPIResponse(err)
+	}
+
+	apiIntent, err := ToAPIPaymentIntent(intent)
+	if err != nil {
+		return mapGetPaymentIntentErrorToAPIResponse(err)
+	}
+
+	return api.GetPaymentIntent200JSONResponse{
+		Success: true,
+		Data:    apiIntent,
+	}, nil
+}
+
+func (h *Handlers) ConfirmPaymentIntent(
+	ctx context.Context,
+	request api.ConfirmPaymentIntentRequestObject,
+) (api.ConfirmPaymentIntentResponseObject, error) {
+	req := request.Body
+
+	cmd := services.ConfirmPaymentIntentCommand{
+		IntentID:    request.IntentID.String(),
+		ClientToken: request.Params.XClientToken,
+		CardNumber:  req.CardNumber,
+		CVV:         req.Cvv,
+		ExpiryMonth: req.ExpiryMonth,
+		ExpiryYear:  req.ExpiryYear,
+	}
+
+	payment, err := h.paymentIntentService.Confirm(ctx, &cmd)
+	if err != nil {
+		return mapConfirmPaymentIntentErrorToAPIResponse(err)
+	}
+
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapConfirmPaymentIntentErrorToAPIResponse(err)
+	}
+
+	if payment.Status == domain.StatusReview {
+		return api.ConfirmPaymentIntent202JSONResponse{
+			Success: true,
+			Data:    apiPayment,
+		}, nil
+	}
+
+	return api.ConfirmPaymentIntent201JSONResponse{
+		Success: true,
+		Data:    apiPayment,
+	}, nil
+}
+
+func mapCreatePaymentIntentErrorToAPIResponse(err error) (api.CreatePaymentIntentResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreatePaymentIntent400JSONResponse(errorResponse), nil
+	default:
+		return api.CreatePaymentIntent500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetPaymentIntentErrorToAPIResponse(err error) (api.GetPaymentIntentResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPaymentIntent404JSONResponse(errorResponse), nil
+	default:
+		return api.GetPaymentIntent500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapConfirmPaymentIntentErrorToAPIResponse(err error) (api.ConfirmPaymentIntentResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.ConfirmPaymentIntent400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.ConfirmPaymentIntent404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.ConfirmPaymentIntent409JSONResponse(errorResponse), nil
+	case http.StatusTooManyRequests:
+		return api.ConfirmPaymentIntent429JSONResponse(errorResponse), nil
+	default:
+		return api.ConfirmPaymentIntent500JSONResponse(errorResponse), nil
+	}
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (h *Handlers) CreatePayout(
+	ctx context.Context,
+	request api.CreatePayoutRequestObject,
+) (api.CreatePayoutResponseObject, error) {
+	req := request.Body
+
+	cmd := services.CreatePayoutCommand{
+		RecipientID:   req.RecipientId,
+		Purpose:       domain.PayoutPurpose(req.Purpose),
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		AccountNumber: req.AccountNumber,
+		RoutingNumber: req.RoutingNumber,
+	}
+	if req.PaymentId != uuid.Nil {
+		cmd.PaymentID = req.PaymentId.String()
+	}
+
+	payout, err := h.payoutService.Create(ctx, &cmd, request.Params.IdempotencyKey)
+	if err != nil {
+		return mapCreatePayoutErrorToAPIResponse(err)
+	}
+
+	apiPayout, err := ToAPIPayout(payout)
+	if err != nil {
+		return mapCreatePayoutErrorToAPIResponse(err)
+	}
+
+	return api.CreatePayout201JSONResponse{
+		Success: true,
+		Data:    apiPayout,
+	}, nil
+}
+
+func (h *Handlers) GetPayout(
+	ctx context.Context,
+	request api.GetPayoutRequestObject,
+) (api.GetPayoutResponseObject, error) {
+	payout, err := h.payoutService.Get(ctx, request.PayoutID.String())
+	if err != nil {
+		return mapGetPayoutErrorToAPIResponse(err)
+	}
+
+	apiPayout, err := ToAPIPayout(payout)
+	if err != nil {
+		return mapGetPayoutErrorToAPIResponse(err)
+	}
+
+	return api.GetPayout200JSONResponse{
+		Success: true,
+		Data:    apiPayout,
+	}, nil
+}
+
+func mapCreatePayoutErrorToAPIResponse(err error) (api.CreatePayoutResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreatePayout400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreatePayout404JSONResponse(errorResponse), nil
+	case http.StatusRequestTimeout:
+		return api.CreatePayout408JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CreatePayout409JSONResponse(errorResponse), nil
+	default:
+		return api.CreatePayout500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetPayoutErrorToAPIResponse(err error) (api.GetPayoutResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPayout404JSONResponse(errorResponse), nil
+	default:
+		return api.GetPayout500JSONResponse(errorResponse), nil
+	}
+}
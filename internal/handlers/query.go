@@ -2,9 +2,26 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+const (
+	defaultExpiringWithinHours    = 24
+	maxExpiringWithinHours        = 720
+	defaultExpiringAuthorizations = 100
+	maxExpiringAuthorizations     = 500
 )
 
 func (h *Handlers) GetPaymentByID(
@@ -14,7 +31,13 @@ func (h *Handlers) GetPaymentByID(
 
 	paymentID := request.PaymentID.String()
 
-	payment, err := h.paymentRepo.FindByID(ctx, paymentID)
+	var payment *domain.Payment
+	var err error
+	if request.Params.AsOf.IsZero() {
+		payment, err = h.paymentRepo.LookupByID(ctx, paymentID)
+	} else {
+		payment, err = h.paymentHistoryService.AsOf(ctx, paymentID, request.Params.AsOf)
+	}
 	if err != nil {
 		return mapIdErrorToAPIResponse(err)
 	}
@@ -24,13 +47,78 @@ func (h *Handlers) GetPaymentByID(
 		return mapIdErrorToAPIResponse(err)
 	}
 
+	etag, err := paymentETag(apiPayment)
+	if err != nil {
+		return mapIdErrorToAPIResponse(err)
+	}
+	if etagMatches(request.Params.IfNoneMatch, etag) {
+		return api.GetPaymentByID304Response{
+			Headers: api.GetPaymentByID304ResponseHeaders{ETag: etag},
+		}, nil
+	}
+
 	return api.GetPaymentByID200JSONResponse{
-		Success: true,
-		Data:    apiPayment,
+		Body: api.PaymentResponse{
+			Success: true,
+			Data:    apiPayment,
+		},
+		Headers: api.GetPaymentByID200ResponseHeaders{ETag: etag},
 	}, nil
 
 }
 
+// paymentETag hashes the payment as it is returned, so any change to a returned
+// field (status, captured amount, retry schedule...) yields a new tag
+func paymentETag(p api.Payment) (string, error) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal payment for ETag: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// etagMatches reports whether an If-None-Match header names etag. Weak tags
+// compare equal to their strong form, as RFC 9110 asks for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handlers) BatchGetPayments(
+	ctx context.Context,
+	request api.BatchGetPaymentsRequestObject,
+) (api.BatchGetPaymentsResponseObject, error) {
+	if len(request.Body.Ids) == 0 || len(request.Body.Ids) > domain.MaxBatchGetPayments {
+		return mapBatchGetErrorToAPIResponse(application.NewInvalidInputError(domain.ErrInvalidBatchGet))
+	}
+
+	ids := make([]string, 0, len(request.Body.Ids))
+	for _, id := range request.Body.Ids {
+		ids = append(ids, id.String())
+	}
+
+	payments, err := h.paymentRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return mapBatchGetErrorToAPIResponse(err)
+	}
+
+	apiPayments, err := ToAPIPayments(payments)
+	if err != nil {
+		return mapBatchGetErrorToAPIResponse(err)
+	}
+
+	return api.BatchGetPayments200JSONResponse{
+		Success: true,
+		Data:    apiPayments,
+	}, nil
+}
+
 func (h *Handlers) GetPaymentsByCustomer(
 	ctx context.Context,
 	request api.GetPaymentsByCustomerRequestObject,
@@ -40,7 +128,12 @@ func (h *Handlers) GetPaymentsByCustomer(
 	limit := request.Params.Limit
 	offset := request.Params.Offset
 
-	customerPayment, err := h.paymentRepo.FindByCustomerID(ctx, customerID, limit, offset)
+	filter, err := domain.NewCustomerPaymentFilter(request.Params.Status, request.Params.From, request.Params.To)
+	if err != nil {
+		return mapCustomerErrorToAPIResponse(application.NewInvalidInputError(err))
+	}
+
+	customerPayment, err := h.readModel.FindByCustomerID(ctx, customerID, filter, limit, offset)
 	if err != nil {
 		return mapCustomerErrorToAPIResponse(err)
 	}
@@ -56,6 +149,37 @@ func (h *Handlers) GetPaymentsByCustomer(
 	}, nil
 }
 
+func (h *Handlers) GetExpiringAuthorizations(
+	ctx context.Context,
+	request api.GetExpiringAuthorizationsRequestObject,
+) (api.GetExpiringAuthorizationsResponseObject, error) {
+	within := request.Params.WithinHours
+	if within <= 0 {
+		within = defaultExpiringWithinHours
+	}
+	within = min(within, maxExpiringWithinHours)
+	limit := request.Params.Limit
+	if limit <= 0 {
+		limit = defaultExpiringAuthorizations
+	}
+	limit = min(limit, maxExpiringAuthorizations)
+
+	payments, err := h.paymentRepo.FindExpiringAuthorizations(ctx, time.Now().Add(time.Duration(within)*time.Hour), limit)
+	if err != nil {
+		return mapExpiringAuthorizationsErrorToAPIResponse(err)
+	}
+
+	apiPayments, err := ToAPIPayments(payments)
+	if err != nil {
+		return mapExpiringAuthorizationsErrorToAPIResponse(err)
+	}
+
+	return api.GetExpiringAuthorizations200JSONResponse{
+		Success: true,
+		Data:    apiPayments,
+	}, nil
+}
+
 func (h *Handlers) GetPaymentByOrder(
 	ctx context.Context,
 	request api.GetPaymentByOrderRequestObject,
@@ -63,7 +187,7 @@ func (h *Handlers) GetPaymentByOrder(
 
 	orderID := request.OrderID
 
-	payment, err := h.paymentRepo.FindByOrderID(ctx, orderID)
+	payment, err := h.paymentRepo.LookupByOrderID(ctx, orderID)
 	if err != nil {
 		return mapOrderErrorToAPIResponse(err)
 	}
@@ -79,6 +203,67 @@ func (h *Handlers) GetPaymentByOrder(
 	}, nil
 }
 
+func (h *Handlers) GetPaymentByIdempotencyKey(
+	ctx context.Context,
+	request api.GetPaymentByIdempotencyKeyRequestObject,
+) (api.GetPaymentByIdempotencyKeyResponseObject, error) {
+
+	payment, err := h.paymentRepo.FindByIdempotencyKey(ctx, request.IdempotencyKey)
+	if err != nil {
+		return mapIdempotencyKeyErrorToAPIResponse(err)
+	}
+
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapIdempotencyKeyErrorToAPIResponse(err)
+	}
+
+	return api.GetPaymentByIdempotencyKey200JSONResponse{
+		Success: true,
+		Data:    apiPayment,
+	}, nil
+}
+
+func (h *Handlers) GetCustomerPaymentSummary(
+	ctx context.Context,
+	request api.GetCustomerPaymentSummaryRequestObject,
+) (api.GetCustomerPaymentSummaryResponseObject, error) {
+	summary, err := h.readModel.SummarizeCustomer(ctx, request.CustomerID)
+	if err != nil {
+		return mapCustomerSummaryErrorToAPIResponse(err)
+	}
+
+	apiSummary := api.CustomerPaymentSummary{
+		CustomerId:   request.CustomerID,
+		PaymentCount: summary.PaymentCount,
+		StatusCounts: []api.StatusCount{},
+		Totals:       []api.CurrencyTotal{},
+	}
+	if summary.LastPaymentAt != nil {
+		apiSummary.LastPaymentAt = *summary.LastPaymentAt
+	}
+	for _, status := range slices.Sorted(maps.Keys(summary.StatusCounts)) {
+		apiSummary.StatusCounts = append(apiSummary.StatusCounts, api.StatusCount{
+			Status: string(status),
+			Count:  summary.StatusCounts[status],
+		})
+	}
+	for _, currency := range slices.Sorted(maps.Keys(summary.Totals)) {
+		total := summary.Totals[currency]
+		apiSummary.Totals = append(apiSummary.Totals, api.CurrencyTotal{
+			Currency:        currency,
+			AuthorizedCents: total.Authorized,
+			CapturedCents:   total.Captured,
+			RefundedCents:   total.Refunded,
+		})
+	}
+
+	return api.GetCustomerPaymentSummary200JSONResponse{
+		Success: true,
+		Data:    apiSummary,
+	}, nil
+}
+
 func mapIdErrorToAPIResponse(err error) (api.GetPaymentByIDResponseObject, error) {
 	statusCode, errorResponse := BuildErrorResponse(err)
 
@@ -92,6 +277,17 @@ func mapIdErrorToAPIResponse(err error) (api.GetPaymentByIDResponseObject, error
 	}
 }
 
+func mapBatchGetErrorToAPIResponse(err error) (api.BatchGetPaymentsResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.BatchGetPayments400JSONResponse(errorResponse), nil
+	default:
+		return api.BatchGetPayments500JSONResponse(errorResponse), nil
+	}
+}
+
 func mapOrderErrorToAPIResponse(err error) (api.GetPaymentByOrderResponseObject, error) {
 	statusCode, errorResponse := BuildErrorResponse(err)
 
@@ -109,6 +305,8 @@ func mapCustomerErrorToAPIResponse(err error) (api.GetPaymentsByCustomerResponse
 	statusCode, errorResponse := BuildErrorResponse(err)
 
 	switch statusCode {
+	case http.StatusBadRequest:
+		return api.GetPaymentsByCustomer400JSONResponse(errorResponse), nil
 	case http.StatusNotFound:
 		return api.GetPaymentsByCustomer404JSONResponse(errorResponse), nil
 	case http.StatusInternalServerError:
@@ -117,3 +315,26 @@ func mapCustomerErrorToAPIResponse(err error) (api.GetPaymentsByCustomerResponse
 		return api.GetPaymentsByCustomer500JSONResponse(errorResponse), nil
 	}
 }
+
+func mapExpiringAuthorizationsErrorToAPIResponse(err error) (api.GetExpiringAuthorizationsResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetExpiringAuthorizations500JSONResponse(errorResponse), nil
+}
+
+func mapIdempotencyKeyErrorToAPIResponse(err error) (api.GetPaymentByIdempotencyKeyResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPaymentByIdempotencyKey404JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.GetPaymentByIdempotencyKey500JSONResponse(errorResponse), nil
+	default:
+		return api.GetPaymentByIdempotencyKey500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapCustomerSummaryErrorToAPIResponse(err error) (api.GetCustomerPaymentSummaryResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetCustomerPaymentSummary500JSONResponse(errorResponse), nil
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPaymentByIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+	captureService := services.NewCaptureService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewOperationRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+	)
+	h := &Handlers{paymentRepo: paymentRepo}
+
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	authKey := "idem-auth-" + uuid.New().String()
+	mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, authKey).
+		Return(&bank.AuthorizationResponse{
+			Amount:          cmd.Amount,
+			Currency:        cmd.Currency,
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-" + uuid.New().String(),
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).
+		Once()
+	payment, err := authService.Authorize(ctx, &cmd, authKey)
+	require.NoError(t, err)
+
+	captureKey := "idem-capt-" + uuid.New().String()
+	mockBank.EXPECT().
+		Capture(mock.Anything, mock.Anything, captureKey).
+		Return(&bank.CaptureResponse{
+			Amount:          payment.AmountCents,
+			Currency:        payment.Currency,
+			AuthorizationID: *payment.BankAuthID,
+			Status:          "captured",
+			CaptureID:       "cap-" + uuid.New().String(),
+			CapturedAt:      time.Now(),
+		}, nil).
+		Once()
+	_, err = captureService.Capture(ctx, payment.ID, 0, captureKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		key      string
+		found    bool
+		wantCode api.ErrorResponseErrorCode
+	}{
+		{name: "authorize key", ctx: ctx, key: authKey, found: true},
+		{name: "capture key", ctx: ctx, key: captureKey, found: true},
+		{name: "unknown key", ctx: ctx, key: "idem-" + uuid.New().String(), wantCode: api.PAYMENTNOTFOUND},
+		{name: "another merchant's key", ctx: postgres.WithMerchant(ctx, "other-merchant"), key: authKey, wantCode: api.PAYMENTNOTFOUND},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := h.GetPaymentByIdempotencyKey(tt.ctx, api.GetPaymentByIdempotencyKeyRequestObject{IdempotencyKey: tt.key})
+			require.NoError(t, err)
+
+			if !tt.found {
+				notFound, ok := response.(api.GetPaymentByIdempotencyKey404JSONResponse)
+				require.True(t, ok, "expected 404, got %T", response)
+				assert.Equal(t, tt.wantCode, notFound.Error.Code)
+				return
+			}
+
+			ok200, ok := response.(api.GetPaymentByIdempotencyKey200JSONResponse)
+			require.True(t, ok, "expected 200, got %T", response)
+			assert.Equal(t, payment.ID, ok200.Data.Id.String())
+			// Either key answers with the payment as it is now
+			assert.Equal(t, api.PaymentStatusCAPTURED, ok200.Data.Status)
+		})
+	}
+}
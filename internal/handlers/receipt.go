@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pdf"
+)
+
+func (h *Handlers) GetPaymentReceipt(
+	ctx context.Context,
+	request api.GetPaymentReceiptRequestObject,
+) (api.GetPaymentReceiptResponseObject, error) {
+	paymentID := request.PaymentID.String()
+
+	payment, err := h.paymentRepo.FindByID(ctx, paymentID)
+	if err != nil {
+		return mapReceiptErrorToAPIResponse(err)
+	}
+
+	operations, err := h.operationRepo.FindByPaymentID(ctx, paymentID)
+	if err != nil {
+		return mapReceiptErrorToAPIResponse(err)
+	}
+
+	receipt, err := domain.NewReceipt(payment, operations)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoReceipt) {
+			return mapReceiptErrorToAPIResponse(application.NewInvalidStateError(err))
+		}
+		return mapReceiptErrorToAPIResponse(err)
+	}
+
+	if request.Params.Format == api.Pdf {
+		doc := receiptPDF(receipt)
+		return api.GetPaymentReceipt200ApplicationpdfResponse{
+			Body:          bytes.NewReader(doc),
+			ContentLength: int64(len(doc)),
+		}, nil
+	}
+
+	apiReceipt, err := ToAPIReceipt(receipt)
+	if err != nil {
+		return mapReceiptErrorToAPIResponse(err)
+	}
+
+	return api.GetPaymentReceipt200JSONResponse{
+		Success: true,
+		Data:    apiReceipt,
+	}, nil
+}
+
+// receiptPDF lays the receipt out for printing or attaching to an email
+func receiptPDF(r *domain.Receipt) []byte {
+	money := func(cents int64) string {
+		return domain.Money{Amount: cents, Currency: r.Currency}.FormatAmount()
+	}
+	at := func(t time.Time) string {
+		return t.UTC().Format("2006-01-02 15:04 UTC")
+	}
+
+	doc := pdf.New().
+		Heading("Payment receipt").
+		Gap().
+		Line("Order: " + r.OrderID).
+		Line("Payment: " + r.PaymentID).
+		Line("Status: " + string(r.Status))
+	if r.CardLast4 != "" {
+		doc.Line("Card: " + r.CardBrand + " ending in " + r.CardLast4)
+	}
+
+	doc.Gap().
+		Line("Authorized: " + money(r.AmountCents) + " on " + at(r.AuthorizedAt)).
+		Line("Bank authorization: " + r.BankAuthID)
+	if r.VoidedAt != nil {
+		doc.Line("Voided on " + at(*r.VoidedAt))
+	}
+
+	for _, entry := range r.Entries {
+		kind := "Captured"
+		if entry.Type == domain.OperationRefund {
+			kind = "Refunded"
+		}
+		line := kind + ": " + money(entry.AmountCents) + " on " + at(entry.CompletedAt)
+		if entry.BankReferenceID != "" {
+			line += " (" + entry.BankReferenceID + ")"
+		}
+		doc.Line(line)
+	}
+
+	return doc.Gap().
+		Line("Total paid: " + money(r.NetCents())).
+		Bytes()
+}
+
+func mapReceiptErrorToAPIResponse(err error) (api.GetPaymentReceiptResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPaymentReceipt404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.GetPaymentReceipt409JSONResponse(errorResponse), nil
+	default:
+		return api.GetPaymentReceipt500JSONResponse(errorResponse), nil
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+const (
+	defaultReconciliationIssues = 100
+	maxReconciliationIssues     = 500
+)
+
+func (h *Handlers) Reconcile(
+	ctx context.Context,
+	request api.ReconcileRequestObject,
+) (api.ReconcileResponseObject, error) {
+	result, err := h.reconciliationService.Reconcile(ctx, request.Body.From, request.Body.To)
+	if err != nil {
+		return mapReconcileErrorToAPIResponse(err)
+	}
+
+	h.logger.Info("reconciled with bank",
+		"from", result.From,
+		"to", result.To,
+		"checked", result.Checked,
+		"issues", len(result.Issues),
+		"next_from", result.NextFrom)
+
+	issues, err := ToAPIReconciliationIssues(result.Issues)
+	if err != nil {
+		return mapReconcileErrorToAPIResponse(err)
+	}
+
+	return api.Reconcile200JSONResponse{
+		Success: true,
+		Data: api.Reconciliation{
+			From:     result.From,
+			To:       result.To,
+			Checked:  result.Checked,
+			NextFrom: result.NextFrom,
+			Issues:   issues,
+		},
+	}, nil
+}
+
+func (h *Handlers) GetReconciliationIssues(
+	ctx context.Context,
+	request api.GetReconciliationIssuesRequestObject,
+) (api.GetReconciliationIssuesResponseObject, error) {
+	limit := request.Params.Limit
+	if limit <= 0 {
+		limit = defaultReconciliationIssues
+	}
+	limit = min(limit, maxReconciliationIssues)
+
+	issues, err := h.reconciliationService.Issues(ctx, request.Params.Since, limit)
+	if err != nil {
+		return mapGetReconciliationIssuesErrorToAPIResponse(err)
+	}
+
+	apiIssues, err := ToAPIReconciliationIssues(issues)
+	if err != nil {
+		return mapGetReconciliationIssuesErrorToAPIResponse(err)
+	}
+
+	return api.GetReconciliationIssues200JSONResponse{
+		Success: true,
+		Data:    apiIssues,
+	}, nil
+}
+
+func (h *Handlers) RecordBankRefunds(
+	ctx context.Context,
+	request api.RecordBankRefundsRequestObject,
+) (api.RecordBankRefundsResponseObject, error) {
+	refunds := make([]domain.BankRefund, 0, len(request.Body.Refunds))
+	for _, refund := range request.Body.Refunds {
+		refunds = append(refunds, domain.BankRefund{
+			BankRefundID: refund.RefundId,
+			BankAuthID:   refund.AuthorizationId,
+			AmountCents:  refund.Amount,
+			RefundedAt:   refund.RefundedAt,
+		})
+	}
+
+	results, err := h.bankRefundService.Record(ctx, refunds)
+	if err != nil {
+		return mapRecordBankRefundsErrorToAPIResponse(err)
+	}
+
+	data := make([]api.BankRefundResult, 0, len(results))
+	for _, result := range results {
+		apiResult := api.BankRefundResult{
+			RefundId:        result.Refund.BankRefundID,
+			AuthorizationId: result.Refund.BankAuthID,
+			Outcome:         api.BankRefundResultOutcome(result.Outcome),
+		}
+		if result.Operation != nil {
+			apiResult.Refund, err = ToAPIOperation(result.Operation)
+			if err != nil {
+				return mapRecordBankRefundsErrorToAPIResponse(err)
+			}
+		}
+		if result.Issue != nil {
+			issues, err := ToAPIReconciliationIssues([]*domain.ReconciliationIssue{result.Issue})
+			if err != nil {
+				return mapRecordBankRefundsErrorToAPIResponse(err)
+			}
+			apiResult.Issue = issues[0]
+			h.logger.Warn("bank refund not recorded",
+				"refund_id", result.Refund.BankRefundID,
+				"bank_auth_id", result.Refund.BankAuthID,
+				"payment_id", result.Issue.PaymentID)
+		}
+		data = append(data, apiResult)
+	}
+
+	return api.RecordBankRefunds200JSONResponse{
+		Success: true,
+		Data:    data,
+	}, nil
+}
+
+func mapReconcileErrorToAPIResponse(err error) (api.ReconcileResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.Reconcile400JSONResponse(errorResponse), nil
+	default:
+		return api.Reconcile500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetReconciliationIssuesErrorToAPIResponse(err error) (api.GetReconciliationIssuesResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetReconciliationIssues500JSONResponse(errorResponse), nil
+}
+
+func mapRecordBankRefundsErrorToAPIResponse(err error) (api.RecordBankRefundsResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.RecordBankRefunds400JSONResponse(errorResponse), nil
+	default:
+		return api.RecordBankRefunds500JSONResponse(errorResponse), nil
+	}
+}
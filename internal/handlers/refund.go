@@ -5,6 +5,12 @@ import (
 	"net/http"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+const (
+	defaultRefundApprovals = 100
+	maxRefundApprovals     = 500
 )
 
 func (h *Handlers) RefundPayment(
@@ -15,7 +21,7 @@ func (h *Handlers) RefundPayment(
 	idempotencyKey := request.Params.IdempotencyKey
 
 	paymentID := req.PaymentId.String()
-	payment, err := h.refundService.Refund(ctx, paymentID, idempotencyKey)
+	payment, err := h.refundService.Refund(ctx, paymentID, req.Amount, domain.OperationReason(req.Reason), idempotencyKey)
 	if err != nil {
 		return mapRefundServiceErrorToAPIResponse(err)
 	}
@@ -25,12 +31,91 @@ func (h *Handlers) RefundPayment(
 		return mapRefundServiceErrorToAPIResponse(err)
 	}
 
+	// The payment of a refund held for approval is unchanged, so only the refund
+	// itself tells
+	operation, err := h.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		return mapRefundServiceErrorToAPIResponse(err)
+	}
+	if operation.Status == domain.OperationPendingApproval {
+		return api.RefundPayment202JSONResponse{
+			Success: true,
+			Data:    apiPayment,
+		}, nil
+	}
+
 	return api.RefundPayment200JSONResponse{
 		Success: true,
 		Data:    apiPayment,
 	}, nil
 }
 
+func (h *Handlers) GetRefundApprovals(
+	ctx context.Context,
+	request api.GetRefundApprovalsRequestObject,
+) (api.GetRefundApprovalsResponseObject, error) {
+	limit := request.Params.Limit
+	if limit <= 0 {
+		limit = defaultRefundApprovals
+	}
+	limit = min(limit, maxRefundApprovals)
+
+	refunds, err := h.refundService.PendingApproval(ctx, limit)
+	if err != nil {
+		return mapGetRefundApprovalsErrorToAPIResponse(err)
+	}
+
+	apiRefunds, err := ToAPIOperations(refunds)
+	if err != nil {
+		return mapGetRefundApprovalsErrorToAPIResponse(err)
+	}
+
+	return api.GetRefundApprovals200JSONResponse{
+		Success: true,
+		Data:    apiRefunds,
+	}, nil
+}
+
+func (h *Handlers) ApproveRefund(
+	ctx context.Context,
+	request api.ApproveRefundRequestObject,
+) (api.ApproveRefundResponseObject, error) {
+	refund, err := h.refundService.Approve(ctx, request.RefundID.String())
+	if err != nil {
+		return mapApproveRefundErrorToAPIResponse(err)
+	}
+
+	apiRefund, err := ToAPIOperation(refund)
+	if err != nil {
+		return mapApproveRefundErrorToAPIResponse(err)
+	}
+
+	return api.ApproveRefund200JSONResponse{
+		Success: true,
+		Data:    apiRefund,
+	}, nil
+}
+
+func (h *Handlers) RejectRefund(
+	ctx context.Context,
+	request api.RejectRefundRequestObject,
+) (api.RejectRefundResponseObject, error) {
+	refund, err := h.refundService.Reject(ctx, request.RefundID.String())
+	if err != nil {
+		return mapRejectRefundErrorToAPIResponse(err)
+	}
+
+	apiRefund, err := ToAPIOperation(refund)
+	if err != nil {
+		return mapRejectRefundErrorToAPIResponse(err)
+	}
+
+	return api.RejectRefund200JSONResponse{
+		Success: true,
+		Data:    apiRefund,
+	}, nil
+}
+
 func mapRefundServiceErrorToAPIResponse(err error) (api.RefundPaymentResponseObject, error) {
 	statusCode, errorResponse := BuildErrorResponse(err)
 
@@ -43,9 +128,52 @@ func mapRefundServiceErrorToAPIResponse(err error) (api.RefundPaymentResponseObj
 		return api.RefundPayment408JSONResponse(errorResponse), nil
 	case http.StatusConflict:
 		return api.RefundPayment409JSONResponse(errorResponse), nil
+	case http.StatusTooManyRequests:
+		return api.RefundPayment429JSONResponse(errorResponse), nil
 	case http.StatusInternalServerError:
 		return api.RefundPayment500JSONResponse(errorResponse), nil
 	default:
 		return api.RefundPayment500JSONResponse(errorResponse), nil
 	}
 }
+
+func mapGetRefundApprovalsErrorToAPIResponse(err error) (api.GetRefundApprovalsResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetRefundApprovals500JSONResponse(errorResponse), nil
+}
+
+func mapApproveRefundErrorToAPIResponse(err error) (api.ApproveRefundResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return api.ApproveRefund401JSONResponse(errorResponse), nil
+	case http.StatusForbidden:
+		return api.ApproveRefund403JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.ApproveRefund404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.ApproveRefund409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.ApproveRefund500JSONResponse(errorResponse), nil
+	default:
+		return api.ApproveRefund500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapRejectRefundErrorToAPIResponse(err error) (api.RejectRefundResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return api.RejectRefund401JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.RejectRefund404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.RejectRefund409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.RejectRefund500JSONResponse(errorResponse), nil
+	default:
+		return api.RejectRefund500JSONResponse(errorResponse), nil
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+)
+
+const (
+	defaultReviews = 100
+	maxReviews     = 500
+)
+
+func (h *Handlers) GetReviews(
+	ctx context.Context,
+	request api.GetReviewsRequestObject,
+) (api.GetReviewsResponseObject, error) {
+	limit := request.Params.Limit
+	if limit <= 0 {
+		limit = defaultReviews
+	}
+	limit = min(limit, maxReviews)
+
+	queued, err := h.reviewService.Queue(ctx, limit)
+	if err != nil {
+		return mapGetReviewsErrorToAPIResponse(err)
+	}
+
+	apiReviews, err := ToAPIPaymentReviews(queued)
+	if err != nil {
+		return mapGetReviewsErrorToAPIResponse(err)
+	}
+
+	return api.GetReviews200JSONResponse{
+		Success: true,
+		Data:    apiReviews,
+	}, nil
+}
+
+func (h *Handlers) ApproveReview(
+	ctx context.Context,
+	request api.ApproveReviewRequestObject,
+) (api.ApproveReviewResponseObject, error) {
+	payment, err := h.reviewService.Approve(ctx, request.PaymentID.String())
+	if err != nil {
+		return mapApproveReviewErrorToAPIResponse(err)
+	}
+
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapApproveReviewErrorToAPIResponse(err)
+	}
+
+	return api.ApproveReview200JSONResponse{
+		Success: true,
+		Data:    apiPayment,
+	}, nil
+}
+
+func (h *Handlers) DeclineReview(
+	ctx context.Context,
+	request api.DeclineReviewRequestObject,
+) (api.DeclineReviewResponseObject, error) {
+	payment, err := h.reviewService.Decline(ctx, request.PaymentID.String())
+	if err != nil {
+		return mapDeclineReviewErrorToAPIResponse(err)
+	}
+
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapDeclineReviewErrorToAPIResponse(err)
+	}
+
+	return api.DeclineReview200JSONResponse{
+		Success: true,
+		Data:    apiPayment,
+	}, nil
+}
+
+func mapGetReviewsErrorToAPIResponse(err error) (api.GetReviewsResponseObject, error) {
+	_, errorResponse := BuildErrorResponse(err)
+	return api.GetReviews500JSONResponse(errorResponse), nil
+}
+
+func mapApproveReviewErrorToAPIResponse(err error) (api.ApproveReviewResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return api.ApproveReview401JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.ApproveReview404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.ApproveReview409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.ApproveReview500JSONResponse(errorResponse), nil
+	default:
+		return api.ApproveReview500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapDeclineReviewErrorToAPIResponse(err error) (api.DeclineReviewResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return api.DeclineReview401JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.DeclineReview404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.DeclineReview409JSONResponse(errorResponse), nil
+	case http.StatusInternalServerError:
+		return api.DeclineReview500JSONResponse(errorResponse), nil
+	default:
+		return api.DeclineReview500JSONResponse(errorResponse), nil
+	}
+}
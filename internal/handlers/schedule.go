@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+)
+
+func (h *Handlers) CreatePaymentMethod(
+	ctx context.Context,
+	request api.CreatePaymentMethodRequestObject,
+) (api.CreatePaymentMethodResponseObject, error) {
+	req := request.Body
+
+	cmd := services.SavePaymentMethodCommand{
+		CustomerID:  req.CustomerId,
+		CardNumber:  req.CardNumber,
+		ExpiryMonth: req.ExpiryMonth,
+		ExpiryYear:  req.ExpiryYear,
+	}
+
+	paymentMethod, err := h.paymentMethodService.Save(ctx, &cmd)
+	if err != nil {
+		return mapCreatePaymentMethodErrorToAPIResponse(err)
+	}
+
+	apiPaymentMethod, err := ToAPIPaymentMethod(paymentMethod)
+	if err != nil {
+		return mapCreatePaymentMethodErrorToAPIResponse(err)
+	}
+
+	return api.CreatePaymentMethod201JSONResponse{
+		Success: true,
+		Data:    apiPaymentMethod,
+	}, nil
+}
+
+func (h *Handlers) GetPaymentMethod(
+	ctx context.Context,
+	request api.GetPaymentMethodRequestObject,
+) (api.GetPaymentMethodResponseObject, error) {
+	paymentMethod, err := h.paymentMethodService.Get(ctx, request.PaymentMethodID.String())
+	if err != nil {
+		return mapGetPaymentMethodErrorToAPIResponse(err)
+	}
+
+	apiPaymentMethod, err := ToAPIPaymentMethod(paymentMethod)
+	if err != nil {
+		return mapGetPaymentMethodErrorToAPIResponse(err)
+	}
+
+	return api.GetPaymentMethod200JSONResponse{
+		Success: true,
+		Data:    apiPaymentMethod,
+	}, nil
+}
+
+func (h *Handlers) SchedulePayment(
+	ctx context.Context,
+	request api.SchedulePaymentRequestObject,
+) (api.SchedulePaymentResponseObject, error) {
+	req := request.Body
+
+	cmd := services.ScheduleCommand{
+		OrderID:         req.OrderId,
+		CustomerID:      req.CustomerId,
+		Amount:          req.Amount,
+		Currency:        "USD",
+		PaymentMethodID: req.PaymentMethodId.String(),
+		ScheduledFor:    req.ScheduledFor,
+	}
+
+	payment, err := h.scheduleService.Schedule(ctx, &cmd, request.Params.IdempotencyKey)
+	if err != nil {
+		return mapSchedulePaymentErrorToAPIResponse(err)
+	}
+
+	apiPayment, err := ToAPIPayment(payment)
+	if err != nil {
+		return mapSchedulePaymentErrorToAPIResponse(err)
+	}
+
+	return api.SchedulePayment201JSONResponse{
+		Success: true,
+		Data:    apiPayment,
+	}, nil
+}
+
+func mapCreatePaymentMethodErrorToAPIResponse(err error) (api.CreatePaymentMethodResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreatePaymentMethod400JSONResponse(errorResponse), nil
+	default:
+		return api.CreatePaymentMethod500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetPaymentMethodErrorToAPIResponse(err error) (api.GetPaymentMethodResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetPaymentMethod404JSONResponse(errorResponse), nil
+	default:
+		return api.GetPaymentMethod500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapSchedulePaymentErrorToAPIResponse(err error) (api.SchedulePaymentResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.SchedulePayment400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.SchedulePayment404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.SchedulePayment409JSONResponse(errorResponse), nil
+	case http.StatusTooManyRequests:
+		return api.SchedulePayment429JSONResponse(errorResponse), nil
+	default:
+		return api.SchedulePayment500JSONResponse(errorResponse), nil
+	}
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+func (h *Handlers) CreateSubscription(
+	ctx context.Context,
+	request api.CreateSubscriptionRequestObject,
+) (api.CreateSubscriptionResponseObject, error) {
+	req := request.Body
+
+	cmd := services.CreateSubscriptionCommand{
+		CustomerID:      req.CustomerId,
+		PaymentMethodID: req.PaymentMethodId.String(),
+		Plan:            req.Plan,
+		Amount:          req.Amount,
+		Currency:        "USD",
+		Interval:        domain.BillingInterval(req.Interval),
+		StartAt:         req.StartAt,
+	}
+
+	sub, err := h.subscriptionService.Create(ctx, &cmd)
+	if err != nil {
+		return mapCreateSubscriptionErrorToAPIResponse(err)
+	}
+
+	apiSubscription, err := ToAPISubscription(sub)
+	if err != nil {
+		return mapCreateSubscriptionErrorToAPIResponse(err)
+	}
+
+	return api.CreateSubscription201JSONResponse{
+		Success: true,
+		Data:    apiSubscription,
+	}, nil
+}
+
+func (h *Handlers) GetSubscription(
+	ctx context.Context,
+	request api.GetSubscriptionRequestObject,
+) (api.GetSubscriptionResponseObject, error) {
+	sub, err := h.subscriptionService.Get(ctx, request.SubscriptionID.String())
+	if err != nil {
+		return mapGetSubscriptionErrorToAPIResponse(err)
+	}
+
+	apiSubscription, err := ToAPISubscription(sub)
+	if err != nil {
+		return mapGetSubscriptionErrorToAPIResponse(err)
+	}
+
+	return api.GetSubscription200JSONResponse{
+		Success: true,
+		Data:    apiSubscription,
+	}, nil
+}
+
+func (h *Handlers) CancelSubscription(
+	ctx context.Context,
+	request api.CancelSubscriptionRequestObject,
+) (api.CancelSubscriptionResponseObject, error) {
+	sub, err := h.subscriptionService.Cancel(ctx, request.SubscriptionID.String())
+	if err != nil {
+		return mapCancelSubscriptionErrorToAPIResponse(err)
+	}
+
+	apiSubscription, err := ToAPISubscription(sub)
+	if err != nil {
+		return mapCancelSubscriptionErrorToAPIResponse(err)
+	}
+
+	return api.CancelSubscription200JSONResponse{
+		Success: true,
+		Data:    apiSubscription,
+	}, nil
+}
+
+func mapCreateSubscriptionErrorToAPIResponse(err error) (api.CreateSubscriptionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return api.CreateSubscription400JSONResponse(errorResponse), nil
+	case http.StatusNotFound:
+		return api.CreateSubscription404JSONResponse(errorResponse), nil
+	default:
+		return api.CreateSubscription500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapGetSubscriptionErrorToAPIResponse(err error) (api.GetSubscriptionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.GetSubscription404JSONResponse(errorResponse), nil
+	default:
+		return api.GetSubscription500JSONResponse(errorResponse), nil
+	}
+}
+
+func mapCancelSubscriptionErrorToAPIResponse(err error) (api.CancelSubscriptionResponseObject, error) {
+	statusCode, errorResponse := BuildErrorResponse(err)
+
+	switch statusCode {
+	case http.StatusNotFound:
+		return api.CancelSubscription404JSONResponse(errorResponse), nil
+	case http.StatusConflict:
+		return api.CancelSubscription409JSONResponse(errorResponse), nil
+	default:
+		return api.CancelSubscription500JSONResponse(errorResponse), nil
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 )
 
 func (h *Handlers) VoidPayment(
@@ -15,7 +16,7 @@ func (h *Handlers) VoidPayment(
 	idempotencyKey := request.Params.IdempotencyKey
 
 	paymentID := req.PaymentId.String()
-	payment, err := h.voidService.Void(ctx, paymentID, idempotencyKey)
+	payment, err := h.voidService.Void(ctx, paymentID, domain.OperationReason(req.Reason), req.Amount, idempotencyKey)
 	if err != nil {
 		return mapVoidServiceErrorToAPIResponse(err)
 	}
@@ -43,6 +44,8 @@ func mapVoidServiceErrorToAPIResponse(err error) (api.VoidPaymentResponseObject,
 		return api.VoidPayment408JSONResponse(errorResponse), nil
 	case http.StatusConflict:
 		return api.VoidPayment409JSONResponse(errorResponse), nil
+	case http.StatusTooManyRequests:
+		return api.VoidPayment429JSONResponse(errorResponse), nil
 	case http.StatusInternalServerError:
 		return api.VoidPayment500JSONResponse(errorResponse), nil
 	default:
@@ -0,0 +1,309 @@
+// Package health reports whether the gateway and what it depends on are working: the
+// database, the acquirers, the outbox and the background workers. The report is served
+// without authentication, so failures are described by status only and their errors
+// go to the log.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+type Status string
+
+const (
+	StatusOK       Status = "ok"
+	StatusDegraded Status = "degraded"
+	StatusDown     Status = "down"
+)
+
+// staleAfter is how many intervals a worker may go without finishing a run before it
+// is reported as stalled
+const staleAfter = 3
+
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type OutboxBacklog interface {
+	Backlog(ctx context.Context) (int, *time.Time, error)
+}
+
+type Queue interface {
+	Pending() int
+	Capacity() int
+}
+
+type Region interface {
+	State(ctx context.Context) domain.RegionState
+}
+
+type Report struct {
+	Status         Status         `json:"status"`
+	CheckedAt      time.Time      `json:"checked_at"`
+	Database       DatabaseReport `json:"database"`
+	Banks          []BankReport   `json:"banks"`
+	Outbox         OutboxReport   `json:"outbox"`
+	Workers        []WorkerReport `json:"workers"`
+	AuthorizeQueue *QueueReport   `json:"authorize_queue,omitempty"`
+	Region         *RegionReport  `json:"region,omitempty"`
+}
+
+type DatabaseReport struct {
+	Status    Status `json:"status"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type BankReport struct {
+	Name       string `json:"name"`
+	Status     Status `json:"status"`
+	LatencyMs  int64  `json:"latency_ms"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+}
+
+type OutboxReport struct {
+	Status           Status  `json:"status"`
+	Pending          int     `json:"pending"`
+	OldestAgeSeconds float64 `json:"oldest_age_seconds"`
+}
+
+type WorkerReport struct {
+	Name            string     `json:"name"`
+	Status          Status     `json:"status"`
+	LastRun         *time.Time `json:"last_run"`
+	IntervalSeconds float64    `json:"interval_seconds"`
+}
+
+type QueueReport struct {
+	Status   Status `json:"status"`
+	Pending  int    `json:"pending"`
+	Capacity int    `json:"capacity"`
+}
+
+type RegionReport struct {
+	Name   string `json:"name"`
+	Active bool   `json:"active"`
+	Epoch  int64  `json:"epoch"`
+}
+
+type bank struct {
+	name string
+	url  string
+}
+
+type worker struct {
+	name     string
+	interval time.Duration
+	lastRun  func() time.Time
+}
+
+// Checker runs the checks on every request. Only an unreachable database makes the
+// gateway down; anything else failing leaves it degraded, since payments can still be
+// accepted and are caught up on once the dependency recovers.
+type Checker struct {
+	db           Pinger
+	outbox       OutboxBacklog
+	outboxMaxAge time.Duration
+	banks        []bank
+	workers      []worker
+	queue        Queue
+	region       Region
+	client       *http.Client
+	timeout      time.Duration
+	startedAt    time.Time
+	logger       *slog.Logger
+}
+
+// NewChecker builds a checker whose checks each give up after timeout. The outbox is
+// degraded once its oldest undelivered event is older than outboxMaxAge.
+func NewChecker(db Pinger, outbox OutboxBacklog, outboxMaxAge, timeout time.Duration, logger *slog.Logger) *Checker {
+	return &Checker{
+		db:           db,
+		outbox:       outbox,
+		outboxMaxAge: outboxMaxAge,
+		client:       &http.Client{Timeout: timeout},
+		timeout:      timeout,
+		startedAt:    time.Now(),
+		logger:       logger,
+	}
+}
+
+// AddBank probes baseURL on every check. Any response below 500 counts as reachable,
+// since the acquirers have no health route of their own.
+func (c *Checker) AddBank(name, baseURL string) {
+	c.banks = append(c.banks, bank{name: name, url: baseURL})
+}
+
+// AddWorker reports the worker as stalled once it has gone staleAfter intervals without
+// finishing a run, counted from startup before its first one
+func (c *Checker) AddWorker(name string, interval time.Duration, lastRun func() time.Time) {
+	c.workers = append(c.workers, worker{name: name, interval: interval, lastRun: lastRun})
+}
+
+// SetAuthorizeQueue reports the queue as degraded while it is full and new
+// authorizations fall back to being made synchronously
+func (c *Checker) SetAuthorizeQueue(q Queue) {
+	c.queue = q
+}
+
+// SetRegion reports whether the region takes writes. The workers of a region on standby
+// are held, so they are not reported as stalled.
+func (c *Checker) SetRegion(r Region) {
+	c.region = r
+}
+
+func (c *Checker) Check(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	report := Report{
+		CheckedAt: time.Now().UTC(),
+		Banks:     make([]BankReport, len(c.banks)),
+		Workers:   make([]WorkerReport, len(c.workers)),
+	}
+
+	var wg sync.WaitGroup
+	wg.Go(func() { report.Database = c.checkDatabase(ctx) })
+	wg.Go(func() { report.Outbox = c.checkOutbox(ctx, report.CheckedAt) })
+	for i, b := range c.banks {
+		wg.Go(func() { report.Banks[i] = c.checkBank(ctx, b) })
+	}
+	wg.Wait()
+
+	standby := false
+	if c.region != nil {
+		state := c.region.State(ctx)
+		report.Region = &RegionReport{Name: state.Region, Active: state.Active, Epoch: state.Epoch}
+		standby = !state.Active
+	}
+
+	for i, w := range c.workers {
+		report.Workers[i] = c.checkWorker(w, report.CheckedAt, standby)
+	}
+	if c.queue != nil {
+		report.AuthorizeQueue = c.checkQueue()
+	}
+
+	report.Status = overallStatus(report)
+	return report
+}
+
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := c.Check(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status == StatusDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report) //nolint:errcheck // client went away
+}
+
+func (c *Checker) checkDatabase(ctx context.Context) DatabaseReport {
+	start := time.Now()
+	err := c.db.Ping(ctx)
+	report := DatabaseReport{Status: StatusOK, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		c.logger.Warn("health check: database unreachable", "error", err)
+		report.Status = StatusDown
+	}
+	return report
+}
+
+func (c *Checker) checkBank(ctx context.Context, b bank) BankReport {
+	report := BankReport{Name: b.name, Status: StatusOK}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.url, nil)
+	if err != nil {
+		c.logger.Warn("health check: bad bank url", "bank", b.name, "error", err)
+		report.Status = StatusDown
+		return report
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	report.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		c.logger.Warn("health check: bank unreachable", "bank", b.name, "error", err)
+		report.Status = StatusDown
+		return report
+	}
+	resp.Body.Close()
+
+	report.HTTPStatus = resp.StatusCode
+	if resp.StatusCode >= http.StatusInternalServerError {
+		report.Status = StatusDown
+	}
+	return report
+}
+
+func (c *Checker) checkOutbox(ctx context.Context, now time.Time) OutboxReport {
+	pending, oldest, err := c.outbox.Backlog(ctx)
+	if err != nil {
+		c.logger.Warn("health check: outbox backlog unavailable", "error", err)
+		return OutboxReport{Status: StatusDown}
+	}
+
+	report := OutboxReport{Status: StatusOK, Pending: pending}
+	if oldest != nil {
+		age := now.Sub(*oldest)
+		report.OldestAgeSeconds = age.Seconds()
+		if age > c.outboxMaxAge {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (c *Checker) checkWorker(w worker, now time.Time, standby bool) WorkerReport {
+	report := WorkerReport{Name: w.name, Status: StatusOK, IntervalSeconds: w.interval.Seconds()}
+
+	since := c.startedAt
+	if lastRun := w.lastRun(); !lastRun.IsZero() {
+		lastRun = lastRun.UTC()
+		report.LastRun = &lastRun
+		since = lastRun
+	}
+	if !standby && now.Sub(since) > staleAfter*w.interval {
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+func (c *Checker) checkQueue() *QueueReport {
+	report := &QueueReport{Status: StatusOK, Pending: c.queue.Pending(), Capacity: c.queue.Capacity()}
+	if report.Pending >= report.Capacity {
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+func overallStatus(r Report) Status {
+	if r.Database.Status != StatusOK {
+		return StatusDown
+	}
+
+	statuses := []Status{r.Outbox.Status}
+	for _, b := range r.Banks {
+		statuses = append(statuses, b.Status)
+	}
+	for _, w := range r.Workers {
+		statuses = append(statuses, w.Status)
+	}
+	if r.AuthorizeQueue != nil {
+		statuses = append(statuses, r.AuthorizeQueue.Status)
+	}
+
+	for _, s := range statuses {
+		if s != StatusOK {
+			return StatusDegraded
+		}
+	}
+	return StatusOK
+}
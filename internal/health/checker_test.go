@@ -0,0 +1,166 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDB struct{ err error }
+
+func (f fakeDB) Ping(context.Context) error { return f.err }
+
+type fakeOutbox struct {
+	pending int
+	oldest  *time.Time
+}
+
+func (f fakeOutbox) Backlog(context.Context) (int, *time.Time, error) {
+	return f.pending, f.oldest, nil
+}
+
+type fakeQueue struct{ pending, capacity int }
+
+func (f fakeQueue) Pending() int  { return f.pending }
+func (f fakeQueue) Capacity() int { return f.capacity }
+
+type fakeRegion struct{ state domain.RegionState }
+
+func (f fakeRegion) State(context.Context) domain.RegionState { return f.state }
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func serve(t *testing.T, c *health.Checker) (int, health.Report) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	c.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var report health.Report
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	return rec.Code, report
+}
+
+func TestChecker_AllHealthy(t *testing.T) {
+	bank := httptest.NewServer(http.NotFoundHandler())
+	defer bank.Close()
+
+	c := health.NewChecker(fakeDB{}, fakeOutbox{}, time.Minute, time.Second, discard)
+	c.AddBank("primary", bank.URL)
+	c.AddWorker("retry", time.Minute, func() time.Time { return time.Now() })
+	c.SetAuthorizeQueue(fakeQueue{pending: 1, capacity: 10})
+
+	code, report := serve(t, c)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, health.StatusOK, report.Status)
+	require.Len(t, report.Banks, 1)
+	assert.Equal(t, health.StatusOK, report.Banks[0].Status)
+	assert.Equal(t, http.StatusNotFound, report.Banks[0].HTTPStatus)
+	require.Len(t, report.Workers, 1)
+	assert.NotNil(t, report.Workers[0].LastRun)
+	assert.Equal(t, 1, report.AuthorizeQueue.Pending)
+}
+
+func TestChecker_DatabaseDownIsUnavailable(t *testing.T) {
+	c := health.NewChecker(fakeDB{err: errors.New("connection refused")}, fakeOutbox{}, time.Minute, time.Second, discard)
+
+	code, report := serve(t, c)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusDown, report.Database.Status)
+}
+
+func TestChecker_DegradedDependencies(t *testing.T) {
+	failingBank := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failingBank.Close()
+
+	oldest := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name  string
+		setup func(c *health.Checker)
+		check func(t *testing.T, r health.Report)
+	}{
+		{
+			name:  "bank erroring",
+			setup: func(c *health.Checker) { c.AddBank("primary", failingBank.URL) },
+			check: func(t *testing.T, r health.Report) {
+				assert.Equal(t, health.StatusDown, r.Banks[0].Status)
+			},
+		},
+		{
+			name: "worker stalled",
+			setup: func(c *health.Checker) {
+				c.AddWorker("outbox", time.Second, func() time.Time { return time.Now().Add(-time.Minute) })
+			},
+			check: func(t *testing.T, r health.Report) {
+				assert.Equal(t, health.StatusDegraded, r.Workers[0].Status)
+			},
+		},
+		{
+			name:  "authorize queue full",
+			setup: func(c *health.Checker) { c.SetAuthorizeQueue(fakeQueue{pending: 10, capacity: 10}) },
+			check: func(t *testing.T, r health.Report) {
+				assert.Equal(t, health.StatusDegraded, r.AuthorizeQueue.Status)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := health.NewChecker(fakeDB{}, fakeOutbox{}, time.Minute, time.Second, discard)
+			tt.setup(c)
+
+			code, report := serve(t, c)
+
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, health.StatusDegraded, report.Status)
+			tt.check(t, report)
+		})
+	}
+
+	t.Run("outbox behind", func(t *testing.T) {
+		c := health.NewChecker(fakeDB{}, fakeOutbox{pending: 3, oldest: &oldest}, time.Minute, time.Second, discard)
+
+		_, report := serve(t, c)
+
+		assert.Equal(t, health.StatusDegraded, report.Status)
+		assert.Equal(t, 3, report.Outbox.Pending)
+		assert.InDelta(t, time.Hour.Seconds(), report.Outbox.OldestAgeSeconds, 5)
+	})
+}
+
+func TestChecker_WorkerWithinGraceBeforeFirstRun(t *testing.T) {
+	c := health.NewChecker(fakeDB{}, fakeOutbox{}, time.Minute, time.Second, discard)
+	c.AddWorker("payout", time.Minute, func() time.Time { return time.Time{} })
+
+	_, report := serve(t, c)
+
+	assert.Equal(t, health.StatusOK, report.Workers[0].Status)
+	assert.Nil(t, report.Workers[0].LastRun)
+}
+
+func TestChecker_StandbyRegionHoldsWorkers(t *testing.T) {
+	c := health.NewChecker(fakeDB{}, fakeOutbox{}, time.Minute, time.Second, discard)
+	c.AddWorker("outbox", time.Second, func() time.Time { return time.Now().Add(-time.Minute) })
+	c.SetRegion(fakeRegion{domain.RegionState{Region: "us-east", Epoch: 3}})
+
+	_, report := serve(t, c)
+
+	assert.Equal(t, health.StatusOK, report.Status)
+	assert.Equal(t, &health.RegionReport{Name: "us-east", Active: false, Epoch: 3}, report.Region)
+}
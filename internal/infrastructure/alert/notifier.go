@@ -0,0 +1,51 @@
+package alert
+
+import (
+	"context"
+	"log/slog"
+)
+
+type Sender interface {
+	Send(ctx context.Context, a Alert) error
+}
+
+// Claims remembers which alerts were sent, across gateway instances
+type Claims interface {
+	Claim(ctx context.Context, key string) (bool, error)
+	Release(ctx context.Context, key string) error
+}
+
+// Notifier sends each alert once. One that cannot be sent is released again, so
+// whoever notices the problem next sends it.
+type Notifier struct {
+	sender Sender
+	claims Claims
+	logger *slog.Logger
+}
+
+func NewNotifier(sender Sender, claims Claims, logger *slog.Logger) *Notifier {
+	return &Notifier{sender: sender, claims: claims, logger: logger}
+}
+
+// Notify sends a unless an alert with its key was sent before. Failures are logged
+// with the alert, so it is not lost while the webhook is down.
+func (n *Notifier) Notify(ctx context.Context, a Alert) {
+	claimed, err := n.claims.Claim(ctx, a.Key)
+	if err != nil {
+		n.logger.Error("failed to claim alert", "key", a.Key, "summary", a.Summary, "error", err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	if err := n.sender.Send(ctx, a); err != nil {
+		n.logger.Error("failed to send alert", "key", a.Key, "summary", a.Summary, "error", err)
+		if err := n.claims.Release(context.WithoutCancel(ctx), a.Key); err != nil {
+			n.logger.Error("failed to release alert", "key", a.Key, "error", err)
+		}
+		return
+	}
+
+	n.logger.Info("alert sent", "key", a.Key, "severity", a.Severity)
+}
@@ -0,0 +1,66 @@
+package alert_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/alert"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryClaims map[string]bool
+
+func (c memoryClaims) Claim(_ context.Context, key string) (bool, error) {
+	if c[key] {
+		return false, nil
+	}
+	c[key] = true
+	return true, nil
+}
+
+func (c memoryClaims) Release(_ context.Context, key string) error {
+	delete(c, key)
+	return nil
+}
+
+type recordingSender struct {
+	sent []alert.Alert
+	err  error
+}
+
+func (s *recordingSender) Send(_ context.Context, a alert.Alert) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, a)
+	return nil
+}
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestNotifier_SendsEachKeyOnce(t *testing.T) {
+	sender := &recordingSender{}
+	n := alert.NewNotifier(sender, memoryClaims{}, discard)
+
+	n.Notify(context.Background(), stuckAlert)
+	n.Notify(context.Background(), stuckAlert)
+	n.Notify(context.Background(), alert.Alert{Key: "orphaned_authorization:pay-2"})
+
+	assert.Len(t, sender.sent, 2)
+}
+
+func TestNotifier_RetriesAlertThatFailedToSend(t *testing.T) {
+	sender := &recordingSender{err: errors.New("webhook down")}
+	claims := memoryClaims{}
+	n := alert.NewNotifier(sender, claims, discard)
+
+	n.Notify(context.Background(), stuckAlert)
+	assert.Empty(t, claims)
+
+	sender.err = nil
+	n.Notify(context.Background(), stuckAlert)
+	assert.Len(t, sender.sent, 1)
+}
@@ -0,0 +1,111 @@
+// Package alert tells the people on call about payments that need them, by posting to a
+// Slack or PagerDuty webhook, instead of leaving it to someone reading the logs.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+)
+
+const (
+	FormatSlack     = "slack"
+	FormatPagerDuty = "pagerduty"
+)
+
+type Severity string
+
+const (
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// Alert is one thing someone has to look at. Key identifies what it is about: an
+// alert is sent once per key.
+type Alert struct {
+	Key      string
+	Summary  string
+	Severity Severity
+	Details  map[string]any
+}
+
+// WebhookSender posts alerts in the body a Slack incoming webhook or the PagerDuty
+// Events API v2 expects
+type WebhookSender struct {
+	url        string
+	format     string
+	routingKey string
+	client     *http.Client
+}
+
+// NewWebhookSender builds a sender for url. routingKey is the PagerDuty integration
+// key and is only sent in that format.
+func NewWebhookSender(url, format, routingKey string, timeout time.Duration) *WebhookSender {
+	return &WebhookSender{
+		url:        url,
+		format:     format,
+		routingKey: routingKey,
+		client:     &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *WebhookSender) Send(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(s.body(a))
+	if err != nil {
+		return fmt.Errorf("error marshalling alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating alert request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting alert: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // only drained for connection reuse
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("alert webhook answered %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (s *WebhookSender) body(a Alert) any {
+	if s.format == FormatPagerDuty {
+		return map[string]any{
+			"routing_key":  s.routingKey,
+			"event_action": "trigger",
+			"dedup_key":    a.Key,
+			"payload": map[string]any{
+				"summary":        a.Summary,
+				"source":         "ficmart-payment-gateway",
+				"severity":       a.Severity,
+				"custom_details": a.Details,
+			},
+		}
+	}
+
+	text := fmt.Sprintf("[%s] %s", a.Severity, a.Summary)
+	for _, key := range sortedKeys(a.Details) {
+		text += fmt.Sprintf("\n• %s: %v", key, a.Details[key])
+	}
+	return map[string]any{"text": text}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,65 @@
+package alert_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/alert"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var stuckAlert = alert.Alert{
+	Key:      "stuck:pay-1",
+	Summary:  "Payment pay-1 stuck in CAPTURING for 45m0s",
+	Severity: alert.SeverityWarning,
+	Details:  map[string]any{"status": "CAPTURING", "payment_id": "pay-1"},
+}
+
+func receive(t *testing.T, status int) (*httptest.Server, *map[string]any) {
+	t.Helper()
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &body
+}
+
+func TestWebhookSender_Slack(t *testing.T) {
+	server, body := receive(t, http.StatusOK)
+
+	err := alert.NewWebhookSender(server.URL, alert.FormatSlack, "", time.Second).Send(context.Background(), stuckAlert)
+
+	require.NoError(t, err)
+	assert.Equal(t, "[warning] Payment pay-1 stuck in CAPTURING for 45m0s\n• payment_id: pay-1\n• status: CAPTURING", (*body)["text"])
+}
+
+func TestWebhookSender_PagerDuty(t *testing.T) {
+	server, body := receive(t, http.StatusAccepted)
+
+	err := alert.NewWebhookSender(server.URL, alert.FormatPagerDuty, "routing-key", time.Second).Send(context.Background(), stuckAlert)
+
+	require.NoError(t, err)
+	assert.Equal(t, "routing-key", (*body)["routing_key"])
+	assert.Equal(t, "trigger", (*body)["event_action"])
+	assert.Equal(t, "stuck:pay-1", (*body)["dedup_key"])
+	payload := (*body)["payload"].(map[string]any)
+	assert.Equal(t, stuckAlert.Summary, payload["summary"])
+	assert.Equal(t, "warning", payload["severity"])
+	assert.Equal(t, "CAPTURING", payload["custom_details"].(map[string]any)["status"])
+}
+
+func TestWebhookSender_RejectedAlertFails(t *testing.T) {
+	server, _ := receive(t, http.StatusBadRequest)
+
+	err := alert.NewWebhookSender(server.URL, alert.FormatSlack, "", time.Second).Send(context.Background(), stuckAlert)
+
+	assert.ErrorContains(t, err, "400")
+}
@@ -0,0 +1,294 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand"
+	"sync"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// AcquirerCanary names the acquirer that receives canary traffic
+const AcquirerCanary = "canary"
+
+const defaultCanaryWindowSize = 100
+
+var ErrInvalidCanaryPercent = errors.New("canary percent must be between 0 and 100")
+
+type acquirerKey struct{}
+
+// WithAcquirer tells the CanaryRouter which acquirer a follow-up call belongs to.
+// Captures, voids, refunds and lookups must go to the bank that authorized the payment.
+func WithAcquirer(ctx context.Context, acquirer string) context.Context {
+	return context.WithValue(ctx, acquirerKey{}, acquirer)
+}
+
+func acquirerFromContext(ctx context.Context) string {
+	if acquirer, ok := ctx.Value(acquirerKey{}).(string); ok && acquirer != "" {
+		return acquirer
+	}
+	return domain.DefaultAcquirer
+}
+
+// AcquirerStats counts outcomes of calls sent to one acquirer since startup
+type AcquirerStats struct {
+	Requests  int64
+	Succeeded int64
+	Declined  int64
+	Errored   int64
+}
+
+func (s AcquirerStats) SuccessRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Succeeded) / float64(s.Requests)
+}
+
+type callOutcome int
+
+const (
+	outcomeSucceeded callOutcome = iota
+	outcomeDeclined
+	outcomeErrored
+)
+
+// CanaryRouter sends a percentage of new authorizations to the canary acquirer and
+// everything else to the primary. Follow-up calls are routed by WithAcquirer.
+// When the canary's decline or error rate over the last window of calls exceeds its
+// threshold, the percentage is rolled back to zero until it is raised again.
+type CanaryRouter struct {
+	primary        BankClient
+	canary         BankClient
+	maxDeclineRate float64
+	maxErrorRate   float64
+	windowSize     int
+	logger         *slog.Logger
+
+	flags FeatureFlags
+
+	mu      sync.Mutex
+	percent int
+	stats   map[string]*AcquirerStats
+	window  []callOutcome
+}
+
+func NewCanaryRouter(primary, canary BankClient, cfg config.CanaryConfig, logger *slog.Logger) *CanaryRouter {
+	windowSize := cfg.WindowSize
+	if windowSize == 0 {
+		windowSize = defaultCanaryWindowSize
+	}
+
+	return &CanaryRouter{
+		primary:        primary,
+		canary:         canary,
+		maxDeclineRate: cfg.MaxDeclineRate,
+		maxErrorRate:   cfg.MaxErrorRate,
+		windowSize:     windowSize,
+		logger:         logger,
+		percent:        cfg.Percent,
+		stats: map[string]*AcquirerStats{
+			domain.DefaultAcquirer: {},
+			AcquirerCanary:         {},
+		},
+	}
+}
+
+// FeatureFlags tells whether a feature is on for the merchant in ctx
+type FeatureFlags interface {
+	Enabled(ctx context.Context, name string) bool
+}
+
+// WithFeatureFlags keeps merchants without the canary routing flag on the primary
+// acquirer. Their follow-up calls are unaffected.
+func (r *CanaryRouter) WithFeatureFlags(flags FeatureFlags) *CanaryRouter {
+	r.flags = flags
+	return r
+}
+
+func (r *CanaryRouter) Authorize(ctx context.Context, req AuthorizationRequest, idempotencyKey string) (*AuthorizationResponse, error) {
+	acquirer := r.pick(ctx)
+	resp, err := r.client(acquirer).Authorize(ctx, req, idempotencyKey)
+	r.observe(acquirer, err)
+	if resp != nil {
+		resp.Acquirer = acquirer
+	}
+	return resp, err
+}
+
+func (r *CanaryRouter) Capture(ctx context.Context, req CaptureRequest, idempotencyKey string) (*CaptureResponse, error) {
+	acquirer := acquirerFromContext(ctx)
+	resp, err := r.client(acquirer).Capture(ctx, req, idempotencyKey)
+	r.observe(acquirer, err)
+	return resp, err
+}
+
+func (r *CanaryRouter) Void(ctx context.Context, req VoidRequest, idempotencyKey string) (*VoidResponse, error) {
+	acquirer := acquirerFromContext(ctx)
+	resp, err := r.client(acquirer).Void(ctx, req, idempotencyKey)
+	r.observe(acquirer, err)
+	return resp, err
+}
+
+func (r *CanaryRouter) Refund(ctx context.Context, req RefundRequest, idempotencyKey string) (*RefundResponse, error) {
+	acquirer := acquirerFromContext(ctx)
+	resp, err := r.client(acquirer).Refund(ctx, req, idempotencyKey)
+	r.observe(acquirer, err)
+	return resp, err
+}
+
+// GetAuthorization is not counted in the stats; expiration checks report expired
+// authorizations as 4xx errors, which would read as canary declines.
+func (r *CanaryRouter) GetAuthorization(ctx context.Context, authID string) (*AuthorizationResponse, error) {
+	acquirer := acquirerFromContext(ctx)
+	resp, err := r.client(acquirer).GetAuthorization(ctx, authID)
+	if resp != nil {
+		resp.Acquirer = acquirer
+	}
+	return resp, err
+}
+
+// GetCapture and GetRefund are not counted in the stats either; a lookup that finds
+// nothing is a 404, not a decline.
+func (r *CanaryRouter) GetCapture(ctx context.Context, idempotencyKey string) (*CaptureResponse, error) {
+	return r.client(acquirerFromContext(ctx)).GetCapture(ctx, idempotencyKey)
+}
+
+func (r *CanaryRouter) GetRefund(ctx context.Context, idempotencyKey string) (*RefundResponse, error) {
+	return r.client(acquirerFromContext(ctx)).GetRefund(ctx, idempotencyKey)
+}
+
+// Payouts always go to the primary acquirer and are not counted in the stats; canary
+// routing only applies to authorizations and their follow-up calls.
+func (r *CanaryRouter) Payout(ctx context.Context, req PayoutRequest, idempotencyKey string) (*PayoutResponse, error) {
+	return r.primary.Payout(ctx, req, idempotencyKey)
+}
+
+func (r *CanaryRouter) GetPayout(ctx context.Context, payoutID string) (*PayoutResponse, error) {
+	return r.primary.GetPayout(ctx, payoutID)
+}
+
+// Percent returns the share of new authorizations currently sent to the canary
+func (r *CanaryRouter) Percent() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.percent
+}
+
+// SetPercent changes the canary share and starts a fresh rollback window
+func (r *CanaryRouter) SetPercent(percent int) error {
+	if percent < 0 || percent > 100 {
+		return ErrInvalidCanaryPercent
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.percent = percent
+	r.window = r.window[:0]
+
+	r.logger.Info("canary percentage changed", "percent", percent)
+	return nil
+}
+
+// Stats returns a copy of the per-acquirer counters
+func (r *CanaryRouter) Stats() map[string]AcquirerStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make(map[string]AcquirerStats, len(r.stats))
+	for acquirer, s := range r.stats {
+		stats[acquirer] = *s
+	}
+	return stats
+}
+
+func (r *CanaryRouter) pick(ctx context.Context) string {
+	if r.flags != nil && !r.flags.Enabled(ctx, domain.FlagCanaryRouting) {
+		return domain.DefaultAcquirer
+	}
+	percent := r.Percent()
+	if percent > 0 && rand.Intn(100) < percent { //nolint:gosec // Routing does not need a secure source
+		return AcquirerCanary
+	}
+	return domain.DefaultAcquirer
+}
+
+func (r *CanaryRouter) client(acquirer string) BankClient {
+	if acquirer == AcquirerCanary {
+		return r.canary
+	}
+	return r.primary
+}
+
+func (r *CanaryRouter) observe(acquirer string, err error) {
+	outcome := classifyOutcome(err)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats, ok := r.stats[acquirer]
+	if !ok {
+		stats = &AcquirerStats{}
+		r.stats[acquirer] = stats
+	}
+	stats.Requests++
+	switch outcome {
+	case outcomeSucceeded:
+		stats.Succeeded++
+	case outcomeDeclined:
+		stats.Declined++
+	case outcomeErrored:
+		stats.Errored++
+	}
+
+	if acquirer != AcquirerCanary || r.percent == 0 {
+		return
+	}
+
+	r.window = append(r.window, outcome)
+	if len(r.window) > r.windowSize {
+		r.window = r.window[1:]
+	}
+	if len(r.window) < r.windowSize {
+		return
+	}
+
+	var declined, errored int
+	for _, o := range r.window {
+		switch o {
+		case outcomeDeclined:
+			declined++
+		case outcomeErrored:
+			errored++
+		case outcomeSucceeded:
+		}
+	}
+	declineRate := float64(declined) / float64(len(r.window))
+	errorRate := float64(errored) / float64(len(r.window))
+
+	if (r.maxDeclineRate > 0 && declineRate > r.maxDeclineRate) ||
+		(r.maxErrorRate > 0 && errorRate > r.maxErrorRate) {
+		r.logger.Error("CANARY_ROLLED_BACK",
+			"previous_percent", r.percent,
+			"decline_rate", declineRate,
+			"error_rate", errorRate,
+			"window", len(r.window))
+		r.percent = 0
+		r.window = r.window[:0]
+	}
+}
+
+// classifyOutcome treats 4xx bank errors as declines and everything else that failed,
+// including transport errors, as errors.
+func classifyOutcome(err error) callOutcome {
+	if err == nil {
+		return outcomeSucceeded
+	}
+	if bankErr, ok := IsBankError(err); ok && bankErr.StatusCode < 500 {
+		return outcomeDeclined
+	}
+	return outcomeErrored
+}
@@ -0,0 +1,117 @@
+package bank_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newCanaryRouter(primary, canary bank.BankClient, cfg config.CanaryConfig) *bank.CanaryRouter {
+	return bank.NewCanaryRouter(primary, canary, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestCanaryRouter_RoutesFollowUpsToAuthorizingAcquirer(t *testing.T) {
+	primary := mocks.NewMockBankClient(t)
+	canary := mocks.NewMockBankClient(t)
+	router := newCanaryRouter(primary, canary, config.CanaryConfig{Percent: 100})
+
+	canary.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "idem-auth").
+		Return(&bank.AuthorizationResponse{AuthorizationID: "auth-123", Status: "AUTHORIZED"}, nil).
+		Once()
+
+	resp, err := router.Authorize(context.Background(), bank.AuthorizationRequest{Amount: 5000}, "idem-auth")
+	require.NoError(t, err)
+	assert.Equal(t, bank.AcquirerCanary, resp.Acquirer)
+
+	captureReq := bank.CaptureRequest{Amount: 5000, AuthorizationID: "auth-123"}
+	canary.EXPECT().
+		Capture(mock.Anything, captureReq, "idem-capture").
+		Return(&bank.CaptureResponse{CaptureID: "cap-123"}, nil).
+		Once()
+
+	_, err = router.Capture(bank.WithAcquirer(context.Background(), resp.Acquirer), captureReq, "idem-capture")
+	require.NoError(t, err)
+
+	voidReq := bank.VoidRequest{AuthorizationID: "auth-456"}
+	primary.EXPECT().
+		Void(mock.Anything, voidReq, "idem-void").
+		Return(&bank.VoidResponse{VoidID: "void-456"}, nil).
+		Once()
+
+	_, err = router.Void(bank.WithAcquirer(context.Background(), domain.DefaultAcquirer), voidReq, "idem-void")
+	require.NoError(t, err)
+
+	stats := router.Stats()
+	assert.Equal(t, int64(2), stats[bank.AcquirerCanary].Succeeded)
+	assert.Equal(t, int64(1), stats[domain.DefaultAcquirer].Succeeded)
+}
+
+// flagsOff turns every feature off
+type flagsOff struct{}
+
+func (flagsOff) Enabled(context.Context, string) bool { return false }
+
+func TestCanaryRouter_KeepsMerchantsWithoutFlagOnPrimary(t *testing.T) {
+	primary := mocks.NewMockBankClient(t)
+	canary := mocks.NewMockBankClient(t)
+	router := newCanaryRouter(primary, canary, config.CanaryConfig{Percent: 100}).WithFeatureFlags(flagsOff{})
+
+	primary.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "idem-auth").
+		Return(&bank.AuthorizationResponse{AuthorizationID: "auth-123", Status: "AUTHORIZED"}, nil).
+		Once()
+
+	resp, err := router.Authorize(context.Background(), bank.AuthorizationRequest{Amount: 5000}, "idem-auth")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultAcquirer, resp.Acquirer)
+}
+
+func TestCanaryRouter_RollsBackOnDeclineRate(t *testing.T) {
+	primary := mocks.NewMockBankClient(t)
+	canary := mocks.NewMockBankClient(t)
+	router := newCanaryRouter(primary, canary, config.CanaryConfig{
+		Percent:        100,
+		MaxDeclineRate: 0.5,
+		WindowSize:     4,
+	})
+
+	canary.EXPECT().
+		Authorize(mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, &bank.BankError{Code: "invalid_card", StatusCode: 400}).
+		Times(4)
+
+	for range 4 {
+		_, err := router.Authorize(context.Background(), bank.AuthorizationRequest{Amount: 5000}, "idem-key")
+		require.Error(t, err)
+	}
+
+	assert.Equal(t, 0, router.Percent())
+	assert.Equal(t, int64(4), router.Stats()[bank.AcquirerCanary].Declined)
+
+	primary.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "idem-after").
+		Return(&bank.AuthorizationResponse{Status: "AUTHORIZED"}, nil).
+		Once()
+
+	resp, err := router.Authorize(context.Background(), bank.AuthorizationRequest{Amount: 5000}, "idem-after")
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultAcquirer, resp.Acquirer)
+}
+
+func TestCanaryRouter_SetPercentRejectsOutOfRange(t *testing.T) {
+	router := newCanaryRouter(mocks.NewMockBankClient(t), mocks.NewMockBankClient(t), config.CanaryConfig{})
+
+	require.ErrorIs(t, router.SetPercent(101), bank.ErrInvalidCanaryPercent)
+	require.NoError(t, router.SetPercent(5))
+	assert.Equal(t, 5, router.Percent())
+}
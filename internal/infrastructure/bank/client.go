@@ -10,6 +10,8 @@ import (
 	"net/http"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
 )
 
 type BankClient interface {
@@ -19,6 +21,13 @@ type BankClient interface {
 	Refund(ctx context.Context, req RefundRequest, idempotencyKey string) (*RefundResponse, error)
 
 	GetAuthorization(ctx context.Context, authID string) (*AuthorizationResponse, error)
+	// GetCapture and GetRefund look up the capture or refund the bank made under an
+	// idempotency key, answering a 404 BankError when it made none
+	GetCapture(ctx context.Context, idempotencyKey string) (*CaptureResponse, error)
+	GetRefund(ctx context.Context, idempotencyKey string) (*RefundResponse, error)
+
+	Payout(ctx context.Context, req PayoutRequest, idempotencyKey string) (*PayoutResponse, error)
+	GetPayout(ctx context.Context, payoutID string) (*PayoutResponse, error)
 }
 
 type HTTPBankClient struct {
@@ -26,11 +35,13 @@ type HTTPBankClient struct {
 	httpClient *http.Client
 }
 
-func NewBankClient(cfg config.BankConfig) BankClient {
+// NewBankClient builds the HTTP client. A nil transport uses http.DefaultTransport.
+func NewBankClient(cfg config.BankConfig, transport http.RoundTripper) BankClient {
 	return &HTTPBankClient{
 		baseURL: cfg.BankBaseURL,
 		httpClient: &http.Client{
-			Timeout: cfg.BankConnTimeout,
+			Timeout:   cfg.BankConnTimeout,
+			Transport: transport,
 		},
 	}
 }
@@ -60,6 +71,26 @@ func (c *HTTPBankClient) GetAuthorization(ctx context.Context, authID string) (*
 	return sendRequest[any, AuthorizationResponse](c, ctx, http.MethodGet, url, nil, "")
 }
 
+func (c *HTTPBankClient) GetCapture(ctx context.Context, idempotencyKey string) (*CaptureResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/captures", c.baseURL)
+	return sendRequest[any, CaptureResponse](c, ctx, http.MethodGet, url, nil, idempotencyKey)
+}
+
+func (c *HTTPBankClient) GetRefund(ctx context.Context, idempotencyKey string) (*RefundResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/refunds", c.baseURL)
+	return sendRequest[any, RefundResponse](c, ctx, http.MethodGet, url, nil, idempotencyKey)
+}
+
+func (c *HTTPBankClient) Payout(ctx context.Context, req PayoutRequest, idempotencyKey string) (*PayoutResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/payouts", c.baseURL)
+	return sendRequest[PayoutRequest, PayoutResponse](c, ctx, http.MethodPost, url, &req, idempotencyKey)
+}
+
+func (c *HTTPBankClient) GetPayout(ctx context.Context, payoutID string) (*PayoutResponse, error) {
+	url := fmt.Sprintf("%s/api/v1/payouts/%s", c.baseURL, payoutID)
+	return sendRequest[any, PayoutResponse](c, ctx, http.MethodGet, url, nil, "")
+}
+
 func sendRequest[Req any, Resp any](c *HTTPBankClient, ctx context.Context, method, url string, reqBody *Req, idempotencyKey string) (*Resp, error) {
 	var bodyReader io.Reader
 	if reqBody != nil {
@@ -80,7 +111,7 @@ func sendRequest[Req any, Resp any](c *HTTPBankClient, ctx context.Context, meth
 	}
 
 	if idempotencyKey != "" {
-		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+		httpReq.Header.Set("Idempotency-Key", merchantKey(ctx, idempotencyKey))
 	}
 
 	resp, err := c.httpClient.Do(httpReq)
@@ -110,9 +141,10 @@ func sendRequest[Req any, Resp any](c *HTTPBankClient, ctx context.Context, meth
 			}
 		}
 		return nil, &BankError{
-			Code:       bankErrResp.Err,
-			Message:    bankErrResp.Message,
-			StatusCode: resp.StatusCode,
+			Code:         bankErrResp.Err,
+			Message:      bankErrResp.Message,
+			StatusCode:   resp.StatusCode,
+			RetryAdvised: bankErrResp.RetryAdvised,
 		}
 	}
 
@@ -123,3 +155,15 @@ func sendRequest[Req any, Resp any](c *HTTPBankClient, ctx context.Context, meth
 
 	return &bankResp, nil
 }
+
+// merchantKey namespaces an idempotency key by the merchant in ctx. Merchants pick their
+// keys independently but share the bank, which would otherwise answer one merchant's
+// request with another's result. Keys of the default merchant are sent unchanged so
+// requests made before there were several merchants still match at the bank.
+func merchantKey(ctx context.Context, idempotencyKey string) string {
+	merchantID := postgres.MerchantFromContext(ctx)
+	if merchantID == domain.DefaultMerchantID {
+		return idempotencyKey
+	}
+	return merchantID + ":" + idempotencyKey
+}
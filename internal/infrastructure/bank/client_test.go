@@ -0,0 +1,88 @@
+package bank_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPBankClient_NamespacesIdempotencyKeyByMerchant(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("Idempotency-Key"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"AUTHORIZED"}`))
+	}))
+	defer server.Close()
+
+	client := bank.NewBankClient(config.BankConfig{BankBaseURL: server.URL}, nil)
+
+	_, err := client.Authorize(context.Background(), bank.AuthorizationRequest{}, "idem-1")
+	require.NoError(t, err)
+	_, err = client.Authorize(postgres.WithMerchant(context.Background(), "acme"), bank.AuthorizationRequest{}, "idem-1")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"idem-1", "acme:idem-1"}, keys)
+}
+
+func TestHTTPBankClient_GetCaptureLooksUpByIdempotencyKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/captures", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Idempotency-Key") != "acme:idem-1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not_found","message":"Capture not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"capture_id":"cap-1","status":"CAPTURED"}`))
+	}))
+	defer server.Close()
+
+	client := bank.NewBankClient(config.BankConfig{BankBaseURL: server.URL}, nil)
+	ctx := postgres.WithMerchant(context.Background(), "acme")
+
+	capture, err := client.GetCapture(ctx, "idem-1")
+	require.NoError(t, err)
+	assert.Equal(t, "cap-1", capture.CaptureID)
+
+	_, err = client.GetCapture(ctx, "idem-2")
+	bankErr, ok := bank.IsBankError(err)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, bankErr.StatusCode)
+}
+
+func TestHTTPBankClient_SoftDeclines(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusPaymentRequired)
+		switch r.Header.Get("Idempotency-Key") {
+		case "hinted":
+			_, _ = w.Write([]byte(`{"error":"do_not_honor","message":"Do not honor","retry_advised":true}`))
+		case "unavailable":
+			_, _ = w.Write([]byte(`{"error":"issuer_unavailable","message":"Issuer unavailable"}`))
+		default:
+			_, _ = w.Write([]byte(`{"error":"do_not_honor","message":"Do not honor"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := bank.NewBankClient(config.BankConfig{BankBaseURL: server.URL}, nil)
+
+	for key, soft := range map[string]bool{"hinted": true, "unavailable": true, "plain": false} {
+		_, err := client.Authorize(context.Background(), bank.AuthorizationRequest{}, key)
+		bankErr, ok := bank.IsBankError(err)
+		require.True(t, ok, key)
+		assert.Equal(t, soft, bankErr.IsSoftDecline(), key)
+	}
+
+	outage := &bank.BankError{Code: "issuer_unavailable", StatusCode: http.StatusServiceUnavailable}
+	assert.False(t, outage.IsSoftDecline(), "a bank outage is retried as such, not as a decline")
+}
@@ -0,0 +1,161 @@
+package bank_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/pkg/banktest"
+	"github.com/google/uuid"
+)
+
+const (
+	declinedCard = "5555555555554444"
+	hangingCard  = "4000000000000259"
+)
+
+// fakeBank answers the mock bank's API from memory: a POST under a key it has seen
+// returns the first answer, and the declined card is refused for insufficient funds
+type fakeBank struct {
+	mu      sync.Mutex
+	answers map[string]any
+	auths   map[string]*bank.AuthorizationResponse
+}
+
+func newFakeBank(t *testing.T) *httptest.Server {
+	f := &fakeBank{answers: map[string]any{}, auths: map[string]*bank.AuthorizationResponse{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/authorizations", f.authorize)
+	mux.HandleFunc("GET /api/v1/authorizations/{id}", f.getAuthorization)
+	mux.HandleFunc("POST /api/v1/captures", replay(f, func(r *http.Request) (any, int) {
+		var req bank.CaptureRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return &bank.CaptureResponse{Amount: req.Amount, AuthorizationID: req.AuthorizationID, CaptureID: "cap-" + uuid.NewString(), Status: "CAPTURED", CapturedAt: time.Now()}, http.StatusOK
+	}))
+	mux.HandleFunc("POST /api/v1/voids", replay(f, func(r *http.Request) (any, int) {
+		var req bank.VoidRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return &bank.VoidResponse{AuthorizationID: req.AuthorizationID, VoidID: "void-" + uuid.NewString(), Status: "VOIDED", VoidedAt: time.Now()}, http.StatusOK
+	}))
+	mux.HandleFunc("POST /api/v1/refunds", replay(f, func(r *http.Request) (any, int) {
+		var req bank.RefundRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		return &bank.RefundResponse{Amount: req.Amount, CaptureID: req.CaptureID, RefundID: "ref-" + uuid.NewString(), Status: "REFUNDED", RefundedAt: time.Now()}, http.StatusOK
+	}))
+	mux.HandleFunc("GET /api/v1/captures", f.lookup)
+	mux.HandleFunc("GET /api/v1/refunds", f.lookup)
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (f *fakeBank) authorize(w http.ResponseWriter, r *http.Request) {
+	var req bank.AuthorizationRequest
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	switch req.CardNumber {
+	case hangingCard:
+		<-r.Context().Done()
+		return
+	case declinedCard:
+		writeJSON(w, http.StatusPaymentRequired, bank.BankErrorResponse{Err: "insufficient_funds", Message: "Insufficient funds"})
+		return
+	}
+
+	replay(f, func(*http.Request) (any, int) {
+		auth := &bank.AuthorizationResponse{
+			Amount:          req.Amount,
+			Currency:        "USD",
+			Status:          "AUTHORIZED",
+			AuthorizationID: "auth-" + uuid.NewString(),
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}
+		f.auths[auth.AuthorizationID] = auth
+		return auth, http.StatusOK
+	})(w, r)
+}
+
+func (f *fakeBank) getAuthorization(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	auth, ok := f.auths[r.PathValue("id")]
+	f.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, bank.BankErrorResponse{Err: "not_found", Message: "Authorization not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, auth)
+}
+
+func (f *fakeBank) lookup(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	answer, ok := f.answers[answerKey(http.MethodPost, r)]
+	f.mu.Unlock()
+	if !ok {
+		writeJSON(w, http.StatusNotFound, bank.BankErrorResponse{Err: "not_found", Message: "Not found"})
+		return
+	}
+	writeJSON(w, http.StatusOK, answer)
+}
+
+// replay answers a POST with act's answer the first time its idempotency key is seen,
+// and with that same answer after
+func replay(f *fakeBank, act func(r *http.Request) (any, int)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		key := answerKey(r.Method, r)
+		if answer, ok := f.answers[key]; ok {
+			writeJSON(w, http.StatusOK, answer)
+			return
+		}
+		answer, status := act(r)
+		f.answers[key] = answer
+		writeJSON(w, status, answer)
+	}
+}
+
+func answerKey(method string, r *http.Request) string {
+	return method + " " + strings.TrimSuffix(r.URL.Path, "/") + " " + r.Header.Get("Idempotency-Key")
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func conformanceConfig(server *httptest.Server, wrap func(bank.BankClient) bank.BankClient) banktest.Config {
+	card := func(number string) bank.AuthorizationRequest {
+		return bank.AuthorizationRequest{Amount: 5000, CardNumber: number, Cvv: "123", ExpiryMonth: 12, ExpiryYear: 2030}
+	}
+	hang := card(hangingCard)
+
+	return banktest.Config{
+		NewClient: func(t *testing.T) bank.BankClient {
+			return wrap(bank.NewBankClient(config.BankConfig{BankBaseURL: server.URL}, nil))
+		},
+		Approve: card("4111111111111111"),
+		Decline: card(declinedCard),
+		Hang:    &hang,
+	}
+}
+
+func TestHTTPBankClient_Conformance(t *testing.T) {
+	banktest.Run(t, conformanceConfig(newFakeBank(t), func(c bank.BankClient) bank.BankClient { return c }))
+}
+
+func TestRetryBankClient_Conformance(t *testing.T) {
+	banktest.Run(t, conformanceConfig(newFakeBank(t), func(c bank.BankClient) bank.BankClient {
+		return bank.NewRetryBankClient(c, config.RetryConfig{BaseDelay: 1, MaxRetries: 3}, nil)
+	}))
+}
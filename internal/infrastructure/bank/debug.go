@@ -0,0 +1,143 @@
+package bank
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DebugCaptureStore finds debug sessions and stores what they capture.
+// postgres.DebugSessionRepository satisfies it.
+type DebugCaptureStore interface {
+	FindActiveSessionID(ctx context.Context, idempotencyKey string) (string, error)
+	CreateCapture(ctx context.Context, capture *domain.DebugCapture) error
+}
+
+// DebugTransport records the raw bodies exchanged with the bank while a debug session
+// covers the request's idempotency key. Bodies are sanitized before they are stored.
+// Requests without an idempotency key, such as authorization lookups, are never captured.
+type DebugTransport struct {
+	inner  http.RoundTripper
+	store  DebugCaptureStore
+	logger *slog.Logger
+}
+
+func NewDebugTransport(inner http.RoundTripper, store DebugCaptureStore, logger *slog.Logger) http.RoundTripper {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	return &DebugTransport{
+		inner:  inner,
+		store:  store,
+		logger: logger,
+	}
+}
+
+func (t *DebugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotencyKey := req.Header.Get("Idempotency-Key")
+	if idempotencyKey == "" {
+		return t.inner.RoundTrip(req)
+	}
+
+	ctx := context.WithoutCancel(req.Context())
+
+	sessionID, err := t.store.FindActiveSessionID(ctx, idempotencyKey)
+	if err != nil {
+		t.logger.Error("failed to look up debug session", "idempotency_key", idempotencyKey, "error", err)
+		return t.inner.RoundTrip(req)
+	}
+	if sessionID == "" {
+		return t.inner.RoundTrip(req)
+	}
+
+	var requestBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			requestBody, _ = io.ReadAll(body) //nolint:errcheck // A partial body is still useful for debugging
+			_ = body.Close()                  //nolint:errcheck // In-memory body
+		}
+	}
+
+	resp, err := t.inner.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, readErr := io.ReadAll(resp.Body)
+	_ = resp.Body.Close() //nolint:errcheck // The body has been read in full
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	capture := &domain.DebugCapture{
+		ID:             uuid.New().String(),
+		SessionID:      sessionID,
+		IdempotencyKey: idempotencyKey,
+		Method:         req.Method,
+		URL:            req.URL.String(),
+		RequestBody:    sanitizeBody(requestBody),
+		ResponseStatus: resp.StatusCode,
+		ResponseBody:   sanitizeBody(responseBody),
+		CapturedAt:     time.Now(),
+	}
+	if err := t.store.CreateCapture(ctx, capture); err != nil {
+		t.logger.Error("failed to store debug capture", "session_id", sessionID, "error", err)
+	}
+
+	return resp, nil
+}
+
+// sanitizeBody masks card and bank account numbers and drops CVVs anywhere in a JSON body. Bodies that
+// are not JSON cannot be inspected and are replaced with a placeholder.
+func sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "[non-JSON body omitted]"
+	}
+
+	sanitized, err := json.Marshal(sanitizeValue(v))
+	if err != nil {
+		return "[unserializable body omitted]"
+	}
+	return string(sanitized)
+}
+
+func sanitizeValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, inner := range val {
+			switch k {
+			case "card_number", "account_number", "network_token":
+				if s, ok := inner.(string); ok {
+					val[k] = maskCardNumber(s)
+				} else {
+					val[k] = "***"
+				}
+			case "cvv", "cryptogram":
+				val[k] = "***"
+			default:
+				val[k] = sanitizeValue(inner)
+			}
+		}
+		return val
+	case []any:
+		for i, inner := range val {
+			val[i] = sanitizeValue(inner)
+		}
+		return val
+	default:
+		return v
+	}
+}
@@ -0,0 +1,84 @@
+package bank_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeDebugStore struct {
+	sessions map[string]string
+	captures []*domain.DebugCapture
+}
+
+func (s *fakeDebugStore) FindActiveSessionID(_ context.Context, idempotencyKey string) (string, error) {
+	return s.sessions[idempotencyKey], nil
+}
+
+func (s *fakeDebugStore) CreateCapture(_ context.Context, capture *domain.DebugCapture) error {
+	s.captures = append(s.captures, capture)
+	return nil
+}
+
+func newDebugBankClient(t *testing.T, store *fakeDebugStore) bank.BankClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"authorization_id":"auth-123","status":"AUTHORIZED","amount":5000}`)
+	}))
+	t.Cleanup(server.Close)
+
+	transport := bank.NewDebugTransport(nil, store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return bank.NewBankClient(config.BankConfig{
+		BankBaseURL:     server.URL,
+		BankConnTimeout: 5 * time.Second,
+	}, transport)
+}
+
+func TestDebugTransport_CapturesSanitizedTraffic(t *testing.T) {
+	store := &fakeDebugStore{sessions: map[string]string{"idem-key": "session-1"}}
+	client := newDebugBankClient(t, store)
+
+	resp, err := client.Authorize(context.Background(), bank.AuthorizationRequest{
+		Amount:      5000,
+		CardNumber:  "4111111111111111",
+		Cvv:         "123",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	}, "idem-key")
+	require.NoError(t, err)
+	assert.Equal(t, "auth-123", resp.AuthorizationID)
+
+	require.Len(t, store.captures, 1)
+	capture := store.captures[0]
+	assert.Equal(t, "session-1", capture.SessionID)
+	assert.Equal(t, http.MethodPost, capture.Method)
+	assert.Equal(t, http.StatusOK, capture.ResponseStatus)
+	assert.Contains(t, capture.RequestBody, `"card_number":"************1111"`)
+	assert.Contains(t, capture.RequestBody, `"cvv":"***"`)
+	assert.NotContains(t, capture.RequestBody, "4111111111111111")
+	assert.NotContains(t, capture.RequestBody, `"123"`)
+	assert.Contains(t, capture.ResponseBody, "auth-123")
+}
+
+func TestDebugTransport_SkipsKeysWithoutSession(t *testing.T) {
+	store := &fakeDebugStore{sessions: map[string]string{}}
+	client := newDebugBankClient(t, store)
+
+	_, err := client.Capture(context.Background(), bank.CaptureRequest{
+		Amount:          5000,
+		AuthorizationID: "auth-123",
+	}, "other-key")
+	require.NoError(t, err)
+
+	assert.Empty(t, store.captures)
+}
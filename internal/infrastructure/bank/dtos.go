@@ -2,12 +2,17 @@ package bank
 
 import "time"
 
+// AuthorizationRequest charges either a card, by CardNumber and Cvv, or a network
+// token, by NetworkToken and Cryptogram; the expiry is that of whichever is sent
 type AuthorizationRequest struct {
-	Amount      int64  `json:"amount"`
-	CardNumber  string `json:"card_number"`
-	Cvv         string `json:"cvv"`
-	ExpiryMonth int    `json:"expiry_month"`
-	ExpiryYear  int    `json:"expiry_year"`
+	Amount       int64  `json:"amount"`
+	CardNumber   string `json:"card_number,omitempty"`
+	Cvv          string `json:"cvv,omitempty"`
+	NetworkToken string `json:"network_token,omitempty"`
+	Cryptogram   string `json:"cryptogram,omitempty"`
+	ECI          string `json:"eci,omitempty"`
+	ExpiryMonth  int    `json:"expiry_month"`
+	ExpiryYear   int    `json:"expiry_year"`
 }
 
 type AuthorizationResponse struct {
@@ -17,6 +22,8 @@ type AuthorizationResponse struct {
 	AuthorizationID string    `json:"authorization_id"`
 	CreatedAt       time.Time `json:"created_at"`
 	ExpiresAt       time.Time `json:"expires_at"`
+	// Acquirer is set by the CanaryRouter to the bank that handled the authorization
+	Acquirer string `json:"-"`
 }
 
 type CaptureRequest struct {
@@ -35,6 +42,7 @@ type CaptureResponse struct {
 
 type VoidRequest struct {
 	AuthorizationID string `json:"authorization_id"`
+	Amount          int64  `json:"amount,omitempty"`
 }
 
 type VoidResponse struct {
@@ -57,3 +65,22 @@ type RefundResponse struct {
 	RefundID   string    `json:"refund_id"`
 	RefundedAt time.Time `json:"refunded_at"`
 }
+
+type PayoutRequest struct {
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	AccountNumber string `json:"account_number"`
+	RoutingNumber string `json:"routing_number"`
+}
+
+type PayoutResponse struct {
+	PayoutID  string    `json:"payout_id"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	// PaidAt is set once the funds have reached the destination account
+	PaidAt *time.Time `json:"paid_at,omitempty"`
+	// FailureCode says why a payout failed or was returned by the receiving bank
+	FailureCode string `json:"failure_code,omitempty"`
+}
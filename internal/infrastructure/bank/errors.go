@@ -3,17 +3,23 @@ package bank
 import (
 	"errors"
 	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 )
 
 type BankError struct {
 	Code       string
 	Message    string
 	StatusCode int
+	// RetryAdvised is the bank's hint that a declined request may pass if sent again
+	// later, whatever its code
+	RetryAdvised bool
 }
 
 type BankErrorResponse struct {
-	Err     string `json:"error"`
-	Message string `json:"message"`
+	Err          string `json:"error"`
+	Message      string `json:"message"`
+	RetryAdvised bool   `json:"retry_advised,omitempty"`
 }
 
 func (e *BankError) Error() string {
@@ -24,6 +30,15 @@ func (e *BankError) IsRetryable() bool {
 	return e.StatusCode >= 500
 }
 
+// IsSoftDecline reports whether e is a decline the bank expects may pass later, such as
+// when the issuer could not be reached, rather than one a later attempt would repeat
+func (e *BankError) IsSoftDecline() bool {
+	if e.StatusCode < 400 || e.StatusCode >= 500 {
+		return false
+	}
+	return e.RetryAdvised || domain.ClassifyDecline(e.Code) == domain.DeclineRetryLater
+}
+
 func IsBankError(err error) (*BankError, bool) {
 	var bankErr *BankError
 	ok := errors.As(err, &bankErr)
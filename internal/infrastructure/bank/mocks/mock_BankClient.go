@@ -202,6 +202,243 @@ func (_c *MockBankClient_GetAuthorization_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetCapture provides a mock function with given fields: ctx, idempotencyKey
+func (_m *MockBankClient) GetCapture(ctx context.Context, idempotencyKey string) (*bank.CaptureResponse, error) {
+	ret := _m.Called(ctx, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCapture")
+	}
+
+	var r0 *bank.CaptureResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*bank.CaptureResponse, error)); ok {
+		return rf(ctx, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *bank.CaptureResponse); ok {
+		r0 = rf(ctx, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bank.CaptureResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBankClient_GetCapture_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCapture'
+type MockBankClient_GetCapture_Call struct {
+	*mock.Call
+}
+
+// GetCapture is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idempotencyKey string
+func (_e *MockBankClient_Expecter) GetCapture(ctx interface{}, idempotencyKey interface{}) *MockBankClient_GetCapture_Call {
+	return &MockBankClient_GetCapture_Call{Call: _e.mock.On("GetCapture", ctx, idempotencyKey)}
+}
+
+func (_c *MockBankClient_GetCapture_Call) Run(run func(ctx context.Context, idempotencyKey string)) *MockBankClient_GetCapture_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBankClient_GetCapture_Call) Return(_a0 *bank.CaptureResponse, _a1 error) *MockBankClient_GetCapture_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBankClient_GetCapture_Call) RunAndReturn(run func(context.Context, string) (*bank.CaptureResponse, error)) *MockBankClient_GetCapture_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPayout provides a mock function with given fields: ctx, payoutID
+func (_m *MockBankClient) GetPayout(ctx context.Context, payoutID string) (*bank.PayoutResponse, error) {
+	ret := _m.Called(ctx, payoutID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPayout")
+	}
+
+	var r0 *bank.PayoutResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*bank.PayoutResponse, error)); ok {
+		return rf(ctx, payoutID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *bank.PayoutResponse); ok {
+		r0 = rf(ctx, payoutID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bank.PayoutResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payoutID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBankClient_GetPayout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPayout'
+type MockBankClient_GetPayout_Call struct {
+	*mock.Call
+}
+
+// GetPayout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - payoutID string
+func (_e *MockBankClient_Expecter) GetPayout(ctx interface{}, payoutID interface{}) *MockBankClient_GetPayout_Call {
+	return &MockBankClient_GetPayout_Call{Call: _e.mock.On("GetPayout", ctx, payoutID)}
+}
+
+func (_c *MockBankClient_GetPayout_Call) Run(run func(ctx context.Context, payoutID string)) *MockBankClient_GetPayout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBankClient_GetPayout_Call) Return(_a0 *bank.PayoutResponse, _a1 error) *MockBankClient_GetPayout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBankClient_GetPayout_Call) RunAndReturn(run func(context.Context, string) (*bank.PayoutResponse, error)) *MockBankClient_GetPayout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRefund provides a mock function with given fields: ctx, idempotencyKey
+func (_m *MockBankClient) GetRefund(ctx context.Context, idempotencyKey string) (*bank.RefundResponse, error) {
+	ret := _m.Called(ctx, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRefund")
+	}
+
+	var r0 *bank.RefundResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*bank.RefundResponse, error)); ok {
+		return rf(ctx, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *bank.RefundResponse); ok {
+		r0 = rf(ctx, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bank.RefundResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBankClient_GetRefund_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRefund'
+type MockBankClient_GetRefund_Call struct {
+	*mock.Call
+}
+
+// GetRefund is a helper method to define mock.On call
+//   - ctx context.Context
+//   - idempotencyKey string
+func (_e *MockBankClient_Expecter) GetRefund(ctx interface{}, idempotencyKey interface{}) *MockBankClient_GetRefund_Call {
+	return &MockBankClient_GetRefund_Call{Call: _e.mock.On("GetRefund", ctx, idempotencyKey)}
+}
+
+func (_c *MockBankClient_GetRefund_Call) Run(run func(ctx context.Context, idempotencyKey string)) *MockBankClient_GetRefund_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockBankClient_GetRefund_Call) Return(_a0 *bank.RefundResponse, _a1 error) *MockBankClient_GetRefund_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBankClient_GetRefund_Call) RunAndReturn(run func(context.Context, string) (*bank.RefundResponse, error)) *MockBankClient_GetRefund_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Payout provides a mock function with given fields: ctx, req, idempotencyKey
+func (_m *MockBankClient) Payout(ctx context.Context, req bank.PayoutRequest, idempotencyKey string) (*bank.PayoutResponse, error) {
+	ret := _m.Called(ctx, req, idempotencyKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Payout")
+	}
+
+	var r0 *bank.PayoutResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, bank.PayoutRequest, string) (*bank.PayoutResponse, error)); ok {
+		return rf(ctx, req, idempotencyKey)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, bank.PayoutRequest, string) *bank.PayoutResponse); ok {
+		r0 = rf(ctx, req, idempotencyKey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*bank.PayoutResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, bank.PayoutRequest, string) error); ok {
+		r1 = rf(ctx, req, idempotencyKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBankClient_Payout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Payout'
+type MockBankClient_Payout_Call struct {
+	*mock.Call
+}
+
+// Payout is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req bank.PayoutRequest
+//   - idempotencyKey string
+func (_e *MockBankClient_Expecter) Payout(ctx interface{}, req interface{}, idempotencyKey interface{}) *MockBankClient_Payout_Call {
+	return &MockBankClient_Payout_Call{Call: _e.mock.On("Payout", ctx, req, idempotencyKey)}
+}
+
+func (_c *MockBankClient_Payout_Call) Run(run func(ctx context.Context, req bank.PayoutRequest, idempotencyKey string)) *MockBankClient_Payout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bank.PayoutRequest), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockBankClient_Payout_Call) Return(_a0 *bank.PayoutResponse, _a1 error) *MockBankClient_Payout_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBankClient_Payout_Call) RunAndReturn(run func(context.Context, bank.PayoutRequest, string) (*bank.PayoutResponse, error)) *MockBankClient_Payout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Refund provides a mock function with given fields: ctx, req, idempotencyKey
 func (_m *MockBankClient) Refund(ctx context.Context, req bank.RefundRequest, idempotencyKey string) (*bank.RefundResponse, error) {
 	ret := _m.Called(ctx, req, idempotencyKey)
@@ -0,0 +1,250 @@
+package bank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// CodeRateLimited is the error code of a call held back by the RateLimitedBankClient
+const CodeRateLimited = "bank_rate_limited"
+
+var ErrUnknownBankOperation = errors.New("unknown bank operation")
+
+// rateLimitedOperations are the calls a limit can be set for, named as the attempts
+// ledger names them
+var rateLimitedOperations = map[string]bool{
+	"AUTHORIZE":         true,
+	"CAPTURE":           true,
+	"VOID":              true,
+	"REFUND":            true,
+	"GET_AUTHORIZATION": true,
+	"GET_CAPTURE":       true,
+	"GET_REFUND":        true,
+	"PAYOUT":            true,
+	"GET_PAYOUT":        true,
+}
+
+// RateLimits holds how many calls of each operation a merchant may make per second
+type RateLimits map[string]float64
+
+// ParseRateLimits reads a comma-separated list of operation:per-second entries, such as
+// "authorize:20,refund:5". Operations left out are not limited.
+func ParseRateLimits(s string) (RateLimits, error) {
+	limits := RateLimits{}
+	for i, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("rate limit %d: expected operation:per-second", i+1)
+		}
+		operation := strings.ToUpper(strings.TrimSpace(name))
+		if !rateLimitedOperations[operation] {
+			return nil, fmt.Errorf("rate limit %q: %w", name, ErrUnknownBankOperation)
+		}
+		if _, seen := limits[operation]; seen {
+			return nil, fmt.Errorf("rate limit %q: listed twice", name)
+		}
+
+		perSecond, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || perSecond <= 0 || math.IsInf(perSecond, 0) {
+			return nil, fmt.Errorf("rate limit %q: must be a positive number of calls per second", name)
+		}
+		limits[operation] = perSecond
+	}
+	return limits, nil
+}
+
+// RateLimitedBankClient keeps each merchant's calls under the bank's per-merchant TPS
+// limits with a token bucket per merchant and operation. A call over the limit waits
+// for its turn when that comes within maxWait, and otherwise fails at once with a 429
+// BankError instead of being sent for the bank to reject. Wrap it inside the
+// RetryBankClient so every retry takes its own turn; 429s are not retried there.
+type RateLimitedBankClient struct {
+	inner   BankClient
+	limits  RateLimits
+	burst   int
+	maxWait time.Duration
+
+	mu      sync.Mutex
+	buckets map[rateLimitKey]*tokenBucket
+}
+
+type rateLimitKey struct {
+	merchantID string
+	operation  string
+}
+
+// NewRateLimitedBankClient limits calls to inner to limits, letting up to cfg.Burst of
+// them through at once
+func NewRateLimitedBankClient(inner BankClient, limits RateLimits, cfg config.BankRateLimitConfig) BankClient {
+	return &RateLimitedBankClient{
+		inner:   inner,
+		limits:  limits,
+		burst:   max(cfg.Burst, 1),
+		maxWait: cfg.MaxWait,
+		buckets: make(map[rateLimitKey]*tokenBucket),
+	}
+}
+
+func (r *RateLimitedBankClient) Authorize(ctx context.Context, req AuthorizationRequest, idempotencyKey string) (*AuthorizationResponse, error) {
+	if err := r.wait(ctx, "AUTHORIZE"); err != nil {
+		return nil, err
+	}
+	return r.inner.Authorize(ctx, req, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) Capture(ctx context.Context, req CaptureRequest, idempotencyKey string) (*CaptureResponse, error) {
+	if err := r.wait(ctx, "CAPTURE"); err != nil {
+		return nil, err
+	}
+	return r.inner.Capture(ctx, req, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) Void(ctx context.Context, req VoidRequest, idempotencyKey string) (*VoidResponse, error) {
+	if err := r.wait(ctx, "VOID"); err != nil {
+		return nil, err
+	}
+	return r.inner.Void(ctx, req, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) Refund(ctx context.Context, req RefundRequest, idempotencyKey string) (*RefundResponse, error) {
+	if err := r.wait(ctx, "REFUND"); err != nil {
+		return nil, err
+	}
+	return r.inner.Refund(ctx, req, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) GetAuthorization(ctx context.Context, authID string) (*AuthorizationResponse, error) {
+	if err := r.wait(ctx, "GET_AUTHORIZATION"); err != nil {
+		return nil, err
+	}
+	return r.inner.GetAuthorization(ctx, authID)
+}
+
+func (r *RateLimitedBankClient) GetCapture(ctx context.Context, idempotencyKey string) (*CaptureResponse, error) {
+	if err := r.wait(ctx, "GET_CAPTURE"); err != nil {
+		return nil, err
+	}
+	return r.inner.GetCapture(ctx, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) GetRefund(ctx context.Context, idempotencyKey string) (*RefundResponse, error) {
+	if err := r.wait(ctx, "GET_REFUND"); err != nil {
+		return nil, err
+	}
+	return r.inner.GetRefund(ctx, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) Payout(ctx context.Context, req PayoutRequest, idempotencyKey string) (*PayoutResponse, error) {
+	if err := r.wait(ctx, "PAYOUT"); err != nil {
+		return nil, err
+	}
+	return r.inner.Payout(ctx, req, idempotencyKey)
+}
+
+func (r *RateLimitedBankClient) GetPayout(ctx context.Context, payoutID string) (*PayoutResponse, error) {
+	if err := r.wait(ctx, "GET_PAYOUT"); err != nil {
+		return nil, err
+	}
+	return r.inner.GetPayout(ctx, payoutID)
+}
+
+// wait holds the call until the merchant in ctx may make it
+func (r *RateLimitedBankClient) wait(ctx context.Context, operation string) error {
+	perSecond, limited := r.limits[operation]
+	if !limited {
+		return nil
+	}
+
+	key := rateLimitKey{merchantID: postgres.MerchantFromContext(ctx), operation: operation}
+	now := time.Now()
+
+	r.mu.Lock()
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = newTokenBucket(perSecond, r.burst, now)
+		r.buckets[key] = bucket
+	}
+	delay, ok := bucket.reserve(now, r.maxWait)
+	r.mu.Unlock()
+
+	if !ok {
+		return &BankError{
+			Code:       CodeRateLimited,
+			Message:    fmt.Sprintf("%s calls of the merchant exceed the bank's limit of %g per second", strings.ToLower(operation), perSecond),
+			StatusCode: http.StatusTooManyRequests,
+		}
+	}
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// The turn is handed back so the calls queued behind it move up
+		r.mu.Lock()
+		bucket.release()
+		r.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// tokenBucket refills at rate tokens per second up to burst. A call takes a token; when
+// none is left it may take one ahead of time, leaving the bucket in debt it waits out.
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   now,
+	}
+}
+
+// reserve takes a token and returns how long the call must wait for it. It takes
+// nothing and returns false when that would be longer than maxWait.
+func (b *tokenBucket) reserve(now time.Time, maxWait time.Duration) (time.Duration, bool) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0, true
+	}
+
+	delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	if delay > maxWait {
+		return 0, false
+	}
+	b.tokens--
+	return delay, true
+}
+
+// release returns a token taken by reserve that was not used
+func (b *tokenBucket) release() {
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
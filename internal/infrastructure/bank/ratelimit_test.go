@@ -0,0 +1,113 @@
+package bank_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := bank.ParseRateLimits(" authorize:20 ,Refund:0.5,")
+	require.NoError(t, err)
+	assert.Equal(t, bank.RateLimits{"AUTHORIZE": 20, "REFUND": 0.5}, limits)
+
+	for _, s := range []string{"authorize", "authorize:0", "authorize:-1", "authorize:fast", "authorize:1,authorize:2"} {
+		_, err := bank.ParseRateLimits(s)
+		assert.Error(t, err, s)
+	}
+	_, err = bank.ParseRateLimits("teleport:5")
+	assert.ErrorIs(t, err, bank.ErrUnknownBankOperation)
+}
+
+func TestRateLimitedBankClient_RejectsCallsOverTheLimit(t *testing.T) {
+	inner := mocks.NewMockBankClient(t)
+	client := bank.NewRateLimitedBankClient(inner, bank.RateLimits{"CAPTURE": 1}, config.BankRateLimitConfig{Burst: 2})
+
+	req := bank.CaptureRequest{Amount: 5000, AuthorizationID: "auth-123"}
+	inner.EXPECT().
+		Capture(mock.Anything, req, mock.Anything).
+		Return(&bank.CaptureResponse{CaptureID: "cap-123"}, nil).
+		Times(2)
+
+	ctx := postgres.WithMerchant(context.Background(), "merchant-1")
+	for _, key := range []string{"idem-1", "idem-2"} {
+		_, err := client.Capture(ctx, req, key)
+		require.NoError(t, err)
+	}
+
+	_, err := client.Capture(ctx, req, "idem-3")
+	bankErr, ok := bank.IsBankError(err)
+	require.True(t, ok)
+	assert.Equal(t, bank.CodeRateLimited, bankErr.Code)
+	assert.Equal(t, http.StatusTooManyRequests, bankErr.StatusCode)
+	assert.False(t, bankErr.IsRetryable())
+}
+
+func TestRateLimitedBankClient_LimitsEachMerchantAndOperationApart(t *testing.T) {
+	inner := mocks.NewMockBankClient(t)
+	client := bank.NewRateLimitedBankClient(inner, bank.RateLimits{"CAPTURE": 1}, config.BankRateLimitConfig{})
+
+	capture := bank.CaptureRequest{Amount: 5000, AuthorizationID: "auth-123"}
+	void := bank.VoidRequest{AuthorizationID: "auth-456"}
+	inner.EXPECT().Capture(mock.Anything, capture, mock.Anything).Return(&bank.CaptureResponse{}, nil).Times(2)
+	inner.EXPECT().Void(mock.Anything, void, mock.Anything).Return(&bank.VoidResponse{}, nil).Times(2)
+
+	for _, merchantID := range []string{"merchant-1", "merchant-2"} {
+		ctx := postgres.WithMerchant(context.Background(), merchantID)
+		_, err := client.Capture(ctx, capture, "idem-capture")
+		require.NoError(t, err)
+		// Voids are not limited
+		_, err = client.Void(ctx, void, "idem-void")
+		require.NoError(t, err)
+	}
+}
+
+func TestRateLimitedBankClient_QueuesWithinMaxWait(t *testing.T) {
+	inner := mocks.NewMockBankClient(t)
+	client := bank.NewRateLimitedBankClient(inner, bank.RateLimits{"AUTHORIZE": 20}, config.BankRateLimitConfig{
+		Burst:   1,
+		MaxWait: time.Second,
+	})
+
+	req := bank.AuthorizationRequest{Amount: 5000}
+	inner.EXPECT().
+		Authorize(mock.Anything, req, mock.Anything).
+		Return(&bank.AuthorizationResponse{Status: "AUTHORIZED"}, nil).
+		Times(2)
+
+	start := time.Now()
+	_, err := client.Authorize(context.Background(), req, "idem-1")
+	require.NoError(t, err)
+	_, err = client.Authorize(context.Background(), req, "idem-2")
+	require.NoError(t, err)
+
+	// The second call waits out the 50ms it takes to earn a token at 20 per second
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+}
+
+func TestRateLimitedBankClient_StopsWaitingWithContext(t *testing.T) {
+	inner := mocks.NewMockBankClient(t)
+	client := bank.NewRateLimitedBankClient(inner, bank.RateLimits{"REFUND": 0.1}, config.BankRateLimitConfig{
+		MaxWait: time.Minute,
+	})
+
+	req := bank.RefundRequest{Amount: 5000, CaptureID: "cap-123"}
+	inner.EXPECT().Refund(mock.Anything, req, "idem-1").Return(&bank.RefundResponse{}, nil).Once()
+
+	_, err := client.Refund(context.Background(), req, "idem-1")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.Refund(ctx, req, "idem-2")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
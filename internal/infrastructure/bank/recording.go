@@ -0,0 +1,185 @@
+package bank
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/google/uuid"
+)
+
+// AttemptStore persists bank attempts. postgres.BankAttemptRepository satisfies it.
+type AttemptStore interface {
+	Create(ctx context.Context, attempt *domain.BankAttempt) error
+}
+
+// RecordingBankClient writes every call to the inner client to the attempts ledger.
+// Wrap it inside the RetryBankClient so each retry is recorded as its own attempt.
+type RecordingBankClient struct {
+	inner    BankClient
+	acquirer string
+	store    AttemptStore
+	logger   *slog.Logger
+}
+
+// NewRecordingBankClient records calls to inner under the given acquirer name
+func NewRecordingBankClient(inner BankClient, acquirer string, store AttemptStore, logger *slog.Logger) BankClient {
+	return &RecordingBankClient{
+		inner:    inner,
+		acquirer: acquirer,
+		store:    store,
+		logger:   logger,
+	}
+}
+
+func (r *RecordingBankClient) Authorize(ctx context.Context, req AuthorizationRequest, idempotencyKey string) (*AuthorizationResponse, error) {
+	return record(r, ctx, "AUTHORIZE", idempotencyKey, redactAuthorization(req),
+		func(ctx context.Context) (*AuthorizationResponse, error) {
+			return r.inner.Authorize(ctx, req, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) Capture(ctx context.Context, req CaptureRequest, idempotencyKey string) (*CaptureResponse, error) {
+	return record(r, ctx, "CAPTURE", idempotencyKey, req,
+		func(ctx context.Context) (*CaptureResponse, error) {
+			return r.inner.Capture(ctx, req, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) Void(ctx context.Context, req VoidRequest, idempotencyKey string) (*VoidResponse, error) {
+	return record(r, ctx, "VOID", idempotencyKey, req,
+		func(ctx context.Context) (*VoidResponse, error) {
+			return r.inner.Void(ctx, req, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) Refund(ctx context.Context, req RefundRequest, idempotencyKey string) (*RefundResponse, error) {
+	return record(r, ctx, "REFUND", idempotencyKey, req,
+		func(ctx context.Context) (*RefundResponse, error) {
+			return r.inner.Refund(ctx, req, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) GetAuthorization(ctx context.Context, authID string) (*AuthorizationResponse, error) {
+	return record(r, ctx, "GET_AUTHORIZATION", "", map[string]string{"authorization_id": authID},
+		func(ctx context.Context) (*AuthorizationResponse, error) {
+			return r.inner.GetAuthorization(ctx, authID)
+		},
+	)
+}
+
+func (r *RecordingBankClient) GetCapture(ctx context.Context, idempotencyKey string) (*CaptureResponse, error) {
+	return record(r, ctx, "GET_CAPTURE", idempotencyKey, nil,
+		func(ctx context.Context) (*CaptureResponse, error) {
+			return r.inner.GetCapture(ctx, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) GetRefund(ctx context.Context, idempotencyKey string) (*RefundResponse, error) {
+	return record(r, ctx, "GET_REFUND", idempotencyKey, nil,
+		func(ctx context.Context) (*RefundResponse, error) {
+			return r.inner.GetRefund(ctx, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) Payout(ctx context.Context, req PayoutRequest, idempotencyKey string) (*PayoutResponse, error) {
+	return record(r, ctx, "PAYOUT", idempotencyKey, redactPayout(req),
+		func(ctx context.Context) (*PayoutResponse, error) {
+			return r.inner.Payout(ctx, req, idempotencyKey)
+		},
+	)
+}
+
+func (r *RecordingBankClient) GetPayout(ctx context.Context, payoutID string) (*PayoutResponse, error) {
+	return record(r, ctx, "GET_PAYOUT", "", map[string]string{"payout_id": payoutID},
+		func(ctx context.Context) (*PayoutResponse, error) {
+			return r.inner.GetPayout(ctx, payoutID)
+		},
+	)
+}
+
+// record runs call and stores the outcome. Failing to store an attempt is logged and
+// never fails the bank call itself.
+func record[T any](
+	r *RecordingBankClient,
+	ctx context.Context,
+	operation string,
+	idempotencyKey string,
+	request any,
+	call func(ctx context.Context) (*T, error),
+) (*T, error) {
+	start := time.Now()
+	resp, err := call(ctx)
+
+	attempt := &domain.BankAttempt{
+		ID:             uuid.New().String(),
+		Acquirer:       r.acquirer,
+		Operation:      operation,
+		IdempotencyKey: idempotencyKey,
+		Latency:        time.Since(start),
+		RequestPayload: marshalPayload(request),
+		AttemptedAt:    start,
+	}
+
+	if err == nil {
+		attempt.StatusCode = http.StatusOK
+		attempt.ResponsePayload = marshalPayload(resp)
+	} else if bankErr, ok := IsBankError(err); ok {
+		attempt.StatusCode = bankErr.StatusCode
+		attempt.ErrorCode = bankErr.Code
+		attempt.ResponsePayload = marshalPayload(BankErrorResponse{Err: bankErr.Code, Message: bankErr.Message})
+	} else {
+		// No response came back, so there is no status code to record
+		attempt.ErrorCode = err.Error()
+	}
+
+	if storeErr := r.store.Create(context.WithoutCancel(ctx), attempt); storeErr != nil {
+		r.logger.Error("failed to record bank attempt",
+			"operation", operation,
+			"idempotency_key", idempotencyKey,
+			"error", storeErr)
+	}
+
+	return resp, err
+}
+
+func redactAuthorization(req AuthorizationRequest) AuthorizationRequest {
+	req.CardNumber = maskCardNumber(req.CardNumber)
+	req.Cvv = "***"
+	req.NetworkToken = maskCardNumber(req.NetworkToken)
+	if req.Cryptogram != "" {
+		req.Cryptogram = "***"
+	}
+	return req
+}
+
+func redactPayout(req PayoutRequest) PayoutRequest {
+	req.AccountNumber = maskCardNumber(req.AccountNumber)
+	return req
+}
+
+// maskCardNumber keeps only the last four digits
+func maskCardNumber(number string) string {
+	if len(number) <= 4 {
+		return strings.Repeat("*", len(number))
+	}
+	return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+}
+
+func marshalPayload(v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return data
+}
@@ -0,0 +1,141 @@
+package bank_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAttemptStore struct {
+	attempts []*domain.BankAttempt
+	err      error
+}
+
+func (s *fakeAttemptStore) Create(_ context.Context, attempt *domain.BankAttempt) error {
+	s.attempts = append(s.attempts, attempt)
+	return s.err
+}
+
+func newRecordingClient(inner bank.BankClient, store bank.AttemptStore) bank.BankClient {
+	return bank.NewRecordingBankClient(inner, domain.DefaultAcquirer, store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestRecordingBankClient_Authorize_RedactsCardData(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	store := &fakeAttemptStore{}
+	client := newRecordingClient(mockClient, store)
+
+	req := bank.AuthorizationRequest{
+		Amount:      5000,
+		CardNumber:  "4111111111111111",
+		Cvv:         "123",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	}
+
+	mockClient.EXPECT().
+		Authorize(mock.Anything, req, "idem-key").
+		Return(&bank.AuthorizationResponse{AuthorizationID: "auth-123"}, nil).
+		Once()
+
+	_, err := client.Authorize(context.Background(), req, "idem-key")
+	require.NoError(t, err)
+
+	require.Len(t, store.attempts, 1)
+	attempt := store.attempts[0]
+	assert.Equal(t, domain.DefaultAcquirer, attempt.Acquirer)
+	assert.Equal(t, "AUTHORIZE", attempt.Operation)
+	assert.Equal(t, "idem-key", attempt.IdempotencyKey)
+	assert.Equal(t, http.StatusOK, attempt.StatusCode)
+	assert.Contains(t, string(attempt.ResponsePayload), "auth-123")
+
+	var recorded bank.AuthorizationRequest
+	require.NoError(t, json.Unmarshal(attempt.RequestPayload, &recorded))
+	assert.Equal(t, "************1111", recorded.CardNumber)
+	assert.Equal(t, "***", recorded.Cvv)
+	assert.NotContains(t, string(attempt.RequestPayload), "4111111111111111")
+}
+
+func TestRecordingBankClient_Authorize_RedactsNetworkToken(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	store := &fakeAttemptStore{}
+	client := newRecordingClient(mockClient, store)
+
+	req := bank.AuthorizationRequest{
+		Amount:       5000,
+		NetworkToken: "4895370012003478",
+		Cryptogram:   "AgAAAAAABk4DWZ4C28yUQAAAAAA=",
+		ECI:          "05",
+		ExpiryMonth:  12,
+		ExpiryYear:   2030,
+	}
+
+	mockClient.EXPECT().
+		Authorize(mock.Anything, req, "idem-key").
+		Return(&bank.AuthorizationResponse{AuthorizationID: "auth-123"}, nil).
+		Once()
+
+	_, err := client.Authorize(context.Background(), req, "idem-key")
+	require.NoError(t, err)
+
+	require.Len(t, store.attempts, 1)
+	var recorded bank.AuthorizationRequest
+	require.NoError(t, json.Unmarshal(store.attempts[0].RequestPayload, &recorded))
+	assert.Equal(t, "************3478", recorded.NetworkToken)
+	assert.Equal(t, "***", recorded.Cryptogram)
+	assert.Equal(t, "05", recorded.ECI)
+	assert.Empty(t, recorded.CardNumber)
+}
+
+func TestRecordingBankClient_Capture_RecordsBankError(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	store := &fakeAttemptStore{}
+	client := newRecordingClient(mockClient, store)
+
+	req := bank.CaptureRequest{Amount: 5000, AuthorizationID: "auth-123"}
+	bankErr := &bank.BankError{Code: "authorization_expired", Message: "expired", StatusCode: 400}
+
+	mockClient.EXPECT().
+		Capture(mock.Anything, req, "idem-key").
+		Return(nil, bankErr).
+		Once()
+
+	_, err := client.Capture(context.Background(), req, "idem-key")
+	require.ErrorIs(t, err, bankErr)
+
+	require.Len(t, store.attempts, 1)
+	attempt := store.attempts[0]
+	assert.Equal(t, "CAPTURE", attempt.Operation)
+	assert.Equal(t, 400, attempt.StatusCode)
+	assert.Equal(t, "authorization_expired", attempt.ErrorCode)
+	assert.Contains(t, string(attempt.RequestPayload), "auth-123")
+}
+
+func TestRecordingBankClient_StoreFailureDoesNotFailCall(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	store := &fakeAttemptStore{err: errors.New("database unavailable")}
+	client := newRecordingClient(mockClient, store)
+
+	req := bank.VoidRequest{AuthorizationID: "auth-123"}
+
+	mockClient.EXPECT().
+		Void(mock.Anything, req, "idem-key").
+		Return(&bank.VoidResponse{VoidID: "void-123"}, nil).
+		Once()
+
+	resp, err := client.Void(context.Background(), req, "idem-key")
+	require.NoError(t, err)
+	assert.Equal(t, "void-123", resp.VoidID)
+	assert.Len(t, store.attempts, 1)
+}
@@ -4,23 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 )
 
+// MerchantSettingsSource supplies the settings of the merchant in ctx
+type MerchantSettingsSource interface {
+	Find(ctx context.Context) (*domain.MerchantSettings, error)
+}
+
 type RetryBankClient struct {
 	inner      BankClient
 	baseDelay  time.Duration
 	maxRetries int
+	settings   MerchantSettingsSource
 }
 
-func NewRetryBankClient(inner BankClient, cfg config.RetryConfig) BankClient {
+// NewRetryBankClient retries failed bank calls with the policy in cfg, or with the
+// merchant's own policy where settings has one. settings may be nil.
+func NewRetryBankClient(inner BankClient, cfg config.RetryConfig, settings MerchantSettingsSource) BankClient {
 	return &RetryBankClient{
 		inner:      inner,
 		baseDelay:  time.Duration(cfg.BaseDelay) * time.Second,
 		maxRetries: int(cfg.MaxRetries),
+		settings:   settings,
 	}
 }
 
@@ -78,11 +87,64 @@ func (r *RetryBankClient) GetAuthorization(ctx context.Context, authID string) (
 	)
 }
 
-// Generic retry helper
+func (r *RetryBankClient) GetCapture(ctx context.Context, idempotencyKey string) (*CaptureResponse, error) {
+	return retry(
+		r,
+		ctx,
+		func(ctx context.Context) (*CaptureResponse, error) {
+			return r.inner.GetCapture(ctx, idempotencyKey)
+		},
+	)
+}
+
+func (r *RetryBankClient) GetRefund(ctx context.Context, idempotencyKey string) (*RefundResponse, error) {
+	return retry(
+		r,
+		ctx,
+		func(ctx context.Context) (*RefundResponse, error) {
+			return r.inner.GetRefund(ctx, idempotencyKey)
+		},
+	)
+}
+
+// Payout with retry logic
+func (r *RetryBankClient) Payout(ctx context.Context, req PayoutRequest, idempotencyKey string) (*PayoutResponse, error) {
+	return retry(
+		r,
+		ctx,
+		func(ctx context.Context) (*PayoutResponse, error) {
+			return r.inner.Payout(ctx, req, idempotencyKey)
+		},
+	)
+}
+
+func (r *RetryBankClient) GetPayout(ctx context.Context, payoutID string) (*PayoutResponse, error) {
+	return retry(
+		r,
+		ctx,
+		func(ctx context.Context) (*PayoutResponse, error) {
+			return r.inner.GetPayout(ctx, payoutID)
+		},
+	)
+}
+
+// outcomeReserve is the part of the caller's deadline left unspent by bank calls, so
+// the gateway can still record what the bank answered before the caller gives up
+const outcomeReserve = 250 * time.Millisecond
+
+// Generic retry helper. Under a deadline it gives up on retries that could not finish
+// before it, leaving the payment to the retry worker rather than answering late.
 func retry[T any](r *RetryBankClient, ctx context.Context, operation func(ctx context.Context) (*T, error)) (*T, error) {
 	var lastErr error
+	maxRetries, baseDelay := r.policy(ctx)
+
+	if deadline, ok := ctx.Deadline(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline.Add(-outcomeReserve))
+		defer cancel()
+	}
 
-	for attempt := 0; attempt < r.maxRetries; attempt++ {
+	for attempt := 0; attempt < maxRetries; attempt++ {
 		select {
 		case <-ctx.Done():
 			return nil, ctx.Err()
@@ -96,18 +158,51 @@ func retry[T any](r *RetryBankClient, ctx context.Context, operation func(ctx co
 
 		lastErr = err
 
-		if !isRetryable(err) {
+		// A timeout of our own deadline rather than of the bank is not worth retrying
+		if !isRetryable(err) || ctx.Err() != nil {
 			return nil, err
 		}
 
-		if attempt < r.maxRetries-1 {
-			time.Sleep(r.backoff(attempt))
+		if attempt < maxRetries-1 {
+			delay := domain.Backoff{Base: baseDelay, Jitter: time.Second}.Delay(attempt)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+				return nil, fmt.Errorf("deadline leaves no time to retry: %w", lastErr)
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			case <-timer.C:
+			}
 		}
 	}
 
 	return nil, fmt.Errorf("maximum retries exceeded: %w", lastErr)
 }
 
+// policy returns the retry policy for the merchant in ctx. Settings that cannot be
+// read fall back to the default policy rather than failing the bank call.
+func (r *RetryBankClient) policy(ctx context.Context) (int, time.Duration) {
+	maxRetries, baseDelay := r.maxRetries, r.baseDelay
+	if r.settings == nil {
+		return maxRetries, baseDelay
+	}
+
+	settings, err := r.settings.Find(ctx)
+	if err != nil {
+		return maxRetries, baseDelay
+	}
+	if settings.MaxRetries != nil {
+		maxRetries = *settings.MaxRetries
+	}
+	if settings.RetryBaseDelay != nil {
+		baseDelay = *settings.RetryBaseDelay
+	}
+	return maxRetries, baseDelay
+}
+
 // Helper: to check retryable errors
 func isRetryable(err error) bool {
 	var bankErr *BankError
@@ -129,12 +224,3 @@ func isRetryable(err error) bool {
 
 	return false
 }
-
-// Backoff calculation with exponential delay and jitter
-func (r *RetryBankClient) backoff(attempt int) time.Duration {
-	base := r.baseDelay * time.Duration(1<<attempt)
-
-	jitter := time.Duration(rand.Intn(1000)) * time.Millisecond //nolint:gosec // not cryptographic
-
-	return base + jitter
-}
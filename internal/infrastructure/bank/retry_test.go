@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +20,7 @@ func TestRetryBankClient_Authorize_Success(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.AuthorizationRequest{
 		Amount:      5000,
@@ -54,7 +55,7 @@ func TestRetryBankClient_Authorize_RetriesOn5xx(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.AuthorizationRequest{
 		Amount:      5000,
@@ -95,7 +96,7 @@ func TestRetryBankClient_Authorize_DoesNotRetryOn4xx(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.AuthorizationRequest{
 		Amount:      5000,
@@ -132,7 +133,7 @@ func TestRetryBankClient_Authorize_ExhaustsRetries(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.AuthorizationRequest{
 		Amount:      5000,
@@ -166,7 +167,7 @@ func TestRetryBankClient_Capture_Success(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.CaptureRequest{
 		Amount:          5000,
@@ -198,7 +199,7 @@ func TestRetryBankClient_Void_Success(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.VoidRequest{
 		AuthorizationID: "auth-123",
@@ -227,7 +228,7 @@ func TestRetryBankClient_Refund_Success(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 3,
-	})
+	}, nil)
 
 	req := bank.RefundRequest{
 		Amount:    5000,
@@ -259,7 +260,7 @@ func TestRetryBankClient_RespectsContextCancellation(t *testing.T) {
 	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
 		BaseDelay:  1,
 		MaxRetries: 10, // High retry count
-	})
+	}, nil)
 
 	req := bank.AuthorizationRequest{
 		Amount:      5000,
@@ -292,3 +293,81 @@ func TestRetryBankClient_RespectsContextCancellation(t *testing.T) {
 	assert.Nil(t, resp)
 	assert.Equal(t, context.Canceled, err)
 }
+
+func TestRetryBankClient_StopsRetryingWhenDeadlineLeavesNoTime(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
+		BaseDelay:  1,
+		MaxRetries: 10,
+	}, nil)
+
+	req := bank.AuthorizationRequest{
+		Amount:      5000,
+		CardNumber:  "4111111111111111",
+		Cvv:         "123",
+		ExpiryMonth: 12,
+		ExpiryYear:  2030,
+	}
+
+	bankErr := &bank.BankError{Code: "internal_error", StatusCode: 500}
+	// The first backoff alone is longer than the client waits
+	mockClient.EXPECT().
+		Authorize(mock.Anything, req, "idem-key").
+		Return(nil, bankErr).
+		Once()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 800*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := retryClient.Authorize(ctx, req, "idem-key")
+
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, bankErr)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestRetryBankClient_SkipsBankWhenDeadlineIsSpent(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
+		BaseDelay:  1,
+		MaxRetries: 3,
+	}, nil)
+
+	// Too little is left to record the bank's answer, so the bank is not called
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	resp, err := retryClient.Capture(ctx, bank.CaptureRequest{Amount: 5000}, "idem-key")
+
+	require.Error(t, err)
+	assert.Nil(t, resp)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+type fakeSettings struct {
+	settings *domain.MerchantSettings
+}
+
+func (f fakeSettings) Find(context.Context) (*domain.MerchantSettings, error) {
+	return f.settings, nil
+}
+
+func TestRetryBankClient_UsesMerchantRetryPolicy(t *testing.T) {
+	mockClient := mocks.NewMockBankClient(t)
+	maxRetries := 1
+	retryClient := bank.NewRetryBankClient(mockClient, config.RetryConfig{
+		BaseDelay:  1,
+		MaxRetries: 3,
+	}, fakeSettings{settings: &domain.MerchantSettings{MaxRetries: &maxRetries}})
+
+	// The merchant allows a single attempt, so the 503 is not retried
+	mockClient.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "idem-key").
+		Return(nil, &bank.BankError{Code: "internal_error", StatusCode: 503}).
+		Once()
+
+	_, err := retryClient.Authorize(context.Background(), bank.AuthorizationRequest{}, "idem-key")
+	require.Error(t, err)
+}
@@ -0,0 +1,100 @@
+package bank
+
+import (
+	"context"
+	"log/slog"
+	"math/rand"
+	"time"
+)
+
+// maxInFlightShadowCalls bounds the goroutines waiting on the candidate acquirer;
+// samples beyond it are dropped rather than queued.
+const maxInFlightShadowCalls = 32
+
+// ShadowBankClient sends every call to the primary client and mirrors a percentage of
+// authorizations to a candidate acquirer. The candidate's responses are only compared
+// with the primary's and logged; they never reach the caller.
+type ShadowBankClient struct {
+	BankClient
+	shadow   BankClient
+	percent  int
+	timeout  time.Duration
+	inFlight chan struct{}
+	logger   *slog.Logger
+}
+
+func NewShadowBankClient(primary, shadow BankClient, percent int, timeout time.Duration, logger *slog.Logger) BankClient {
+	return &ShadowBankClient{
+		BankClient: primary,
+		shadow:     shadow,
+		percent:    percent,
+		timeout:    timeout,
+		inFlight:   make(chan struct{}, maxInFlightShadowCalls),
+		logger:     logger,
+	}
+}
+
+func (s *ShadowBankClient) Authorize(ctx context.Context, req AuthorizationRequest, idempotencyKey string) (*AuthorizationResponse, error) {
+	start := time.Now()
+	resp, err := s.BankClient.Authorize(ctx, req, idempotencyKey)
+	primaryLatency := time.Since(start)
+
+	if s.percent > 0 && rand.Intn(100) < s.percent { //nolint:gosec // Sampling does not need a secure source
+		select {
+		case s.inFlight <- struct{}{}:
+			go func() {
+				defer func() { <-s.inFlight }()
+				s.mirrorAuthorize(context.WithoutCancel(ctx), req, idempotencyKey, resp, err, primaryLatency)
+			}()
+		default:
+			s.logger.Warn("shadow authorization dropped, too many in flight", "idempotency_key", idempotencyKey)
+		}
+	}
+
+	return resp, err
+}
+
+func (s *ShadowBankClient) mirrorAuthorize(
+	ctx context.Context,
+	req AuthorizationRequest,
+	idempotencyKey string,
+	primaryResp *AuthorizationResponse,
+	primaryErr error,
+	primaryLatency time.Duration,
+) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	shadowResp, shadowErr := s.shadow.Authorize(ctx, req, "shadow-"+idempotencyKey)
+	shadowLatency := time.Since(start)
+
+	primaryOutcome := authorizeOutcome(primaryResp, primaryErr)
+	shadowOutcome := authorizeOutcome(shadowResp, shadowErr)
+
+	attrs := []any{
+		"idempotency_key", idempotencyKey,
+		"primary_outcome", primaryOutcome,
+		"shadow_outcome", shadowOutcome,
+		"primary_latency_ms", primaryLatency.Milliseconds(),
+		"shadow_latency_ms", shadowLatency.Milliseconds(),
+	}
+
+	if primaryOutcome != shadowOutcome {
+		s.logger.Warn("shadow authorization mismatch", attrs...)
+		return
+	}
+	s.logger.Info("shadow authorization matched", attrs...)
+}
+
+// authorizeOutcome reduces an authorization result to what both acquirers should agree
+// on: the resulting status, or the error code when the bank declined.
+func authorizeOutcome(resp *AuthorizationResponse, err error) string {
+	if err != nil {
+		if bankErr, ok := IsBankError(err); ok {
+			return "error:" + bankErr.Code
+		}
+		return "error:transport"
+	}
+	return resp.Status
+}
@@ -0,0 +1,67 @@
+package bank_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShadowBankClient_Authorize_MirrorsAndReturnsPrimary(t *testing.T) {
+	primary := mocks.NewMockBankClient(t)
+	shadow := mocks.NewMockBankClient(t)
+	client := bank.NewShadowBankClient(primary, shadow, 100, time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	req := bank.AuthorizationRequest{Amount: 5000, CardNumber: "4111111111111111", Cvv: "123"}
+	primaryResp := &bank.AuthorizationResponse{AuthorizationID: "auth-primary", Status: "AUTHORIZED"}
+
+	primary.EXPECT().
+		Authorize(mock.Anything, req, "idem-key").
+		Return(primaryResp, nil).
+		Once()
+
+	mirrored := make(chan struct{})
+	shadow.EXPECT().
+		Authorize(mock.Anything, req, "shadow-idem-key").
+		Run(func(context.Context, bank.AuthorizationRequest, string) { close(mirrored) }).
+		Return(nil, &bank.BankError{Code: "internal_error", StatusCode: 500}).
+		Once()
+
+	resp, err := client.Authorize(context.Background(), req, "idem-key")
+	require.NoError(t, err)
+	assert.Equal(t, primaryResp, resp)
+
+	select {
+	case <-mirrored:
+	case <-time.After(time.Second):
+		t.Fatal("authorization was not mirrored to the shadow acquirer")
+	}
+}
+
+func TestShadowBankClient_ZeroPercentNeverMirrors(t *testing.T) {
+	primary := mocks.NewMockBankClient(t)
+	shadow := mocks.NewMockBankClient(t)
+	client := bank.NewShadowBankClient(primary, shadow, 0, time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	req := bank.CaptureRequest{Amount: 5000, AuthorizationID: "auth-123"}
+	primary.EXPECT().
+		Capture(mock.Anything, req, "idem-key").
+		Return(&bank.CaptureResponse{CaptureID: "cap-123"}, nil).
+		Once()
+	primary.EXPECT().
+		Authorize(mock.Anything, mock.Anything, "idem-key-2").
+		Return(&bank.AuthorizationResponse{Status: "AUTHORIZED"}, nil).
+		Once()
+
+	_, err := client.Capture(context.Background(), req, "idem-key")
+	require.NoError(t, err)
+	_, err = client.Authorize(context.Background(), bank.AuthorizationRequest{Amount: 100}, "idem-key-2")
+	require.NoError(t, err)
+}
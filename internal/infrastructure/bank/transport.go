@@ -0,0 +1,40 @@
+package bank
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+)
+
+const (
+	defaultMaxIdleConnsPerHost = 100
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// NewTransport builds the connection pool the bank clients share. http.DefaultTransport
+// keeps only two idle connections per host, so under a burst of captures most calls
+// would open a new connection, and pay for a TLS handshake, rather than reuse one.
+func NewTransport(cfg config.BankConfig) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.MaxIdleConnsPerHost = defaultMaxIdleConnsPerHost
+	if cfg.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConnsPerHost
+	}
+	// The pool's overall limit must not cut the per-host one short
+	transport.MaxIdleConns = max(transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+
+	transport.IdleConnTimeout = defaultIdleConnTimeout
+	if cfg.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = cfg.IdleConnTimeout
+	}
+
+	transport.TLSHandshakeTimeout = defaultTLSHandshakeTimeout
+	if cfg.TLSHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = cfg.TLSHandshakeTimeout
+	}
+
+	return transport
+}
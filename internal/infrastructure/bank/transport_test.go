@@ -0,0 +1,63 @@
+package bank_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransport_Defaults(t *testing.T) {
+	transport := bank.NewTransport(config.BankConfig{})
+
+	assert.Equal(t, 100, transport.MaxIdleConnsPerHost)
+	assert.GreaterOrEqual(t, transport.MaxIdleConns, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+	assert.Equal(t, 10*time.Second, transport.TLSHandshakeTimeout)
+}
+
+func TestNewTransport_Configured(t *testing.T) {
+	transport := bank.NewTransport(config.BankConfig{
+		MaxIdleConnsPerHost: 500,
+		IdleConnTimeout:     time.Minute,
+		TLSHandshakeTimeout: 3 * time.Second,
+	})
+
+	assert.Equal(t, 500, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 500, transport.MaxIdleConns)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout)
+}
+
+func TestNewTransport_ReusesConnections(t *testing.T) {
+	var conns atomic.Int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status":"AUTHORIZED"}`))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	cfg := config.BankConfig{BankBaseURL: server.URL}
+	client := bank.NewBankClient(cfg, bank.NewTransport(cfg))
+
+	for range 5 {
+		_, err := client.GetAuthorization(context.Background(), "auth-1")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), conns.Load())
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// PaymentCache keeps payments by merchant and ID, and by merchant and order, for as long
+// as ttl. It never fails a read: when Redis is unreachable the caller goes to the
+// database, and the error is logged.
+type PaymentCache struct {
+	client *RedisClient
+	ttl    time.Duration
+	logger *slog.Logger
+}
+
+func NewPaymentCache(client *RedisClient, ttl time.Duration, logger *slog.Logger) *PaymentCache {
+	return &PaymentCache{client: client, ttl: ttl, logger: logger}
+}
+
+func (c *PaymentCache) ByID(ctx context.Context, merchantID, id string) (*domain.Payment, bool) {
+	return c.get(ctx, idKey(merchantID, id))
+}
+
+func (c *PaymentCache) ByOrderID(ctx context.Context, merchantID, orderID string) (*domain.Payment, bool) {
+	return c.get(ctx, orderKey(merchantID, orderID))
+}
+
+// StoreByID keeps payment under its ID
+func (c *PaymentCache) StoreByID(ctx context.Context, payment *domain.Payment) {
+	c.set(ctx, idKey(payment.MerchantID, payment.ID), payment)
+}
+
+// StoreByOrderID keeps payment as the one found for its order
+func (c *PaymentCache) StoreByOrderID(ctx context.Context, payment *domain.Payment) {
+	c.set(ctx, orderKey(payment.MerchantID, payment.OrderID), payment)
+}
+
+// Invalidate drops the copies of the payment kept under its ID and its order
+func (c *PaymentCache) Invalidate(ctx context.Context, merchantID, id, orderID string) {
+	if err := c.client.Del(ctx, idKey(merchantID, id), orderKey(merchantID, orderID)); err != nil {
+		c.logger.Error("failed to invalidate cached payment; it may be served stale until it expires",
+			"payment_id", id,
+			"ttl", c.ttl,
+			"error", err,
+		)
+	}
+}
+
+func (c *PaymentCache) get(ctx context.Context, key string) (*domain.Payment, bool) {
+	data, err := c.client.Get(ctx, key)
+	if err != nil {
+		c.logger.Warn("payment cache read failed", "error", err)
+		return nil, false
+	}
+	if data == nil {
+		return nil, false
+	}
+
+	var payment domain.Payment
+	if err := json.Unmarshal(data, &payment); err != nil {
+		c.logger.Warn("dropping unreadable cached payment", "error", err)
+		return nil, false
+	}
+	return &payment, true
+}
+
+func (c *PaymentCache) set(ctx context.Context, key string, payment *domain.Payment) {
+	data, err := json.Marshal(payment)
+	if err != nil {
+		c.logger.Warn("payment cache write failed", "error", err)
+		return
+	}
+	if err := c.client.Set(ctx, key, data, c.ttl); err != nil {
+		c.logger.Warn("payment cache write failed", "error", err)
+	}
+}
+
+func idKey(merchantID, id string) string {
+	return "payment:" + merchantID + ":id:" + id
+}
+
+func orderKey(merchantID, orderID string) string {
+	return "payment:" + merchantID + ":order:" + orderID
+}
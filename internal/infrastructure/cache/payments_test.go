@@ -0,0 +1,49 @@
+package cache_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func TestPaymentCache_StoresAndInvalidates(t *testing.T) {
+	ctx := context.Background()
+	server := startFakeRedis(t, "")
+	c := cache.NewPaymentCache(cache.NewRedisClient(server.addr, "", 0, 2, time.Second), time.Minute, discard)
+
+	payment := &domain.Payment{ID: "pay-1", MerchantID: "m-1", OrderID: "order-1", Status: domain.StatusAuthorized, AmountCents: 500}
+	c.StoreByID(ctx, payment)
+	c.StoreByOrderID(ctx, payment)
+
+	cached, ok := c.ByID(ctx, "m-1", "pay-1")
+	require.True(t, ok)
+	assert.Equal(t, payment, cached)
+	_, ok = c.ByOrderID(ctx, "m-1", "order-1")
+	assert.True(t, ok)
+	_, ok = c.ByID(ctx, "m-2", "pay-1")
+	assert.False(t, ok, "another merchant must not see the copy")
+
+	c.Invalidate(ctx, "m-1", "pay-1", "order-1")
+
+	_, ok = c.ByID(ctx, "m-1", "pay-1")
+	assert.False(t, ok)
+	_, ok = c.ByOrderID(ctx, "m-1", "order-1")
+	assert.False(t, ok)
+}
+
+func TestPaymentCache_UnreachableRedisIsAMiss(t *testing.T) {
+	c := cache.NewPaymentCache(cache.NewRedisClient("127.0.0.1:1", "", 0, 2, 100*time.Millisecond), time.Minute, discard)
+
+	_, ok := c.ByID(context.Background(), "m-1", "pay-1")
+
+	assert.False(t, ok)
+}
@@ -0,0 +1,78 @@
+// Package cache keeps copies of hot reads in Redis
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrRedis is a command Redis refused, as opposed to a connection failure
+var ErrRedis = errors.New("redis error")
+
+// RedisClient runs the few commands the cache needs over a go-redis connection pool
+type RedisClient struct {
+	client *redis.Client
+}
+
+// NewRedisClient builds a client for the Redis server at addr, with a pool of up to
+// poolSize connections. Dialing and each command give up after timeout, or sooner at
+// the context's deadline. Failed dials and commands are not retried: a slow cache is no
+// use, and the caller can always go to the database instead.
+func NewRedisClient(addr, password string, db, poolSize int, timeout time.Duration) *RedisClient {
+	return &RedisClient{client: redis.NewClient(&redis.Options{
+		Addr:                  addr,
+		Password:              password,
+		DB:                    db,
+		PoolSize:              poolSize,
+		DialTimeout:           timeout,
+		ReadTimeout:           timeout,
+		WriteTimeout:          timeout,
+		ContextTimeoutEnabled: true,
+		MaxRetries:            -1,
+		DialerRetries:         1,
+	})}
+}
+
+// Get returns the value of key, or nil if it is not set
+func (c *RedisClient) Get(ctx context.Context, key string) ([]byte, error) {
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, redisError(err)
+	}
+	return value, nil
+}
+
+// Set stores value under key until ttl has passed
+func (c *RedisClient) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return redisError(c.client.Set(ctx, key, value, ttl).Err())
+}
+
+func (c *RedisClient) Del(ctx context.Context, keys ...string) error {
+	return redisError(c.client.Del(ctx, keys...).Err())
+}
+
+func (c *RedisClient) Ping(ctx context.Context) error {
+	return redisError(c.client.Ping(ctx).Err())
+}
+
+// Close closes the pool's connections
+func (c *RedisClient) Close() {
+	_ = c.client.Close()
+}
+
+// redisError marks an error reply from Redis with ErrRedis, leaving connection
+// failures as they are
+func redisError(err error) error {
+	var reply redis.Error
+	if errors.As(err, &reply) {
+		return fmt.Errorf("%w: %s", ErrRedis, reply.Error())
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+package cache_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRedis answers GET, SET, DEL, PING and AUTH from memory, ignoring expiry
+type fakeRedis struct {
+	mu       sync.Mutex
+	values   map[string]string
+	password string
+	addr     string
+}
+
+func startFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	f := &fakeRedis{values: make(map[string]string), password: password, addr: listener.Addr().String()}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go f.serve(conn)
+		}
+	}()
+	return f
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	authenticated := f.password == ""
+
+	for {
+		args, err := readCommand(r)
+		if err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		var reply string
+		switch cmd := strings.ToUpper(args[0]); {
+		case cmd == "AUTH":
+			authenticated = args[1] == f.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case cmd == "PING":
+			reply = "+PONG\r\n"
+		case cmd == "GET":
+			if value, ok := f.values[args[1]]; ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			} else {
+				reply = "$-1\r\n"
+			}
+		case cmd == "SET":
+			f.values[args[1]] = args[2]
+			reply = "+OK\r\n"
+		case cmd == "DEL":
+			deleted := 0
+			for _, key := range args[1:] {
+				if _, ok := f.values[key]; ok {
+					delete(f.values, key)
+					deleted++
+				}
+			}
+			reply = fmt.Sprintf(":%d\r\n", deleted)
+		default:
+			reply = "-ERR unknown command\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+func readCommand(r *bufio.Reader) ([]string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		if _, err := r.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		arg, err := r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(arg, "\r\n")
+	}
+	return args, nil
+}
+
+func TestRedisClient_SetGetDel(t *testing.T) {
+	ctx := context.Background()
+	server := startFakeRedis(t, "secret")
+	client := cache.NewRedisClient(server.addr, "secret", 0, 2, time.Second)
+	defer client.Close()
+
+	require.NoError(t, client.Ping(ctx))
+
+	value, err := client.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, client.Set(ctx, "payment:1", []byte(`{"ID":"1"}`), time.Minute))
+	value, err = client.Get(ctx, "payment:1")
+	require.NoError(t, err)
+	assert.Equal(t, `{"ID":"1"}`, string(value))
+
+	require.NoError(t, client.Del(ctx, "payment:1", "payment:2"))
+	value, err = client.Get(ctx, "payment:1")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
+
+func TestRedisClient_WrongPassword(t *testing.T) {
+	server := startFakeRedis(t, "secret")
+	client := cache.NewRedisClient(server.addr, "wrong", 0, 2, time.Second)
+
+	err := client.Ping(context.Background())
+
+	assert.ErrorIs(t, err, cache.ErrRedis)
+}
@@ -0,0 +1,132 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+)
+
+// faultStatuses are the failures a call can be given, the ones clients are expected to
+// retry
+var faultStatuses = []int{
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// Fault is what is injected into one call: a delay before it is handled and, when
+// Status is set, the 5xx it fails with
+type Fault struct {
+	Delay  time.Duration
+	Status int
+}
+
+// Injected reports whether the call is affected at all
+func (f Fault) Injected() bool {
+	return f.Delay > 0 || f.Status != 0
+}
+
+// String describes the fault as it is reported in the X-Chaos-Injected header
+func (f Fault) String() string {
+	var parts []string
+	if f.Delay > 0 {
+		parts = append(parts, "delay="+f.Delay.String())
+	}
+	if f.Status != 0 {
+		parts = append(parts, fmt.Sprintf("status=%d", f.Status))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Wait holds the call for the fault's delay, or until ctx is done
+func (f Fault) Wait(ctx context.Context) error {
+	if f.Delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(f.Delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Injector draws the fault for each call from the configured odds
+type Injector struct {
+	latency        time.Duration
+	latencyPercent int
+	errorPercent   int
+}
+
+func NewInjector(cfg config.ChaosConfig) *Injector {
+	return &Injector{
+		latency:        cfg.Latency,
+		latencyPercent: cfg.LatencyPercent,
+		errorPercent:   cfg.ErrorPercent,
+	}
+}
+
+// Next draws the fault for the next call
+func (i *Injector) Next() Fault {
+	var fault Fault
+	if i.latency > 0 && rand.IntN(100) < i.latencyPercent {
+		fault.Delay = i.latency
+	}
+	if rand.IntN(100) < i.errorPercent {
+		fault.Status = faultStatuses[rand.IntN(len(faultStatuses))]
+	}
+	return fault
+}
+
+// Transport injects faults into the calls made to the bank. A failed call still reaches
+// the bank and only its response is replaced, as when a response is lost on the way
+// back, so retries meet whatever the bank did with the first attempt.
+type Transport struct {
+	inner    http.RoundTripper
+	injector *Injector
+}
+
+func NewTransport(inner http.RoundTripper, injector *Injector) http.RoundTripper {
+	if inner == nil {
+		inner = http.DefaultTransport
+	}
+	return &Transport{
+		inner:    inner,
+		injector: injector,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fault := t.injector.Next()
+	if err := fault.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.inner.RoundTrip(req)
+	if err != nil || fault.Status == 0 {
+		return resp, err
+	}
+	_ = resp.Body.Close() //nolint:errcheck // The response is discarded
+
+	body := `{"error":"chaos_injected","message":"fault injected by the gateway for chaos testing"}`
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fault.Status, http.StatusText(fault.Status)),
+		StatusCode:    fault.Status,
+		Proto:         resp.Proto,
+		ProtoMajor:    resp.ProtoMajor,
+		ProtoMinor:    resp.ProtoMinor,
+		Header:        http.Header{"Content-Type": []string{"application/json"}, "X-Chaos-Injected": []string{fault.String()}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
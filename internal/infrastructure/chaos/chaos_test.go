@@ -0,0 +1,83 @@
+package chaos_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/chaos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjector_Next(t *testing.T) {
+	quiet := chaos.NewInjector(config.ChaosConfig{Enabled: true, Latency: time.Second})
+	for range 100 {
+		assert.False(t, quiet.Next().Injected())
+	}
+
+	always := chaos.NewInjector(config.ChaosConfig{
+		Enabled:        true,
+		Latency:        time.Second,
+		LatencyPercent: 100,
+		ErrorPercent:   100,
+	})
+	for range 100 {
+		fault := always.Next()
+		assert.Equal(t, time.Second, fault.Delay)
+		assert.GreaterOrEqual(t, fault.Status, 500)
+		assert.Less(t, fault.Status, 600)
+	}
+}
+
+func TestFault_WaitStopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := chaos.Fault{Delay: time.Hour}.Wait(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestTransport_ReplacesResponseAfterBankHandledCall(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = io.WriteString(w, `{"status":"AUTHORIZED"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	injector := chaos.NewInjector(config.ChaosConfig{Enabled: true, ErrorPercent: 100})
+	client := &http.Client{Transport: chaos.NewTransport(nil, injector)}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.GreaterOrEqual(t, resp.StatusCode, 500)
+	assert.Contains(t, string(body), "chaos_injected")
+	assert.NotEmpty(t, resp.Header.Get("X-Chaos-Injected"))
+}
+
+func TestTransport_PassesThroughWithoutFault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, `{"status":"AUTHORIZED"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{Transport: chaos.NewTransport(nil, chaos.NewInjector(config.ChaosConfig{Enabled: true}))}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("X-Chaos-Injected"))
+}
@@ -0,0 +1,60 @@
+// Package clock tells the gateway what time it is. Production runs on the system clock;
+// a sandbox can run on a test clock that is moved forward on request, so expiry,
+// retries and scheduled payments can be rehearsed without waiting for them.
+package clock
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrNotForward = errors.New("the test clock only moves forward")
+
+// Clock returns the current time
+type Clock interface {
+	Now() time.Time
+}
+
+// System is the wall clock
+type System struct{}
+
+func (System) Now() time.Time {
+	return time.Now()
+}
+
+// Test runs ahead of the wall clock by an offset that only grows. Time keeps passing
+// between advances, so the workers' tickers and timeouts behave as usual.
+type Test struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+func NewTest() *Test {
+	return &Test{}
+}
+
+func (c *Test) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// Advance moves the clock forward by d and returns the time it now reads
+func (c *Test) Advance(d time.Duration) (time.Time, error) {
+	if d <= 0 {
+		return time.Time{}, ErrNotForward
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset += d
+	return time.Now().Add(c.offset), nil
+}
+
+// Offset returns how far the clock has been advanced in all
+func (c *Test) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
@@ -0,0 +1,34 @@
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTest_Advance(t *testing.T) {
+	c := clock.NewTest()
+	assert.WithinDuration(t, time.Now(), c.Now(), time.Second)
+
+	now, err := c.Advance(48 * time.Hour)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), now, time.Second)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), c.Now(), time.Second)
+
+	_, err = c.Advance(time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 49*time.Hour, c.Offset())
+}
+
+func TestTest_OnlyMovesForward(t *testing.T) {
+	c := clock.NewTest()
+
+	_, err := c.Advance(-time.Hour)
+	assert.ErrorIs(t, err, clock.ErrNotForward)
+	_, err = c.Advance(0)
+	assert.ErrorIs(t, err, clock.ErrNotForward)
+	assert.Zero(t, c.Offset())
+}
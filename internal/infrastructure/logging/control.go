@@ -0,0 +1,162 @@
+// Package logging lets the log level be changed while the gateway runs, for every
+// record or only for the records of chosen payments.
+package logging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// PaymentIDKey is the attribute that ties a record to a payment
+const PaymentIDKey = "payment_id"
+
+// MaxDebugPayments caps the payments debug logging can be enabled for at once
+const MaxDebugPayments = 100
+
+var ErrTooManyDebugPayments = fmt.Errorf("debug logging can be enabled for at most %d payments", MaxDebugPayments)
+
+// Controller holds the level records must reach to be logged, and the payments whose
+// records are logged down to debug whatever that level is
+type Controller struct {
+	level slog.LevelVar
+
+	mu       sync.RWMutex
+	payments map[string]struct{}
+	// anyPayments spares Enabled the lock while no payment is being debugged
+	anyPayments atomic.Bool
+}
+
+func NewController(level slog.Level) *Controller {
+	c := &Controller{payments: make(map[string]struct{})}
+	c.level.Set(level)
+	return c
+}
+
+// ParseLevel parses debug, info, warn or error, in any case
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, errors.New("log level must be debug, info, warn or error")
+	}
+}
+
+func (c *Controller) Level() slog.Level {
+	return c.level.Level()
+}
+
+func (c *Controller) SetLevel(level slog.Level) {
+	c.level.Set(level)
+}
+
+// DebugPayments returns the payments logged down to debug, sorted
+func (c *Controller) DebugPayments() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make([]string, 0, len(c.payments))
+	for id := range c.payments {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// SetDebugPayments replaces the payments logged down to debug; none turns it off
+func (c *Controller) SetDebugPayments(paymentIDs []string) error {
+	if len(paymentIDs) > MaxDebugPayments {
+		return ErrTooManyDebugPayments
+	}
+
+	payments := make(map[string]struct{}, len(paymentIDs))
+	for _, id := range paymentIDs {
+		payments[id] = struct{}{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.payments = payments
+	c.anyPayments.Store(len(payments) > 0)
+	return nil
+}
+
+func (c *Controller) debugging(paymentID string) bool {
+	if paymentID == "" || !c.anyPayments.Load() {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.payments[paymentID]
+	return ok
+}
+
+// Handler wraps next, which must let debug records through, so that it only receives
+// records at the controller's level or records of a payment being debugged
+func (c *Controller) Handler(next slog.Handler) slog.Handler {
+	return &handler{next: next, control: c}
+}
+
+type handler struct {
+	next    slog.Handler
+	control *Controller
+	// paymentID is the payment bound to the logger with With, if any
+	paymentID string
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	if level >= h.control.Level() {
+		return h.next.Enabled(ctx, level)
+	}
+	if !h.control.anyPayments.Load() {
+		return false
+	}
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= h.control.Level() {
+		return h.next.Handle(ctx, r)
+	}
+
+	paymentID := h.paymentID
+	if paymentID == "" {
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == PaymentIDKey {
+				paymentID = a.Value.Resolve().String()
+				return false
+			}
+			return true
+		})
+	}
+	if !h.control.debugging(paymentID) {
+		return nil
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	paymentID := h.paymentID
+	for _, a := range attrs {
+		if a.Key == PaymentIDKey {
+			paymentID = a.Value.Resolve().String()
+		}
+	}
+	return &handler{next: h.next.WithAttrs(attrs), control: h.control, paymentID: paymentID}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), control: h.control, paymentID: h.paymentID}
+}
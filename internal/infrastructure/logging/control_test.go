@@ -0,0 +1,60 @@
+package logging_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogger(control *logging.Controller) (*slog.Logger, *bytes.Buffer) {
+	var out bytes.Buffer
+	next := slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return slog.New(control.Handler(next)), &out
+}
+
+func TestController_SetLevel(t *testing.T) {
+	control := logging.NewController(slog.LevelInfo)
+	logger, out := newLogger(control)
+
+	logger.Debug("hidden")
+	assert.Empty(t, out.String())
+
+	control.SetLevel(slog.LevelDebug)
+	logger.Debug("shown")
+	assert.Contains(t, out.String(), "shown")
+}
+
+func TestController_DebugsChosenPayments(t *testing.T) {
+	control := logging.NewController(slog.LevelInfo)
+	logger, out := newLogger(control)
+	require.NoError(t, control.SetDebugPayments([]string{"pay-1"}))
+
+	logger.Debug("other payment", "payment_id", "pay-2")
+	logger.Debug("no payment")
+	assert.Empty(t, out.String())
+
+	logger.Debug("chosen payment", "payment_id", "pay-1")
+	logger.With("payment_id", "pay-1").Debug("bound payment")
+	assert.Contains(t, out.String(), "chosen payment")
+	assert.Contains(t, out.String(), "bound payment")
+
+	require.NoError(t, control.SetDebugPayments(nil))
+	out.Reset()
+	logger.Debug("chosen payment", "payment_id", "pay-1")
+	assert.Empty(t, out.String())
+}
+
+func TestController_CapsDebugPayments(t *testing.T) {
+	control := logging.NewController(slog.LevelInfo)
+
+	ids := make([]string, logging.MaxDebugPayments+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("pay-%d", i)
+	}
+	assert.ErrorIs(t, control.SetDebugPayments(ids), logging.ErrTooManyDebugPayments)
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ExpiringWindows are how far ahead, in hours, the expiring authorizations gauge looks
+var ExpiringWindows = []int{1, 24}
+
+// authorizationTimeout bounds the queries a scrape makes for the expiring gauge
+const authorizationTimeout = 2 * time.Second
+
+// ExpiringAuthorizations counts the AUTHORIZED payments whose authorization lapses
+// before cutoff
+type ExpiringAuthorizations interface {
+	CountExpiring(ctx context.Context, cutoff time.Time) (int, error)
+}
+
+// AuthorizationMetrics reads, when scraped, how many authorizations are about to lapse
+// uncaptured, so capture SLAs can be alerted on before the funds are released. Like
+// OutboxMetrics it has no series of its own.
+type AuthorizationMetrics struct {
+	payments ExpiringAuthorizations
+	windows  []int
+}
+
+func NewAuthorizationMetrics(windows []int, payments ExpiringAuthorizations) *AuthorizationMetrics {
+	return &AuthorizationMetrics{payments: payments, windows: windows}
+}
+
+// WriteTo writes the expiring gauge, one series per window, in the Prometheus text
+// exposition format
+func (m *AuthorizationMetrics) WriteTo(w io.Writer) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), authorizationTimeout)
+	defer cancel()
+
+	now := time.Now()
+	counts := make([]int, len(m.windows))
+	for i, hours := range m.windows {
+		n, err := m.payments.CountExpiring(ctx, now.Add(time.Duration(hours)*time.Hour))
+		if err != nil {
+			return 0, nil
+		}
+		counts[i] = n
+	}
+
+	var b strings.Builder
+	b.WriteString("# HELP gateway_authorizations_expiring Authorized payments not captured yet whose authorization lapses within the window.\n")
+	b.WriteString("# TYPE gateway_authorizations_expiring gauge\n")
+	for i, hours := range m.windows {
+		fmt.Fprintf(&b, "gateway_authorizations_expiring{within_hours=\"%d\"} %d\n", hours, counts[i])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,53 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExpiring holds the expiry times of the authorized payments
+type fakeExpiring struct {
+	expiresAt []time.Time
+	err       error
+}
+
+func (f fakeExpiring) CountExpiring(_ context.Context, cutoff time.Time) (int, error) {
+	var n int
+	for _, t := range f.expiresAt {
+		if t.Before(cutoff) {
+			n++
+		}
+	}
+	return n, f.err
+}
+
+func TestAuthorizationMetrics_CountsEachWindow(t *testing.T) {
+	now := time.Now()
+	m := metrics.NewAuthorizationMetrics(metrics.ExpiringWindows, fakeExpiring{
+		expiresAt: []time.Time{now.Add(30 * time.Minute), now.Add(5 * time.Hour), now.Add(72 * time.Hour)},
+	})
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "gateway_authorizations_expiring{within_hours=\"1\"} 1\n")
+	assert.Contains(t, out.String(), "gateway_authorizations_expiring{within_hours=\"24\"} 2\n")
+}
+
+func TestAuthorizationMetrics_LeavesOutPaymentsItCannotRead(t *testing.T) {
+	m := metrics.NewAuthorizationMetrics(metrics.ExpiringWindows, fakeExpiring{err: errors.New("connection refused")})
+
+	var out strings.Builder
+	n, err := m.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Empty(t, out.String())
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// histogramVec is a histogram per set of labels, rendered as the label list they are
+// written with
+type histogramVec struct {
+	mu     sync.Mutex
+	bounds []float64
+	series map[string]*series
+}
+
+func newHistogramVec(buckets []float64) *histogramVec {
+	bounds := slices.Clone(buckets)
+	slices.Sort(bounds)
+	return &histogramVec{bounds: bounds, series: make(map[string]*series)}
+}
+
+func (h *histogramVec) observe(labels string, seconds float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[labels]
+	if !ok {
+		s = newSeries(h.bounds)
+		h.series[labels] = s
+	}
+	s.observe(h.bounds, seconds)
+}
+
+// snapshot copies every series, returning their labels in order
+func (h *histogramVec) snapshot() ([]string, map[string]series) {
+	h.mu.Lock()
+	labels := make([]string, 0, len(h.series))
+	snapshot := make(map[string]series, len(h.series))
+	for l, s := range h.series {
+		labels = append(labels, l)
+		snapshot[l] = s.clone()
+	}
+	h.mu.Unlock()
+
+	slices.Sort(labels)
+	return labels, snapshot
+}
+
+// writeTo writes a counter named counter, when given, and the histogram named name
+func (h *histogramVec) writeTo(b *strings.Builder, counter, counterHelp, name, help string) {
+	labels, snapshot := h.snapshot()
+
+	if counter != "" {
+		b.WriteString("# HELP " + counter + " " + counterHelp + "\n")
+		b.WriteString("# TYPE " + counter + " counter\n")
+		for _, l := range labels {
+			b.WriteString(counter + "{" + l + "} " + strconv.FormatUint(snapshot[l].count, 10) + "\n")
+		}
+	}
+
+	b.WriteString("# HELP " + name + " " + help + "\n")
+	b.WriteString("# TYPE " + name + " histogram\n")
+	for _, l := range labels {
+		writeHistogram(b, name, l, h.bounds, snapshot[l])
+	}
+}
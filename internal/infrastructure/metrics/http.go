@@ -0,0 +1,154 @@
+// Package metrics keeps request metrics in memory and serves them in the Prometheus
+// text format, so per-operation SLOs can be set without a client library.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the request duration histogram.
+// They reach from a cached query to a bank call that ran into its timeout.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+type seriesKey struct {
+	route       string
+	method      string
+	statusClass string
+}
+
+type series struct {
+	count   uint64
+	sum     float64
+	buckets []uint64
+}
+
+// HTTPMetrics counts requests and their durations by route, method and status class:
+// the rate, errors and duration of each operation
+type HTTPMetrics struct {
+	mu     sync.Mutex
+	bounds []float64
+	series map[seriesKey]*series
+}
+
+func NewHTTPMetrics(buckets []float64) *HTTPMetrics {
+	bounds := slices.Clone(buckets)
+	slices.Sort(bounds)
+	return &HTTPMetrics{
+		bounds: bounds,
+		series: make(map[seriesKey]*series),
+	}
+}
+
+// StatusClass returns the class of an HTTP status, such as "2xx"
+func StatusClass(status int) string {
+	if status < 100 || status > 599 {
+		return "unknown"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// Observe records a request to route that was answered with status after d
+func (m *HTTPMetrics) Observe(route, method string, status int, d time.Duration) {
+	key := seriesKey{route: route, method: method, statusClass: StatusClass(status)}
+	seconds := d.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.series[key]
+	if !ok {
+		s = newSeries(m.bounds)
+		m.series[key] = s
+	}
+	s.observe(m.bounds, seconds)
+}
+
+// ServeHTTP writes every series in the Prometheus text exposition format
+func (m *HTTPMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WriteTo(w) //nolint:errcheck // the scraper sees a truncated response
+}
+
+// WriteTo writes every series in the Prometheus text exposition format, ordered by
+// route, method and status class
+func (m *HTTPMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	keys := make([]seriesKey, 0, len(m.series))
+	snapshot := make(map[seriesKey]series, len(m.series))
+	for key, s := range m.series {
+		keys = append(keys, key)
+		snapshot[key] = s.clone()
+	}
+	m.mu.Unlock()
+
+	slices.SortFunc(keys, func(a, b seriesKey) int {
+		return strings.Compare(a.route+" "+a.method+" "+a.statusClass, b.route+" "+b.method+" "+b.statusClass)
+	})
+
+	var b strings.Builder
+	b.WriteString("# HELP gateway_http_requests_total Requests served, by route, method and status class.\n")
+	b.WriteString("# TYPE gateway_http_requests_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "gateway_http_requests_total{%s} %d\n", key.labels(), snapshot[key].count)
+	}
+
+	b.WriteString("# HELP gateway_http_request_duration_seconds Time taken to serve requests, by route, method and status class.\n")
+	b.WriteString("# TYPE gateway_http_request_duration_seconds histogram\n")
+	for _, key := range keys {
+		writeHistogram(&b, "gateway_http_request_duration_seconds", key.labels(), m.bounds, snapshot[key])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func newSeries(bounds []float64) *series {
+	return &series{buckets: make([]uint64, len(bounds))}
+}
+
+func (s *series) observe(bounds []float64, seconds float64) {
+	s.count++
+	s.sum += seconds
+	for i, bound := range bounds {
+		if seconds <= bound {
+			s.buckets[i]++
+		}
+	}
+}
+
+func (s *series) clone() series {
+	return series{count: s.count, sum: s.sum, buckets: slices.Clone(s.buckets)}
+}
+
+// writeHistogram writes the bucket, sum and count lines of one histogram series
+func writeHistogram(b *strings.Builder, name, labels string, bounds []float64, s series) {
+	for i, bound := range bounds {
+		fmt.Fprintf(b, "%s_bucket{%s,le=%q} %d\n", name, labels, strconv.FormatFloat(bound, 'g', -1, 64), s.buckets[i])
+	}
+	fmt.Fprintf(b, "%s_bucket{%s,le=\"+Inf\"} %d\n", name, labels, s.count)
+	fmt.Fprintf(b, "%s_sum{%s} %s\n", name, labels, strconv.FormatFloat(s.sum, 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count{%s} %d\n", name, labels, s.count)
+}
+
+// Handler serves the metrics of every source in the Prometheus text exposition format
+func Handler(sources ...io.WriterTo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		for _, source := range sources {
+			if _, err := source.WriteTo(w); err != nil {
+				return
+			}
+		}
+	})
+}
+
+func (k seriesKey) labels() string {
+	return fmt.Sprintf("route=%q,method=%q,status_class=%q", k.route, k.method, k.statusClass)
+}
@@ -0,0 +1,39 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusClass(t *testing.T) {
+	assert.Equal(t, "2xx", metrics.StatusClass(201))
+	assert.Equal(t, "4xx", metrics.StatusClass(429))
+	assert.Equal(t, "5xx", metrics.StatusClass(503))
+	assert.Equal(t, "unknown", metrics.StatusClass(0))
+}
+
+func TestHTTPMetrics_WritesCountersAndHistograms(t *testing.T) {
+	m := metrics.NewHTTPMetrics([]float64{0.1, 1})
+	m.Observe("/authorize", "POST", 201, 50*time.Millisecond)
+	m.Observe("/authorize", "POST", 200, 500*time.Millisecond)
+	m.Observe("/authorize", "POST", 502, 2*time.Second)
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	ok := `route="/authorize",method="POST",status_class="2xx"`
+	failed := `route="/authorize",method="POST",status_class="5xx"`
+	assert.Contains(t, out.String(), "gateway_http_requests_total{"+ok+"} 2\n")
+	assert.Contains(t, out.String(), "gateway_http_requests_total{"+failed+"} 1\n")
+	assert.Contains(t, out.String(), "gateway_http_request_duration_seconds_bucket{"+ok+`,le="0.1"} 1`+"\n")
+	assert.Contains(t, out.String(), "gateway_http_request_duration_seconds_bucket{"+ok+`,le="1"} 2`+"\n")
+	assert.Contains(t, out.String(), "gateway_http_request_duration_seconds_bucket{"+failed+`,le="1"} 0`+"\n")
+	assert.Contains(t, out.String(), "gateway_http_request_duration_seconds_bucket{"+failed+`,le="+Inf"} 1`+"\n")
+	assert.Contains(t, out.String(), "gateway_http_request_duration_seconds_count{"+ok+"} 2\n")
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// outboxTimeout bounds the queries a scrape makes for the outbox gauges
+const outboxTimeout = 2 * time.Second
+
+// Outbox reports the undelivered transition events, as the backlog and when the oldest
+// of them occurred, and the pause holding back their delivery, if any
+type Outbox interface {
+	Backlog(ctx context.Context) (int, *time.Time, error)
+	FindPause(ctx context.Context) (*domain.OutboxPause, error)
+}
+
+// OutboxMetrics reads how far delivery of transition events lags when scraped. It has
+// no series of its own, so a scrape that cannot read the outbox leaves it out entirely.
+type OutboxMetrics struct {
+	outbox Outbox
+}
+
+func NewOutboxMetrics(outbox Outbox) *OutboxMetrics {
+	return &OutboxMetrics{outbox: outbox}
+}
+
+// WriteTo writes the outbox gauges in the Prometheus text exposition format
+func (m *OutboxMetrics) WriteTo(w io.Writer) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), outboxTimeout)
+	defer cancel()
+
+	pending, oldest, err := m.outbox.Backlog(ctx)
+	if err != nil {
+		return 0, nil
+	}
+	pause, err := m.outbox.FindPause(ctx)
+	if err != nil {
+		return 0, nil
+	}
+
+	var age float64
+	if oldest != nil {
+		age = time.Since(*oldest).Seconds()
+	}
+	var paused int
+	if pause != nil {
+		paused = 1
+	}
+
+	var b strings.Builder
+	b.WriteString("# HELP gateway_outbox_pending Transition events waiting to be delivered.\n")
+	b.WriteString("# TYPE gateway_outbox_pending gauge\n")
+	fmt.Fprintf(&b, "gateway_outbox_pending %d\n", pending)
+	b.WriteString("# HELP gateway_outbox_oldest_age_seconds How long the longest waiting transition event has waited to be delivered.\n")
+	b.WriteString("# TYPE gateway_outbox_oldest_age_seconds gauge\n")
+	fmt.Fprintf(&b, "gateway_outbox_oldest_age_seconds %g\n", age)
+	b.WriteString("# HELP gateway_outbox_paused Whether delivery of transition events is paused.\n")
+	b.WriteString("# TYPE gateway_outbox_paused gauge\n")
+	fmt.Fprintf(&b, "gateway_outbox_paused %d\n", paused)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,67 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOutbox struct {
+	pending int
+	oldest  *time.Time
+	pause   *domain.OutboxPause
+	err     error
+}
+
+func (o fakeOutbox) Backlog(context.Context) (int, *time.Time, error) {
+	return o.pending, o.oldest, o.err
+}
+
+func (o fakeOutbox) FindPause(context.Context) (*domain.OutboxPause, error) {
+	return o.pause, o.err
+}
+
+func TestOutboxMetrics_WritesBacklogAndPause(t *testing.T) {
+	oldest := time.Now().Add(-90 * time.Second)
+	m := metrics.NewOutboxMetrics(fakeOutbox{
+		pending: 42,
+		oldest:  &oldest,
+		pause:   &domain.OutboxPause{PausedAt: oldest, Reason: "notification service down"},
+	})
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "gateway_outbox_pending 42\n")
+	assert.Contains(t, out.String(), "gateway_outbox_oldest_age_seconds 90")
+	assert.Contains(t, out.String(), "gateway_outbox_paused 1\n")
+}
+
+func TestOutboxMetrics_EmptyOutbox(t *testing.T) {
+	m := metrics.NewOutboxMetrics(fakeOutbox{})
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "gateway_outbox_pending 0\n")
+	assert.Contains(t, out.String(), "gateway_outbox_oldest_age_seconds 0\n")
+	assert.Contains(t, out.String(), "gateway_outbox_paused 0\n")
+}
+
+func TestOutboxMetrics_LeavesOutOutboxItCannotRead(t *testing.T) {
+	m := metrics.NewOutboxMetrics(fakeOutbox{err: errors.New("database down")})
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Empty(t, out.String())
+}
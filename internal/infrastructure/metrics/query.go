@@ -0,0 +1,43 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// QueryBuckets are the upper bounds, in seconds, of the query duration histogram. They
+// reach from an index lookup to a query scanning a large table.
+var QueryBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// QueryMetrics keeps the duration of database queries by the method that made them
+// and whether they failed
+type QueryMetrics struct {
+	queries *histogramVec
+}
+
+func NewQueryMetrics(buckets []float64) *QueryMetrics {
+	return &QueryMetrics{queries: newHistogramVec(buckets)}
+}
+
+// Observe records a query made by method that finished after d
+func (m *QueryMetrics) Observe(method string, failed bool, d time.Duration) {
+	result := "ok"
+	if failed {
+		result = "error"
+	}
+	m.queries.observe(fmt.Sprintf("method=%q,result=%q", method, result), d.Seconds())
+}
+
+// WriteTo writes every series in the Prometheus text exposition format, ordered by
+// method and result
+func (m *QueryMetrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.queries.writeTo(&b, "", "",
+		"gateway_db_query_duration_seconds", "Time taken by database queries, by the method that made them and result.",
+	)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
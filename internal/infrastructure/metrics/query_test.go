@@ -0,0 +1,30 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryMetrics_WritesHistogramPerMethod(t *testing.T) {
+	m := metrics.NewQueryMetrics([]float64{0.01, 0.1})
+	m.Observe("PaymentRepository.FindByID", false, 2*time.Millisecond)
+	m.Observe("PaymentRepository.FindByID", false, 50*time.Millisecond)
+	m.Observe("OutboxRepository.ClaimBatch", true, time.Second)
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	findByID := `method="PaymentRepository.FindByID",result="ok"`
+	claim := `method="OutboxRepository.ClaimBatch",result="error"`
+	assert.Contains(t, out.String(), "# TYPE gateway_db_query_duration_seconds histogram\n")
+	assert.Contains(t, out.String(), "gateway_db_query_duration_seconds_bucket{"+findByID+`,le="0.01"} 1`+"\n")
+	assert.Contains(t, out.String(), "gateway_db_query_duration_seconds_bucket{"+findByID+`,le="0.1"} 2`+"\n")
+	assert.Contains(t, out.String(), "gateway_db_query_duration_seconds_count{"+claim+"} 1\n")
+	assert.Less(t, strings.Index(out.String(), claim), strings.Index(out.String(), findByID))
+}
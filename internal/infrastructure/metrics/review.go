@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// ReviewBuckets are the upper bounds, in seconds, of the review wait histogram: from a
+// minute to three days
+var ReviewBuckets = []float64{60, 300, 900, 1800, 3600, 4 * 3600, 8 * 3600, 24 * 3600, 72 * 3600}
+
+// reviewQueueTimeout bounds the query a scrape makes for the queue gauges
+const reviewQueueTimeout = 2 * time.Second
+
+// ReviewQueue reports how many payments wait for review and when the longest waiting
+// of them was flagged, which is nil when none do
+type ReviewQueue interface {
+	QueueStats(ctx context.Context) (int, *time.Time, error)
+}
+
+// ReviewMetrics counts review decisions and how long the payments waited for them, by
+// decision, and reads the size and age of the queue when scraped
+type ReviewMetrics struct {
+	waits *histogramVec
+	queue ReviewQueue
+}
+
+func NewReviewMetrics(buckets []float64, queue ReviewQueue) *ReviewMetrics {
+	return &ReviewMetrics{
+		waits: newHistogramVec(buckets),
+		queue: queue,
+	}
+}
+
+// Observe records a decision on a payment that waited d for it
+func (m *ReviewMetrics) Observe(decision string, d time.Duration) {
+	m.waits.observe(fmt.Sprintf("decision=%q", strings.ToLower(decision)), d.Seconds())
+}
+
+// WriteTo writes every series in the Prometheus text exposition format. The queue
+// gauges are left out of a scrape that cannot read the queue.
+func (m *ReviewMetrics) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	m.waits.writeTo(&b,
+		"gateway_review_decisions_total", "Reviews of flagged payments, by decision.",
+		"gateway_review_wait_seconds", "Time flagged payments waited in the review queue, by decision.",
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), reviewQueueTimeout)
+	defer cancel()
+	if pending, oldest, err := m.queue.QueueStats(ctx); err == nil {
+		var age float64
+		if oldest != nil {
+			age = time.Since(*oldest).Seconds()
+		}
+		b.WriteString("# HELP gateway_review_queue_pending Flagged payments waiting for review.\n")
+		b.WriteString("# TYPE gateway_review_queue_pending gauge\n")
+		fmt.Fprintf(&b, "gateway_review_queue_pending %d\n", pending)
+		b.WriteString("# HELP gateway_review_queue_oldest_age_seconds How long the longest waiting payment has been in the review queue.\n")
+		b.WriteString("# TYPE gateway_review_queue_oldest_age_seconds gauge\n")
+		fmt.Fprintf(&b, "gateway_review_queue_oldest_age_seconds %g\n", age)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,51 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReviewQueue struct {
+	pending int
+	oldest  *time.Time
+	err     error
+}
+
+func (q fakeReviewQueue) QueueStats(context.Context) (int, *time.Time, error) {
+	return q.pending, q.oldest, q.err
+}
+
+func TestReviewMetrics_WritesDecisionsAndQueue(t *testing.T) {
+	oldest := time.Now().Add(-2 * time.Hour)
+	m := metrics.NewReviewMetrics([]float64{60, 3600}, fakeReviewQueue{pending: 3, oldest: &oldest})
+	m.Observe("APPROVED", 30*time.Second)
+	m.Observe("DECLINED", 2*time.Hour)
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), `gateway_review_decisions_total{decision="approved"} 1`+"\n")
+	assert.Contains(t, out.String(), `gateway_review_wait_seconds_bucket{decision="approved",le="60"} 1`+"\n")
+	assert.Contains(t, out.String(), `gateway_review_wait_seconds_bucket{decision="declined",le="3600"} 0`+"\n")
+	assert.Contains(t, out.String(), "gateway_review_queue_pending 3\n")
+	assert.Contains(t, out.String(), "gateway_review_queue_oldest_age_seconds 7200")
+}
+
+func TestReviewMetrics_LeavesOutQueueItCannotRead(t *testing.T) {
+	m := metrics.NewReviewMetrics([]float64{60}, fakeReviewQueue{err: errors.New("database down")})
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "# TYPE gateway_review_wait_seconds histogram\n")
+	assert.NotContains(t, out.String(), "gateway_review_queue_pending")
+}
@@ -0,0 +1,74 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyntheticMetrics counts the steps of synthetic transactions and their durations by
+// acquirer, step and result, and keeps when each acquirer last completed one
+type SyntheticMetrics struct {
+	steps *histogramVec
+
+	mu          sync.Mutex
+	lastSuccess map[string]time.Time
+}
+
+func NewSyntheticMetrics(buckets []float64) *SyntheticMetrics {
+	return &SyntheticMetrics{
+		steps:       newHistogramVec(buckets),
+		lastSuccess: make(map[string]time.Time),
+	}
+}
+
+// Observe records a step of a synthetic transaction against acquirer that finished
+// after d
+func (m *SyntheticMetrics) Observe(acquirer, step string, ok bool, d time.Duration) {
+	result := "ok"
+	if !ok {
+		result = "failed"
+	}
+	m.steps.observe(fmt.Sprintf("acquirer=%q,step=%q,result=%q", acquirer, step, result), d.Seconds())
+}
+
+// Succeeded records that a synthetic transaction against acquirer completed at at
+func (m *SyntheticMetrics) Succeeded(acquirer string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastSuccess[acquirer] = at
+}
+
+// WriteTo writes every series in the Prometheus text exposition format, ordered by
+// acquirer, step and result
+func (m *SyntheticMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	acquirers := make([]string, 0, len(m.lastSuccess))
+	lastSuccess := make(map[string]time.Time, len(m.lastSuccess))
+	for acquirer, at := range m.lastSuccess {
+		acquirers = append(acquirers, acquirer)
+		lastSuccess[acquirer] = at
+	}
+	m.mu.Unlock()
+	slices.Sort(acquirers)
+
+	var b strings.Builder
+	m.steps.writeTo(&b,
+		"gateway_synthetic_steps_total", "Steps of synthetic transactions, by acquirer, step and result.",
+		"gateway_synthetic_step_duration_seconds", "Time taken by the acquirer to answer each step, by acquirer, step and result.",
+	)
+
+	b.WriteString("# HELP gateway_synthetic_last_success_timestamp_seconds When a synthetic transaction last completed, by acquirer.\n")
+	b.WriteString("# TYPE gateway_synthetic_last_success_timestamp_seconds gauge\n")
+	for _, acquirer := range acquirers {
+		fmt.Fprintf(&b, "gateway_synthetic_last_success_timestamp_seconds{acquirer=%q} %s\n",
+			acquirer, strconv.FormatInt(lastSuccess[acquirer].Unix(), 10))
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,34 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticMetrics_ServedWithHTTPMetrics(t *testing.T) {
+	httpMetrics := metrics.NewHTTPMetrics([]float64{1})
+	httpMetrics.Observe("/authorize", "POST", 201, time.Millisecond)
+
+	synthetic := metrics.NewSyntheticMetrics([]float64{0.1, 1})
+	synthetic.Observe("primary", "authorize", true, 50*time.Millisecond)
+	synthetic.Observe("primary", "void", false, 2*time.Second)
+	synthetic.Succeeded("canary", time.Unix(1760000000, 0))
+
+	rec := httptest.NewRecorder()
+	metrics.Handler(httpMetrics, synthetic).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `gateway_http_requests_total{route="/authorize",method="POST",status_class="2xx"} 1`+"\n")
+	assert.Contains(t, body, `gateway_synthetic_steps_total{acquirer="primary",step="authorize",result="ok"} 1`+"\n")
+	assert.Contains(t, body, `gateway_synthetic_steps_total{acquirer="primary",step="void",result="failed"} 1`+"\n")
+	assert.Contains(t, body, `gateway_synthetic_step_duration_seconds_bucket{acquirer="primary",step="authorize",result="ok",le="0.1"} 1`+"\n")
+	assert.Contains(t, body, `gateway_synthetic_step_duration_seconds_bucket{acquirer="primary",step="void",result="failed",le="1"} 0`+"\n")
+	assert.Contains(t, body, `gateway_synthetic_last_success_timestamp_seconds{acquirer="canary"} 1760000000`+"\n")
+}
@@ -0,0 +1,83 @@
+// Package notification hands messages for customers, such as a completed refund, to
+// the notification service that emails or texts them.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// WebhookSender posts each notification as JSON to the notification service. The
+// notification's key goes in the Idempotency-Key header, since the outbox may deliver
+// one notification more than once.
+type WebhookSender struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookSender(url string, timeout time.Duration) *WebhookSender {
+	return &WebhookSender{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+type webhookBody struct {
+	Kind          domain.NotificationKind `json:"kind"`
+	Channels      []string                `json:"channels"`
+	MerchantID    string                  `json:"merchant_id"`
+	CustomerID    string                  `json:"customer_id"`
+	PaymentID     string                  `json:"payment_id"`
+	OrderID       string                  `json:"order_id"`
+	AmountCents   int64                   `json:"amount_cents"`
+	Currency      string                  `json:"currency"`
+	FailureReason *string                 `json:"failure_reason"`
+	// PaymentMethodID is only sent with a card update
+	PaymentMethodID string `json:"payment_method_id,omitempty"`
+	// RefundDestination is omitted for refunds made before refunds had destinations
+	RefundDestination domain.RefundDestinationType `json:"refund_destination,omitempty"`
+	OccurredAt        time.Time                    `json:"occurred_at"`
+}
+
+func (s *WebhookSender) Notify(ctx context.Context, n domain.CustomerNotification) error {
+	body, err := json.Marshal(webhookBody{
+		Kind:              n.Kind,
+		Channels:          n.Channels,
+		MerchantID:        n.MerchantID,
+		CustomerID:        n.CustomerID,
+		PaymentID:         n.PaymentID,
+		OrderID:           n.OrderID,
+		AmountCents:       n.AmountCents,
+		Currency:          n.Currency,
+		FailureReason:     n.FailureReason,
+		PaymentMethodID:   n.PaymentMethodID,
+		RefundDestination: n.RefundDestination,
+		OccurredAt:        n.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshalling notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("error creating notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", n.Key)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting notification: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck // only drained for connection reuse
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("notification service answered %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package notification_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/notification"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var refundCompleted = domain.CustomerNotification{
+	Key:         "evt-1",
+	Kind:        domain.NotificationRefundCompleted,
+	Channels:    []string{domain.ChannelEmail},
+	MerchantID:  "default",
+	CustomerID:  "cust-1",
+	PaymentID:   "pay-1",
+	OrderID:     "order-1",
+	AmountCents: 2500,
+	Currency:    "USD",
+	OccurredAt:  time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestWebhookSender_PostsNotification(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "evt-1", r.Header.Get("Idempotency-Key"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(server.Close)
+
+	err := notification.NewWebhookSender(server.URL, time.Second).Notify(context.Background(), refundCompleted)
+
+	require.NoError(t, err)
+	assert.Equal(t, "refund_completed", body["kind"])
+	assert.Equal(t, []any{"email"}, body["channels"])
+	assert.Equal(t, "cust-1", body["customer_id"])
+	assert.InDelta(t, 2500, body["amount_cents"], 0)
+	assert.Equal(t, "2026-03-01T12:00:00Z", body["occurred_at"])
+	assert.Nil(t, body["failure_reason"])
+}
+
+func TestWebhookSender_RejectedNotificationFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	err := notification.NewWebhookSender(server.URL, time.Second).Notify(context.Background(), refundCompleted)
+
+	assert.Error(t, err)
+}
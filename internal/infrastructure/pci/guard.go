@@ -0,0 +1,172 @@
+// Package pci keeps card verification values out of storage and logs. A CVV may only
+// travel from the request to the bank; anything written to the database or a log must
+// pass Guard, and values hashed or kept for later go through WithoutCVV first.
+package pci
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+)
+
+var ErrCVVPresent = errors.New("value carries a cvv and must not be persisted")
+
+// Guard returns ErrCVVPresent if v carries a CVV
+func Guard(v any) error {
+	if ContainsCVV(v) {
+		return ErrCVVPresent
+	}
+	return nil
+}
+
+// ContainsCVV reports whether a CVV is found anywhere in v: a field named CVV or
+// tagged json:"cvv", or a "cvv" key of a map or of a JSON document held in a string
+// or byte slice. A value masked with asterisks, as recorded bank requests are, is not
+// a CVV.
+func ContainsCVV(v any) bool {
+	if v == nil {
+		return false
+	}
+	return containsCVV(reflect.ValueOf(v), make(map[uintptr]bool), 0)
+}
+
+// maxDepth bounds how far ContainsCVV follows nested values, since log attributes can
+// be arbitrarily large structures. Request and payload types are far shallower.
+const maxDepth = 8
+
+func containsCVV(v reflect.Value, seen map[uintptr]bool, depth int) bool {
+	if depth > maxDepth {
+		return false
+	}
+	depth++
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() || seen[v.Pointer()] {
+			return false
+		}
+		seen[v.Pointer()] = true
+		return containsCVV(v.Elem(), seen, depth)
+	case reflect.Interface:
+		if v.IsNil() {
+			return false
+		}
+		return containsCVV(v.Elem(), seen, depth)
+	case reflect.Struct:
+		t := v.Type()
+		for i := range t.NumField() {
+			field := v.Field(i)
+			if isCVVField(t.Field(i)) && isCVVValue(field) {
+				return true
+			}
+			if containsCVV(field, seen, depth) {
+				return true
+			}
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			if key.Kind() == reflect.String && strings.EqualFold(key.String(), "cvv") && isCVVValue(iter.Value()) {
+				return true
+			}
+			if containsCVV(iter.Value(), seen, depth) {
+				return true
+			}
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return jsonContainsCVV(v.Bytes())
+		}
+		fallthrough
+	case reflect.Array:
+		for i := range v.Len() {
+			if containsCVV(v.Index(i), seen, depth) {
+				return true
+			}
+		}
+	case reflect.String:
+		return jsonContainsCVV([]byte(v.String()))
+	}
+	return false
+}
+
+func jsonContainsCVV(data []byte) bool {
+	trimmed := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return false
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return false
+	}
+	return containsCVV(reflect.ValueOf(doc), make(map[uintptr]bool), 0)
+}
+
+func isCVVField(f reflect.StructField) bool {
+	if strings.EqualFold(f.Name, "cvv") {
+		return true
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	return strings.EqualFold(name, "cvv")
+}
+
+func isCVVValue(v reflect.Value) bool {
+	for v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return strings.Trim(v.String(), "*") != ""
+	case reflect.Invalid:
+		return false
+	default:
+		return !v.IsZero()
+	}
+}
+
+// WithoutCVV returns a copy of v with the CVV fields of v, and of structs nested in
+// it by value, emptied. v itself is left untouched. Values of other kinds are
+// returned as they are.
+func WithoutCVV(v any) any {
+	if v == nil {
+		return nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer && !rv.IsNil() && rv.Elem().Kind() == reflect.Struct {
+		scrubbed := reflect.New(rv.Elem().Type())
+		scrubbed.Elem().Set(rv.Elem())
+		scrubCVV(scrubbed.Elem())
+		return scrubbed.Interface()
+	}
+	if rv.Kind() == reflect.Struct {
+		scrubbed := reflect.New(rv.Type()).Elem()
+		scrubbed.Set(rv)
+		scrubCVV(scrubbed)
+		return scrubbed.Interface()
+	}
+	return v
+}
+
+func scrubCVV(v reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := v.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+		if isCVVField(t.Field(i)) {
+			field.SetZero()
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			scrubCVV(field)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package pci_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
+	"github.com/stretchr/testify/assert"
+)
+
+type card struct {
+	Number string
+	CVV    string
+}
+
+type request struct {
+	Amount int64
+	Card   card
+}
+
+func TestGuard(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		carries bool
+	}{
+		{"struct field", card{Number: "4111111111111111", CVV: "123"}, true},
+		{"nested by pointer", &struct{ Card *card }{Card: &card{CVV: "123"}}, true},
+		{"nested by value", request{Card: card{CVV: "123"}}, true},
+		{"json tag", struct {
+			Code string `json:"cvv,omitempty"`
+		}{Code: "123"}, true},
+		{"map key", map[string]any{"CVV": "123"}, true},
+		{"json bytes", []byte(`{"card":{"cvv":"123"}}`), true},
+		{"json raw message", json.RawMessage(`[{"cvv":"123"}]`), true},
+		{"json string", `{"cvv":"123"}`, true},
+		{"empty cvv", card{Number: "4111111111111111"}, false},
+		{"masked cvv", []byte(`{"card_number":"************1111","cvv":"***"}`), false},
+		{"plain string", "cvv 123", false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pci.Guard(tt.value)
+			if tt.carries {
+				assert.ErrorIs(t, err, pci.ErrCVVPresent)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithoutCVV(t *testing.T) {
+	original := &request{Amount: 100, Card: card{Number: "4111111111111111", CVV: "123"}}
+
+	scrubbed := pci.WithoutCVV(original).(*request)
+
+	assert.Empty(t, scrubbed.Card.CVV)
+	assert.Equal(t, "4111111111111111", scrubbed.Card.Number)
+	assert.Equal(t, "123", original.Card.CVV, "the original is not modified")
+	assert.NoError(t, pci.Guard(scrubbed))
+}
@@ -0,0 +1,88 @@
+package pci
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// logHandler refuses to write log records that carry a CVV. Such a record is replaced
+// with an error naming only its message, so the leak is noticed without being logged.
+type logHandler struct {
+	next slog.Handler
+	// tainted is set once attributes carrying a CVV were bound to the logger; every
+	// record it writes would include them
+	tainted bool
+}
+
+// NewLogHandler wraps next so that no record carrying a CVV reaches it
+func NewLogHandler(next slog.Handler) slog.Handler {
+	return &logHandler{next: next}
+}
+
+func (h *logHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *logHandler) Handle(ctx context.Context, r slog.Record) error {
+	tainted := h.tainted
+	if !tainted {
+		r.Attrs(func(a slog.Attr) bool {
+			tainted = attrContainsCVV(a)
+			return !tainted
+		})
+	}
+	if !tainted {
+		return h.next.Handle(ctx, r)
+	}
+
+	dropped := slog.NewRecord(r.Time, slog.LevelError, "log record dropped: it carried a CVV", r.PC)
+	dropped.AddAttrs(slog.String("dropped_message", r.Message))
+	if err := h.next.Handle(ctx, dropped); err != nil {
+		return err
+	}
+	return ErrCVVPresent
+}
+
+func (h *logHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	// The offending attributes are kept from next, which still writes the replacement
+	// records
+	tainted := h.tainted
+	clean := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		if attrContainsCVV(a) {
+			tainted = true
+			continue
+		}
+		clean = append(clean, a)
+	}
+	return &logHandler{next: h.next.WithAttrs(clean), tainted: tainted}
+}
+
+func (h *logHandler) WithGroup(name string) slog.Handler {
+	return &logHandler{next: h.next.WithGroup(name), tainted: h.tainted}
+}
+
+func attrContainsCVV(a slog.Attr) bool {
+	value := a.Value.Resolve()
+
+	if strings.EqualFold(a.Key, "cvv") {
+		if s := value.String(); strings.Trim(s, "*") != "" {
+			return true
+		}
+	}
+
+	switch value.Kind() {
+	case slog.KindGroup:
+		for _, inner := range value.Group() {
+			if attrContainsCVV(inner) {
+				return true
+			}
+		}
+	case slog.KindString:
+		return ContainsCVV(value.String())
+	case slog.KindAny:
+		return ContainsCVV(value.Any())
+	}
+	return false
+}
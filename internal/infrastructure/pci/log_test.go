@@ -0,0 +1,33 @@
+package pci_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(pci.NewLogHandler(slog.NewJSONHandler(&buf, nil)))
+
+	logger.Info("authorizing", "order_id", "order-123")
+	assert.Contains(t, buf.String(), `"order_id":"order-123"`)
+
+	buf.Reset()
+	logger.Info("authorizing", "card", card{Number: "4111111111111111", CVV: "987"})
+	assert.NotContains(t, buf.String(), "987")
+	assert.NotContains(t, buf.String(), "4111111111111111")
+	assert.Contains(t, buf.String(), `"dropped_message":"authorizing"`)
+
+	buf.Reset()
+	logger.With("cvv", "987").Info("charging")
+	assert.NotContains(t, buf.String(), "987")
+	assert.Contains(t, buf.String(), `"dropped_message":"charging"`)
+
+	buf.Reset()
+	logger.Info("recorded", "cvv", "***")
+	assert.Contains(t, buf.String(), `"msg":"recorded"`)
+}
@@ -0,0 +1,151 @@
+// Package pdf writes plain text documents, such as payment receipts, as PDF. It only
+// knows Helvetica on A4 pages, which every PDF reader has built in, so no fonts are
+// embedded and no library is needed.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	pageWidth    = 595
+	pageHeight   = 842
+	margin       = 56
+	headingSize  = 16
+	textSize     = 11
+	lineSpacing  = 1.5
+	objectsFixed = 3 // catalog, page tree and font
+)
+
+type line struct {
+	text string
+	size float64
+}
+
+// Document is text set line by line from the top of the page, running onto a new
+// page when one is full
+type Document struct {
+	lines []line
+}
+
+func New() *Document {
+	return &Document{}
+}
+
+// Heading adds a line in large type
+func (d *Document) Heading(text string) *Document {
+	d.lines = append(d.lines, line{text: text, size: headingSize})
+	return d
+}
+
+// Line adds a line of body text
+func (d *Document) Line(text string) *Document {
+	d.lines = append(d.lines, line{text: text, size: textSize})
+	return d
+}
+
+// Gap leaves an empty line
+func (d *Document) Gap() *Document {
+	return d.Line("")
+}
+
+// Bytes renders the document
+func (d *Document) Bytes() []byte {
+	pages := d.paginate()
+
+	var buf bytes.Buffer
+	offsets := make([]int, 0, objectsFixed+2*len(pages))
+	object := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", objectsFixed+1+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, objectsFixed+2+2*i))
+		content := render(page)
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return buf.Bytes()
+}
+
+// paginate splits the lines into pages; a document without lines is one blank page
+func (d *Document) paginate() [][]line {
+	pages := [][]line{nil}
+	y := float64(pageHeight - margin)
+	for _, l := range d.lines {
+		y -= l.size * lineSpacing
+		if y < margin && len(pages[len(pages)-1]) > 0 {
+			pages = append(pages, nil)
+			y = pageHeight - margin - l.size*lineSpacing
+		}
+		pages[len(pages)-1] = append(pages[len(pages)-1], l)
+	}
+	return pages
+}
+
+func render(lines []line) string {
+	var b strings.Builder
+	y := float64(pageHeight - margin)
+	for _, l := range lines {
+		y -= l.size * lineSpacing
+		if l.text == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "BT /F1 %g Tf %d %g Td (%s) Tj ET\n", l.size, margin, y, escape(l.text))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// winAnsi holds the characters WinAnsiEncoding puts at 0x80-0x9f in place of Latin-1's
+// control codes, such as the euro sign and typographic quotes and dashes
+var winAnsi = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8a, '‹': 0x8b, 'Œ': 0x8c, 'Ž': 0x8e, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9a, '›': 0x9b,
+	'œ': 0x9c, 'ž': 0x9e, 'Ÿ': 0x9f,
+}
+
+// escape writes text as the body of a PDF string in WinAnsiEncoding. Text is composed
+// first, so an accent typed as a separate mark still lands on its letter. Characters
+// the encoding has no code for, which Helvetica cannot show, become '?'.
+func escape(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFC.String(text) {
+		switch code, ok := winAnsi[r]; {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case ok:
+			fmt.Fprintf(&b, "\\%03o", code)
+		case r < 0x20 || (r >= 0x7f && r < 0xa0) || r > 0xff:
+			b.WriteByte('?')
+		case r < 0x80:
+			b.WriteRune(r)
+		default:
+			fmt.Fprintf(&b, "\\%03o", r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package pdf_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var startxref = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+
+// xrefOffsets returns where the cross-reference table says each object starts
+func xrefOffsets(t *testing.T, doc []byte) []int {
+	t.Helper()
+	match := startxref.FindSubmatch(doc)
+	require.NotNil(t, match, "document must end with startxref and %%EOF")
+	xref, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(doc[xref:], []byte("xref\n")))
+
+	var offsets []int
+	for _, entry := range strings.Split(string(doc[xref:]), "\n")[3:] {
+		if !strings.HasSuffix(entry, " n ") {
+			break
+		}
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := pdf.New().Heading("Receipt").Gap().Line("Total: 50.00 USD").Bytes()
+
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4\n")))
+	assert.Contains(t, string(doc), "(Receipt) Tj")
+	assert.Contains(t, string(doc), "(Total: 50.00 USD) Tj")
+
+	offsets := xrefOffsets(t, doc)
+	require.Len(t, offsets, 5)
+	for i, offset := range offsets {
+		assert.True(t, bytes.HasPrefix(doc[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+}
+
+func TestDocument_EscapesText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"balanced parentheses", "Order (1)", `(Order \(1\))`},
+		{"unbalanced parentheses", "a) b) (c", `(a\) b\) \(c)`},
+		{"parentheses only", "()", `(\(\))`},
+		{"backslash", `ref\123`, `(ref\\123)`},
+		{"trailing backslash", `C:\`, `(C:\\)`},
+		{"backslash before a parenthesis", `\)`, `(\\\))`},
+		{"escape sequence as text", `\n\t\(`, `(\\n\\t\\\()`},
+		{"Latin-1", "café ÿ", `(caf\351 \377)`},
+		{"decomposed accent", "cafe\u0301", `(caf\351)`},
+		{"WinAnsi punctuation", "€5 – “ok”", `(\2005 \226 \223ok\224)`},
+		{"CJK", "注文 42", `(?? 42)`},
+		{"emoji", "card 💳", `(card ?)`},
+		{"Cyrillic", "Заказ", `(?????)`},
+		{"control characters", "a\tb\rc\x7fd\u0085e", `(a?b?c?d?e)`},
+		{"unused WinAnsi code", "\u0081", `(?)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := pdf.New().Line(tt.text).Bytes()
+
+			// The whole line must be one string: an unescaped parenthesis or backslash
+			// would end it early or swallow the closing one
+			assert.Contains(t, string(doc), " Td "+tt.want+" Tj ET")
+		})
+	}
+}
+
+func TestDocument_RunsOntoNewPages(t *testing.T) {
+	d := pdf.New()
+	for range 100 {
+		d.Line("entry")
+	}
+	doc := d.Bytes()
+
+	assert.Contains(t, string(doc), "/Count 3")
+	assert.Len(t, xrefOffsets(t, doc), 3+2*3)
+}
@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type AlertRepository struct {
+	db *DB
+}
+
+func NewAlertRepository(db *DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+// Claim records that the alert with key is being sent. It reports false if it was
+// claimed before, by this instance or another.
+func (r *AlertRepository) Claim(ctx context.Context, key string) (bool, error) {
+	query := `INSERT INTO sent_alerts (key) VALUES ($1) ON CONFLICT (key) DO NOTHING RETURNING key`
+
+	var claimed string
+	if err := r.db.QueryRow(ctx, query, key).Scan(&claimed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim alert: %w", err)
+	}
+	return true, nil
+}
+
+// Release forgets a claimed alert that could not be sent, so it is sent next time
+func (r *AlertRepository) Release(ctx context.Context, key string) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM sent_alerts WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("failed to release alert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+type AuditRepository struct {
+	db *DB
+}
+
+func NewAuditRepository(db *DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Create stores an entry for the merchant in ctx
+func (r *AuditRepository) Create(ctx context.Context, entry *domain.AuditEntry) error {
+	entry.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO audit_log (id, merchant_id, api_key_id, role, action, target, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	if _, err := r.db.Exec(ctx, query,
+		entry.ID,
+		entry.MerchantID,
+		entry.APIKeyID,
+		entry.Role,
+		entry.Action,
+		entry.Target,
+		entry.Status,
+		entry.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create audit entry: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
+	"github.com/jackc/pgx/v5"
+)
+
+type BankAttemptRepository struct {
+	db *DB
+}
+
+func NewBankAttemptRepository(db *DB) *BankAttemptRepository {
+	return &BankAttemptRepository{db: db}
+}
+
+// Create stores an attempt. The payment is resolved from the merchant's idempotency
+// key, which is always written before the bank is called.
+func (r *BankAttemptRepository) Create(ctx context.Context, attempt *domain.BankAttempt) error {
+	if err := pci.Guard(attempt); err != nil {
+		return fmt.Errorf("refusing to store bank attempt: %w", err)
+	}
+
+	query := `
+		INSERT INTO bank_attempts (
+			id, payment_id, acquirer, operation, idempotency_key,
+			status_code, error_code, latency_ms,
+			request_payload, response_payload, attempted_at
+		) VALUES (
+			$1, (SELECT payment_id FROM idempotency_keys WHERE merchant_id = $11 AND key = $4), $2, $3, NULLIF($4, ''),
+			$5, NULLIF($6, ''), $7,
+			$8, $9, $10
+		)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		attempt.ID,
+		attempt.Acquirer,
+		attempt.Operation,
+		attempt.IdempotencyKey,
+		attempt.StatusCode,
+		attempt.ErrorCode,
+		attempt.Latency.Milliseconds(),
+		attempt.RequestPayload,
+		attempt.ResponsePayload,
+		attempt.AttemptedAt,
+		MerchantFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create bank attempt: %w", err)
+	}
+
+	return nil
+}
+
+// FindByPaymentID retrieves every bank attempt made for a payment, oldest first
+func (r *BankAttemptRepository) FindByPaymentID(ctx context.Context, paymentID string) ([]*domain.BankAttempt, error) {
+	query := `
+		SELECT id, acquirer, operation, COALESCE(idempotency_key, ''),
+		       status_code, COALESCE(error_code, ''), latency_ms,
+		       request_payload, response_payload, attempted_at
+		FROM bank_attempts
+		WHERE payment_id = (SELECT id FROM payments WHERE id = $1 AND merchant_id = $2)
+		ORDER BY attempted_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, paymentID, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("query bank attempts by payment_id: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.BankAttempt, error) {
+		var a domain.BankAttempt
+		var latencyMs int64
+		err := row.Scan(
+			&a.ID, &a.Acquirer, &a.Operation, &a.IdempotencyKey,
+			&a.StatusCode, &a.ErrorCode, &latencyMs,
+			&a.RequestPayload, &a.ResponsePayload, &a.AttemptedAt,
+		)
+		a.Latency = time.Duration(latencyMs) * time.Millisecond
+		return &a, err
+	})
+}
+
+// GrantedAuthorization is an authorization the bank granted for a payment, as the
+// attempts ledger recorded it
+type GrantedAuthorization struct {
+	BankAuthID    string
+	Acquirer      string
+	PaymentID     string
+	PaymentStatus domain.PaymentStatus
+	GrantedAt     time.Time
+}
+
+// FindUnclaimedAuthorizations retrieves up to limit authorizations the bank granted in
+// [from, to) for payments of the merchant in ctx that do not hold them, oldest first.
+// Only payments in one of statuses are considered, so one still waiting to save its
+// authorization is not mistaken for having lost it.
+func (r *BankAttemptRepository) FindUnclaimedAuthorizations(
+	ctx context.Context,
+	from, to time.Time,
+	statuses []domain.PaymentStatus,
+	limit int,
+) ([]GrantedAuthorization, error) {
+	query := `
+		SELECT bank_auth_id, acquirer, payment_id, payment_status, granted_at
+		FROM (
+			SELECT DISTINCT ON (a.response_payload->>'authorization_id')
+			       a.response_payload->>'authorization_id' AS bank_auth_id, a.acquirer,
+			       p.id AS payment_id, p.status AS payment_status, a.attempted_at AS granted_at
+			FROM bank_attempts a
+			JOIN payments p ON p.id = a.payment_id
+			WHERE a.operation = 'AUTHORIZE' AND a.status_code = 200
+			  AND a.attempted_at >= $2 AND a.attempted_at < $3
+			  AND p.merchant_id = $1 AND p.status = ANY($4)
+			  AND a.response_payload->>'authorization_id' <> ''
+			  AND p.bank_auth_id IS DISTINCT FROM a.response_payload->>'authorization_id'
+			ORDER BY a.response_payload->>'authorization_id', a.attempted_at ASC
+		) unclaimed
+		ORDER BY granted_at ASC
+		LIMIT $5
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), from, to, statusStrings(statuses), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query unclaimed authorizations: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[GrantedAuthorization])
+}
@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrBatchNotFound = errors.New("batch not found")
+
+type BatchRepository struct {
+	db *DB
+}
+
+func NewBatchRepository(db *DB) *BatchRepository {
+	return &BatchRepository{db: db}
+}
+
+// Create stores a batch of the merchant in ctx and its items under the idempotency key
+// of the request that created it
+func (r *BatchRepository) Create(ctx context.Context, tx pgx.Tx, batch *domain.Batch, idempotencyKey, requestHash string) error {
+	query := `
+		INSERT INTO payment_batches (id, merchant_id, type, reason, status, idempotency_key, request_hash, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		batch.ID,
+		MerchantFromContext(ctx),
+		batch.Type,
+		batch.Reason,
+		batch.Status,
+		idempotencyKey,
+		requestHash,
+		batch.CreatedAt,
+	)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return ErrDuplicateIdempotencyKey
+		}
+		return fmt.Errorf("failed to create batch: %w", err)
+	}
+
+	itemQuery := `
+		INSERT INTO payment_batch_items (id, batch_id, position, payment_id, amount_cents, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for position, item := range batch.Items {
+		if _, err := tx.Exec(ctx, itemQuery,
+			item.ID,
+			batch.ID,
+			position,
+			item.PaymentID,
+			item.AmountCents,
+			item.Status,
+		); err != nil {
+			return fmt.Errorf("failed to create batch item: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// FindByID returns a batch with its items in the order they were submitted
+func (r *BatchRepository) FindByID(ctx context.Context, id string) (*domain.Batch, error) {
+	query := `
+		SELECT id, type, reason, status, created_at, completed_at
+		FROM payment_batches WHERE id = $1 AND merchant_id = $2
+	`
+
+	var b domain.Batch
+	err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(&b.ID, &b.Type, &b.Reason, &b.Status, &b.CreatedAt, &b.CompletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBatchNotFound
+		}
+		return nil, fmt.Errorf("failed to scan batch: %w", err)
+	}
+
+	itemQuery := `
+		SELECT id, batch_id, payment_id, amount_cents, status, operation_id, error_code, completed_at
+		FROM payment_batch_items
+		WHERE batch_id = $1
+		ORDER BY position ASC
+	`
+
+	rows, err := r.db.Query(ctx, itemQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("query batch items: %w", err)
+	}
+
+	b.Items, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.BatchItem, error) {
+		return scanBatchItem(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+// FindIDByIdempotencyKey returns the batch created under the key with its request
+// hash, or ErrBatchNotFound if the key is unused
+func (r *BatchRepository) FindIDByIdempotencyKey(ctx context.Context, key string) (string, string, error) {
+	query := `SELECT id, request_hash FROM payment_batches WHERE merchant_id = $1 AND idempotency_key = $2`
+
+	var id, requestHash string
+	if err := r.db.QueryRow(ctx, query, MerchantFromContext(ctx), key).Scan(&id, &requestHash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", ErrBatchNotFound
+		}
+		return "", "", fmt.Errorf("failed to scan batch: %w", err)
+	}
+
+	return id, requestHash, nil
+}
+
+// PendingBatchItem is an item still to be processed, with what its batch applies and
+// the merchant it belongs to
+type PendingBatchItem struct {
+	Item       *domain.BatchItem
+	Type       domain.BatchType
+	Reason     domain.OperationReason
+	MerchantID string
+}
+
+// FindPendingItems returns up to limit PENDING items of all merchants, oldest batch first
+func (r *BatchRepository) FindPendingItems(ctx context.Context, limit int) ([]PendingBatchItem, error) {
+	query := `
+		SELECT b.type, b.reason, b.merchant_id,
+		       i.id, i.batch_id, i.payment_id, i.amount_cents, i.status, i.operation_id, i.error_code, i.completed_at
+		FROM payment_batch_items i
+		JOIN payment_batches b ON b.id = i.batch_id
+		WHERE i.status = 'PENDING'
+		ORDER BY b.created_at ASC, i.position ASC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query pending batch items: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (PendingBatchItem, error) {
+		var p PendingBatchItem
+		var item domain.BatchItem
+		err := row.Scan(
+			&p.Type, &p.Reason, &p.MerchantID,
+			&item.ID, &item.BatchID, &item.PaymentID, &item.AmountCents, &item.Status,
+			&item.OperationID, &item.ErrorCode, &item.CompletedAt,
+		)
+		p.Item = &item
+		return p, err
+	})
+}
+
+// UpdateItem stores an item's outcome. Only a PENDING item is updated, so two workers
+// settling the same item record it once.
+func (r *BatchRepository) UpdateItem(ctx context.Context, item *domain.BatchItem) error {
+	query := `
+		UPDATE payment_batch_items i
+		SET status = $1, operation_id = $2, error_code = $3, completed_at = $4
+		FROM payment_batches b
+		WHERE i.id = $5 AND i.status = 'PENDING'
+		  AND b.id = i.batch_id AND b.merchant_id = $6
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		item.Status,
+		item.OperationID,
+		item.ErrorCode,
+		item.CompletedAt,
+		item.ID,
+		MerchantFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update batch item: %w", err)
+	}
+	return nil
+}
+
+// CompleteFinished marks every PROCESSING batch of any merchant without pending items
+// COMPLETED and returns how many were
+func (r *BatchRepository) CompleteFinished(ctx context.Context) (int64, error) {
+	query := `
+		UPDATE payment_batches b
+		SET status = 'COMPLETED', completed_at = NOW()
+		WHERE b.status = 'PROCESSING'
+		  AND NOT EXISTS (
+		      SELECT 1 FROM payment_batch_items i
+		      WHERE i.batch_id = b.id AND i.status = 'PENDING'
+		  )
+	`
+
+	tag, err := r.db.Exec(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to complete batches: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func scanBatchItem(row pgx.Row) (*domain.BatchItem, error) {
+	var item domain.BatchItem
+	err := row.Scan(
+		&item.ID, &item.BatchID, &item.PaymentID, &item.AmountCents, &item.Status,
+		&item.OperationID, &item.ErrorCode, &item.CompletedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan batch item: %w", err)
+	}
+	return &item, nil
+}
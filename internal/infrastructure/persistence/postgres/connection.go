@@ -3,9 +3,13 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/config"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -17,13 +21,23 @@ type DB struct {
 
 // Connect establishes a connection to the PostgreSQL database using the provided configuration.
 // It creates a connection pool with the specified settings and verifies connectivity by  the database.
-func Connect(ctx context.Context, cfg *config.DatabaseConfig, logger *slog.Logger) (*DB, error) {
+// Queries are timed into queries when it is not nil.
+func Connect(ctx context.Context, cfg *config.DatabaseConfig, queries QueryObserver, logger *slog.Logger) (*DB, error) {
 	pgxCfg, err := cfg.PgxConfig(ctx)
 	if err != nil {
 		logger.Error("failed to build pgx config", "error", err)
 		return nil, err
 	}
 
+	scopeConnections(pgxCfg)
+	if queries != nil || cfg.SlowQueryThreshold > 0 {
+		pgxCfg.ConnConfig.Tracer = &queryTracer{
+			observer:      queries,
+			slowThreshold: cfg.SlowQueryThreshold,
+			logger:        logger,
+		}
+	}
+
 	logger.Info("connecting to database",
 		"host", cfg.Host,
 		"port", cfg.Port,
@@ -42,6 +56,18 @@ func Connect(ctx context.Context, cfg *config.DatabaseConfig, logger *slog.Logge
 		return nil, err
 	}
 
+	var bypassesRLS bool
+	if err := pool.QueryRow(ctx, `SELECT rolsuper OR rolbypassrls FROM pg_roles WHERE rolname = current_user`).Scan(&bypassesRLS); err != nil {
+		logger.Error("failed to read database role", "error", err)
+		pool.Close()
+		return nil, err
+	}
+	if bypassesRLS {
+		logger.Warn("database role bypasses row-level security; merchant isolation relies on repository queries alone",
+			"user", cfg.User,
+		)
+	}
+
 	logger.Info("successfully connected to database",
 		"max_conns", pgxCfg.MaxConns,
 		"min_conns", pgxCfg.MinConns,
@@ -53,6 +79,139 @@ func Connect(ctx context.Context, cfg *config.DatabaseConfig, logger *slog.Logge
 	}, nil
 }
 
+// scopeConnections sets the merchant of the acquiring context on every connection
+// taken from the pool, for the row-level security policies on payments and
+// idempotency_keys to check. A connection keeps the settings between uses, so they
+// are only sent when the merchant changes.
+func scopeConnections(pgxCfg *pgxpool.Config) {
+	var mu sync.Mutex
+	scopes := make(map[*pgx.Conn]merchantScope)
+
+	pgxCfg.PrepareConn = func(ctx context.Context, conn *pgx.Conn) (bool, error) {
+		scope := scopeFromContext(ctx)
+
+		mu.Lock()
+		current, ok := scopes[conn]
+		mu.Unlock()
+		if ok && current == scope {
+			return true, nil
+		}
+
+		allMerchants := "off"
+		if scope.all {
+			allMerchants = "on"
+		}
+		query := `SELECT set_config('app.merchant_id', $1, false), set_config('app.all_merchants', $2, false)`
+		if _, err := conn.Exec(ctx, query, scope.id, allMerchants); err != nil {
+			return false, fmt.Errorf("set connection merchant: %w", err)
+		}
+
+		mu.Lock()
+		scopes[conn] = scope
+		mu.Unlock()
+		return true, nil
+	}
+
+	pgxCfg.BeforeClose = func(conn *pgx.Conn) {
+		mu.Lock()
+		delete(scopes, conn)
+		mu.Unlock()
+	}
+}
+
+// BeginTx starts a transaction that runs the functions AfterCommit registers on it once
+// it commits
+func (db *DB) BeginTx(ctx context.Context, opts pgx.TxOptions) (pgx.Tx, error) {
+	tx, err := db.Pool.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &hookedTx{Tx: tx}, nil
+}
+
+// Begin is BeginTx with the default options
+func (db *DB) Begin(ctx context.Context) (pgx.Tx, error) {
+	return db.BeginTx(ctx, pgx.TxOptions{})
+}
+
+type hookedTx struct {
+	pgx.Tx
+	afterCommit []func()
+}
+
+func (t *hookedTx) Commit(ctx context.Context) error {
+	if err := t.Tx.Commit(ctx); err != nil {
+		return err
+	}
+	for _, fn := range t.afterCommit {
+		fn()
+	}
+	t.afterCommit = nil
+	return nil
+}
+
+// AfterCommit runs fn once tx commits, and never if it rolls back. Without a
+// transaction begun by DB.BeginTx, as when tx is nil, the write is already visible and
+// fn runs right away.
+func AfterCommit(tx pgx.Tx, fn func()) {
+	if hooked, ok := tx.(*hookedTx); ok {
+		hooked.afterCommit = append(hooked.afterCommit, fn)
+		return
+	}
+	fn()
+}
+
+// txAttempts is how many times RunInTx runs a transaction that keeps losing to
+// concurrent ones, and txBackoff the wait before its first rerun, doubled after each
+const (
+	txAttempts = 3
+	txBackoff  = 10 * time.Millisecond
+)
+
+// RunInTx runs fn in a transaction with opts and commits it, rolling it back if fn
+// fails. A transaction Postgres aborts with a serialization failure or a deadlock is
+// run again from the start, so fn must not depend on what an earlier run did; after
+// txAttempts runs the last error is returned. fn's errors are returned unchanged.
+// Serialization failures are only raised at repeatable read and above; at read
+// committed this retries deadlocks.
+func (db *DB) RunInTx(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	backoff := txBackoff
+	for attempt := 1; ; attempt++ {
+		err := db.runTxOnce(ctx, opts, fn)
+		if err == nil || !IsSerializationFailure(err) || attempt == txAttempts {
+			return err
+		}
+
+		db.logger.Warn("transaction aborted by a concurrent one, running it again",
+			"attempt", attempt,
+			"error", err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (db *DB) runTxOnce(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	tx, err := db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
 func (db *DB) Close() {
 	db.logger.Info("closing database connection pool")
 	db.Pool.Close()
@@ -63,3 +222,30 @@ func IsUniqueViolation(err error) bool {
 	var pgErr *pgconn.PgError
 	return errors.As(err, &pgErr) && pgErr.Code == "23505"
 }
+
+// isUniqueViolationOf checks if err is a PostgreSQL unique violation of the named
+// constraint or index
+func isUniqueViolationOf(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == constraint
+}
+
+// isCheckViolationOf checks if err is a PostgreSQL check violation of the named
+// constraint, or raised under its name by a trigger
+func isCheckViolationOf(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23514" && pgErr.ConstraintName == constraint
+}
+
+// IsForeignKeyViolation checks if the given error is a PostgreSQL foreign key constraint violation.
+func IsForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503"
+}
+
+// IsSerializationFailure checks if the given error is a PostgreSQL serialization
+// failure or deadlock, after which the aborted transaction can simply be run again.
+func IsSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "40001" || pgErr.Code == "40P01")
+}
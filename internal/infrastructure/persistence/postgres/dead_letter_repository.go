@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+const deadLetterColumns = `payment_id, merchant_id, status, idempotency_key, attempts, last_error, dead_lettered_at`
+
+// DeadLetterRepository stores the payments the retry worker gave up on
+type DeadLetterRepository struct {
+	db *DB
+}
+
+func NewDeadLetterRepository(db *DB) *DeadLetterRepository {
+	return &DeadLetterRepository{db: db}
+}
+
+// Create dead-letters a payment of the merchant in ctx. A payment already dead-lettered
+// keeps its first entry.
+func (r *DeadLetterRepository) Create(ctx context.Context, deadLetter *domain.DeadLetter) error {
+	deadLetter.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO retry_dead_letters (` + deadLetterColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (payment_id) DO NOTHING
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		deadLetter.PaymentID, deadLetter.MerchantID, deadLetter.Status, deadLetter.IdempotencyKey,
+		deadLetter.Attempts, deadLetter.LastError, deadLetter.DeadLetteredAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+	return nil
+}
+
+// Delete takes a payment of the merchant in ctx off the dead letters
+func (r *DeadLetterRepository) Delete(ctx context.Context, tx pgx.Tx, paymentID string) (*domain.DeadLetter, error) {
+	query := `
+		DELETE FROM retry_dead_letters
+		WHERE payment_id = $1 AND merchant_id = $2
+		RETURNING ` + deadLetterColumns
+
+	deadLetter, err := scanDeadLetter(tx.QueryRow(ctx, query, paymentID, MerchantFromContext(ctx)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete dead letter: %w", err)
+	}
+	return deadLetter, nil
+}
+
+// FindAll retrieves up to limit dead letters of the merchant in ctx, the oldest first
+func (r *DeadLetterRepository) FindAll(ctx context.Context, limit int) ([]*domain.DeadLetter, error) {
+	query := `
+		SELECT ` + deadLetterColumns + `
+		FROM retry_dead_letters
+		WHERE merchant_id = $1
+		ORDER BY dead_lettered_at ASC, payment_id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query dead letters: %w", err)
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.DeadLetter, error) {
+		return scanDeadLetter(row)
+	})
+}
+
+func scanDeadLetter(row pgx.Row) (*domain.DeadLetter, error) {
+	var deadLetter domain.DeadLetter
+	err := row.Scan(
+		&deadLetter.PaymentID, &deadLetter.MerchantID, &deadLetter.Status, &deadLetter.IdempotencyKey,
+		&deadLetter.Attempts, &deadLetter.LastError, &deadLetter.DeadLetteredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetter, nil
+}
@@ -0,0 +1,180 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrDebugSessionNotFound = errors.New("debug session not found")
+
+type DebugSessionRepository struct {
+	db *DB
+}
+
+func NewDebugSessionRepository(db *DB) *DebugSessionRepository {
+	return &DebugSessionRepository{db: db}
+}
+
+// Create stores a session for the merchant in ctx. Expired sessions, and their captures with them, are
+// purged first so captured traffic never outlives its session for long.
+func (r *DebugSessionRepository) Create(ctx context.Context, session *domain.DebugSession) error {
+	if err := r.DeleteExpired(ctx); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO debug_sessions (id, merchant_id, payment_id, idempotency_key, expires_at, created_at)
+		SELECT $1, $2, NULLIF($3, '')::uuid, NULLIF($4, ''), $5, $6
+		WHERE $3 = '' OR EXISTS (SELECT 1 FROM payments WHERE id = NULLIF($3, '')::uuid AND merchant_id = $2)
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		session.ID,
+		MerchantFromContext(ctx),
+		session.PaymentID,
+		session.IdempotencyKey,
+		session.ExpiresAt,
+		session.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create debug session: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// FindByID retrieves a session that has not expired yet
+func (r *DebugSessionRepository) FindByID(ctx context.Context, id string) (*domain.DebugSession, error) {
+	query := `
+		SELECT id, COALESCE(payment_id::text, ''), COALESCE(idempotency_key, ''), expires_at, created_at
+		FROM debug_sessions WHERE id = $1 AND merchant_id = $2 AND expires_at > NOW()
+	`
+
+	var s domain.DebugSession
+	err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(&s.ID, &s.PaymentID, &s.IdempotencyKey, &s.ExpiresAt, &s.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrDebugSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to scan debug session: %w", err)
+	}
+
+	return &s, nil
+}
+
+// FindActiveSessionID returns the session covering an idempotency key, either directly
+// or through the payment the key belongs to. It returns an empty ID when there is none.
+func (r *DebugSessionRepository) FindActiveSessionID(ctx context.Context, idempotencyKey string) (string, error) {
+	query := `
+		SELECT id FROM debug_sessions
+		WHERE expires_at > NOW()
+		  AND merchant_id = $2
+		  AND (idempotency_key = $1
+		       OR payment_id = (SELECT payment_id FROM idempotency_keys WHERE merchant_id = $2 AND key = $1))
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	var id string
+	err := r.db.QueryRow(ctx, query, idempotencyKey, MerchantFromContext(ctx)).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to find active debug session: %w", err)
+	}
+
+	return id, nil
+}
+
+// Delete ends a session and removes everything it captured
+func (r *DebugSessionRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM debug_sessions WHERE id = $1 AND merchant_id = $2`
+
+	results, err := r.db.Exec(ctx, query, id, MerchantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to delete debug session: %w", err)
+	}
+
+	if results.RowsAffected() == 0 {
+		return ErrDebugSessionNotFound
+	}
+
+	return nil
+}
+
+// DeleteExpired purges the expired sessions of all merchants
+func (r *DebugSessionRepository) DeleteExpired(ctx context.Context) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM debug_sessions WHERE expires_at <= NOW()`); err != nil {
+		return fmt.Errorf("failed to delete expired debug sessions: %w", err)
+	}
+	return nil
+}
+
+func (r *DebugSessionRepository) CreateCapture(ctx context.Context, capture *domain.DebugCapture) error {
+	if err := pci.Guard(capture); err != nil {
+		return fmt.Errorf("refusing to store debug capture: %w", err)
+	}
+
+	query := `
+		INSERT INTO bank_debug_captures (
+			id, session_id, idempotency_key, method, url,
+			request_body, response_status, response_body, captured_at
+		)
+		SELECT $1, s.id, $3, $4, $5, $6, $7, $8, $9
+		FROM debug_sessions s
+		WHERE s.id = $2 AND s.merchant_id = $10
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		capture.ID,
+		capture.SessionID,
+		capture.IdempotencyKey,
+		capture.Method,
+		capture.URL,
+		capture.RequestBody,
+		capture.ResponseStatus,
+		capture.ResponseBody,
+		capture.CapturedAt,
+		MerchantFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create debug capture: %w", err)
+	}
+
+	return nil
+}
+
+// FindCapturesBySessionID retrieves a session's captures, oldest first
+func (r *DebugSessionRepository) FindCapturesBySessionID(ctx context.Context, sessionID string) ([]*domain.DebugCapture, error) {
+	query := `
+		SELECT c.id, c.session_id, c.idempotency_key, c.method, c.url,
+		       COALESCE(c.request_body, ''), c.response_status, COALESCE(c.response_body, ''), c.captured_at
+		FROM bank_debug_captures c
+		JOIN debug_sessions s ON s.id = c.session_id
+		WHERE c.session_id = $1 AND s.merchant_id = $2
+		ORDER BY c.captured_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, sessionID, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("query debug captures by session_id: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.DebugCapture, error) {
+		var c domain.DebugCapture
+		err := row.Scan(
+			&c.ID, &c.SessionID, &c.IdempotencyKey, &c.Method, &c.URL,
+			&c.RequestBody, &c.ResponseStatus, &c.ResponseBody, &c.CapturedAt,
+		)
+		return &c, err
+	})
+}
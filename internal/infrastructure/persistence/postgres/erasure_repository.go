@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+type ErasureRepository struct {
+	db    *DB
+	cache PaymentCache
+}
+
+func NewErasureRepository(db *DB) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// WithCache drops the cached copies of the payments an erasure anonymizes
+func (r *ErasureRepository) WithCache(cache PaymentCache) *ErasureRepository {
+	r.cache = cache
+	return r
+}
+
+type anonymizedPayment struct {
+	ID      string
+	OrderID string
+}
+
+// Erase replaces customerID with the erasure's token on the records of the merchant in
+// ctx that may be forgotten, fills in the erasure's counts and stores it, all in tx.
+// Copies of a payment kept elsewhere (its outbox events, its read model row, the bank
+// requests made for it, debug captures of those requests and the bank accounts its
+// refunds went to) are scrubbed with it, and the payments lose their card fingerprints,
+// last four digits and brands. A saved card is only erased once no kept payment and no
+// live subscription uses it.
+func (r *ErasureRepository) Erase(ctx context.Context, tx pgx.Tx, erasure *domain.Erasure, customerID string) error {
+	erasure.MerchantID = MerchantFromContext(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE payments SET customer_id = $1, card_fingerprint = NULL, card_last4 = NULL, card_brand = NULL
+		WHERE merchant_id = $2 AND customer_id = $3 AND created_at < $4
+		RETURNING id, order_id
+	`, erasure.CustomerToken, erasure.MerchantID, customerID, erasure.RetentionCutoff)
+	if err != nil {
+		return fmt.Errorf("anonymize payments: %w", err)
+	}
+	anonymized, err := pgx.CollectRows(rows, pgx.RowToStructByPos[anonymizedPayment])
+	if err != nil {
+		return fmt.Errorf("anonymize payments: %w", err)
+	}
+	paymentIDs := make([]string, len(anonymized))
+	for i, p := range anonymized {
+		paymentIDs[i] = p.ID
+		if r.cache != nil {
+			AfterCommit(tx, func() {
+				r.cache.Invalidate(ctx, erasure.MerchantID, p.ID, p.OrderID)
+			})
+		}
+	}
+	erasure.PaymentsAnonymized = len(paymentIDs)
+
+	// A payment group follows its parts, once none of them is kept
+	if _, err := tx.Exec(ctx, `
+		UPDATE payment_groups g SET customer_id = $1
+		WHERE g.merchant_id = $2 AND g.customer_id = $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM payments p
+			WHERE p.group_id = g.id AND p.customer_id = $3
+		  )
+	`, erasure.CustomerToken, erasure.MerchantID, customerID); err != nil {
+		return fmt.Errorf("anonymize payment groups: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE outbox SET payload = jsonb_set(payload, '{customer_id}', to_jsonb($1::text))
+		WHERE payment_id = ANY($2::uuid[])
+	`, erasure.CustomerToken, paymentIDs); err != nil {
+		return fmt.Errorf("anonymize outbox events: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE payment_read_model SET customer_id = $1, card_last4 = NULL, card_brand = NULL
+		WHERE id = ANY($2::uuid[])
+	`, erasure.CustomerToken, paymentIDs); err != nil {
+		return fmt.Errorf("anonymize payment read model: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE bank_attempts SET request_payload = request_payload - 'card_number' - 'expiry_month' - 'expiry_year'
+		WHERE payment_id = ANY($1::uuid[]) AND request_payload IS NOT NULL
+	`, paymentIDs); err != nil {
+		return fmt.Errorf("anonymize bank attempts: %w", err)
+	}
+
+	// A capture is covered by a session on its payment or on its idempotency key
+	if _, err := tx.Exec(ctx, `
+		DELETE FROM bank_debug_captures c
+		USING debug_sessions s
+		WHERE c.session_id = s.id AND s.merchant_id = $1
+		  AND (s.payment_id = ANY($2::uuid[])
+		       OR c.idempotency_key IN (
+		           SELECT key FROM idempotency_keys WHERE merchant_id = $1 AND payment_id = ANY($2::uuid[])
+		       ))
+	`, erasure.MerchantID, paymentIDs); err != nil {
+		return fmt.Errorf("delete debug captures: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE payment_operations SET destination_last4 = NULL, destination_routing_number = NULL
+		WHERE payment_id = ANY($1::uuid[])
+		  AND (destination_last4 IS NOT NULL OR destination_routing_number IS NOT NULL)
+	`, paymentIDs); err != nil {
+		return fmt.Errorf("anonymize refund destinations: %w", err)
+	}
+
+	if err := tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM payments WHERE merchant_id = $1 AND customer_id = $2
+	`, erasure.MerchantID, customerID).Scan(&erasure.PaymentsRetained); err != nil {
+		return fmt.Errorf("count retained payments: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE payment_methods m
+		SET customer_id = $1, card_number_ciphertext = ''::bytea, last4 = '', expiry_month = 0, expiry_year = 0
+		WHERE m.merchant_id = $2 AND m.customer_id = $3
+		  AND NOT EXISTS (
+			SELECT 1 FROM payments p
+			WHERE p.payment_method_id = m.id AND p.merchant_id = $2 AND p.customer_id = $3
+		  )
+		  AND NOT EXISTS (
+			SELECT 1 FROM subscriptions s
+			WHERE s.payment_method_id = m.id AND s.status <> $4
+		  )
+	`, erasure.CustomerToken, erasure.MerchantID, customerID, domain.SubscriptionCanceled)
+	if err != nil {
+		return fmt.Errorf("anonymize payment methods: %w", err)
+	}
+	erasure.PaymentMethodsAnonymized = int(tag.RowsAffected())
+
+	tag, err = tx.Exec(ctx, `
+		UPDATE subscriptions SET customer_id = $1
+		WHERE merchant_id = $2 AND customer_id = $3 AND status = $4
+	`, erasure.CustomerToken, erasure.MerchantID, customerID, domain.SubscriptionCanceled)
+	if err != nil {
+		return fmt.Errorf("anonymize subscriptions: %w", err)
+	}
+	erasure.SubscriptionsAnonymized = int(tag.RowsAffected())
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO erasures (
+			id, merchant_id, customer_token, retention_cutoff, payments_anonymized, payments_retained,
+			payment_methods_anonymized, subscriptions_anonymized, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		erasure.ID,
+		erasure.MerchantID,
+		erasure.CustomerToken,
+		erasure.RetentionCutoff,
+		erasure.PaymentsAnonymized,
+		erasure.PaymentsRetained,
+		erasure.PaymentMethodsAnonymized,
+		erasure.SubscriptionsAnonymized,
+		erasure.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to create erasure: %w", err)
+	}
+
+	return nil
+}
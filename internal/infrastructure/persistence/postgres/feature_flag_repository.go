@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+var ErrFeatureOverrideNotFound = errors.New("feature flag override not found")
+
+// FeatureFlagRepository stores the flags changed at runtime. Flags are gateway-wide,
+// so its calls ignore the merchant in ctx.
+type FeatureFlagRepository struct {
+	db *DB
+}
+
+func NewFeatureFlagRepository(db *DB) *FeatureFlagRepository {
+	return &FeatureFlagRepository{db: db}
+}
+
+// FindAll returns every known flag, sorted by name, with its stored percent or else
+// the one in rollout, and its overrides
+func (r *FeatureFlagRepository) FindAll(ctx context.Context, rollout domain.FeatureRollout) ([]*domain.FeatureFlag, error) {
+	flags := make(map[string]*domain.FeatureFlag, len(domain.DefaultFeatureRollout))
+	for name := range domain.DefaultFeatureRollout {
+		flags[name] = &domain.FeatureFlag{Name: name, Percent: rollout[name], Overrides: map[string]bool{}}
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT name, percent, updated_at FROM feature_flags`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var percent int
+		var updatedAt time.Time
+		if err := rows.Scan(&name, &percent, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		// Flags that were since removed from the gateway are left in the table
+		if flag, ok := flags[name]; ok {
+			flag.Percent = percent
+			flag.UpdatedAt = &updatedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature flags: %w", err)
+	}
+
+	rows, err = r.db.Query(ctx, `SELECT name, merchant_id, enabled FROM feature_flag_overrides`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature flag overrides: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, merchantID string
+		var enabled bool
+		if err := rows.Scan(&name, &merchantID, &enabled); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag override: %w", err)
+		}
+		if flag, ok := flags[name]; ok {
+			flag.Overrides[merchantID] = enabled
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature flag overrides: %w", err)
+	}
+
+	result := make([]*domain.FeatureFlag, 0, len(flags))
+	for _, name := range slices.Sorted(maps.Keys(flags)) {
+		result = append(result, flags[name])
+	}
+	return result, nil
+}
+
+// SetPercent stores the rollout percent of a flag, replacing the configured one
+func (r *FeatureFlagRepository) SetPercent(ctx context.Context, name string, percent int) error {
+	query := `
+		INSERT INTO feature_flags (name, percent)
+		VALUES ($1, $2)
+		ON CONFLICT (name) DO UPDATE
+		SET percent = EXCLUDED.percent,
+		    updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, name, percent); err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+	return nil
+}
+
+// SetOverride turns a flag on or off for one merchant
+func (r *FeatureFlagRepository) SetOverride(ctx context.Context, name, merchantID string, enabled bool) error {
+	query := `
+		INSERT INTO feature_flag_overrides (name, merchant_id, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name, merchant_id) DO UPDATE
+		SET enabled = EXCLUDED.enabled,
+		    updated_at = NOW()
+	`
+
+	if _, err := r.db.Exec(ctx, query, name, merchantID, enabled); err != nil {
+		if IsForeignKeyViolation(err) {
+			return ErrMerchantNotFound
+		}
+		return fmt.Errorf("failed to set feature flag override: %w", err)
+	}
+	return nil
+}
+
+// DeleteOverride returns a merchant to the flag's rollout percent
+func (r *FeatureFlagRepository) DeleteOverride(ctx context.Context, name, merchantID string) error {
+	query := `DELETE FROM feature_flag_overrides WHERE name = $1 AND merchant_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, name, merchantID)
+	if err != nil {
+		return fmt.Errorf("failed to delete feature flag override: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrFeatureOverrideNotFound
+	}
+	return nil
+}
@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 var ErrDuplicateIdempotencyKey = errors.New("duplicate transaction")
@@ -20,13 +22,15 @@ func NewIdempotencyRepository(db *DB) *IdempotencyRepository {
 	return &IdempotencyRepository{db: db}
 }
 
+// AcquireLock claims a key of the merchant in ctx for a payment request, at recovery
+// point CALLING_BANK. Keys are unique per merchant.
 func (r *IdempotencyRepository) AcquireLock(ctx context.Context, tx pgx.Tx, key, paymentID, requestHash string) error {
 	query := `
-		INSERT INTO idempotency_keys (key, payment_id, request_hash, locked_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO idempotency_keys (merchant_id, key, payment_id, request_hash, locked_at, recovery_point)
+		VALUES ($1, $2, $3, $4, $5, 'CALLING_BANK')
 	`
 
-	_, err := tx.Exec(ctx, query, key, paymentID, requestHash, time.Now())
+	_, err := tx.Exec(ctx, query, MerchantFromContext(ctx), key, paymentID, requestHash, time.Now())
 	if err != nil {
 		if IsUniqueViolation(err) {
 			return ErrDuplicateIdempotencyKey
@@ -37,20 +41,79 @@ func (r *IdempotencyRepository) AcquireLock(ctx context.Context, tx pgx.Tx, key,
 	return nil
 }
 
+// AcquirePayoutLock is AcquireLock for a key that belongs to a payout
+func (r *IdempotencyRepository) AcquirePayoutLock(ctx context.Context, tx pgx.Tx, key, payoutID, requestHash string) error {
+	query := `
+		INSERT INTO idempotency_keys (merchant_id, key, payout_id, request_hash, locked_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+
+	_, err := tx.Exec(ctx, query, MerchantFromContext(ctx), key, payoutID, requestHash, time.Now())
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return ErrDuplicateIdempotencyKey
+		}
+		return fmt.Errorf("failed to acquire idempotency lock: %w", err)
+	}
+
+	return nil
+}
+
+// ReacquireLock locks a released key again, for work that resumes a request after it
+// was answered, such as a refund once approved. It returns ErrDuplicateIdempotencyKey
+// if another request holds the key.
+func (r *IdempotencyRepository) ReacquireLock(ctx context.Context, tx pgx.Tx, key string) error {
+	query := `
+		UPDATE idempotency_keys
+		SET locked_at = $1, recovery_point = 'CALLING_BANK'
+		WHERE merchant_id = $2 AND key = $3 AND locked_at IS NULL
+	`
+
+	tag, err := tx.Exec(ctx, query, time.Now(), MerchantFromContext(ctx), key)
+	if err != nil {
+		return fmt.Errorf("failed to reacquire idempotency lock: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrDuplicateIdempotencyKey
+	}
+
+	return nil
+}
+
+// TakeOverLock hands a lock still held since lockedAt to the caller, restarting its
+// clock. It returns false if the lock has been released or taken over since.
+func (r *IdempotencyRepository) TakeOverLock(ctx context.Context, key string, lockedAt time.Time) (bool, error) {
+	query := `
+		UPDATE idempotency_keys
+		SET locked_at = $1
+		WHERE merchant_id = $2 AND key = $3 AND locked_at = $4
+	`
+
+	tag, err := r.db.Exec(ctx, query, time.Now(), MerchantFromContext(ctx), key, lockedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to take over idempotency lock: %w", err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
 func (r *IdempotencyRepository) FindByKey(ctx context.Context, key string) (*IdempotencyKey, error) {
 	query := `
-        SELECT key, payment_id, request_hash, locked_at, response_payload
+        SELECT key, COALESCE(payment_id::text, ''), COALESCE(payout_id::text, ''),
+               request_hash, locked_at, response_payload, COALESCE(recovery_point, '')
         FROM idempotency_keys
-        WHERE key = $1
+        WHERE merchant_id = $1 AND key = $2
     `
 	var i IdempotencyKey
 
-	err := r.db.QueryRow(ctx, query, key).Scan(
+	err := r.db.QueryRow(ctx, query, MerchantFromContext(ctx), key).Scan(
 		&i.Key,
 		&i.PaymentID,
+		&i.PayoutID,
 		&i.RequestHash,
 		&i.LockedAt,
 		&i.ResponsePayload,
+		&i.RecoveryPoint,
 	)
 
 	if err != nil {
@@ -64,12 +127,16 @@ func (r *IdempotencyRepository) FindByKey(ctx context.Context, key string) (*Ide
 }
 
 func (r *IdempotencyRepository) StoreResponse(ctx context.Context, tx pgx.Tx, key string, responsePayload []byte) error {
+	if err := pci.Guard(responsePayload); err != nil {
+		return fmt.Errorf("refusing to store idempotent response: %w", err)
+	}
+
 	query := `
 		UPDATE idempotency_keys
 		SET response_payload = $1
-		WHERE key = $2
+		WHERE merchant_id = $2 AND key = $3
 	`
-	_, err := tx.Exec(ctx, query, responsePayload, key)
+	_, err := tx.Exec(ctx, query, responsePayload, MerchantFromContext(ctx), key)
 	if err != nil {
 		return fmt.Errorf("failed to store idempotency response: %w", err)
 	}
@@ -80,14 +147,37 @@ func (r *IdempotencyRepository) StoreResponse(ctx context.Context, tx pgx.Tx, ke
 func (r *IdempotencyRepository) ReleaseLock(ctx context.Context, tx pgx.Tx, key string) error {
 	query := `
         UPDATE idempotency_keys
-        SET locked_at = NULL
-        WHERE key = $1
+        SET locked_at = NULL, recovery_point = NULL
+        WHERE merchant_id = $1 AND key = $2
     `
 
-	_, err := tx.Exec(ctx, query, key)
+	_, err := tx.Exec(ctx, query, MerchantFromContext(ctx), key)
 	if err != nil {
 		return fmt.Errorf("failed to release idempotency lock: %w", err)
 	}
 
 	return nil
 }
+
+// MarkBankResponded moves a locked key to recovery point BANK_RESPONDED, once the bank
+// has answered the request holding it. A nil tx writes straight away.
+func (r *IdempotencyRepository) MarkBankResponded(ctx context.Context, tx pgx.Tx, key string) error {
+	query := `
+		UPDATE idempotency_keys
+		SET recovery_point = 'BANK_RESPONDED'
+		WHERE merchant_id = $1 AND key = $2 AND locked_at IS NOT NULL
+	`
+
+	var q interface {
+		Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	} = r.db
+	if tx != nil {
+		q = tx
+	}
+
+	if _, err := q.Exec(ctx, query, MerchantFromContext(ctx), key); err != nil {
+		return fmt.Errorf("failed to record bank response: %w", err)
+	}
+
+	return nil
+}
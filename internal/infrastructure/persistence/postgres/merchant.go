@@ -0,0 +1,202 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrAPIKeyNotFound   = errors.New("api key not found")
+	ErrMerchantNotFound = errors.New("merchant not found")
+)
+
+type (
+	merchantKey struct{}
+	apiKeyKey   struct{}
+)
+
+// merchantScope is what a context allows repository calls to see. all is set only
+// for workers that pick up work across merchants.
+type merchantScope struct {
+	id  string
+	all bool
+}
+
+// WithMerchant scopes every repository call made with ctx to one merchant: rows of
+// other merchants are neither read nor changed, and new rows belong to it. Workers
+// that pick up work across merchants scope each item to the merchant that owns it.
+func WithMerchant(ctx context.Context, merchantID string) context.Context {
+	return context.WithValue(ctx, merchantKey{}, merchantScope{id: merchantID})
+}
+
+// AcrossMerchants lifts the row-level security policies for connections acquired
+// with ctx, so a worker can find the work of every merchant. Queries made with it
+// must still name the merchant of each row they write.
+func AcrossMerchants(ctx context.Context) context.Context {
+	return context.WithValue(ctx, merchantKey{}, merchantScope{id: MerchantFromContext(ctx), all: true})
+}
+
+// MerchantFromContext returns the merchant repository calls made with ctx act for
+func MerchantFromContext(ctx context.Context) string {
+	if scope, ok := ctx.Value(merchantKey{}).(merchantScope); ok && scope.id != "" {
+		return scope.id
+	}
+	return domain.DefaultMerchantID
+}
+
+// WithAPIKey records the API key a request was made with, for the audit log. It does
+// not scope ctx to the key's merchant; WithMerchant does that.
+func WithAPIKey(ctx context.Context, key *domain.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, key)
+}
+
+// APIKeyFromContext returns the API key recorded with WithAPIKey, or nil if there is none
+func APIKeyFromContext(ctx context.Context) *domain.APIKey {
+	key, _ := ctx.Value(apiKeyKey{}).(*domain.APIKey)
+	return key
+}
+
+func scopeFromContext(ctx context.Context) merchantScope {
+	scope, _ := ctx.Value(merchantKey{}).(merchantScope)
+	scope.id = MerchantFromContext(ctx)
+	return scope
+}
+
+// acrossMerchantsInTx lifts the row-level security policies for the rest of tx, for
+// a transaction that claims work of every merchant and then updates it
+func acrossMerchantsInTx(ctx context.Context, tx pgx.Tx) error {
+	if _, err := tx.Exec(ctx, `SELECT set_config('app.all_merchants', 'on', true)`); err != nil {
+		return fmt.Errorf("lift merchant isolation: %w", err)
+	}
+	return nil
+}
+
+type APIKeyRepository struct {
+	db *DB
+}
+
+func NewAPIKeyRepository(db *DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Find returns the API key with the merchant it was issued to and its role, or
+// ErrAPIKeyNotFound if the key is unknown or revoked
+func (r *APIKeyRepository) Find(ctx context.Context, key string) (*domain.APIKey, error) {
+	query := `SELECT id, merchant_id, role FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL`
+
+	var apiKey domain.APIKey
+	if err := r.db.QueryRow(ctx, query, HashAPIKey(key)).Scan(&apiKey.ID, &apiKey.MerchantID, &apiKey.Role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to scan api key: %w", err)
+	}
+
+	return &apiKey, nil
+}
+
+// FindByID returns an API key that is not revoked by its ID, with the sealed secret
+// signed requests are signed with, or nil if none was issued for it
+func (r *APIKeyRepository) FindByID(ctx context.Context, id string) (*domain.APIKey, *vault.Sealed, error) {
+	query := `
+		SELECT id, merchant_id, role, signing_secret_ciphertext, signing_secret_key_id
+		FROM api_keys WHERE id = $1 AND revoked_at IS NULL
+	`
+
+	var apiKey domain.APIKey
+	var ciphertext []byte
+	var keyID *string
+	if err := r.db.QueryRow(ctx, query, id).Scan(&apiKey.ID, &apiKey.MerchantID, &apiKey.Role, &ciphertext, &keyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil, ErrAPIKeyNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to scan api key: %w", err)
+	}
+
+	if keyID == nil {
+		return &apiKey, nil, nil
+	}
+	return &apiKey, &vault.Sealed{KeyID: *keyID, Ciphertext: ciphertext}, nil
+}
+
+// SetSigningSecret stores secret as the signing secret of an API key that is not
+// revoked, replacing any it had, or returns ErrAPIKeyNotFound
+func (r *APIKeyRepository) SetSigningSecret(ctx context.Context, id string, secret vault.Sealed) error {
+	query := `
+		UPDATE api_keys SET signing_secret_ciphertext = $1, signing_secret_key_id = $2
+		WHERE id = $3 AND revoked_at IS NULL
+	`
+
+	tag, err := r.db.Exec(ctx, query, secret.Ciphertext, secret.KeyID, id)
+	if err != nil {
+		return fmt.Errorf("failed to set signing secret: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// ReplaceSigningSecret stores a signing secret re-sealed as resealed, unless it was
+// replaced since it was read. It reports whether it was replaced.
+func (r *APIKeyRepository) ReplaceSigningSecret(ctx context.Context, id string, secret, resealed vault.Sealed) (bool, error) {
+	query := `
+		UPDATE api_keys SET signing_secret_ciphertext = $1, signing_secret_key_id = $2
+		WHERE id = $3 AND signing_secret_key_id = $4 AND signing_secret_ciphertext = $5
+	`
+
+	tag, err := r.db.Exec(ctx, query, resealed.Ciphertext, resealed.KeyID, id, secret.KeyID, secret.Ciphertext)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace signing secret: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindStaleSigningSecrets returns up to limit signing secrets of keys that are not
+// revoked sealed with a key other than primaryKeyID
+func (r *APIKeyRepository) FindStaleSigningSecrets(ctx context.Context, primaryKeyID string, limit int) ([]StaleCiphertext, error) {
+	query := `
+		SELECT id, merchant_id, signing_secret_ciphertext, signing_secret_key_id
+		FROM api_keys
+		WHERE signing_secret_key_id <> $1 AND revoked_at IS NULL
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, primaryKeyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale signing secrets: %w", err)
+	}
+	return collectStaleCiphertexts(rows)
+}
+
+type MerchantRepository struct {
+	db *DB
+}
+
+func NewMerchantRepository(db *DB) *MerchantRepository {
+	return &MerchantRepository{db: db}
+}
+
+// FindIDs returns the ID of every merchant, for jobs that work through each in turn
+func (r *MerchantRepository) FindIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT id FROM merchants ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("query merchants: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// HashAPIKey returns the form an API key is stored in. Keys are random, so a plain
+// SHA-256 is enough to keep a leaked table from yielding usable keys. Being readable
+// from the table, the hash is no secret to sign requests with.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
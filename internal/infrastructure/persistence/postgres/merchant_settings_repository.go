@@ -0,0 +1,142 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+type MerchantSettingsRepository struct {
+	db *DB
+}
+
+func NewMerchantSettingsRepository(db *DB) *MerchantSettingsRepository {
+	return &MerchantSettingsRepository{db: db}
+}
+
+// Find returns the settings of the merchant in ctx. A merchant that has none gets
+// the zero settings, which keep every gateway default.
+func (r *MerchantSettingsRepository) Find(ctx context.Context) (*domain.MerchantSettings, error) {
+	query := `
+		SELECT allowed_currencies, max_retries, retry_base_delay_seconds, refund_window_days, auto_capture,
+		       customer_notifications, skip_soft_decline_retry, auto_capture_categories
+		FROM merchant_settings
+		WHERE merchant_id = $1
+	`
+
+	settings := &domain.MerchantSettings{MerchantID: MerchantFromContext(ctx)}
+	var retryBaseDelaySeconds, refundWindowDays *int
+	err := r.db.QueryRow(ctx, query, settings.MerchantID).Scan(
+		&settings.AllowedCurrencies,
+		&settings.MaxRetries,
+		&retryBaseDelaySeconds,
+		&refundWindowDays,
+		&settings.AutoCapture,
+		&settings.CustomerNotifications,
+		&settings.SkipSoftDeclineRetry,
+		&settings.AutoCaptureCategories,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return settings, nil
+		}
+		return nil, fmt.Errorf("failed to scan merchant settings: %w", err)
+	}
+
+	if retryBaseDelaySeconds != nil {
+		delay := time.Duration(*retryBaseDelaySeconds) * time.Second
+		settings.RetryBaseDelay = &delay
+	}
+	if refundWindowDays != nil {
+		window := time.Duration(*refundWindowDays) * 24 * time.Hour
+		settings.RefundWindow = &window
+	}
+
+	return settings, nil
+}
+
+// FindQuota returns the daily quota of the merchant in ctx with today's usage
+func (r *MerchantSettingsRepository) FindQuota(ctx context.Context) (*domain.MerchantQuota, error) {
+	query := `
+		SELECT s.daily_transaction_limit, s.daily_volume_limit_cents,
+		       COALESCE(u.transaction_count, 0), COALESCE(u.volume_cents, 0)
+		FROM merchants m
+		LEFT JOIN merchant_settings s ON s.merchant_id = m.id
+		LEFT JOIN merchant_quota_usage u ON u.merchant_id = m.id AND u.day = (NOW() AT TIME ZONE 'UTC')::date
+		WHERE m.id = $1
+	`
+
+	quota := &domain.MerchantQuota{MerchantID: MerchantFromContext(ctx)}
+	err := r.db.QueryRow(ctx, query, quota.MerchantID).Scan(
+		&quota.DailyTransactionLimit,
+		&quota.DailyVolumeLimitCents,
+		&quota.UsedTransactions,
+		&quota.UsedVolumeCents,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrMerchantNotFound
+		}
+		return nil, fmt.Errorf("failed to scan merchant quota: %w", err)
+	}
+
+	return quota, nil
+}
+
+// UpdateQuota sets the daily limits of the merchant in ctx; nil removes a limit
+func (r *MerchantSettingsRepository) UpdateQuota(ctx context.Context, transactionLimit *int, volumeLimitCents *int64) error {
+	query := `
+		INSERT INTO merchant_settings (merchant_id, daily_transaction_limit, daily_volume_limit_cents)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (merchant_id) DO UPDATE
+		SET daily_transaction_limit = EXCLUDED.daily_transaction_limit,
+		    daily_volume_limit_cents = EXCLUDED.daily_volume_limit_cents,
+		    updated_at = NOW()
+	`
+
+	_, err := r.db.Exec(ctx, query, MerchantFromContext(ctx), transactionLimit, volumeLimitCents)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return ErrMerchantNotFound
+		}
+		return fmt.Errorf("failed to update merchant quota: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeQuota counts a new payment of amount against today's quota of the merchant
+// in ctx and returns the quota with the payment counted. It belongs in the transaction
+// that creates the payment, so rolling that back returns the quota.
+func (r *MerchantSettingsRepository) ConsumeQuota(ctx context.Context, tx pgx.Tx, amount int64) (*domain.MerchantQuota, error) {
+	query := `
+		WITH usage AS (
+			INSERT INTO merchant_quota_usage (merchant_id, day, transaction_count, volume_cents)
+			VALUES ($1, (NOW() AT TIME ZONE 'UTC')::date, 1, $2)
+			ON CONFLICT (merchant_id, day) DO UPDATE
+			SET transaction_count = merchant_quota_usage.transaction_count + 1,
+			    volume_cents = merchant_quota_usage.volume_cents + EXCLUDED.volume_cents
+			RETURNING transaction_count, volume_cents
+		)
+		SELECT s.daily_transaction_limit, s.daily_volume_limit_cents, u.transaction_count, u.volume_cents
+		FROM usage u
+		LEFT JOIN merchant_settings s ON s.merchant_id = $1
+	`
+
+	quota := &domain.MerchantQuota{MerchantID: MerchantFromContext(ctx)}
+	err := tx.QueryRow(ctx, query, quota.MerchantID, amount).Scan(
+		&quota.DailyTransactionLimit,
+		&quota.DailyVolumeLimitCents,
+		&quota.UsedTransactions,
+		&quota.UsedVolumeCents,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume merchant quota: %w", err)
+	}
+
+	return quota, nil
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// migrationLockID serializes gateways migrating the same database at once
+const migrationLockID = 7340012
+
+// Migration is one up migration, numbered by the prefix of its file name
+type Migration struct {
+	Version uint64
+	Name    string
+}
+
+// Migrate applies the up migrations in files newer than the database's version, each in
+// its own transaction. The version is kept in schema_migrations the way the migrate CLI
+// keeps it, so either can be used on the same database. A migration that fails leaves
+// the version at the last one applied.
+func Migrate(ctx context.Context, db *DB, files fs.FS) ([]Migration, error) {
+	pending, err := upMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return nil, fmt.Errorf("lock migrations: %w", err)
+	}
+	defer conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID) //nolint:errcheck // released with the session anyway
+
+	_, err = conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)`)
+	if err != nil {
+		return nil, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	var current uint64
+	var dirty bool
+	err = conn.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&current, &dirty)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return nil, fmt.Errorf("database is dirty at version %d; fix it by hand and reset the version before migrating", current)
+	}
+
+	var applied []Migration
+	for _, m := range pending {
+		if m.Version <= current {
+			continue
+		}
+		sql, err := fs.ReadFile(files, m.Name)
+		if err != nil {
+			return applied, fmt.Errorf("read migration %s: %w", m.Name, err)
+		}
+
+		err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, string(sql)); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `DELETE FROM schema_migrations`); err != nil {
+				return err
+			}
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, dirty) VALUES ($1, FALSE)`, m.Version)
+			return err
+		})
+		if err != nil {
+			return applied, fmt.Errorf("apply migration %s: %w", m.Name, err)
+		}
+		applied = append(applied, m)
+	}
+
+	return applied, nil
+}
+
+// upMigrations lists the up migrations in files, oldest first
+func upMigrations(files fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list migrations: %w", err)
+	}
+
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "_")
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s is not numbered: %w", name, err)
+		}
+		migrations = append(migrations, Migration{Version: version, Name: name})
+	}
+
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
@@ -7,9 +7,23 @@ import (
 // IdempotencyKey enforces at-most-once semantics via unique constraint on key.
 // LockedAt prevents polling clients from blocking on uncommitted rows.
 type IdempotencyKey struct {
-	Key             string
-	PaymentID       string
+	Key       string
+	PaymentID string
+	// PayoutID is set instead of PaymentID when the key belongs to a payout request
+	PayoutID        string
 	RequestHash     string
 	LockedAt        *time.Time
 	ResponsePayload *[]byte
+	// RecoveryPoint is how far the request holding the lock got with the bank; it is
+	// empty once the lock is released
+	RecoveryPoint RecoveryPoint
 }
+
+// RecoveryPoint records how far a locked payment request got with the bank, so a crash
+// can be told apart from a call the bank answered
+type RecoveryPoint string
+
+const (
+	RecoveryCallingBank   RecoveryPoint = "CALLING_BANK"
+	RecoveryBankResponded RecoveryPoint = "BANK_RESPONDED"
+)
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type NonceRepository struct {
+	db *DB
+}
+
+func NewNonceRepository(db *DB) *NonceRepository {
+	return &NonceRepository{db: db}
+}
+
+// Claim records that apiKeyID has used nonce until expiresAt. It reports false if the
+// key already used it and that use has not expired by now, which makes the request a
+// replay. The key's expired nonces are pruned on the way.
+func (r *NonceRepository) Claim(ctx context.Context, apiKeyID, nonce string, expiresAt, now time.Time) (bool, error) {
+	query := `
+		WITH pruned AS (
+			DELETE FROM request_nonces
+			WHERE api_key_id = $1 AND expires_at < $4 AND nonce <> $2
+		)
+		INSERT INTO request_nonces (api_key_id, nonce, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (api_key_id, nonce) DO UPDATE SET expires_at = EXCLUDED.expires_at
+		WHERE request_nonces.expires_at < $4
+		RETURNING nonce
+	`
+
+	var claimed string
+	if err := r.db.QueryRow(ctx, query, apiKeyID, nonce, expiresAt, now).Scan(&claimed); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim nonce: %w", err)
+	}
+
+	return true, nil
+}
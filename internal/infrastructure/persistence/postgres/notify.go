@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// RetryChannel carries the IDs of payments a transient bank failure left mid-transition,
+// for the retry workers to resume without waiting for their next poll
+const RetryChannel = "payment_retries"
+
+// Notify sends payload to everyone listening on channel. Nothing is queued for
+// listeners that are not connected.
+func (db *DB) Notify(ctx context.Context, channel, payload string) error {
+	if _, err := db.Exec(ctx, `SELECT pg_notify($1, $2)`, channel, payload); err != nil {
+		return fmt.Errorf("notify %s: %w", channel, err)
+	}
+	return nil
+}
+
+// Listen calls receive with the payload of every notification sent on channel until
+// ctx is done or the connection fails. It holds one pooled connection meanwhile.
+func (db *DB) Listen(ctx context.Context, channel string, receive func(payload string)) error {
+	conn, err := db.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+		return fmt.Errorf("listen on %s: %w", channel, err)
+	}
+	// The connection goes back to the pool afterwards, where nobody would read what
+	// arrives on the channel
+	defer func() {
+		unlistenCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+		defer cancel()
+		conn.Exec(unlistenCtx, "UNLISTEN *") //nolint:errcheck // a broken connection is not reused
+	}()
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("wait for notification on %s: %w", channel, err)
+		}
+		receive(notification.Payload)
+	}
+}
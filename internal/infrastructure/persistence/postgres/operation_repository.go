@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	ErrOperationNotFound = errors.New("operation not found")
+	ErrRefundNotFound    = errors.New("refund not found")
+)
+
+// operationColumns selects an operation aliased o. Operations belong to the merchant
+// of their payment, so queries join it as p to scope them.
+const operationColumns = `
+	o.id, o.payment_id, o.type, o.status, o.amount_cents, o.idempotency_key,
+	o.reason, o.bank_reference_id, o.created_at, o.completed_at,
+	o.requested_by, o.reviewed_by, o.reviewed_at,
+	COALESCE(o.destination, ''), o.destination_last4, o.destination_routing_number,
+	o.bank_initiated
+`
+
+type OperationRepository struct {
+	db *DB
+}
+
+func NewOperationRepository(db *DB) *OperationRepository {
+	return &OperationRepository{db: db}
+}
+
+// Create stores an operation against a payment of the merchant in ctx
+func (r *OperationRepository) Create(ctx context.Context, tx pgx.Tx, op *domain.Operation) error {
+	query := `
+		INSERT INTO payment_operations (
+			id, payment_id, type, status, amount_cents, idempotency_key,
+			reason, bank_reference_id, created_at, completed_at, requested_by,
+			bank_initiated
+		)
+		SELECT $1, p.id, $3, $4, $5, $6, $7, $8, $9, $10, $12, $13
+		FROM payments p
+		WHERE p.id = $2 AND p.merchant_id = $11
+	`
+
+	tag, err := tx.Exec(ctx, query,
+		op.ID,
+		op.PaymentID,
+		op.Type,
+		op.Status,
+		op.AmountCents,
+		op.IdempotencyKey,
+		op.Reason,
+		op.BankReferenceID,
+		op.CreatedAt,
+		op.CompletedAt,
+		MerchantFromContext(ctx),
+		op.RequestedBy,
+		op.BankInitiated,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create operation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentNotFound
+	}
+
+	return nil
+}
+
+// FindByID retrieves an operation
+func (r *OperationRepository) FindByID(ctx context.Context, id string) (*domain.Operation, error) {
+	query := `
+		SELECT ` + operationColumns + `
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.id = $1 AND p.merchant_id = $2
+	`
+
+	row := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx))
+	return scanOperation(row)
+}
+
+// FindRefundByID retrieves an operation only if it is a refund
+func (r *OperationRepository) FindRefundByID(ctx context.Context, id string) (*domain.Operation, error) {
+	query := `
+		SELECT ` + operationColumns + `
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.id = $1 AND o.type = 'REFUND' AND p.merchant_id = $2
+	`
+
+	row := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx))
+	op, err := scanOperation(row)
+	if errors.Is(err, ErrOperationNotFound) {
+		return nil, ErrRefundNotFound
+	}
+	return op, err
+}
+
+// FindRefundByIDForUpdate is FindRefundByID that locks the refund until tx ends, so it
+// is reviewed by one request at a time
+func (r *OperationRepository) FindRefundByIDForUpdate(ctx context.Context, tx pgx.Tx, id string) (*domain.Operation, error) {
+	query := `
+		SELECT ` + operationColumns + `
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.id = $1 AND o.type = 'REFUND' AND p.merchant_id = $2
+		FOR UPDATE OF o
+	`
+
+	row := tx.QueryRow(ctx, query, id, MerchantFromContext(ctx))
+	op, err := scanOperation(row)
+	if errors.Is(err, ErrOperationNotFound) {
+		return nil, ErrRefundNotFound
+	}
+	return op, err
+}
+
+// FindByIdempotencyKey retrieves the operation started with an idempotency key
+func (r *OperationRepository) FindByIdempotencyKey(ctx context.Context, idempotencyKey string) (*domain.Operation, error) {
+	query := `
+		SELECT ` + operationColumns + `
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.idempotency_key = $1 AND p.merchant_id = $2
+	`
+
+	row := r.db.QueryRow(ctx, query, idempotencyKey, MerchantFromContext(ctx))
+	return scanOperation(row)
+}
+
+// FindByPaymentID retrieves all operations for a payment, oldest first
+func (r *OperationRepository) FindByPaymentID(ctx context.Context, paymentID string) ([]*domain.Operation, error) {
+	query := `
+		SELECT ` + operationColumns + `
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.payment_id = $1 AND p.merchant_id = $2
+		ORDER BY o.created_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, paymentID, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("query operations by payment_id: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Operation, error) {
+		return scanOperation(row)
+	})
+}
+
+// FindPendingApproval retrieves the refunds waiting for approval, oldest first
+func (r *OperationRepository) FindPendingApproval(ctx context.Context, limit int) ([]*domain.Operation, error) {
+	query := `
+		SELECT ` + operationColumns + `
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.status = 'PENDING_APPROVAL' AND p.merchant_id = $1
+		ORDER BY o.created_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query operations pending approval: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Operation, error) {
+		return scanOperation(row)
+	})
+}
+
+func (r *OperationRepository) Update(ctx context.Context, tx pgx.Tx, op *domain.Operation) error {
+	query := `
+		UPDATE payment_operations o
+		SET status = $1, bank_reference_id = $2, completed_at = $3,
+		    reviewed_by = $6, reviewed_at = $7,
+		    destination_account_ciphertext = CASE WHEN $3::timestamptz IS NULL THEN o.destination_account_ciphertext END,
+		    destination_account_key_id = CASE WHEN $3::timestamptz IS NULL THEN o.destination_account_key_id END
+		FROM payments p
+		WHERE o.id = $4 AND p.id = o.payment_id AND p.merchant_id = $5
+	`
+	var q interface {
+		Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	} = r.db
+	if tx != nil {
+		q = tx
+	}
+
+	results, err := q.Exec(ctx, query, op.Status, op.BankReferenceID, op.CompletedAt, op.ID, MerchantFromContext(ctx),
+		op.ReviewedBy, op.ReviewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update operation: %w", err)
+	}
+
+	if results.RowsAffected() == 0 {
+		return ErrOperationNotFound
+	}
+
+	return nil
+}
+
+// SetDestination stores where a refund created in tx sends the funds, with the account
+// number of a bank transfer already encrypted by the vault. The account is cleared when
+// the refund completes, since it is only kept to resend an interrupted transfer.
+func (r *OperationRepository) SetDestination(ctx context.Context, tx pgx.Tx, op *domain.Operation, account *vault.Sealed) error {
+	query := `
+		UPDATE payment_operations o
+		SET destination = $1, destination_last4 = $2, destination_routing_number = $3,
+		    destination_account_ciphertext = $4, destination_account_key_id = $5
+		FROM payments p
+		WHERE o.id = $6 AND p.id = o.payment_id AND p.merchant_id = $7
+	`
+
+	var ciphertext []byte
+	var keyID *string
+	if account != nil {
+		ciphertext, keyID = account.Ciphertext, &account.KeyID
+	}
+
+	tag, err := tx.Exec(ctx, query,
+		op.Destination, op.DestinationLast4, op.DestinationRoutingNumber,
+		ciphertext, keyID,
+		op.ID, MerchantFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set refund destination: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrOperationNotFound
+	}
+	return nil
+}
+
+// FindDestinationAccount returns the encrypted account number a bank transfer refund
+// is sent to, or ErrOperationNotFound once the refund has completed
+func (r *OperationRepository) FindDestinationAccount(ctx context.Context, id string) (vault.Sealed, error) {
+	query := `
+		SELECT o.destination_account_ciphertext, o.destination_account_key_id
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.id = $1 AND p.merchant_id = $2 AND o.destination_account_ciphertext IS NOT NULL
+	`
+
+	var account vault.Sealed
+	if err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(&account.Ciphertext, &account.KeyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault.Sealed{}, ErrOperationNotFound
+		}
+		return vault.Sealed{}, fmt.Errorf("failed to scan refund account: %w", err)
+	}
+	return account, nil
+}
+
+// ReplaceDestinationAccount stores account re-sealed as resealed, unless it was
+// re-sealed or cleared since it was read. It reports whether it was replaced.
+func (r *OperationRepository) ReplaceDestinationAccount(ctx context.Context, id string, account, resealed vault.Sealed) (bool, error) {
+	query := `
+		UPDATE payment_operations o
+		SET destination_account_ciphertext = $1, destination_account_key_id = $2
+		FROM payments p
+		WHERE o.id = $3 AND p.id = o.payment_id AND p.merchant_id = $4 AND o.destination_account_key_id = $5
+	`
+
+	tag, err := r.db.Exec(ctx, query, resealed.Ciphertext, resealed.KeyID, id, MerchantFromContext(ctx), account.KeyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace refund account: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindStaleDestinationAccounts returns up to limit refund accounts of all merchants
+// sealed with a key other than primaryKeyID
+func (r *OperationRepository) FindStaleDestinationAccounts(ctx context.Context, primaryKeyID string, limit int) ([]StaleCiphertext, error) {
+	query := `
+		SELECT o.id, p.merchant_id, o.destination_account_ciphertext, o.destination_account_key_id
+		FROM payment_operations o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.destination_account_key_id <> $1
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, primaryKeyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale refund accounts: %w", err)
+	}
+	return collectStaleCiphertexts(rows)
+}
+
+// scanOperation converts a database row into a domain Operation.
+// Returns ErrOperationNotFound if the row doesn't exist.
+func scanOperation(row pgx.Row) (*domain.Operation, error) {
+	var op domain.Operation
+	err := row.Scan(
+		&op.ID, &op.PaymentID, &op.Type, &op.Status, &op.AmountCents, &op.IdempotencyKey,
+		&op.Reason, &op.BankReferenceID, &op.CreatedAt, &op.CompletedAt,
+		&op.RequestedBy, &op.ReviewedBy, &op.ReviewedAt,
+		&op.Destination, &op.DestinationLast4, &op.DestinationRoutingNumber,
+		&op.BankInitiated,
+	)
+
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOperationNotFound
+		}
+		return nil, fmt.Errorf("failed to scan operation: %w", err)
+	}
+	return &op, nil
+}
@@ -0,0 +1,203 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// insertOutboxEvent is the head of an INSERT ... SELECT that records a transition.
+// Callers complete it with a FROM clause binding `payment` to the payment row after
+// the change, `previous` to a row whose status is the one before it and `origin` to
+// a row whose actor is the one in ctx. The payload names each field rather than
+// copying the row, so a new column does not change it behind its schema's back.
+var insertOutboxEvent = `
+	INSERT INTO outbox (
+		id, payment_id, event_type, from_status, to_status, actor, attempt_count,
+		payload, schema_version, occurred_at
+	)
+	SELECT gen_random_uuid(), payment.id, 'payment.' || lower(payment.status),
+	       previous.status, payment.status, origin.actor, payment.attempt_count,
+	       jsonb_build_object(
+	           'id', payment.id,
+	           'merchant_id', payment.merchant_id,
+	           'order_id', payment.order_id,
+	           'customer_id', payment.customer_id,
+	           'amount_cents', payment.amount_cents,
+	           'currency', payment.currency,
+	           'status', payment.status,
+	           'acquirer', payment.acquirer,
+	           'bank_auth_id', payment.bank_auth_id,
+	           'bank_capture_id', payment.bank_capture_id,
+	           'bank_void_id', payment.bank_void_id,
+	           'bank_refund_id', payment.bank_refund_id,
+	           'captured_amount_cents', payment.captured_amount_cents,
+	           'refunded_amount_cents', payment.refunded_amount_cents,
+	           'released_amount_cents', payment.released_amount_cents,
+	           'failure_reason', payment.failure_reason,
+	           'decline_category', payment.decline_category,
+	           'payment_method_id', payment.payment_method_id,
+	           'attempt_count', payment.attempt_count,
+	           'created_at', payment.created_at,
+	           'authorized_at', payment.authorized_at,
+	           'captured_at', payment.captured_at,
+	           'voided_at', payment.voided_at,
+	           'refunded_at', payment.refunded_at,
+	           'expires_at', payment.expires_at
+	       ),
+	       ` + strconv.Itoa(domain.TransitionSchemaVersion) + `, NOW()`
+
+type actorKey struct{}
+
+// WithActor records what the changes made with ctx are made by, for their events
+func WithActor(ctx context.Context, actor domain.Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor recorded with WithActor. Changes made without one
+// come from a background job.
+func ActorFromContext(ctx context.Context) domain.Actor {
+	if actor, ok := ctx.Value(actorKey{}).(domain.Actor); ok {
+		return actor
+	}
+	return domain.ActorSystem
+}
+
+type OutboxRepository struct {
+	db *DB
+}
+
+func NewOutboxRepository(db *DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// ClaimBatch locks up to limit undelivered events of all merchants, oldest first. Rows
+// locked by another worker are skipped, so several gateway instances can drain the outbox.
+func (r *OutboxRepository) ClaimBatch(ctx context.Context, tx pgx.Tx, limit int) ([]*domain.TransitionEvent, error) {
+	query := `
+		SELECT id, payment_id, COALESCE(payload->>'merchant_id', $2), event_type, from_status, to_status,
+		       COALESCE(actor, ''), attempt_count, payload, schema_version, occurred_at, attempts
+		FROM outbox
+		WHERE processed_at IS NULL
+		ORDER BY occurred_at ASC
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`
+
+	// Events written before payments had a merchant belong to the default one
+	rows, err := tx.Query(ctx, query, limit, domain.DefaultMerchantID)
+	if err != nil {
+		return nil, fmt.Errorf("query outbox: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.TransitionEvent, error) {
+		var e domain.TransitionEvent
+		err := row.Scan(
+			&e.ID, &e.PaymentID, &e.MerchantID, &e.EventType, &e.FromStatus, &e.ToStatus,
+			&e.Actor, &e.AttemptCount, &e.Payload, &e.SchemaVersion, &e.OccurredAt, &e.Attempts,
+		)
+		return &e, err
+	})
+}
+
+// FindByPaymentID retrieves the transitions of one of the merchant's payments, oldest
+// first, whether or not they have been delivered
+func (r *OutboxRepository) FindByPaymentID(ctx context.Context, paymentID string) ([]*domain.TransitionEvent, error) {
+	query := `
+		SELECT o.id, o.payment_id, p.merchant_id, o.event_type, o.from_status, o.to_status,
+		       COALESCE(o.actor, ''), o.attempt_count, o.payload, o.schema_version, o.occurred_at, o.attempts
+		FROM outbox o
+		JOIN payments p ON p.id = o.payment_id
+		WHERE o.payment_id = $1 AND p.merchant_id = $2
+		ORDER BY o.occurred_at ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, paymentID, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("query outbox by payment_id: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.TransitionEvent, error) {
+		var e domain.TransitionEvent
+		err := row.Scan(
+			&e.ID, &e.PaymentID, &e.MerchantID, &e.EventType, &e.FromStatus, &e.ToStatus,
+			&e.Actor, &e.AttemptCount, &e.Payload, &e.SchemaVersion, &e.OccurredAt, &e.Attempts,
+		)
+		return &e, err
+	})
+}
+
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, tx pgx.Tx, id string) error {
+	query := `UPDATE outbox SET processed_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1`
+
+	if _, err := tx.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to mark outbox event processed: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed leaves the event undelivered so the next poll retries it
+func (r *OutboxRepository) MarkFailed(ctx context.Context, tx pgx.Tx, id string, cause error) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`
+
+	if _, err := tx.Exec(ctx, query, id, cause.Error()); err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+	return nil
+}
+
+// Backlog counts the undelivered events of all merchants and returns when the oldest
+// of them occurred, or nil when there are none
+func (r *OutboxRepository) Backlog(ctx context.Context) (int, *time.Time, error) {
+	query := `SELECT COUNT(*), MIN(occurred_at) FROM outbox WHERE processed_at IS NULL`
+
+	var count int
+	var oldest *time.Time
+	if err := r.db.QueryRow(ctx, query).Scan(&count, &oldest); err != nil {
+		return 0, nil, fmt.Errorf("failed to read outbox backlog: %w", err)
+	}
+	return count, oldest, nil
+}
+
+// FindPause returns the pause holding back delivery, or nil while events are delivered
+func (r *OutboxRepository) FindPause(ctx context.Context) (*domain.OutboxPause, error) {
+	query := `SELECT paused_at, COALESCE(paused_by, ''), reason FROM outbox_pause`
+
+	var pause domain.OutboxPause
+	err := r.db.QueryRow(ctx, query).Scan(&pause.PausedAt, &pause.PausedBy, &pause.Reason)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find outbox pause: %w", err)
+	}
+	return &pause, nil
+}
+
+// Pause holds back delivery. Pausing while paused keeps the first pause, so the time
+// and reason shown are those of when delivery stopped.
+func (r *OutboxRepository) Pause(ctx context.Context, pause *domain.OutboxPause) error {
+	query := `
+		INSERT INTO outbox_pause (paused_at, paused_by, reason)
+		VALUES ($1, NULLIF($2, ''), $3)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	if _, err := r.db.Exec(ctx, query, pause.PausedAt, pause.PausedBy, pause.Reason); err != nil {
+		return fmt.Errorf("failed to pause outbox: %w", err)
+	}
+	return nil
+}
+
+// Resume lifts the pause, if any
+func (r *OutboxRepository) Resume(ctx context.Context) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM outbox_pause`); err != nil {
+		return fmt.Errorf("failed to resume outbox: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrPaymentGroupNotFound = errors.New("payment group not found")
+
+type PaymentGroupRepository struct {
+	db *DB
+}
+
+func NewPaymentGroupRepository(db *DB) *PaymentGroupRepository {
+	return &PaymentGroupRepository{db: db}
+}
+
+// Create stores a group of the merchant in ctx under the idempotency key of the
+// request that created it. Its parts are stored as payments as they are authorized.
+func (r *PaymentGroupRepository) Create(ctx context.Context, group *domain.PaymentGroup, idempotencyKey, requestHash string) error {
+	group.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO payment_groups (
+			id, merchant_id, order_id, customer_id, amount_cents, currency, idempotency_key, request_hash, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		group.ID,
+		group.MerchantID,
+		group.OrderID,
+		group.CustomerID,
+		group.AmountCents,
+		group.Currency,
+		idempotencyKey,
+		requestHash,
+		group.CreatedAt,
+	)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return ErrDuplicateIdempotencyKey
+		}
+		return fmt.Errorf("failed to create payment group: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID returns the group without its parts
+func (r *PaymentGroupRepository) FindByID(ctx context.Context, id string) (*domain.PaymentGroup, error) {
+	query := `
+		SELECT id, merchant_id, order_id, customer_id, amount_cents, currency, created_at
+		FROM payment_groups WHERE id = $1 AND merchant_id = $2
+	`
+
+	var g domain.PaymentGroup
+	err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(
+		&g.ID, &g.MerchantID, &g.OrderID, &g.CustomerID, &g.AmountCents, &g.Currency, &g.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to scan payment group: %w", err)
+	}
+
+	return &g, nil
+}
+
+// FindIDByIdempotencyKey returns the group created under the key with its request
+// hash, or ErrPaymentGroupNotFound if the key is unused
+func (r *PaymentGroupRepository) FindIDByIdempotencyKey(ctx context.Context, key string) (string, string, error) {
+	query := `SELECT id, request_hash FROM payment_groups WHERE merchant_id = $1 AND idempotency_key = $2`
+
+	var id, requestHash string
+	if err := r.db.QueryRow(ctx, query, MerchantFromContext(ctx), key).Scan(&id, &requestHash); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", "", ErrPaymentGroupNotFound
+		}
+		return "", "", fmt.Errorf("failed to scan payment group: %w", err)
+	}
+
+	return id, requestHash, nil
+}
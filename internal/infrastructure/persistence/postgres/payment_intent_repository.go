@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrPaymentIntentNotFound = errors.New("payment intent not found")
+
+type PaymentIntentRepository struct {
+	db *DB
+}
+
+func NewPaymentIntentRepository(db *DB) *PaymentIntentRepository {
+	return &PaymentIntentRepository{db: db}
+}
+
+// Create stores the intent for the merchant in ctx
+func (r *PaymentIntentRepository) Create(ctx context.Context, intent *domain.PaymentIntent) error {
+	intent.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO payment_intents (
+			id, merchant_id, order_id, customer_id, amount_cents, currency, status, client_token_hash,
+			attempts, payment_id, created_at, expires_at, confirmed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		intent.ID,
+		intent.MerchantID,
+		intent.OrderID,
+		intent.CustomerID,
+		intent.AmountCents,
+		intent.Currency,
+		intent.Status,
+		intent.ClientTokenHash,
+		intent.Attempts,
+		intent.PaymentID,
+		intent.CreatedAt,
+		intent.ExpiresAt,
+		intent.ConfirmedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PaymentIntentRepository) FindByID(ctx context.Context, id string) (*domain.PaymentIntent, error) {
+	query := `
+		SELECT id, merchant_id, order_id, customer_id, amount_cents, currency, status, client_token_hash,
+		       attempts, payment_id, created_at, expires_at, confirmed_at
+		FROM payment_intents WHERE id = $1 AND merchant_id = $2
+	`
+
+	return scanPaymentIntent(r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)))
+}
+
+// FindByClientToken returns the intent with the ID and client token given, whichever
+// merchant it belongs to. A storefront confirming an intent has no API key, so the
+// token is what identifies the merchant.
+func (r *PaymentIntentRepository) FindByClientToken(ctx context.Context, id, clientToken string) (*domain.PaymentIntent, error) {
+	query := `
+		SELECT id, merchant_id, order_id, customer_id, amount_cents, currency, status, client_token_hash,
+		       attempts, payment_id, created_at, expires_at, confirmed_at
+		FROM payment_intents WHERE id = $1 AND client_token_hash = $2
+	`
+
+	return scanPaymentIntent(r.db.QueryRow(ctx, query, id, HashAPIKey(clientToken)))
+}
+
+// NextAttempt moves the intent on from a declined confirmation, unless another request
+// already has
+func (r *PaymentIntentRepository) NextAttempt(ctx context.Context, intent *domain.PaymentIntent) error {
+	query := `
+		UPDATE payment_intents SET attempts = attempts + 1
+		WHERE id = $1 AND merchant_id = $2 AND attempts = $3 AND status = $4
+	`
+
+	_, err := r.db.Exec(ctx, query, intent.ID, MerchantFromContext(ctx), intent.Attempts, domain.IntentRequiresConfirmation)
+	if err != nil {
+		return fmt.Errorf("failed to advance payment intent: %w", err)
+	}
+
+	return nil
+}
+
+// Confirm saves the intent's confirmation. Confirming an intent twice records the
+// first payment only; both requests carry the same payment, as they share its
+// idempotency key.
+func (r *PaymentIntentRepository) Confirm(ctx context.Context, intent *domain.PaymentIntent) error {
+	query := `
+		UPDATE payment_intents SET status = $1, payment_id = $2, confirmed_at = $3
+		WHERE id = $4 AND merchant_id = $5 AND status = $6
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		intent.Status,
+		intent.PaymentID,
+		intent.ConfirmedAt,
+		intent.ID,
+		MerchantFromContext(ctx),
+		domain.IntentRequiresConfirmation,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to confirm payment intent: %w", err)
+	}
+
+	return nil
+}
+
+func scanPaymentIntent(row pgx.Row) (*domain.PaymentIntent, error) {
+	var i domain.PaymentIntent
+	err := row.Scan(
+		&i.ID, &i.MerchantID, &i.OrderID, &i.CustomerID, &i.AmountCents, &i.Currency, &i.Status, &i.ClientTokenHash,
+		&i.Attempts, &i.PaymentID, &i.CreatedAt, &i.ExpiresAt, &i.ConfirmedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentIntentNotFound
+		}
+		return nil, fmt.Errorf("failed to scan payment intent: %w", err)
+	}
+	return &i, nil
+}
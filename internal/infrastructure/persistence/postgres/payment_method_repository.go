@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrPaymentMethodNotFound = errors.New("payment method not found")
+
+type PaymentMethodRepository struct {
+	db *DB
+}
+
+func NewPaymentMethodRepository(db *DB) *PaymentMethodRepository {
+	return &PaymentMethodRepository{db: db}
+}
+
+// StaleCiphertext is a value sealed with a key other than the vault's primary one
+type StaleCiphertext struct {
+	ID         string
+	MerchantID string
+	Sealed     vault.Sealed
+}
+
+// Create stores a payment method of the merchant in ctx with its card number already
+// encrypted by the vault
+func (r *PaymentMethodRepository) Create(ctx context.Context, pm *domain.PaymentMethod, card vault.Sealed) error {
+	if err := pci.Guard(pm); err != nil {
+		return fmt.Errorf("refusing to store payment method: %w", err)
+	}
+
+	query := `
+		INSERT INTO payment_methods (
+			id, merchant_id, customer_id, card_number_ciphertext, card_number_key_id, last4, expiry_month, expiry_year, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		pm.ID,
+		MerchantFromContext(ctx),
+		pm.CustomerID,
+		card.Ciphertext,
+		card.KeyID,
+		pm.Last4,
+		pm.ExpiryMonth,
+		pm.ExpiryYear,
+		pm.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create payment method: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PaymentMethodRepository) FindByID(ctx context.Context, id string) (*domain.PaymentMethod, error) {
+	query := `
+		SELECT id, customer_id, last4, expiry_month, expiry_year, created_at
+		FROM payment_methods WHERE id = $1 AND merchant_id = $2
+	`
+
+	var pm domain.PaymentMethod
+	err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(
+		&pm.ID, &pm.CustomerID, &pm.Last4, &pm.ExpiryMonth, &pm.ExpiryYear, &pm.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPaymentMethodNotFound
+		}
+		return nil, fmt.Errorf("failed to scan payment method: %w", err)
+	}
+
+	return &pm, nil
+}
+
+// FindCardCiphertext returns the encrypted card number of a payment method
+func (r *PaymentMethodRepository) FindCardCiphertext(ctx context.Context, id string) (vault.Sealed, error) {
+	query := `SELECT card_number_ciphertext, card_number_key_id FROM payment_methods WHERE id = $1 AND merchant_id = $2`
+
+	var card vault.Sealed
+	if err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(&card.Ciphertext, &card.KeyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault.Sealed{}, ErrPaymentMethodNotFound
+		}
+		return vault.Sealed{}, fmt.Errorf("failed to scan payment method card: %w", err)
+	}
+
+	return card, nil
+}
+
+// ReplaceCardCiphertext stores card re-sealed as resealed, unless the card was
+// re-sealed by someone else since it was read. It reports whether it was replaced.
+func (r *PaymentMethodRepository) ReplaceCardCiphertext(ctx context.Context, id string, card, resealed vault.Sealed) (bool, error) {
+	query := `
+		UPDATE payment_methods SET card_number_ciphertext = $1, card_number_key_id = $2
+		WHERE id = $3 AND merchant_id = $4 AND card_number_key_id = $5
+	`
+
+	tag, err := r.db.Exec(ctx, query, resealed.Ciphertext, resealed.KeyID, id, MerchantFromContext(ctx), card.KeyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace payment method card: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindStaleCards returns up to limit cards of all merchants sealed with a key other
+// than primaryKeyID. Cards emptied by an erasure have nothing left to re-seal.
+func (r *PaymentMethodRepository) FindStaleCards(ctx context.Context, primaryKeyID string, limit int) ([]StaleCiphertext, error) {
+	query := `
+		SELECT id, merchant_id, card_number_ciphertext, card_number_key_id
+		FROM payment_methods
+		WHERE card_number_key_id <> $1 AND octet_length(card_number_ciphertext) > 0
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, primaryKeyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale cards: %w", err)
+	}
+	return collectStaleCiphertexts(rows)
+}
+
+func collectStaleCiphertexts(rows pgx.Rows) ([]StaleCiphertext, error) {
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (StaleCiphertext, error) {
+		var s StaleCiphertext
+		err := row.Scan(&s.ID, &s.MerchantID, &s.Sealed.Ciphertext, &s.Sealed.KeyID)
+		return s, err
+	})
+}
@@ -0,0 +1,176 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+)
+
+// PaymentReadModelRepository reads and refreshes payment_read_model, the copy of each
+// payment that customer listings and summaries are served from. The copy follows the
+// payment as the outbox delivers its transitions, so it can trail the payment by the
+// outbox's lag, and longer while delivery is paused.
+type PaymentReadModelRepository struct {
+	db *DB
+}
+
+func NewPaymentReadModelRepository(db *DB) *PaymentReadModelRepository {
+	return &PaymentReadModelRepository{db: db}
+}
+
+// Refresh copies the payment as it is now into the read model. A copy taken before a
+// later status change is kept from overwriting one taken after it, so refreshes of
+// the same payment may run in any order.
+func (r *PaymentReadModelRepository) Refresh(ctx context.Context, paymentID string) error {
+	query := `
+		INSERT INTO payment_read_model (
+			id, merchant_id, order_id, customer_id, amount_cents, currency, status,
+			bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+			created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at, first_captured_at,
+			attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+			payment_method_id, card_last4, card_brand, last_error_category, decline_category, group_id, group_part,
+			released_amount_cents, as_of
+		)
+		SELECT id, merchant_id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at, first_captured_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, card_last4, card_brand, last_error_category, decline_category, group_id, group_part,
+		       released_amount_cents, COALESCE(status_changed_at, created_at)
+		FROM payments
+		WHERE id = $1 AND merchant_id = $2
+		ON CONFLICT (id) DO UPDATE SET
+			customer_id = EXCLUDED.customer_id, status = EXCLUDED.status,
+			bank_auth_id = EXCLUDED.bank_auth_id, bank_capture_id = EXCLUDED.bank_capture_id,
+			bank_void_id = EXCLUDED.bank_void_id, bank_refund_id = EXCLUDED.bank_refund_id,
+			authorized_at = EXCLUDED.authorized_at, captured_at = EXCLUDED.captured_at,
+			voided_at = EXCLUDED.voided_at, refunded_at = EXCLUDED.refunded_at,
+			expires_at = EXCLUDED.expires_at, first_captured_at = EXCLUDED.first_captured_at,
+			attempt_count = EXCLUDED.attempt_count, next_retry_at = EXCLUDED.next_retry_at,
+			captured_amount_cents = EXCLUDED.captured_amount_cents,
+			refunded_amount_cents = EXCLUDED.refunded_amount_cents,
+			acquirer = EXCLUDED.acquirer, failure_reason = EXCLUDED.failure_reason,
+			payment_method_id = EXCLUDED.payment_method_id,
+			card_last4 = EXCLUDED.card_last4, card_brand = EXCLUDED.card_brand,
+			last_error_category = EXCLUDED.last_error_category, decline_category = EXCLUDED.decline_category,
+			released_amount_cents = EXCLUDED.released_amount_cents,
+			as_of = EXCLUDED.as_of, refreshed_at = NOW()
+		WHERE payment_read_model.as_of <= EXCLUDED.as_of
+	`
+
+	if _, err := r.db.Exec(ctx, query, paymentID, MerchantFromContext(ctx)); err != nil {
+		return fmt.Errorf("refresh payment read model: %w", err)
+	}
+	return nil
+}
+
+// FindByCustomerID retrieves a customer's payments that match filter, newest first.
+// The payments come without their card fingerprints, which the read model leaves out.
+func (r *PaymentReadModelRepository) FindByCustomerID(
+	ctx context.Context,
+	customerID string,
+	filter domain.CustomerPaymentFilter,
+	limit, offset int,
+) ([]*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, NULL::text, first_captured_at, decline_category, released_amount_cents
+		FROM payment_read_model
+		WHERE customer_id = $1 AND merchant_id = $2
+		  AND ($3::text[] IS NULL OR status = ANY($3))
+		  AND ($4::timestamptz IS NULL OR created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR created_at < $5)
+		ORDER BY created_at DESC
+		LIMIT $6 OFFSET $7
+	`
+
+	var from, to *time.Time
+	if !filter.From.IsZero() {
+		from = &filter.From
+	}
+	if !filter.To.IsZero() {
+		to = &filter.To
+	}
+
+	rows, err := r.db.Query(ctx, query, customerID, MerchantFromContext(ctx), statusStrings(filter.Statuses), from, to, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("query payment read model by customer_id: %w", err)
+	}
+	return scanPayments(rows)
+}
+
+// CustomerPaymentSummary totals all payments of a customer
+type CustomerPaymentSummary struct {
+	PaymentCount  int
+	StatusCounts  map[domain.PaymentStatus]int
+	Totals        map[string]CurrencyTotal
+	LastPaymentAt *time.Time
+}
+
+// CurrencyTotal sums a customer's payments in one currency. Authorized counts every
+// payment the bank authorized, whatever happened to it since.
+type CurrencyTotal struct {
+	Authorized int64
+	Captured   int64
+	Refunded   int64
+}
+
+// SummarizeCustomer totals a customer's payments by status and currency in one query
+func (r *PaymentReadModelRepository) SummarizeCustomer(ctx context.Context, customerID string) (*CustomerPaymentSummary, error) {
+	query := `
+		SELECT status, currency, COUNT(*),
+		       SUM(amount_cents)::bigint, SUM(captured_amount_cents)::bigint, SUM(refunded_amount_cents)::bigint,
+		       MAX(created_at)
+		FROM payment_read_model
+		WHERE customer_id = $1 AND merchant_id = $2
+		GROUP BY status, currency
+	`
+
+	rows, err := r.db.Query(ctx, query, customerID, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("summarize customer payments: %w", err)
+	}
+	defer rows.Close()
+
+	summary := &CustomerPaymentSummary{
+		StatusCounts: map[domain.PaymentStatus]int{},
+		Totals:       map[string]CurrencyTotal{},
+	}
+	for rows.Next() {
+		var (
+			status                     domain.PaymentStatus
+			currency                   string
+			count                      int
+			amount, captured, refunded int64
+			lastCreatedAt              time.Time
+		)
+		if err := rows.Scan(&status, &currency, &count, &amount, &captured, &refunded, &lastCreatedAt); err != nil {
+			return nil, fmt.Errorf("scan customer payment summary: %w", err)
+		}
+
+		summary.PaymentCount += count
+		summary.StatusCounts[status] += count
+		total := summary.Totals[currency]
+		switch status {
+		case domain.StatusScheduled, domain.StatusPending, domain.StatusReview, domain.StatusFailed:
+		default:
+			total.Authorized += amount
+		}
+		total.Captured += captured
+		total.Refunded += refunded
+		summary.Totals[currency] = total
+		if summary.LastPaymentAt == nil || lastCreatedAt.After(*summary.LastPaymentAt) {
+			summary.LastPaymentAt = &lastCreatedAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("summarize customer payments: %w", err)
+	}
+	return summary, nil
+}
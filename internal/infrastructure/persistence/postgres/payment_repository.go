@@ -7,31 +7,102 @@ import (
 	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/pci"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 )
 
 var ErrPaymentNotFound = errors.New("payment not found")
 
+// ErrOrderAlreadyPaid is returned by Create, while order uniqueness is enforced, for an
+// order that already has a payment that has not failed
+var ErrOrderAlreadyPaid = errors.New("order already has a payment that has not failed")
+
+// ErrPaymentTerminal is returned by Update for a change to the status or amounts of a
+// payment stored as FAILED, VOIDED or REFUNDED, which the database refuses
+var ErrPaymentTerminal = errors.New("payment is in a terminal state")
+
+// PaymentCache keeps copies of payments for LookupByID and LookupByOrderID
+type PaymentCache interface {
+	ByID(ctx context.Context, merchantID, id string) (*domain.Payment, bool)
+	ByOrderID(ctx context.Context, merchantID, orderID string) (*domain.Payment, bool)
+	StoreByID(ctx context.Context, payment *domain.Payment)
+	StoreByOrderID(ctx context.Context, payment *domain.Payment)
+	Invalidate(ctx context.Context, merchantID, id, orderID string)
+}
+
 type PaymentRepository struct {
-	db *DB
+	db           *DB
+	cache        PaymentCache
+	uniqueOrders bool
+	fence        RegionFence
 }
 
 func NewPaymentRepository(db *DB) *PaymentRepository {
 	return &PaymentRepository{db: db}
 }
 
+// WithCache serves LookupByID and LookupByOrderID from cache, dropping a payment's
+// copies whenever its creation or an update of it commits
+func (r *PaymentRepository) WithCache(cache PaymentCache) *PaymentRepository {
+	r.cache = cache
+	return r
+}
+
+// WithUniqueOrders makes Create refuse a second payment for an order unless every
+// earlier one created this way has failed. The database enforces it, so it holds across
+// gateway instances and idempotency keys.
+func (r *PaymentRepository) WithUniqueOrders() *PaymentRepository {
+	r.uniqueOrders = true
+	return r
+}
+
+// WithRegionFence records the epoch of fence on every payment written, and refuses
+// writes while the region is on standby or the payment was written under a later epoch
+func (r *PaymentRepository) WithRegionFence(fence RegionFence) *PaymentRepository {
+	r.fence = fence
+	return r
+}
+
+// epoch returns the region epoch to write under, zero without a fence
+func (r *PaymentRepository) epoch(ctx context.Context) (int64, error) {
+	if r.fence == nil {
+		return 0, nil
+	}
+	return r.fence.Epoch(ctx)
+}
+
+// Create stores the payment for the merchant in ctx
 func (r *PaymentRepository) Create(ctx context.Context, tx pgx.Tx, payment *domain.Payment) error {
+	payment.MerchantID = MerchantFromContext(ctx)
+
+	if err := pci.Guard(payment); err != nil {
+		return fmt.Errorf("refusing to store payment: %w", err)
+	}
+
+	epoch, err := r.epoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The outbox row is written by the same statement, so the event exists if and only
+	// if the payment does
 	query := `
-		INSERT INTO payments (
-            id, order_id, customer_id, amount_cents, currency, status,
-            bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
-            created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
-			attempt_count, next_retry_at
-        ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		WITH created AS (
+			INSERT INTO payments (
+				id, order_id, customer_id, amount_cents, currency, status,
+				bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+				created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+				attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+				payment_method_id, merchant_id, unique_order, region_epoch, group_id, group_part,
+				card_fingerprint
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $27, $28, $29, $30)
+			RETURNING *
+		)
+		` + insertOutboxEvent + `
+		FROM created AS payment, (SELECT NULL::text AS status) AS previous, (SELECT $26::text AS actor) AS origin
 	`
 
-	_, err := tx.Exec(ctx, query,
+	_, err = tx.Exec(ctx, query,
 		payment.ID,
 		payment.OrderID,
 		payment.CustomerID,
@@ -50,12 +121,29 @@ func (r *PaymentRepository) Create(ctx context.Context, tx pgx.Tx, payment *doma
 		payment.ExpiresAt,
 		payment.AttemptCount,
 		payment.NextRetryAt,
+		payment.CapturedAmountCents,
+		payment.RefundedAmountCents,
+		payment.Acquirer,
+		payment.FailureReason,
+		payment.PaymentMethodID,
+		payment.MerchantID,
+		r.uniqueOrders && payment.ClaimsOrder(),
+		ActorFromContext(ctx),
+		epoch,
+		payment.GroupID,
+		payment.GroupPart,
+		payment.CardFingerprint,
 	)
 
 	if err != nil {
+		if isUniqueViolationOf(err, "idx_payments_unique_order") {
+			return ErrOrderAlreadyPaid
+		}
 		return fmt.Errorf("failed to create payment: %w", err)
 	}
 
+	// A new payment for an order replaces the one cached for it
+	r.invalidate(ctx, tx, payment)
 	return nil
 }
 
@@ -65,11 +153,13 @@ func (r *PaymentRepository) FindByID(ctx context.Context, id string) (*domain.Pa
 		SELECT id, order_id, customer_id, amount_cents, currency, status,
 		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
 		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
-			   attempt_count, next_retry_at
-		FROM payments WHERE id = $1
+			   attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+			   payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments WHERE id = $1 AND merchant_id = $2
 	`
 
-	row := r.db.QueryRow(ctx, query, id)
+	row := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx))
 	return scanPayment(row)
 }
 
@@ -79,56 +169,284 @@ func (r *PaymentRepository) FindByIDForUpdate(ctx context.Context, tx pgx.Tx, id
 		SELECT id, order_id, customer_id, amount_cents, currency, status,
 		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
 		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
-			   attempt_count, next_retry_at
-		FROM payments WHERE id = $1
+			   attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+			   payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments WHERE id = $1 AND merchant_id = $2
+		FOR UPDATE
+	`
+
+	row := tx.QueryRow(ctx, query, id, MerchantFromContext(ctx))
+	return scanPayment(row)
+}
+
+// FindByBankAuthIDForUpdate retrieves the payment holding a bank authorization with
+// row-level lock
+func (r *PaymentRepository) FindByBankAuthIDForUpdate(ctx context.Context, tx pgx.Tx, bankAuthID string) (*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments WHERE bank_auth_id = $1 AND merchant_id = $2
 		FOR UPDATE
 	`
 
-	row := tx.QueryRow(ctx, query, id)
+	row := tx.QueryRow(ctx, query, bankAuthID, MerchantFromContext(ctx))
 	return scanPayment(row)
 }
 
-// FindByOrderID retrieves a payment by order
+// FindByIDs retrieves the merchant's payments among ids, newest first. IDs with no
+// payment are skipped.
+func (r *PaymentRepository) FindByIDs(ctx context.Context, ids []string) ([]*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments WHERE id = ANY($1) AND merchant_id = $2
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(ctx, query, ids, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("query payments by ids: %w", err)
+	}
+	return scanPayments(rows)
+}
+
+// FindByOrderID retrieves an order's payment, the first if it has several, such as
+// declined ones before it. Of a payment group only the first part is the order's
+// payment, as for the one payment per order rule; the rest share its order ID.
 func (r *PaymentRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Payment, error) {
 	query := `
 		SELECT id, order_id, customer_id, amount_cents, currency, status,
 		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
 		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
-			   attempt_count, next_retry_at
-		FROM payments WHERE order_id = $1
+			   attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+			   payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments
+		WHERE order_id = $1 AND merchant_id = $2 AND (group_part IS NULL OR group_part = 1)
+		ORDER BY created_at, id
+		LIMIT 1
+	`
+
+	row := r.db.QueryRow(ctx, query, orderID, MerchantFromContext(ctx))
+	return scanPayment(row)
+}
+
+// FindByGroupID retrieves the parts of a payment group in order
+func (r *PaymentRepository) FindByGroupID(ctx context.Context, groupID string) ([]*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments WHERE group_id = $1 AND merchant_id = $2
+		ORDER BY group_part ASC
 	`
 
-	row := r.db.QueryRow(ctx, query, orderID)
+	rows, err := r.db.Query(ctx, query, groupID, MerchantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("query payments by group: %w", err)
+	}
+	return scanPayments(rows)
+}
+
+// FindDuplicate retrieves the newest payment created since since for the same order,
+// customer and amount. Failed payments are not duplicates: the customer may try again.
+func (r *PaymentRepository) FindDuplicate(ctx context.Context, orderID, customerID string, amount int64, since time.Time) (*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments
+		WHERE merchant_id = $1 AND order_id = $2 AND customer_id = $3 AND amount_cents = $4
+		  AND created_at >= $5 AND status <> 'FAILED'
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+
+	row := r.db.QueryRow(ctx, query, MerchantFromContext(ctx), orderID, customerID, amount, since)
 	return scanPayment(row)
+}
+
+// CountCardUse counts the payments made with the card of fingerprint since since, and
+// the customers other than customerID they were made for. Failed payments count: a
+// card tried over and over is what the count is meant to catch.
+func (r *PaymentRepository) CountCardUse(ctx context.Context, fingerprint, customerID string, since time.Time) (payments, otherCustomers int, err error) {
+	query := `
+		SELECT count(*), count(DISTINCT customer_id) FILTER (WHERE customer_id <> $3)
+		FROM payments
+		WHERE merchant_id = $1 AND card_fingerprint = $2 AND created_at >= $4
+	`
+
+	err = r.db.QueryRow(ctx, query, MerchantFromContext(ctx), fingerprint, customerID, since).Scan(&payments, &otherCustomers)
+	if err != nil {
+		return 0, 0, fmt.Errorf("count card use: %w", err)
+	}
+	return payments, otherCustomers, nil
+}
 
+// FindByIdempotencyKey retrieves the payment an idempotency key was used for
+func (r *PaymentRepository) FindByIdempotencyKey(ctx context.Context, idempotencyKey string) (*domain.Payment, error) {
+	query := `
+		SELECT p.id, p.order_id, p.customer_id, p.amount_cents, p.currency, p.status,
+		       p.bank_auth_id, p.bank_capture_id, p.bank_void_id, p.bank_refund_id,
+		       p.created_at, p.authorized_at, p.captured_at, p.voided_at, p.refunded_at, p.expires_at,
+		       p.attempt_count, p.next_retry_at, p.captured_amount_cents, p.refunded_amount_cents, p.acquirer, p.failure_reason,
+		       p.payment_method_id, p.merchant_id, p.card_last4, p.card_brand, p.last_error_category,
+		       p.group_id, p.group_part, p.card_fingerprint, p.first_captured_at, p.decline_category, p.released_amount_cents
+		FROM payments p
+		JOIN idempotency_keys i ON i.payment_id = p.id AND i.merchant_id = p.merchant_id
+		WHERE i.key = $1 AND i.merchant_id = $2
+	`
+
+	row := r.db.QueryRow(ctx, query, idempotencyKey, MerchantFromContext(ctx))
+	return scanPayment(row)
 }
 
-// FindByCustomerID retrieves a payment for a customer
-func (r *PaymentRepository) FindByCustomerID(ctx context.Context, customerID string, limit, offset int) ([]*domain.Payment, error) {
+// FindByCustomerID retrieves a customer's payments that match filter, newest first
+func (r *PaymentRepository) FindByCustomerID(
+	ctx context.Context,
+	customerID string,
+	filter domain.CustomerPaymentFilter,
+	limit, offset int,
+) ([]*domain.Payment, error) {
 	query := `
 		SELECT id, order_id, customer_id, amount_cents, currency, status,
 		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
 		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
-			   attempt_count, next_retry_at
-		FROM payments WHERE customer_id = $1
+			   attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+			   payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments
+		WHERE customer_id = $1 AND merchant_id = $2
+		  AND ($3::text[] IS NULL OR status = ANY($3))
+		  AND ($4::timestamptz IS NULL OR created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR created_at < $5)
 		ORDER BY created_at DESC
-		LIMIT $2 OFFSET $3
+		LIMIT $6 OFFSET $7
 	`
 
-	rows, err := r.db.Query(ctx, query, customerID, limit, offset)
+	var from, to *time.Time
+	if !filter.From.IsZero() {
+		from = &filter.From
+	}
+	if !filter.To.IsZero() {
+		to = &filter.To
+	}
+
+	rows, err := r.db.Query(ctx, query, customerID, MerchantFromContext(ctx), statusStrings(filter.Statuses), from, to, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("query payments by customer_id: %w", err)
 	}
 	return scanPayments(rows)
 }
 
-// FindExpiredAuthorizations finds AUTHORIZED payments older than the cutoff time
+// ClaimForReconciliation leases up to limit payments of the merchant in ctx created in
+// [from, to) that hold a bank authorization and are in one of statuses, oldest first,
+// for lease. Payments another run holds a lease on, and those locked by a change in
+// progress, are skipped, so overlapping runs never check the same payment at once.
+// ReleaseReconciliation hands them back.
+func (r *PaymentRepository) ClaimForReconciliation(
+	ctx context.Context,
+	from, to time.Time,
+	statuses []domain.PaymentStatus,
+	limit int,
+	lease time.Duration,
+) ([]*domain.Payment, error) {
+	// The lease insert only wins over an expired lease, so a run that read the lease
+	// table before another committed its claim still cannot take the same payment
+	query := `
+		WITH candidates AS (
+			SELECT p.id
+			FROM payments p
+			LEFT JOIN reconciliation_leases l ON l.payment_id = p.id
+			WHERE p.merchant_id = $1 AND p.created_at >= $2 AND p.created_at < $3
+			  AND p.bank_auth_id IS NOT NULL AND p.status = ANY($4)
+			  AND (l.leased_until IS NULL OR l.leased_until < NOW())
+			ORDER BY p.created_at ASC, p.id ASC
+			LIMIT $5
+			FOR UPDATE OF p SKIP LOCKED
+		), leased AS (
+			INSERT INTO reconciliation_leases (payment_id, leased_until)
+			SELECT id, NOW() + $6::interval FROM candidates
+			ON CONFLICT (payment_id) DO UPDATE SET leased_until = EXCLUDED.leased_until
+			WHERE reconciliation_leases.leased_until < NOW()
+			RETURNING payment_id
+		)
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments
+		WHERE id IN (SELECT payment_id FROM leased)
+		ORDER BY created_at ASC, id ASC
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), from, to, statusStrings(statuses), limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("claim payments for reconciliation: %w", err)
+	}
+	return scanPayments(rows)
+}
+
+// ReleaseReconciliation drops the reconciliation leases on the payments
+func (r *PaymentRepository) ReleaseReconciliation(ctx context.Context, paymentIDs []string) error {
+	query := `DELETE FROM reconciliation_leases WHERE payment_id = ANY($1::uuid[])`
+
+	if _, err := r.db.Exec(ctx, query, paymentIDs); err != nil {
+		return fmt.Errorf("release reconciliation leases: %w", err)
+	}
+	return nil
+}
+
+// FindLongestInStatus retrieves up to limit payments of the merchant in ctx in status,
+// those that entered it first first
+func (r *PaymentRepository) FindLongestInStatus(ctx context.Context, status domain.PaymentStatus, limit int) ([]*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments
+		WHERE merchant_id = $1 AND status = $2
+		ORDER BY status_changed_at ASC, id ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), status, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query payments by status: %w", err)
+	}
+	return scanPayments(rows)
+}
+
+// FindExpiredAuthorizations finds AUTHORIZED payments older than the cutoff time. It
+// spans all merchants, for the expiration worker.
 func (r *PaymentRepository) FindExpiredAuthorizations(ctx context.Context, cutoffTime time.Time, limit int) ([]*domain.Payment, error) {
 	query := `
 		SELECT id, order_id, customer_id, amount_cents, currency, status,
 		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
 		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
-		       attempt_count, next_retry_at
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
 		FROM payments
 		WHERE status = 'AUTHORIZED'
 		  AND authorized_at < $1
@@ -136,30 +454,195 @@ func (r *PaymentRepository) FindExpiredAuthorizations(ctx context.Context, cutof
 		LIMIT $2
 	`
 
-	rows, err := r.db.Query(ctx, query, cutoffTime, limit)
+	rows, err := r.db.Query(AcrossMerchants(ctx), query, cutoffTime, limit)
 	if err != nil {
 		return nil, fmt.Errorf("query expired authorizations: %w", err)
 	}
 	return scanPayments(rows)
 }
 
+// StuckPayment is a payment that has been in a processing status since Since
+type StuckPayment struct {
+	ID         string
+	MerchantID string
+	OrderID    string
+	Amount     domain.Money
+	Status     domain.PaymentStatus
+	Since      time.Time
+}
+
+// FindStuck finds up to limit payments of all merchants that entered a processing
+// status before cutoff and are still in it, longest stuck first
+func (r *PaymentRepository) FindStuck(ctx context.Context, cutoff time.Time, limit int) ([]StuckPayment, error) {
+	query := `
+		SELECT id, merchant_id, order_id, amount_cents, currency, status, status_changed_at
+		FROM payments
+		WHERE status IN ('CAPTURING', 'VOIDING', 'REFUNDING', 'REAUTHORIZING')
+		  AND status_changed_at < $1
+		ORDER BY status_changed_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(AcrossMerchants(ctx), query, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stuck payments: %w", err)
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (StuckPayment, error) {
+		var p StuckPayment
+		err := row.Scan(&p.ID, &p.MerchantID, &p.OrderID, &p.Amount.Amount, &p.Amount.Currency, &p.Status, &p.Since)
+		return p, err
+	})
+}
+
+// FindExpiringAuthorizations finds up to limit AUTHORIZED payments of the merchant in
+// ctx whose authorization lapses before cutoff, soonest first. Those already past
+// expires_at are included until the expiration worker marks them EXPIRED.
+func (r *PaymentRepository) FindExpiringAuthorizations(ctx context.Context, cutoff time.Time, limit int) ([]*domain.Payment, error) {
+	query := `
+		SELECT id, order_id, customer_id, amount_cents, currency, status,
+		       bank_auth_id, bank_capture_id, bank_void_id, bank_refund_id,
+		       created_at, authorized_at, captured_at, voided_at, refunded_at, expires_at,
+		       attempt_count, next_retry_at, captured_amount_cents, refunded_amount_cents, acquirer, failure_reason,
+		       payment_method_id, merchant_id, card_last4, card_brand, last_error_category,
+		       group_id, group_part, card_fingerprint, first_captured_at, decline_category, released_amount_cents
+		FROM payments
+		WHERE status = 'AUTHORIZED' AND merchant_id = $1
+		  AND expires_at < $2
+		ORDER BY expires_at ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query expiring authorizations: %w", err)
+	}
+	return scanPayments(rows)
+}
+
+// ExpiringAuthorization is an AUTHORIZED payment whose authorization lapses at ExpiresAt
+type ExpiringAuthorization struct {
+	ID           string
+	MerchantID   string
+	OrderID      string
+	Amount       domain.Money
+	AuthorizedAt time.Time
+	ExpiresAt    time.Time
+}
+
+// FindExpiringAcrossMerchants finds up to limit AUTHORIZED payments of all merchants
+// whose authorization lapses between now and cutoff, soonest first, for expiry alerts
+func (r *PaymentRepository) FindExpiringAcrossMerchants(ctx context.Context, now, cutoff time.Time, limit int) ([]ExpiringAuthorization, error) {
+	query := `
+		SELECT id, merchant_id, order_id, amount_cents, currency, authorized_at, expires_at
+		FROM payments
+		WHERE status = 'AUTHORIZED'
+		  AND expires_at >= $1 AND expires_at < $2
+		ORDER BY expires_at ASC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(AcrossMerchants(ctx), query, now, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query expiring authorizations: %w", err)
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (ExpiringAuthorization, error) {
+		var a ExpiringAuthorization
+		err := row.Scan(&a.ID, &a.MerchantID, &a.OrderID, &a.Amount.Amount, &a.Amount.Currency, &a.AuthorizedAt, &a.ExpiresAt)
+		return a, err
+	})
+}
+
+// CountExpiring counts the AUTHORIZED payments of all merchants whose authorization
+// lapses before cutoff
+func (r *PaymentRepository) CountExpiring(ctx context.Context, cutoff time.Time) (int, error) {
+	var n int
+	err := r.db.QueryRow(AcrossMerchants(ctx), `SELECT count(*) FROM payments WHERE status = 'AUTHORIZED' AND expires_at < $1`, cutoff).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("count expiring authorizations: %w", err)
+	}
+	return n, nil
+}
+
+// FindAuthorizedIDs returns the IDs of up to limit AUTHORIZED payments, oldest
+// authorization first, matching every criterion given: one of orderIDs, customerID,
+// and authorized before authorizedBefore. Empty criteria are ignored.
+func (r *PaymentRepository) FindAuthorizedIDs(
+	ctx context.Context,
+	orderIDs []string,
+	customerID string,
+	authorizedBefore time.Time,
+	limit int,
+) ([]string, error) {
+	query := `
+		SELECT id
+		FROM payments
+		WHERE status = 'AUTHORIZED'
+		  AND merchant_id = $1
+		  AND ($2::text[] IS NULL OR order_id = ANY($2))
+		  AND ($3 = '' OR customer_id = $3)
+		  AND ($4::timestamptz IS NULL OR authorized_at < $4)
+		ORDER BY authorized_at ASC
+		LIMIT $5
+	`
+
+	var before *time.Time
+	if !authorizedBefore.IsZero() {
+		before = &authorizedBefore
+	}
+	if len(orderIDs) == 0 {
+		orderIDs = nil
+	}
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), orderIDs, customerID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query authorized payments: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
 func (r *PaymentRepository) Update(ctx context.Context, tx pgx.Tx, payment *domain.Payment) error {
+	if err := pci.Guard(payment); err != nil {
+		return fmt.Errorf("refusing to store payment: %w", err)
+	}
+
+	epoch, err := r.epoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A status change writes its outbox row in the same statement as the update
 	query := `
-		UPDATE payments
-		SET status = $1,
-			bank_auth_id = $2, bank_capture_id = $3, bank_void_id = $4, bank_refund_id = $5,
-			authorized_at = $6, captured_at = $7, voided_at = $8, refunded_at = $9, expires_at = $10,
-			attempt_count = $11, next_retry_at = $12
-		WHERE id = $13
+		WITH previous AS (
+			SELECT status FROM payments WHERE id = $18 AND merchant_id = $19 FOR UPDATE
+		), updated AS (
+			UPDATE payments
+			SET status = $1,
+				bank_auth_id = $2, bank_capture_id = $3, bank_void_id = $4, bank_refund_id = $5,
+				authorized_at = $6, captured_at = $7, voided_at = $8, refunded_at = $9, expires_at = $10,
+				attempt_count = $11, next_retry_at = $12, captured_amount_cents = $13,
+				refunded_amount_cents = $14, acquirer = $15, failure_reason = $16,
+				payment_method_id = $17, card_last4 = $21, card_brand = $22, region_epoch = $23,
+				last_error_category = $24, first_captured_at = $25, decline_category = $26,
+				released_amount_cents = $27,
+				status_changed_at = CASE WHEN status IS DISTINCT FROM $1 THEN NOW() ELSE status_changed_at END
+			WHERE id = $18 AND merchant_id = $19 AND region_epoch <= $23
+			RETURNING *
+		), event AS (
+			` + insertOutboxEvent + `
+			FROM updated AS payment, previous, (SELECT $20::text AS actor) AS origin
+			WHERE previous.status IS DISTINCT FROM payment.status
+		)
+		SELECT (SELECT COUNT(*) FROM updated), (SELECT COUNT(*) FROM previous)
 	`
 	var q interface {
-		Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+		QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	} = r.db
 	if tx != nil {
 		q = tx
 	}
 
-	results, err := q.Exec(ctx, query,
+	var rowsAffected, rowsFound int
+	err = q.QueryRow(ctx, query,
 		payment.Status,
 		payment.BankAuthID,
 		payment.BankCaptureID,
@@ -172,21 +655,89 @@ func (r *PaymentRepository) Update(ctx context.Context, tx pgx.Tx, payment *doma
 		payment.ExpiresAt,
 		payment.AttemptCount,
 		payment.NextRetryAt,
+		payment.CapturedAmountCents,
+		payment.RefundedAmountCents,
+		payment.Acquirer,
+		payment.FailureReason,
+		payment.PaymentMethodID,
 		payment.ID,
-	)
+		MerchantFromContext(ctx),
+		ActorFromContext(ctx),
+		payment.CardLast4,
+		payment.CardBrand,
+		epoch,
+		payment.LastErrorCategory,
+		payment.FirstCapturedAt,
+		payment.DeclineCategory,
+		payment.ReleasedAmountCents,
+	).Scan(&rowsAffected, &rowsFound)
 
 	if err != nil {
+		if isCheckViolationOf(err, "payments_terminal_immutable") {
+			return fmt.Errorf("%w: %s is already closed", ErrPaymentTerminal, payment.ID)
+		}
 		return fmt.Errorf("failed to update payment status: %w", err)
 	}
 
-	rowsAffected := results.RowsAffected()
-	if rowsAffected == 0 {
+	if rowsFound == 0 {
 		return ErrPaymentNotFound
 	}
+	if rowsAffected == 0 {
+		return domain.ErrStaleRegionEpoch
+	}
 
+	r.invalidate(ctx, tx, payment)
 	return nil
 }
 
+// LookupByID is FindByID for callers that only show the payment, such as a storefront
+// polling its status. With a cache, a change is seen once it commits.
+func (r *PaymentRepository) LookupByID(ctx context.Context, id string) (*domain.Payment, error) {
+	if r.cache == nil {
+		return r.FindByID(ctx, id)
+	}
+	if payment, ok := r.cache.ByID(ctx, MerchantFromContext(ctx), id); ok {
+		return payment, nil
+	}
+
+	payment, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.StoreByID(ctx, payment)
+	return payment, nil
+}
+
+// LookupByOrderID is FindByOrderID for callers that only show the payment, cached like
+// LookupByID
+func (r *PaymentRepository) LookupByOrderID(ctx context.Context, orderID string) (*domain.Payment, error) {
+	if r.cache == nil {
+		return r.FindByOrderID(ctx, orderID)
+	}
+	if payment, ok := r.cache.ByOrderID(ctx, MerchantFromContext(ctx), orderID); ok {
+		return payment, nil
+	}
+
+	payment, err := r.FindByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.StoreByOrderID(ctx, payment)
+	return payment, nil
+}
+
+// invalidate drops the cached copies of payment once tx commits. Dropping them
+// before would let a lookup in between cache the row as it was until then.
+func (r *PaymentRepository) invalidate(ctx context.Context, tx pgx.Tx, payment *domain.Payment) {
+	if r.cache == nil {
+		return
+	}
+	merchantID := MerchantFromContext(ctx)
+	AfterCommit(tx, func() {
+		r.cache.Invalidate(ctx, merchantID, payment.ID, payment.OrderID)
+	})
+}
+
 // scanPayment converts a database row into a domain Payment.
 // Returns ErrPaymentNotFound if the row doesn't exist.
 func scanPayment(row pgx.Row) (*domain.Payment, error) {
@@ -195,7 +746,10 @@ func scanPayment(row pgx.Row) (*domain.Payment, error) {
 		&p.ID, &p.OrderID, &p.CustomerID, &p.AmountCents, &p.Currency, &p.Status,
 		&p.BankAuthID, &p.BankCaptureID, &p.BankVoidID, &p.BankRefundID,
 		&p.CreatedAt, &p.AuthorizedAt, &p.CapturedAt, &p.VoidedAt, &p.RefundedAt, &p.ExpiresAt,
-		&p.AttemptCount, &p.NextRetryAt,
+		&p.AttemptCount, &p.NextRetryAt, &p.CapturedAmountCents, &p.RefundedAmountCents, &p.Acquirer,
+		&p.FailureReason, &p.PaymentMethodID, &p.MerchantID, &p.CardLast4, &p.CardBrand,
+		&p.LastErrorCategory, &p.GroupID, &p.GroupPart, &p.CardFingerprint, &p.FirstCapturedAt,
+		&p.DeclineCategory, &p.ReleasedAmountCents,
 	)
 
 	if err != nil {
@@ -214,7 +768,10 @@ func scanPayments(rows pgx.Rows) ([]*domain.Payment, error) {
 			&p.ID, &p.OrderID, &p.CustomerID, &p.AmountCents, &p.Currency, &p.Status,
 			&p.BankAuthID, &p.BankCaptureID, &p.BankVoidID, &p.BankRefundID,
 			&p.CreatedAt, &p.AuthorizedAt, &p.CapturedAt, &p.VoidedAt, &p.RefundedAt, &p.ExpiresAt,
-			&p.AttemptCount, &p.NextRetryAt,
+			&p.AttemptCount, &p.NextRetryAt, &p.CapturedAmountCents, &p.RefundedAmountCents, &p.Acquirer,
+			&p.FailureReason, &p.PaymentMethodID, &p.MerchantID, &p.CardLast4, &p.CardBrand,
+			&p.LastErrorCategory, &p.GroupID, &p.GroupPart, &p.CardFingerprint, &p.FirstCapturedAt,
+			&p.DeclineCategory, &p.ReleasedAmountCents,
 		)
 		return &p, err
 	})
@@ -225,3 +782,12 @@ func scanPayments(rows pgx.Rows) ([]*domain.Payment, error) {
 
 	return results, nil
 }
+
+// statusStrings converts statuses for a text[] parameter; no statuses become NULL
+func statusStrings(statuses []domain.PaymentStatus) []string {
+	var result []string
+	for _, status := range statuses {
+		result = append(result, string(status))
+	}
+	return result
+}
@@ -0,0 +1,242 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrPayoutNotFound = errors.New("payout not found")
+
+const payoutColumns = `
+	id, merchant_id, recipient_id, purpose, payment_id, amount_cents, currency, status,
+	account_last4, routing_number, bank_payout_id, failure_code,
+	created_at, paid_at, failed_at
+`
+
+type PayoutRepository struct {
+	db *DB
+}
+
+func NewPayoutRepository(db *DB) *PayoutRepository {
+	return &PayoutRepository{db: db}
+}
+
+// Create stores a payout of the merchant in ctx with its account number already
+// encrypted by the vault
+func (r *PayoutRepository) Create(ctx context.Context, tx pgx.Tx, payout *domain.Payout, account vault.Sealed) error {
+	payout.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO payouts (
+			id, merchant_id, recipient_id, purpose, payment_id, amount_cents, currency, status,
+			account_number_ciphertext, account_number_key_id, account_last4, routing_number, bank_payout_id, failure_code,
+			created_at, paid_at, failed_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+	`
+
+	_, err := tx.Exec(ctx, query,
+		payout.ID,
+		payout.MerchantID,
+		payout.RecipientID,
+		payout.Purpose,
+		payout.PaymentID,
+		payout.AmountCents,
+		payout.Currency,
+		payout.Status,
+		account.Ciphertext,
+		account.KeyID,
+		payout.AccountLast4,
+		payout.RoutingNumber,
+		payout.BankPayoutID,
+		payout.FailureCode,
+		payout.CreatedAt,
+		payout.PaidAt,
+		payout.FailedAt,
+	)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return ErrPaymentNotFound
+		}
+		return fmt.Errorf("failed to create payout: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PayoutRepository) FindByID(ctx context.Context, id string) (*domain.Payout, error) {
+	query := `SELECT ` + payoutColumns + ` FROM payouts WHERE id = $1 AND merchant_id = $2`
+	return scanPayout(r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)))
+}
+
+// FindAccountCiphertext returns the encrypted destination account number of a payout
+func (r *PayoutRepository) FindAccountCiphertext(ctx context.Context, id string) (vault.Sealed, error) {
+	query := `SELECT account_number_ciphertext, account_number_key_id FROM payouts WHERE id = $1 AND merchant_id = $2`
+
+	var account vault.Sealed
+	if err := r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)).Scan(&account.Ciphertext, &account.KeyID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault.Sealed{}, ErrPayoutNotFound
+		}
+		return vault.Sealed{}, fmt.Errorf("failed to scan payout account: %w", err)
+	}
+
+	return account, nil
+}
+
+// ReplaceAccountCiphertext stores account re-sealed as resealed, unless it was
+// re-sealed by someone else since it was read. It reports whether it was replaced.
+func (r *PayoutRepository) ReplaceAccountCiphertext(ctx context.Context, id string, account, resealed vault.Sealed) (bool, error) {
+	query := `
+		UPDATE payouts SET account_number_ciphertext = $1, account_number_key_id = $2
+		WHERE id = $3 AND merchant_id = $4 AND account_number_key_id = $5
+	`
+
+	tag, err := r.db.Exec(ctx, query, resealed.Ciphertext, resealed.KeyID, id, MerchantFromContext(ctx), account.KeyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to replace payout account: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// FindStaleAccounts returns up to limit payout accounts of all merchants sealed with a
+// key other than primaryKeyID
+func (r *PayoutRepository) FindStaleAccounts(ctx context.Context, primaryKeyID string, limit int) ([]StaleCiphertext, error) {
+	query := `
+		SELECT id, merchant_id, account_number_ciphertext, account_number_key_id
+		FROM payouts
+		WHERE account_number_key_id <> $1
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, primaryKeyID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale payout accounts: %w", err)
+	}
+	return collectStaleCiphertexts(rows)
+}
+
+// SumOpenRefunds returns the amount of the payment already being refunded or refunded
+// by payouts that have not failed
+func (r *PayoutRepository) SumOpenRefunds(ctx context.Context, tx pgx.Tx, paymentID string) (int64, error) {
+	query := `
+		SELECT COALESCE(SUM(amount_cents), 0)
+		FROM payouts
+		WHERE payment_id = $1 AND merchant_id = $2 AND status <> 'FAILED'
+	`
+
+	var total int64
+	if err := tx.QueryRow(ctx, query, paymentID, MerchantFromContext(ctx)).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to sum refund payouts: %w", err)
+	}
+	return total, nil
+}
+
+// StuckPayout is a PENDING payout whose bank call never completed, with the
+// idempotency key it was sent under
+type StuckPayout struct {
+	Payout         *domain.Payout
+	IdempotencyKey string
+}
+
+// FindStuck returns PENDING payouts of all merchants whose idempotency key has been
+// locked for longer than olderThan
+func (r *PayoutRepository) FindStuck(ctx context.Context, olderThan time.Duration, limit int) ([]StuckPayout, error) {
+	query := `
+		SELECT i.key, p.id, p.merchant_id, p.recipient_id, p.purpose, p.payment_id, p.amount_cents, p.currency, p.status,
+		       p.account_last4, p.routing_number, p.bank_payout_id, p.failure_code,
+		       p.created_at, p.paid_at, p.failed_at
+		FROM payouts p
+		JOIN idempotency_keys i ON i.payout_id = p.id AND i.merchant_id = p.merchant_id
+		WHERE p.status = 'PENDING'
+		  AND i.locked_at < NOW() - $1::interval
+		ORDER BY p.created_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(AcrossMerchants(ctx), query, olderThan, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stuck payouts: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (StuckPayout, error) {
+		var sp StuckPayout
+		var p domain.Payout
+		err := row.Scan(
+			&sp.IdempotencyKey,
+			&p.ID, &p.MerchantID, &p.RecipientID, &p.Purpose, &p.PaymentID, &p.AmountCents, &p.Currency, &p.Status,
+			&p.AccountLast4, &p.RoutingNumber, &p.BankPayoutID, &p.FailureCode,
+			&p.CreatedAt, &p.PaidAt, &p.FailedAt,
+		)
+		sp.Payout = &p
+		return sp, err
+	})
+}
+
+// FindInTransit returns payouts of all merchants the bank accepted but has not reported
+// paid, oldest first
+func (r *PayoutRepository) FindInTransit(ctx context.Context, limit int) ([]*domain.Payout, error) {
+	query := `
+		SELECT ` + payoutColumns + `
+		FROM payouts
+		WHERE status = 'IN_TRANSIT'
+		ORDER BY created_at ASC
+		LIMIT $1
+	`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query payouts in transit: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Payout, error) {
+		return scanPayout(row)
+	})
+}
+
+func (r *PayoutRepository) Update(ctx context.Context, tx pgx.Tx, payout *domain.Payout) error {
+	query := `
+		UPDATE payouts
+		SET status = $1, bank_payout_id = $2, failure_code = $3, paid_at = $4, failed_at = $5
+		WHERE id = $6 AND merchant_id = $7
+	`
+
+	tag, err := tx.Exec(ctx, query,
+		payout.Status,
+		payout.BankPayoutID,
+		payout.FailureCode,
+		payout.PaidAt,
+		payout.FailedAt,
+		payout.ID,
+		MerchantFromContext(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payout: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPayoutNotFound
+	}
+
+	return nil
+}
+
+func scanPayout(row pgx.Row) (*domain.Payout, error) {
+	var p domain.Payout
+	err := row.Scan(
+		&p.ID, &p.MerchantID, &p.RecipientID, &p.Purpose, &p.PaymentID, &p.AmountCents, &p.Currency, &p.Status,
+		&p.AccountLast4, &p.RoutingNumber, &p.BankPayoutID, &p.FailureCode,
+		&p.CreatedAt, &p.PaidAt, &p.FailedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrPayoutNotFound
+		}
+		return nil, fmt.Errorf("failed to scan payout: %w", err)
+	}
+	return &p, nil
+}
@@ -0,0 +1,95 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+type ReconciliationRepository struct {
+	db *DB
+}
+
+func NewReconciliationRepository(db *DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+// Record stores an issue for the merchant in ctx. An issue already recorded for the
+// same authorization and kind is updated instead and keeps its ID, when it was first
+// detected and when it was voided, which are filled back into issue.
+func (r *ReconciliationRepository) Record(ctx context.Context, issue *domain.ReconciliationIssue) error {
+	issue.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO reconciliation_issues (
+			id, merchant_id, kind, bank_auth_id, payment_id,
+			gateway_status, bank_status, first_detected_at, last_detected_at
+		) VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid, NULLIF($6, ''), NULLIF($7, ''), $8, $8)
+		ON CONFLICT (merchant_id, kind, bank_auth_id) DO UPDATE
+		SET payment_id = EXCLUDED.payment_id,
+		    gateway_status = EXCLUDED.gateway_status,
+		    bank_status = EXCLUDED.bank_status,
+		    last_detected_at = EXCLUDED.last_detected_at
+		RETURNING id, first_detected_at, voided_at
+	`
+
+	err := r.db.QueryRow(ctx, query,
+		issue.ID,
+		issue.MerchantID,
+		issue.Kind,
+		issue.BankAuthID,
+		issue.PaymentID,
+		issue.GatewayStatus,
+		issue.BankStatus,
+		issue.LastDetectedAt,
+	).Scan(&issue.ID, &issue.FirstDetectedAt, &issue.VoidedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record reconciliation issue: %w", err)
+	}
+	return nil
+}
+
+// MarkVoided records when the authorization of an issue of the merchant in ctx was voided
+func (r *ReconciliationRepository) MarkVoided(ctx context.Context, id string, voidedAt time.Time) error {
+	query := `
+		UPDATE reconciliation_issues
+		SET voided_at = $3
+		WHERE id = $1 AND merchant_id = $2
+	`
+
+	if _, err := r.db.Exec(ctx, query, id, MerchantFromContext(ctx), voidedAt); err != nil {
+		return fmt.Errorf("failed to mark reconciliation issue voided: %w", err)
+	}
+	return nil
+}
+
+// FindDetectedSince retrieves up to limit issues of the merchant in ctx last detected
+// at or after since, most recent first
+func (r *ReconciliationRepository) FindDetectedSince(ctx context.Context, since time.Time, limit int) ([]*domain.ReconciliationIssue, error) {
+	query := `
+		SELECT id, merchant_id, kind, bank_auth_id, COALESCE(payment_id::text, ''),
+		       COALESCE(gateway_status, ''), COALESCE(bank_status, ''),
+		       first_detected_at, last_detected_at, voided_at
+		FROM reconciliation_issues
+		WHERE merchant_id = $1 AND last_detected_at >= $2
+		ORDER BY last_detected_at DESC, id
+		LIMIT $3
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query reconciliation issues: %w", err)
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.ReconciliationIssue, error) {
+		var issue domain.ReconciliationIssue
+		err := row.Scan(
+			&issue.ID, &issue.MerchantID, &issue.Kind, &issue.BankAuthID, &issue.PaymentID,
+			&issue.GatewayStatus, &issue.BankStatus,
+			&issue.FirstDetectedAt, &issue.LastDetectedAt, &issue.VoidedAt,
+		)
+		return &issue, err
+	})
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrRegionLeaseNotFound = errors.New("no region has been promoted")
+
+// RegionFence gives the epoch writes are recorded under, or domain.ErrRegionStandby
+// while the region may not write
+type RegionFence interface {
+	Epoch(ctx context.Context) (int64, error)
+}
+
+// RegionRepository stores which region takes writes. The lease is gateway-wide, so its
+// calls ignore the merchant in ctx.
+type RegionRepository struct {
+	db *DB
+}
+
+func NewRegionRepository(db *DB) *RegionRepository {
+	return &RegionRepository{db: db}
+}
+
+// FindLease returns the lease, or ErrRegionLeaseNotFound before any region was promoted
+func (r *RegionRepository) FindLease(ctx context.Context) (*domain.RegionLease, error) {
+	query := `SELECT active_region, epoch, promoted_at FROM region_lease`
+
+	var lease domain.RegionLease
+	err := r.db.QueryRow(ctx, query).Scan(&lease.ActiveRegion, &lease.Epoch, &lease.PromotedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRegionLeaseNotFound
+		}
+		return nil, fmt.Errorf("failed to find region lease: %w", err)
+	}
+	return &lease, nil
+}
+
+// Promote gives the lease to region under the next epoch. Promoting the region that
+// already holds it leaves the lease as it is, so a failover step can be repeated.
+func (r *RegionRepository) Promote(ctx context.Context, region string) (*domain.RegionLease, error) {
+	query := `
+		INSERT INTO region_lease (active_region, epoch)
+		VALUES ($1, 1)
+		ON CONFLICT (id) DO UPDATE
+		SET active_region = EXCLUDED.active_region,
+		    epoch = region_lease.epoch + 1,
+		    promoted_at = NOW()
+		WHERE region_lease.active_region <> EXCLUDED.active_region
+	`
+
+	if _, err := r.db.Exec(ctx, query, region); err != nil {
+		return nil, fmt.Errorf("failed to promote region: %w", err)
+	}
+	return r.FindLease(ctx)
+}
@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var ErrReviewNotFound = errors.New("payment review not found")
+
+const reviewColumns = `payment_id, merchant_id, reason, flagged_at, decision, reviewed_by, reviewed_at`
+
+// ReviewRepository stores the review queue of risk-flagged payments
+type ReviewRepository struct {
+	db *DB
+}
+
+func NewReviewRepository(db *DB) *ReviewRepository {
+	return &ReviewRepository{db: db}
+}
+
+// Create puts a payment of the merchant in ctx in the queue
+func (r *ReviewRepository) Create(ctx context.Context, tx pgx.Tx, review *domain.PaymentReview) error {
+	review.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO payment_reviews (payment_id, merchant_id, reason, flagged_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := tx.Exec(ctx, query, review.PaymentID, review.MerchantID, review.Reason, review.FlaggedAt); err != nil {
+		return fmt.Errorf("failed to create payment review: %w", err)
+	}
+	return nil
+}
+
+// FindForUpdate retrieves the review of a payment of the merchant in ctx and locks it
+// until tx ends, so two reviewers cannot both decide it
+func (r *ReviewRepository) FindForUpdate(ctx context.Context, tx pgx.Tx, paymentID string) (*domain.PaymentReview, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM payment_reviews
+		WHERE payment_id = $1 AND merchant_id = $2
+		FOR UPDATE
+	`
+
+	review, err := scanReview(tx.QueryRow(ctx, query, paymentID, MerchantFromContext(ctx)))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan payment review: %w", err)
+	}
+	return review, nil
+}
+
+// Update records the decision on a review
+func (r *ReviewRepository) Update(ctx context.Context, tx pgx.Tx, review *domain.PaymentReview) error {
+	query := `
+		UPDATE payment_reviews
+		SET decision = $3, reviewed_by = $4, reviewed_at = $5
+		WHERE payment_id = $1 AND merchant_id = $2
+	`
+
+	tag, err := tx.Exec(ctx, query, review.PaymentID, MerchantFromContext(ctx), review.Decision, review.ReviewedBy, review.ReviewedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update payment review: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
+
+// FindQueued retrieves up to limit undecided reviews of the merchant in ctx, the
+// longest waiting first
+func (r *ReviewRepository) FindQueued(ctx context.Context, limit int) ([]*domain.PaymentReview, error) {
+	query := `
+		SELECT ` + reviewColumns + `
+		FROM payment_reviews
+		WHERE merchant_id = $1 AND decision IS NULL
+		ORDER BY flagged_at ASC, payment_id
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, MerchantFromContext(ctx), limit)
+	if err != nil {
+		return nil, fmt.Errorf("query payment reviews: %w", err)
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.PaymentReview, error) {
+		return scanReview(row)
+	})
+}
+
+// QueueStats returns how many reviews are undecided across every merchant and when the
+// longest waiting of them was flagged, which is nil when the queue is empty
+func (r *ReviewRepository) QueueStats(ctx context.Context) (int, *time.Time, error) {
+	query := `SELECT COUNT(*), MIN(flagged_at) FROM payment_reviews WHERE decision IS NULL`
+
+	var pending int
+	var oldest *time.Time
+	if err := r.db.QueryRow(ctx, query).Scan(&pending, &oldest); err != nil {
+		return 0, nil, fmt.Errorf("failed to query review queue: %w", err)
+	}
+	return pending, oldest, nil
+}
+
+func scanReview(row pgx.Row) (*domain.PaymentReview, error) {
+	var review domain.PaymentReview
+	err := row.Scan(
+		&review.PaymentID, &review.MerchantID, &review.Reason, &review.FlaggedAt,
+		&review.Decision, &review.ReviewedBy, &review.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
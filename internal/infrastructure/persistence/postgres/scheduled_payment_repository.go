@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+type ScheduledPaymentRepository struct {
+	db *DB
+}
+
+func NewScheduledPaymentRepository(db *DB) *ScheduledPaymentRepository {
+	return &ScheduledPaymentRepository{db: db}
+}
+
+// Create schedules a payment of the merchant in ctx. The payment method must belong to
+// the same merchant.
+func (r *ScheduledPaymentRepository) Create(ctx context.Context, tx pgx.Tx, sp *domain.ScheduledPayment) error {
+	query := `
+		INSERT INTO scheduled_payments (payment_id, payment_method_id, scheduled_for, created_at)
+		SELECT p.id, m.id, $3, $4
+		FROM payments p
+		JOIN payment_methods m ON m.id = $2 AND m.merchant_id = p.merchant_id
+		WHERE p.id = $1 AND p.merchant_id = $5
+	`
+
+	tag, err := tx.Exec(ctx, query, sp.PaymentID, sp.PaymentMethodID, sp.ScheduledFor, sp.CreatedAt, MerchantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled payment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPaymentMethodNotFound
+	}
+
+	sp.MerchantID = MerchantFromContext(ctx)
+	return nil
+}
+
+func (r *ScheduledPaymentRepository) FindByPaymentID(ctx context.Context, paymentID string) (*domain.ScheduledPayment, error) {
+	query := `
+		SELECT s.payment_id, s.payment_method_id, s.scheduled_for, s.created_at, p.merchant_id
+		FROM scheduled_payments s
+		JOIN payments p ON p.id = s.payment_id
+		WHERE s.payment_id = $1 AND p.merchant_id = $2
+	`
+
+	var sp domain.ScheduledPayment
+	err := r.db.QueryRow(ctx, query, paymentID, MerchantFromContext(ctx)).Scan(
+		&sp.PaymentID, &sp.PaymentMethodID, &sp.ScheduledFor, &sp.CreatedAt, &sp.MerchantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan scheduled payment: %w", err)
+	}
+
+	return &sp, nil
+}
+
+// ClaimDue locks up to limit payments of all merchants that are still SCHEDULED and
+// whose time has come by now, earliest first. Rows locked by another worker are skipped.
+func (r *ScheduledPaymentRepository) ClaimDue(ctx context.Context, tx pgx.Tx, now time.Time, limit int) ([]*domain.ScheduledPayment, error) {
+	query := `
+		SELECT s.payment_id, s.payment_method_id, s.scheduled_for, s.created_at, p.merchant_id
+		FROM scheduled_payments s
+		JOIN payments p ON p.id = s.payment_id
+		WHERE p.status = 'SCHEDULED'
+		  AND s.scheduled_for <= $1
+		ORDER BY s.scheduled_for ASC
+		LIMIT $2
+		FOR UPDATE OF p SKIP LOCKED
+	`
+
+	// The claimed payments are updated in tx under their own merchants
+	if err := acrossMerchantsInTx(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due scheduled payments: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.ScheduledPayment, error) {
+		var sp domain.ScheduledPayment
+		err := row.Scan(&sp.PaymentID, &sp.PaymentMethodID, &sp.ScheduledFor, &sp.CreatedAt, &sp.MerchantID)
+		return &sp, err
+	})
+}
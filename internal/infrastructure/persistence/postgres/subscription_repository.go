@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+var (
+	ErrSubscriptionNotFound = errors.New("subscription not found")
+	// ErrSubscriptionConflict means the subscription was charged or rescheduled by
+	// someone else since it was read
+	ErrSubscriptionConflict = errors.New("subscription changed concurrently")
+)
+
+type SubscriptionRepository struct {
+	db *DB
+}
+
+func NewSubscriptionRepository(db *DB) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db}
+}
+
+// Create stores the subscription for the merchant in ctx
+func (r *SubscriptionRepository) Create(ctx context.Context, sub *domain.Subscription) error {
+	sub.MerchantID = MerchantFromContext(ctx)
+
+	query := `
+		INSERT INTO subscriptions (
+			id, merchant_id, customer_id, payment_method_id, plan, amount_cents, currency, billing_interval, status,
+			period_start, next_charge_at, failed_attempts, last_payment_id, created_at, canceled_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		sub.ID,
+		sub.MerchantID,
+		sub.CustomerID,
+		sub.PaymentMethodID,
+		sub.Plan,
+		sub.AmountCents,
+		sub.Currency,
+		sub.Interval,
+		sub.Status,
+		sub.PeriodStart,
+		sub.NextChargeAt,
+		sub.FailedAttempts,
+		sub.LastPaymentID,
+		sub.CreatedAt,
+		sub.CanceledAt,
+	)
+	if err != nil {
+		if IsForeignKeyViolation(err) {
+			return ErrPaymentMethodNotFound
+		}
+		return fmt.Errorf("failed to create subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *SubscriptionRepository) FindByID(ctx context.Context, id string) (*domain.Subscription, error) {
+	query := `
+		SELECT id, merchant_id, customer_id, payment_method_id, plan, amount_cents, currency, billing_interval, status,
+		       period_start, next_charge_at, failed_attempts, last_payment_id, created_at, canceled_at
+		FROM subscriptions WHERE id = $1 AND merchant_id = $2
+	`
+
+	return scanSubscription(r.db.QueryRow(ctx, query, id, MerchantFromContext(ctx)))
+}
+
+// FindDue returns up to limit live subscriptions of all merchants whose next charge is
+// due by now, earliest first
+func (r *SubscriptionRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*domain.Subscription, error) {
+	query := `
+		SELECT id, merchant_id, customer_id, payment_method_id, plan, amount_cents, currency, billing_interval, status,
+		       period_start, next_charge_at, failed_attempts, last_payment_id, created_at, canceled_at
+		FROM subscriptions
+		WHERE status IN ('ACTIVE', 'PAST_DUE')
+		  AND next_charge_at <= $1
+		ORDER BY next_charge_at ASC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due subscriptions: %w", err)
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Subscription, error) {
+		return scanSubscription(row)
+	})
+}
+
+// Update saves the subscription only if its next charge is still the one it was read
+// with, so two workers cannot both book the same charge.
+func (r *SubscriptionRepository) Update(ctx context.Context, sub *domain.Subscription, readNextChargeAt time.Time) error {
+	query := `
+		UPDATE subscriptions
+		SET status = $1, period_start = $2, next_charge_at = $3, failed_attempts = $4,
+		    last_payment_id = $5, canceled_at = $6
+		WHERE id = $7 AND merchant_id = $8 AND next_charge_at = $9
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		sub.Status,
+		sub.PeriodStart,
+		sub.NextChargeAt,
+		sub.FailedAttempts,
+		sub.LastPaymentID,
+		sub.CanceledAt,
+		sub.ID,
+		MerchantFromContext(ctx),
+		readNextChargeAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update subscription: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return ErrSubscriptionConflict
+	}
+
+	return nil
+}
+
+func scanSubscription(row pgx.Row) (*domain.Subscription, error) {
+	var s domain.Subscription
+	err := row.Scan(
+		&s.ID, &s.MerchantID, &s.CustomerID, &s.PaymentMethodID, &s.Plan, &s.AmountCents, &s.Currency, &s.Interval, &s.Status,
+		&s.PeriodStart, &s.NextChargeAt, &s.FailedAttempts, &s.LastPaymentID, &s.CreatedAt, &s.CanceledAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to scan subscription: %w", err)
+	}
+	return &s, nil
+}
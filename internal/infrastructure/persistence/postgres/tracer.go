@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryObserver records how long each query took, by the method that made it
+type QueryObserver interface {
+	Observe(method string, failed bool, d time.Duration)
+}
+
+const modulePath = "github.com/DanielPopoola/ficmart-payment-gateway/"
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	method string
+	sql    string
+	at     time.Time
+}
+
+// queryTracer times every query and logs the ones slower than slowThreshold. Queries
+// are labeled with the gateway function that made them, such as
+// PaymentRepository.FindByID, so every repository method is covered without wrapping
+// each one.
+type queryTracer struct {
+	observer      QueryObserver
+	slowThreshold time.Duration
+	logger        *slog.Logger
+}
+
+func (t *queryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{method: callingMethod(), sql: data.SQL, at: time.Now()})
+}
+
+func (t *queryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(queryStartKey{}).(queryStart)
+	if !ok {
+		return
+	}
+	elapsed := time.Since(start.at)
+
+	if t.observer != nil {
+		t.observer.Observe(start.method, data.Err != nil, elapsed)
+	}
+	if t.slowThreshold > 0 && elapsed >= t.slowThreshold {
+		// Only the statement is logged: its arguments can hold customer data
+		t.logger.Warn("slow query",
+			"method", start.method,
+			"duration_ms", elapsed.Milliseconds(),
+			"command", data.CommandTag.String(),
+			"sql", strings.Join(strings.Fields(start.sql), " "),
+		)
+	}
+}
+
+// callingMethod names the first function of the gateway on the stack that is not the
+// tracer or the database driver
+func callingMethod() string {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if strings.HasPrefix(frame.Function, modulePath) && !strings.Contains(frame.Function, "(*queryTracer)") {
+			return methodName(frame.Function)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
+
+var closureSuffix = regexp.MustCompile(`(\.(func|gowrap)\d+)+$`)
+
+// methodName turns a qualified function name such as
+// ".../postgres.(*PaymentRepository).FindByID.func1" into "PaymentRepository.FindByID"
+func methodName(function string) string {
+	name := function[strings.LastIndex(function, "/")+1:]
+	_, name, _ = strings.Cut(name, ".")
+	name = strings.NewReplacer("(*", "", ")", "", "[...]", "").Replace(name)
+	return closureSuffix.ReplaceAllString(name, "")
+}
@@ -0,0 +1,42 @@
+package vault_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+
+func TestCipher_RoundTrip(t *testing.T) {
+	c, err := vault.NewCipher(testKey)
+	require.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(ciphertext), "4111111111111111")
+
+	plaintext, err := c.Decrypt(ciphertext)
+	require.NoError(t, err)
+	assert.Equal(t, "4111111111111111", string(plaintext))
+}
+
+func TestCipher_RejectsTamperedCiphertext(t *testing.T) {
+	c, err := vault.NewCipher(testKey)
+	require.NoError(t, err)
+
+	ciphertext, err := c.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+	ciphertext[len(ciphertext)-1] ^= 0xff
+
+	_, err = c.Decrypt(ciphertext)
+	require.ErrorIs(t, err, vault.ErrInvalidCiphertext)
+}
+
+func TestNewCipher_RejectsShortKey(t *testing.T) {
+	_, err := vault.NewCipher(base64.StdEncoding.EncodeToString([]byte("short")))
+	require.Error(t, err)
+}
@@ -0,0 +1,41 @@
+package vault_test
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFingerprinter_Fingerprint(t *testing.T) {
+	f, err := vault.NewFingerprinter(testKey)
+	require.NoError(t, err)
+
+	fingerprint := f.Fingerprint("4111111111111111")
+	assert.Len(t, fingerprint, 64)
+	assert.Equal(t, fingerprint, f.Fingerprint("4111111111111111"))
+	assert.NotEqual(t, fingerprint, f.Fingerprint("5555555555554444"))
+
+	other, err := vault.NewFingerprinter(base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210")))
+	require.NoError(t, err)
+	assert.NotEqual(t, fingerprint, other.Fingerprint("4111111111111111"))
+}
+
+func TestNewFingerprinter_RejectsShortSalt(t *testing.T) {
+	_, err := vault.NewFingerprinter(base64.StdEncoding.EncodeToString([]byte("short")))
+	assert.Error(t, err)
+}
+
+func TestFingerprinter_DeriveKey(t *testing.T) {
+	f, err := vault.NewFingerprinter(testKey)
+	require.NoError(t, err)
+
+	key := f.DeriveKey("request-hash:acme")
+	assert.Len(t, key, 32)
+	assert.Equal(t, key, f.DeriveKey("request-hash:acme"))
+	assert.NotEqual(t, key, f.DeriveKey("request-hash:globex"))
+	assert.NotEqual(t, f.Fingerprint("request-hash:acme"), hex.EncodeToString(key), "a key is not the fingerprint of its purpose")
+}
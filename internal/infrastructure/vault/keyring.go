@@ -0,0 +1,105 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// LegacyKeyID names the key that sealed everything stored before keys had IDs
+const LegacyKeyID = "legacy"
+
+var ErrUnknownKey = errors.New("ciphertext sealed with an unknown key")
+
+// Sealed is a ciphertext with the ID of the key that sealed it
+type Sealed struct {
+	KeyID      string
+	Ciphertext []byte
+}
+
+// Keyring seals with its primary key and opens with any key it holds, so a key can be
+// retired by re-sealing its data gradually instead of all at once
+type Keyring struct {
+	primaryID string
+	ciphers   map[string]*Cipher
+}
+
+// ParseKeyring builds a keyring from keys, a comma-separated list of id:key pairs with
+// base64-encoded 32-byte keys, and legacyKey, the key data sealed before keys had IDs
+// was sealed with. The first pair is the primary key; without any, the legacy key is.
+func ParseKeyring(keys, legacyKey string) (*Keyring, error) {
+	k := &Keyring{ciphers: make(map[string]*Cipher)}
+
+	if legacyKey != "" {
+		c, err := NewCipher(legacyKey)
+		if err != nil {
+			return nil, fmt.Errorf("legacy key: %w", err)
+		}
+		k.ciphers[LegacyKeyID] = c
+		k.primaryID = LegacyKeyID
+	}
+
+	for i, pair := range strings.Split(keys, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		id, encodedKey, ok := strings.Cut(pair, ":")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("key %d: expected id:key", i+1)
+		}
+		if _, exists := k.ciphers[id]; exists {
+			return nil, fmt.Errorf("key %q: listed twice", id)
+		}
+
+		c, err := NewCipher(encodedKey)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", id, err)
+		}
+		k.ciphers[id] = c
+		if k.primaryID == "" || k.primaryID == LegacyKeyID {
+			k.primaryID = id
+		}
+	}
+
+	if k.primaryID == "" {
+		return nil, errors.New("no encryption key configured")
+	}
+	return k, nil
+}
+
+// PrimaryKeyID returns the ID of the key new data is sealed with
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primaryID
+}
+
+func (k *Keyring) Encrypt(plaintext []byte) (Sealed, error) {
+	ciphertext, err := k.ciphers[k.primaryID].Encrypt(plaintext)
+	if err != nil {
+		return Sealed{}, err
+	}
+	return Sealed{KeyID: k.primaryID, Ciphertext: ciphertext}, nil
+}
+
+func (k *Keyring) Decrypt(s Sealed) ([]byte, error) {
+	c, ok := k.ciphers[s.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, s.KeyID)
+	}
+	return c.Decrypt(s.Ciphertext)
+}
+
+// NeedsRotation reports whether s was sealed with a key other than the primary one
+func (k *Keyring) NeedsRotation(s Sealed) bool {
+	return s.KeyID != k.primaryID
+}
+
+// Reseal opens s and seals its plaintext again with the primary key
+func (k *Keyring) Reseal(s Sealed) (Sealed, error) {
+	plaintext, err := k.Decrypt(s)
+	if err != nil {
+		return Sealed{}, err
+	}
+	return k.Encrypt(plaintext)
+}
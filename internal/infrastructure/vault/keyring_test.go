@@ -0,0 +1,67 @@
+package vault_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var newKey = base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))
+
+func TestParseKeyring_LegacyKeyIsPrimaryWithoutKeys(t *testing.T) {
+	k, err := vault.ParseKeyring("", testKey)
+	require.NoError(t, err)
+	assert.Equal(t, vault.LegacyKeyID, k.PrimaryKeyID())
+}
+
+func TestParseKeyring_FirstListedKeyIsPrimary(t *testing.T) {
+	k, err := vault.ParseKeyring("k2:"+newKey+", k1:"+testKey, testKey)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", k.PrimaryKeyID())
+}
+
+func TestParseKeyring_RejectsInvalidKeys(t *testing.T) {
+	for name, keys := range map[string]string{
+		"no key":       "",
+		"missing id":   ":" + testKey,
+		"no separator": testKey,
+		"duplicate id": "k1:" + testKey + ",k1:" + newKey,
+		"short key":    "k1:" + base64.StdEncoding.EncodeToString([]byte("short")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := vault.ParseKeyring(keys, "")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestKeyring_ResealsWithPrimaryKey(t *testing.T) {
+	old, err := vault.ParseKeyring("", testKey)
+	require.NoError(t, err)
+	sealed, err := old.Encrypt([]byte("4111111111111111"))
+	require.NoError(t, err)
+
+	k, err := vault.ParseKeyring("k2:"+newKey, testKey)
+	require.NoError(t, err)
+	require.True(t, k.NeedsRotation(sealed))
+
+	resealed, err := k.Reseal(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", resealed.KeyID)
+	assert.False(t, k.NeedsRotation(resealed))
+
+	plaintext, err := k.Decrypt(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, "4111111111111111", string(plaintext))
+}
+
+func TestKeyring_RejectsUnknownKey(t *testing.T) {
+	k, err := vault.ParseKeyring("k2:"+newKey, "")
+	require.NoError(t, err)
+
+	_, err = k.Decrypt(vault.Sealed{KeyID: "k1", Ciphertext: []byte("ciphertext")})
+	require.ErrorIs(t, err, vault.ErrUnknownKey)
+}
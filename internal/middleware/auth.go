@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// APIKeyHeader carries the key that identifies the merchant making a request
+const APIKeyHeader = "X-API-Key"
+
+// ClientTokenHeader carries the token a storefront confirms a payment intent with
+const ClientTokenHeader = "X-Client-Token"
+
+// clientTokenRoutes are served without a key to requests carrying a client token,
+// which the handler checks and which names the merchant instead
+var clientTokenRoutes = map[string]bool{
+	"POST /payment-intents/{intentID}/confirm": true,
+}
+
+// usesClientToken reports whether a request made without a key is authorized by its
+// client token instead
+func usesClientToken(r *http.Request) bool {
+	return clientTokenRoutes[r.Pattern] && r.Header.Get(ClientTokenHeader) != ""
+}
+
+// Authenticate resolves the merchant of each request from its API key and scopes the
+// repository calls made while serving it to that merchant. An unknown or revoked key is
+// rejected with 401. A request signed as SignatureVerifier describes names its key by
+// ID instead, and is rejected with 401 unless its signature, timestamp and nonce check
+// out. A request without a key is rejected too when requireKey is set; otherwise it
+// acts for the default merchant with every role, as every request did before the
+// gateway served several merchants. Confirming a payment intent with its client
+// token needs no key either way.
+func Authenticate(
+	apiKeys *postgres.APIKeyRepository,
+	signatures *SignatureVerifier,
+	requireKey bool,
+	logger *slog.Logger,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r = r.WithContext(postgres.WithActor(r.Context(), requestActor(r)))
+
+			if r.Header.Get(APIKeyHeader) == "" && r.Header.Get(SignatureHeader) == "" {
+				if requireKey && !usesClientToken(r) {
+					handlers.WriteError(w, application.NewUnauthorizedError(), logger)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			apiKey, err := authenticate(r, apiKeys, signatures)
+			if err != nil {
+				if application.ToHTTPStatus(err) == http.StatusInternalServerError {
+					logger.Error("failed to authenticate request", "error", err)
+				}
+				handlers.WriteError(w, err, logger)
+				return
+			}
+
+			ctx := postgres.WithMerchant(r.Context(), apiKey.MerchantID)
+			next.ServeHTTP(w, r.WithContext(postgres.WithAPIKey(ctx, apiKey)))
+		})
+	}
+}
+
+// authenticate returns the key a request that carries a key or a signature was made with
+func authenticate(r *http.Request, apiKeys *postgres.APIKeyRepository, signatures *SignatureVerifier) (*domain.APIKey, error) {
+	if r.Header.Get(SignatureHeader) != "" {
+		return signatures.Verify(r)
+	}
+
+	apiKey, err := apiKeys.Find(r.Context(), r.Header.Get(APIKeyHeader))
+	if err != nil {
+		if errors.Is(err, postgres.ErrAPIKeyNotFound) {
+			return nil, application.NewUnauthorizedError()
+		}
+		return nil, application.NewInternalError(err)
+	}
+	return apiKey, nil
+}
@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/chaos"
+)
+
+// Chaos holds requests and fails them at the odds the injector draws, so clients can
+// rehearse their timeouts and retries. A failed request never reaches its handler. With
+// a nil injector requests pass through untouched.
+func Chaos(injector *chaos.Injector, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if injector == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fault := injector.Next()
+			if !fault.Injected() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			logger.Info("chaos injected into request", "method", r.Method, "path", r.URL.Path, "fault", fault.String())
+			if err := fault.Wait(r.Context()); err != nil {
+				return
+			}
+			w.Header().Set("X-Chaos-Injected", fault.String())
+			if fault.Status != 0 {
+				handlers.WriteError(w, application.NewChaosInjectedError(fault.Status), logger)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+)
+
+// Metrics records the rate, status class and duration of each request under the
+// route it matched, such as "/payments/{paymentID}", so the label set stays bounded
+// however many payments there are. It runs outside Authenticate so rejected requests
+// are counted too.
+func Metrics(m *metrics.HTTPMetrics) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(rw, r)
+
+			// Patterns are "METHOD /path"
+			_, route, found := strings.Cut(r.Pattern, " ")
+			if !found {
+				route = r.Pattern
+			}
+			m.Observe(route, r.Method, rw.statusCode, time.Since(start))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+const (
+	QuotaTransactionsRemainingHeader = "X-Quota-Transactions-Remaining"
+	QuotaVolumeRemainingHeader       = "X-Quota-Volume-Remaining"
+)
+
+type quotaWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	settings    *postgres.MerchantSettingsRepository
+	logger      *slog.Logger
+	wroteHeader bool
+}
+
+func (qw *quotaWriter) WriteHeader(code int) {
+	if !qw.wroteHeader {
+		qw.wroteHeader = true
+		qw.setHeaders()
+	}
+	qw.ResponseWriter.WriteHeader(code)
+}
+
+func (qw *quotaWriter) Write(p []byte) (int, error) {
+	if !qw.wroteHeader {
+		qw.WriteHeader(http.StatusOK)
+	}
+	return qw.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (qw *quotaWriter) Unwrap() http.ResponseWriter {
+	return qw.ResponseWriter
+}
+
+// setHeaders adds the remaining quota for each limit the merchant has. A failed
+// lookup only costs the headers, not the response.
+func (qw *quotaWriter) setHeaders() {
+	quota, err := qw.settings.FindQuota(qw.ctx)
+	if err != nil {
+		qw.logger.Warn("failed to read merchant quota", "error", err)
+		return
+	}
+
+	if remaining := quota.RemainingTransactions(); remaining != nil {
+		qw.Header().Set(QuotaTransactionsRemainingHeader, strconv.Itoa(*remaining))
+	}
+	if remaining := quota.RemainingVolumeCents(); remaining != nil {
+		qw.Header().Set(QuotaVolumeRemainingHeader, strconv.FormatInt(*remaining, 10))
+	}
+}
+
+// QuotaHeaders reports what is left of the merchant's daily quota on every response.
+// The quota is read as the response is written, so it counts a payment the request
+// has just created. A request authorized by a client token is not told, as it does
+// not act for a merchant until the handler finds the intent. It must run inside
+// Authenticate.
+func QuotaHeaders(settings *postgres.MerchantSettingsRepository, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if postgres.APIKeyFromContext(r.Context()) == nil && usesClientToken(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			next.ServeHTTP(&quotaWriter{
+				ResponseWriter: w,
+				ctx:            r.Context(),
+				settings:       settings,
+				logger:         logger,
+			}, r)
+		})
+	}
+}
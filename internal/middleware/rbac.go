@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/google/uuid"
+)
+
+const adminPathPrefix = "/admin/"
+
+// readOnlyPosts are POSTs that only read, taking their input in the body because it
+// does not fit in a URL
+var readOnlyPosts = map[string]bool{
+	"/payments/batch-get": true,
+}
+
+// isRead reports whether a request only reads
+func isRead(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead ||
+		(r.Method == http.MethodPost && readOnlyPosts[r.URL.Path])
+}
+
+// requiredRole returns the role a request needs. Reads need a viewer and everything
+// else an operator, except under /admin, where reads need an operator and changes an
+// admin.
+func requiredRole(r *http.Request) domain.Role {
+	read := isRead(r)
+
+	if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+		if read {
+			return domain.RoleOperator
+		}
+		return domain.RoleAdmin
+	}
+	if read {
+		return domain.RoleViewer
+	}
+	return domain.RoleOperator
+}
+
+// requestActor returns what changes a request makes are attributed to
+func requestActor(r *http.Request) domain.Actor {
+	if strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+		return domain.ActorAdmin
+	}
+	return domain.ActorAPI
+}
+
+// RequireRole rejects with 403 requests whose API key's role does not allow them.
+// Requests made without a key while keys are optional are let through, except under
+// /admin, where they are rejected with 401 as Require does. It must run inside
+// Authenticate.
+func RequireRole(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := postgres.APIKeyFromContext(r.Context())
+			if apiKey == nil && strings.HasPrefix(r.URL.Path, adminPathPrefix) {
+				handlers.WriteError(w, application.NewUnauthorizedError(), logger)
+				return
+			}
+			if apiKey != nil && !apiKey.Role.Allows(requiredRole(r)) {
+				handlers.WriteError(w, application.NewForbiddenError(), logger)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Require rejects requests made without a key with 401, even while keys are optional,
+// and requests whose key's role does not allow role with 403. It guards what is served
+// outside the API. It must run inside Authenticate.
+func Require(role domain.Role, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey := postgres.APIKeyFromContext(r.Context())
+			if apiKey == nil {
+				handlers.WriteError(w, application.NewUnauthorizedError(), logger)
+				return
+			}
+			if !apiKey.Role.Allows(role) {
+				handlers.WriteError(w, application.NewForbiddenError(), logger)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Audit records every request that changes something under /admin in the audit log,
+// with the key that made it and its status, including requests RequireRole rejected
+// when it runs inside Audit. The request has already been served when it is recorded,
+// so a failure to record it is logged rather than returned. It must run inside
+// Authenticate.
+func Audit(auditRepo *postgres.AuditRepository, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !strings.HasPrefix(r.URL.Path, adminPathPrefix) || r.Method == http.MethodGet || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rw, r)
+
+			entry := &domain.AuditEntry{
+				ID:        uuid.New().String(),
+				Role:      domain.RoleAdmin,
+				Action:    r.Pattern,
+				Target:    r.URL.Path,
+				Status:    rw.statusCode,
+				CreatedAt: time.Now(),
+			}
+			if apiKey := postgres.APIKeyFromContext(r.Context()); apiKey != nil {
+				entry.APIKeyID = &apiKey.ID
+				entry.Role = apiKey.Role
+			}
+
+			// The request's own context may already be canceled by a timeout
+			ctx := context.WithoutCancel(r.Context())
+			if err := auditRepo.Create(ctx, entry); err != nil {
+				logger.Error("failed to record audit entry",
+					"action", entry.Action,
+					"target", entry.Target,
+					"status", entry.Status,
+					"error", err,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package middleware_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireRole(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := middleware.RequireRole(logger)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		role       domain.Role
+		wantStatus int
+	}{
+		{"no key changing an admin setting", http.MethodPost, "/admin/clock/advance", "", http.StatusUnauthorized},
+		{"no key reading an admin resource", http.MethodGet, "/admin/dead-letters", "", http.StatusUnauthorized},
+		{"no key capturing while keys are optional", http.MethodPost, "/payments/capture", "", http.StatusOK},
+		{"operator changing an admin setting", http.MethodPost, "/admin/clock/advance", domain.RoleOperator, http.StatusForbidden},
+		{"operator reading an admin resource", http.MethodGet, "/admin/dead-letters", domain.RoleOperator, http.StatusOK},
+		{"admin changing an admin setting", http.MethodPost, "/admin/clock/advance", domain.RoleAdmin, http.StatusOK},
+		{"viewer capturing", http.MethodPost, "/payments/capture", domain.RoleViewer, http.StatusForbidden},
+		{"viewer batch-getting payments", http.MethodPost, "/payments/batch-get", domain.RoleViewer, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.role != "" {
+				r = r.WithContext(postgres.WithAPIKey(r.Context(), &domain.APIKey{ID: "key-1", MerchantID: "m-1", Role: tt.role}))
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+)
+
+// promotePath ends a region's standby, so it is the one change a standby region takes
+const promotePath = "/admin/region/promote"
+
+// Standby rejects with 503 every request that changes something while the region is on
+// standby, except its promotion. Reads are still answered, from whatever the region's
+// database has replicated.
+func Standby(regions *services.RegionService, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isRead(r) || r.URL.Path == promotePath || regions.State(r.Context()).Active {
+				next.ServeHTTP(w, r)
+				return
+			}
+			handlers.WriteError(w, application.NewRegionStandbyError(), logger)
+		})
+	}
+}
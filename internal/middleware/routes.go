@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+)
+
+// RouteErrors serves mux, answering requests it has no route for with the JSON error
+// body of every other failure instead of its plain-text 404 and 405. A 405 keeps the
+// Allow header listing the methods the path does take.
+func RouteErrors(mux *http.ServeMux, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fallback, pattern := mux.Handler(r)
+		if pattern != "" {
+			mux.ServeHTTP(w, r)
+			return
+		}
+
+		// Only the mux knows whether another method would match, so its answer is
+		// recorded and rewritten
+		recorded := &statusRecorder{header: make(http.Header)}
+		fallback.ServeHTTP(recorded, r)
+
+		if recorded.status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", recorded.header.Get("Allow"))
+			handlers.WriteError(w, application.NewMethodNotAllowedError(r.Method, r.URL.Path), logger)
+			return
+		}
+		handlers.WriteError(w, application.NewRouteNotFoundError(r.Method, r.URL.Path), logger)
+	})
+}
+
+// Routes reports whether mux routes a request to a handler registered under one of
+// patterns, whatever headers the request carries
+func Routes(mux *http.ServeMux, patterns ...string) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		_, pattern := mux.Handler(r)
+		return slices.Contains(patterns, pattern)
+	}
+}
+
+// statusRecorder keeps the status and headers written to it and discards the body
+type statusRecorder struct {
+	header http.Header
+	status int
+}
+
+func (s *statusRecorder) Header() http.Header { return s.header }
+
+func (s *statusRecorder) WriteHeader(status int) { s.status = status }
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	return len(b), nil
+}
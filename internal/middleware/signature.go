@@ -0,0 +1,143 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/google/uuid"
+)
+
+// Headers of a signed request. It names its key by ID instead of sending the key.
+const (
+	APIKeyIDHeader           = "X-API-Key-ID"
+	SignatureHeader          = "X-Signature"
+	SignatureTimestampHeader = "X-Signature-Timestamp"
+	SignatureNonceHeader     = "X-Signature-Nonce"
+)
+
+const (
+	minNonceLength = 16
+	maxNonceLength = 128
+	// maxSignedBodyBytes bounds the body read to check a signature
+	maxSignedBodyBytes = 1 << 20
+)
+
+// SignatureVerifier checks requests signed with HMAC-SHA256. A signature covers the
+// request's timestamp, nonce, method, URI and body, and is keyed by the signing secret
+// issued for the API key, which the gateway keeps sealed by the vault. A request is
+// accepted once, and only while its timestamp is within window of the gateway's clock,
+// so a captured request cannot be replayed to capture or refund again.
+type SignatureVerifier struct {
+	apiKeys *postgres.APIKeyRepository
+	nonces  *postgres.NonceRepository
+	keyring *vault.Keyring
+	window  time.Duration
+}
+
+func NewSignatureVerifier(
+	apiKeys *postgres.APIKeyRepository,
+	nonces *postgres.NonceRepository,
+	keyring *vault.Keyring,
+	window time.Duration,
+) *SignatureVerifier {
+	return &SignatureVerifier{
+		apiKeys: apiKeys,
+		nonces:  nonces,
+		keyring: keyring,
+		window:  window,
+	}
+}
+
+// SignaturePayload returns what a request's signature is computed over: its timestamp,
+// nonce, method and URI on one line each, then the hex SHA-256 of its body
+func SignaturePayload(timestamp, nonce, method, requestURI string, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	return fmt.Appendf(nil, "%s\n%s\n%s\n%s\n%s", timestamp, nonce, method, requestURI, hex.EncodeToString(bodyHash[:]))
+}
+
+// Sign returns the hex HMAC-SHA256 of payload keyed by the signing secret of an API key
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns the key a signed request was made with. The body is read to check the
+// signature and put back for the handler. The nonce is claimed only once everything
+// else checks out, so a forged request cannot use up a legitimate client's nonce.
+func (v *SignatureVerifier) Verify(r *http.Request) (*domain.APIKey, error) {
+	keyID := r.Header.Get(APIKeyIDHeader)
+	timestamp := r.Header.Get(SignatureTimestampHeader)
+	nonce := r.Header.Get(SignatureNonceHeader)
+	signature := r.Header.Get(SignatureHeader)
+
+	if _, err := uuid.Parse(keyID); err != nil {
+		return nil, application.NewUnauthorizedError()
+	}
+	if len(nonce) < minNonceLength || len(nonce) > maxNonceLength {
+		return nil, application.NewInvalidSignatureError(
+			fmt.Errorf("nonce must be %d to %d characters", minNonceLength, maxNonceLength),
+		)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, application.NewInvalidSignatureError(errors.New("timestamp must be unix seconds"))
+	}
+	now := time.Now()
+	signedAt := time.Unix(unix, 0)
+	if signedAt.Before(now.Add(-v.window)) || signedAt.After(now.Add(v.window)) {
+		return nil, application.NewInvalidSignatureError(errors.New("timestamp is outside the freshness window"))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxSignedBodyBytes+1))
+	if err != nil {
+		return nil, application.NewInvalidInputError(err)
+	}
+	if len(body) > maxSignedBodyBytes {
+		return nil, application.NewInvalidSignatureError(errors.New("body is too large to sign"))
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	apiKey, sealed, err := v.apiKeys.FindByID(r.Context(), keyID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrAPIKeyNotFound) {
+			return nil, application.NewUnauthorizedError()
+		}
+		return nil, application.NewInternalError(err)
+	}
+	if sealed == nil {
+		return nil, application.NewInvalidSignatureError(errors.New("key has no signing secret"))
+	}
+	secret, err := v.keyring.Decrypt(*sealed)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+
+	expected := Sign(string(secret), SignaturePayload(timestamp, nonce, r.Method, r.URL.RequestURI(), body))
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return nil, application.NewInvalidSignatureError(errors.New("signature does not match"))
+	}
+
+	claimed, err := v.nonces.Claim(r.Context(), apiKey.ID, nonce, signedAt.Add(v.window), now)
+	if err != nil {
+		return nil, application.NewInternalError(err)
+	}
+	if !claimed {
+		return nil, application.NewInvalidSignatureError(errors.New("nonce was already used"))
+	}
+
+	return apiKey, nil
+}
@@ -0,0 +1,196 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/vault"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/middleware"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const captureBody = `{"payment_id": "pay-1", "amount": 5000}`
+
+// signedRequest builds a request to /capture signed with secret at signedAt
+func signedRequest(keyID, secret, nonce string, signedAt time.Time) *http.Request {
+	timestamp := strconv.FormatInt(signedAt.Unix(), 10)
+	payload := middleware.SignaturePayload(timestamp, nonce, http.MethodPost, "/capture", []byte(captureBody))
+
+	r := httptest.NewRequest(http.MethodPost, "/capture", strings.NewReader(captureBody))
+	r.Header.Set(middleware.APIKeyIDHeader, keyID)
+	r.Header.Set(middleware.SignatureTimestampHeader, timestamp)
+	r.Header.Set(middleware.SignatureNonceHeader, nonce)
+	r.Header.Set(middleware.SignatureHeader, middleware.Sign(secret, payload))
+	return r
+}
+
+func TestSignatureVerifier_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	keyring, err := vault.ParseKeyring("k1:"+base64.StdEncoding.EncodeToString(make([]byte, 32)), "")
+	require.NoError(t, err)
+
+	apiKeys := postgres.NewAPIKeyRepository(testDB.DB)
+	const window = 5 * time.Minute
+	verifier := middleware.NewSignatureVerifier(apiKeys, postgres.NewNonceRepository(testDB.DB), keyring, window)
+
+	createKey := func(apiKey string) string {
+		id := uuid.New().String()
+		_, err := testDB.DB.Pool.Exec(ctx,
+			`INSERT INTO api_keys (id, merchant_id, key_hash, role) VALUES ($1, $2, $3, $4)`,
+			id, domain.DefaultMerchantID, postgres.HashAPIKey(apiKey), domain.RoleOperator,
+		)
+		require.NoError(t, err)
+		return id
+	}
+
+	keyID := createKey("sk_test_signing")
+	secret, err := services.NewSigningSecretService(apiKeys, keyring).Issue(ctx, keyID)
+	require.NoError(t, err)
+	unsignedKeyID := createKey("sk_test_no_secret")
+
+	nonce := func() string { return uuid.New().String() }
+	replayed := nonce()
+	_, err = verifier.Verify(signedRequest(keyID, secret, replayed, time.Now()))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		request  func() *http.Request
+		wantCode string
+	}{
+		{
+			name:    "valid signature",
+			request: func() *http.Request { return signedRequest(keyID, secret, nonce(), time.Now()) },
+		},
+		{
+			name: "timestamp before the window",
+			request: func() *http.Request {
+				return signedRequest(keyID, secret, nonce(), time.Now().Add(-window-time.Minute))
+			},
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+		{
+			name: "timestamp after the window",
+			request: func() *http.Request {
+				return signedRequest(keyID, secret, nonce(), time.Now().Add(window+time.Minute))
+			},
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+		{
+			name:     "nonce replay",
+			request:  func() *http.Request { return signedRequest(keyID, secret, replayed, time.Now()) },
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+		{
+			name: "tampered body",
+			request: func() *http.Request {
+				r := signedRequest(keyID, secret, nonce(), time.Now())
+				r.Body = io.NopCloser(strings.NewReader(strings.Replace(captureBody, "5000", "500000", 1)))
+				return r
+			},
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+		{
+			name: "tampered URI",
+			request: func() *http.Request {
+				r := signedRequest(keyID, secret, nonce(), time.Now())
+				r.URL.Path = "/refund"
+				r.RequestURI = "/refund"
+				return r
+			},
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+		{
+			name:     "unknown key ID",
+			request:  func() *http.Request { return signedRequest(uuid.New().String(), secret, nonce(), time.Now()) },
+			wantCode: application.ErrCodeUnauthorized,
+		},
+		{
+			name: "signed with the stored hash of the key",
+			request: func() *http.Request {
+				return signedRequest(keyID, postgres.HashAPIKey("sk_test_signing"), nonce(), time.Now())
+			},
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+		{
+			name: "key without a signing secret",
+			request: func() *http.Request {
+				return signedRequest(unsignedKeyID, postgres.HashAPIKey("sk_test_no_secret"), nonce(), time.Now())
+			},
+			wantCode: application.ErrCodeInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.request()
+			apiKey, err := verifier.Verify(r)
+
+			if tt.wantCode != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantCode, application.ToErrorCode(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, keyID, apiKey.ID)
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, captureBody, string(body), "the body must be put back for the handler")
+		})
+	}
+}
+
+func TestSignatureVerifier_VerifyClaimsNonceOnlyForValidSignatures(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	keyring, err := vault.ParseKeyring("k1:"+base64.StdEncoding.EncodeToString(make([]byte, 32)), "")
+	require.NoError(t, err)
+
+	apiKeys := postgres.NewAPIKeyRepository(testDB.DB)
+	verifier := middleware.NewSignatureVerifier(apiKeys, postgres.NewNonceRepository(testDB.DB), keyring, 5*time.Minute)
+
+	keyID := uuid.New().String()
+	_, err = testDB.DB.Pool.Exec(ctx,
+		`INSERT INTO api_keys (id, merchant_id, key_hash, role) VALUES ($1, $2, $3, $4)`,
+		keyID, domain.DefaultMerchantID, postgres.HashAPIKey("sk_test_signing"), domain.RoleOperator,
+	)
+	require.NoError(t, err)
+	secret, err := services.NewSigningSecretService(apiKeys, keyring).Issue(ctx, keyID)
+	require.NoError(t, err)
+
+	// A forged request must not use up the nonce of the client it copied it from
+	nonce := uuid.New().String()
+	_, err = verifier.Verify(signedRequest(keyID, "forged", nonce, time.Now()))
+	require.Error(t, err)
+
+	_, err = verifier.Verify(signedRequest(keyID, secret, nonce, time.Now()))
+	require.NoError(t, err)
+
+	// Issuing a new secret retires the old one
+	_, err = services.NewSigningSecretService(apiKeys, keyring).Issue(ctx, keyID)
+	require.NoError(t, err)
+	_, err = verifier.Verify(signedRequest(keyID, secret, uuid.New().String(), time.Now()))
+	assert.Equal(t, application.ErrCodeInvalidSignature, application.ToErrorCode(err))
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/handlers"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// maxBodyBytes bounds a JSON request body; the largest, a batch, is far smaller
+const maxBodyBytes = 1 << 20
+
+// StrictJSON checks a JSON request body against its operation's schema in spec before
+// the handler decodes it. A body over maxBodyBytes, or one with a field the schema does
+// not define, is answered with 400 naming the problem: a misspelt "amout" would
+// otherwise be dropped and leave the amount at zero. Objects the schema leaves open,
+// such as metadata, may hold any field. It must run after the router has matched the
+// request, since the operation is found by the matched pattern.
+func StrictJSON(spec *openapi3.T, logger *slog.Logger) func(http.Handler) http.Handler {
+	schemas := requestSchemas(spec)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			schema, ok := schemas[r.Pattern]
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					err = fmt.Errorf("body exceeds %d bytes", maxBodyBytes)
+				}
+				handlers.WriteError(w, application.NewInvalidBodyError(err), logger)
+				return
+			}
+
+			var value any
+			if err := json.Unmarshal(body, &value); err != nil {
+				handlers.WriteError(w, application.NewInvalidBodyError(err), logger)
+				return
+			}
+			if field := unknownField(value, schema, ""); field != "" {
+				handlers.WriteError(w, application.NewInvalidBodyError(fmt.Errorf("unknown field %q", field)), logger)
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// requestSchemas returns the JSON body schema of each operation in spec, keyed by the
+// pattern its route is registered under
+func requestSchemas(spec *openapi3.T) map[string]*openapi3.Schema {
+	schemas := make(map[string]*openapi3.Schema)
+	for path, item := range spec.Paths.Map() {
+		for method, op := range item.Operations() {
+			if op.RequestBody == nil || op.RequestBody.Value == nil {
+				continue
+			}
+			media := op.RequestBody.Value.Content.Get("application/json")
+			if media == nil || media.Schema == nil || media.Schema.Value == nil {
+				continue
+			}
+			schemas[method+" "+path] = media.Schema.Value
+		}
+	}
+	return schemas
+}
+
+// unknownField returns the path of the first field in value that schema does not
+// define, or "" if there is none
+func unknownField(value any, schema *openapi3.Schema, path string) string {
+	switch v := value.(type) {
+	case map[string]any:
+		properties := definedProperties(schema)
+		if properties == nil {
+			return ""
+		}
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			fieldPath := name
+			if path != "" {
+				fieldPath = path + "." + name
+			}
+			property, ok := properties[name]
+			if !ok {
+				return fieldPath
+			}
+			if property.Value == nil {
+				continue
+			}
+			if field := unknownField(v[name], property.Value, fieldPath); field != "" {
+				return field
+			}
+		}
+	case []any:
+		if schema.Items == nil || schema.Items.Value == nil {
+			return ""
+		}
+		for i, item := range v {
+			if field := unknownField(item, schema.Items.Value, fmt.Sprintf("%s[%d]", path, i)); field != "" {
+				return field
+			}
+		}
+	}
+	return ""
+}
+
+// definedProperties returns the properties an object of schema may have, including
+// those of the schemas it is composed of with allOf. It returns nil for an object that
+// may have any: one allowing additional properties, choosing between schemas with
+// oneOf or anyOf, or defining none.
+func definedProperties(schema *openapi3.Schema) openapi3.Schemas {
+	if schema.AdditionalProperties.Schema != nil ||
+		(schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has) ||
+		len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return nil
+	}
+
+	properties := maps.Clone(schema.Properties)
+	for _, part := range schema.AllOf {
+		if part.Value == nil {
+			continue
+		}
+		partProperties := definedProperties(part.Value)
+		if partProperties == nil {
+			return nil
+		}
+		if properties == nil {
+			properties = make(openapi3.Schemas)
+		}
+		maps.Copy(properties, partProperties)
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return properties
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -26,16 +27,53 @@ func (tw *timeoutWriter) Write(p []byte) (int, error) {
 
 func (tw *timeoutWriter) WriteHeader(code int) {
 	tw.code = code
+
+	dst := tw.ResponseWriter.Header()
+	for k, v := range tw.h {
+		dst[k] = v
+	}
+
 	tw.ResponseWriter.WriteHeader(code)
 }
 
+// RequestDeadlineHeader carries the RFC 3339 time after which the client no longer
+// waits for the response
+const RequestDeadlineHeader = "X-Request-Deadline"
+
 // Timeout creates middleware that enforces a request timeout.
 // If the timeout is exceeded, it returns a 408 Request Timeout with a JSON error.
 // It was used over http.TimeoutHandler to be compatible with defined OpenAPI schema
-func Timeout(timeout time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+// Requests exempt reports true for, such as Server-Sent Event streams, which are
+// long-lived by design, are not subject to the timeout; exempt may be nil.
+// A client that sends an X-Request-Deadline earlier than the timeout gets its own
+// deadline instead, which bank calls made for the request then keep to.
+func Timeout(timeout time.Duration, exempt func(*http.Request) bool, logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			if exempt != nil && exempt(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			deadline := time.Now().Add(timeout)
+			if header := r.Header.Get(RequestDeadlineHeader); header != "" {
+				clientDeadline, err := time.Parse(time.RFC3339Nano, header)
+				if err != nil {
+					handlers.WriteError(w, application.NewInvalidInputError(
+						fmt.Errorf("%s must be an RFC 3339 time", RequestDeadlineHeader),
+					), logger)
+					return
+				}
+				if !clientDeadline.After(time.Now()) {
+					handlers.WriteError(w, application.NewTimeoutError(), logger)
+					return
+				}
+				if clientDeadline.Before(deadline) {
+					deadline = clientDeadline
+				}
+			}
+
+			ctx, cancel := context.WithDeadline(r.Context(), deadline)
 			defer cancel()
 
 			r = r.WithContext(ctx)
@@ -0,0 +1,114 @@
+package middleware_test
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventsPattern = "GET /payments/events/{paymentID}"
+
+// streamingMux serves an event stream that sends one event at once and another only
+// after hold, and a lookup that never answers before its context is done
+func streamingMux(hold time.Duration) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc(eventsPattern, func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		for i, status := range []string{"PENDING", "AUTHORIZED"} {
+			if i > 0 {
+				select {
+				case <-time.After(hold):
+				case <-r.Context().Done():
+					return
+				}
+			}
+			fmt.Fprintf(w, "event: status\ndata: {\"status\":%q}\n\n", status)
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	})
+	mux.HandleFunc("GET /payments/{paymentID}", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	return mux
+}
+
+func TestTimeout_EventStreamOutlivesTimeout(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+	mux := streamingMux(3 * timeout)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := httptest.NewServer(middleware.Timeout(timeout, middleware.Routes(mux, eventsPattern), logger)(mux))
+	defer server.Close()
+
+	tests := []struct {
+		name   string
+		accept string
+	}{
+		{"without an Accept header", ""},
+		{"accepting only event streams", "text/event-stream"},
+		{"with media type parameters", "text/event-stream; charset=utf-8"},
+		{"among other media types", "application/json, text/event-stream;q=0.9"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+"/payments/events/550e8400-e29b-41d4-a716-446655440000", nil)
+			require.NoError(t, err)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+
+			start := time.Now()
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+			var events []string
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+					events = append(events, data)
+				}
+			}
+			require.NoError(t, scanner.Err())
+
+			assert.Equal(t, []string{`{"status":"PENDING"}`, `{"status":"AUTHORIZED"}`}, events)
+			assert.Greater(t, time.Since(start), timeout, "the stream must outlive the timeout")
+		})
+	}
+}
+
+func TestTimeout_TimesOutOtherRoutes(t *testing.T) {
+	const timeout = 100 * time.Millisecond
+	mux := streamingMux(time.Second)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := httptest.NewServer(middleware.Timeout(timeout, middleware.Routes(mux, eventsPattern), logger)(mux))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/payments/550e8400-e29b-41d4-a716-446655440000", nil)
+	require.NoError(t, err)
+	// Asking for an event stream does not lift the timeout off another route
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusRequestTimeout, resp.StatusCode)
+}
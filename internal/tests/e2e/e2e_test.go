@@ -2,8 +2,12 @@ package e2e
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -67,6 +71,17 @@ func (suite *E2ETestSuite) waitForGateway() {
 	}
 }
 
+// awaitReadModel waits for the outbox to bring the customer's listings up to the
+// status counts given, since they are served from the read model
+func (suite *E2ETestSuite) awaitReadModel(customerID string, want []api.StatusCount) {
+	t := suite.T()
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		summary, err := suite.client.CustomerSummary(t, customerID)
+		require.NoError(c, err)
+		assert.Equal(c, want, summary.StatusCounts)
+	}, 15*time.Second, 250*time.Millisecond)
+}
+
 func (suite *E2ETestSuite) createAuthorizedPayment(orderID, customerID string) *api.Payment {
 	t := suite.T()
 
@@ -83,7 +98,7 @@ func (suite *E2ETestSuite) createAuthorizedPayment(orderID, customerID string) *
 	payment, err := suite.client.Authorize(t, authReq)
 	require.NoError(t, err, "Authorization should succeed")
 
-	assert.Equal(t, api.AUTHORIZED, payment.Status)
+	assert.Equal(t, api.PaymentStatusAUTHORIZED, payment.Status)
 	assert.NotEmpty(t, payment.BankAuthId)
 	assert.NotZero(t, payment.AuthorizedAt)
 	assert.NotZero(t, payment.ExpiresAt)
@@ -106,7 +121,7 @@ func (suite *E2ETestSuite) TestHappyPath_AuthorizeAndCapture() {
 	capturedPayment, err := suite.client.Capture(t, payment.Id)
 	require.NoError(t, err, "Capture should succeed")
 
-	assert.Equal(t, api.CAPTURED, capturedPayment.Status)
+	assert.Equal(t, api.PaymentStatusCAPTURED, capturedPayment.Status)
 	assert.NotEmpty(t, capturedPayment.BankCaptureId)
 	assert.NotZero(t, capturedPayment.CapturedAt)
 }
@@ -122,7 +137,7 @@ func (suite *E2ETestSuite) TestHappyPath_AuthorizeAndVoid() {
 	voidedPayment, err := suite.client.Void(t, payment.Id)
 	require.NoError(t, err, "Void should succeed")
 
-	assert.Equal(t, api.VOIDED, voidedPayment.Status)
+	assert.Equal(t, api.PaymentStatusVOIDED, voidedPayment.Status)
 	assert.NotEmpty(t, voidedPayment.BankVoidId)
 	assert.NotZero(t, voidedPayment.VoidedAt)
 }
@@ -140,7 +155,7 @@ func (suite *E2ETestSuite) TestHappyPath_AuthorizeCaptureRefund() {
 	refundedPayment, err := suite.client.Refund(t, payment.Id)
 	require.NoError(t, err, "Refund should succeed")
 
-	assert.Equal(t, api.REFUNDED, refundedPayment.Status)
+	assert.Equal(t, api.PaymentStatusREFUNDED, refundedPayment.Status)
 	assert.NotEmpty(t, refundedPayment.BankRefundId)
 	assert.NotZero(t, refundedPayment.RefundedAt)
 
@@ -156,6 +171,7 @@ func (suite *E2ETestSuite) Test_FindByCustomerID_WithPagination() {
 		orderID := "order-" + uuid.New().String()
 		suite.createAuthorizedPayment(orderID, customerID)
 	}
+	suite.awaitReadModel(customerID, []api.StatusCount{{Status: "AUTHORIZED", Count: 5}})
 
 	page1, err := suite.client.GetByCustomerID(t, customerID, 2, 0)
 	require.NoError(suite.T(), err)
@@ -177,6 +193,165 @@ func (suite *E2ETestSuite) Test_FindByCustomerID_WithPagination() {
 	}
 }
 
+func (suite *E2ETestSuite) Test_FindByCustomerID_FiltersByStatusAndDate() {
+	t := suite.T()
+
+	customerID := "cust-" + uuid.New().String()
+	start := time.Now().Add(-time.Minute)
+	authorized := suite.createAuthorizedPayment("order-"+uuid.New().String(), customerID)
+	captured := suite.createAuthorizedPayment("order-"+uuid.New().String(), customerID)
+	_, err := suite.client.Capture(t, captured.Id)
+	require.NoError(t, err)
+	suite.awaitReadModel(customerID, []api.StatusCount{{Status: "AUTHORIZED", Count: 1}, {Status: "CAPTURED", Count: 1}})
+
+	payments, err := suite.client.FilterByCustomerID(t, customerID, url.Values{"status": {"CAPTURED,REFUNDED"}})
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, captured.Id, payments[0].Id)
+
+	payments, err = suite.client.FilterByCustomerID(t, customerID, url.Values{
+		"status": {"AUTHORIZED"},
+		"from":   {start.Format(time.RFC3339)},
+	})
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, authorized.Id, payments[0].Id)
+
+	payments, err = suite.client.FilterByCustomerID(t, customerID, url.Values{"to": {start.Format(time.RFC3339)}})
+	require.NoError(t, err)
+	assert.Empty(t, payments)
+
+	_, err = suite.client.FilterByCustomerID(t, customerID, url.Values{"status": {"SETTLED"}})
+	assert.ErrorContains(t, err, "status 400")
+}
+
+func (suite *E2ETestSuite) Test_CustomerPaymentSummary() {
+	t := suite.T()
+
+	customerID := "cust-" + uuid.New().String()
+	suite.createAuthorizedPayment("order-"+uuid.New().String(), customerID)
+	captured := suite.createAuthorizedPayment("order-"+uuid.New().String(), customerID)
+	_, err := suite.client.Capture(t, captured.Id)
+	require.NoError(t, err)
+	suite.awaitReadModel(customerID, []api.StatusCount{{Status: "AUTHORIZED", Count: 1}, {Status: "CAPTURED", Count: 1}})
+
+	summary, err := suite.client.CustomerSummary(t, customerID)
+	require.NoError(t, err)
+
+	assert.Equal(t, customerID, summary.CustomerId)
+	assert.Equal(t, 2, summary.PaymentCount)
+	assert.Equal(t, []api.StatusCount{{Status: "AUTHORIZED", Count: 1}, {Status: "CAPTURED", Count: 1}}, summary.StatusCounts)
+	require.Len(t, summary.Totals, 1)
+	assert.Equal(t, api.CurrencyTotal{Currency: "USD", AuthorizedCents: 10000, CapturedCents: 5000}, summary.Totals[0])
+	assert.False(t, summary.LastPaymentAt.Before(captured.CreatedAt))
+
+	empty, err := suite.client.CustomerSummary(t, "cust-"+uuid.New().String())
+	require.NoError(t, err)
+	assert.Zero(t, empty.PaymentCount)
+	assert.Empty(t, empty.Totals)
+}
+
+func (suite *E2ETestSuite) Test_GetByID_ConditionalGet() {
+	t := suite.T()
+
+	payment := suite.createAuthorizedPayment("order-"+uuid.New().String(), "cust-"+uuid.New().String())
+
+	status, etag, fetched, err := suite.client.GetByID(t, payment.Id, "")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, payment.Id, fetched.Id)
+	require.NotEmpty(t, etag)
+
+	status, notModifiedTag, fetched, err := suite.client.GetByID(t, payment.Id, `"stale", W/`+etag)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNotModified, status)
+	assert.Equal(t, etag, notModifiedTag)
+	assert.Nil(t, fetched)
+
+	_, err = suite.client.Capture(t, payment.Id)
+	require.NoError(t, err)
+
+	status, capturedTag, fetched, err := suite.client.GetByID(t, payment.Id, etag)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status, "a changed payment is sent in full")
+	assert.Equal(t, api.PaymentStatusCAPTURED, fetched.Status)
+	assert.NotEqual(t, etag, capturedTag)
+}
+
+func (suite *E2ETestSuite) Test_BatchGet_SkipsUnknownIDs() {
+	t := suite.T()
+
+	customerID := "cust-" + uuid.New().String()
+	first := suite.createAuthorizedPayment("order-"+uuid.New().String(), customerID)
+	second := suite.createAuthorizedPayment("order-"+uuid.New().String(), customerID)
+
+	payments, err := suite.client.BatchGet(t, []uuid.UUID{first.Id, uuid.New(), second.Id, first.Id})
+	require.NoError(t, err)
+
+	require.Len(t, payments, 2)
+	assert.Equal(t, second.Id, payments[0].Id, "newest payment comes first")
+	assert.Equal(t, first.Id, payments[1].Id)
+}
+
+func (suite *E2ETestSuite) Test_BatchGet_RejectsTooManyIDs() {
+	ids := make([]uuid.UUID, 101)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+
+	_, err := suite.client.BatchGet(suite.T(), ids)
+
+	assert.ErrorContains(suite.T(), err, "status 400")
+}
+
+func (suite *E2ETestSuite) Test_Capture_RejectsUnknownField() {
+	t := suite.T()
+	payment := suite.createAuthorizedPayment("order-"+uuid.New().String(), "cust-"+uuid.New().String())
+
+	body := fmt.Appendf(nil, `{"payment_id": %q, "amout": 2000}`, payment.Id)
+	status, errResp, err := suite.client.PostRaw("/capture", body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusBadRequest, status)
+	assert.Equal(t, api.INVALIDINPUT, errResp.Error.Code)
+	assert.Contains(t, errResp.Error.Message, `unknown field "amout"`)
+
+	saved, err := suite.client.GetByOrderID(t, payment.OrderId)
+	require.NoError(t, err)
+	assert.Equal(t, api.PaymentStatusAUTHORIZED, saved.Status, "nothing was captured")
+}
+
+func (suite *E2ETestSuite) Test_Authorize_RejectsOversizedBody() {
+	body := fmt.Appendf(nil, `{"order_id": %q}`, strings.Repeat("x", 2<<20))
+	status, errResp, err := suite.client.PostRaw("/authorize", body)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, status)
+	assert.Contains(suite.T(), errResp.Error.Message, "body exceeds")
+}
+
+func (suite *E2ETestSuite) Test_UnknownRoutes_AnswerWithJSON() {
+	t := suite.T()
+
+	resp, err := http.Get(suite.client.baseURL + "/capture")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var errResp api.ErrorResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal(t, "POST", resp.Header.Get("Allow"))
+	assert.Equal(t, api.METHODNOTALLOWED, errResp.Error.Code)
+
+	resp, err = http.Get(suite.client.baseURL + "/no-such-route")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, api.ROUTENOTFOUND, errResp.Error.Code)
+}
+
 // ============================================================================
 // FAILURE MODE: Insufficient Funds
 // ============================================================================
@@ -200,7 +375,7 @@ func (suite *E2ETestSuite) TestFailure_ExpiredCard() {
 	assert.Contains(t, err.Error(), "card_expired")
 
 	if payment != nil {
-		assert.Equal(t, api.FAILED, payment.Status)
+		assert.Equal(t, api.PaymentStatusFAILED, payment.Status)
 	}
 }
 
@@ -223,7 +398,7 @@ func (suite *E2ETestSuite) TestFailure_InsufficientFunds() {
 	assert.Contains(t, err.Error(), "insufficient_funds")
 
 	if payment != nil {
-		assert.Equal(t, api.FAILED, payment.Status)
+		assert.Equal(t, api.PaymentStatusFAILED, payment.Status)
 	}
 }
 
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
@@ -151,6 +153,38 @@ func (c *TestClient) Refund(t *testing.T, paymentID uuid.UUID) (*api.Payment, er
 	return &paymentResp.Data, nil
 }
 
+// GetByID fetches a payment, sending ifNoneMatch when set. It returns the status code
+// and ETag along with the payment, which is nil on a 304.
+func (c *TestClient) GetByID(t *testing.T, paymentID uuid.UUID, ifNoneMatch string) (int, string, *api.Payment, error) {
+	url := fmt.Sprintf("%s/payments/%s", c.baseURL, paymentID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+	if ifNoneMatch != "" {
+		httpReq.Header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	etag := resp.Header.Get("ETag")
+
+	if resp.StatusCode == http.StatusNotModified {
+		return resp.StatusCode, etag, nil, nil
+	}
+	if resp.StatusCode >= 400 {
+		var errResp api.ErrorResponse
+		json.Unmarshal(bodyBytes, &errResp)
+		return resp.StatusCode, "", nil, fmt.Errorf("status %d: %s", resp.StatusCode, errResp.Error.Message)
+	}
+
+	var paymentResp api.PaymentResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &paymentResp))
+	return resp.StatusCode, etag, &paymentResp.Data, nil
+}
+
 func (c *TestClient) GetByOrderID(t *testing.T, orderID string) (*api.Payment, error) {
 	url := fmt.Sprintf("%s/payments/order/%s", c.baseURL, orderID)
 	httpReq, _ := http.NewRequest("GET", url, nil)
@@ -177,8 +211,16 @@ func (c *TestClient) GetByOrderID(t *testing.T, orderID string) (*api.Payment, e
 }
 
 func (c *TestClient) GetByCustomerID(t *testing.T, customerID string, limit, offset int) ([]api.Payment, error) {
-	url := fmt.Sprintf("%s/payments/customer/%s?limit=%d&offset=%d",
-		c.baseURL, customerID, limit, offset)
+	return c.FilterByCustomerID(t, customerID, url.Values{
+		"limit":  {strconv.Itoa(limit)},
+		"offset": {strconv.Itoa(offset)},
+	})
+}
+
+// FilterByCustomerID lists a customer's payments with the given query parameters, such
+// as status, from and to
+func (c *TestClient) FilterByCustomerID(t *testing.T, customerID string, query url.Values) ([]api.Payment, error) {
+	url := fmt.Sprintf("%s/payments/customer/%s?%s", c.baseURL, customerID, query.Encode())
 
 	httpReq, _ := http.NewRequest("GET", url, nil)
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -205,6 +247,75 @@ func (c *TestClient) GetByCustomerID(t *testing.T, customerID string, limit, off
 	return response.Data, nil
 }
 
+func (c *TestClient) CustomerSummary(t *testing.T, customerID string) (*api.CustomerPaymentSummary, error) {
+	url := fmt.Sprintf("%s/customers/%s/payment-summary", c.baseURL, customerID)
+	httpReq, _ := http.NewRequest("GET", url, nil)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		var errResp api.ErrorResponse
+		json.Unmarshal(bodyBytes, &errResp)
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, errResp.Error.Message)
+	}
+
+	var summaryResp api.CustomerPaymentSummaryResponse
+	require.NoError(t, json.Unmarshal(bodyBytes, &summaryResp))
+	return &summaryResp.Data, nil
+}
+
+func (c *TestClient) BatchGet(t *testing.T, ids []uuid.UUID) ([]api.Payment, error) {
+	body, _ := json.Marshal(api.BatchGetPaymentsRequest{Ids: ids})
+	httpReq, _ := http.NewRequest("POST", c.baseURL+"/payments/batch-get", bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode >= 400 {
+		var errResp api.ErrorResponse
+		json.Unmarshal(bodyBytes, &errResp)
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, errResp.Error.Message)
+	}
+
+	var response struct {
+		Success bool          `json:"success"`
+		Data    []api.Payment `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bodyBytes, &response))
+	return response.Data, nil
+}
+
+// PostRaw sends body to path as it is, for requests the API types cannot express
+func (c *TestClient) PostRaw(path string, body []byte) (int, *api.ErrorResponse, error) {
+	httpReq, _ := http.NewRequest("POST", c.baseURL+path, bytes.NewReader(body))
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Idempotency-Key", "e2e-raw-"+uuid.New().String())
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, _ := io.ReadAll(resp.Body)
+
+	var errResp api.ErrorResponse
+	json.Unmarshal(bodyBytes, &errResp)
+	return resp.StatusCode, &errResp, nil
+}
+
 func (c *TestClient) AuthorizeWithKey(t *testing.T, req api.AuthorizeRequest, idempotencyKey string) (*api.Payment, error) {
 	body, _ := json.Marshal(req)
 	httpReq, _ := http.NewRequest("POST", c.baseURL+"/authorize", bytes.NewReader(body))
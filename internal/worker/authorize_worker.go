@@ -0,0 +1,128 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// AuthorizeWorker performs bank authorizations accepted through the async API.
+// Jobs are kept in memory only because card data must never be persisted; if the
+// process dies before a job runs, the payment stays PENDING and is failed by the
+// RetryWorker's unauthorized payment timeout.
+type AuthorizeWorker struct {
+	authService *services.AuthorizeService
+	jobs        chan authorizeJob
+	concurrency int
+	logger      *slog.Logger
+}
+
+type authorizeJob struct {
+	payment        *domain.Payment
+	cmd            services.AuthorizeCommand
+	idempotencyKey string
+	// softDeclineRetry marks the second attempt at an authorization the bank
+	// soft-declined
+	softDeclineRetry bool
+}
+
+func NewAuthorizeWorker(
+	authService *services.AuthorizeService,
+	queueSize int,
+	concurrency int,
+	logger *slog.Logger,
+) *AuthorizeWorker {
+	return &AuthorizeWorker{
+		authService: authService,
+		jobs:        make(chan authorizeJob, queueSize),
+		concurrency: concurrency,
+		logger:      logger,
+	}
+}
+
+// Submit enqueues an authorization. It returns false when the queue is full so the
+// caller can fall back to authorizing synchronously.
+func (w *AuthorizeWorker) Submit(payment *domain.Payment, cmd services.AuthorizeCommand, idempotencyKey string) bool {
+	select {
+	case w.jobs <- authorizeJob{payment: payment, cmd: cmd, idempotencyKey: idempotencyKey}:
+		return true
+	default:
+		return false
+	}
+}
+
+// SubmitRetry enqueues the retry of a soft-declined authorization once delay has
+// passed. A retry that then finds the queue full is dropped, and the payment is failed
+// by the RetryWorker's unauthorized payment timeout.
+func (w *AuthorizeWorker) SubmitRetry(delay time.Duration, payment *domain.Payment, cmd services.AuthorizeCommand, idempotencyKey string) {
+	time.AfterFunc(delay, func() {
+		job := authorizeJob{payment: payment, cmd: cmd, idempotencyKey: idempotencyKey, softDeclineRetry: true}
+		select {
+		case w.jobs <- job:
+		default:
+			w.logger.Warn("authorize queue full, soft decline retry dropped",
+				"payment_id", payment.ID,
+				"order_id", payment.OrderID)
+		}
+	})
+}
+
+func (w *AuthorizeWorker) Start(ctx context.Context) {
+	w.logger.Info("authorize worker started", "concurrency", w.concurrency)
+
+	var wg sync.WaitGroup
+	for range w.concurrency {
+		wg.Go(func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-w.jobs:
+					w.process(ctx, job)
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+	w.logger.Info("authorize worker stopping", "pending_jobs", len(w.jobs))
+}
+
+func (w *AuthorizeWorker) process(ctx context.Context, job authorizeJob) {
+	ctx = postgres.WithActor(postgres.WithMerchant(ctx, job.payment.MerchantID), domain.ActorAPI)
+
+	var payment *domain.Payment
+	var err error
+	if job.softDeclineRetry {
+		payment, err = w.authService.RetrySoftDecline(ctx, job.payment, &job.cmd, job.idempotencyKey)
+	} else {
+		// Jobs come from the payments API, which answers before the bank does
+		payment, err = w.authService.CompleteAuthorize(services.AllowSoftDeclineRetry(ctx), job.payment, &job.cmd, job.idempotencyKey)
+	}
+	if err != nil {
+		w.logger.Error("async authorization failed",
+			"payment_id", job.payment.ID,
+			"order_id", job.payment.OrderID,
+			"error", err)
+		return
+	}
+
+	w.logger.Info("async authorization completed",
+		"payment_id", payment.ID,
+		"status", payment.Status)
+}
+
+// Pending returns how many authorizations are queued and not yet picked up
+func (w *AuthorizeWorker) Pending() int {
+	return len(w.jobs)
+}
+
+// Capacity returns how many authorizations the queue holds before Submit refuses more
+func (w *AuthorizeWorker) Capacity() int {
+	return cap(w.jobs)
+}
@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+)
+
+// BatchWorker runs the items of bulk operations accepted by the API
+type BatchWorker struct {
+	heartbeat
+
+	batchService *services.BatchService
+	interval     time.Duration
+	batchSize    int
+	logger       *slog.Logger
+}
+
+func NewBatchWorker(
+	batchService *services.BatchService,
+	interval time.Duration,
+	batchSize int,
+	logger *slog.Logger,
+) *BatchWorker {
+	return &BatchWorker{
+		batchService: batchService,
+		interval:     interval,
+		batchSize:    batchSize,
+		logger:       logger,
+	}
+}
+
+func (w *BatchWorker) Start(ctx context.Context) {
+	w.logger.Info("batch worker started", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("batch worker stopping")
+			return
+		case <-ticker.C:
+			w.ProcessPending(ctx)
+			w.beat()
+		}
+	}
+}
+
+// ProcessPending runs one round of pending items. Failed items are recorded on the
+// batch, and items that hit a transient error are retried on the next run, so errors
+// here are only logged.
+func (w *BatchWorker) ProcessPending(ctx context.Context) {
+	count, err := w.batchService.ProcessPending(ctx, w.batchSize)
+	if err != nil {
+		w.logger.Error("batch items failed", "error", err)
+	}
+	if count > 0 {
+		w.logger.Info("processed batch items", "count", count)
+	}
+}
@@ -7,13 +7,17 @@ import (
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
 )
 
 type ExpirationWorker struct {
+	heartbeat
+
 	paymentRepo *postgres.PaymentRepository
 	bankClient  bank.BankClient
 	interval    time.Duration
+	clock       clock.Clock
 	logger      *slog.Logger
 }
 
@@ -27,10 +31,17 @@ func NewExpirationWorker(
 		paymentRepo: paymentRepo,
 		bankClient:  bankClient,
 		interval:    interval,
+		clock:       clock.System{},
 		logger:      logger,
 	}
 }
 
+// WithClock ages authorizations by c rather than the system clock
+func (w *ExpirationWorker) WithClock(c clock.Clock) *ExpirationWorker {
+	w.clock = c
+	return w
+}
+
 func (w *ExpirationWorker) Start(ctx context.Context) {
 	w.logger.Info("expiration worker started", "interval", w.interval)
 	ticker := time.NewTicker(w.interval)
@@ -45,12 +56,13 @@ func (w *ExpirationWorker) Start(ctx context.Context) {
 			if err := w.processExpirations(ctx); err != nil {
 				w.logger.Error("expiration processing failed", "error", err)
 			}
+			w.beat()
 		}
 	}
 }
 
 func (w *ExpirationWorker) processExpirations(ctx context.Context) error {
-	cutoffTime := time.Now().Add(-8 * 24 * time.Hour)
+	cutoffTime := w.clock.Now().Add(-8 * 24 * time.Hour)
 
 	expiredPayments, err := w.paymentRepo.FindExpiredAuthorizations(ctx, cutoffTime, 100)
 	if err != nil {
@@ -82,7 +94,9 @@ func (w *ExpirationWorker) processExpirations(ctx context.Context) error {
 }
 
 func (w *ExpirationWorker) checkAndMarkExpired(ctx context.Context, payment *domain.Payment) error {
-	bankAuth, err := w.bankClient.GetAuthorization(ctx, *payment.BankAuthID)
+	ctx = postgres.WithMerchant(ctx, payment.MerchantID)
+
+	bankAuth, err := w.bankClient.GetAuthorization(bank.WithAcquirer(ctx, payment.Acquirer), *payment.BankAuthID)
 
 	if err != nil {
 		if bankErr, ok := bank.IsBankError(err); ok {
@@ -95,7 +109,7 @@ func (w *ExpirationWorker) checkAndMarkExpired(ctx context.Context, payment *dom
 	}
 
 	if bankAuth.Status == "AUTHORIZED" {
-		if time.Since(*payment.AuthorizedAt) > 9*24*time.Hour {
+		if w.clock.Now().Sub(*payment.AuthorizedAt) > 9*24*time.Hour {
 			w.logger.Error("FORCE_EXPIRED", "payment_id", payment.ID)
 			return w.markAsExpired(ctx, payment)
 		}
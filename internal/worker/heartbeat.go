@@ -0,0 +1,26 @@
+package worker
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// heartbeat records when a polling worker last finished a run, so the health check
+// can tell a worker that stopped polling from one with nothing to do
+type heartbeat struct {
+	lastRun atomic.Int64
+}
+
+func (h *heartbeat) beat() {
+	h.lastRun.Store(time.Now().UnixNano())
+}
+
+// LastRun returns when the worker last finished a run, or the zero time before its
+// first run
+func (h *heartbeat) LastRun() time.Time {
+	nanos := h.lastRun.Load()
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
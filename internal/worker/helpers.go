@@ -2,20 +2,53 @@ package worker
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/alert"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
 )
 
+// resumeOperation completes the operation started with the idempotency key. When
+// lookup is set, the bank is first asked whether it already carried the operation out,
+// and callBank only resends it when it did not. An operation the bank has no record of
+// whose key never left CALLING_BANK was cut off before reaching the bank, and is rolled
+// back instead.
 func (w *RetryWorker) resumeOperation(
 	ctx context.Context,
 	payment *domain.Payment,
 	idempotencyKey string,
+	lookup func(ctx context.Context, idempotencyKey string) (any, error),
 	callBank func(ctx context.Context, idempotencyKey string) (any, error),
 	applyResponse func(payment *domain.Payment, response any) error,
 ) error {
+	if lookup != nil {
+		resp, err := lookup(ctx, idempotencyKey)
+		if err == nil {
+			w.logger.Info("operation already completed at bank",
+				"payment_id", payment.ID,
+				"status", payment.Status,
+				"idempotency_key", idempotencyKey)
+			return w.completeOperation(ctx, payment, idempotencyKey, resp, applyResponse)
+		}
+
+		if bankErr, ok := bank.IsBankError(err); !ok || bankErr.StatusCode != http.StatusNotFound {
+			// Resending under the same key is still safe
+			w.logger.Warn("bank lookup failed, resending operation",
+				"payment_id", payment.ID,
+				"status", payment.Status,
+				"error", err)
+		} else if w.neverReachedBank(ctx, idempotencyKey) {
+			return w.rollBack(ctx, payment, idempotencyKey, err)
+		}
+	}
+
 	resp, err := callBank(ctx, idempotencyKey)
 	if err != nil {
 		if hferr := services.HandleBankFailure(
@@ -23,12 +56,13 @@ func (w *RetryWorker) resumeOperation(
 			w.db,
 			w.paymentRepo,
 			w.idempotencyRepo,
+			w.operationRepo,
 			payment,
 			idempotencyKey,
 			err,
 		); hferr != nil {
 			if application.IsRetryable(hferr) {
-				return w.scheduleRetry(ctx, payment)
+				return w.scheduleRetry(ctx, payment, idempotencyKey, hferr)
 			}
 			return hferr
 		}
@@ -36,6 +70,16 @@ func (w *RetryWorker) resumeOperation(
 		return err
 	}
 
+	return w.completeOperation(ctx, payment, idempotencyKey, resp, applyResponse)
+}
+
+func (w *RetryWorker) completeOperation(
+	ctx context.Context,
+	payment *domain.Payment,
+	idempotencyKey string,
+	resp any,
+	applyResponse func(payment *domain.Payment, response any) error,
+) error {
 	if err := applyResponse(payment, resp); err != nil {
 		return err
 	}
@@ -45,21 +89,129 @@ func (w *RetryWorker) resumeOperation(
 		w.db,
 		w.paymentRepo,
 		w.idempotencyRepo,
+		w.operationRepo,
 		payment,
 		idempotencyKey,
 		resp,
 	)
 }
 
-func (w *RetryWorker) scheduleRetry(ctx context.Context, payment *domain.Payment) error {
-	backoff := w.calculateBackoff(payment.AttemptCount)
-	payment.ScheduleRetry(backoff)
-	return w.paymentRepo.Update(ctx, nil, payment)
+// neverReachedBank reports whether the request holding the idempotency key stopped at
+// CALLING_BANK longer than a poll ago. The wait keeps a call the bank is still working
+// on, such as one that just timed out, from being taken for one it never got.
+func (w *RetryWorker) neverReachedBank(ctx context.Context, idempotencyKey string) bool {
+	key, err := w.idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	if err != nil || key == nil || key.LockedAt == nil {
+		return false
+	}
+	return key.RecoveryPoint == postgres.RecoveryCallingBank && time.Since(*key.LockedAt) > w.interval
+}
+
+// rollBack returns a payment whose operation the bank has no record of, notFound, to
+// the status it left, so the merchant can start the operation again
+func (w *RetryWorker) rollBack(ctx context.Context, payment *domain.Payment, idempotencyKey string, notFound error) error {
+	w.logger.Warn("OPERATION_ROLLED_BACK",
+		"payment_id", payment.ID,
+		"status", payment.Status,
+		"idempotency_key", idempotencyKey,
+		"reason", "no record at bank")
+
+	err := services.RollBackOperation(
+		ctx,
+		w.db,
+		w.paymentRepo,
+		w.idempotencyRepo,
+		w.operationRepo,
+		payment,
+		idempotencyKey,
+		notFound,
+	)
+	if errors.Is(err, notFound) {
+		return nil
+	}
+	return err
+}
+
+// operationAmount returns the amount recorded for the operation started with the
+// idempotency key, or fallback for payments that predate operation records.
+func (w *RetryWorker) operationAmount(ctx context.Context, idempotencyKey string, fallback int64) (int64, error) {
+	op, err := w.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, postgres.ErrOperationNotFound) {
+			return fallback, nil
+		}
+		return 0, err
+	}
+	return op.AmountCents, nil
+}
+
+// scheduleRetry books the payment's next attempt after a transient failure, cause, or
+// gives up on it once that was its last
+func (w *RetryWorker) scheduleRetry(ctx context.Context, payment *domain.Payment, idempotencyKey string, cause error) error {
+	backoff := w.backoffFor(payment.Status).Delay(payment.AttemptCount)
+	payment.ScheduleRetry(backoff, w.clock.Now())
+	payment.RecordError(string(application.CategorizeError(cause)))
+	if payment.AttemptCount < w.maxAttemptsFor(payment.Status) {
+		return w.paymentRepo.Update(ctx, nil, payment)
+	}
+	return w.exhaust(ctx, payment, idempotencyKey, cause)
 }
 
-func (w *RetryWorker) calculateBackoff(attemptCount int) time.Duration {
-	exponentialMinutes := 1 << attemptCount
-	cappedMinutes := min(exponentialMinutes, int(w.maxBackoff))
+// exhaust gives up on a payment whose attempts ran out as the worker is configured to
+func (w *RetryWorker) exhaust(ctx context.Context, payment *domain.Payment, idempotencyKey string, cause error) error {
+	w.logger.Error("RETRIES_EXHAUSTED",
+		"payment_id", payment.ID,
+		"status", payment.Status,
+		"attempts", payment.AttemptCount,
+		"action", w.onExhausted,
+		"error", cause)
+
+	switch w.onExhausted {
+	case ExhaustedFail:
+		err := services.AbandonOperation(
+			ctx,
+			w.db,
+			w.paymentRepo,
+			w.idempotencyRepo,
+			w.operationRepo,
+			payment,
+			idempotencyKey,
+			cause,
+		)
+		if errors.Is(err, cause) {
+			return nil
+		}
+		return err
+
+	case ExhaustedDeadLetter:
+		if err := w.paymentRepo.Update(ctx, nil, payment); err != nil {
+			return err
+		}
+		return w.deadLetters.Create(ctx, domain.NewDeadLetter(payment, idempotencyKey, cause))
+
+	case ExhaustedAlert:
+		if err := w.paymentRepo.Update(ctx, nil, payment); err != nil {
+			return err
+		}
+		w.alertExhausted(ctx, payment, cause)
+	}
+	return nil
+}
 
-	return time.Duration(cappedMinutes) * time.Minute
+// alertExhausted raises a critical alert for a payment left stuck mid-transition
+func (w *RetryWorker) alertExhausted(ctx context.Context, payment *domain.Payment, cause error) {
+	if w.alerts != nil {
+		w.alerts.Notify(ctx, alert.Alert{
+			Key:      fmt.Sprintf("retries_exhausted:%s:%s", payment.ID, payment.Status),
+			Summary:  fmt.Sprintf("Payment %s is stuck in %s after %d attempts", payment.ID, payment.Status, payment.AttemptCount),
+			Severity: alert.SeverityCritical,
+			Details: map[string]any{
+				"payment_id":  payment.ID,
+				"merchant_id": payment.MerchantID,
+				"status":      payment.Status,
+				"attempts":    payment.AttemptCount,
+				"last_error":  cause.Error(),
+			},
+		})
+	}
 }
@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/jackc/pgx/v5"
+)
+
+// OutboxWorker delivers transition events from the outbox to the hook registry
+type OutboxWorker struct {
+	heartbeat
+
+	outboxRepo *postgres.OutboxRepository
+	registry   *hooks.Registry
+	db         *postgres.DB
+	interval   time.Duration
+	batchSize  int
+	logger     *slog.Logger
+	// paused is whether the last run found delivery paused, so the change is logged once
+	paused bool
+}
+
+func NewOutboxWorker(
+	outboxRepo *postgres.OutboxRepository,
+	registry *hooks.Registry,
+	db *postgres.DB,
+	interval time.Duration,
+	batchSize int,
+	logger *slog.Logger,
+) *OutboxWorker {
+	return &OutboxWorker{
+		outboxRepo: outboxRepo,
+		registry:   registry,
+		db:         db,
+		interval:   interval,
+		batchSize:  batchSize,
+		logger:     logger,
+	}
+}
+
+func (w *OutboxWorker) Start(ctx context.Context) {
+	w.logger.Info("outbox worker started", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("outbox worker stopping")
+			return
+		case <-ticker.C:
+			if err := w.ProcessOutbox(ctx); err != nil {
+				w.logger.Error("outbox processing failed", "error", err)
+			}
+			w.beat()
+		}
+	}
+}
+
+// ProcessOutbox dispatches one batch of events, unless delivery is paused. An event
+// whose hooks fail stays in the outbox and is dispatched again on the next run.
+func (w *OutboxWorker) ProcessOutbox(ctx context.Context) error {
+	pause, err := w.outboxRepo.FindPause(ctx)
+	if err != nil {
+		return err
+	}
+	if paused := pause != nil; paused != w.paused {
+		w.paused = paused
+		if paused {
+			w.logger.Warn("outbox delivery paused", "paused_at", pause.PausedAt, "reason", pause.Reason)
+		} else {
+			w.logger.Info("outbox delivery resumed")
+		}
+	}
+	if w.paused {
+		return nil
+	}
+
+	tx, err := w.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return fmt.Errorf("begin outbox transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // rollback error is not critical in defer
+
+	events, err := w.outboxRepo.ClaimBatch(ctx, tx, w.batchSize)
+	if err != nil {
+		return err
+	}
+
+	var delivered int
+	for _, event := range events {
+		ctx := postgres.WithMerchant(ctx, event.MerchantID)
+		if dispatchErr := w.registry.Dispatch(ctx, event); dispatchErr != nil {
+			w.logger.Error("outbox event delivery failed",
+				"event_id", event.ID,
+				"payment_id", event.PaymentID,
+				"event_type", event.EventType,
+				"attempts", event.Attempts+1,
+				"error", dispatchErr)
+			if err := w.outboxRepo.MarkFailed(ctx, tx, event.ID, dispatchErr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := w.outboxRepo.MarkProcessed(ctx, tx, event.ID); err != nil {
+			return err
+		}
+		delivered++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit outbox transaction: %w", err)
+	}
+
+	if delivered > 0 {
+		w.logger.Info("delivered outbox events", "count", delivered)
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/hooks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxWorker_DeliversTransitionsToHooks(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+	authService := services.NewAuthorizeService(paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(testDB.DB), mockBank, testDB.DB, services.AuthorizeLimits{})
+
+	payment := testhelpers.CreateAuthorizedPayment(t, postgres.WithActor(ctx, domain.ActorAPI), authService, mockBank)
+
+	var delivered []*domain.TransitionEvent
+	registry := hooks.NewRegistry()
+	registry.OnAny("test", func(_ context.Context, event *domain.TransitionEvent) error {
+		delivered = append(delivered, event)
+		return nil
+	})
+
+	outboxWorker := worker.NewOutboxWorker(
+		postgres.NewOutboxRepository(testDB.DB),
+		registry,
+		testDB.DB,
+		time.Second,
+		100,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	)
+
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+
+	require.Len(t, delivered, 2)
+	assert.Equal(t, payment.ID, delivered[0].PaymentID)
+	assert.Nil(t, delivered[0].FromStatus)
+	assert.Equal(t, domain.StatusPending, delivered[0].ToStatus)
+	assert.Equal(t, "payment.authorized", delivered[1].EventType)
+	require.NotNil(t, delivered[1].FromStatus)
+	assert.Equal(t, domain.StatusPending, *delivered[1].FromStatus)
+	assert.Equal(t, domain.ActorAPI, delivered[1].Actor)
+	assert.Equal(t, 0, delivered[1].AttemptCount)
+	assert.Equal(t, domain.TransitionSchemaVersion, delivered[1].SchemaVersion)
+	assert.Contains(t, string(delivered[1].Payload), payment.ID)
+
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+	assert.Len(t, delivered, 2, "delivered events must not be dispatched again")
+}
+
+func TestOutboxWorker_RetriesFailedHooks(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+	authService := services.NewAuthorizeService(paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(testDB.DB), mockBank, testDB.DB, services.AuthorizeLimits{})
+
+	testhelpers.CreateAuthorizedPayment(t, ctx, authService, mockBank)
+
+	fail := true
+	var delivered int
+	registry := hooks.NewRegistry()
+	registry.On(domain.StatusAuthorized, "flaky", func(context.Context, *domain.TransitionEvent) error {
+		if fail {
+			return errors.New("downstream unavailable")
+		}
+		delivered++
+		return nil
+	})
+
+	outboxWorker := worker.NewOutboxWorker(
+		postgres.NewOutboxRepository(testDB.DB),
+		registry,
+		testDB.DB,
+		time.Second,
+		100,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	)
+
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+	assert.Equal(t, 0, delivered)
+
+	fail = false
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+	assert.Equal(t, 1, delivered)
+
+	var attempts int
+	err := testDB.DB.QueryRow(ctx,
+		"SELECT attempts FROM outbox WHERE to_status = 'AUTHORIZED'",
+	).Scan(&attempts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestOutboxWorker_HoldsEventsWhilePaused(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+	authService := services.NewAuthorizeService(paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(testDB.DB), mockBank, testDB.DB, services.AuthorizeLimits{})
+
+	testhelpers.CreateAuthorizedPayment(t, ctx, authService, mockBank)
+
+	var delivered int
+	registry := hooks.NewRegistry()
+	registry.OnAny("test", func(context.Context, *domain.TransitionEvent) error {
+		delivered++
+		return nil
+	})
+
+	outboxRepo := postgres.NewOutboxRepository(testDB.DB)
+	outboxService := services.NewOutboxService(outboxRepo)
+	outboxWorker := worker.NewOutboxWorker(
+		outboxRepo,
+		registry,
+		testDB.DB,
+		time.Second,
+		100,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	)
+
+	status, err := outboxService.Pause(ctx, "consumer incident")
+	require.NoError(t, err)
+	require.NotNil(t, status.Pause)
+	assert.Equal(t, "consumer incident", status.Pause.Reason)
+
+	_, err = outboxService.Pause(ctx, "second pause")
+	require.NoError(t, err)
+
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+	assert.Equal(t, 0, delivered)
+
+	status, err = outboxService.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, status.Pending)
+	assert.NotNil(t, status.OldestOccurredAt)
+	assert.Equal(t, "consumer incident", status.Pause.Reason, "pausing again keeps the first pause")
+
+	status, err = outboxService.Resume(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, status.Pause)
+
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+	assert.Equal(t, 2, delivered)
+}
+
+func TestOutboxWorker_DispatchesPayloadsTheCurrentSchemaAccepts(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	outboxRepo := postgres.NewOutboxRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+	authService := services.NewAuthorizeService(paymentRepo, idempotencyRepo, postgres.NewMerchantSettingsRepository(testDB.DB), mockBank, testDB.DB, services.AuthorizeLimits{})
+
+	authorized := testhelpers.CreateAuthorizedPayment(t, ctx, authService, mockBank)
+
+	// A declined payment carries a decline category, the newest field of the payload
+	declineKey := "idem-" + uuid.New().String()
+	mockBank.EXPECT().
+		Authorize(mock.Anything, mock.Anything, declineKey).
+		Return(nil, &bank.BankError{Code: "insufficient_funds", Message: "Insufficient funds", StatusCode: 402}).
+		Once()
+	cmd := testhelpers.DefaultAuthorizeCommand()
+	_, err := authService.Authorize(ctx, &cmd, declineKey)
+	require.Error(t, err)
+	declined, err := paymentRepo.FindByIdempotencyKey(ctx, declineKey)
+	require.NoError(t, err)
+
+	schemas, err := hooks.LoadSchemas()
+	require.NoError(t, err)
+
+	for _, paymentID := range []string{authorized.ID, declined.ID} {
+		events, err := outboxRepo.FindByPaymentID(ctx, paymentID)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		for _, event := range events {
+			assert.Equal(t, domain.TransitionSchemaVersion, event.SchemaVersion)
+			assert.NoError(t, schemas.Validate(event), "payload of %s event", event.ToStatus)
+		}
+	}
+
+	var delivered int
+	registry := hooks.NewRegistry().WithSchemas(schemas)
+	registry.OnAny("test", func(context.Context, *domain.TransitionEvent) error {
+		delivered++
+		return nil
+	})
+
+	outboxWorker := worker.NewOutboxWorker(
+		outboxRepo,
+		registry,
+		testDB.DB,
+		time.Second,
+		100,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	)
+
+	require.NoError(t, outboxWorker.ProcessOutbox(ctx))
+	assert.Equal(t, 4, delivered, "every event must pass the schema of its version")
+
+	var pending int
+	require.NoError(t, testDB.DB.QueryRow(ctx, "SELECT count(*) FROM outbox WHERE processed_at IS NULL").Scan(&pending))
+	assert.Zero(t, pending)
+}
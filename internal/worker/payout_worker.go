@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+)
+
+// PayoutWorker resends payouts whose bank call never completed and reconciles payouts
+// still in transit with the bank
+type PayoutWorker struct {
+	heartbeat
+
+	payoutService *services.PayoutService
+	interval      time.Duration
+	batchSize     int
+	logger        *slog.Logger
+}
+
+func NewPayoutWorker(
+	payoutService *services.PayoutService,
+	interval time.Duration,
+	batchSize int,
+	logger *slog.Logger,
+) *PayoutWorker {
+	return &PayoutWorker{
+		payoutService: payoutService,
+		interval:      interval,
+		batchSize:     batchSize,
+		logger:        logger,
+	}
+}
+
+func (w *PayoutWorker) Start(ctx context.Context) {
+	w.logger.Info("payout worker started", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("payout worker stopping")
+			return
+		case <-ticker.C:
+			w.ProcessPayouts(ctx)
+			w.beat()
+		}
+	}
+}
+
+// ProcessPayouts runs one batch of each. A payout is only considered stuck after a full
+// interval, so a request still waiting on the bank is not sent twice concurrently.
+func (w *PayoutWorker) ProcessPayouts(ctx context.Context) {
+	resumed, err := w.payoutService.ResumeStuck(ctx, w.interval, w.batchSize)
+	if err != nil {
+		w.logger.Error("resuming stuck payouts failed", "error", err)
+	}
+	if resumed > 0 {
+		w.logger.Info("resumed stuck payouts", "count", resumed)
+	}
+
+	settled, err := w.payoutService.Reconcile(ctx, w.batchSize)
+	if err != nil {
+		w.logger.Error("payout reconciliation failed", "error", err)
+	}
+	if settled > 0 {
+		w.logger.Info("reconciled payouts", "count", settled)
+	}
+}
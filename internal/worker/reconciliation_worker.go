@@ -0,0 +1,112 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// ReconciliationWorker reconciles the payments every merchant made within the last
+// window with the bank. Each pass covers the whole window, so issues already recorded
+// are found again and only their last detection moves.
+type ReconciliationWorker struct {
+	heartbeat
+
+	reconciliation *services.ReconciliationService
+	merchantRepo   *postgres.MerchantRepository
+	window         time.Duration
+	interval       time.Duration
+	logger         *slog.Logger
+}
+
+func NewReconciliationWorker(
+	reconciliation *services.ReconciliationService,
+	merchantRepo *postgres.MerchantRepository,
+	window time.Duration,
+	interval time.Duration,
+	logger *slog.Logger,
+) *ReconciliationWorker {
+	return &ReconciliationWorker{
+		reconciliation: reconciliation,
+		merchantRepo:   merchantRepo,
+		window:         window,
+		interval:       interval,
+		logger:         logger,
+	}
+}
+
+func (w *ReconciliationWorker) Start(ctx context.Context) {
+	w.logger.Info("reconciliation worker started", "interval", w.interval, "window", w.window)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("reconciliation worker stopping")
+			return
+		case <-ticker.C:
+			if err := w.ReconcileAll(ctx); err != nil {
+				w.logger.Error("reconciliation failed", "error", err)
+			}
+		}
+	}
+}
+
+// ReconcileAll reconciles the window ending now for every merchant. A merchant whose
+// reconciliation fails does not stop the others; the first failure is returned.
+func (w *ReconciliationWorker) ReconcileAll(ctx context.Context) error {
+	merchantIDs, err := w.merchantRepo.FindIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	to := time.Now()
+	from := to.Add(-w.window)
+
+	var firstErr error
+	for _, merchantID := range merchantIDs {
+		if err := w.reconcileMerchant(postgres.WithMerchant(ctx, merchantID), from, to); err != nil {
+			w.logger.Error("merchant reconciliation failed", "merchant_id", merchantID, "error", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("reconcile merchant %s: %w", merchantID, err)
+			}
+		}
+	}
+
+	w.beat()
+	return firstErr
+}
+
+func (w *ReconciliationWorker) reconcileMerchant(ctx context.Context, from, to time.Time) error {
+	var checked, issues int
+	for {
+		result, err := w.reconciliation.Reconcile(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		checked += result.Checked
+		issues += len(result.Issues)
+
+		if result.NextFrom.IsZero() {
+			break
+		}
+		// More payments than a run checks created at the same instant would never move on
+		if !result.NextFrom.After(from) {
+			return fmt.Errorf("reconciliation stuck at %s", from.Format(time.RFC3339Nano))
+		}
+		from = result.NextFrom
+	}
+
+	if issues > 0 {
+		w.logger.Warn("reconciliation found issues",
+			"merchant_id", postgres.MerchantFromContext(ctx),
+			"checked", checked,
+			"issues", issues)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package worker_test
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconciliationWorker_ReconcilesEveryMerchant(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	_, err := testDB.DB.Pool.Exec(ctx, "INSERT INTO merchants (id, name) VALUES ('acme', 'Acme')")
+	require.NoError(t, err)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	issueRepo := postgres.NewReconciliationRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		postgres.NewIdempotencyRepository(testDB.DB),
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	defaultCtx := postgres.WithMerchant(ctx, domain.DefaultMerchantID)
+	acmeCtx := postgres.WithMerchant(ctx, "acme")
+	agreeing := testhelpers.CreateAuthorizedPayment(t, defaultCtx, authService, mockBank)
+	voided := testhelpers.CreateAuthorizedPayment(t, acmeCtx, authService, mockBank)
+
+	mockBank.EXPECT().
+		GetAuthorization(mock.Anything, *agreeing.BankAuthID).
+		Return(&bank.AuthorizationResponse{AuthorizationID: *agreeing.BankAuthID, Status: "AUTHORIZED"}, nil).
+		Once()
+	mockBank.EXPECT().
+		GetAuthorization(mock.Anything, *voided.BankAuthID).
+		Return(&bank.AuthorizationResponse{AuthorizationID: *voided.BankAuthID, Status: "VOIDED"}, nil).
+		Once()
+
+	reconciliationWorker := worker.NewReconciliationWorker(
+		services.NewReconciliationService(
+			paymentRepo,
+			postgres.NewBankAttemptRepository(testDB.DB),
+			issueRepo,
+			mockBank,
+			domain.MaxReconciliationChecks,
+		),
+		postgres.NewMerchantRepository(testDB.DB),
+		time.Hour,
+		time.Hour,
+		slog.New(slog.NewTextHandler(os.Stdout, nil)),
+	)
+
+	require.NoError(t, reconciliationWorker.ReconcileAll(ctx))
+	assert.False(t, reconciliationWorker.LastRun().IsZero())
+
+	since := time.Now().Add(-time.Hour)
+	defaultIssues, err := issueRepo.FindDetectedSince(defaultCtx, since, 10)
+	require.NoError(t, err)
+	assert.Empty(t, defaultIssues)
+
+	acmeIssues, err := issueRepo.FindDetectedSince(acmeCtx, since, 10)
+	require.NoError(t, err)
+	require.Len(t, acmeIssues, 1)
+	assert.Equal(t, domain.IssueStatusMismatch, acmeIssues[0].Kind)
+	assert.Equal(t, voided.ID, acmeIssues[0].PaymentID)
+}
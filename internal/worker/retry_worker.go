@@ -2,94 +2,331 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/alert"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/clock"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
 )
 
+// retryPriority is the order stuck payments are resumed in. A capture left waiting
+// costs revenue, and a reauthorization left waiting lets the authorization lapse; a
+// void or refund only delays money going back.
+var retryPriority = []domain.PaymentStatus{
+	domain.StatusCapturing,
+	domain.StatusReauthorizing,
+	domain.StatusVoiding,
+	domain.StatusRefunding,
+}
+
+// RetryExhaustedAction is what the retry worker does with a payment whose attempts ran
+// out
+type RetryExhaustedAction string
+
+const (
+	// ExhaustedAlert leaves the payment mid-transition and raises a critical alert
+	ExhaustedAlert RetryExhaustedAction = "alert"
+	// ExhaustedFail fails the operation as a permanent bank error would
+	ExhaustedFail RetryExhaustedAction = "fail"
+	// ExhaustedDeadLetter leaves the payment mid-transition and records it in the dead
+	// letters, from where it can be requeued
+	ExhaustedDeadLetter RetryExhaustedAction = "dead_letter"
+)
+
+// ParseRetryBatchSizes reads a comma-separated list of status:size entries, such as
+// "capturing:100,refunding:20", capping how many payments of each status a pass resumes.
+// Statuses left out take the worker's batch size.
+func ParseRetryBatchSizes(s string) (map[domain.PaymentStatus]int, error) {
+	return parseStatusCounts(s, "retry batch size", 0)
+}
+
+// ParseRetryMaxAttempts reads a comma-separated list of status:attempts entries, such as
+// "capturing:10,refunding:3", giving how many times a payment of each status is sent to
+// the bank before the worker gives up. Statuses left out take the worker's max retries.
+func ParseRetryMaxAttempts(s string) (map[domain.PaymentStatus]int, error) {
+	return parseStatusCounts(s, "retry max attempts", 1)
+}
+
+// ParseRetryMaxBackoffs reads a comma-separated list of status:duration entries, such
+// as "capturing:24h,voiding:4m", capping how long the worker waits between attempts at
+// a payment of each status. Statuses left out take the worker's max backoff.
+func ParseRetryMaxBackoffs(s string) (map[domain.PaymentStatus]time.Duration, error) {
+	return parseStatusEntries(s, "retry max backoff", "a duration of at least 1m", func(value string) (time.Duration, bool) {
+		d, err := time.ParseDuration(value)
+		return d, err == nil && d >= time.Minute
+	})
+}
+
+// parseStatusCounts reads status:count entries of the statuses the retry worker resumes,
+// each count at least minimum
+func parseStatusCounts(s, setting string, minimum int) (map[domain.PaymentStatus]int, error) {
+	return parseStatusEntries(s, setting, fmt.Sprintf("a whole number of at least %d", minimum), func(value string) (int, bool) {
+		count, err := strconv.Atoi(value)
+		return count, err == nil && count >= minimum
+	})
+}
+
+// parseStatusEntries reads status:value entries of the statuses the retry worker
+// resumes; parse reports whether a value is what the setting takes, described by want
+func parseStatusEntries[T any](s, setting, want string, parse func(string) (T, bool)) (map[domain.PaymentStatus]T, error) {
+	values := map[domain.PaymentStatus]T{}
+	for i, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%s %d: expected status:value", setting, i+1)
+		}
+		status := domain.PaymentStatus(strings.ToUpper(strings.TrimSpace(name)))
+		if !slices.Contains(retryPriority, status) {
+			return nil, fmt.Errorf("%s %q: not a status the retry worker resumes", setting, name)
+		}
+		if _, seen := values[status]; seen {
+			return nil, fmt.Errorf("%s %q: listed twice", setting, name)
+		}
+
+		parsed, ok := parse(strings.TrimSpace(value))
+		if !ok {
+			return nil, fmt.Errorf("%s %q: must be %s", setting, name, want)
+		}
+		values[status] = parsed
+	}
+	return values, nil
+}
+
 type RetryWorker struct {
+	heartbeat
+
 	paymentRepo     *postgres.PaymentRepository
 	idempotencyRepo *postgres.IdempotencyRepository
+	operationRepo   *postgres.OperationRepository
+	paymentMethods  *services.PaymentMethodService
+	refunds         *services.RefundService
 	bankClient      bank.BankClient
 	interval        time.Duration
 	batchSize       int
+	batchSizes      map[domain.PaymentStatus]int
 	maxRetries      int32
-	maxBackoff      int32
+	maxAttempts     map[domain.PaymentStatus]int
+	onExhausted     RetryExhaustedAction
+	deadLetters     *postgres.DeadLetterRepository
+	backoff         domain.Backoff
+	maxBackoffs     map[domain.PaymentStatus]time.Duration
 	db              *postgres.DB
+	alerts          *alert.Notifier
+	clock           clock.Clock
 	logger          *slog.Logger
 }
 
 func NewRetryWorker(
 	paymentRepo *postgres.PaymentRepository,
 	idempotencyRepo *postgres.IdempotencyRepository,
+	operationRepo *postgres.OperationRepository,
+	paymentMethods *services.PaymentMethodService,
 	bankClient bank.BankClient,
 	db *postgres.DB,
 	interval time.Duration,
 	batchSize int,
 	maxRetries int32,
 	maxBackoff int32,
+	alerts *alert.Notifier,
 	logger *slog.Logger,
 ) *RetryWorker {
 	return &RetryWorker{
 		paymentRepo:     paymentRepo,
 		idempotencyRepo: idempotencyRepo,
+		operationRepo:   operationRepo,
+		paymentMethods:  paymentMethods,
 		bankClient:      bankClient,
 		interval:        interval,
 		batchSize:       batchSize,
 		maxRetries:      maxRetries,
-		maxBackoff:      maxBackoff,
+		backoff:         domain.Backoff{Base: time.Minute, Max: time.Duration(maxBackoff) * time.Minute},
+		onExhausted:     ExhaustedAlert,
 		db:              db,
+		alerts:          alerts,
+		clock:           clock.System{},
 		logger:          logger,
 	}
 }
 
+// WithBatchSizes caps how many payments of each status a pass resumes; statuses sizes
+// leaves out take the worker's batch size, and a size of zero leaves the status to wait
+func (w *RetryWorker) WithBatchSizes(sizes map[domain.PaymentStatus]int) *RetryWorker {
+	w.batchSizes = sizes
+	return w
+}
+
+// WithMaxAttempts sets how many times a payment of each status is sent to the bank
+// before the worker gives up; statuses maxAttempts leaves out take maxRetries
+func (w *RetryWorker) WithMaxAttempts(maxAttempts map[domain.PaymentStatus]int) *RetryWorker {
+	w.maxAttempts = maxAttempts
+	return w
+}
+
+// WithMaxBackoffs caps the wait between attempts at a payment of each status;
+// statuses maxBackoffs leaves out take maxBackoff
+func (w *RetryWorker) WithMaxBackoffs(maxBackoffs map[domain.PaymentStatus]time.Duration) *RetryWorker {
+	w.maxBackoffs = maxBackoffs
+	return w
+}
+
+// WithExhaustedAction sets what happens to a payment whose attempts ran out, instead of
+// an alert. deadLetters is only used by ExhaustedDeadLetter.
+func (w *RetryWorker) WithExhaustedAction(action RetryExhaustedAction, deadLetters *postgres.DeadLetterRepository) *RetryWorker {
+	w.onExhausted = action
+	w.deadLetters = deadLetters
+	return w
+}
+
+// WithRefunds lets the worker resume refunds sent by bank transfer, whose account
+// number refunds decrypts
+func (w *RetryWorker) WithRefunds(refunds *services.RefundService) *RetryWorker {
+	w.refunds = refunds
+	return w
+}
+
+// WithClock books retries and decides which are due by c rather than the system clock
+func (w *RetryWorker) WithClock(c clock.Clock) *RetryWorker {
+	w.clock = c
+	return w
+}
+
+// Start polls every interval, and resumes a payment as soon as a service reports that
+// a transient bank failure left it mid-transition
 func (w *RetryWorker) Start(ctx context.Context) {
 	ticker := time.NewTicker(w.interval)
 	defer ticker.Stop()
 
+	woken := make(chan string, w.batchSize)
+	go w.listen(ctx, woken)
+
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			if err := w.ProcessRetries(ctx); err != nil {
+			w.RunOnce(ctx) //nolint:errcheck // failures are logged
+		case paymentID := <-woken:
+			paymentIDs := []string{paymentID}
+			for len(paymentIDs) < w.batchSize && len(woken) > 0 {
+				paymentIDs = append(paymentIDs, <-woken)
+			}
+			if err := w.processRetries(ctx, paymentIDs); err != nil {
 				w.logger.Error("retry processing failed", "error", err)
 			}
+		}
+	}
+}
 
-			if err := w.timeoutUnauthorizedPayments(ctx); err != nil {
-				w.logger.Error("timeout failed", "error", err)
+// listen passes on the payments services report on postgres.RetryChannel until ctx is
+// done, listening again an interval after the connection fails. Reports that arrive
+// while woken is full are dropped; the poll picks those payments up.
+func (w *RetryWorker) listen(ctx context.Context, woken chan<- string) {
+	for {
+		err := w.db.Listen(ctx, postgres.RetryChannel, func(paymentID string) {
+			select {
+			case woken <- paymentID:
+			default:
 			}
+		})
+		if err != nil {
+			w.logger.Warn("retry notifications interrupted, polling only", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.interval):
 		}
 	}
 }
 
+// RunOnce makes one pass: stuck payments are resumed, then payments left PENDING are
+// timed out. Failures are logged, and the first one is returned.
+func (w *RetryWorker) RunOnce(ctx context.Context) error {
+	retryErr := w.ProcessRetries(ctx)
+	if retryErr != nil {
+		w.logger.Error("retry processing failed", "error", retryErr)
+	}
+
+	timeoutErr := w.timeoutUnauthorizedPayments(ctx)
+	if timeoutErr != nil {
+		w.logger.Error("timeout failed", "error", timeoutErr)
+	}
+	w.beat()
+
+	if retryErr != nil {
+		return retryErr
+	}
+	return timeoutErr
+}
+
 type stuckPayment struct {
 	id             string
+	merchantID     string
 	status         string
 	idempotencyKey string
 }
 
 func (w *RetryWorker) ProcessRetries(ctx context.Context) error {
+	return w.processRetries(ctx, nil)
+}
+
+// processRetries resumes the stuck payments that are due, in retryPriority order and,
+// within a status, largest and then oldest first, up to each status's batch size.
+// Payments out of attempts or dead-lettered are passed over. The idempotency lock of a
+// payment in woken is not waited out, since the request holding it has already given up.
+func (w *RetryWorker) processRetries(ctx context.Context, woken []string) error {
 	query := `
-		SELECT p.id, p.status, i.key
-		FROM payments p
-		JOIN idempotency_keys i on p.id = i.payment_id
-		WHERE
-			p.status IN ('CAPTURING', 'VOIDING', 'REFUNDING')
-			AND (
-				p.next_retry_at IS NULL OR p.next_retry_at <= NOW()
-			)
-			AND p.attempt_count < $1
-			AND i.locked_at < NOW() - $2::interval
-		ORDER BY p.created_at ASC
-		LIMIT $3
+		SELECT due.id, due.merchant_id, due.status, due.key
+		FROM (
+			SELECT p.id, p.merchant_id, p.status, i.key, b.batch_size, b.priority,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY p.status
+			           ORDER BY p.amount_cents DESC, p.created_at ASC
+			       ) AS rank
+			FROM payments p
+			JOIN unnest($1::text[], $2::int[], $3::int[]) WITH ORDINALITY AS b(status, batch_size, max_attempts, priority)
+				ON b.status = p.status
+			JOIN idempotency_keys i on p.id = i.payment_id AND p.merchant_id = i.merchant_id
+			WHERE
+				(
+					p.next_retry_at IS NULL OR p.next_retry_at <= $6
+				)
+				AND p.attempt_count < b.max_attempts
+				AND (i.locked_at < NOW() - $4::interval OR p.id = ANY($5))
+				AND NOT EXISTS (SELECT 1 FROM retry_dead_letters d WHERE d.payment_id = p.id)
+		) due
+		WHERE due.rank <= due.batch_size
+		ORDER BY due.priority, due.rank
 	`
 
-	rows, err := w.db.Query(ctx, query, w.maxRetries, w.interval, w.batchSize)
+	statuses := make([]string, len(retryPriority))
+	batchSizes := make([]int, len(retryPriority))
+	maxAttempts := make([]int, len(retryPriority))
+	for i, status := range retryPriority {
+		statuses[i] = string(status)
+		batchSizes[i] = w.batchSize
+		if size, ok := w.batchSizes[status]; ok {
+			batchSizes[i] = size
+		}
+		maxAttempts[i] = w.maxAttemptsFor(status)
+	}
+
+	rows, err := w.db.Query(postgres.AcrossMerchants(ctx), query, statuses, batchSizes, maxAttempts, w.interval, woken, w.clock.Now())
 	if err != nil {
 		return fmt.Errorf("query stuck payments: %w", err)
 	}
@@ -98,7 +335,7 @@ func (w *RetryWorker) ProcessRetries(ctx context.Context) error {
 	var processed int
 	for rows.Next() {
 		var sp stuckPayment
-		if err := rows.Scan(&sp.id, &sp.status, &sp.idempotencyKey); err != nil {
+		if err := rows.Scan(&sp.id, &sp.merchantID, &sp.status, &sp.idempotencyKey); err != nil {
 			w.logger.Error("scan failed", "error", err)
 			continue
 		}
@@ -120,30 +357,49 @@ func (w *RetryWorker) ProcessRetries(ctx context.Context) error {
 	return rows.Err()
 }
 
+// maxAttemptsFor returns how many times a payment in status is sent to the bank
+func (w *RetryWorker) maxAttemptsFor(status domain.PaymentStatus) int {
+	if attempts, ok := w.maxAttempts[status]; ok {
+		return attempts
+	}
+	return int(w.maxRetries)
+}
+
+// backoffFor returns how the worker spaces out its attempts at a payment in status
+func (w *RetryWorker) backoffFor(status domain.PaymentStatus) domain.Backoff {
+	if limit, ok := w.maxBackoffs[status]; ok {
+		return w.backoff.WithMax(limit)
+	}
+	return w.backoff
+}
+
 func (w *RetryWorker) timeoutUnauthorizedPayments(ctx context.Context) error {
 	query := `
-        SELECT p.id, p.order_id, i.key, p.created_at
+        SELECT p.id, p.merchant_id, p.order_id, GREATEST(p.created_at, s.scheduled_for, r.reviewed_at)
         FROM payments p
-        JOIN idempotency_keys i ON p.id = i.payment_id
+        JOIN idempotency_keys i ON p.id = i.payment_id AND p.merchant_id = i.merchant_id
+        LEFT JOIN scheduled_payments s ON s.payment_id = p.id
+        LEFT JOIN payment_reviews r ON r.payment_id = p.id
         WHERE
             p.status = 'PENDING'
-            AND p.created_at < NOW() - INTERVAL '10 minutes'
+            AND GREATEST(p.created_at, s.scheduled_for, r.reviewed_at) < $1::timestamptz - INTERVAL '10 minutes'
             AND i.locked_at IS NOT NULL
     `
 
-	rows, err := w.db.Query(ctx, query)
+	rows, err := w.db.Query(postgres.AcrossMerchants(ctx), query, w.clock.Now())
 	if err != nil {
 		return err
 	}
 	defer rows.Close()
 
 	for rows.Next() {
-		var id, orderID, key string
+		var id, merchantID, orderID string
 		var createdAt time.Time
-		if err := rows.Scan(&id, &orderID, &key, &createdAt); err != nil {
+		if err := rows.Scan(&id, &merchantID, &orderID, &createdAt); err != nil {
 			w.logger.Error("scan failed", "error", err)
 			continue
 		}
+		ctx := postgres.WithActor(postgres.WithMerchant(ctx, merchantID), domain.ActorRetryWorker)
 
 		payment, err := w.paymentRepo.FindByID(ctx, id)
 		if err != nil {
@@ -163,16 +419,33 @@ func (w *RetryWorker) timeoutUnauthorizedPayments(ctx context.Context) error {
 			"age_minutes", time.Since(createdAt).Minutes(),
 			"action", "MANUAL_RECONCILIATION_REQUIRED")
 
+		if w.alerts != nil {
+			w.alerts.Notify(ctx, alert.Alert{
+				Key:      "orphaned_authorization:" + id,
+				Summary:  fmt.Sprintf("Payment %s may hold an authorization the gateway never recorded", id),
+				Severity: alert.SeverityCritical,
+				Details: map[string]any{
+					"payment_id":  id,
+					"merchant_id": merchantID,
+					"order_id":    orderID,
+					"age_minutes": int(time.Since(createdAt).Minutes()),
+					"action":      "MANUAL_RECONCILIATION_REQUIRED",
+				},
+			})
+		}
 	}
 
 	return nil
 }
 
 func (w *RetryWorker) retryPayment(ctx context.Context, sp stuckPayment) error {
+	ctx = postgres.WithActor(postgres.WithMerchant(ctx, sp.merchantID), domain.ActorRetryWorker)
+
 	payment, err := w.paymentRepo.FindByID(ctx, sp.id)
 	if err != nil {
 		return err
 	}
+	ctx = bank.WithAcquirer(ctx, payment.Acquirer)
 
 	//nolint:exhaustive //statuses are pre-filtered by SQL query
 	switch domain.PaymentStatus(sp.status) {
@@ -182,19 +455,29 @@ func (w *RetryWorker) retryPayment(ctx context.Context, sp stuckPayment) error {
 		return w.resumeVoid(ctx, payment, sp.idempotencyKey)
 	case domain.StatusRefunding:
 		return w.resumeRefund(ctx, payment, sp.idempotencyKey)
+	case domain.StatusReauthorizing:
+		return w.resumeReauthorize(ctx, payment, sp.idempotencyKey)
 	default:
 		return fmt.Errorf("unexpected status %s: %w", sp.status, domain.ErrInvalidState)
 	}
 }
 
 func (w *RetryWorker) resumeCapture(ctx context.Context, payment *domain.Payment, idempotencyKey string) error {
+	amount, err := w.operationAmount(ctx, idempotencyKey, payment.RemainingCaptureAmount())
+	if err != nil {
+		return err
+	}
+
 	return w.resumeOperation(
 		ctx,
 		payment,
 		idempotencyKey,
+		func(ctx context.Context, key string) (any, error) {
+			return w.bankClient.GetCapture(ctx, key)
+		},
 		func(ctx context.Context, key string) (any, error) {
 			req := bank.CaptureRequest{
-				Amount:          payment.AmountCents,
+				Amount:          amount,
 				AuthorizationID: *payment.BankAuthID,
 			}
 			return w.bankClient.Capture(ctx, req, key)
@@ -204,19 +487,32 @@ func (w *RetryWorker) resumeCapture(ctx context.Context, payment *domain.Payment
 			if !ok {
 				return fmt.Errorf("expected *bank.CaptureResponse, got %T", resp)
 			}
-			return p.Capture(r.Status, r.CaptureID, r.CapturedAt)
+			return p.Capture(r.Status, r.CaptureID, amount, r.CapturedAt)
 		},
 	)
 }
 
 func (w *RetryWorker) resumeVoid(ctx context.Context, payment *domain.Payment, idempotencyKey string) error {
+	// A void after a partial capture only releases what was left to capture
+	releasing := payment.CapturedAmountCents > 0
+	var amount int64
+	if releasing {
+		var err error
+		amount, err = w.operationAmount(ctx, idempotencyKey, payment.RemainingCaptureAmount())
+		if err != nil {
+			return err
+		}
+	}
+
 	return w.resumeOperation(
 		ctx,
 		payment,
 		idempotencyKey,
+		nil,
 		func(ctx context.Context, key string) (any, error) {
 			req := bank.VoidRequest{
 				AuthorizationID: *payment.BankAuthID,
+				Amount:          amount,
 			}
 			return w.bankClient.Void(ctx, req, key)
 		},
@@ -225,20 +521,84 @@ func (w *RetryWorker) resumeVoid(ctx context.Context, payment *domain.Payment, i
 			if !ok {
 				return fmt.Errorf("expected *bank.VoidResponse, got %T", resp)
 			}
+			if releasing {
+				return p.Release(r.Status, r.VoidID, amount, r.VoidedAt)
+			}
 			return p.Void(r.Status, r.VoidID, r.VoidedAt)
 		},
 	)
-
 }
 
 func (w *RetryWorker) resumeRefund(ctx context.Context, payment *domain.Payment, idempotencyKey string) error {
+	amount := payment.RefundableAmount()
+	var destination domain.RefundDestination
+	op, err := w.operationRepo.FindByIdempotencyKey(ctx, idempotencyKey)
+	switch {
+	case err == nil:
+		amount = op.AmountCents
+		destination, err = w.refundDestination(ctx, op)
+		if err != nil {
+			return err
+		}
+	case !errors.Is(err, postgres.ErrOperationNotFound):
+		return err
+	}
+
+	switch destination.Type {
+	case domain.DestinationStoreCredit:
+		// Nothing reached the bank, so the credit is simply recorded
+		return w.resumeOperation(
+			ctx,
+			payment,
+			idempotencyKey,
+			nil,
+			func(context.Context, string) (any, error) {
+				return services.NewStoreCreditResponse(payment, amount), nil
+			},
+			func(p *domain.Payment, resp any) error {
+				r, ok := resp.(*services.StoreCreditResponse)
+				if !ok {
+					return fmt.Errorf("expected *services.StoreCreditResponse, got %T", resp)
+				}
+				return p.Refund(r.CreditID, amount, r.CreditedAt)
+			},
+		)
+	case domain.DestinationBankTransfer:
+		// The bank's payout API answers a resent key with the payout it already made
+		return w.resumeOperation(
+			ctx,
+			payment,
+			idempotencyKey,
+			nil,
+			func(ctx context.Context, key string) (any, error) {
+				req := bank.PayoutRequest{
+					Amount:        amount,
+					Currency:      payment.Currency,
+					AccountNumber: destination.AccountNumber,
+					RoutingNumber: destination.RoutingNumber,
+				}
+				return w.bankClient.Payout(ctx, req, key)
+			},
+			func(p *domain.Payment, resp any) error {
+				r, ok := resp.(*bank.PayoutResponse)
+				if !ok {
+					return fmt.Errorf("expected *bank.PayoutResponse, got %T", resp)
+				}
+				return p.Refund(r.PayoutID, amount, r.CreatedAt)
+			},
+		)
+	}
+
 	return w.resumeOperation(
 		ctx,
 		payment,
 		idempotencyKey,
+		func(ctx context.Context, key string) (any, error) {
+			return w.bankClient.GetRefund(ctx, key)
+		},
 		func(ctx context.Context, key string) (any, error) {
 			req := bank.RefundRequest{
-				Amount:    payment.AmountCents,
+				Amount:    amount,
 				CaptureID: *payment.BankCaptureID,
 			}
 			return w.bankClient.Refund(ctx, req, key)
@@ -248,7 +608,62 @@ func (w *RetryWorker) resumeRefund(ctx context.Context, payment *domain.Payment,
 			if !ok {
 				return fmt.Errorf("expected *bank.RefundResponse, got %T", resp)
 			}
-			return p.Refund(r.RefundID, r.RefundedAt)
+			return p.Refund(r.RefundID, amount, r.RefundedAt)
+		},
+	)
+}
+
+// refundDestination returns where the refund op sends the funds. Only a bank transfer
+// needs the refund service, to decrypt its account number.
+func (w *RetryWorker) refundDestination(ctx context.Context, op *domain.Operation) (domain.RefundDestination, error) {
+	if op.Destination != domain.DestinationBankTransfer {
+		return domain.RefundDestination{Type: op.Destination}, nil
+	}
+	if w.refunds == nil {
+		return domain.RefundDestination{}, fmt.Errorf("refund %s is a bank transfer: %w", op.ID, domain.ErrInvalidState)
+	}
+	return w.refunds.Destination(ctx, op)
+}
+
+// resumeReauthorize sends the reauthorization again under its original idempotency key,
+// so the bank returns the authorization it already granted instead of a second one
+func (w *RetryWorker) resumeReauthorize(ctx context.Context, payment *domain.Payment, idempotencyKey string) error {
+	if payment.PaymentMethodID == nil {
+		return fmt.Errorf("payment %s has no payment method: %w", payment.ID, domain.ErrInvalidState)
+	}
+
+	paymentMethod, err := w.paymentMethods.Get(ctx, *payment.PaymentMethodID)
+	if err != nil {
+		return err
+	}
+	cardNumber, err := w.paymentMethods.CardNumber(ctx, paymentMethod.ID)
+	if err != nil {
+		return err
+	}
+
+	return w.resumeOperation(
+		ctx,
+		payment,
+		idempotencyKey,
+		nil,
+		func(ctx context.Context, key string) (any, error) {
+			req := bank.AuthorizationRequest{
+				Amount:      payment.AmountCents,
+				CardNumber:  cardNumber,
+				ExpiryMonth: paymentMethod.ExpiryMonth,
+				ExpiryYear:  paymentMethod.ExpiryYear,
+			}
+			return w.bankClient.Authorize(ctx, req, key)
+		},
+		func(p *domain.Payment, resp any) error {
+			r, ok := resp.(*bank.AuthorizationResponse)
+			if !ok {
+				return fmt.Errorf("expected *bank.AuthorizationResponse, got %T", resp)
+			}
+			if r.Acquirer != "" {
+				p.Acquirer = r.Acquirer
+			}
+			return p.Authorize(r.AuthorizationID, r.CreatedAt, r.ExpiresAt)
 		},
 	)
 }
@@ -2,11 +2,13 @@ package worker_test
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
 	"github.com/DanielPopoola/ficmart-payment-gateway/internal/domain"
@@ -20,6 +22,9 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// errNotAtBank is the bank's answer to a lookup of an operation it never received
+var errNotAtBank = &bank.BankError{Code: "not_found", Message: "Capture not found", StatusCode: 404}
+
 func TestRetryWorker_RecoversStuckCapture(t *testing.T) {
 	ctx := context.Background()
 
@@ -28,13 +33,16 @@ func TestRetryWorker_RecoversStuckCapture(t *testing.T) {
 
 	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
 	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
 	mockBank := mocks.NewMockBankClient(t)
 
 	authService := services.NewAuthorizeService(
 		paymentRepo,
 		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
 		mockBank,
 		testDB.DB,
+		services.AuthorizeLimits{},
 	)
 
 	idempotencyKey := "idem-test-capture-" + uuid.New().String()
@@ -57,7 +65,7 @@ func TestRetryWorker_RecoversStuckCapture(t *testing.T) {
 	payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
 	require.NoError(t, err)
 
-	err = payment.MarkCapturing()
+	err = payment.MarkCapturing(payment.AmountCents)
 	require.NoError(t, err)
 
 	err = paymentRepo.Update(ctx, nil, payment)
@@ -70,6 +78,7 @@ func TestRetryWorker_RecoversStuckCapture(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	mockBank.EXPECT().GetCapture(mock.Anything, mock.Anything).Return(nil, errNotAtBank).Once()
 	mockBank.EXPECT().Capture(
 		mock.Anything,
 		mock.Anything,
@@ -90,12 +99,15 @@ func TestRetryWorker_RecoversStuckCapture(t *testing.T) {
 	worker := worker.NewRetryWorker(
 		paymentRepo,
 		idempotencyRepo,
+		operationRepo,
+		nil,
 		mockBank,
 		testDB.DB,
 		1*time.Minute,
 		10,
 		5,
 		10,
+		nil,
 		logger,
 	)
 
@@ -112,6 +124,186 @@ func TestRetryWorker_RecoversStuckCapture(t *testing.T) {
 	assert.Nil(t, key.LockedAt, "Lock should be released after success")
 }
 
+func TestRetryWorker_CompletesCaptureFoundAtBank(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	idempotencyKey := "idem-test-found-" + uuid.New().String()
+
+	authCmd := testhelpers.DefaultAuthorizeCommand()
+
+	mockBank.EXPECT().Authorize(
+		mock.Anything,
+		mock.Anything,
+		idempotencyKey,
+	).Return(&bank.AuthorizationResponse{
+		Amount:          authCmd.Amount,
+		Currency:        authCmd.Currency,
+		Status:          "authorized",
+		AuthorizationID: "auth-123",
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+	}, nil).Once()
+
+	payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
+	require.NoError(t, err)
+
+	err = payment.MarkCapturing(payment.AmountCents)
+	require.NoError(t, err)
+
+	err = paymentRepo.Update(ctx, nil, payment)
+	require.NoError(t, err)
+
+	_, err = testDB.DB.Exec(ctx,
+		"UPDATE idempotency_keys SET locked_at = $1 WHERE key = $2",
+		time.Now().Add(-2*time.Hour),
+		idempotencyKey,
+	)
+	require.NoError(t, err)
+
+	// The bank captured it before the gateway crashed, so nothing is resent
+	mockBank.EXPECT().GetCapture(mock.Anything, idempotencyKey).Return(&bank.CaptureResponse{
+		Amount:          payment.AmountCents,
+		Currency:        payment.Currency,
+		AuthorizationID: *payment.BankAuthID,
+		CaptureID:       "cap-already-at-bank",
+		Status:          "captured",
+		CapturedAt:      time.Now(),
+	}, nil).Once()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	worker := worker.NewRetryWorker(
+		paymentRepo,
+		idempotencyRepo,
+		operationRepo,
+		nil,
+		mockBank,
+		testDB.DB,
+		1*time.Minute,
+		10,
+		5,
+		10,
+		nil,
+		logger,
+	)
+
+	err = worker.ProcessRetries(ctx)
+	require.NoError(t, err)
+
+	updatedPayment, err := paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, updatedPayment.Status)
+	assert.Equal(t, "cap-already-at-bank", *updatedPayment.BankCaptureID)
+
+	key, err := idempotencyRepo.FindByKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Nil(t, key.LockedAt, "Lock should be released after success")
+}
+
+func TestRetryWorker_RollsBackCaptureThatNeverReachedBank(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+	captureService := services.NewCaptureService(paymentRepo, idempotencyRepo, operationRepo, mockBank, testDB.DB)
+
+	authCmd := testhelpers.DefaultAuthorizeCommand()
+	mockBank.EXPECT().Authorize(mock.Anything, mock.Anything, mock.Anything).Return(&bank.AuthorizationResponse{
+		Amount:          authCmd.Amount,
+		Currency:        authCmd.Currency,
+		Status:          "authorized",
+		AuthorizationID: "auth-rollback",
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+	}, nil).Once()
+
+	payment, err := authService.Authorize(ctx, &authCmd, "idem-test-auth-"+uuid.New().String())
+	require.NoError(t, err)
+
+	// The connection drops before the bank answers, leaving the key at CALLING_BANK
+	captureKey := "idem-test-rollback-" + uuid.New().String()
+	mockBank.EXPECT().Capture(mock.Anything, mock.Anything, captureKey).
+		Return(nil, errors.New("connection reset by peer")).Once()
+	_, err = captureService.Capture(ctx, payment.ID, 0, captureKey)
+	require.Error(t, err)
+
+	key, err := idempotencyRepo.FindByKey(ctx, captureKey)
+	require.NoError(t, err)
+	assert.Equal(t, postgres.RecoveryCallingBank, key.RecoveryPoint)
+
+	_, err = testDB.DB.Exec(ctx,
+		"UPDATE idempotency_keys SET locked_at = $1 WHERE key = $2",
+		time.Now().Add(-2*time.Hour),
+		captureKey,
+	)
+	require.NoError(t, err)
+
+	mockBank.EXPECT().GetCapture(mock.Anything, captureKey).Return(nil, errNotAtBank).Once()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	worker := worker.NewRetryWorker(
+		paymentRepo,
+		idempotencyRepo,
+		operationRepo,
+		nil,
+		mockBank,
+		testDB.DB,
+		1*time.Minute,
+		10,
+		5,
+		10,
+		nil,
+		logger,
+	)
+
+	require.NoError(t, worker.ProcessRetries(ctx))
+
+	updatedPayment, err := paymentRepo.FindByID(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAuthorized, updatedPayment.Status)
+	assert.Zero(t, updatedPayment.CapturedAmountCents)
+
+	op, err := operationRepo.FindByIdempotencyKey(ctx, captureKey)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OperationFailed, op.Status)
+}
+
 func TestRetryWorker_SchedulesRetryOnTransientError(t *testing.T) {
 	ctx := context.Background()
 
@@ -120,13 +312,16 @@ func TestRetryWorker_SchedulesRetryOnTransientError(t *testing.T) {
 
 	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
 	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
 	mockBank := mocks.NewMockBankClient(t)
 
 	authService := services.NewAuthorizeService(
 		paymentRepo,
 		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
 		mockBank,
 		testDB.DB,
+		services.AuthorizeLimits{},
 	)
 
 	idempotencyKey := "idem-test-capture-" + uuid.New().String()
@@ -149,7 +344,7 @@ func TestRetryWorker_SchedulesRetryOnTransientError(t *testing.T) {
 	payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
 	require.NoError(t, err)
 
-	err = payment.MarkCapturing()
+	err = payment.MarkCapturing(payment.AmountCents)
 	require.NoError(t, err)
 
 	err = paymentRepo.Update(ctx, nil, payment)
@@ -162,6 +357,7 @@ func TestRetryWorker_SchedulesRetryOnTransientError(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	mockBank.EXPECT().GetCapture(mock.Anything, mock.Anything).Return(nil, errNotAtBank).Once()
 	mockBank.EXPECT().Capture(
 		mock.Anything,
 		mock.Anything,
@@ -178,12 +374,15 @@ func TestRetryWorker_SchedulesRetryOnTransientError(t *testing.T) {
 	worker := worker.NewRetryWorker(
 		paymentRepo,
 		idempotencyRepo,
+		operationRepo,
+		nil,
 		mockBank,
 		testDB.DB,
 		1*time.Minute,
 		10,
 		5,
 		10,
+		nil,
 		logger,
 	)
 
@@ -207,13 +406,16 @@ func TestRetryWorker_FailsOnPermanentError(t *testing.T) {
 
 	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
 	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
 	mockBank := mocks.NewMockBankClient(t)
 
 	authService := services.NewAuthorizeService(
 		paymentRepo,
 		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
 		mockBank,
 		testDB.DB,
+		services.AuthorizeLimits{},
 	)
 
 	idempotencyKey := "idem-test-capture-" + uuid.New().String()
@@ -236,7 +438,7 @@ func TestRetryWorker_FailsOnPermanentError(t *testing.T) {
 	payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
 	require.NoError(t, err)
 
-	err = payment.MarkCapturing()
+	err = payment.MarkCapturing(payment.AmountCents)
 	require.NoError(t, err)
 
 	err = paymentRepo.Update(ctx, nil, payment)
@@ -249,6 +451,7 @@ func TestRetryWorker_FailsOnPermanentError(t *testing.T) {
 	)
 	require.NoError(t, err)
 
+	mockBank.EXPECT().GetCapture(mock.Anything, mock.Anything).Return(nil, errNotAtBank).Once()
 	mockBank.EXPECT().Capture(
 		mock.Anything,
 		mock.Anything,
@@ -265,12 +468,15 @@ func TestRetryWorker_FailsOnPermanentError(t *testing.T) {
 	worker := worker.NewRetryWorker(
 		paymentRepo,
 		idempotencyRepo,
+		operationRepo,
+		nil,
 		mockBank,
 		testDB.DB,
 		1*time.Minute,
 		10,
 		5,
 		10,
+		nil,
 		logger,
 	)
 
@@ -282,3 +488,384 @@ func TestRetryWorker_FailsOnPermanentError(t *testing.T) {
 
 	assert.Nil(t, updatedPayment.NextRetryAt)
 }
+
+func TestRetryWorker_WakesOnNotification(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	idempotencyKey := "idem-test-wake-" + uuid.New().String()
+	authCmd := testhelpers.DefaultAuthorizeCommand()
+
+	mockBank.EXPECT().Authorize(
+		mock.Anything,
+		mock.Anything,
+		idempotencyKey,
+	).Return(&bank.AuthorizationResponse{
+		Amount:          authCmd.Amount,
+		Currency:        authCmd.Currency,
+		Status:          "authorized",
+		AuthorizationID: "auth-wake",
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+	}, nil).Once()
+
+	payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
+	require.NoError(t, err)
+
+	// Left mid-capture with the lock just taken, as a request that gave up leaves it
+	require.NoError(t, payment.MarkCapturing(payment.AmountCents))
+	require.NoError(t, paymentRepo.Update(ctx, nil, payment))
+	_, err = testDB.DB.Exec(ctx, "UPDATE idempotency_keys SET locked_at = NOW() WHERE key = $1", idempotencyKey)
+	require.NoError(t, err)
+
+	mockBank.EXPECT().GetCapture(mock.Anything, mock.Anything).Return(nil, errNotAtBank).Once()
+	mockBank.EXPECT().Capture(
+		mock.Anything,
+		mock.Anything,
+		idempotencyKey,
+	).Return(&bank.CaptureResponse{
+		Amount:          payment.AmountCents,
+		Currency:        payment.Currency,
+		AuthorizationID: *payment.BankAuthID,
+		CaptureID:       "cap-wake",
+		Status:          "captured",
+		CapturedAt:      time.Now(),
+	}, nil).Once()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	// The poll would not come round during the test
+	worker := worker.NewRetryWorker(
+		paymentRepo,
+		idempotencyRepo,
+		operationRepo,
+		nil,
+		mockBank,
+		testDB.DB,
+		time.Hour,
+		10,
+		5,
+		10,
+		nil,
+		logger,
+	)
+	go worker.Start(ctx)
+
+	// Notifications sent before the worker listens are lost, so keep sending
+	assert.Eventually(t, func() bool {
+		if err := testDB.DB.Notify(ctx, postgres.RetryChannel, payment.ID); err != nil {
+			return false
+		}
+		updated, err := paymentRepo.FindByID(ctx, payment.ID)
+		return err == nil && updated.Status == domain.StatusCaptured
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+func TestParseRetryBatchSizes(t *testing.T) {
+	sizes, err := worker.ParseRetryBatchSizes(" capturing:100 ,Refunding:0,")
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.PaymentStatus]int{domain.StatusCapturing: 100, domain.StatusRefunding: 0}, sizes)
+
+	for _, s := range []string{"capturing", "capturing:-1", "capturing:many", "authorized:10", "voiding:1,voiding:2"} {
+		_, err := worker.ParseRetryBatchSizes(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestParseRetryMaxAttempts(t *testing.T) {
+	attempts, err := worker.ParseRetryMaxAttempts("capturing:10,reauthorizing:2")
+	require.NoError(t, err)
+	assert.Equal(t, map[domain.PaymentStatus]int{domain.StatusCapturing: 10, domain.StatusReauthorizing: 2}, attempts)
+
+	for _, s := range []string{"capturing:0", "pending:3", "refunding:2,refunding:3"} {
+		_, err := worker.ParseRetryMaxAttempts(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestRetryWorker_DeadLettersExhaustedPayment(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
+	deadLetterRepo := postgres.NewDeadLetterRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	idempotencyKey := "idem-test-exhausted-" + uuid.New().String()
+	authCmd := testhelpers.DefaultAuthorizeCommand()
+
+	mockBank.EXPECT().Authorize(
+		mock.Anything,
+		mock.Anything,
+		idempotencyKey,
+	).Return(&bank.AuthorizationResponse{
+		Amount:          authCmd.Amount,
+		Currency:        authCmd.Currency,
+		Status:          "authorized",
+		AuthorizationID: "auth-exhausted",
+		CreatedAt:       time.Now(),
+		ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+	}, nil).Once()
+
+	payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
+	require.NoError(t, err)
+	require.NoError(t, payment.MarkCapturing(payment.AmountCents))
+	require.NoError(t, paymentRepo.Update(ctx, nil, payment))
+	_, err = testDB.DB.Exec(ctx,
+		"UPDATE idempotency_keys SET locked_at = $1 WHERE key = $2",
+		time.Now().Add(-2*time.Hour),
+		idempotencyKey,
+	)
+	require.NoError(t, err)
+
+	mockBank.EXPECT().GetCapture(mock.Anything, mock.Anything).Return(nil, errNotAtBank).Once()
+	mockBank.EXPECT().Capture(
+		mock.Anything,
+		mock.Anything,
+		idempotencyKey,
+	).Return(nil, &bank.BankError{
+		Code:       "internal_error",
+		Message:    "Bank internal error",
+		StatusCode: 500}).Once()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	maxAttempts, err := worker.ParseRetryMaxAttempts("capturing:1")
+	require.NoError(t, err)
+	worker := worker.NewRetryWorker(
+		paymentRepo,
+		idempotencyRepo,
+		operationRepo,
+		nil,
+		mockBank,
+		testDB.DB,
+		1*time.Minute,
+		10,
+		5,
+		10,
+		nil,
+		logger,
+	).
+		WithMaxAttempts(maxAttempts).
+		WithExhaustedAction(worker.ExhaustedDeadLetter, deadLetterRepo)
+
+	require.NoError(t, worker.ProcessRetries(ctx))
+
+	deadLetters := services.NewDeadLetterService(paymentRepo, deadLetterRepo, testDB.DB)
+	listed, err := deadLetters.List(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, payment.ID, listed[0].Payment.ID)
+	assert.Equal(t, domain.StatusCapturing, listed[0].DeadLetter.Status)
+	assert.Equal(t, 1, listed[0].DeadLetter.Attempts)
+	assert.Contains(t, listed[0].DeadLetter.LastError, "internal_error")
+
+	// Parked until requeued, even once its retry is due
+	_, err = testDB.DB.Exec(ctx, "UPDATE payments SET next_retry_at = NULL, attempt_count = 0 WHERE id = $1", payment.ID)
+	require.NoError(t, err)
+	require.NoError(t, worker.ProcessRetries(ctx))
+
+	requeued, err := deadLetters.Requeue(ctx, payment.ID)
+	require.NoError(t, err)
+	assert.Zero(t, requeued.AttemptCount)
+	assert.Nil(t, requeued.NextRetryAt)
+
+	_, err = deadLetters.Requeue(ctx, payment.ID)
+	assert.ErrorIs(t, err, postgres.ErrDeadLetterNotFound)
+}
+
+func TestRetryWorker_ReplayClearsTheBackoffOfStuckPayments(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		postgres.NewIdempotencyRepository(testDB.DB),
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	payments := make([]*domain.Payment, 0, 2)
+	for range 2 {
+		idempotencyKey := "idem-test-replay-" + uuid.New().String()
+		authCmd := testhelpers.DefaultAuthorizeCommand()
+
+		mockBank.EXPECT().Authorize(
+			mock.Anything,
+			mock.Anything,
+			idempotencyKey,
+		).Return(&bank.AuthorizationResponse{
+			Amount:          authCmd.Amount,
+			Currency:        authCmd.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-" + idempotencyKey,
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).Once()
+
+		payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
+		require.NoError(t, err)
+		payments = append(payments, payment)
+	}
+
+	// The first is backing off after the bank went down mid-capture, the second is settled
+	stuck := payments[0]
+	require.NoError(t, stuck.MarkCapturing(stuck.AmountCents))
+	stuck.ScheduleRetry(time.Hour, time.Now())
+	stuck.ScheduleRetry(time.Hour, time.Now())
+	require.NoError(t, paymentRepo.Update(ctx, nil, stuck))
+
+	deadLetters := services.NewDeadLetterService(paymentRepo, postgres.NewDeadLetterRepository(testDB.DB), testDB.DB)
+
+	replayed, err := deadLetters.Replay(ctx, domain.ReplayFilter{PaymentIDs: []string{stuck.ID, payments[1].ID}})
+	require.NoError(t, err)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, stuck.ID, replayed[0].ID)
+	assert.Zero(t, replayed[0].AttemptCount)
+	assert.Nil(t, replayed[0].NextRetryAt)
+
+	replayed, err = deadLetters.Replay(ctx, domain.ReplayFilter{Status: domain.StatusCapturing})
+	require.NoError(t, err)
+	require.Len(t, replayed, 1)
+	assert.Equal(t, stuck.ID, replayed[0].ID)
+
+	_, err = deadLetters.Replay(ctx, domain.ReplayFilter{Status: domain.StatusAuthorized})
+	svcErr, ok := application.IsServiceError(err)
+	require.True(t, ok)
+	assert.Equal(t, application.ErrCodeInvalidInput, svcErr.Code)
+}
+
+func TestRetryWorker_ResumesLargestCapturesFirst(t *testing.T) {
+	ctx := context.Background()
+
+	testDB := testhelpers.SetupTestDatabase(t)
+	defer testDB.Cleanup(t)
+
+	paymentRepo := postgres.NewPaymentRepository(testDB.DB)
+	idempotencyRepo := postgres.NewIdempotencyRepository(testDB.DB)
+	operationRepo := postgres.NewOperationRepository(testDB.DB)
+	mockBank := mocks.NewMockBankClient(t)
+
+	authService := services.NewAuthorizeService(
+		paymentRepo,
+		idempotencyRepo,
+		postgres.NewMerchantSettingsRepository(testDB.DB),
+		mockBank,
+		testDB.DB,
+		services.AuthorizeLimits{},
+	)
+
+	// The smaller capture is older, so plain age would pick it first
+	stuck := map[int64]string{}
+	for _, amount := range []int64{100, 900} {
+		idempotencyKey := "idem-test-priority-" + uuid.New().String()
+		authCmd := testhelpers.DefaultAuthorizeCommand()
+		authCmd.Amount = amount
+
+		mockBank.EXPECT().Authorize(
+			mock.Anything,
+			mock.Anything,
+			idempotencyKey,
+		).Return(&bank.AuthorizationResponse{
+			Amount:          amount,
+			Currency:        authCmd.Currency,
+			Status:          "authorized",
+			AuthorizationID: "auth-" + idempotencyKey,
+			CreatedAt:       time.Now(),
+			ExpiresAt:       time.Now().Add(7 * 24 * time.Hour),
+		}, nil).Once()
+
+		payment, err := authService.Authorize(ctx, &authCmd, idempotencyKey)
+		require.NoError(t, err)
+		require.NoError(t, payment.MarkCapturing(payment.AmountCents))
+		require.NoError(t, paymentRepo.Update(ctx, nil, payment))
+		stuck[amount] = payment.ID
+	}
+	_, err := testDB.DB.Exec(ctx, "UPDATE idempotency_keys SET locked_at = $1", time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+
+	mockBank.EXPECT().GetCapture(mock.Anything, mock.Anything).Return(nil, errNotAtBank).Once()
+	mockBank.EXPECT().Capture(
+		mock.Anything,
+		mock.MatchedBy(func(req bank.CaptureRequest) bool { return req.Amount == 900 }),
+		mock.Anything,
+	).Return(&bank.CaptureResponse{
+		Amount:     900,
+		Currency:   "USD",
+		CaptureID:  "cap-priority",
+		Status:     "captured",
+		CapturedAt: time.Now(),
+	}, nil).Once()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		Level: slog.LevelError,
+	}))
+
+	sizes, err := worker.ParseRetryBatchSizes("capturing:1")
+	require.NoError(t, err)
+	worker := worker.NewRetryWorker(
+		paymentRepo,
+		idempotencyRepo,
+		operationRepo,
+		nil,
+		mockBank,
+		testDB.DB,
+		1*time.Minute,
+		10,
+		5,
+		10,
+		nil,
+		logger,
+	).WithBatchSizes(sizes)
+
+	require.NoError(t, worker.ProcessRetries(ctx))
+
+	large, err := paymentRepo.FindByID(ctx, stuck[900])
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCaptured, large.Status)
+
+	small, err := paymentRepo.FindByID(ctx, stuck[100])
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCapturing, small.Status)
+}
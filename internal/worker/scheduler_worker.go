@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+)
+
+// SchedulerWorker authorizes scheduled payments once their scheduled time has passed
+type SchedulerWorker struct {
+	heartbeat
+
+	scheduleService *services.ScheduleService
+	interval        time.Duration
+	batchSize       int
+	logger          *slog.Logger
+}
+
+func NewSchedulerWorker(
+	scheduleService *services.ScheduleService,
+	interval time.Duration,
+	batchSize int,
+	logger *slog.Logger,
+) *SchedulerWorker {
+	return &SchedulerWorker{
+		scheduleService: scheduleService,
+		interval:        interval,
+		batchSize:       batchSize,
+		logger:          logger,
+	}
+}
+
+func (w *SchedulerWorker) Start(ctx context.Context) {
+	w.logger.Info("scheduler worker started", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("scheduler worker stopping")
+			return
+		case <-ticker.C:
+			w.ProcessDue(ctx)
+			w.beat()
+		}
+	}
+}
+
+// ProcessDue runs one batch of due payments. Bank declines are recorded on the
+// payments themselves, so errors here are only logged.
+func (w *SchedulerWorker) ProcessDue(ctx context.Context) {
+	count, err := w.scheduleService.RunDue(ctx, w.batchSize)
+	if err != nil {
+		w.logger.Error("scheduled payments failed", "error", err)
+	}
+	if count > 0 {
+		w.logger.Info("processed scheduled payments", "count", count)
+	}
+}
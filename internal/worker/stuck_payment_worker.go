@@ -0,0 +1,129 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/alert"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/persistence/postgres"
+)
+
+// StuckPaymentWorker alerts about payments that stayed in a processing status longer
+// than the retry worker should need to finish them. Each time a payment gets stuck is
+// alerted once. With an expiry warning set, it also alerts once about each
+// authorization that is about to lapse uncaptured.
+type StuckPaymentWorker struct {
+	heartbeat
+
+	paymentRepo   *postgres.PaymentRepository
+	alerts        *alert.Notifier
+	stuckAfter    time.Duration
+	expiryWarning time.Duration
+	interval      time.Duration
+	batchSize     int
+	logger        *slog.Logger
+}
+
+func NewStuckPaymentWorker(
+	paymentRepo *postgres.PaymentRepository,
+	alerts *alert.Notifier,
+	stuckAfter time.Duration,
+	interval time.Duration,
+	batchSize int,
+	logger *slog.Logger,
+) *StuckPaymentWorker {
+	return &StuckPaymentWorker{
+		paymentRepo: paymentRepo,
+		alerts:      alerts,
+		stuckAfter:  stuckAfter,
+		interval:    interval,
+		batchSize:   batchSize,
+		logger:      logger,
+	}
+}
+
+// WithExpiryWarning alerts about AUTHORIZED payments whose authorization lapses within
+// d. Zero, the default, leaves them to the expiration worker.
+func (w *StuckPaymentWorker) WithExpiryWarning(d time.Duration) *StuckPaymentWorker {
+	w.expiryWarning = d
+	return w
+}
+
+func (w *StuckPaymentWorker) Start(ctx context.Context) {
+	w.logger.Info("stuck payment worker started", "interval", w.interval, "stuck_after", w.stuckAfter)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stuck payment worker stopping")
+			return
+		case <-ticker.C:
+			if err := w.AlertStuckPayments(ctx); err != nil {
+				w.logger.Error("stuck payment check failed", "error", err)
+			}
+			if err := w.AlertExpiringAuthorizations(ctx); err != nil {
+				w.logger.Error("expiring authorization check failed", "error", err)
+			}
+			w.beat()
+		}
+	}
+}
+
+func (w *StuckPaymentWorker) AlertStuckPayments(ctx context.Context) error {
+	stuck, err := w.paymentRepo.FindStuck(ctx, time.Now().Add(-w.stuckAfter), w.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range stuck {
+		w.alerts.Notify(ctx, alert.Alert{
+			Key:      fmt.Sprintf("stuck:%s:%s:%d", p.ID, p.Status, p.Since.Unix()),
+			Summary:  fmt.Sprintf("Payment %s stuck in %s for %s", p.ID, p.Status, time.Since(p.Since).Round(time.Minute)),
+			Severity: alert.SeverityWarning,
+			Details: map[string]any{
+				"payment_id":  p.ID,
+				"merchant_id": p.MerchantID,
+				"order_id":    p.OrderID,
+				"amount":      p.Amount.FormatAmount(),
+				"status":      p.Status,
+				"since":       p.Since.UTC().Format(time.RFC3339),
+			},
+		})
+	}
+	return nil
+}
+
+// AlertExpiringAuthorizations alerts about the authorizations that lapse within the
+// expiry warning without having been captured, soonest first
+func (w *StuckPaymentWorker) AlertExpiringAuthorizations(ctx context.Context) error {
+	if w.expiryWarning <= 0 {
+		return nil
+	}
+
+	now := time.Now()
+	expiring, err := w.paymentRepo.FindExpiringAcrossMerchants(ctx, now, now.Add(w.expiryWarning), w.batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, a := range expiring {
+		w.alerts.Notify(ctx, alert.Alert{
+			Key:      fmt.Sprintf("expiring:%s:%d", a.ID, a.ExpiresAt.Unix()),
+			Summary:  fmt.Sprintf("Payment %s authorized but not captured, lapses in %s", a.ID, a.ExpiresAt.Sub(now).Round(time.Minute)),
+			Severity: alert.SeverityWarning,
+			Details: map[string]any{
+				"payment_id":    a.ID,
+				"merchant_id":   a.MerchantID,
+				"order_id":      a.OrderID,
+				"amount":        a.Amount.FormatAmount(),
+				"authorized_at": a.AuthorizedAt.UTC().Format(time.RFC3339),
+				"expires_at":    a.ExpiresAt.UTC().Format(time.RFC3339),
+			},
+		})
+	}
+	return nil
+}
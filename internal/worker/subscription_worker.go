@@ -0,0 +1,62 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services"
+)
+
+// SubscriptionWorker charges subscriptions whose next charge is due
+type SubscriptionWorker struct {
+	heartbeat
+
+	subscriptionService *services.SubscriptionService
+	interval            time.Duration
+	batchSize           int
+	logger              *slog.Logger
+}
+
+func NewSubscriptionWorker(
+	subscriptionService *services.SubscriptionService,
+	interval time.Duration,
+	batchSize int,
+	logger *slog.Logger,
+) *SubscriptionWorker {
+	return &SubscriptionWorker{
+		subscriptionService: subscriptionService,
+		interval:            interval,
+		batchSize:           batchSize,
+		logger:              logger,
+	}
+}
+
+func (w *SubscriptionWorker) Start(ctx context.Context) {
+	w.logger.Info("subscription worker started", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("subscription worker stopping")
+			return
+		case <-ticker.C:
+			w.ProcessDue(ctx)
+			w.beat()
+		}
+	}
+}
+
+// ProcessDue runs one batch of due charges. A charge that could not be settled is
+// retried on the next run, so errors are only logged.
+func (w *SubscriptionWorker) ProcessDue(ctx context.Context) {
+	count, err := w.subscriptionService.ChargeDue(ctx, w.batchSize)
+	if err != nil {
+		w.logger.Error("subscription charges failed", "error", err)
+	}
+	if count > 0 {
+		w.logger.Info("settled subscription charges", "count", count)
+	}
+}
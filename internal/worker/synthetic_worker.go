@@ -0,0 +1,99 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/google/uuid"
+)
+
+// SyntheticWorker authorizes a test card with each acquirer and voids the hold again,
+// so an acquirer that stopped answering or declines a card it should accept shows in
+// the metrics before customers run into it. The calls go to the acquirers directly and
+// leave no payment behind.
+type SyntheticWorker struct {
+	heartbeat
+
+	acquirers map[string]bank.BankClient
+	card      bank.AuthorizationRequest
+	metrics   *metrics.SyntheticMetrics
+	interval  time.Duration
+	logger    *slog.Logger
+}
+
+func NewSyntheticWorker(
+	acquirers map[string]bank.BankClient,
+	card bank.AuthorizationRequest,
+	metrics *metrics.SyntheticMetrics,
+	interval time.Duration,
+	logger *slog.Logger,
+) *SyntheticWorker {
+	return &SyntheticWorker{
+		acquirers: acquirers,
+		card:      card,
+		metrics:   metrics,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+func (w *SyntheticWorker) Start(ctx context.Context) {
+	w.logger.Info("synthetic worker started", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("synthetic worker stopping")
+			return
+		case <-ticker.C:
+			w.RunTransactions(ctx)
+			w.beat()
+		}
+	}
+}
+
+// RunTransactions runs one authorize and void against each acquirer in turn
+func (w *SyntheticWorker) RunTransactions(ctx context.Context) {
+	names := make([]string, 0, len(w.acquirers))
+	for name := range w.acquirers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		w.runTransaction(ctx, name, w.acquirers[name])
+	}
+}
+
+func (w *SyntheticWorker) runTransaction(ctx context.Context, acquirer string, client bank.BankClient) {
+	idempotencyKey := "synthetic-" + uuid.NewString()
+
+	start := time.Now()
+	auth, err := client.Authorize(ctx, w.card, idempotencyKey+"-authorize")
+	w.metrics.Observe(acquirer, "authorize", err == nil, time.Since(start))
+	if err != nil {
+		w.logger.Warn("synthetic authorization failed", "acquirer", acquirer, "error", err)
+		return
+	}
+
+	start = time.Now()
+	_, err = client.Void(ctx, bank.VoidRequest{AuthorizationID: auth.AuthorizationID}, idempotencyKey+"-void")
+	w.metrics.Observe(acquirer, "void", err == nil, time.Since(start))
+	if err != nil {
+		// The hold on the test card lapses with the authorization
+		w.logger.Warn("synthetic void failed",
+			"acquirer", acquirer,
+			"authorization_id", auth.AuthorizationID,
+			"error", err,
+		)
+		return
+	}
+
+	w.metrics.Succeeded(acquirer, time.Now())
+}
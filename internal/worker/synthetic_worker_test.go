@@ -0,0 +1,48 @@
+package worker_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank/mocks"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/metrics"
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/worker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyntheticWorker_AuthorizesAndVoidsWithEachAcquirer(t *testing.T) {
+	ctx := context.Background()
+	card := bank.AuthorizationRequest{Amount: 100, CardNumber: "4111111111111111", Cvv: "123", ExpiryMonth: 12, ExpiryYear: 2030}
+
+	primary := mocks.NewMockBankClient(t)
+	primary.EXPECT().Authorize(mock.Anything, card, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "synthetic-")
+	})).Return(&bank.AuthorizationResponse{AuthorizationID: "auth-1"}, nil)
+	primary.EXPECT().Void(mock.Anything, bank.VoidRequest{AuthorizationID: "auth-1"}, mock.Anything).
+		Return(&bank.VoidResponse{AuthorizationID: "auth-1"}, nil)
+
+	canary := mocks.NewMockBankClient(t)
+	canary.EXPECT().Authorize(mock.Anything, card, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	m := metrics.NewSyntheticMetrics(metrics.DefaultBuckets)
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	w := worker.NewSyntheticWorker(map[string]bank.BankClient{"primary": primary, "canary": canary}, card, m, time.Minute, logger)
+
+	w.RunTransactions(ctx)
+
+	var out strings.Builder
+	_, err := m.WriteTo(&out)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `gateway_synthetic_steps_total{acquirer="primary",step="void",result="ok"} 1`)
+	assert.Contains(t, out.String(), `gateway_synthetic_steps_total{acquirer="canary",step="authorize",result="failed"} 1`)
+	assert.Contains(t, out.String(), `gateway_synthetic_last_success_timestamp_seconds{acquirer="primary"}`)
+	assert.NotContains(t, out.String(), `gateway_synthetic_last_success_timestamp_seconds{acquirer="canary"}`)
+}
@@ -0,0 +1,222 @@
+// Package banktest checks that a bank.BankClient behaves the way the gateway's services
+// rely on, so an adapter for a new acquirer can be validated before it is wired in. The
+// services replay a bank call under the same idempotency key after a crash or a
+// timeout, so a replay must return the original result rather than act twice; they
+// decide what to retry from the errors a client returns, so a decline must be a 4xx
+// *bank.BankError and a timeout must wrap context.DeadlineExceeded.
+//
+// An adapter's tests call Run with a client for its acquirer's sandbox, or for a fake
+// of its API, and the requests the sandbox approves and declines:
+//
+//	func TestConformance(t *testing.T) {
+//		banktest.Run(t, banktest.Config{
+//			NewClient: func(t *testing.T) bank.BankClient { return acme.NewClient(sandbox) },
+//			Approve:   bank.AuthorizationRequest{Amount: 5000, CardNumber: "4111111111111111", ...},
+//			Decline:   bank.AuthorizationRequest{Amount: 5000, CardNumber: "5555555555554444", ...},
+//		})
+//	}
+package banktest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/infrastructure/bank"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Config describes the client under test and how to provoke each outcome from its bank
+type Config struct {
+	// NewClient returns the client under test. It is called once per check.
+	NewClient func(t *testing.T) bank.BankClient
+	// Approve is an authorization the bank grants in full, and lets be captured, voided
+	// and refunded
+	Approve bank.AuthorizationRequest
+	// Decline is an authorization the bank declines, such as for insufficient funds
+	Decline bank.AuthorizationRequest
+	// Hang, if set, is an authorization the bank takes longer than Timeout to answer,
+	// to check that a client gives up when its context does. Without it only an
+	// expired deadline is checked.
+	Hang *bank.AuthorizationRequest
+	// Timeout is the deadline Hang is sent with; 100ms if zero
+	Timeout time.Duration
+}
+
+// Run runs every check against cfg's client, each as a subtest
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+	require.NotNil(t, cfg.NewClient, "banktest: Config.NewClient is required")
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 100 * time.Millisecond
+	}
+
+	t.Run("IdempotentReplays", func(t *testing.T) {
+		t.Run("Authorize", func(t *testing.T) { authorizeReplays(t, cfg) })
+		t.Run("Capture", func(t *testing.T) { captureReplays(t, cfg) })
+		t.Run("Void", func(t *testing.T) { voidReplays(t, cfg) })
+		t.Run("Refund", func(t *testing.T) { refundReplays(t, cfg) })
+	})
+	t.Run("ErrorMapping", func(t *testing.T) {
+		t.Run("Decline", func(t *testing.T) { declineIsBankError(t, cfg) })
+		t.Run("UnknownLookups", func(t *testing.T) { unknownLookupsAreNotFound(t, cfg) })
+	})
+	t.Run("Timeouts", func(t *testing.T) {
+		t.Run("ExpiredDeadline", func(t *testing.T) { expiredDeadline(t, cfg) })
+		t.Run("Hang", func(t *testing.T) { hang(t, cfg) })
+	})
+}
+
+func key(op string) string {
+	return "banktest-" + op + "-" + uuid.New().String()
+}
+
+func authorize(t *testing.T, client bank.BankClient, req bank.AuthorizationRequest) *bank.AuthorizationResponse {
+	auth, err := client.Authorize(context.Background(), req, key("auth"))
+	require.NoError(t, err, "authorize")
+	require.NotEmpty(t, auth.AuthorizationID, "authorize returned no authorization ID")
+	return auth
+}
+
+func authorizeReplays(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+	ctx := context.Background()
+	idempotencyKey := key("auth")
+
+	first, err := client.Authorize(ctx, cfg.Approve, idempotencyKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.AuthorizationID)
+
+	replay, err := client.Authorize(ctx, cfg.Approve, idempotencyKey)
+	require.NoError(t, err, "a replay of an authorization must succeed")
+	assert.Equal(t, first.AuthorizationID, replay.AuthorizationID, "a replay must return the original authorization")
+	assert.Equal(t, first.Amount, replay.Amount)
+
+	found, err := client.GetAuthorization(ctx, first.AuthorizationID)
+	require.NoError(t, err)
+	assert.Equal(t, first.AuthorizationID, found.AuthorizationID)
+}
+
+func captureReplays(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+	ctx := context.Background()
+	auth := authorize(t, client, cfg.Approve)
+	idempotencyKey := key("capture")
+	req := bank.CaptureRequest{Amount: cfg.Approve.Amount, AuthorizationID: auth.AuthorizationID}
+
+	first, err := client.Capture(ctx, req, idempotencyKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.CaptureID)
+
+	replay, err := client.Capture(ctx, req, idempotencyKey)
+	require.NoError(t, err, "a replay of a capture must not be refused as a second capture")
+	assert.Equal(t, first.CaptureID, replay.CaptureID, "a replay must return the original capture")
+
+	found, err := client.GetCapture(ctx, idempotencyKey)
+	require.NoError(t, err, "a capture must be found by its idempotency key")
+	assert.Equal(t, first.CaptureID, found.CaptureID)
+}
+
+func voidReplays(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+	ctx := context.Background()
+	auth := authorize(t, client, cfg.Approve)
+	idempotencyKey := key("void")
+	req := bank.VoidRequest{AuthorizationID: auth.AuthorizationID}
+
+	first, err := client.Void(ctx, req, idempotencyKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.VoidID)
+
+	replay, err := client.Void(ctx, req, idempotencyKey)
+	require.NoError(t, err, "a replay of a void must not be refused as a second void")
+	assert.Equal(t, first.VoidID, replay.VoidID, "a replay must return the original void")
+}
+
+func refundReplays(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+	ctx := context.Background()
+	auth := authorize(t, client, cfg.Approve)
+
+	capture, err := client.Capture(ctx, bank.CaptureRequest{Amount: cfg.Approve.Amount, AuthorizationID: auth.AuthorizationID}, key("capture"))
+	require.NoError(t, err)
+
+	idempotencyKey := key("refund")
+	req := bank.RefundRequest{Amount: cfg.Approve.Amount, CaptureID: capture.CaptureID}
+
+	first, err := client.Refund(ctx, req, idempotencyKey)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.RefundID)
+
+	replay, err := client.Refund(ctx, req, idempotencyKey)
+	require.NoError(t, err, "a replay of a refund must not be refused as a second refund")
+	assert.Equal(t, first.RefundID, replay.RefundID, "a replay must return the original refund")
+
+	found, err := client.GetRefund(ctx, idempotencyKey)
+	require.NoError(t, err, "a refund must be found by its idempotency key")
+	assert.Equal(t, first.RefundID, found.RefundID)
+}
+
+func declineIsBankError(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+
+	_, err := client.Authorize(context.Background(), cfg.Decline, key("auth"))
+	require.Error(t, err, "the decline request was approved")
+
+	bankErr, ok := bank.IsBankError(err)
+	require.True(t, ok, "a decline must be a *bank.BankError, got %T: %v", err, err)
+	assert.NotEmpty(t, bankErr.Code, "a decline must carry the bank's reason")
+	assert.GreaterOrEqual(t, bankErr.StatusCode, 400, "a decline must have a 4xx status")
+	assert.Less(t, bankErr.StatusCode, 500, "a decline must have a 4xx status")
+	assert.False(t, bankErr.IsRetryable(), "a decline must not be retried")
+}
+
+// unknownLookupsAreNotFound checks the 404s reconciliation and recovery take to mean the
+// bank never acted, rather than that the lookup failed
+func unknownLookupsAreNotFound(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+	ctx := context.Background()
+
+	assertNotFound := func(name string, err error) {
+		bankErr, ok := bank.IsBankError(err)
+		if assert.True(t, ok, "%s: an unknown lookup must be a *bank.BankError, got %T: %v", name, err, err) {
+			assert.Equal(t, http.StatusNotFound, bankErr.StatusCode, "%s: an unknown lookup must be a 404", name)
+		}
+	}
+
+	_, err := client.GetAuthorization(ctx, uuid.New().String())
+	assertNotFound("GetAuthorization", err)
+	_, err = client.GetCapture(ctx, key("capture"))
+	assertNotFound("GetCapture", err)
+	_, err = client.GetRefund(ctx, key("refund"))
+	assertNotFound("GetRefund", err)
+}
+
+func expiredDeadline(t *testing.T, cfg Config) {
+	client := cfg.NewClient(t)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := client.Authorize(ctx, cfg.Approve, key("auth"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "a timeout must wrap context.DeadlineExceeded, got %v", err)
+}
+
+func hang(t *testing.T, cfg Config) {
+	if cfg.Hang == nil {
+		t.Skip("banktest: Config.Hang is not set")
+	}
+	client := cfg.NewClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	started := time.Now()
+	_, err := client.Authorize(ctx, *cfg.Hang, key("auth"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "a timeout must wrap context.DeadlineExceeded, got %v", err)
+	assert.Less(t, time.Since(started), cfg.Timeout+time.Second, "the client must give up when its context does")
+}
@@ -0,0 +1,219 @@
+// Package client calls the payment gateway's REST API from Go services. Requests and
+// responses use the types generated from the API's OpenAPI spec. Writes carry an
+// idempotency key, generated when the caller passes none, and are retried under the
+// same key when the gateway fails with a 5xx or cannot be reached, so a retry never
+// charges twice.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/api"
+	"github.com/google/uuid"
+)
+
+type (
+	Payment                 = api.Payment
+	PaymentStatus           = api.PaymentStatus
+	Operation               = api.Operation
+	OperationReason         = api.OperationReason
+	AuthorizeRequest        = api.AuthorizeRequest
+	CreateCaptureRequest    = api.CreateCaptureRequest
+	CreateVoidRequest       = api.CreateVoidRequest
+	CreateRefundRequest     = api.CreateRefundRequest
+	ErrorCode               = api.ErrorResponseErrorCode
+	BatchGetPaymentsRequest = api.BatchGetPaymentsRequest
+	CustomerPaymentSummary  = api.CustomerPaymentSummary
+	Receipt                 = api.Receipt
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBaseDelay   = 200 * time.Millisecond
+	defaultTimeout     = 30 * time.Second
+)
+
+// Config configures a Client. Zero values take the defaults noted on each field.
+type Config struct {
+	// BaseURL is the gateway's address, e.g. https://payments.internal
+	BaseURL string
+	// APIKey is sent as X-API-Key. It decides the merchant every request acts for.
+	APIKey string
+	// HTTPClient sends the requests; a client with a 30s timeout by default
+	HTTPClient *http.Client
+	// MaxAttempts is how many times a request is sent before giving up; 3 by default,
+	// and 1 turns retries off
+	MaxAttempts int
+	// RetryBaseDelay is the wait before the first retry, doubled for each one after;
+	// 200ms by default
+	RetryBaseDelay time.Duration
+}
+
+type Client struct {
+	baseURL     string
+	apiKey      string
+	httpClient  *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+}
+
+func New(cfg Config) *Client {
+	c := &Client{
+		baseURL:     strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:      cfg.APIKey,
+		httpClient:  cfg.HTTPClient,
+		maxAttempts: cfg.MaxAttempts,
+		baseDelay:   cfg.RetryBaseDelay,
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	if c.maxAttempts <= 0 {
+		c.maxAttempts = defaultMaxAttempts
+	}
+	if c.baseDelay <= 0 {
+		c.baseDelay = defaultBaseDelay
+	}
+	return c
+}
+
+// Error is a request the gateway answered with an error
+type Error struct {
+	StatusCode int
+	Code       ErrorCode
+	Message    string
+	// PaymentID is the existing payment the error refers to, such as the original
+	// payment of a DUPLICATE_PAYMENT; uuid.Nil for most errors
+	PaymentID uuid.UUID
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("gateway error %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound reports whether err is the gateway answering that what was asked for does
+// not exist
+func IsNotFound(err error) bool {
+	var gatewayErr *Error
+	return errors.As(err, &gatewayErr) && gatewayErr.StatusCode == http.StatusNotFound
+}
+
+// retryable reports whether sending the request again may succeed. The gateway answers
+// 409 REQUEST_PROCESSING while an earlier attempt with the same key is still running.
+func (e *Error) retryable() bool {
+	return e.StatusCode >= 500 || e.StatusCode == http.StatusRequestTimeout || e.Code == api.REQUESTPROCESSING
+}
+
+// envelope is the body of every successful response
+type envelope[T any] struct {
+	Success bool `json:"success"`
+	Data    T    `json:"data"`
+}
+
+// send sends a request and decodes the data of its response into a T, retrying
+// failures worth retrying. Every attempt carries the same idempotency key.
+func send[T any](ctx context.Context, c *Client, method, path string, body any, idempotencyKey string) (*T, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.backoff(attempt - 1)):
+			}
+		}
+
+		resp, retry, err := sendOnce[T](ctx, c, method, path, payload, idempotencyKey)
+		if err == nil {
+			return resp, nil
+		}
+		if !retry || ctx.Err() != nil {
+			return nil, err
+		}
+		lastErr = err
+	}
+	return nil, fmt.Errorf("giving up after %d attempts: %w", c.maxAttempts, lastErr)
+}
+
+// sendOnce sends a request once. A failure is worth retrying when the gateway could not
+// be reached or answered that it may succeed later.
+func sendOnce[T any](ctx context.Context, c *Client, method, path string, payload []byte, idempotencyKey string) (*T, bool, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, false, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		gatewayErr := &Error{StatusCode: resp.StatusCode, Message: http.StatusText(resp.StatusCode)}
+		var errResp api.ErrorResponse
+		if json.Unmarshal(respBody, &errResp) == nil && errResp.Error.Code != "" {
+			gatewayErr.Code = errResp.Error.Code
+			gatewayErr.Message = errResp.Error.Message
+			gatewayErr.PaymentID = errResp.Error.PaymentId
+		}
+		return nil, gatewayErr.retryable(), gatewayErr
+	}
+
+	var result envelope[T]
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, false, fmt.Errorf("decode response: %w", err)
+	}
+	return &result.Data, false, nil
+}
+
+// backoff doubles the base delay for each retry and adds up to a quarter of it again,
+// so clients that failed together do not retry together
+func (c *Client) backoff(retry int) time.Duration {
+	delay := c.baseDelay << retry
+	return delay + time.Duration(rand.Int63n(int64(delay)/4+1)) //nolint:gosec // not cryptographic
+}
+
+// keyOrNew returns key, or a new random key when the caller did not pass one
+func keyOrNew(key string) string {
+	if key != "" {
+		return key
+	}
+	return uuid.NewString()
+}
@@ -0,0 +1,194 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/pkg/client"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder answers each request with the next of its responses and keeps what it got
+type recorder struct {
+	mu        sync.Mutex
+	responses []response
+	requests  []*http.Request
+	bodies    []string
+}
+
+type response struct {
+	status int
+	body   string
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, string(body))
+	next := r.responses[0]
+	if len(r.responses) > 1 {
+		r.responses = r.responses[1:]
+	}
+	r.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(next.status)
+	io.WriteString(w, next.body) //nolint:errcheck // test server
+}
+
+func newClient(t *testing.T, responses ...response) (*client.Client, *recorder) {
+	t.Helper()
+	rec := &recorder{responses: responses}
+	server := httptest.NewServer(rec)
+	t.Cleanup(server.Close)
+
+	return client.New(client.Config{
+		BaseURL:        server.URL + "/",
+		APIKey:         "sk_test",
+		RetryBaseDelay: time.Millisecond,
+	}), rec
+}
+
+const paymentJSON = `{"success": true, "data": {"id": "550e8400-e29b-41d4-a716-446655440000", "order_id": "order-1", "status": "AUTHORIZED", "amount_cents": 5000}}`
+
+func TestClient_AuthorizeSendsKeyAndDecodesPayment(t *testing.T) {
+	c, rec := newClient(t, response{http.StatusCreated, paymentJSON})
+
+	payment, err := c.Authorize(context.Background(), client.AuthorizeRequest{OrderId: "order-1", Amount: 5000}, "key-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "order-1", payment.OrderId)
+	assert.Equal(t, client.PaymentStatus("AUTHORIZED"), payment.Status)
+
+	require.Len(t, rec.requests, 1)
+	req := rec.requests[0]
+	assert.Equal(t, "/authorize", req.URL.Path)
+	assert.Equal(t, "key-1", req.Header.Get("Idempotency-Key"))
+	assert.Equal(t, "sk_test", req.Header.Get("X-API-Key"))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+	var sent map[string]any
+	require.NoError(t, json.Unmarshal([]byte(rec.bodies[0]), &sent))
+	assert.Equal(t, "order-1", sent["order_id"])
+}
+
+func TestClient_RetriesServerErrorsUnderTheSameKey(t *testing.T) {
+	c, rec := newClient(t,
+		response{http.StatusBadGateway, `{"success": false, "error": {"code": "INTERNAL_ERROR", "message": "bank unavailable"}}`},
+		response{http.StatusConflict, `{"success": false, "error": {"code": "REQUEST_PROCESSING", "message": "still processing"}}`},
+		response{http.StatusCreated, `{"success": true, "data": {"type": "CAPTURE", "status": "SUCCEEDED"}}`},
+	)
+
+	op, err := c.Capture(context.Background(), uuid.New(), client.CreateCaptureRequest{Amount: 100}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCEEDED", string(op.Status))
+	require.Len(t, rec.requests, 3)
+	key := rec.requests[0].Header.Get("Idempotency-Key")
+	assert.NotEmpty(t, key, "a key is generated when none is given")
+	for _, req := range rec.requests {
+		assert.Equal(t, key, req.Header.Get("Idempotency-Key"))
+	}
+}
+
+func TestClient_DoesNotRetryClientErrors(t *testing.T) {
+	c, rec := newClient(t, response{http.StatusConflict, `{"success": false, "error": {"code": "INVALID_TRANSITION", "message": "payment is VOIDED"}}`})
+
+	_, err := c.Void(context.Background(), uuid.New(), client.CreateVoidRequest{}, "key-1")
+
+	var gatewayErr *client.Error
+	require.ErrorAs(t, err, &gatewayErr)
+	assert.Equal(t, http.StatusConflict, gatewayErr.StatusCode)
+	assert.Equal(t, client.ErrorCode("INVALID_TRANSITION"), gatewayErr.Code)
+	assert.Equal(t, "payment is VOIDED", gatewayErr.Message)
+	assert.Len(t, rec.requests, 1)
+}
+
+func TestClient_DuplicateNamesTheOriginalPayment(t *testing.T) {
+	c, _ := newClient(t, response{http.StatusConflict, `{"success": false, "error": {"code": "DUPLICATE_PAYMENT", "message": "already paid", "payment_id": "550e8400-e29b-41d4-a716-446655440000"}}`})
+
+	_, err := c.Authorize(context.Background(), client.AuthorizeRequest{OrderId: "order-1"}, "key-2")
+
+	var gatewayErr *client.Error
+	require.ErrorAs(t, err, &gatewayErr)
+	assert.Equal(t, client.ErrorCode("DUPLICATE_PAYMENT"), gatewayErr.Code)
+	assert.Equal(t, uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"), gatewayErr.PaymentID)
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	c, rec := newClient(t, response{http.StatusServiceUnavailable, `upstream down`})
+
+	_, err := c.GetPayment(context.Background(), uuid.New())
+
+	var gatewayErr *client.Error
+	require.ErrorAs(t, err, &gatewayErr)
+	assert.Equal(t, http.StatusServiceUnavailable, gatewayErr.StatusCode)
+	assert.Equal(t, "Service Unavailable", gatewayErr.Message, "bodies that are not JSON errors keep the status text")
+	assert.Len(t, rec.requests, 3)
+}
+
+func TestClient_StopsRetryingWhenContextIsDone(t *testing.T) {
+	rec := &recorder{responses: []response{{http.StatusInternalServerError, `{}`}}}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+	c := client.New(client.Config{BaseURL: server.URL, MaxAttempts: 5, RetryBaseDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := c.GetPaymentByOrder(ctx, "order-1")
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Len(t, rec.requests, 1)
+}
+
+func TestClient_Queries(t *testing.T) {
+	c, rec := newClient(t,
+		response{http.StatusOK, `{"success": true, "data": [{"id": "550e8400-e29b-41d4-a716-446655440000", "status": "CAPTURED"}]}`},
+		response{http.StatusOK, `{"success": true, "data": []}`},
+		response{http.StatusNotFound, `{"success": false, "error": {"code": "PAYMENT_NOT_FOUND", "message": "payment not found"}}`},
+		response{http.StatusOK, `{"success": true, "data": {"customer_id": "cust 1", "payment_count": 2, "status_counts": [{"status": "CAPTURED", "count": 2}], "totals": [{"currency": "USD", "authorized_cents": 10000, "captured_cents": 10000, "refunded_cents": 0}]}}`},
+		response{http.StatusOK, `{"success": true, "data": {"payment_id": "550e8400-e29b-41d4-a716-446655440000", "card_brand": "visa", "card_last4": "1111", "net_amount_cents": 7500, "entries": []}}`},
+	)
+	ctx := context.Background()
+	id := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	payments, err := c.GetPayments(ctx, []uuid.UUID{id})
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+	assert.Equal(t, id, payments[0].Id)
+	assert.Equal(t, "/payments/batch-get", rec.requests[0].URL.Path)
+	assert.JSONEq(t, `{"ids": ["550e8400-e29b-41d4-a716-446655440000"]}`, rec.bodies[0])
+
+	payments, err = c.GetPaymentsByCustomer(ctx, "cust 1", 5, 10)
+	require.NoError(t, err)
+	assert.Empty(t, payments)
+	assert.Equal(t, "/payments/customer/cust%201", rec.requests[1].URL.EscapedPath())
+	assert.Equal(t, "limit=5&offset=10", rec.requests[1].URL.RawQuery)
+
+	_, err = c.GetPaymentByIdempotencyKey(ctx, "key-1")
+	assert.True(t, client.IsNotFound(err))
+	assert.Empty(t, rec.requests[2].Header.Get("Idempotency-Key"), "reads carry no key")
+
+	summary, err := c.GetCustomerPaymentSummary(ctx, "cust 1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.PaymentCount)
+	assert.Equal(t, int64(10000), summary.Totals[0].CapturedCents)
+	assert.Equal(t, "/customers/cust%201/payment-summary", rec.requests[3].URL.EscapedPath())
+
+	receipt, err := c.GetReceipt(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, "1111", receipt.CardLast4)
+	assert.Equal(t, int64(7500), receipt.NetAmountCents)
+	assert.Equal(t, "/payments/receipts/550e8400-e29b-41d4-a716-446655440000", rec.requests[4].URL.Path)
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// Authorize reserves the amount on the customer's card and returns the AUTHORIZED
+// payment; a decline is returned as an *Error. Pass the same idempotencyKey when
+// retrying after a crash so the card is only charged once. An empty key gets a new one,
+// shared by this call's own retries.
+func (c *Client) Authorize(ctx context.Context, req AuthorizeRequest, idempotencyKey string) (*Payment, error) {
+	return send[Payment](ctx, c, http.MethodPost, "/authorize", req, keyOrNew(idempotencyKey))
+}
+
+// Capture charges all of an authorized payment, or req.Amount of it, and returns the
+// capture operation
+func (c *Client) Capture(ctx context.Context, paymentID uuid.UUID, req CreateCaptureRequest, idempotencyKey string) (*Operation, error) {
+	path := fmt.Sprintf("/payments/%s/captures", paymentID)
+	return send[Operation](ctx, c, http.MethodPost, path, req, keyOrNew(idempotencyKey))
+}
+
+// Void releases an authorized payment and returns the void operation
+func (c *Client) Void(ctx context.Context, paymentID uuid.UUID, req CreateVoidRequest, idempotencyKey string) (*Operation, error) {
+	path := fmt.Sprintf("/payments/%s/voids", paymentID)
+	return send[Operation](ctx, c, http.MethodPost, path, req, keyOrNew(idempotencyKey))
+}
+
+// Refund returns all of a captured payment, or req.Amount of it, and returns the
+// refund operation
+func (c *Client) Refund(ctx context.Context, paymentID uuid.UUID, req CreateRefundRequest, idempotencyKey string) (*Operation, error) {
+	path := fmt.Sprintf("/payments/%s/refunds", paymentID)
+	return send[Operation](ctx, c, http.MethodPost, path, req, keyOrNew(idempotencyKey))
+}
+
+func (c *Client) GetPayment(ctx context.Context, paymentID uuid.UUID) (*Payment, error) {
+	return send[Payment](ctx, c, http.MethodGet, "/payments/"+paymentID.String(), nil, "")
+}
+
+func (c *Client) GetPaymentByOrder(ctx context.Context, orderID string) (*Payment, error) {
+	return send[Payment](ctx, c, http.MethodGet, "/payments/order/"+url.PathEscape(orderID), nil, "")
+}
+
+// GetPaymentByIdempotencyKey returns the payment an Authorize with idempotencyKey
+// created, such as after the call timed out without an answer
+func (c *Client) GetPaymentByIdempotencyKey(ctx context.Context, idempotencyKey string) (*Payment, error) {
+	return send[Payment](ctx, c, http.MethodGet, "/payments/by-idempotency-key/"+url.PathEscape(idempotencyKey), nil, "")
+}
+
+// GetPaymentsByCustomer returns a page of the customer's payments, newest first
+func (c *Client) GetPaymentsByCustomer(ctx context.Context, customerID string, limit, offset int) ([]Payment, error) {
+	path := fmt.Sprintf("/payments/customer/%s?limit=%d&offset=%d", url.PathEscape(customerID), limit, offset)
+	payments, err := send[[]Payment](ctx, c, http.MethodGet, path, nil, "")
+	if err != nil {
+		return nil, err
+	}
+	return *payments, nil
+}
+
+// GetCustomerPaymentSummary returns the customer's payment counts per status and
+// lifetime totals per currency
+func (c *Client) GetCustomerPaymentSummary(ctx context.Context, customerID string) (*CustomerPaymentSummary, error) {
+	path := fmt.Sprintf("/customers/%s/payment-summary", url.PathEscape(customerID))
+	return send[CustomerPaymentSummary](ctx, c, http.MethodGet, path, nil, "")
+}
+
+// GetReceipt returns the receipt of an authorized payment, for an order confirmation
+func (c *Client) GetReceipt(ctx context.Context, paymentID uuid.UUID) (*Receipt, error) {
+	return send[Receipt](ctx, c, http.MethodGet, "/payments/receipts/"+paymentID.String(), nil, "")
+}
+
+// GetPayments returns up to 100 payments by ID in one request, newest first. IDs with
+// no payment are left out.
+func (c *Client) GetPayments(ctx context.Context, paymentIDs []uuid.UUID) ([]Payment, error) {
+	payments, err := send[[]Payment](ctx, c, http.MethodPost, "/payments/batch-get", BatchGetPaymentsRequest{Ids: paymentIDs}, "")
+	if err != nil {
+		return nil, err
+	}
+	return *payments, nil
+}
+
+// GetOperation returns a capture, void, refund or reauthorization
+func (c *Client) GetOperation(ctx context.Context, operationID uuid.UUID) (*Operation, error) {
+	return send[Operation](ctx, c, http.MethodGet, "/operations/"+operationID.String(), nil, "")
+}
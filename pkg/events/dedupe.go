@@ -0,0 +1,101 @@
+// Package events helps services that consume the gateway's payment events act on each
+// event once. The gateway delivers events at least once: an event whose delivery fails,
+// or whose acknowledgement is lost, is delivered again under the same ID, which the
+// notification webhook sends in the Idempotency-Key header. A Deduper records the IDs a
+// consumer has processed in the consumer's own Postgres database, in the transaction
+// that applies the event, so an event's effects and its record commit together or not
+// at all.
+package events
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Schema creates the table a Deduper records processed events in. Run it with the
+// consumer's own migrations; it is safe to run again.
+const Schema = `
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer     TEXT NOT NULL,
+    event_id     TEXT NOT NULL,
+    processed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (consumer, event_id)
+);
+CREATE INDEX IF NOT EXISTS idx_processed_events_processed_at ON processed_events (processed_at);
+`
+
+// ErrMissingEventID is returned for an event without an ID, which cannot be deduplicated
+var ErrMissingEventID = errors.New("event has no ID")
+
+// Deduper runs each event's handler at most once per consumer. Consumers sharing a
+// database keep separate records under their own names, so each still sees every event.
+type Deduper struct {
+	db       *sql.DB
+	consumer string
+}
+
+// NewDeduper records the events consumer processes in db, which must hold the table
+// created by Schema
+func NewDeduper(db *sql.DB, consumer string) *Deduper {
+	return &Deduper{db: db, consumer: consumer}
+}
+
+// Process runs handle for the event unless it was processed before, and reports whether
+// it ran. handle gets the transaction the event is recorded in and must make its
+// changes through it: if handle fails, or the commit does, neither the changes nor the
+// record are kept and the event can be processed again when it is redelivered. A
+// duplicate delivered while the first is being processed waits for it to finish, then
+// is skipped if it committed.
+func (d *Deduper) Process(ctx context.Context, eventID string, handle func(ctx context.Context, tx *sql.Tx) error) (bool, error) {
+	if eventID == "" {
+		return false, ErrMissingEventID
+	}
+
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // a no-op after commit
+
+	result, err := tx.ExecContext(ctx,
+		`INSERT INTO processed_events (consumer, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+		d.consumer, eventID,
+	)
+	if err != nil {
+		return false, fmt.Errorf("record event %s: %w", eventID, err)
+	}
+	recorded, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("record event %s: %w", eventID, err)
+	}
+	if recorded == 0 {
+		return false, nil
+	}
+
+	if err := handle(ctx, tx); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("commit event %s: %w", eventID, err)
+	}
+	return true, nil
+}
+
+// Forget deletes the records of events processed before cutoff and returns how many it
+// deleted. Pick a cutoff well beyond the longest the gateway may go on redelivering an
+// event, such as while its delivery is paused, since an event forgotten and then
+// redelivered is processed again.
+func (d *Deduper) Forget(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := d.db.ExecContext(ctx,
+		`DELETE FROM processed_events WHERE consumer = $1 AND processed_at < $2`,
+		d.consumer, cutoff,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("forget processed events: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,93 @@
+package events_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DanielPopoola/ficmart-payment-gateway/internal/application/services/testhelpers"
+	"github.com/DanielPopoola/ficmart-payment-gateway/pkg/events"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConsumerDB(t *testing.T) *sql.DB {
+	t.Helper()
+	testDB := testhelpers.SetupTestDatabase(t)
+	t.Cleanup(func() { testDB.Cleanup(t) })
+
+	db := stdlib.OpenDBFromPool(testDB.DB.Pool)
+	t.Cleanup(func() { db.Close() })
+
+	_, err := db.Exec(events.Schema)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE refunds_seen (payment_id TEXT PRIMARY KEY)`)
+	require.NoError(t, err)
+	return db
+}
+
+func TestDeduper_ProcessesEachEventOnce(t *testing.T) {
+	ctx := context.Background()
+	db := setupConsumerDB(t)
+	deduper := events.NewDeduper(db, "ledger")
+
+	record := func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO refunds_seen (payment_id) VALUES ('pay-1')`)
+		return err
+	}
+
+	ran, err := deduper.Process(ctx, "evt-1", record)
+	require.NoError(t, err)
+	assert.True(t, ran)
+
+	ran, err = deduper.Process(ctx, "evt-1", record)
+	require.NoError(t, err)
+	assert.False(t, ran, "a redelivered event must not be handled again")
+
+	// Another consumer in the same database still gets the event
+	ran, err = events.NewDeduper(db, "emails").Process(ctx, "evt-1", func(context.Context, *sql.Tx) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func TestDeduper_FailedHandlerLeavesEventUnprocessed(t *testing.T) {
+	ctx := context.Background()
+	db := setupConsumerDB(t)
+	deduper := events.NewDeduper(db, "ledger")
+
+	downstream := errors.New("downstream unavailable")
+	_, err := deduper.Process(ctx, "evt-1", func(ctx context.Context, tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO refunds_seen (payment_id) VALUES ('pay-1')`); err != nil {
+			return err
+		}
+		return downstream
+	})
+	require.ErrorIs(t, err, downstream)
+
+	var seen int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM refunds_seen`).Scan(&seen))
+	assert.Equal(t, 0, seen, "the handler's changes roll back with the record")
+
+	ran, err := deduper.Process(ctx, "evt-1", func(context.Context, *sql.Tx) error { return nil })
+	require.NoError(t, err)
+	assert.True(t, ran)
+}
+
+func TestDeduper_Forget(t *testing.T) {
+	ctx := context.Background()
+	db := setupConsumerDB(t)
+	deduper := events.NewDeduper(db, "ledger")
+
+	_, err := deduper.Process(ctx, "evt-1", func(context.Context, *sql.Tx) error { return nil })
+	require.NoError(t, err)
+
+	forgotten, err := deduper.Forget(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), forgotten)
+
+	_, err = deduper.Process(ctx, "", func(context.Context, *sql.Tx) error { return nil })
+	assert.ErrorIs(t, err, events.ErrMissingEventID)
+}